@@ -0,0 +1,87 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package capture writes the GTP messages to the pcapng files, and reads the
+// GTP messages from the pcapng and pcap files.
+//
+// Writer writes the messages with the Ethernet, IP and UDP headers synthesized
+// from the addresses, so that the files can be opened with the tools such as
+// Wireshark. It provides the interceptors for gtpv2.Conn and gtpv1.UPlaneConn
+// to capture the messages they send and receive.
+//
+//	w, err := capture.NewWriter(f)
+//	if err != nil {
+//		// ...
+//	}
+//	conn := gtpv2.NewConn(laddr, gtpv2.IFTypeS11MMEGTPC, 0, w.ConnOptions()...)
+//
+// Reader reads the UDP packets from the files and parses the payloads with
+// gtp.Parse, which is useful to assert the messages in the recorded traces.
+package capture
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/wmnsk/go-gtp"
+)
+
+// Error definitions.
+var (
+	ErrInvalidFormat   = errors.New("invalid capture file format")
+	ErrUnsupportedLink = errors.New("unsupported link type")
+)
+
+// The link types supported by Reader. Writer always uses LinkTypeEthernet.
+const (
+	LinkTypeNull     uint16 = 0
+	LinkTypeEthernet uint16 = 1
+	LinkTypeRaw      uint16 = 101
+	LinkTypeLinuxSLL uint16 = 113
+)
+
+// Direction is the direction of the packet seen from the node that captured it.
+type Direction uint8
+
+// Direction definitions. Unknown is used when the capture file does not have
+// the information.
+const (
+	Unknown Direction = iota
+	Inbound
+	Outbound
+)
+
+// String returns the name of Direction.
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a GTP message read from the capture file.
+type Record struct {
+	// Time is the time the packet is captured.
+	Time time.Time
+
+	// Src and Dst are the source and destination addresses of the packet.
+	Src, Dst *net.UDPAddr
+
+	// Direction is the direction of the packet, available only in pcapng.
+	Direction Direction
+
+	// Message is the message parsed from Payload. It is nil if Payload cannot
+	// be parsed as a GTP message.
+	Message gtp.Message
+
+	// Payload is the UDP payload of the packet.
+	Payload []byte
+
+	ifaceID int
+}
@@ -0,0 +1,227 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package capture_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp"
+	"github.com/wmnsk/go-gtp/capture"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// record is the comparable summary of capture.Record.
+type record struct {
+	Time      time.Time
+	Src, Dst  string
+	Direction capture.Direction
+	MsgType   string
+	Payload   []byte
+	Err       bool
+}
+
+func summarize(rec *capture.Record, err error) record {
+	r := record{
+		Time:      rec.Time,
+		Src:       rec.Src.String(),
+		Dst:       rec.Dst.String(),
+		Direction: rec.Direction,
+		Payload:   rec.Payload,
+		Err:       err != nil,
+	}
+	if rec.Message != nil {
+		r.MsgType = rec.Message.MessageTypeName()
+	}
+	return r
+}
+
+func mustMarshal(t *testing.T, msg gtp.Message) []byte {
+	t.Helper()
+
+	b, err := gtp.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestWriterReader(t *testing.T) {
+	ts := time.Unix(1700000000, 123456789)
+	v4a := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2123}
+	v4b := &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2123}
+	v6a := &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 2152}
+	v6b := &net.UDPAddr{IP: net.ParseIP("2001:db8::2"), Port: 2152}
+
+	echo := mustMarshal(t, v2msg.NewEchoRequest(1, v2ie.NewRecovery(1)))
+	tpdu := mustMarshal(t, v1msg.NewTPDU(0x11111111, []byte{0xde, 0xad, 0xbe, 0xef}))
+	broken := []byte{0x48, 0x20, 0x00}
+
+	var buf bytes.Buffer
+	w, err := capture.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []struct {
+		src, dst net.Addr
+		dir      capture.Direction
+		payload  []byte
+	}{
+		{v4a, v4b, capture.Outbound, echo},
+		{v6a, v6b, capture.Inbound, tpdu},
+		{v4b, v4a, capture.Unknown, broken},
+	} {
+		if err := w.WritePacket(ts, p.src, p.dst, p.dir, p.payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := capture.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []record
+	for rec, err := range r.All() {
+		if rec == nil {
+			t.Fatal(err)
+		}
+		got = append(got, summarize(rec, err))
+	}
+
+	want := []record{
+		{ts, v4a.String(), v4b.String(), capture.Outbound, "Echo Request", echo, false},
+		{ts, v6a.String(), v6b.String(), capture.Inbound, "T-PDU", tpdu, false},
+		{ts, v4b.String(), v4a.String(), capture.Unknown, "", broken, true},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestReaderPcap(t *testing.T) {
+	echo := mustMarshal(t, v2msg.NewEchoResponse(1, v2ie.NewRecovery(1)))
+
+	// pcap in big endian with microsecond timestamps and LINKTYPE_RAW.
+	var buf bytes.Buffer
+	be := binary.BigEndian
+	hdr := make([]byte, 24)
+	be.PutUint32(hdr[0:4], 0xa1b2c3d4)
+	be.PutUint16(hdr[4:6], 2)
+	be.PutUint16(hdr[6:8], 4)
+	be.PutUint32(hdr[16:20], 65535)
+	be.PutUint32(hdr[20:24], uint32(capture.LinkTypeRaw))
+	buf.Write(hdr)
+
+	pkt := make([]byte, 28+len(echo))
+	pkt[0] = 0x45
+	be.PutUint16(pkt[2:4], uint16(len(pkt)))
+	pkt[8], pkt[9] = 64, 17
+	copy(pkt[12:16], []byte{10, 0, 0, 1})
+	copy(pkt[16:20], []byte{10, 0, 0, 2})
+	be.PutUint16(pkt[20:22], 2123)
+	be.PutUint16(pkt[22:24], 2123)
+	be.PutUint16(pkt[24:26], uint16(8+len(echo)))
+	copy(pkt[28:], echo)
+
+	rec := make([]byte, 16)
+	be.PutUint32(rec[0:4], 1700000000)
+	be.PutUint32(rec[4:8], 500000)
+	be.PutUint32(rec[8:12], uint32(len(pkt)))
+	be.PutUint32(rec[12:16], uint32(len(pkt)))
+	buf.Write(rec)
+	buf.Write(pkt)
+
+	r, err := capture.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := record{
+		time.Unix(1700000000, 500000000), "10.0.0.1:2123", "10.0.0.2:2123",
+		capture.Unknown, "Echo Response", echo, false,
+	}
+	if diff := cmp.Diff(summarize(got, nil), want); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReaderInvalidFormat(t *testing.T) {
+	if _, err := capture.NewReader(bytes.NewReader(make([]byte, 32))); !errors.Is(err, capture.ErrInvalidFormat) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWriterConnOptions(t *testing.T) {
+	cliAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 15}, Port: 2123}
+	srvAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 16}, Port: 2123}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvConn := gtpv2.NewConn(srvAddr, gtpv2.IFTypeS11S4SGWGTPC, 0)
+	if err := srvConn.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.Serve(ctx); err != nil {
+			t.Errorf("error on serving: %v", err)
+		}
+	}()
+
+	var buf bytes.Buffer
+	w, err := capture.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cliConn, err := gtpv2.Dial(ctx, cliAddr, srvAddr, gtpv2.IFTypeS11MMEGTPC, 0, w.ConnOptions()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cliConn.Close()
+	srvConn.Close()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := capture.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []record
+	for rec, err := range r.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := summarize(rec, err)
+		s.Time, s.Payload = time.Time{}, nil
+		got = append(got, s)
+	}
+
+	want := []record{
+		{Src: cliAddr.String(), Dst: srvAddr.String(), Direction: capture.Outbound, MsgType: "Echo Request"},
+		{Src: srvAddr.String(), Dst: cliAddr.String(), Direction: capture.Inbound, MsgType: "Echo Response"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package capture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+	etherTypeVLAN = 0x8100
+	etherTypeQinQ = 0x88a8
+
+	protoUDP = 17
+
+	ethernetLen = 14
+	ipv4Len     = 20
+	ipv6Len     = 40
+	udpLen      = 8
+
+	maxPayloadLen = 0xffff - ipv6Len - udpLen
+)
+
+// udpAddrOf returns addr as *net.UDPAddr, or the unspecified one if it is not
+// a UDP address.
+func udpAddrOf(addr net.Addr) *net.UDPAddr {
+	if a, ok := addr.(*net.UDPAddr); ok && a != nil {
+		return a
+	}
+	if addr != nil {
+		if a, err := net.ResolveUDPAddr("udp", addr.String()); err == nil {
+			return a
+		}
+	}
+	return &net.UDPAddr{IP: net.IPv4zero}
+}
+
+// encapsulate returns the Ethernet frame that contains the UDP packet from src
+// to dst with payload. The frame is over IPv6 if either of the addresses is IPv6.
+func encapsulate(src, dst *net.UDPAddr, payload []byte) ([]byte, error) {
+	if len(payload) > maxPayloadLen {
+		return nil, fmt.Errorf("payload too long: %d", len(payload))
+	}
+
+	src4, dst4 := src.IP.To4(), dst.IP.To4()
+	v4 := src4 != nil && dst4 != nil
+
+	ipLen := ipv6Len
+	if v4 {
+		ipLen = ipv4Len
+	}
+	b := make([]byte, ethernetLen+ipLen+udpLen+len(payload))
+
+	// the MAC addresses are left zero, as they are unknown.
+	if v4 {
+		binary.BigEndian.PutUint16(b[12:14], etherTypeIPv4)
+	} else {
+		binary.BigEndian.PutUint16(b[12:14], etherTypeIPv6)
+	}
+
+	ip := b[ethernetLen:]
+	udp := ip[ipLen:]
+	binary.BigEndian.PutUint16(udp[0:2], uint16(src.Port))
+	binary.BigEndian.PutUint16(udp[2:4], uint16(dst.Port))
+	binary.BigEndian.PutUint16(udp[4:6], uint16(udpLen+len(payload)))
+	copy(udp[udpLen:], payload)
+
+	if v4 {
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:4], uint16(ipv4Len+udpLen+len(payload)))
+		binary.BigEndian.PutUint16(ip[6:8], 0x4000) // Don't Fragment
+		ip[8] = 64
+		ip[9] = protoUDP
+		copy(ip[12:16], src4)
+		copy(ip[16:20], dst4)
+		binary.BigEndian.PutUint16(ip[10:12], checksum(0, ip[:ipv4Len]))
+
+		// UDP checksum is optional in IPv4 and left zero.
+		return b, nil
+	}
+
+	ip[0] = 0x60
+	binary.BigEndian.PutUint16(ip[4:6], uint16(udpLen+len(payload)))
+	ip[6] = protoUDP
+	ip[7] = 64
+	copy(ip[8:24], src.IP.To16())
+	copy(ip[24:40], dst.IP.To16())
+
+	// UDP checksum is mandatory in IPv6, calculated with the pseudo header.
+	var sum uint32
+	for i := 8; i < 40; i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(ip[i : i+2]))
+	}
+	sum += uint32(len(udp)) + protoUDP
+	cs := checksum(sum, udp)
+	if cs == 0 {
+		cs = 0xffff
+	}
+	binary.BigEndian.PutUint16(udp[6:8], cs)
+	return b, nil
+}
+
+// checksum returns the Internet checksum of b, added to the initial sum.
+func checksum(sum uint32, b []byte) uint16 {
+	for len(b) >= 2 {
+		sum += uint32(binary.BigEndian.Uint16(b))
+		b = b[2:]
+	}
+	if len(b) == 1 {
+		sum += uint32(b[0]) << 8
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+func isSupported(linkType uint16) bool {
+	switch linkType {
+	case LinkTypeNull, LinkTypeEthernet, LinkTypeRaw, LinkTypeLinuxSLL:
+		return true
+	default:
+		return false
+	}
+}
+
+// decapsulate returns the addresses and the payload of the UDP packet in the
+// frame b of linkType. ok is false if b is not a UDP packet, or is a fragment.
+func decapsulate(linkType uint16, b []byte) (src, dst *net.UDPAddr, payload []byte, ok bool) {
+	switch linkType {
+	case LinkTypeNull:
+		// the address family is in the host byte order, which is unknown;
+		// the version in IP header is used instead.
+		if len(b) < 4 {
+			return nil, nil, nil, false
+		}
+		b = b[4:]
+	case LinkTypeEthernet:
+		if len(b) < ethernetLen {
+			return nil, nil, nil, false
+		}
+		etherType, off := binary.BigEndian.Uint16(b[12:14]), ethernetLen
+		for etherType == etherTypeVLAN || etherType == etherTypeQinQ {
+			if len(b) < off+4 {
+				return nil, nil, nil, false
+			}
+			etherType = binary.BigEndian.Uint16(b[off+2 : off+4])
+			off += 4
+		}
+		if etherType != etherTypeIPv4 && etherType != etherTypeIPv6 {
+			return nil, nil, nil, false
+		}
+		b = b[off:]
+	case LinkTypeRaw:
+	case LinkTypeLinuxSLL:
+		if len(b) < 16 {
+			return nil, nil, nil, false
+		}
+		if p := binary.BigEndian.Uint16(b[14:16]); p != etherTypeIPv4 && p != etherTypeIPv6 {
+			return nil, nil, nil, false
+		}
+		b = b[16:]
+	default:
+		return nil, nil, nil, false
+	}
+
+	if len(b) < 1 {
+		return nil, nil, nil, false
+	}
+
+	var srcIP, dstIP net.IP
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < ipv4Len {
+			return nil, nil, nil, false
+		}
+		ihl := int(b[0]&0x0f) * 4
+		total := int(binary.BigEndian.Uint16(b[2:4]))
+		// fragments are not reassembled.
+		if frag := binary.BigEndian.Uint16(b[6:8]); frag&0x3fff != 0 {
+			return nil, nil, nil, false
+		}
+		if b[9] != protoUDP || ihl < ipv4Len || total < ihl || len(b) < total {
+			return nil, nil, nil, false
+		}
+		srcIP, dstIP = net.IP(b[12:16]), net.IP(b[16:20])
+		b = b[ihl:total]
+	case 6:
+		if len(b) < ipv6Len {
+			return nil, nil, nil, false
+		}
+		// the extension headers are not supported.
+		total := ipv6Len + int(binary.BigEndian.Uint16(b[4:6]))
+		if b[6] != protoUDP || len(b) < total {
+			return nil, nil, nil, false
+		}
+		srcIP, dstIP = net.IP(b[8:24]), net.IP(b[24:40])
+		b = b[ipv6Len:total]
+	default:
+		return nil, nil, nil, false
+	}
+
+	if len(b) < udpLen {
+		return nil, nil, nil, false
+	}
+	l := int(binary.BigEndian.Uint16(b[4:6]))
+	if l < udpLen || len(b) < l {
+		return nil, nil, nil, false
+	}
+
+	src = &net.UDPAddr{IP: append(net.IP(nil), srcIP...), Port: int(binary.BigEndian.Uint16(b[0:2]))}
+	dst = &net.UDPAddr{IP: append(net.IP(nil), dstIP...), Port: int(binary.BigEndian.Uint16(b[2:4]))}
+	return src, dst, b[udpLen:l], true
+}
@@ -0,0 +1,301 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package capture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"time"
+
+	"github.com/wmnsk/go-gtp"
+)
+
+// pcap magic numbers for the timestamps in microseconds and nanoseconds.
+const (
+	pcapMagicMicro = 0xa1b2c3d4
+	pcapMagicNano  = 0xa1b23c4d
+)
+
+type iface struct {
+	linkType uint16
+	// unit is the duration of the timestamp unit in nanoseconds.
+	unit float64
+}
+
+// Reader reads the GTP messages from a pcapng or pcap file.
+//
+// Reader yields only the UDP packets over IPv4 or IPv6, skipping the others and
+// the IP fragments. It does not filter the packets by the port numbers.
+type Reader struct {
+	r      io.Reader
+	pcapng bool
+	order  binary.ByteOrder
+	ifaces []iface
+}
+
+// NewReader creates a new Reader that reads from r, detecting the format of the
+// file with the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	hdr := make([]byte, 24)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	cr := &Reader{r: r}
+	if binary.LittleEndian.Uint32(hdr[0:4]) == blockTypeSHB {
+		cr.pcapng = true
+		if err := cr.readSHB(hdr); err != nil {
+			return nil, err
+		}
+		return cr, nil
+	}
+
+	// pcap global header.
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		unit := 0.0
+		switch order.Uint32(hdr[0:4]) {
+		case pcapMagicMicro:
+			unit = 1000
+		case pcapMagicNano:
+			unit = 1
+		default:
+			continue
+		}
+		cr.order = order
+		cr.ifaces = []iface{{linkType: uint16(order.Uint32(hdr[20:24])), unit: unit}}
+		return cr, nil
+	}
+	return nil, ErrInvalidFormat
+}
+
+// readSHB reads the rest of Section Header Block, of which the first 24 bytes
+// are given in hdr.
+func (r *Reader) readSHB(hdr []byte) error {
+	switch binary.LittleEndian.Uint32(hdr[8:12]) {
+	case byteOrderMagic:
+		r.order = binary.LittleEndian
+	case 0x4d3c2b1a:
+		r.order = binary.BigEndian
+	default:
+		return ErrInvalidFormat
+	}
+
+	total := r.order.Uint32(hdr[4:8])
+	if total < 28 || total%4 != 0 {
+		return ErrInvalidFormat
+	}
+	if _, err := io.CopyN(io.Discard, r.r, int64(total)-24); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	// interfaces are defined per section.
+	r.ifaces = r.ifaces[:0]
+	return nil
+}
+
+// Next returns the next GTP message in the file. It returns io.EOF when no more
+// message is available.
+//
+// If the UDP payload cannot be parsed as a GTP message, it returns the Record
+// without Message together with the error from gtp.Parse. The caller can keep
+// calling Next after such an error.
+func (r *Reader) Next() (*Record, error) {
+	for {
+		var (
+			rec   *Record
+			frame []byte
+			err   error
+		)
+		if r.pcapng {
+			rec, frame, err = r.nextBlock()
+		} else {
+			rec, frame, err = r.nextRecord()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rec == nil {
+			continue
+		}
+
+		if len(r.ifaces) == 0 {
+			return nil, ErrInvalidFormat
+		}
+		linkType := r.linkType(rec)
+		if !isSupported(linkType) {
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedLink, linkType)
+		}
+		src, dst, payload, ok := decapsulate(linkType, frame)
+		if !ok {
+			continue
+		}
+		rec.Src, rec.Dst, rec.Payload = src, dst, payload
+
+		msg, err := gtp.Parse(payload)
+		if err != nil {
+			return rec, fmt.Errorf("failed to parse the message from %s to %s: %w", src, dst, err)
+		}
+		rec.Message = msg
+		return rec, nil
+	}
+}
+
+// All returns an iterator over the messages in the file, which stops at the end
+// of the file or at the first error other than the one in parsing the message.
+func (r *Reader) All() iter.Seq2[*Record, error] {
+	return func(yield func(*Record, error) bool) {
+		for {
+			rec, err := r.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(rec, err) || (err != nil && rec == nil) {
+				return
+			}
+		}
+	}
+}
+
+// linkType returns the link type of the interface the Record is captured on.
+func (r *Reader) linkType(rec *Record) uint16 {
+	return r.ifaces[rec.ifaceID].linkType
+}
+
+// nextRecord reads the next record in pcap. It returns nil Record for the
+// record to be skipped.
+func (r *Reader) nextRecord() (*Record, []byte, error) {
+	hdr := make([]byte, 16)
+	if _, err := io.ReadFull(r.r, hdr); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		return nil, nil, err
+	}
+
+	sec, frac, capLen := r.order.Uint32(hdr[0:4]), r.order.Uint32(hdr[4:8]), r.order.Uint32(hdr[8:12])
+	if capLen > 0x40000 {
+		return nil, nil, ErrInvalidFormat
+	}
+	frame := make([]byte, capLen)
+	if _, err := io.ReadFull(r.r, frame); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	ts := time.Unix(int64(sec), int64(float64(frac)*r.ifaces[0].unit))
+	return &Record{Time: ts}, frame, nil
+}
+
+// nextBlock reads the next block in pcapng. It returns nil Record for the block
+// that does not contain a packet.
+func (r *Reader) nextBlock() (*Record, []byte, error) {
+	hdr := make([]byte, 8)
+	if _, err := io.ReadFull(r.r, hdr); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		return nil, nil, err
+	}
+
+	// the byte order of SHB is determined by the magic in it.
+	if binary.LittleEndian.Uint32(hdr[0:4]) == blockTypeSHB {
+		shb := make([]byte, 24)
+		copy(shb, hdr)
+		if _, err := io.ReadFull(r.r, shb[8:]); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		}
+		return nil, nil, r.readSHB(shb)
+	}
+
+	typ, total := r.order.Uint32(hdr[0:4]), r.order.Uint32(hdr[4:8])
+	if total < 12 || total%4 != 0 || total > 0x40000 {
+		return nil, nil, ErrInvalidFormat
+	}
+	body := make([]byte, total-8)
+	if _, err := io.ReadFull(r.r, body); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	body = body[:len(body)-4] // trailing Block Total Length
+
+	switch typ {
+	case blockTypeIDB:
+		if len(body) < 8 {
+			return nil, nil, ErrInvalidFormat
+		}
+		ifc := iface{linkType: r.order.Uint16(body[0:2]), unit: 1000}
+		r.walkOptions(body[8:], func(code uint16, v []byte) {
+			if code == optTSResol && len(v) >= 1 {
+				ifc.unit = tsUnit(v[0])
+			}
+		})
+		r.ifaces = append(r.ifaces, ifc)
+		return nil, nil, nil
+	case blockTypeEPB:
+		if len(body) < 20 {
+			return nil, nil, ErrInvalidFormat
+		}
+		id, capLen := r.order.Uint32(body[0:4]), r.order.Uint32(body[12:16])
+		padded := (int(capLen) + 3) &^ 3
+		if int(id) >= len(r.ifaces) || len(body) < 20+padded {
+			return nil, nil, ErrInvalidFormat
+		}
+		ts := uint64(r.order.Uint32(body[4:8]))<<32 | uint64(r.order.Uint32(body[8:12]))
+
+		rec := &Record{ifaceID: int(id), Time: r.ifaces[id].time(ts)}
+		r.walkOptions(body[20+padded:], func(code uint16, v []byte) {
+			if code == optEPBFlags && len(v) >= 4 {
+				rec.Direction = Direction(r.order.Uint32(v) & 0x03)
+			}
+		})
+		return rec, body[20 : 20+capLen], nil
+	case blockTypeSPB:
+		if len(body) < 4 || len(r.ifaces) == 0 {
+			return nil, nil, ErrInvalidFormat
+		}
+		// Simple Packet Block has no timestamp and may be truncated by snaplen.
+		frame := body[4:]
+		if l := int(r.order.Uint32(body[0:4])); l < len(frame) {
+			frame = frame[:l]
+		}
+		return &Record{}, frame, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// walkOptions calls fn with each option in b until opt_endofopt.
+func (r *Reader) walkOptions(b []byte, fn func(code uint16, v []byte)) {
+	for len(b) >= 4 {
+		code, l := r.order.Uint16(b[0:2]), int(r.order.Uint16(b[2:4]))
+		if code == optEndOfOpt {
+			return
+		}
+		padded := (l + 3) &^ 3
+		if len(b) < 4+padded {
+			return
+		}
+		fn(code, b[4:4+l])
+		b = b[4+padded:]
+	}
+}
+
+// tsUnit returns the duration of the timestamp unit in nanoseconds from the
+// value of if_tsresol.
+func tsUnit(v uint8) float64 {
+	if v&0x80 != 0 {
+		return 1e9 / math.Pow(2, float64(v&0x7f))
+	}
+	return 1e9 / math.Pow(10, float64(v))
+}
+
+// time returns the time of the timestamp ts in the unit of the interface.
+func (i iface) time(ts uint64) time.Time {
+	if i.unit == 1 {
+		return time.Unix(0, int64(ts))
+	}
+	return time.Unix(0, int64(float64(ts)*i.unit))
+}
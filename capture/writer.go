@@ -0,0 +1,174 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv2"
+)
+
+// pcapng block types and options, defined in draft-ietf-opsawg-pcapng.
+const (
+	blockTypeSHB = 0x0a0d0d0a
+	blockTypeIDB = 0x00000001
+	blockTypeSPB = 0x00000003
+	blockTypeEPB = 0x00000006
+
+	byteOrderMagic = 0x1a2b3c4d
+
+	optEndOfOpt = 0
+	optTSResol  = 9
+	optEPBFlags = 2
+)
+
+// Writer writes the GTP messages to a pcapng file, with an interface of
+// LinkTypeEthernet and the timestamps in nanoseconds.
+//
+// Writer is safe for concurrent use.
+type Writer struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf []byte
+	err error
+}
+
+// NewWriter creates a new Writer that writes to w, and writes the headers of
+// pcapng file. It is the caller's responsibility to flush or close w.
+func NewWriter(w io.Writer) (*Writer, error) {
+	cw := &Writer{w: w}
+
+	// Section Header Block, without options.
+	shb := make([]byte, 28)
+	le := binary.LittleEndian
+	le.PutUint32(shb[0:4], blockTypeSHB)
+	le.PutUint32(shb[4:8], uint32(len(shb)))
+	le.PutUint32(shb[8:12], byteOrderMagic)
+	le.PutUint16(shb[12:14], 1)
+	le.PutUint16(shb[14:16], 0)
+	le.PutUint64(shb[16:24], 0xffffffffffffffff) // section length unknown
+	le.PutUint32(shb[24:28], uint32(len(shb)))
+
+	// Interface Description Block, with if_tsresol=9 for nanoseconds.
+	idb := make([]byte, 32)
+	le.PutUint32(idb[0:4], blockTypeIDB)
+	le.PutUint32(idb[4:8], uint32(len(idb)))
+	le.PutUint16(idb[8:10], LinkTypeEthernet)
+	le.PutUint32(idb[12:16], 0) // no snap length limit
+	le.PutUint16(idb[16:18], optTSResol)
+	le.PutUint16(idb[18:20], 1)
+	idb[20] = 9
+	le.PutUint16(idb[24:26], optEndOfOpt)
+	le.PutUint32(idb[28:32], uint32(len(idb)))
+
+	if _, err := w.Write(append(shb, idb...)); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// WritePacket writes the payload of the UDP packet sent from src to dst at ts.
+// dir is recorded in the packet as the direction seen from the node.
+func (w *Writer) WritePacket(ts time.Time, src, dst net.Addr, dir Direction, payload []byte) error {
+	frame, err := encapsulate(udpAddrOf(src), udpAddrOf(dst), payload)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Enhanced Packet Block with epb_flags.
+	padded := (len(frame) + 3) &^ 3
+	total := 28 + padded + 12 + 4
+	if cap(w.buf) < total {
+		w.buf = make([]byte, total)
+	}
+	b := w.buf[:total]
+	clear(b)
+
+	le := binary.LittleEndian
+	ns := uint64(ts.UnixNano())
+	le.PutUint32(b[0:4], blockTypeEPB)
+	le.PutUint32(b[4:8], uint32(total))
+	le.PutUint32(b[8:12], 0) // interface ID
+	le.PutUint32(b[12:16], uint32(ns>>32))
+	le.PutUint32(b[16:20], uint32(ns))
+	le.PutUint32(b[20:24], uint32(len(frame)))
+	le.PutUint32(b[24:28], uint32(len(frame)))
+	copy(b[28:], frame)
+
+	opts := b[28+padded:]
+	le.PutUint16(opts[0:2], optEPBFlags)
+	le.PutUint16(opts[2:4], 4)
+	le.PutUint32(opts[4:8], uint32(dir))
+	le.PutUint16(opts[8:10], optEndOfOpt)
+	le.PutUint32(b[total-4:], uint32(total))
+
+	_, err = w.w.Write(b)
+	return err
+}
+
+// capture writes the packet in the interceptor, keeping the first error.
+func (w *Writer) capture(local, peer net.Addr, dir Direction, raw []byte) {
+	src, dst := peer, local
+	if dir == Outbound {
+		src, dst = local, peer
+	}
+
+	if err := w.WritePacket(time.Now(), src, dst, dir, raw); err != nil {
+		w.mu.Lock()
+		if w.err == nil {
+			w.err = err
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Err returns the first error occurred in writing the packets captured by the
+// interceptors, which never veto the messages even when they fail to write.
+func (w *Writer) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// ConnOptions returns the options to let gtpv2.Conn write the messages it sends
+// and receives. The messages received are written even if they cannot be parsed.
+//
+// The messages are written as they are seen by the Interceptors, which means
+// that the ones mutated or vetoed by the Interceptors given before these options
+// are written as mutated or not written at all.
+func (w *Writer) ConnOptions() []gtpv2.ConnOption {
+	return []gtpv2.ConnOption{
+		gtpv2.WithInboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			w.capture(c.LocalAddr(), pkt.Peer, Inbound, pkt.Raw)
+			return nil
+		}),
+		gtpv2.WithOutboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			w.capture(c.LocalAddr(), pkt.Peer, Outbound, pkt.Raw)
+			return nil
+		}),
+	}
+}
+
+// AttachUPlaneConn lets gtpv1.UPlaneConn write the messages it sends and receives,
+// including T-PDUs. See (*gtpv1.UPlaneConn).AddInboundInterceptor for the impact
+// on the performance and the packets that cannot be captured.
+func (w *Writer) AttachUPlaneConn(u *gtpv1.UPlaneConn) {
+	u.AddInboundInterceptor(func(u *gtpv1.UPlaneConn, pkt *gtpv1.Packet) error {
+		w.capture(u.LocalAddr(), pkt.Peer, Inbound, pkt.Raw)
+		return nil
+	})
+	u.AddOutboundInterceptor(func(u *gtpv1.UPlaneConn, pkt *gtpv1.Packet) error {
+		w.capture(u.LocalAddr(), pkt.Peer, Outbound, pkt.Raw)
+		return nil
+	})
+}
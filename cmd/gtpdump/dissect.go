@@ -0,0 +1,378 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/wmnsk/go-gtp"
+	"github.com/wmnsk/go-gtp/capture"
+	v0ie "github.com/wmnsk/go-gtp/gtpv0/ie"
+	v0msg "github.com/wmnsk/go-gtp/gtpv0/message"
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/utils"
+)
+
+// message is a dissected GTP message.
+type message struct {
+	Time     time.Time      `json:"time"`
+	Src      string         `json:"src"`
+	Dst      string         `json:"dst"`
+	Version  int            `json:"version"`
+	Type     uint8          `json:"type"`
+	Name     string         `json:"name"`
+	TEID     *uint32        `json:"teid,omitempty"`
+	TID      *tid           `json:"tid,omitempty"`
+	Sequence uint32         `json:"sequence"`
+	IEs      []*infoElement `json:"ies,omitempty"`
+	Payload  hexBytes       `json:"payload,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// tid is the Tunnel Identifier in GTPv0 header.
+type tid struct {
+	IMSI  string `json:"imsi"`
+	NSAPI uint8  `json:"nsapi"`
+}
+
+// infoElement is a dissected IE.
+type infoElement struct {
+	Type     uint8          `json:"type"`
+	Instance *uint8         `json:"instance,omitempty"`
+	Name     string         `json:"name"`
+	Value    any            `json:"value,omitempty"`
+	Raw      hexBytes       `json:"raw,omitempty"`
+	IEs      []*infoElement `json:"ies,omitempty"`
+	Error    string         `json:"error,omitempty"`
+
+	// teids are the TEIDs in the IE, used by the filter.
+	teids []uint32
+}
+
+// field is a named value in the decoded IE.
+type field struct {
+	Key   string
+	Value any
+}
+
+// fields is the ordered set of the fields in the decoded IE.
+type fields []field
+
+// MarshalJSON returns fields as a JSON object, keeping the order.
+func (f fields) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for n, v := range f {
+		if n > 0 {
+			b = append(b, ',')
+		}
+		k, err := json.Marshal(v.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		b = append(append(append(b, k...), ':'), val...)
+	}
+	return append(b, '}'), nil
+}
+
+// String returns fields in human readable format.
+func (f fields) String() string {
+	s := make([]string, len(f))
+	for n, v := range f {
+		s[n] = v.Key + ": " + format(v.Value)
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// hexBytes is the bytes printed in hex.
+type hexBytes []byte
+
+// MarshalText returns b in hex.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// String returns b in hex.
+func (b hexBytes) String() string {
+	return hex.EncodeToString(b)
+}
+
+// format returns the value of IE in human readable format.
+func format(v any) string {
+	switch x := v.(type) {
+	case []fields:
+		s := make([]string, len(x))
+		for n, f := range x {
+			s[n] = f.String()
+		}
+		return "[" + strings.Join(s, ", ") + "]"
+	case string:
+		return x
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// String returns the message in human readable format, ending with a newline.
+func (m *message) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s -> %s GTPv%d %s (%d)", m.Time.Format(time.RFC3339Nano), m.Src, m.Dst, m.Version, m.Name, m.Type)
+	if m.TEID != nil {
+		fmt.Fprintf(&sb, " TEID: %#08x", *m.TEID)
+	}
+	if m.TID != nil {
+		fmt.Fprintf(&sb, " TID: %s/%d", m.TID.IMSI, m.TID.NSAPI)
+	}
+	fmt.Fprintf(&sb, " Seq: %#x\n", m.Sequence)
+	if m.Error != "" {
+		fmt.Fprintf(&sb, "  error: %s\n", m.Error)
+	}
+	if m.Payload != nil {
+		fmt.Fprintf(&sb, "  payload: %d bytes\n", len(m.Payload))
+	}
+	writeIEs(&sb, m.IEs, "  ")
+	return sb.String()
+}
+
+func writeIEs(sb *strings.Builder, ies []*infoElement, indent string) {
+	for _, i := range ies {
+		sb.WriteString(indent + i.Name)
+		if i.Instance != nil {
+			fmt.Fprintf(sb, "[%d]", *i.Instance)
+		}
+		switch {
+		case i.Error != "":
+			fmt.Fprintf(sb, ": %s (error: %s)\n", i.Raw, i.Error)
+		case i.IEs != nil:
+			sb.WriteString(":\n")
+			writeIEs(sb, i.IEs, indent+"  ")
+		case i.Value != nil:
+			fmt.Fprintf(sb, ": %s\n", format(i.Value))
+		default:
+			fmt.Fprintf(sb, ": %s\n", i.Raw)
+		}
+	}
+}
+
+// dissect returns the messages in the payload of rec. It returns more than one
+// message if the GTPv2 messages are piggybacked.
+func dissect(rec *capture.Record) []*message {
+	var msgs []*message
+	b := rec.Payload
+	for {
+		m := &message{Time: rec.Time.UTC(), Src: rec.Src.String(), Dst: rec.Dst.String()}
+		msgs = append(msgs, m)
+
+		n, err := dissectMessage(m, b)
+		if err != nil {
+			m.Error = err.Error()
+			m.Payload = b
+			return msgs
+		}
+		if n == 0 || n >= len(b) {
+			return msgs
+		}
+		b = b[n:]
+	}
+}
+
+// dissectMessage dissects the message at the beginning of b into m, and returns
+// the length of the message if another message is piggybacked after it.
+func dissectMessage(m *message, b []byte) (int, error) {
+	if len(b) < 1 {
+		return 0, gtp.ErrTooShortToParse
+	}
+
+	m.Version = int(b[0] >> 5)
+	switch m.Version {
+	case 0:
+		h, err := v0msg.ParseHeader(b)
+		if err != nil {
+			return 0, err
+		}
+		m.Type, m.Sequence = h.Type, uint32(h.SequenceNumber)
+		label := uint32(h.FlowLabel)
+		m.TEID = &label
+
+		t := make([]byte, 8)
+		binary.BigEndian.PutUint64(t, h.TID)
+		m.TID = &tid{
+			IMSI:  strings.TrimRight(utils.SwappedBytesToStr(t[:7], false)+fmt.Sprintf("%x", t[7]&0x0f), "f"),
+			NSAPI: t[7] >> 4,
+		}
+
+		if err := nameOf(m, b); err != nil {
+			return 0, err
+		}
+		if h.Type == v0msg.MsgTypeTPDU {
+			m.Payload = h.Payload
+			return 0, nil
+		}
+		ies, err := v0ie.ParseMultiIEs(h.Payload)
+		if err != nil {
+			return 0, err
+		}
+		m.IEs = v0Elements(ies)
+	case 1:
+		h, err := v1msg.ParseHeader(b)
+		if err != nil {
+			return 0, err
+		}
+		m.Type, m.Sequence = h.Type, uint32(h.SequenceNumber)
+		m.TEID = &h.TEID
+
+		if err := nameOf(m, b); err != nil {
+			return 0, err
+		}
+		if h.Type == v1msg.MsgTypeTPDU {
+			m.Payload = h.Payload
+			return 0, nil
+		}
+		ies, err := v1ie.ParseMultiIEs(h.Payload)
+		if err != nil {
+			return 0, err
+		}
+		m.IEs = v1Elements(ies)
+	case 2:
+		h, err := v2msg.ParseHeader(b)
+		if err != nil {
+			return 0, err
+		}
+		m.Type, m.Sequence = h.Type, h.SequenceNumber
+		if h.HasTEID() {
+			m.TEID = &h.TEID
+		}
+
+		l := h.MarshalLen()
+		if err := nameOf(m, b[:min(l, len(b))]); err != nil {
+			return 0, err
+		}
+		ies, err := v2ie.ParseMultiIEs(h.Payload)
+		if err != nil {
+			return 0, err
+		}
+		m.IEs = v2Elements(ies)
+		if h.IsPiggybacking() {
+			return l, nil
+		}
+	default:
+		return 0, gtp.ErrInvalidVersion
+	}
+	return 0, nil
+}
+
+// nameOf sets the name of the message in b to m.
+func nameOf(m *message, b []byte) error {
+	msg, err := gtp.Parse(b)
+	if err != nil {
+		return err
+	}
+	m.Name = msg.MessageTypeName()
+	return nil
+}
+
+// decoder decodes the value of IE, setting the TEIDs and child IEs in e if any.
+type decoder[T any] func(i T, e *infoElement) (any, error)
+
+// decode sets the value of IE decoded with dec to e. The IE is printed in hex if
+// dec is nil or it fails.
+func decode[T any](e *infoElement, i T, payload []byte, dec decoder[T]) {
+	if dec == nil {
+		e.Raw = payload
+		return
+	}
+	v, err := dec(i, e)
+	if err != nil {
+		e.Raw = payload
+		e.Error = err.Error()
+		return
+	}
+	if v == nil && e.IEs == nil {
+		e.Raw = payload
+		return
+	}
+	e.Value = v
+}
+
+// ip returns the IP address as string, or nil if ip is empty so that the field
+// can be omitted.
+func ip(ip net.IP) any {
+	if len(ip) == 0 {
+		return nil
+	}
+	return ip.String()
+}
+
+// appendNonNil appends the field only if v is not nil.
+func appendNonNil(f fields, key string, v any) fields {
+	if v == nil {
+		return f
+	}
+	return append(f, field{key, v})
+}
+
+// collector collects the fields from the accessors, keeping the first error.
+type collector struct {
+	f   fields
+	err error
+}
+
+// add returns the function to add the value returned by an accessor as key.
+func (c *collector) add(key string) func(v any, err error) {
+	return func(v any, err error) {
+		if err != nil {
+			if c.err == nil {
+				c.err = err
+			}
+			return
+		}
+		c.f = append(c.f, field{key, v})
+	}
+}
+
+// result returns the fields collected and the first error.
+func (c *collector) result() (any, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.f, nil
+}
+
+// teid is a TEID, printed in hex.
+type teid uint32
+
+// String returns the TEID in hex.
+func (t teid) String() string {
+	return fmt.Sprintf("%#08x", uint32(t))
+}
+
+// duration returns the duration in human readable format.
+func duration(d time.Duration, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return d.String(), nil
+}
+
+// ints returns the list of numbers in b, which is not printed in hex.
+func ints(b []uint8) []int {
+	n := make([]int, len(b))
+	for i, v := range b {
+		n[i] = int(v)
+	}
+	return n
+}
@@ -0,0 +1,112 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// filter selects the messages to be printed.
+type filter struct {
+	imsi  string
+	teid  *uint32
+	names map[string]bool
+	types map[uint8]bool
+
+	// tracked is the set of TEIDs that appear in the messages of the IMSI.
+	tracked map[uint32]bool
+}
+
+// newFilter creates a new filter from the values of flags. The empty values are
+// not used for filtering.
+func newFilter(imsi, teid, types string) (*filter, error) {
+	f := &filter{imsi: imsi, tracked: map[uint32]bool{}}
+
+	if teid != "" {
+		t, err := strconv.ParseUint(teid, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TEID %q: %w", teid, err)
+		}
+		v := uint32(t)
+		f.teid = &v
+	}
+
+	if types != "" {
+		f.names, f.types = map[string]bool{}, map[uint8]bool{}
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			if n, err := strconv.ParseUint(t, 10, 8); err == nil {
+				f.types[uint8(n)] = true
+				continue
+			}
+			f.names[strings.ToLower(t)] = true
+		}
+	}
+	return f, nil
+}
+
+// match reports whether m should be printed.
+func (f *filter) match(m *message) bool {
+	// the IMSI is checked first, to track the TEIDs in all the messages.
+	if f.imsi != "" && !f.matchIMSI(m) {
+		return false
+	}
+	if f.teid != nil && !m.hasTEID(*f.teid) {
+		return false
+	}
+	if f.types != nil && !f.types[m.Type] && !f.names[strings.ToLower(m.Name)] {
+		return false
+	}
+	return true
+}
+
+// matchIMSI reports whether m has the IMSI, or is sent to the TEID tracked. The
+// TEIDs in the matched message are tracked.
+func (f *filter) matchIMSI(m *message) bool {
+	ok := m.TID != nil && m.TID.IMSI == f.imsi
+	if !ok && m.TEID != nil && *m.TEID != 0 {
+		ok = f.tracked[*m.TEID]
+	}
+	walk(m.IEs, func(e *infoElement) {
+		if s, isStr := e.Value.(string); isStr && e.Name == "IMSI" && s == f.imsi {
+			ok = true
+		}
+	})
+	if !ok {
+		return false
+	}
+
+	walk(m.IEs, func(e *infoElement) {
+		for _, t := range e.teids {
+			if t != 0 {
+				f.tracked[t] = true
+			}
+		}
+	})
+	return true
+}
+
+// hasTEID reports whether m has teid in the header or in the IEs.
+func (m *message) hasTEID(teid uint32) bool {
+	found := m.TEID != nil && *m.TEID == teid
+	walk(m.IEs, func(e *infoElement) {
+		for _, t := range e.teids {
+			if t == teid {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// walk calls fn with each IE in ies, including the ones in the grouped IEs.
+func walk(ies []*infoElement, fn func(e *infoElement)) {
+	for _, e := range ies {
+		fn(e)
+		walk(e.IEs, fn)
+	}
+}
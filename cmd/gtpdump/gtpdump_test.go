@@ -0,0 +1,174 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp"
+	"github.com/wmnsk/go-gtp/capture"
+	v0msg "github.com/wmnsk/go-gtp/gtpv0/message"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+var (
+	mme = &net.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 2123}
+	sgw = &net.UDPAddr{IP: net.IP{10, 0, 0, 2}, Port: 2123}
+	enb = &net.UDPAddr{IP: net.IP{10, 0, 0, 3}, Port: 2152}
+	sgu = &net.UDPAddr{IP: net.IP{10, 0, 0, 2}, Port: 2152}
+	gsn = &net.UDPAddr{IP: net.IP{10, 0, 0, 4}, Port: 3386}
+)
+
+// newCapture returns the pcapng file with the messages, given as the triples of
+// source, destination and message.
+func newCapture(t *testing.T, pkts ...any) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := capture.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < len(pkts); n += 3 {
+		b, err := gtp.Marshal(pkts[n+2].(gtp.Message))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := time.Unix(1700000000+int64(n), 0)
+		if err := w.WritePacket(ts, pkts[n].(net.Addr), pkts[n+1].(net.Addr), capture.Unknown, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func testCapture(t *testing.T) []byte {
+	t.Helper()
+
+	return newCapture(t,
+		mme, sgw, v2msg.NewCreateSessionRequest(0, 1,
+			v2ie.NewIMSI("123451234567890"),
+			v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", ""),
+			v2ie.NewUserLocationInformationStruct(
+				nil, nil, nil,
+				v2ie.NewTAI("123", "45", 0x0001),
+				v2ie.NewECGI("123", "45", 0x00000101),
+				nil, nil, nil,
+			),
+			v2ie.NewBearerContext(
+				v2ie.NewEPSBearerID(5),
+				v2ie.NewBearerQoS(1, 2, 1, 9, 0, 0, 0, 0),
+			),
+		),
+		sgw, mme, v2msg.NewCreateSessionResponse(0x11111111, 1,
+			v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+			v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11S4SGWGTPC, 0x22222222, "10.0.0.2", ""),
+		),
+		mme, sgw, v2msg.NewModifyBearerRequest(0x22222222, 2),
+		mme, sgw, v2msg.NewEchoRequest(3, v2ie.NewRecovery(1)),
+		enb, sgu, v1msg.NewTPDU(0x33333333, []byte{0xde, 0xad, 0xbe, 0xef}),
+		gsn, gsn, v0msg.NewEchoRequest(4, 0, 0x2143658709214355),
+	)
+}
+
+func TestDump(t *testing.T) {
+	cases := []struct {
+		description      string
+		imsi, teid, typs string
+		want             []string
+	}{
+		{
+			"no filter", "", "", "",
+			[]string{"Create Session Request", "Create Session Response", "Modify Bearer Request", "Echo Request", "T-PDU", "Echo Request"},
+		},
+		{
+			"imsi", "123451234567890", "", "",
+			[]string{"Create Session Request", "Create Session Response", "Modify Bearer Request"},
+		},
+		{
+			"imsi in tid", "123456789012345", "", "",
+			[]string{"Echo Request"},
+		},
+		{
+			"teid", "", "0x22222222", "",
+			[]string{"Create Session Response", "Modify Bearer Request"},
+		},
+		{
+			"type", "", "", "echo request, 255",
+			[]string{"Echo Request", "T-PDU", "Echo Request"},
+		},
+		{
+			"imsi and type", "123451234567890", "", "Modify Bearer Request",
+			[]string{"Modify Bearer Request"},
+		},
+	}
+
+	ports, err := parsePorts("2123,2152,3386")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			f, err := newFilter(c.imsi, c.teid, c.typs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if err := dump(&out, bytes.NewReader(testCapture(t)), ports, f, true); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			dec := json.NewDecoder(&out)
+			for dec.More() {
+				var m struct{ Name string }
+				if err := dec.Decode(&m); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, m.Name)
+			}
+			if diff := cmp.Diff(got, c.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDumpText(t *testing.T) {
+	f, err := newFilter("", "", "Create Session Request")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := dump(&out, bytes.NewReader(testCapture(t)), map[int]bool{2123: true}, f, false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"2023-11-14T22:13:20Z 10.0.0.1:2123 -> 10.0.0.2:2123 GTPv2 Create Session Request (32) TEID: 0x00000000 Seq: 0x1",
+		"  IMSI[0]: 123451234567890",
+		"  UserLocationInformation[0]: {TAI: {MCC: 123, MNC: 45, TAC: 1}, ECGI: {MCC: 123, MNC: 45, ECI: 257}}",
+		"  FullyQualifiedTEID[0]: {InterfaceType: 10, TEID: 0x11111111, IPv4: 10.0.0.1}",
+		"  BearerContext[0]:",
+		"    EPSBearerID[0]: 5",
+		"    BearerQoS[0]: {PriorityLevel: 2, PreemptionCapability: false, PreemptionVulnerability: false, QCI: 9, MBRUplink: 0, MBRDownlink: 0, GBRUplink: 0, GBRDownlink: 0}",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(out.String(), want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Command gtpdump prints the GTPv0, GTPv1 and GTPv2 messages in a pcap or pcapng
+// file, with all the IEs decoded.
+//
+// The UDP packets on the ports 2123, 2152 and 3386 (either source or destination)
+// are parsed with gtp.Parse, and the IEs are decoded with their typed accessors
+// such as IMSI() or FullyQualifiedTEID(). The IEs that have no accessor are
+// printed in hex.
+//
+//	gtpdump [flags] <file>
+//
+// The messages can be filtered with the flags below, which are combined with AND.
+//
+//	-imsi   IMSI in the messages, or in the TID of GTPv0 header. The messages sent
+//	        to the TEIDs that appear in the matched ones are also printed, so
+//	        that the whole session can be followed.
+//	-teid   TEID in the header or in the TEID/F-TEID IEs, in decimal or hex (0x).
+//	-type   comma-separated message types, by name (e.g. "Create Session Request")
+//	        or by number.
+//
+// With -json, the messages are printed in JSON, one object per line.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wmnsk/go-gtp/capture"
+)
+
+// command-line flags.
+var (
+	imsiFlag = flag.String("imsi", "", "IMSI to filter the messages with.")
+	teidFlag = flag.String("teid", "", "TEID to filter the messages with, in decimal or hex (0x).")
+	typeFlag = flag.String("type", "", "comma-separated message types to filter the messages with, by name or number.")
+	ports    = flag.String("ports", "2123,2152,3386", "comma-separated UDP ports to dissect.")
+	asJSON   = flag.Bool("json", false, "print the messages in JSON, one object per line.")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gtpdump: ")
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := newFilter(*imsiFlag, *teidFlag, *typeFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	portSet, err := parsePorts(*ports)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	if err := dump(os.Stdout, file, portSet, f, *asJSON); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// dump prints the messages read from r that match f.
+func dump(w io.Writer, r io.Reader, ports map[int]bool, f *filter, asJSON bool) error {
+	cr, err := capture.NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for rec, err := range cr.All() {
+		if rec == nil {
+			return err
+		}
+		if !ports[rec.Src.Port] && !ports[rec.Dst.Port] {
+			continue
+		}
+
+		for _, m := range dissect(rec) {
+			if !f.match(m) {
+				continue
+			}
+			if asJSON {
+				if err := enc.Encode(m); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := io.WriteString(w, m.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parsePorts parses the comma-separated port numbers.
+func parsePorts(s string) (map[int]bool, error) {
+	ports := map[int]bool{}
+	for _, p := range strings.Split(s, ",") {
+		n, err := strconv.ParseUint(strings.TrimSpace(p), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", p, err)
+		}
+		ports[int(n)] = true
+	}
+	return ports, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+)
+
+// v0Elements dissects the GTPv0 IEs.
+func v0Elements(ies []*ie.IE) []*infoElement {
+	es := make([]*infoElement, len(ies))
+	for n, i := range ies {
+		e := &infoElement{Type: i.Type, Name: i.Name()}
+		decode(e, i, i.Payload, v0Decoders[i.Type])
+		es[n] = e
+	}
+	return es
+}
+
+// v0Decoders are the decoders of GTPv0 IEs, by IE type.
+var v0Decoders = map[uint8]decoder[*ie.IE]{
+	ie.Cause: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Cause()
+	},
+	ie.IMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IMSI()
+	},
+	ie.RouteingAreaIdentity: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("LAC")(i.LAC())
+		c.add("RAC")(i.RAC())
+		return c.result()
+	},
+	ie.TemporaryLogicalLinkIdentity: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.TemporaryLogicalLinkIdentity()
+	},
+	ie.PacketTMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PacketTMSI()
+	},
+	ie.QualityOfServiceProfile: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("Delay")(i.QoSDelay())
+		c.add("Reliability")(i.QoSReliability())
+		c.add("Peak")(i.QoSPeak())
+		c.add("Precedence")(i.QoSPrecedence())
+		c.add("Mean")(i.QoSMean())
+		return c.result()
+	},
+	ie.ReorderingRequired: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ReorderingRequired(), nil
+	},
+	ie.PTMSISignature: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PTMSISignature()
+	},
+	ie.Recovery: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Recovery()
+	},
+	ie.SelectionMode: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.SelectionMode()
+	},
+	ie.FlowLabelDataI: func(i *ie.IE, e *infoElement) (any, error) {
+		l, err := i.FlowLabelDataI()
+		if err != nil {
+			return nil, err
+		}
+		e.teids = append(e.teids, uint32(l))
+		return l, nil
+	},
+	ie.FlowLabelSignalling: func(i *ie.IE, e *infoElement) (any, error) {
+		l, err := i.FlowLabelSignalling()
+		if err != nil {
+			return nil, err
+		}
+		e.teids = append(e.teids, uint32(l))
+		return l, nil
+	},
+	ie.FlowLabelDataII: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("NSAPI")(i.NSAPI())
+		c.add("FlowLabel")(i.FlowLabelData())
+		return c.result()
+	},
+	ie.MSNotReachableReason: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MSNotReachableReason()
+	},
+	ie.ChargingID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ChargingID()
+	},
+	ie.EndUserAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("PDPTypeOrganization")(i.PDPTypeOrganization())
+		c.add("PDPTypeNumber")(i.PDPTypeNumber())
+		// the address is absent when it is to be allocated dynamically.
+		if len(i.Payload) > 2 {
+			c.add("Address")(i.IPAddress())
+		}
+		return c.result()
+	},
+	ie.AccessPointName: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.AccessPointName()
+	},
+	ie.GSNAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.GSNAddress()
+	},
+	ie.MSISDN: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MSISDN()
+	},
+	ie.ChargingGatewayAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ChargingGatewayAddress()
+	},
+	ie.PrivateExtension: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("ExtensionIdentifier")(i.ExtensionIdentifier())
+		v, err := i.ExtensionValue()
+		c.add("ExtensionValue")(hexBytes(v), err)
+		return c.result()
+	},
+}
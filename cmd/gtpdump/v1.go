@@ -0,0 +1,175 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"io"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// v1Elements dissects the GTPv1 IEs.
+func v1Elements(ies []*ie.IE) []*infoElement {
+	es := make([]*infoElement, len(ies))
+	for n, i := range ies {
+		e := &infoElement{Type: i.Type, Name: i.Name()}
+		decode(e, i, i.Payload, v1Decoders[i.Type])
+		es[n] = e
+	}
+	return es
+}
+
+// v1TEID decodes the TEID IEs.
+func v1TEID(i *ie.IE, e *infoElement) (any, error) {
+	t, err := i.TEID()
+	if err != nil {
+		return nil, err
+	}
+	e.teids = append(e.teids, t)
+	return teid(t), nil
+}
+
+// v1Decoders are the decoders of GTPv1 IEs, by IE type.
+var v1Decoders = map[uint8]decoder[*ie.IE]{
+	ie.Cause: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Cause()
+	},
+	ie.IMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IMSI()
+	},
+	ie.RouteingAreaIdentity: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("LAC")(i.LAC())
+		c.add("RAC")(i.RAC())
+		return c.result()
+	},
+	ie.PacketTMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PacketTMSI()
+	},
+	ie.ReorderingRequired: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ReorderingRequired(), nil
+	},
+	ie.MAPCause: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MAPCause()
+	},
+	ie.PTMSISignature: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PTMSISignature()
+	},
+	ie.MSValidated: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MSValidated(), nil
+	},
+	ie.Recovery: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Recovery()
+	},
+	ie.SelectionMode: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.SelectionMode()
+	},
+	ie.TEIDDataI:  v1TEID,
+	ie.TEIDCPlane: v1TEID,
+	ie.TeardownInd: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.TeardownInd(), nil
+	},
+	ie.NSAPI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.NSAPI()
+	},
+	ie.RANAPCause: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.RANAPCause()
+	},
+	ie.ChargingID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ChargingID()
+	},
+	ie.EndUserAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("PDPTypeOrganization")(i.PDPTypeOrganization())
+		c.add("PDPTypeNumber")(i.PDPTypeNumber())
+		// the address is absent when it is to be allocated dynamically.
+		if len(i.Payload) > 2 {
+			c.add("Address")(i.IPAddress())
+		}
+		return c.result()
+	},
+	ie.AccessPointName: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.AccessPointName()
+	},
+	ie.ProtocolConfigurationOptions: func(i *ie.IE, _ *infoElement) (any, error) {
+		pco, err := i.ProtocolConfigurationOptions()
+		if err != nil {
+			return nil, err
+		}
+		os := make([]fields, len(pco.ConfigurationProtocolOptions))
+		for n, o := range pco.ConfigurationProtocolOptions {
+			os[n] = fields{{"ID", o.ProtocolID}, {"Contents", hexBytes(o.Contents)}}
+		}
+		return fields{{"ConfigurationProtocol", pco.ConfigurationProtocol}, {"Options", os}}, nil
+	},
+	ie.GSNAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.GSNAddress()
+	},
+	ie.MSISDN: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MSISDN()
+	},
+	ie.QoSProfile: func(i *ie.IE, _ *infoElement) (any, error) {
+		b, err := i.QoSProfile()
+		return hexBytes(b), err
+	},
+	ie.ExtensionHeaderTypeList: func(i *ie.IE, _ *infoElement) (any, error) {
+		l, err := i.ExtensionHeaderTypeList()
+		return ints(l), err
+	},
+	ie.CommonFlags: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.CommonFlags()
+	},
+	ie.APNRestriction: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.APNRestriction()
+	},
+	ie.RATType: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.RATType()
+	},
+	ie.UserLocationInformation: func(i *ie.IE, _ *infoElement) (any, error) {
+		if len(i.Payload) == 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("LAC")(i.LAC())
+		switch i.Payload[0] {
+		case 0:
+			c.add("CI")(i.CGI())
+		case 1:
+			c.add("SAC")(i.SAC())
+		case 2:
+			c.add("RAC")(i.RAC())
+		}
+		return c.result()
+	},
+	ie.MSTimeZone: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("TimeZone")(duration(i.TimeZone()))
+		c.add("DaylightSaving")(i.DaylightSaving())
+		return c.result()
+	},
+	ie.IMEISV: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IMEISV()
+	},
+	ie.ExtendedCommonFlags: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ExtendedCommonFlags()
+	},
+	ie.ULITimestamp: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Timestamp()
+	},
+	ie.ExtendedCommonFlagsII: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ExtendedCommonFlagsII()
+	},
+	ie.PrivateExtension: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("ExtensionIdentifier")(i.ExtensionIdentifier())
+		v, err := i.ExtensionValue()
+		c.add("ExtensionValue")(hexBytes(v), err)
+		return c.result()
+	},
+}
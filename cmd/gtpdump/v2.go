@@ -0,0 +1,393 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// v2Elements dissects the GTPv2 IEs, including the ones in the grouped IEs.
+func v2Elements(ies []*ie.IE) []*infoElement {
+	es := make([]*infoElement, len(ies))
+	for n, i := range ies {
+		inst := i.Instance()
+		e := &infoElement{Type: i.Type, Instance: &inst, Name: i.Name()}
+		if i.IsGrouped() {
+			e.IEs = v2Elements(i.ChildIEs)
+		} else {
+			decode(e, i, i.Payload, v2Decoders[i.Type])
+		}
+		es[n] = e
+	}
+	return es
+}
+
+// v2Decoders are the decoders of GTPv2 IEs, by IE type.
+var v2Decoders = map[uint8]decoder[*ie.IE]{
+	ie.IMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IMSI()
+	},
+	ie.Cause: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("Cause")(i.Cause())
+		c.f = append(c.f, field{"PCE", i.HasPCE()}, field{"BCE", i.HasBCE()}, field{"CS", i.HasCS()})
+		if len(i.Payload) >= 6 {
+			o, err := i.OffendingIE()
+			if err != nil {
+				return nil, err
+			}
+			c.f = append(c.f, field{"OffendingIE", fields{{"Type", o.Type}, {"Instance", o.Instance()}}})
+		}
+		return c.result()
+	},
+	ie.Recovery: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Recovery()
+	},
+	ie.AccessPointName: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.AccessPointName()
+	},
+	ie.AggregateMaximumBitRate: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("Uplink")(i.AggregateMaximumBitRateUp())
+		c.add("Downlink")(i.AggregateMaximumBitRateDown())
+		return c.result()
+	},
+	ie.EPSBearerID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.EPSBearerID()
+	},
+	ie.IPAddress: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IPAddress()
+	},
+	ie.MobileEquipmentIdentity: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MobileEquipmentIdentity()
+	},
+	ie.MSISDN: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MSISDN()
+	},
+	ie.Indication: func(i *ie.IE, _ *infoElement) (any, error) {
+		b, err := i.Indication()
+		return hexBytes(b), err
+	},
+	ie.ProtocolConfigurationOptions: func(i *ie.IE, _ *infoElement) (any, error) {
+		pco, err := i.ProtocolConfigurationOptions()
+		if err != nil {
+			return nil, err
+		}
+		cs := make([]fields, len(pco.ProtocolOrContainers))
+		for n, c := range pco.ProtocolOrContainers {
+			cs[n] = fields{{"ID", c.ID}, {"Contents", hexBytes(c.Contents)}}
+		}
+		return fields{{"ConfigurationProtocol", pco.ConfigurationProtocol}, {"Containers", cs}}, nil
+	},
+	ie.PDNAddressAllocation: func(i *ie.IE, _ *infoElement) (any, error) {
+		paa, err := ie.ParsePDNAddressAllocationFields(i.Payload)
+		if err != nil {
+			return nil, err
+		}
+		f := fields{{"PDNType", paa.PDNType}}
+		f = appendNonNil(f, "IPv4", ip(paa.IPv4Address))
+		if len(paa.IPv6Address) > 0 {
+			f = append(f, field{"IPv6", ip(paa.IPv6Address)}, field{"IPv6PrefixLength", paa.IPv6PrefixLength})
+		}
+		return f, nil
+	},
+	ie.BearerQoS: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("PriorityLevel")(i.PriorityLevel())
+		c.f = append(c.f,
+			field{"PreemptionCapability", i.PreemptionCapability()},
+			field{"PreemptionVulnerability", i.PreemptionVulnerability()},
+		)
+		c.add("QCI")(i.QCILabel())
+		c.add("MBRUplink")(i.MBRForUplink())
+		c.add("MBRDownlink")(i.MBRForDownlink())
+		c.add("GBRUplink")(i.GBRForUplink())
+		c.add("GBRDownlink")(i.GBRForDownlink())
+		return c.result()
+	},
+	ie.FlowQoS: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("QCI")(i.QCILabel())
+		c.add("MBRUplink")(i.MBRForUplink())
+		c.add("MBRDownlink")(i.MBRForDownlink())
+		c.add("GBRUplink")(i.GBRForUplink())
+		c.add("GBRDownlink")(i.GBRForDownlink())
+		return c.result()
+	},
+	ie.RATType: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.RATType()
+	},
+	ie.ServingNetwork: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		return c.result()
+	},
+	ie.BearerTFT: func(i *ie.IE, _ *infoElement) (any, error) {
+		return v2TFT(i.BearerTFT())
+	},
+	ie.TrafficAggregateDescription: func(i *ie.IE, _ *infoElement) (any, error) {
+		return v2TFT(i.TrafficAggregateDescription())
+	},
+	ie.UserLocationInformation: func(i *ie.IE, _ *infoElement) (any, error) {
+		uli, err := i.UserLocationInformation()
+		if err != nil {
+			return nil, err
+		}
+		var f fields
+		if uli.HasCGI() {
+			f = append(f, field{"CGI", fields{{"MCC", uli.CGI.MCC}, {"MNC", uli.CGI.MNC}, {"LAC", uli.CGI.LAC}, {"CI", uli.CGI.CI}}})
+		}
+		if uli.HasSAI() {
+			f = append(f, field{"SAI", fields{{"MCC", uli.SAI.MCC}, {"MNC", uli.SAI.MNC}, {"LAC", uli.SAI.LAC}, {"SAC", uli.SAI.SAC}}})
+		}
+		if uli.HasRAI() {
+			f = append(f, field{"RAI", fields{{"MCC", uli.RAI.MCC}, {"MNC", uli.RAI.MNC}, {"LAC", uli.RAI.LAC}, {"RAC", uli.RAI.RAC}}})
+		}
+		if uli.HasTAI() {
+			f = append(f, field{"TAI", fields{{"MCC", uli.TAI.MCC}, {"MNC", uli.TAI.MNC}, {"TAC", uli.TAI.TAC}}})
+		}
+		if uli.HasECGI() {
+			f = append(f, field{"ECGI", fields{{"MCC", uli.ECGI.MCC}, {"MNC", uli.ECGI.MNC}, {"ECI", uli.ECGI.ECI}}})
+		}
+		if uli.HasLAI() {
+			f = append(f, field{"LAI", fields{{"MCC", uli.LAI.MCC}, {"MNC", uli.LAI.MNC}, {"LAC", uli.LAI.LAC}}})
+		}
+		if uli.HasMENBI() {
+			f = append(f, field{"MacroENBID", fields{{"MCC", uli.MENBI.MCC}, {"MNC", uli.MENBI.MNC}, {"ENBID", uli.MENBI.MENBI}}})
+		}
+		if uli.HasEMENBI() {
+			f = append(f, field{"ExtendedMacroENBID", fields{{"MCC", uli.EMENBI.MCC}, {"MNC", uli.EMENBI.MNC}, {"ENBID", uli.EMENBI.EMENBI}}})
+		}
+		return f, nil
+	},
+	ie.FullyQualifiedTEID: func(i *ie.IE, e *infoElement) (any, error) {
+		fteid, err := i.FullyQualifiedTEID()
+		if err != nil {
+			return nil, err
+		}
+		e.teids = append(e.teids, fteid.TEIDGREKey)
+		f := fields{{"InterfaceType", fteid.InterfaceType}, {"TEID", teid(fteid.TEIDGREKey)}}
+		f = appendNonNil(f, "IPv4", ip(fteid.IPv4Address))
+		f = appendNonNil(f, "IPv6", ip(fteid.IPv6Address))
+		return f, nil
+	},
+	ie.TMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.TMSI()
+	},
+	ie.GlobalCNID: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("CNID")(i.CNID())
+		return c.result()
+	},
+	ie.S103PDNDataForwardingInfo: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.S103PDNDataForwardingInfo()
+		if err != nil {
+			return nil, err
+		}
+		return fields{{"HSGWAddress", ip(f.HSGWAddressForForwarding)}, {"GREKey", f.GREKey}, {"EBIs", ints(f.EPSBearerIDs)}}, nil
+	},
+	ie.S1UDataForwarding: func(i *ie.IE, e *infoElement) (any, error) {
+		f, err := i.S1UDataForwarding()
+		if err != nil {
+			return nil, err
+		}
+		e.teids = append(e.teids, f.ServingGWS1UTEID)
+		return fields{{"EBI", f.EPSBearerID}, {"SGWAddress", ip(f.ServingGWAddress)}, {"TEID", teid(f.ServingGWS1UTEID)}}, nil
+	},
+	ie.DelayValue: func(i *ie.IE, _ *infoElement) (any, error) {
+		return duration(i.DelayValue())
+	},
+	ie.ChargingID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ChargingID()
+	},
+	ie.ChargingCharacteristics: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ChargingCharacteristics()
+	},
+	ie.BearerFlags: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.BearerFlags()
+	},
+	ie.PDNType: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PDNType()
+	},
+	ie.ProcedureTransactionID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ProcedureTransactionID()
+	},
+	ie.PacketTMSI: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PacketTMSI()
+	},
+	ie.PTMSISignature: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PTMSISignature()
+	},
+	ie.HopCounter: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.HopCounter()
+	},
+	ie.UETimeZone: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("TimeZone")(duration(i.TimeZone()))
+		c.add("DaylightSaving")(i.DaylightSaving())
+		return c.result()
+	},
+	ie.TraceReference: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("TraceID")(i.TraceID())
+		return c.result()
+	},
+	ie.GUTI: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("MCC")(i.MCC())
+		c.add("MNC")(i.MNC())
+		c.add("MMEGroupID")(i.MMEGroupID())
+		c.add("MMECode")(i.MMECode())
+		c.add("MTMSI")(i.MTMSI())
+		return c.result()
+	},
+	ie.PLMNID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PLMNID()
+	},
+	ie.PortNumber: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.PortNumber()
+	},
+	ie.APNRestriction: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.APNRestriction()
+	},
+	ie.SelectionMode: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.SelectionMode()
+	},
+	ie.FullyQualifiedCSID: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.FullyQualifiedCSID()
+		if err != nil {
+			return nil, err
+		}
+		var id any = hexBytes(f.NodeID)
+		if f.NodeIDType == 0 || f.NodeIDType == 1 {
+			id = ip(f.NodeID)
+		}
+		return fields{{"NodeIDType", f.NodeIDType}, {"NodeID", id}, {"CSIDs", f.CSIDs}}, nil
+	},
+	ie.NodeType: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.NodeType()
+	},
+	ie.FullyQualifiedDomainName: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.FullyQualifiedDomainName()
+	},
+	ie.RFSPIndex: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.RFSPIndex()
+	},
+	ie.UserCSGInformation: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.UserCSGInformation()
+		if err != nil {
+			return nil, err
+		}
+		return fields{{"MCC", f.MCC}, {"MNC", f.MNC}, {"CSGID", f.CSGID}, {"AccessMode", f.AccessMode}, {"Flags", f.Flags}}, nil
+	},
+	ie.CSGID: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.CSGID()
+	},
+	ie.CSGMembershipIndication: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.CMI()
+	},
+	ie.ServiceIndicator: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.ServiceIndicator()
+	},
+	ie.DetachType: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.DetachType()
+	},
+	ie.LocalDistinguishedName: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.LocalDistinguishedName()
+	},
+	ie.NodeFeatures: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.NodeFeatures()
+	},
+	ie.Throttling: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.Throttling()
+		if err != nil {
+			return nil, err
+		}
+		return fields{{"Delay", f.DelayValue.String()}, {"Factor", f.Factor}}, nil
+	},
+	ie.AllocationRetensionPriority: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("PriorityLevel")(i.PriorityLevel())
+		c.f = append(c.f,
+			field{"PreemptionCapability", i.PreemptionCapability()},
+			field{"PreemptionVulnerability", i.PreemptionVulnerability()},
+		)
+		return c.result()
+	},
+	ie.EPCTimer: func(i *ie.IE, _ *infoElement) (any, error) {
+		return duration(i.EPCTimer())
+	},
+	ie.ULITimestamp: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.Timestamp()
+	},
+	ie.MBMSFlags: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.MBMSFlags()
+	},
+	ie.RANNASCause: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.RANNASCause()
+		if err != nil {
+			return nil, err
+		}
+		return fields{{"ProtocolType", f.ProtocolType}, {"CauseType", f.CauseType}, {"Cause", hexBytes(f.Cause)}}, nil
+	},
+	ie.PagingAndServiceInformation: func(i *ie.IE, _ *infoElement) (any, error) {
+		f, err := i.PagingAndServiceInformation()
+		if err != nil {
+			return nil, err
+		}
+		return fields{{"EBI", f.EPSBearerID}, {"PagingPolicyIndication", f.PagingPolicyIndication}}, nil
+	},
+	ie.IntegerNumber: func(i *ie.IE, _ *infoElement) (any, error) {
+		return i.IntegerNumber()
+	},
+	ie.PrivateExtension: func(i *ie.IE, _ *infoElement) (any, error) {
+		c := &collector{}
+		c.add("EnterpriseID")(i.EnterpriseID())
+		v, err := i.PrivateExtension()
+		c.add("Value")(hexBytes(v), err)
+		return c.result()
+	},
+}
+
+// v2TFT returns the fields of TFT.
+func v2TFT(tft *ie.TrafficFlowTemplate, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	f := fields{{"OperationCode", tft.OperationCode}}
+	if len(tft.PacketFilters) > 0 {
+		pfs := make([]fields, len(tft.PacketFilters))
+		for n, pf := range tft.PacketFilters {
+			cs := make([]fields, len(pf.Components))
+			for m, c := range pf.Components {
+				cs[m] = fields{{"Type", c.Type}, {"Contents", hexBytes(c.Contents)}}
+			}
+			pfs[n] = fields{
+				{"Identifier", pf.Identifier},
+				{"Direction", pf.Direction},
+				{"EvaluationPrecedence", pf.EvaluationPrecedence},
+				{"Components", cs},
+			}
+		}
+		f = append(f, field{"PacketFilters", pfs})
+	}
+	if len(tft.PacketFilterIdentifiers) > 0 {
+		f = append(f, field{"PacketFilterIdentifiers", ints(tft.PacketFilterIdentifiers)})
+	}
+	if len(tft.Parameters) > 0 {
+		ps := make([]fields, len(tft.Parameters))
+		for n, p := range tft.Parameters {
+			ps[n] = fields{{"Identifier", p.Identifier}, {"Contents", hexBytes(p.Contents)}}
+		}
+		f = append(f, field{"Parameters", ps})
+	}
+	return f, nil
+}
@@ -98,5 +98,43 @@ func TestMessage(t *testing.T) {
 				t.Fatalf("got %v want %v", got, want)
 			}
 		})
+
+		t.Run("JSON/"+c.description, func(t *testing.T) {
+			doc, err := MarshalJSON(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := ParseJSON(doc)
+			if err != nil {
+				t.Fatalf("%s: %v", doc, err)
+			}
+			got, err := Marshal(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, c.serialized); diff != "" {
+				t.Error(diff)
+			}
+		})
+
+		t.Run("YAML/"+c.description, func(t *testing.T) {
+			doc, err := MarshalYAML(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := ParseYAML(doc)
+			if err != nil {
+				t.Fatalf("%s: %v", doc, err)
+			}
+			got, err := Marshal(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, c.serialized); diff != "" {
+				t.Error(diff)
+			}
+		})
 	}
 }
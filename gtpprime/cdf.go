@@ -0,0 +1,201 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+import (
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+)
+
+// PendingRequest is a Data Record Transfer Request sent by CDFConn that is not
+// responded yet.
+type PendingRequest struct {
+	Sequence uint16
+	PeerAddr net.Addr
+
+	// Command is the Packet Transfer Command of the request.
+	Command uint8
+
+	// Packet is the Data Record Packet IE sent, which is nil for the requests to
+	// release or cancel the packets.
+	Packet *ie.IE
+}
+
+// CDFConn is a Conn of the CDF(Charging Data Function), which sends the Data
+// Records to CGFs(Charging Gateway Functions) and keeps track of the requests
+// until they are responded.
+//
+// TS 32.295 6.3.4; when the CGF does not respond, the CDF sends the same Data
+// Record Packet to another CGF as possibly duplicated with
+// SendPossiblyDuplicated, and then tells the CGF to release or cancel it with
+// ReleaseDataRecordPackets or CancelDataRecordPackets, depending on whether
+// the original CGF turns out to have received the packet or not.
+type CDFConn struct {
+	*Conn
+
+	mu      sync.Mutex
+	pending map[uint16]*PendingRequest
+}
+
+// NewCDFConn creates a new CDFConn.
+//
+// The HandlerFunc for DataRecordTransferResponse calls ApplyDataRecordTransferResponse,
+// and the one for RedirectionRequest just accepts the request. Override them with
+// AddHandler to do something more, e.g., sending the pending Data Record Packets to
+// the recommended node on the Redirection Request.
+func NewCDFConn(laddr net.Addr, counter uint8, opts ...ConnOption) *CDFConn {
+	c := &CDFConn{
+		Conn:    NewConn(laddr, counter, opts...),
+		pending: map[uint16]*PendingRequest{},
+	}
+
+	c.AddHandlers(map[uint8]HandlerFunc{
+		message.MsgTypeDataRecordTransferResponse: func(_ *Conn, _ net.Addr, msg message.Message) error {
+			res, ok := msg.(*message.DataRecordTransferResponse)
+			if !ok {
+				return ErrUnexpectedType
+			}
+			_, err := c.ApplyDataRecordTransferResponse(res)
+			return err
+		},
+		message.MsgTypeRedirectionRequest: func(conn *Conn, senderAddr net.Addr, msg message.Message) error {
+			if _, ok := msg.(*message.RedirectionRequest); !ok {
+				return ErrUnexpectedType
+			}
+			return conn.RedirectionResponse(senderAddr, msg, CauseRequestAccepted)
+		},
+	})
+	return c
+}
+
+// send sends a Data Record Transfer Request with the Packet Transfer Command and
+// keeps it pending until it is responded.
+func (c *CDFConn) send(raddr net.Addr, cmd uint8, packet *ie.IE, ies ...*ie.IE) (uint16, error) {
+	req := message.NewDataRecordTransferRequest(0, append([]*ie.IE{ie.NewPacketTransferCommand(cmd), packet}, ies...)...)
+
+	// register the request before sending it so that the response never comes
+	// before it becomes pending. the Sequence Number is set by SendMessageTo.
+	seq := c.IncSequence()
+	req.SetSequenceNumber(seq)
+	c.mu.Lock()
+	c.pending[seq] = &PendingRequest{Sequence: seq, PeerAddr: raddr, Command: cmd, Packet: packet}
+	c.mu.Unlock()
+
+	b, err := message.Marshal(req)
+	if err == nil {
+		_, err = c.WriteTo(b, raddr)
+	}
+	if err != nil {
+		c.mu.Lock()
+		delete(c.pending, seq)
+		c.mu.Unlock()
+		return 0, err
+	}
+	return seq, nil
+}
+
+// SendDataRecordPacket sends the records to raddr with the Packet Transfer Command
+// "Send Data Record Packet", and returns the Sequence Number used.
+func (c *CDFConn) SendDataRecordPacket(raddr net.Addr, format uint8, version uint16, records ...[]byte) (uint16, error) {
+	return c.send(raddr, PacketTransferCommandSendDataRecordPacket, ie.NewDataRecordPacket(format, version, records...))
+}
+
+// SendPossiblyDuplicated sends the Data Record Packet in the pending request seq to
+// raddr with the Packet Transfer Command "Send possibly duplicated Data Record Packet",
+// and returns the Sequence Number used.
+//
+// The request seq is no longer pending after this, and the one to raddr becomes
+// pending instead. UnknownSequenceError is returned if seq is not pending or
+// not the one with Data Record Packet.
+func (c *CDFConn) SendPossiblyDuplicated(raddr net.Addr, seq uint16) (uint16, error) {
+	c.mu.Lock()
+	req, ok := c.pending[seq]
+	if !ok || req.Packet == nil {
+		c.mu.Unlock()
+		return 0, &UnknownSequenceError{Sequence: seq}
+	}
+	delete(c.pending, seq)
+	c.mu.Unlock()
+
+	newSeq, err := c.send(raddr, PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket, req.Packet)
+	if err != nil {
+		// keep it pending to be sent again.
+		c.mu.Lock()
+		c.pending[seq] = req
+		c.mu.Unlock()
+		return 0, err
+	}
+	return newSeq, nil
+}
+
+// ReleaseDataRecordPackets tells raddr to release the possibly duplicated Data
+// Record Packets sent with the Sequence Numbers seqs, as the CGF that they are
+// sent to originally has not received them. It returns the Sequence Number used.
+func (c *CDFConn) ReleaseDataRecordPackets(raddr net.Addr, seqs ...uint16) (uint16, error) {
+	return c.send(raddr, PacketTransferCommandReleaseDataRecordPacket, nil, ie.NewSequenceNumbersOfReleasedPackets(seqs...))
+}
+
+// CancelDataRecordPackets tells raddr to cancel the possibly duplicated Data
+// Record Packets sent with the Sequence Numbers seqs, as the CGF that they are
+// sent to originally has received them. It returns the Sequence Number used.
+func (c *CDFConn) CancelDataRecordPackets(raddr net.Addr, seqs ...uint16) (uint16, error) {
+	return c.send(raddr, PacketTransferCommandCancelDataRecordPacket, nil, ie.NewSequenceNumbersOfCancelledPackets(seqs...))
+}
+
+// ApplyDataRecordTransferResponse removes the requests responded in the
+// DataRecordTransferResponse from the pending ones, and returns their Sequence Numbers.
+//
+// The requests are kept pending if the Cause is not "Request accepted" or the ones
+// that tell the request has already been fulfilled, and CauseNotOKError is returned.
+func (c *CDFConn) ApplyDataRecordTransferResponse(res *message.DataRecordTransferResponse) ([]uint16, error) {
+	if err := checkCause(
+		res, res.Cause,
+		CauseRequestAccepted, CauseRequestAlreadyFulfilled, CausePossiblyDuplicatedRequestAlreadyFulfilled,
+	); err != nil {
+		return nil, err
+	}
+
+	// the Requests Responded IE is mandatory, but the Sequence Number in the
+	// header is the one of the request in any case.
+	seqs := []uint16{res.Sequence()}
+	if res.RequestsResponded != nil {
+		var err error
+		if seqs, err = res.RequestsResponded.RequestsResponded(); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var responded []uint16
+	for _, seq := range seqs {
+		if _, ok := c.pending[seq]; ok {
+			delete(c.pending, seq)
+			responded = append(responded, seq)
+		}
+	}
+	return responded, nil
+}
+
+// PendingRequests returns the Data Record Transfer Requests not responded yet,
+// in the order of the Sequence Numbers.
+func (c *CDFConn) PendingRequests() []*PendingRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reqs []*PendingRequest
+	for _, req := range c.pending {
+		reqs = append(reqs, req)
+	}
+	slices.SortFunc(reqs, func(a, b *PendingRequest) int {
+		return int(a.Sequence) - int(b.Sequence)
+	})
+	return reqs
+}
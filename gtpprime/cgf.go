@@ -0,0 +1,292 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+import (
+	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+)
+
+// recordsQueueSize is the number of DataRecords that can be queued to be read
+// by ReadDataRecords without blocking.
+const recordsQueueSize = 1024
+
+// fulfilledWindow is the number of the Sequence Numbers of the requests fulfilled
+// that CGFConn remembers per CDF to detect the requests sent again.
+const fulfilledWindow = 4096
+
+// DataRecords is a set of Data Records received by CGFConn in a Data Record Packet.
+type DataRecords struct {
+	PeerAddr net.Addr
+
+	// Sequence is the Sequence Number of the request that the Data Record Packet
+	// is sent in.
+	Sequence uint16
+
+	// PossiblyDuplicated is true if the Data Record Packet is sent as possibly
+	// duplicated and then released by the CDF. The records may have been received
+	// by another CGF.
+	PossiblyDuplicated bool
+
+	*ie.DataRecordPacketFields
+}
+
+// cdfPeer is the state of the requests from a CDF.
+type cdfPeer struct {
+	// fulfilled is the set of the Sequence Numbers of the requests fulfilled,
+	// and order is the same ones in the order of arrival to forget the old ones.
+	fulfilled map[uint16]struct{}
+	order     []uint16
+
+	// held is the possibly duplicated Data Record Packets waiting to be released
+	// or cancelled.
+	held map[uint16]*DataRecords
+}
+
+// fulfill marks the request seq as fulfilled, and returns false if it has already been.
+func (p *cdfPeer) fulfill(seq uint16) bool {
+	if _, ok := p.fulfilled[seq]; ok {
+		return false
+	}
+
+	p.fulfilled[seq] = struct{}{}
+	p.order = append(p.order, seq)
+	if len(p.order) > fulfilledWindow {
+		delete(p.fulfilled, p.order[0])
+		p.order = p.order[1:]
+	}
+	return true
+}
+
+// CGFConn is a Conn of the CGF(Charging Gateway Function), which receives the
+// Data Records from CDFs(Charging Data Functions).
+//
+// The Data Records received are read with ReadDataRecords. The request sent again
+// with the same Sequence Number is responded without delivering the records twice,
+// and the possibly duplicated Data Record Packets are held until the CDF tells to
+// release or cancel them.
+type CGFConn struct {
+	*Conn
+
+	mu        sync.Mutex
+	peers     map[string]*cdfPeer
+	recordsCh chan *DataRecords
+}
+
+// NewCGFConn creates a new CGFConn.
+//
+// The HandlerFunc for DataRecordTransferRequest is registered, which responds to
+// the request after the records are queued to be read by ReadDataRecords.
+func NewCGFConn(laddr net.Addr, counter uint8, opts ...ConnOption) *CGFConn {
+	c := &CGFConn{
+		Conn:      NewConn(laddr, counter, opts...),
+		peers:     map[string]*cdfPeer{},
+		recordsCh: make(chan *DataRecords, recordsQueueSize),
+	}
+
+	c.AddHandler(message.MsgTypeDataRecordTransferRequest, func(_ *Conn, senderAddr net.Addr, msg message.Message) error {
+		req, ok := msg.(*message.DataRecordTransferRequest)
+		if !ok {
+			return ErrUnexpectedType
+		}
+		return c.handleDataRecordTransferRequest(senderAddr, req)
+	})
+	return c
+}
+
+// ReadDataRecords returns the Data Records received.
+func (c *CGFConn) ReadDataRecords() (*DataRecords, error) {
+	select {
+	case <-c.closed():
+		return nil, ErrConnNotOpened
+	case r := <-c.recordsCh:
+		return r, nil
+	}
+}
+
+// HeldDataRecords returns the possibly duplicated Data Records from raddr that
+// are waiting to be released or cancelled.
+func (c *CGFConn) HeldDataRecords(raddr net.Addr) []*DataRecords {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.peers[raddr.String()]
+	if !ok {
+		return nil
+	}
+
+	var records []*DataRecords
+	for _, r := range p.held {
+		records = append(records, r)
+	}
+	return records
+}
+
+func (c *CGFConn) peer(raddr net.Addr) *cdfPeer {
+	p, ok := c.peers[raddr.String()]
+	if !ok {
+		p = &cdfPeer{fulfilled: map[uint16]struct{}{}, held: map[uint16]*DataRecords{}}
+		c.peers[raddr.String()] = p
+	}
+	return p
+}
+
+// deliver queues the records to be read by ReadDataRecords, and blocks until it
+// is queued or Conn is closed.
+func (c *CGFConn) deliver(records ...*DataRecords) error {
+	for _, r := range records {
+		select {
+		case c.recordsCh <- r:
+		case <-c.closed():
+			return ErrConnNotOpened
+		}
+	}
+	return nil
+}
+
+func (c *CGFConn) respond(raddr net.Addr, req *message.DataRecordTransferRequest, cause uint8) error {
+	return c.RespondTo(raddr, req, message.NewDataRecordTransferResponse(0,
+		ie.NewCause(cause),
+		ie.NewRequestsResponded(req.Sequence()),
+	))
+}
+
+func (c *CGFConn) handleDataRecordTransferRequest(raddr net.Addr, req *message.DataRecordTransferRequest) error {
+	if req.PacketTransferCommand == nil {
+		if err := c.respond(raddr, req, CauseMandatoryIEMissing); err != nil {
+			return err
+		}
+		return &RequiredIEMissingError{Type: ie.PacketTransferCommand}
+	}
+	cmd, err := req.PacketTransferCommand.PacketTransferCommand()
+	if err != nil {
+		if rerr := c.respond(raddr, req, CauseMandatoryIEIncorrect); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+
+	switch cmd {
+	case PacketTransferCommandSendDataRecordPacket, PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket:
+		return c.handleSend(raddr, req, cmd)
+	case PacketTransferCommandReleaseDataRecordPacket:
+		return c.handleRelease(raddr, req, true)
+	case PacketTransferCommandCancelDataRecordPacket:
+		return c.handleRelease(raddr, req, false)
+	default:
+		return c.respond(raddr, req, CauseServiceNotSupported)
+	}
+}
+
+// handleSend delivers the Data Record Packet in the request, or holds it if it is
+// possibly duplicated.
+func (c *CGFConn) handleSend(raddr net.Addr, req *message.DataRecordTransferRequest, cmd uint8) error {
+	if req.DataRecordPacket == nil {
+		if err := c.respond(raddr, req, CauseMandatoryIEMissing); err != nil {
+			return err
+		}
+		return &RequiredIEMissingError{Type: ie.DataRecordPacket}
+	}
+	fields, err := req.DataRecordPacket.DataRecordPacket()
+	if err != nil {
+		if rerr := c.respond(raddr, req, CauseMandatoryIEIncorrect); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+	records := &DataRecords{PeerAddr: raddr, Sequence: req.Sequence(), DataRecordPacketFields: fields}
+
+	c.mu.Lock()
+	p := c.peer(raddr)
+	if !p.fulfill(req.Sequence()) {
+		c.mu.Unlock()
+		if cmd == PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket {
+			return c.respond(raddr, req, CausePossiblyDuplicatedRequestAlreadyFulfilled)
+		}
+		return c.respond(raddr, req, CauseRequestAlreadyFulfilled)
+	}
+	if cmd == PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket {
+		records.PossiblyDuplicated = true
+		p.held[req.Sequence()] = records
+		c.mu.Unlock()
+		return c.respond(raddr, req, CauseRequestAccepted)
+	}
+	c.mu.Unlock()
+
+	if err := c.deliver(records); err != nil {
+		return err
+	}
+	return c.respond(raddr, req, CauseRequestAccepted)
+}
+
+// handleRelease delivers (if release is true) or discards the possibly duplicated
+// Data Record Packets held.
+//
+// The request is rejected with the Cause "Sequence numbers of released/cancelled
+// packets IE incorrect" if any of them is not held, after the others are processed.
+func (c *CGFConn) handleRelease(raddr net.Addr, req *message.DataRecordTransferRequest, release bool) error {
+	seqIE, seqType := req.SequenceNumbersOfCancelledPackets, ie.SequenceNumbersOfCancelledPackets
+	if release {
+		seqIE, seqType = req.SequenceNumbersOfReleasedPackets, ie.SequenceNumbersOfReleasedPackets
+	}
+	if seqIE == nil {
+		if err := c.respond(raddr, req, CauseMandatoryIEMissing); err != nil {
+			return err
+		}
+		return &RequiredIEMissingError{Type: seqType}
+	}
+	var (
+		seqs []uint16
+		err  error
+	)
+	if release {
+		seqs, err = seqIE.SequenceNumbersOfReleasedPackets()
+	} else {
+		seqs, err = seqIE.SequenceNumbersOfCancelledPackets()
+	}
+	if err != nil {
+		if rerr := c.respond(raddr, req, CauseMandatoryIEIncorrect); rerr != nil {
+			return rerr
+		}
+		return err
+	}
+
+	c.mu.Lock()
+	p := c.peer(raddr)
+	if !p.fulfill(req.Sequence()) {
+		c.mu.Unlock()
+		return c.respond(raddr, req, CauseRequestAlreadyFulfilled)
+	}
+	var (
+		released []*DataRecords
+		unknown  error
+	)
+	for _, seq := range seqs {
+		r, ok := p.held[seq]
+		if !ok {
+			unknown = &UnknownSequenceError{Sequence: seq}
+			continue
+		}
+		delete(p.held, seq)
+		if release {
+			released = append(released, r)
+		}
+	}
+	c.mu.Unlock()
+
+	if err := c.deliver(released...); err != nil {
+		return err
+	}
+	if unknown != nil {
+		if err := c.respond(raddr, req, CauseSequenceNumbersIncorrect); err != nil {
+			return err
+		}
+		return unknown
+	}
+	return c.respond(raddr, req, CauseRequestAccepted)
+}
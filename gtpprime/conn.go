@@ -0,0 +1,340 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+// Conn represents a GTP' connection, which is the common part of CDFConn and
+// CGFConn.
+//
+// Conn handles Echo and Node Alive by default, and the other messages by adding
+// handlers to it with AddHandler(s).
+type Conn struct {
+	mu      sync.Mutex
+	laddr   net.Addr
+	pktConn net.PacketConn
+	logger  *slog.Logger
+
+	closeCh chan struct{}
+	*msgHandlerMap
+
+	// sequence is the last SequenceNumber used in the request.
+	sequence uint16
+
+	// RestartCounter is the RestartCounter value in Recovery IE, which represents how many
+	// times the node is restarted.
+	RestartCounter uint8
+}
+
+// ConnOption is an option to configure Conn at NewConn, NewCDFConn or NewCGFConn.
+type ConnOption func(c *Conn)
+
+// NewConn creates a new Conn. Use NewCDFConn or NewCGFConn instead to transfer
+// the Data Records.
+func NewConn(laddr net.Addr, counter uint8, opts ...ConnOption) *Conn {
+	c := &Conn{
+		mu:             sync.Mutex{},
+		laddr:          laddr,
+		closeCh:        make(chan struct{}),
+		msgHandlerMap:  newDefaultMsgHandlerMap(),
+		RestartCounter: counter,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.logger = connlog.New(c.logger, logProto, laddr)
+	return c
+}
+
+// ListenAndServe creates a new GTP' Conn and start serving background.
+func (c *Conn) ListenAndServe(ctx context.Context) error {
+	if err := c.Listen(ctx); err != nil {
+		return err
+	}
+	return c.Serve(ctx)
+}
+
+// Listen creates a new GTP' Conn.
+//
+// Calling Listen and then Serve in another goroutine enables sending messages
+// right after Listen returns, without waiting for Serve to start.
+func (c *Conn) Listen(ctx context.Context) error {
+	var err error
+	c.mu.Lock()
+	c.pktConn, err = net.ListenPacket(c.laddr.Network(), c.laddr.String())
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Conn) closed() <-chan struct{} {
+	return c.closeCh
+}
+
+// Serve starts serving GTP' connection.
+func (c *Conn) Serve(ctx context.Context) error {
+	go func() {
+		select { // ctx is canceled or Close() is called
+		case <-ctx.Done():
+		case <-c.closed():
+		}
+
+		if err := c.pktConn.Close(); err != nil {
+			c.logger.Warn("error closing the underlying conn", logkey.Error, err)
+		}
+	}()
+
+	buf := make([]byte, 65535)
+	for {
+		n, raddr, err := c.pktConn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("error reading from Conn %s: %w", c.LocalAddr(), err)
+		}
+
+		raw := make([]byte, n)
+		copy(raw, buf)
+		go func() {
+			msg, err := message.Parse(raw)
+			if err != nil {
+				c.logger.Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, err, "raw", fmt.Sprintf("%x", raw))
+				return
+			}
+
+			if err := c.handleMessage(raddr, msg); err != nil {
+				c.logger.Warn("error handling the message", append(msgAttrs(raddr, msg), logkey.Error, err)...)
+			}
+		}()
+	}
+}
+
+// ReadFrom reads a packet from the connection,
+// copying the payload into p. It returns the number of
+// bytes copied into p and the return address that
+// was on the packet.
+// It returns the number of bytes read (0 <= n <= len(p))
+// and any error encountered. Callers should always process
+// the n > 0 bytes returned before considering the error err.
+// ReadFrom can be made to time out and return
+// an Error with Timeout() == true after a fixed time limit;
+// see SetDeadline and SetReadDeadline.
+func (c *Conn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
+	return c.pktConn.ReadFrom(p)
+}
+
+// WriteTo writes a packet with payload p to addr.
+// WriteTo can be made to time out and return
+// an Error with Timeout() == true after a fixed time limit;
+// see SetDeadline and SetWriteDeadline.
+// On packet-oriented connections, write timeouts are rare.
+func (c *Conn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
+	return c.pktConn.WriteTo(p, addr)
+}
+
+// Close closes the connection.
+// Any blocked Read or Write operations will be unblocked and return errors.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	close(c.closeCh)
+
+	return nil
+}
+
+// LocalAddr returns the local network address.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.pktConn.LocalAddr()
+}
+
+// SetDeadline sets the read and write deadlines associated
+// with the connection. It is equivalent to calling both
+// SetReadDeadline and SetWriteDeadline.
+//
+// A zero value for t means I/O operations will not time out.
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.pktConn.SetDeadline(t)
+}
+
+// SetReadDeadline sets the deadline for future Read calls
+// and any currently-blocked Read call.
+// A zero value for t means Read will not time out.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.pktConn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for future Write calls
+// and any currently-blocked Write call.
+// A zero value for t means Write will not time out.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.pktConn.SetWriteDeadline(t)
+}
+
+// AddHandler adds a message handler to Conn.
+//
+// By adding HandlerFunc, Conn will handle the specified type of message with it's
+// paired HandlerFunc when receiving. Messages without registered handlers are just
+// ignored and logged.
+//
+// HandlerFunc for EchoRequest, EchoResponse, NodeAliveRequest and NodeAliveResponse
+// are registered by default, and so are the ones for Data Record Transfer by
+// NewCDFConn and NewCGFConn. These HandlerFunc can be overridden by specifying the
+// message type.
+func (c *Conn) AddHandler(msgType uint8, fn HandlerFunc) {
+	c.msgHandlerMap.store(msgType, fn)
+}
+
+// AddHandlers adds multiple handler funcs at a time, using a map.
+// The key of the map is message type of the GTP' message.
+//
+// See AddHandler for how the given handlers behave.
+func (c *Conn) AddHandlers(funcs map[uint8]HandlerFunc) {
+	for msgType, fn := range funcs {
+		c.msgHandlerMap.store(msgType, fn)
+	}
+}
+
+func (c *Conn) handleMessage(senderAddr net.Addr, msg message.Message) error {
+	handle, ok := c.msgHandlerMap.load(msg.MessageType())
+	if !ok {
+		return &HandlerNotFoundError{MsgType: msg.MessageTypeName()}
+	}
+
+	if err := handle(c, senderAddr, msg); err != nil {
+		return fmt.Errorf("failed to handle %s: %w", msg.MessageTypeName(), err)
+	}
+	return nil
+}
+
+// SendMessageTo sends a message to addr.
+// Unlike WriteTo, it sets the Sequence Number properly and returns the one used in the message.
+func (c *Conn) SendMessageTo(msg message.Message, addr net.Addr) (uint16, error) {
+	seq := c.IncSequence()
+	msg.SetSequenceNumber(seq)
+
+	payload, err := message.Marshal(msg)
+	if err != nil {
+		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
+	}
+
+	if _, err := c.WriteTo(payload, addr); err != nil {
+		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
+	}
+	return seq, nil
+}
+
+// IncSequence increments the SequenceNumber associated with Conn.
+func (c *Conn) IncSequence() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sequence++
+	return c.sequence
+}
+
+// SequenceNumber returns the current(=last used) SequenceNumber associated with Conn.
+func (c *Conn) SequenceNumber() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sequence
+}
+
+// RespondTo sends a message(specified with "toBeSent" param) in response to a message
+// (specified with "received" param), with the Sequence Number copied from the one
+// received.
+func (c *Conn) RespondTo(raddr net.Addr, received, toBeSent message.Message) error {
+	toBeSent.SetSequenceNumber(received.Sequence())
+
+	b, err := message.Marshal(toBeSent)
+	if err != nil {
+		return err
+	}
+	if _, err := c.WriteTo(b, raddr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EchoRequest sends a EchoRequest.
+func (c *Conn) EchoRequest(raddr net.Addr) (uint16, error) {
+	return c.SendMessageTo(message.NewEchoRequest(0), raddr)
+}
+
+// EchoResponse sends a EchoResponse in response to the EchoRequest.
+func (c *Conn) EchoResponse(raddr net.Addr, req message.Message) error {
+	return c.RespondTo(raddr, req, message.NewEchoResponse(0, ie.NewRecovery(c.RestartCounter)))
+}
+
+// NodeAliveRequest sends a NodeAliveRequest to tell raddr that the node is ready
+// to work, e.g., after restarting.
+//
+// The Node Address is the IP address of Conn if not given in ies.
+func (c *Conn) NodeAliveRequest(raddr net.Addr, ies ...*ie.IE) (uint16, error) {
+	req := message.NewNodeAliveRequest(0, ies...)
+	if req.NodeAddress == nil {
+		if udpAddr, ok := c.LocalAddr().(*net.UDPAddr); ok {
+			req.NodeAddress = ie.NewChargingGatewayAddress(udpAddr.IP.String())
+		}
+	}
+	return c.SendMessageTo(req, raddr)
+}
+
+// NodeAliveResponse sends a NodeAliveResponse in response to the NodeAliveRequest.
+func (c *Conn) NodeAliveResponse(raddr net.Addr, req message.Message) error {
+	return c.RespondTo(raddr, req, message.NewNodeAliveResponse(0))
+}
+
+// RedirectionRequest sends a RedirectionRequest to tell raddr to send the Data
+// Records to another node, with the reason in cause.
+//
+// The Address of Recommended Node should be given in ies if any.
+func (c *Conn) RedirectionRequest(raddr net.Addr, cause uint8, ies ...*ie.IE) (uint16, error) {
+	return c.SendMessageTo(message.NewRedirectionRequest(0, append([]*ie.IE{ie.NewCause(cause)}, ies...)...), raddr)
+}
+
+// RedirectionResponse sends a RedirectionResponse in response to the RedirectionRequest.
+func (c *Conn) RedirectionResponse(raddr net.Addr, req message.Message, cause uint8) error {
+	return c.RespondTo(raddr, req, message.NewRedirectionResponse(0, ie.NewCause(cause)))
+}
+
+// checkCause returns CauseNotOKError if the Cause in i is not one of ok.
+func checkCause(msg message.Message, i *ie.IE, ok ...uint8) error {
+	if i == nil {
+		return &RequiredIEMissingError{Type: ie.Cause}
+	}
+	cause, err := i.Cause()
+	if err != nil {
+		return err
+	}
+	for _, c := range ok {
+		if cause == c {
+			return nil
+		}
+	}
+	return &CauseNotOKError{MsgType: msg.MessageTypeName(), Cause: cause}
+}
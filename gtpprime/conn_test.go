@@ -0,0 +1,216 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpprime"
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+)
+
+type result struct {
+	seqs []uint16
+	err  error
+}
+
+func mustAddr(t *testing.T, ip string) net.Addr {
+	t.Helper()
+
+	addr, err := net.ResolveUDPAddr("udp", ip+gtpprime.GTPPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func setup(ctx context.Context, t *testing.T) (cdf *gtpprime.CDFConn, cgf *gtpprime.CGFConn, resCh chan result) {
+	t.Helper()
+
+	cgf = gtpprime.NewCGFConn(mustAddr(t, "127.0.0.26"), 0)
+	if err := cgf.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := cgf.Serve(ctx); err != nil {
+			return
+		}
+	}()
+
+	resCh = make(chan result, 1)
+	cdf = gtpprime.NewCDFConn(mustAddr(t, "127.0.0.25"), 0)
+	cdf.AddHandler(message.MsgTypeDataRecordTransferResponse, func(c *gtpprime.Conn, senderAddr net.Addr, msg message.Message) error {
+		seqs, err := cdf.ApplyDataRecordTransferResponse(msg.(*message.DataRecordTransferResponse))
+		resCh <- result{seqs, err}
+		return err
+	})
+	if err := cdf.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := cdf.Serve(ctx); err != nil {
+			return
+		}
+	}()
+
+	return cdf, cgf, resCh
+}
+
+func wait(t *testing.T, resCh chan result) result {
+	t.Helper()
+
+	select {
+	case r := <-resCh:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	return result{}
+}
+
+func read(t *testing.T, cgf *gtpprime.CGFConn) *gtpprime.DataRecords {
+	t.Helper()
+
+	ch := make(chan *gtpprime.DataRecords, 1)
+	go func() {
+		r, err := cgf.ReadDataRecords()
+		if err != nil {
+			return
+		}
+		ch <- r
+	}()
+
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	return nil
+}
+
+func TestDataRecordTransfer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cdf, cgf, resCh := setup(ctx, t)
+	defer cdf.Close()
+	defer cgf.Close()
+
+	var (
+		cgfAddr  = mustAddr(t, "127.0.0.26")
+		downAddr = mustAddr(t, "127.0.0.27") // CGF not responding
+		records  = [][]byte{{0xde, 0xad}, {0xbe, 0xef}}
+	)
+
+	t.Run("send", func(t *testing.T) {
+		seq, err := cdf.SendDataRecordPacket(cgfAddr, gtpprime.DataRecordFormatBER, 0x0a08, records...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := read(t, cgf)
+		want := &gtpprime.DataRecords{
+			PeerAddr:               mustAddr(t, "127.0.0.25"),
+			Sequence:               seq,
+			DataRecordPacketFields: ie.NewDataRecordPacketFields(gtpprime.DataRecordFormatBER, 0x0a08, records...),
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+
+		r := wait(t, resCh)
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		if diff := cmp.Diff(r.seqs, []uint16{seq}); diff != "" {
+			t.Error(diff)
+		}
+
+		// the same request sent again is not delivered twice.
+		req := message.NewDataRecordTransferRequest(seq,
+			ie.NewPacketTransferCommand(gtpprime.PacketTransferCommandSendDataRecordPacket),
+			ie.NewDataRecordPacket(gtpprime.DataRecordFormatBER, 0x0a08, records...),
+		)
+		b, err := req.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cdf.WriteTo(b, cgfAddr); err != nil {
+			t.Fatal(err)
+		}
+		if r := wait(t, resCh); r.err != nil || len(r.seqs) != 0 {
+			t.Errorf("unexpected result: %v", r)
+		}
+	})
+
+	for _, release := range []bool{true, false} {
+		name := map[bool]string{true: "release", false: "cancel"}[release]
+		t.Run("possibly-duplicated/"+name, func(t *testing.T) {
+			seq, err := cdf.SendDataRecordPacket(downAddr, gtpprime.DataRecordFormatBER, 0x0a08, records...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dupSeq, err := cdf.SendPossiblyDuplicated(cgfAddr, seq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := wait(t, resCh); r.err != nil {
+				t.Fatal(r.err)
+			}
+			if got := cdf.PendingRequests(); len(got) != 0 {
+				t.Errorf("unexpected pending requests: %v", got)
+			}
+			if got := cgf.HeldDataRecords(mustAddr(t, "127.0.0.25")); len(got) != 1 || got[0].Sequence != dupSeq {
+				t.Errorf("unexpected held records: %v", got)
+			}
+
+			if release {
+				_, err = cdf.ReleaseDataRecordPackets(cgfAddr, dupSeq)
+			} else {
+				_, err = cdf.CancelDataRecordPackets(cgfAddr, dupSeq)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := wait(t, resCh); r.err != nil {
+				t.Fatal(r.err)
+			}
+			if got := cgf.HeldDataRecords(mustAddr(t, "127.0.0.25")); len(got) != 0 {
+				t.Errorf("unexpected held records: %v", got)
+			}
+
+			if release {
+				got := read(t, cgf)
+				if !got.PossiblyDuplicated || got.Sequence != dupSeq {
+					t.Errorf("unexpected records: %v", got)
+				}
+			}
+		})
+	}
+
+	t.Run("cancel-unknown", func(t *testing.T) {
+		seq, err := cdf.CancelDataRecordPackets(cgfAddr, 0xffff)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := wait(t, resCh)
+		var cerr *gtpprime.CauseNotOKError
+		if !errors.As(r.err, &cerr) || cerr.Cause != gtpprime.CauseSequenceNumbersIncorrect {
+			t.Errorf("unexpected error: %v", r.err)
+		}
+		if got := cdf.PendingRequests(); len(got) != 1 || got[0].Sequence != seq {
+			t.Errorf("unexpected pending requests: %v", got)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+// GTPPort is the port number used for GTP' by default.
+const GTPPort = ":3386"
+
+// Cause definitions.
+//
+// The Causes below 128 are used in the requests, and the others in the responses.
+const (
+	CauseNodeSystemFailure                         uint8 = 59
+	CauseTransmitBuffersBecomingFull               uint8 = 60
+	CauseReceiveBuffersBecomingFull                uint8 = 61
+	CauseAnotherNodeAboutToGoDown                  uint8 = 62
+	CauseThisNodeAboutToGoDown                     uint8 = 63
+	CauseRequestAccepted                           uint8 = 128
+	CauseCDRDecodingError                          uint8 = 177
+	CauseInvalidMessageFormat                      uint8 = 193
+	CauseVersionNotSupported                       uint8 = 198
+	CauseNoResourcesAvailable                      uint8 = 199
+	CauseServiceNotSupported                       uint8 = 200
+	CauseMandatoryIEIncorrect                      uint8 = 201
+	CauseMandatoryIEMissing                        uint8 = 202
+	CauseOptionalIEIncorrect                       uint8 = 203
+	CauseSystemFailure                             uint8 = 204
+	CausePossiblyDuplicatedRequestAlreadyFulfilled uint8 = 252
+	CauseRequestAlreadyFulfilled                   uint8 = 253
+	CauseSequenceNumbersIncorrect                  uint8 = 254
+	CauseRequestNotFulfilled                       uint8 = 255
+)
+
+// Packet Transfer Command definitions.
+const (
+	_ uint8 = iota
+	PacketTransferCommandSendDataRecordPacket
+	PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket
+	PacketTransferCommandCancelDataRecordPacket
+	PacketTransferCommandReleaseDataRecordPacket
+)
+
+// Data Record Format definitions.
+const (
+	_ uint8 = iota
+	DataRecordFormatBER
+	DataRecordFormatUnalignedPER
+	DataRecordFormatAlignedPER
+)
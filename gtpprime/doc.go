@@ -0,0 +1,12 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package gtpprime provides simple and painless handling of GTP' protocol (TS 32.295) in pure Golang.
+//
+// CDFConn sends the Data Records to the CGFs and keeps track of the requests until they are
+// responded, and CGFConn receives them with the duplicated and possibly duplicated packets handled.
+// Please see README.md for detailed usage of the APIs provided by this package.
+//
+// https://github.com/wmnsk/go-gtp/blob/main/gtpprime/README.md
+package gtpprime
@@ -0,0 +1,64 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnexpectedType indicates that the type of incoming message is not expected.
+	ErrUnexpectedType = errors.New("got unexpected type of message")
+
+	// ErrConnNotOpened indicates that some operation is failed due to the status of
+	// Conn is not valid.
+	ErrConnNotOpened = errors.New("connection is not opened")
+)
+
+// CauseNotOKError indicates that the value in Cause IE is not OK.
+type CauseNotOKError struct {
+	MsgType string
+	Cause   uint8
+}
+
+// Error returns error cause with message.
+func (e *CauseNotOKError) Error() string {
+	return fmt.Sprintf("got non-OK Cause: %d in %s", e.Cause, e.MsgType)
+}
+
+// RequiredIEMissingError indicates that the IE required is missing.
+type RequiredIEMissingError struct {
+	Type uint8
+}
+
+// Error returns error with missing IE type.
+func (e *RequiredIEMissingError) Error() string {
+	return fmt.Sprintf("required IE missing: %d", e.Type)
+}
+
+// UnknownSequenceError indicates that the Sequence Number is not the one of the
+// Data Record Packets pending or held.
+type UnknownSequenceError struct {
+	Sequence uint16
+}
+
+// Error returns the Sequence Number that is unknown.
+func (e *UnknownSequenceError) Error() string {
+	return fmt.Sprintf("unknown Sequence Number: %#04x", e.Sequence)
+}
+
+// HandlerNotFoundError indicates that the handler func is not registered in *Conn
+// for the incoming GTP' message. In usual cases this error should not be taken
+// as fatal, as the other endpoint can make your program stop working just by
+// sending unregistered message.
+type HandlerNotFoundError struct {
+	MsgType string
+}
+
+// Error returns violating message type to handle.
+func (e *HandlerNotFoundError) Error() string {
+	return fmt.Sprintf("no handlers found for incoming message: %s, ignoring", e.MsgType)
+}
@@ -0,0 +1,96 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpprime
+
+import (
+	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+)
+
+// HandlerFunc is a handler for specific GTP' message.
+type HandlerFunc func(c *Conn, senderAddr net.Addr, msg message.Message) error
+
+type msgHandlerMap struct {
+	syncMap sync.Map
+}
+
+func (m *msgHandlerMap) store(msgType uint8, handler HandlerFunc) {
+	m.syncMap.Store(msgType, handler)
+}
+
+func (m *msgHandlerMap) load(msgType uint8) (HandlerFunc, bool) {
+	handler, ok := m.syncMap.Load(msgType)
+	if !ok {
+		return nil, false
+	}
+
+	return handler.(HandlerFunc), true
+}
+
+func newMsgHandlerMap(m map[uint8]HandlerFunc) *msgHandlerMap {
+	mhm := &msgHandlerMap{syncMap: sync.Map{}}
+	for k, v := range m {
+		mhm.store(k, v)
+	}
+
+	return mhm
+}
+
+func newDefaultMsgHandlerMap() *msgHandlerMap {
+	return newMsgHandlerMap(
+		map[uint8]HandlerFunc{
+			message.MsgTypeEchoRequest:       handleEchoRequest,
+			message.MsgTypeEchoResponse:      handleEchoResponse,
+			message.MsgTypeNodeAliveRequest:  handleNodeAliveRequest,
+			message.MsgTypeNodeAliveResponse: handleNodeAliveResponse,
+		},
+	)
+}
+
+func handleEchoRequest(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.EchoRequest); !ok {
+		return ErrUnexpectedType
+	}
+
+	// respond with EchoResponse.
+	return c.EchoResponse(senderAddr, msg)
+}
+
+func handleEchoResponse(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.EchoResponse); !ok {
+		return ErrUnexpectedType
+	}
+
+	// do nothing.
+	return nil
+}
+
+func handleNodeAliveRequest(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.NodeAliveRequest); !ok {
+		return ErrUnexpectedType
+	}
+
+	// respond with NodeAliveResponse.
+	return c.NodeAliveResponse(senderAddr, msg)
+}
+
+func handleNodeAliveResponse(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.NodeAliveResponse); !ok {
+		return ErrUnexpectedType
+	}
+
+	// do nothing.
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"io"
+	"net"
+)
+
+// NewAddressOfRecommendedNode creates a new AddressOfRecommendedNode IE from string.
+func NewAddressOfRecommendedNode(addr string) *IE {
+	ip := net.ParseIP(addr)
+	v4 := ip.To4()
+
+	// IPv4
+	if v4 != nil {
+		return New(AddressOfRecommendedNode, v4)
+	}
+	// IPv6
+	return New(AddressOfRecommendedNode, ip)
+}
+
+// AddressOfRecommendedNode returns AddressOfRecommendedNode value if type matches.
+func (i *IE) AddressOfRecommendedNode() (string, error) {
+	if i.Type != AddressOfRecommendedNode {
+		return "", &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) < 4 {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	return net.IP(i.Payload).String(), nil
+}
+
+// MustAddressOfRecommendedNode returns AddressOfRecommendedNode in string if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustAddressOfRecommendedNode() string {
+	v, _ := i.AddressOfRecommendedNode()
+	return v
+}
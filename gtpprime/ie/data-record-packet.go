@@ -0,0 +1,142 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// NewDataRecordPacket creates a new DataRecordPacket IE that contains the
+// records encoded in the format and the format version given.
+func NewDataRecordPacket(format uint8, version uint16, records ...[]byte) *IE {
+	v := NewDataRecordPacketFields(format, version, records...)
+	b, err := v.Marshal()
+	if err != nil {
+		return nil
+	}
+
+	return New(DataRecordPacket, b)
+}
+
+// DataRecordPacket returns DataRecordPacket in DataRecordPacketFields type if the type of IE matches.
+func (i *IE) DataRecordPacket() (*DataRecordPacketFields, error) {
+	switch i.Type {
+	case DataRecordPacket:
+		return ParseDataRecordPacketFields(i.Payload)
+	default:
+		return nil, &InvalidTypeError{Type: i.Type}
+	}
+}
+
+// MustDataRecordPacket returns DataRecordPacket in *DataRecordPacketFields if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustDataRecordPacket() *DataRecordPacketFields {
+	v, _ := i.DataRecordPacket()
+	return v
+}
+
+// DataRecordPacketFields is a set of fields in DataRecordPacket IE.
+//
+// The Number of Data Records and the length of each record are not in the fields,
+// as they are derived from Records.
+type DataRecordPacketFields struct {
+	DataRecordFormat        uint8
+	DataRecordFormatVersion uint16
+	Records                 [][]byte
+}
+
+// NewDataRecordPacketFields creates a new DataRecordPacketFields.
+func NewDataRecordPacketFields(format uint8, version uint16, records ...[]byte) *DataRecordPacketFields {
+	return &DataRecordPacketFields{
+		DataRecordFormat:        format,
+		DataRecordFormatVersion: version,
+		Records:                 records,
+	}
+}
+
+// Marshal serializes DataRecordPacketFields.
+func (f *DataRecordPacketFields) Marshal() ([]byte, error) {
+	b := make([]byte, f.MarshalLen())
+	if err := f.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo serializes DataRecordPacketFields.
+func (f *DataRecordPacketFields) MarshalTo(b []byte) error {
+	if len(b) < f.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+	if len(f.Records) > 0xff {
+		return ErrMalformed
+	}
+
+	b[0] = uint8(len(f.Records))
+	b[1] = f.DataRecordFormat
+	binary.BigEndian.PutUint16(b[2:4], f.DataRecordFormatVersion)
+
+	offset := 4
+	for _, r := range f.Records {
+		if len(r) > 0xffff {
+			return ErrMalformed
+		}
+		binary.BigEndian.PutUint16(b[offset:offset+2], uint16(len(r)))
+		copy(b[offset+2:], r)
+		offset += 2 + len(r)
+	}
+
+	return nil
+}
+
+// ParseDataRecordPacketFields decodes DataRecordPacketFields.
+func ParseDataRecordPacketFields(b []byte) (*DataRecordPacketFields, error) {
+	f := &DataRecordPacketFields{}
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// UnmarshalBinary decodes given bytes into DataRecordPacketFields.
+func (f *DataRecordPacketFields) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+
+	n := int(b[0])
+	f.DataRecordFormat = b[1]
+	f.DataRecordFormatVersion = binary.BigEndian.Uint16(b[2:4])
+
+	f.Records = make([][]byte, n)
+	offset := 4
+	for i := range n {
+		if len(b) < offset+2 {
+			return io.ErrUnexpectedEOF
+		}
+		l := int(binary.BigEndian.Uint16(b[offset : offset+2]))
+		offset += 2
+
+		if len(b) < offset+l {
+			return io.ErrUnexpectedEOF
+		}
+		f.Records[i] = b[offset : offset+l]
+		offset += l
+	}
+
+	return nil
+}
+
+// MarshalLen returns the serial length of DataRecordPacketFields in int.
+func (f *DataRecordPacketFields) MarshalLen() int {
+	l := 4
+	for _, r := range f.Records {
+		l += 2 + len(r)
+	}
+	return l
+}
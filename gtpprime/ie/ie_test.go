@@ -0,0 +1,162 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpprime"
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+)
+
+var cases = []struct {
+	description string
+	structured  *ie.IE
+	serialized  []byte
+}{
+	{
+		"Cause",
+		ie.NewCause(gtpprime.CauseRequestAccepted),
+		[]byte{0x01, 0x80},
+	}, {
+		"Recovery",
+		ie.NewRecovery(0x80),
+		[]byte{0x0e, 0x80},
+	}, {
+		"PacketTransferCommand",
+		ie.NewPacketTransferCommand(gtpprime.PacketTransferCommandSendPossiblyDuplicatedDataRecordPacket),
+		[]byte{0x7e, 0x02},
+	}, {
+		"SequenceNumbersOfReleasedPackets",
+		ie.NewSequenceNumbersOfReleasedPackets(0x0001, 0xffff),
+		[]byte{
+			// Type, Length
+			0xf9, 0x00, 0x04,
+			// Value
+			0x00, 0x01, 0xff, 0xff,
+		},
+	}, {
+		"SequenceNumbersOfCancelledPackets",
+		ie.NewSequenceNumbersOfCancelledPackets(0x0001),
+		[]byte{
+			// Type, Length
+			0xfa, 0x00, 0x02,
+			// Value
+			0x00, 0x01,
+		},
+	}, {
+		"ChargingGatewayAddress/v4",
+		ie.NewChargingGatewayAddress("1.1.1.1"),
+		[]byte{
+			// Type, Length
+			0xfb, 0x00, 0x04,
+			// Value
+			0x01, 0x01, 0x01, 0x01,
+		},
+	}, {
+		"ChargingGatewayAddress/v6",
+		ie.NewChargingGatewayAddress("2001::1"),
+		[]byte{
+			// Type, Length
+			0xfb, 0x00, 0x10,
+			// Value
+			0x20, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		},
+	}, {
+		"DataRecordPacket",
+		ie.NewDataRecordPacket(gtpprime.DataRecordFormatBER, 0x0a08, []byte{0xde, 0xad, 0xbe, 0xef}, []byte{0x30, 0x00}),
+		[]byte{
+			// Type, Length
+			0xfc, 0x00, 0x0e,
+			// Number of Data Records, Format, Format Version
+			0x02, 0x01, 0x0a, 0x08,
+			// Records
+			0x00, 0x04, 0xde, 0xad, 0xbe, 0xef,
+			0x00, 0x02, 0x30, 0x00,
+		},
+	}, {
+		"DataRecordPacket/empty",
+		ie.NewDataRecordPacket(gtpprime.DataRecordFormatBER, 0x0a08),
+		[]byte{
+			// Type, Length
+			0xfc, 0x00, 0x04,
+			// Number of Data Records, Format, Format Version
+			0x00, 0x01, 0x0a, 0x08,
+		},
+	}, {
+		"RequestsResponded",
+		ie.NewRequestsResponded(0x0001, 0x0002, 0x0003),
+		[]byte{
+			// Type, Length
+			0xfd, 0x00, 0x06,
+			// Value
+			0x00, 0x01, 0x00, 0x02, 0x00, 0x03,
+		},
+	}, {
+		"AddressOfRecommendedNode",
+		ie.NewAddressOfRecommendedNode("1.1.1.1"),
+		[]byte{
+			// Type, Length
+			0xfe, 0x00, 0x04,
+			// Value
+			0x01, 0x01, 0x01, 0x01,
+		},
+	}, {
+		"PrivateExtension",
+		ie.NewPrivateExtension(0x0080, []byte{0xde, 0xad, 0xbe, 0xef}),
+		[]byte{
+			// Type, Length
+			0xff, 0x00, 0x06,
+			// Value
+			0x00, 0x80, 0xde, 0xad, 0xbe, 0xef,
+		},
+	},
+}
+
+func TestIE(t *testing.T) {
+	for _, c := range cases {
+		t.Run("Marshal/"+c.description, func(t *testing.T) {
+			got, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, c.serialized); diff != "" {
+				t.Error(diff)
+			}
+		})
+
+		t.Run("Parse/"+c.description, func(t *testing.T) {
+			got, err := ie.Parse(c.serialized)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, c.structured); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDataRecordPacket(t *testing.T) {
+	records := [][]byte{{0xde, 0xad, 0xbe, 0xef}, {0x30, 0x00}}
+	got, err := ie.NewDataRecordPacket(gtpprime.DataRecordFormatBER, 0x0a08, records...).DataRecordPacket()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ie.NewDataRecordPacketFields(gtpprime.DataRecordFormatBER, 0x0a08, records...)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+
+	// the Number of Data Records larger than the records actually included.
+	if _, err := ie.New(ie.DataRecordPacket, []byte{0x02, 0x01, 0x0a, 0x08, 0x00, 0x01, 0xff}).DataRecordPacket(); err == nil {
+		t.Error("unexpectedly succeeded")
+	}
+}
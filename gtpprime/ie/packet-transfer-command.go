@@ -0,0 +1,31 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "io"
+
+// NewPacketTransferCommand creates a new PacketTransferCommand IE.
+func NewPacketTransferCommand(cmd uint8) *IE {
+	return newUint8ValIE(PacketTransferCommand, cmd)
+}
+
+// PacketTransferCommand returns PacketTransferCommand value if type matches.
+func (i *IE) PacketTransferCommand() (uint8, error) {
+	if i.Type != PacketTransferCommand {
+		return 0, &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return i.Payload[0], nil
+}
+
+// MustPacketTransferCommand returns PacketTransferCommand in uint8 if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustPacketTransferCommand() uint8 {
+	v, _ := i.PacketTransferCommand()
+	return v
+}
@@ -0,0 +1,90 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "encoding/binary"
+
+// NewRequestsResponded creates a new RequestsResponded IE, which contains the
+// Sequence Numbers of the requests responded.
+func NewRequestsResponded(seqs ...uint16) *IE {
+	return newSequenceNumbersIE(RequestsResponded, seqs)
+}
+
+// RequestsResponded returns the Sequence Numbers in RequestsResponded if type matches.
+func (i *IE) RequestsResponded() ([]uint16, error) {
+	if i.Type != RequestsResponded {
+		return nil, &InvalidTypeError{Type: i.Type}
+	}
+	return i.sequenceNumbers()
+}
+
+// MustRequestsResponded returns RequestsResponded in []uint16 if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustRequestsResponded() []uint16 {
+	v, _ := i.RequestsResponded()
+	return v
+}
+
+// NewSequenceNumbersOfReleasedPackets creates a new SequenceNumbersOfReleasedPackets IE.
+func NewSequenceNumbersOfReleasedPackets(seqs ...uint16) *IE {
+	return newSequenceNumbersIE(SequenceNumbersOfReleasedPackets, seqs)
+}
+
+// SequenceNumbersOfReleasedPackets returns the Sequence Numbers in
+// SequenceNumbersOfReleasedPackets if type matches.
+func (i *IE) SequenceNumbersOfReleasedPackets() ([]uint16, error) {
+	if i.Type != SequenceNumbersOfReleasedPackets {
+		return nil, &InvalidTypeError{Type: i.Type}
+	}
+	return i.sequenceNumbers()
+}
+
+// MustSequenceNumbersOfReleasedPackets returns SequenceNumbersOfReleasedPackets in []uint16 if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustSequenceNumbersOfReleasedPackets() []uint16 {
+	v, _ := i.SequenceNumbersOfReleasedPackets()
+	return v
+}
+
+// NewSequenceNumbersOfCancelledPackets creates a new SequenceNumbersOfCancelledPackets IE.
+func NewSequenceNumbersOfCancelledPackets(seqs ...uint16) *IE {
+	return newSequenceNumbersIE(SequenceNumbersOfCancelledPackets, seqs)
+}
+
+// SequenceNumbersOfCancelledPackets returns the Sequence Numbers in
+// SequenceNumbersOfCancelledPackets if type matches.
+func (i *IE) SequenceNumbersOfCancelledPackets() ([]uint16, error) {
+	if i.Type != SequenceNumbersOfCancelledPackets {
+		return nil, &InvalidTypeError{Type: i.Type}
+	}
+	return i.sequenceNumbers()
+}
+
+// MustSequenceNumbersOfCancelledPackets returns SequenceNumbersOfCancelledPackets in []uint16 if type matches.
+// This should only be used if it is assured to have the value.
+func (i *IE) MustSequenceNumbersOfCancelledPackets() []uint16 {
+	v, _ := i.SequenceNumbersOfCancelledPackets()
+	return v
+}
+
+func newSequenceNumbersIE(t uint8, seqs []uint16) *IE {
+	i := New(t, make([]byte, 2*len(seqs)))
+	for n, seq := range seqs {
+		binary.BigEndian.PutUint16(i.Payload[2*n:2*n+2], seq)
+	}
+	return i
+}
+
+func (i *IE) sequenceNumbers() ([]uint16, error) {
+	if len(i.Payload)%2 != 0 {
+		return nil, ErrMalformed
+	}
+
+	seqs := make([]uint16, len(i.Payload)/2)
+	for n := range seqs {
+		seqs[n] = binary.BigEndian.Uint16(i.Payload[2*n : 2*n+2])
+	}
+	return seqs, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpprime"
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/gtpprime/testutils"
+)
+
+func TestDataRecordTransferRequest(t *testing.T) {
+	cases := []testutils.TestCase{
+		{
+			Description: "send",
+			Structured: message.NewDataRecordTransferRequest(
+				testutils.TestSeq,
+				ie.NewPacketTransferCommand(gtpprime.PacketTransferCommandSendDataRecordPacket),
+				ie.NewDataRecordPacket(gtpprime.DataRecordFormatBER, 0x1234, []byte{0xde, 0xad}, []byte{0xbe, 0xef, 0x00}),
+			),
+			Serialized: []byte{
+				// Header
+				0x4e, 0xf0, 0x00, 0x12, 0x00, 0x01,
+				// PacketTransferCommand
+				0x7e, 0x01,
+				// DataRecordPacket
+				0xfc, 0x00, 0x0d, 0x02, 0x01, 0x12, 0x34,
+				0x00, 0x02, 0xde, 0xad,
+				0x00, 0x03, 0xbe, 0xef, 0x00,
+			},
+		}, {
+			Description: "release",
+			Structured: message.NewDataRecordTransferRequest(
+				testutils.TestSeq,
+				ie.NewPacketTransferCommand(gtpprime.PacketTransferCommandReleaseDataRecordPacket),
+				ie.NewSequenceNumbersOfReleasedPackets(0x0001, 0x0002),
+			),
+			Serialized: []byte{
+				// Header
+				0x4e, 0xf0, 0x00, 0x09, 0x00, 0x01,
+				// PacketTransferCommand
+				0x7e, 0x04,
+				// SequenceNumbersOfReleasedPackets
+				0xf9, 0x00, 0x04, 0x00, 0x01, 0x00, 0x02,
+			},
+		}, {
+			Description: "cancel",
+			Structured: message.NewDataRecordTransferRequest(
+				testutils.TestSeq,
+				ie.NewPacketTransferCommand(gtpprime.PacketTransferCommandCancelDataRecordPacket),
+				ie.NewSequenceNumbersOfCancelledPackets(0x0001),
+			),
+			Serialized: []byte{
+				// Header
+				0x4e, 0xf0, 0x00, 0x07, 0x00, 0x01,
+				// PacketTransferCommand
+				0x7e, 0x03,
+				// SequenceNumbersOfCancelledPackets
+				0xfa, 0x00, 0x02, 0x00, 0x01,
+			},
+		},
+	}
+
+	testutils.Run(t, cases, func(b []byte) (testutils.Serializable, error) {
+		v, err := message.ParseDataRecordTransferRequest(b)
+		if err != nil {
+			return nil, err
+		}
+		v.Payload = nil
+		return v, nil
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/gtpprime/testutils"
+)
+
+func TestEchoRequest(t *testing.T) {
+	cases := []testutils.TestCase{
+		{
+			Description: "no-ie",
+			Structured:  message.NewEchoRequest(testutils.TestSeq),
+			Serialized: []byte{
+				// Header
+				0x4e, 0x01, 0x00, 0x00, 0x00, 0x01,
+			},
+		},
+	}
+
+	testutils.Run(t, cases, func(b []byte) (testutils.Serializable, error) {
+		v, err := message.ParseEchoRequest(b)
+		if err != nil {
+			return nil, err
+		}
+		v.Payload = nil
+		return v, nil
+	})
+}
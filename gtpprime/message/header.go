@@ -0,0 +1,163 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Header length definitions.
+//
+// TS 32.295 6.1.1; the 6 octet header is used by default. Only in GTP' version 0,
+// the 20 octet header, which is the same length as GTPv0 header with the spare
+// octets, is used when the last bit of Flags is set to 0. The bit is unused in
+// the other versions.
+const (
+	HeaderLen       = 6
+	LegacyHeaderLen = 20
+)
+
+// Header is a GTP' header.
+type Header struct {
+	Flags          uint8
+	Type           uint8
+	Length         uint16
+	SequenceNumber uint16
+	Payload        []byte
+}
+
+// NewHeader creates a new Header.
+func NewHeader(flags, mtype uint8, seq uint16, payload []byte) *Header {
+	h := &Header{
+		Flags:          flags,
+		Type:           mtype,
+		SequenceNumber: seq,
+		Payload:        payload,
+	}
+	h.SetLength()
+
+	return h
+}
+
+// HeaderFlags returns a Header Flag built by its components given as arguments.
+//
+// The Protocol Type is always 0 (GTP'), and the spare bits are set to 1.
+// Set legacy to true to use the 20 octet header, which is valid only with
+// version 0 and ignored with the other versions.
+func HeaderFlags(v int, legacy bool) uint8 {
+	f := uint8(((v & 0x7) << 5) | 0x0e)
+	if v == 0 && !legacy {
+		f |= 0x01
+	}
+	return f
+}
+
+// Marshal returns the byte sequence generated from a Header instance.
+func (h *Header) Marshal() ([]byte, error) {
+	b := make([]byte, h.MarshalLen())
+	if err := h.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (h *Header) MarshalTo(b []byte) error {
+	if len(b) < h.MarshalLen() {
+		return ErrTooShortToMarshal
+	}
+
+	b[0] = h.Flags
+	b[1] = h.Type
+	binary.BigEndian.PutUint16(b[2:4], h.Length)
+	binary.BigEndian.PutUint16(b[4:6], h.SequenceNumber)
+	hl := h.headerLen()
+	for i := HeaderLen; i < hl; i++ {
+		b[i] = 0xff
+	}
+	copy(b[hl:h.MarshalLen()], h.Payload)
+	return nil
+}
+
+// ParseHeader Parses given byte sequence as a GTP' header.
+func ParseHeader(b []byte) (*Header, error) {
+	h := &Header{}
+	if err := h.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in GTP' header.
+func (h *Header) UnmarshalBinary(b []byte) error {
+	l := len(b)
+	if l < HeaderLen {
+		return ErrTooShortToParse
+	}
+	h.Flags = b[0]
+	h.Type = b[1]
+	h.Length = binary.BigEndian.Uint16(b[2:4])
+	h.SequenceNumber = binary.BigEndian.Uint16(b[4:6])
+
+	hl := h.headerLen()
+	if l < hl {
+		return ErrTooShortToParse
+	}
+	if int(h.Length)+hl > l {
+		return ErrInvalidLength
+	}
+	h.Payload = b[hl : hl+int(h.Length)]
+	return nil
+}
+
+// MarshalLen returns the serial length of Header.
+func (h *Header) MarshalLen() int {
+	return h.headerLen() + len(h.Payload)
+}
+
+// SetLength sets the length in Length field.
+func (h *Header) SetLength() {
+	h.Length = uint16(len(h.Payload))
+}
+
+// String returns the GTP' header values in human readable format.
+func (h *Header) String() string {
+	return fmt.Sprintf("{Flags: %#x, Type: %#x, Length: %d, SequenceNumber: %#04x, Payload: %#v}",
+		h.Flags,
+		h.Type,
+		h.Length,
+		h.SequenceNumber,
+		h.Payload,
+	)
+}
+
+// headerLen returns the length of the header without Payload.
+func (h *Header) headerLen() int {
+	if h.Version() == 0 && h.Flags&0x01 == 0 {
+		return LegacyHeaderLen
+	}
+	return HeaderLen
+}
+
+// Version returns the version of GTP'.
+func (h *Header) Version() int {
+	return int(h.Flags >> 5)
+}
+
+// MessageType returns the type of message.
+func (h *Header) MessageType() uint8 {
+	return h.Type
+}
+
+// Sequence returns SequenceNumber in uint16.
+func (h *Header) Sequence() uint16 {
+	return h.SequenceNumber
+}
+
+// SetSequenceNumber sets the SequenceNumber in Header.
+func (h *Header) SetSequenceNumber(seq uint16) {
+	h.SequenceNumber = seq
+}
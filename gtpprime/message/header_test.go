@@ -0,0 +1,102 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/gtpprime/testutils"
+)
+
+func TestHeader(t *testing.T) {
+	cases := []testutils.TestCase{
+		{
+			Description: "6-octet",
+			Structured: message.NewHeader(
+				message.HeaderFlags(2, false), // Flags
+				0xf0,                          // Message type
+				testutils.TestSeq,
+				[]byte{ // Payload
+					0xde, 0xad, 0xbe, 0xef,
+				},
+			),
+			Serialized: []byte{
+				// Flags, MessageType, Length
+				0x4e, 0xf0, 0x00, 0x04,
+				// SequenceNumber
+				0x00, 0x01,
+				// dummy Payload
+				0xde, 0xad, 0xbe, 0xef,
+			},
+		}, {
+			Description: "6-octet-v0",
+			Structured: message.NewHeader(
+				message.HeaderFlags(0, false), // Flags
+				0xf0,                          // Message type
+				testutils.TestSeq,
+				[]byte{ // Payload
+					0xde, 0xad, 0xbe, 0xef,
+				},
+			),
+			Serialized: []byte{
+				// Flags, MessageType, Length
+				0x0f, 0xf0, 0x00, 0x04,
+				// SequenceNumber
+				0x00, 0x01,
+				// dummy Payload
+				0xde, 0xad, 0xbe, 0xef,
+			},
+		}, {
+			Description: "6-octet-v2-with-bit",
+			Structured: message.NewHeader(
+				0x4f, // Flags
+				0xf0, // Message type
+				testutils.TestSeq,
+				[]byte{ // Payload
+					0xde, 0xad, 0xbe, 0xef,
+				},
+			),
+			Serialized: []byte{
+				// Flags, MessageType, Length
+				0x4f, 0xf0, 0x00, 0x04,
+				// SequenceNumber
+				0x00, 0x01,
+				// dummy Payload
+				0xde, 0xad, 0xbe, 0xef,
+			},
+		}, {
+			Description: "20-octet",
+			Structured: message.NewHeader(
+				message.HeaderFlags(0, true), // Flags
+				0xf0,                         // Message type
+				testutils.TestSeq,
+				[]byte{ // Payload
+					0xde, 0xad, 0xbe, 0xef,
+				},
+			),
+			Serialized: []byte{
+				// Flags, MessageType, Length
+				0x0e, 0xf0, 0x00, 0x04,
+				// SequenceNumber
+				0x00, 0x01,
+				// Spare
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				// dummy Payload
+				0xde, 0xad, 0xbe, 0xef,
+			},
+		},
+	}
+
+	testutils.Run(t, cases, func(b []byte) (testutils.Serializable, error) {
+		v, err := message.ParseHeader(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return v, nil
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+/*
+Package message provides encoding/decoding feature of GTP' protocol.
+*/
+package message
+
+import (
+	"fmt"
+)
+
+// MessageType definitions.
+const (
+	_ uint8 = iota
+	MsgTypeEchoRequest
+	MsgTypeEchoResponse
+	MsgTypeVersionNotSupported
+	MsgTypeNodeAliveRequest
+	MsgTypeNodeAliveResponse
+	MsgTypeRedirectionRequest
+	MsgTypeRedirectionResponse
+	MsgTypeDataRecordTransferRequest  uint8 = 240
+	MsgTypeDataRecordTransferResponse uint8 = 241
+)
+
+// defaultFlags is the Flags used in the messages created by constructors;
+// GTP' version 2 with the 6 octet header.
+const defaultFlags uint8 = 0x4e
+
+// Message is an interface that defines GTP' message.
+type Message interface {
+	MarshalTo([]byte) error
+	UnmarshalBinary(b []byte) error
+	MarshalLen() int
+	String() string
+	Version() int
+	MessageType() uint8
+	MessageTypeName() string
+	Sequence() uint16
+	SetSequenceNumber(seq uint16)
+}
+
+// Marshal returns the byte sequence generated from a Message instance.
+// Better to use MarshalXxx instead if you know the name of message to be Serialized.
+func Marshal(g Message) ([]byte, error) {
+	b := make([]byte, g.MarshalLen())
+	if err := g.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Parse parses the given bytes as a Message.
+func Parse(b []byte) (Message, error) {
+	if len(b) < 2 {
+		return nil, ErrTooShortToParse
+	}
+
+	var g Message
+
+	switch b[1] {
+	case MsgTypeEchoRequest:
+		g = &EchoRequest{}
+	case MsgTypeEchoResponse:
+		g = &EchoResponse{}
+	case MsgTypeVersionNotSupported:
+		g = &VersionNotSupported{}
+	case MsgTypeNodeAliveRequest:
+		g = &NodeAliveRequest{}
+	case MsgTypeNodeAliveResponse:
+		g = &NodeAliveResponse{}
+	case MsgTypeRedirectionRequest:
+		g = &RedirectionRequest{}
+	case MsgTypeRedirectionResponse:
+		g = &RedirectionResponse{}
+	case MsgTypeDataRecordTransferRequest:
+		g = &DataRecordTransferRequest{}
+	case MsgTypeDataRecordTransferResponse:
+		g = &DataRecordTransferResponse{}
+	default:
+		g = &Generic{}
+	}
+
+	if err := g.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("failed to Parse Message: %w", err)
+	}
+	return g, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpprime"
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/gtpprime/testutils"
+)
+
+func TestRedirectionRequest(t *testing.T) {
+	cases := []testutils.TestCase{
+		{
+			Description: "with-recommended-node",
+			Structured: message.NewRedirectionRequest(
+				testutils.TestSeq,
+				ie.NewCause(gtpprime.CauseThisNodeAboutToGoDown),
+				ie.NewAddressOfRecommendedNode("192.168.1.2"),
+			),
+			Serialized: []byte{
+				// Header
+				0x4e, 0x06, 0x00, 0x09, 0x00, 0x01,
+				// Cause
+				0x01, 0x3f,
+				// AddressOfRecommendedNode
+				0xfe, 0x00, 0x04, 0xc0, 0xa8, 0x01, 0x02,
+			},
+		},
+	}
+
+	testutils.Run(t, cases, func(b []byte) (testutils.Serializable, error) {
+		v, err := message.ParseRedirectionRequest(b)
+		if err != nil {
+			return nil, err
+		}
+		v.Payload = nil
+		return v, nil
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpprime"
+	"github.com/wmnsk/go-gtp/gtpprime/ie"
+	"github.com/wmnsk/go-gtp/gtpprime/message"
+	"github.com/wmnsk/go-gtp/gtpprime/testutils"
+)
+
+func TestRedirectionResponse(t *testing.T) {
+	cases := []testutils.TestCase{
+		{
+			Description: "accepted",
+			Structured: message.NewRedirectionResponse(
+				testutils.TestSeq,
+				ie.NewCause(gtpprime.CauseRequestAccepted),
+			),
+			Serialized: []byte{
+				// Header
+				0x4e, 0x07, 0x00, 0x02, 0x00, 0x01,
+				// Cause
+				0x01, 0x80,
+			},
+		},
+	}
+
+	testutils.Run(t, cases, func(b []byte) (testutils.Serializable, error) {
+		v, err := message.ParseRedirectionResponse(b)
+		if err != nil {
+			return nil, err
+		}
+		v.Payload = nil
+		return v, nil
+	})
+}
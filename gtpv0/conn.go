@@ -0,0 +1,812 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+	"github.com/wmnsk/go-gtp/gtpv0/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+// tpduQueueSize is the number of T-PDUs that can be queued to be read by
+// ReadFromGTP without blocking.
+const tpduQueueSize = 1024
+
+type tpduSet struct {
+	pdp     *PDPContext
+	seq     uint16
+	payload []byte
+}
+
+// Conn represents a GTPv0 connection, which carries both the signalling messages
+// and the T-PDUs on the same address (usually on GTPPort).
+//
+// Conn provides the automatic handling of message by adding handlers to it with
+// AddHandler(s), and the functions to manage the PDPContexts that work over the
+// connection. See the docs of CreatePDPContext and RespondToCreatePDPContext for
+// how the PDP Contexts are established.
+type Conn struct {
+	mu      sync.Mutex
+	laddr   net.Addr
+	pktConn net.PacketConn
+	logger  *slog.Logger
+
+	closeCh chan struct{}
+	tpduCh  chan *tpduSet
+	*msgHandlerMap
+
+	labels      *labels
+	pdpContexts map[uint64]*PDPContext
+
+	// sequence is the last SequenceNumber used in the signalling request.
+	//
+	// TS 09.60 7.8; the Sequence Number is used as a transaction identity for
+	// the signalling messages, which is copied to the response from the request.
+	sequence uint16
+
+	// RestartCounter is the RestartCounter value in Recovery IE, which represents how many
+	// times the GSN is restarted.
+	RestartCounter uint8
+}
+
+// ConnOption is an option to configure Conn at NewConn or Dial.
+type ConnOption func(c *Conn)
+
+// NewConn creates a new Conn used for server. On client side, use Dial instead.
+func NewConn(laddr net.Addr, counter uint8, opts ...ConnOption) *Conn {
+	c := &Conn{
+		mu:             sync.Mutex{},
+		laddr:          laddr,
+		closeCh:        make(chan struct{}),
+		tpduCh:         make(chan *tpduSet, tpduQueueSize),
+		msgHandlerMap:  newDefaultMsgHandlerMap(),
+		labels:         newLabels(),
+		pdpContexts:    map[uint64]*PDPContext{},
+		RestartCounter: counter,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.logger = connlog.New(c.logger, logProto, laddr)
+	return c
+}
+
+// Dial sends Echo Request to raddr to check if the endpoint is alive and returns Conn.
+//
+// It does not bind the raddr to the underlying connection, which enables a Conn to
+// send to/receive from multiple peers with single laddr.
+//
+// If Echo exchange is unnecessary, use NewConn and ListenAndServe instead.
+func Dial(ctx context.Context, laddr, raddr net.Addr, counter uint8, opts ...ConnOption) (*Conn, error) {
+	c := NewConn(laddr, counter, opts...)
+
+	// setup underlying connection first.
+	// not using net.Dial, as it binds src/dst IP:Port, which makes it harder to
+	// handle multiple connections with a Conn.
+	var err error
+	c.pktConn, err = net.ListenPacket(c.laddr.Network(), c.laddr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// send EchoRequest to raddr.
+	if _, err := c.EchoRequest(raddr); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1500)
+
+	// if no response coming within 3 seconds, returns error without retrying.
+	if err := c.pktConn.SetReadDeadline(time.Now().Add(3 * time.Second)); err != nil {
+		return nil, err
+	}
+	n, raddr, err := c.pktConn.ReadFrom(buf)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pktConn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	// decode incoming message and let it be handled by default handler funcs.
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := msg.(*message.EchoResponse); !ok {
+		return nil, ErrUnexpectedType
+	}
+	if err := c.handleMessage(raddr, msg); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := c.Serve(ctx); err != nil {
+			c.logger.Error("fatal error on Conn", logkey.Error, err)
+		}
+	}()
+	return c, nil
+}
+
+// ListenAndServe creates a new GTPv0 Conn and start serving background.
+func (c *Conn) ListenAndServe(ctx context.Context) error {
+	if err := c.Listen(ctx); err != nil {
+		return err
+	}
+	return c.Serve(ctx)
+}
+
+// Listen creates a new GTPv0 Conn.
+func (c *Conn) Listen(ctx context.Context) error {
+	var err error
+	c.mu.Lock()
+	c.pktConn, err = net.ListenPacket(c.laddr.Network(), c.laddr.String())
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Conn) closed() <-chan struct{} {
+	return c.closeCh
+}
+
+// Serve starts serving GTPv0 connection.
+func (c *Conn) Serve(ctx context.Context) error {
+	go func() {
+		select { // ctx is canceled or Close() is called
+		case <-ctx.Done():
+		case <-c.closed():
+		}
+
+		if err := c.pktConn.Close(); err != nil {
+			c.logger.Warn("error closing the underlying conn", logkey.Error, err)
+		}
+	}()
+
+	buf := make([]byte, 1500)
+	for {
+		n, raddr, err := c.pktConn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("error reading from Conn %s: %w", c.LocalAddr(), err)
+		}
+
+		raw := make([]byte, n)
+		copy(raw, buf)
+		go func() {
+			msg, err := message.Parse(raw)
+			if err != nil {
+				c.logger.Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, err, "raw", fmt.Sprintf("%x", raw))
+				return
+			}
+
+			if err := c.handleMessage(raddr, msg); err != nil {
+				c.logger.Warn("error handling the message", append(msgAttrs(raddr, msg), logkey.Error, err)...)
+			}
+		}()
+	}
+}
+
+// ReadFrom reads a packet from the connection,
+// copying the payload into p. It returns the number of
+// bytes copied into p and the return address that
+// was on the packet.
+// It returns the number of bytes read (0 <= n <= len(p))
+// and any error encountered. Callers should always process
+// the n > 0 bytes returned before considering the error err.
+// ReadFrom can be made to time out and return
+// an Error with Timeout() == true after a fixed time limit;
+// see SetDeadline and SetReadDeadline.
+func (c *Conn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
+	return c.pktConn.ReadFrom(p)
+}
+
+// ReadFromGTP reads a T-PDU received on the PDPContexts established over Conn,
+// copying the payload without GTP header into p. It returns the number of bytes
+// copied into p and the PDPContext the T-PDU is received on.
+//
+// The T-PDUs with unknown TID or Flow Label are discarded before being read, and
+// so are the ones received while tpduQueueSize T-PDUs are waiting to be read.
+func (c *Conn) ReadFromGTP(p []byte) (n int, pdp *PDPContext, err error) {
+	select {
+	case <-c.closed():
+		err = ErrConnNotOpened
+		return
+	case tpdu := <-c.tpduCh:
+		n = copy(p, tpdu.payload)
+		pdp = tpdu.pdp
+		return
+	}
+}
+
+// WriteTo writes a packet with payload p to addr.
+// WriteTo can be made to time out and return
+// an Error with Timeout() == true after a fixed time limit;
+// see SetDeadline and SetWriteDeadline.
+// On packet-oriented connections, write timeouts are rare.
+func (c *Conn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
+	return c.pktConn.WriteTo(p, addr)
+}
+
+// WriteToGTP sends the payload p as a T-PDU on the PDPContext, with the TID, the
+// Flow Label for user traffic allocated by the peer and the Sequence Number
+// incremented for each T-PDU on the PDPContext.
+func (c *Conn) WriteToGTP(pdp *PDPContext, p []byte) (n int, err error) {
+	pdp.mu.Lock()
+	label, tid, raddr := pdp.RemoteDataLabel, pdp.TID, pdp.PeerDataAddr
+	pdp.mu.Unlock()
+
+	b, err := message.NewTPDU(pdp.nextSequence(), label, tid, p).Marshal()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := c.WriteTo(b, raddr); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection.
+// Any blocked Read or Write operations will be unblocked and return errors.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	close(c.closeCh)
+
+	return nil
+}
+
+// LocalAddr returns the local network address.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.pktConn.LocalAddr()
+}
+
+// SetDeadline sets the read and write deadlines associated
+// with the connection. It is equivalent to calling both
+// SetReadDeadline and SetWriteDeadline.
+//
+// A zero value for t means I/O operations will not time out.
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.pktConn.SetDeadline(t)
+}
+
+// SetReadDeadline sets the deadline for future Read calls
+// and any currently-blocked Read call.
+// A zero value for t means Read will not time out.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.pktConn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for future Write calls
+// and any currently-blocked Write call.
+// A zero value for t means Write will not time out.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.pktConn.SetWriteDeadline(t)
+}
+
+// AddHandler adds a message handler to Conn.
+//
+// By adding HandlerFunc, Conn will handle the specified type of message with it's
+// paired HandlerFunc when receiving. Messages without registered handlers are just
+// ignored and logged.
+//
+// HandlerFunc for EchoRequest, EchoResponse and T-PDU are registered by default.
+// These HandlerFunc can be overridden by specifying the message type, though
+// ReadFromGTP no longer works if T-PDU is overridden.
+func (c *Conn) AddHandler(msgType uint8, fn HandlerFunc) {
+	c.msgHandlerMap.store(msgType, fn)
+}
+
+// AddHandlers adds multiple handler funcs at a time, using a map.
+// The key of the map is message type of the GTPv0 message.
+//
+// See AddHandler for how the given handlers behave.
+func (c *Conn) AddHandlers(funcs map[uint8]HandlerFunc) {
+	for msgType, fn := range funcs {
+		c.msgHandlerMap.store(msgType, fn)
+	}
+}
+
+func (c *Conn) handleMessage(senderAddr net.Addr, msg message.Message) error {
+	if msg.Version() != 0 {
+		return fmt.Errorf("received an invalid version(%d) of message: %v", msg.Version(), msg)
+	}
+
+	handle, ok := c.msgHandlerMap.load(msg.MessageType())
+	if !ok {
+		return &HandlerNotFoundError{MsgType: msg.MessageTypeName()}
+	}
+
+	if err := handle(c, senderAddr, msg); err != nil {
+		return fmt.Errorf("failed to handle %s: %w", msg.MessageTypeName(), err)
+	}
+	return nil
+}
+
+// SendMessageTo sends a message to addr.
+// Unlike WriteTo, it sets the Sequence Number properly and returns the one used in the message.
+func (c *Conn) SendMessageTo(msg message.Message, addr net.Addr) (uint16, error) {
+	seq := c.IncSequence()
+	msg.SetSequenceNumber(seq)
+
+	payload, err := message.Marshal(msg)
+	if err != nil {
+		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
+	}
+
+	if _, err := c.WriteTo(payload, addr); err != nil {
+		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
+	}
+	return seq, nil
+}
+
+// IncSequence increments the SequenceNumber associated with Conn.
+func (c *Conn) IncSequence() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sequence++
+	return c.sequence
+}
+
+// SequenceNumber returns the current(=last used) SequenceNumber associated with Conn.
+func (c *Conn) SequenceNumber() uint16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sequence
+}
+
+// RespondTo sends a message(specified with "toBeSent" param) in response to a message
+// (specified with "received" param), with the Sequence Number copied from the one
+// received.
+func (c *Conn) RespondTo(raddr net.Addr, received, toBeSent message.Message) error {
+	toBeSent.SetSequenceNumber(received.Sequence())
+
+	b, err := message.Marshal(toBeSent)
+	if err != nil {
+		return err
+	}
+	if _, err := c.WriteTo(b, raddr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EchoRequest sends a EchoRequest.
+func (c *Conn) EchoRequest(raddr net.Addr) (uint16, error) {
+	return c.SendMessageTo(message.NewEchoRequest(0, 0, 0), raddr)
+}
+
+// EchoResponse sends a EchoResponse in response to the EchoRequest.
+func (c *Conn) EchoResponse(raddr net.Addr, req message.Message) error {
+	return c.RespondTo(raddr, req, message.NewEchoResponse(0, 0, 0, ie.NewRecovery(c.RestartCounter)))
+}
+
+// GetPDPContext returns the PDPContext identified by the TID.
+func (c *Conn) GetPDPContext(tid uint64) (*PDPContext, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pdp, ok := c.pdpContexts[tid]
+	return pdp, ok
+}
+
+// PDPContexts returns all the PDPContexts established or being established over Conn.
+func (c *Conn) PDPContexts() []*PDPContext {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var pdps []*PDPContext
+	for _, pdp := range c.pdpContexts {
+		pdps = append(pdps, pdp)
+	}
+	return pdps
+}
+
+// newPDPContext creates a PDPContext with the Flow Labels allocated and stores
+// it in Conn, replacing the existing one with the same TID.
+func (c *Conn) newPDPContext(tid uint64, raddr net.Addr) (*PDPContext, error) {
+	sl, err := c.labels.allocate()
+	if err != nil {
+		return nil, err
+	}
+	dl, err := c.labels.allocate()
+	if err != nil {
+		c.labels.release(sl)
+		return nil, err
+	}
+
+	imsi, nsapi := ParseTID(tid)
+	pdp := &PDPContext{
+		IMSI:                 imsi,
+		NSAPI:                nsapi,
+		TID:                  tid,
+		PeerAddr:             raddr,
+		PeerDataAddr:         raddr,
+		LocalSignallingLabel: sl,
+		LocalDataLabel:       dl,
+	}
+
+	c.mu.Lock()
+	old, ok := c.pdpContexts[tid]
+	c.pdpContexts[tid] = pdp
+	c.mu.Unlock()
+	if ok {
+		c.labels.release(old.LocalSignallingLabel, old.LocalDataLabel)
+	}
+	return pdp, nil
+}
+
+// removePDPContext removes the PDPContext from Conn and releases the Flow Labels.
+func (c *Conn) removePDPContext(pdp *PDPContext) {
+	c.mu.Lock()
+	if c.pdpContexts[pdp.TID] == pdp {
+		delete(c.pdpContexts, pdp.TID)
+	}
+	c.mu.Unlock()
+
+	c.labels.release(pdp.LocalSignallingLabel, pdp.LocalDataLabel)
+}
+
+// lookupPDPContext returns the PDPContext for the signalling message from the
+// peer, which should have the Flow Label for signalling allocated by Conn.
+func (c *Conn) lookupPDPContext(h *message.Header) (*PDPContext, error) {
+	pdp, ok := c.GetPDPContext(h.TID)
+	if !ok || pdp.LocalSignallingLabel != h.FlowLabel {
+		return nil, &UnknownTIDError{TID: h.TID, Label: h.FlowLabel}
+	}
+	return pdp, nil
+}
+
+// withLocalLabels returns ies with the Flow Label IEs of pdp added if not given.
+func withLocalLabels(pdp *PDPContext, ies []*ie.IE) []*ie.IE {
+	var hasData, hasSignalling bool
+	for _, i := range ies {
+		if i == nil {
+			continue
+		}
+		switch i.Type {
+		case ie.FlowLabelDataI:
+			hasData = true
+		case ie.FlowLabelSignalling:
+			hasSignalling = true
+		}
+	}
+
+	if !hasData {
+		ies = append(ies, ie.NewFlowLabelDataI(pdp.LocalDataLabel))
+	}
+	if !hasSignalling {
+		ies = append(ies, ie.NewFlowLabelSignalling(pdp.LocalSignallingLabel))
+	}
+	return ies
+}
+
+// causeOf returns the Cause in ies, or CauseRequestAccepted with ies appended
+// the Cause IE if not given.
+func causeOf(ies []*ie.IE) (uint8, []*ie.IE, error) {
+	for _, i := range ies {
+		if i != nil && i.Type == ie.Cause {
+			cause, err := i.Cause()
+			return cause, ies, err
+		}
+	}
+	return CauseRequestAccepted, append(ies, ie.NewCause(CauseRequestAccepted)), nil
+}
+
+// checkCause returns CauseNotOKError if the Cause in i is not "Request accepted".
+func checkCause(msg message.Message, i *ie.IE) error {
+	if i == nil {
+		return &RequiredIEMissingError{Type: ie.Cause}
+	}
+	cause, err := i.Cause()
+	if err != nil {
+		return err
+	}
+	if cause != CauseRequestAccepted {
+		return &CauseNotOKError{MsgType: msg.MessageTypeName(), Cause: cause}
+	}
+	return nil
+}
+
+// CreatePDPContext sends a CreatePDPContextRequest to raddr for the subscriber
+// identified by imsi and nsapi, and returns the PDPContext created and the Sequence
+// Number used.
+//
+// The Flow Label IEs are added with the labels allocated by Conn if not given in ies,
+// and the others (e.g., GSN Addresses, QoS Profile) should be given as ies. The
+// PDPContext becomes available for the T-PDUs after the response is given to
+// ApplyCreatePDPContextResponse.
+func (c *Conn) CreatePDPContext(raddr net.Addr, imsi string, nsapi uint8, ies ...*ie.IE) (*PDPContext, uint16, error) {
+	tid, err := NewTID(imsi, nsapi)
+	if err != nil {
+		return nil, 0, err
+	}
+	pdp, err := c.newPDPContext(tid, raddr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req := message.NewCreatePDPContextRequest(0, 0, tid, withLocalLabels(pdp, ies)...)
+	if req.APN != nil {
+		pdp.APN, _ = req.APN.AccessPointName()
+	}
+
+	seq, err := c.SendMessageTo(req, raddr)
+	if err != nil {
+		c.removePDPContext(pdp)
+		return nil, 0, err
+	}
+	return pdp, seq, nil
+}
+
+// ApplyCreatePDPContextResponse updates the PDPContext created by CreatePDPContext
+// with the Flow Labels, GGSN Addresses and End User Address in the response, and
+// returns it.
+//
+// If the Cause is not "Request accepted", the PDPContext is removed from Conn
+// and CauseNotOKError is returned. The Flow Label 0 is accepted in that case, as
+// the peer may not know the label when rejecting the request.
+func (c *Conn) ApplyCreatePDPContextResponse(res *message.CreatePDPContextResponse) (*PDPContext, error) {
+	pdp, err := c.lookupPDPContext(res.Header)
+	if err != nil {
+		if res.Header.FlowLabel != 0 {
+			return nil, err
+		}
+		cerr := checkCause(res, res.Cause)
+		if cerr == nil {
+			return nil, err
+		}
+		if pdp, ok := c.GetPDPContext(res.Header.TID); ok {
+			c.removePDPContext(pdp)
+		}
+		return nil, cerr
+	}
+
+	if err := checkCause(res, res.Cause); err != nil {
+		c.removePDPContext(pdp)
+		return nil, err
+	}
+	if err := pdp.applyIEs(
+		res.FlowLabelDataI, res.FlowLabelSignalling, res.EndUserAddress, nil,
+		res.GGSNAddressForSignalling, res.GGSNAddressForUserTraffic,
+	); err != nil {
+		return nil, err
+	}
+	return pdp, nil
+}
+
+// RespondToCreatePDPContext creates the PDPContext requested in the
+// CreatePDPContextRequest and responds to it with ies, then returns the PDPContext.
+//
+// The Flow Label IEs allocated by Conn and the Cause "Request accepted" are added
+// if not given in ies, and the others (e.g., GGSN Addresses, End User Address)
+// should be given as ies. The PDPContext is not created if the Cause in ies
+// is not "Request accepted", and the request that lacks the mandatory IEs is
+// rejected with the Cause "Mandatory IE missing".
+func (c *Conn) RespondToCreatePDPContext(raddr net.Addr, req *message.CreatePDPContextRequest, ies ...*ie.IE) (*PDPContext, error) {
+	reject := func(cause uint8, err error) (*PDPContext, error) {
+		// use the requester's label if known, so that it can find its PDPContext.
+		var label uint16
+		if req.FlowLabelSignalling != nil {
+			label, _ = req.FlowLabelSignalling.FlowLabelSignalling()
+		}
+		res := message.NewCreatePDPContextResponse(0, label, req.Header.TID, ie.NewCause(cause))
+		if rerr := c.RespondTo(raddr, req, res); rerr != nil {
+			return nil, fmt.Errorf("failed to reject the request (%w): %w", err, rerr)
+		}
+		return nil, err
+	}
+
+	for _, i := range []struct {
+		ie  *ie.IE
+		typ uint8
+	}{
+		{req.FlowLabelDataI, ie.FlowLabelDataI},
+		{req.FlowLabelSignalling, ie.FlowLabelSignalling},
+		{req.SGSNAddressForSignalling, ie.GSNAddress},
+	} {
+		if i.ie == nil {
+			return reject(CauseMandatoryIEMissing, &RequiredIEMissingError{Type: i.typ})
+		}
+	}
+
+	cause, ies, err := causeOf(ies)
+	if err != nil {
+		return nil, err
+	}
+	if cause != CauseRequestAccepted {
+		label, _ := req.FlowLabelSignalling.FlowLabelSignalling()
+		return nil, c.RespondTo(raddr, req, message.NewCreatePDPContextResponse(0, label, req.Header.TID, ies...))
+	}
+
+	pdp, err := c.newPDPContext(req.Header.TID, raddr)
+	if err != nil {
+		return reject(CauseNoResourcesAvailable, err)
+	}
+	if err := pdp.applyIEs(
+		req.FlowLabelDataI, req.FlowLabelSignalling, req.EndUserAddress, req.APN,
+		req.SGSNAddressForSignalling, req.SGSNAddressForUserTraffic,
+	); err != nil {
+		c.removePDPContext(pdp)
+		return reject(CauseMandatoryIEIncorrect, err)
+	}
+
+	res := message.NewCreatePDPContextResponse(0, pdp.RemoteSignallingLabel, pdp.TID, withLocalLabels(pdp, ies)...)
+	if res.EndUserAddress != nil {
+		if addr, err := res.EndUserAddress.IPAddress(); err == nil {
+			pdp.EndUserAddress = addr
+		}
+	}
+	if err := c.RespondTo(raddr, req, res); err != nil {
+		c.removePDPContext(pdp)
+		return nil, err
+	}
+	return pdp, nil
+}
+
+// UpdatePDPContext sends an UpdatePDPContextRequest for the PDPContext with ies,
+// and returns the Sequence Number used.
+//
+// The Flow Label IEs allocated by Conn are added if not given in ies.
+func (c *Conn) UpdatePDPContext(pdp *PDPContext, ies ...*ie.IE) (uint16, error) {
+	pdp.mu.Lock()
+	label, raddr := pdp.RemoteSignallingLabel, pdp.PeerAddr
+	pdp.mu.Unlock()
+
+	return c.SendMessageTo(message.NewUpdatePDPContextRequest(0, label, pdp.TID, withLocalLabels(pdp, ies)...), raddr)
+}
+
+// ApplyUpdatePDPContextResponse updates the PDPContext with the Flow Labels and
+// GGSN Addresses in the UpdatePDPContextResponse, and returns it.
+//
+// If the Cause is not "Request accepted", CauseNotOKError is returned and the
+// PDPContext is left unchanged.
+func (c *Conn) ApplyUpdatePDPContextResponse(res *message.UpdatePDPContextResponse) (*PDPContext, error) {
+	pdp, err := c.lookupPDPContext(res.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCause(res, res.Cause); err != nil {
+		return nil, err
+	}
+	if err := pdp.applyIEs(
+		res.FlowLabelDataI, res.FlowLabelSignalling, res.EndUserAddress, nil,
+		res.GGSNAddressForSignalling, res.GGSNAddressForUserTraffic,
+	); err != nil {
+		return nil, err
+	}
+	return pdp, nil
+}
+
+// RespondToUpdatePDPContext updates the PDPContext with the Flow Labels and SGSN
+// Addresses in the UpdatePDPContextRequest and responds to it with ies, then
+// returns the PDPContext.
+//
+// The Flow Label IEs allocated by Conn and the Cause "Request accepted" are added
+// if not given in ies. The PDPContext is left unchanged if the Cause in ies is not
+// "Request accepted", and the request for the unknown PDPContext is rejected with
+// the Cause "Non-existent".
+func (c *Conn) RespondToUpdatePDPContext(raddr net.Addr, req *message.UpdatePDPContextRequest, ies ...*ie.IE) (*PDPContext, error) {
+	pdp, err := c.lookupPDPContext(req.Header)
+	if err != nil {
+		res := message.NewUpdatePDPContextResponse(0, 0, req.Header.TID, ie.NewCause(CauseNonExistent))
+		if rerr := c.RespondTo(raddr, req, res); rerr != nil {
+			return nil, fmt.Errorf("failed to reject the request (%w): %w", err, rerr)
+		}
+		return nil, err
+	}
+
+	cause, ies, err := causeOf(ies)
+	if err != nil {
+		return nil, err
+	}
+	if cause == CauseRequestAccepted {
+		if err := pdp.applyIEs(
+			req.FlowLabelDataI, req.FlowLabelSignalling, req.EndUserAddress, nil,
+			req.SGSNAddressForSignalling, req.SGSNAddressForUserTraffic,
+		); err != nil {
+			return nil, err
+		}
+		ies = withLocalLabels(pdp, ies)
+	}
+
+	pdp.mu.Lock()
+	label := pdp.RemoteSignallingLabel
+	pdp.mu.Unlock()
+	if err := c.RespondTo(raddr, req, message.NewUpdatePDPContextResponse(0, label, pdp.TID, ies...)); err != nil {
+		return nil, err
+	}
+	return pdp, nil
+}
+
+// DeletePDPContext sends a DeletePDPContextRequest for the PDPContext with ies,
+// and returns the Sequence Number used.
+//
+// The PDPContext is kept in Conn until the response is given to
+// ApplyDeletePDPContextResponse.
+func (c *Conn) DeletePDPContext(pdp *PDPContext, ies ...*ie.IE) (uint16, error) {
+	pdp.mu.Lock()
+	label, raddr := pdp.RemoteSignallingLabel, pdp.PeerAddr
+	pdp.mu.Unlock()
+
+	return c.SendMessageTo(message.NewDeletePDPContextRequest(0, label, pdp.TID, ies...), raddr)
+}
+
+// ApplyDeletePDPContextResponse removes the PDPContext from Conn and returns it.
+//
+// The PDPContext is removed also when the Cause is "Non-existent", as the peer no
+// longer has it. For the other Causes than "Request accepted", CauseNotOKError is
+// returned and the PDPContext is kept.
+func (c *Conn) ApplyDeletePDPContextResponse(res *message.DeletePDPContextResponse) (*PDPContext, error) {
+	pdp, err := c.lookupPDPContext(res.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCause(res, res.Cause); err != nil {
+		var cerr *CauseNotOKError
+		if errors.As(err, &cerr) && cerr.Cause == CauseNonExistent {
+			c.removePDPContext(pdp)
+		}
+		return nil, err
+	}
+	c.removePDPContext(pdp)
+	return pdp, nil
+}
+
+// RespondToDeletePDPContext removes the PDPContext requested in the
+// DeletePDPContextRequest and responds to it with ies, then returns the PDPContext.
+//
+// The Cause "Request accepted" is added if not given in ies. The PDPContext is
+// kept if the Cause in ies is not "Request accepted", and the request for the
+// unknown PDPContext is rejected with the Cause "Non-existent".
+func (c *Conn) RespondToDeletePDPContext(raddr net.Addr, req *message.DeletePDPContextRequest, ies ...*ie.IE) (*PDPContext, error) {
+	pdp, err := c.lookupPDPContext(req.Header)
+	if err != nil {
+		res := message.NewDeletePDPContextResponse(0, 0, req.Header.TID, ie.NewCause(CauseNonExistent))
+		if rerr := c.RespondTo(raddr, req, res); rerr != nil {
+			return nil, fmt.Errorf("failed to reject the request (%w): %w", err, rerr)
+		}
+		return nil, err
+	}
+
+	cause, ies, err := causeOf(ies)
+	if err != nil {
+		return nil, err
+	}
+
+	pdp.mu.Lock()
+	label := pdp.RemoteSignallingLabel
+	pdp.mu.Unlock()
+	if err := c.RespondTo(raddr, req, message.NewDeletePDPContextResponse(0, label, pdp.TID, ies...)); err != nil {
+		return nil, err
+	}
+	if cause == CauseRequestAccepted {
+		c.removePDPContext(pdp)
+	}
+	return pdp, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv0"
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+	"github.com/wmnsk/go-gtp/gtpv0/message"
+)
+
+const (
+	sgsnIP = "127.0.0.23"
+	ggsnIP = "127.0.0.24"
+)
+
+func setup(ctx context.Context, t *testing.T) (sgsn, ggsn *gtpv0.Conn, ggsnCh, resCh chan *gtpv0.PDPContext, errCh chan error) {
+	t.Helper()
+
+	sgsnAddr, err := net.ResolveUDPAddr("udp", sgsnIP+gtpv0.GTPPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ggsnAddr, err := net.ResolveUDPAddr("udp", ggsnIP+gtpv0.GTPPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ggsnCh = make(chan *gtpv0.PDPContext, 1)
+	resCh = make(chan *gtpv0.PDPContext, 1)
+	errCh = make(chan error, 1)
+
+	ggsn = gtpv0.NewConn(ggsnAddr, 0)
+	ggsn.AddHandlers(map[uint8]gtpv0.HandlerFunc{
+		message.MsgTypeCreatePDPContextRequest: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			pdp, err := c.RespondToCreatePDPContext(senderAddr, msg.(*message.CreatePDPContextRequest),
+				ie.NewEndUserAddressIPv4("10.10.10.10"),
+				ie.NewGSNAddress(ggsnIP),
+				ie.NewGSNAddress(ggsnIP),
+			)
+			if err != nil {
+				return err
+			}
+			ggsnCh <- pdp
+			return nil
+		},
+		message.MsgTypeUpdatePDPContextRequest: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			_, err := c.RespondToUpdatePDPContext(senderAddr, msg.(*message.UpdatePDPContextRequest),
+				ie.NewGSNAddress(ggsnIP),
+				ie.NewGSNAddress(ggsnIP),
+			)
+			return err
+		},
+		message.MsgTypeDeletePDPContextRequest: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			_, err := c.RespondToDeletePDPContext(senderAddr, msg.(*message.DeletePDPContextRequest))
+			return err
+		},
+	})
+	go func() {
+		if err := ggsn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+	sgsn, err = gtpv0.Dial(ctx, sgsnAddr, ggsnAddr, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := func(pdp *gtpv0.PDPContext, err error) error {
+		if err != nil {
+			errCh <- err
+			return err
+		}
+		resCh <- pdp
+		return nil
+	}
+	sgsn.AddHandlers(map[uint8]gtpv0.HandlerFunc{
+		message.MsgTypeCreatePDPContextResponse: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			return report(c.ApplyCreatePDPContextResponse(msg.(*message.CreatePDPContextResponse)))
+		},
+		message.MsgTypeUpdatePDPContextResponse: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			return report(c.ApplyUpdatePDPContextResponse(msg.(*message.UpdatePDPContextResponse)))
+		},
+		message.MsgTypeDeletePDPContextResponse: func(c *gtpv0.Conn, senderAddr net.Addr, msg message.Message) error {
+			return report(c.ApplyDeletePDPContextResponse(msg.(*message.DeletePDPContextResponse)))
+		},
+	})
+
+	return sgsn, ggsn, ggsnCh, resCh, errCh
+}
+
+func wait(t *testing.T, resCh chan *gtpv0.PDPContext, errCh chan error) *gtpv0.PDPContext {
+	t.Helper()
+
+	select {
+	case pdp := <-resCh:
+		return pdp
+	case err := <-errCh:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	return nil
+}
+
+func TestPDPContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sgsn, ggsn, ggsnCh, resCh, errCh := setup(ctx, t)
+	defer sgsn.Close()
+	defer ggsn.Close()
+
+	ggsnAddr, err := net.ResolveUDPAddr("udp", ggsnIP+gtpv0.GTPPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := sgsn.CreatePDPContext(ggsnAddr, "123456789012345", 5,
+		ie.NewSelectionMode(0xff),
+		ie.NewEndUserAddressIPv4(""),
+		ie.NewAccessPointName("some.apn.example"),
+		ie.NewGSNAddress(sgsnIP),
+		ie.NewGSNAddress(sgsnIP),
+	); err != nil {
+		t.Fatal(err)
+	}
+	pdp := wait(t, resCh, errCh)
+
+	if diff := cmp.Diff(pdp.EndUserAddress, "10.10.10.10"); diff != "" {
+		t.Error(diff)
+	}
+	ggsnPDP := <-ggsnCh
+	if got, ok := ggsn.GetPDPContext(pdp.TID); !ok || got != ggsnPDP {
+		t.Fatal("PDP Context not found on GGSN")
+	}
+	if diff := cmp.Diff(
+		[]any{ggsnPDP.IMSI, ggsnPDP.NSAPI, ggsnPDP.APN, ggsnPDP.RemoteSignallingLabel, ggsnPDP.RemoteDataLabel},
+		[]any{"123456789012345", uint8(5), "some.apn.example", pdp.LocalSignallingLabel, pdp.LocalDataLabel},
+	); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(
+		[]uint16{pdp.RemoteSignallingLabel, pdp.RemoteDataLabel},
+		[]uint16{ggsnPDP.LocalSignallingLabel, ggsnPDP.LocalDataLabel},
+	); diff != "" {
+		t.Error(diff)
+	}
+
+	// T-PDU in both directions.
+	payload := []byte{0xde, 0xad, 0xbe, 0xef}
+	buf := make([]byte, 1500)
+	for _, dir := range []struct {
+		from, to  *gtpv0.Conn
+		pdp, recv *gtpv0.PDPContext
+	}{
+		{sgsn, ggsn, pdp, ggsnPDP},
+		{ggsn, sgsn, ggsnPDP, pdp},
+	} {
+		if _, err := dir.from.WriteToGTP(dir.pdp, payload); err != nil {
+			t.Fatal(err)
+		}
+		n, got, err := dir.to.ReadFromGTP(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(buf[:n], payload); diff != "" {
+			t.Error(diff)
+		}
+		if got != dir.recv {
+			t.Errorf("T-PDU received on unexpected PDP Context: %v", got.TID)
+		}
+	}
+
+	if _, err := sgsn.UpdatePDPContext(pdp, ie.NewGSNAddress(sgsnIP), ie.NewGSNAddress(sgsnIP)); err != nil {
+		t.Fatal(err)
+	}
+	if got := wait(t, resCh, errCh); got != pdp {
+		t.Errorf("unexpected PDP Context updated: %v", got.TID)
+	}
+
+	if _, err := sgsn.DeletePDPContext(pdp); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, resCh, errCh)
+	if _, ok := sgsn.GetPDPContext(pdp.TID); ok {
+		t.Error("PDP Context not deleted on SGSN")
+	}
+	if _, ok := ggsn.GetPDPContext(pdp.TID); ok {
+		t.Error("PDP Context not deleted on GGSN")
+	}
+
+	// the request on the deleted PDP Context is rejected.
+	if _, err := sgsn.DeletePDPContext(pdp); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-resCh:
+		t.Error("unexpectedly succeeded")
+	case err := <-errCh:
+		var uerr *gtpv0.UnknownTIDError
+		if !errors.As(err, &uerr) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+
+	// the request without SGSN Address for signalling is rejected, and the PDP
+	// Context created on SGSN is removed.
+	pdp, _, err = sgsn.CreatePDPContext(ggsnAddr, "123456789012345", 6,
+		ie.NewSelectionMode(0xff),
+		ie.NewEndUserAddressIPv4(""),
+		ie.NewAccessPointName("some.apn.example"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-resCh:
+		t.Error("unexpectedly succeeded")
+	case err := <-errCh:
+		var cerr *gtpv0.CauseNotOKError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(cerr.Cause, gtpv0.CauseMandatoryIEMissing); diff != "" {
+			t.Error(diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+	}
+	if _, ok := sgsn.GetPDPContext(pdp.TID); ok {
+		t.Error("PDP Context not removed on SGSN")
+	}
+}
@@ -4,6 +4,9 @@
 
 package gtpv0
 
+// GTPPort is the port number used for both signalling and user traffic in GTPv0.
+const GTPPort = ":3386"
+
 // Cause definitions.
 const (
 	CauseRequestIMSI              uint8 = 0
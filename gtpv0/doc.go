@@ -4,7 +4,8 @@
 
 // Package gtpv0 provides simple and painless handling of GTPv0 protocol in pure Golang.
 //
-// This package is still under construction. The networking feature would be available in the future.
+// Conn handles the Echo and PDP Context management messages and the T-PDUs over a single
+// GTPv0 connection, with the PDP Contexts identified by TID (IMSI and NSAPI) and Flow Labels.
 // Please see README.md for detailed usage of the APIs provided by this package.
 //
 // https://github.com/wmnsk/go-gtp/blob/main/gtpv0/README.md
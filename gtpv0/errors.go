@@ -0,0 +1,68 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnexpectedType indicates that the type of incoming message is not expected.
+	ErrUnexpectedType = errors.New("got unexpected type of message")
+
+	// ErrConnNotOpened indicates that some operation is failed due to the status of
+	// Conn is not valid.
+	ErrConnNotOpened = errors.New("connection is not opened")
+
+	// ErrNoLabelAvailable indicates that all the Flow Labels are in use.
+	ErrNoLabelAvailable = errors.New("no Flow Label available")
+)
+
+// CauseNotOKError indicates that the value in Cause IE is not OK.
+type CauseNotOKError struct {
+	MsgType string
+	Cause   uint8
+}
+
+// Error returns error cause with message.
+func (e *CauseNotOKError) Error() string {
+	return fmt.Sprintf("got non-OK Cause: %d in %s", e.Cause, e.MsgType)
+}
+
+// RequiredIEMissingError indicates that the IE required is missing.
+type RequiredIEMissingError struct {
+	Type uint8
+}
+
+// Error returns error with missing IE type.
+func (e *RequiredIEMissingError) Error() string {
+	return fmt.Sprintf("required IE missing: %d", e.Type)
+}
+
+// UnknownTIDError indicates that the PDP Context for the TID is not known to Conn,
+// or the Flow Label in the message does not match the one allocated for it.
+type UnknownTIDError struct {
+	TID   uint64
+	Label uint16
+}
+
+// Error returns the TID and Flow Label that are unknown.
+func (e *UnknownTIDError) Error() string {
+	return fmt.Sprintf("unknown TID %#016x with Flow Label %#04x", e.TID, e.Label)
+}
+
+// HandlerNotFoundError indicates that the handler func is not registered in *Conn
+// for the incoming GTPv0 message. In usual cases this error should not be taken
+// as fatal, as the other endpoint can make your program stop working just by
+// sending unregistered message.
+type HandlerNotFoundError struct {
+	MsgType string
+}
+
+// Error returns violating message type to handle.
+func (e *HandlerNotFoundError) Error() string {
+	return fmt.Sprintf("no handlers found for incoming message: %s, ignoring", e.MsgType)
+}
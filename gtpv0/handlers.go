@@ -0,0 +1,104 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpv0/message"
+)
+
+// HandlerFunc is a handler for specific GTPv0 message.
+type HandlerFunc func(c *Conn, senderAddr net.Addr, msg message.Message) error
+
+type msgHandlerMap struct {
+	syncMap sync.Map
+}
+
+func (m *msgHandlerMap) store(msgType uint8, handler HandlerFunc) {
+	m.syncMap.Store(msgType, handler)
+}
+
+func (m *msgHandlerMap) load(msgType uint8) (HandlerFunc, bool) {
+	handler, ok := m.syncMap.Load(msgType)
+	if !ok {
+		return nil, false
+	}
+
+	return handler.(HandlerFunc), true
+}
+
+func newMsgHandlerMap(m map[uint8]HandlerFunc) *msgHandlerMap {
+	mhm := &msgHandlerMap{syncMap: sync.Map{}}
+	for k, v := range m {
+		mhm.store(k, v)
+	}
+
+	return mhm
+}
+
+func newDefaultMsgHandlerMap() *msgHandlerMap {
+	return newMsgHandlerMap(
+		map[uint8]HandlerFunc{
+			message.MsgTypeTPDU:         handleTPDU,
+			message.MsgTypeEchoRequest:  handleEchoRequest,
+			message.MsgTypeEchoResponse: handleEchoResponse,
+		},
+	)
+}
+
+func handleEchoRequest(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.EchoRequest); !ok {
+		return ErrUnexpectedType
+	}
+
+	// respond with EchoResponse.
+	return c.EchoResponse(senderAddr, msg)
+}
+
+func handleEchoResponse(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	if _, ok := msg.(*message.EchoResponse); !ok {
+		return ErrUnexpectedType
+	}
+
+	// do nothing.
+	return nil
+}
+
+// handleTPDU passes the T-PDU to be read by ReadFromGTP if the TID and Flow
+// Label are the ones of the PDP Context known to Conn.
+func handleTPDU(c *Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	pdu, ok := msg.(*message.TPDU)
+	if !ok {
+		return ErrUnexpectedType
+	}
+
+	pdp, ok := c.GetPDPContext(pdu.Header.TID)
+	if !ok || pdp.LocalDataLabel != pdu.FlowLabel {
+		return &UnknownTIDError{TID: pdu.Header.TID, Label: pdu.FlowLabel}
+	}
+
+	tpdu := &tpduSet{
+		pdp:     pdp,
+		seq:     pdu.SequenceNumber,
+		payload: pdu.Payload,
+	}
+
+	// queue the T-PDU to be read by ReadFromGTP, or drop it if the queue is full
+	// not to block the other messages.
+	select {
+	case c.tpduCh <- tpdu:
+	default:
+		c.Logger().Debug("T-PDU dropped as the queue is full", msgAttrs(senderAddr, msg)...)
+	}
+	return nil
+}
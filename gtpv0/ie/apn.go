@@ -40,7 +40,7 @@ func (i *IE) AccessPointName() (string, error) {
 	max := len(i.Payload)
 	for offset < max {
 		l := int(i.Payload[offset])
-		if offset+l+1 >= max {
+		if offset+l+1 > max {
 			return "", io.ErrUnexpectedEOF
 		}
 		apn = append(apn, string(i.Payload[offset+1:offset+l+1]))
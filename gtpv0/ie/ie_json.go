@@ -0,0 +1,333 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// document is the JSON/YAML representation of an IE.
+//
+// The IEs that have the typed accessor are represented with the decoded Value,
+// and the others with the Payload in hex.
+type document struct {
+	Type    ieType   `json:"type" yaml:"type"`
+	Value   any      `json:"value,omitempty" yaml:"value,omitempty"`
+	Payload hexBytes `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// document returns the IE in the form to be encoded in JSON or YAML.
+func (i *IE) document() *document {
+	d := &document{Type: ieType(i.Type)}
+
+	// the value is used only when it is encoded back into exactly the same
+	// payload, so that the document can always be decoded into the original IE.
+	if c, ok := codecs[i.Type]; ok {
+		if v, ok := c.value(i); ok {
+			d.Value = v
+			return d
+		}
+	}
+	d.Payload = i.Payload
+	return d
+}
+
+// build creates the IE from the fields in the document except Value.
+//
+// The payload of TV format IE must have the fixed length of its type.
+func (d *document) build() (*IE, error) {
+	b, err := New(uint8(d.Type), d.Payload).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	i, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(i.Payload, d.Payload) {
+		return nil, fmt.Errorf("invalid payload length %d for %s: %w", len(d.Payload), d.Type, ErrInvalidLength)
+	}
+	return i, nil
+}
+
+// MarshalJSON returns the IE in JSON.
+//
+// The IE is represented as the object with its type (name if known), and the
+// decoded value or the payload in hex.
+func (i *IE) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.document())
+}
+
+// UnmarshalJSON decodes the IE in JSON, in the format generated by MarshalJSON.
+func (i *IE) UnmarshalJSON(b []byte) error {
+	var d struct {
+		document
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+
+	if d.Value == nil || string(d.Value) == "null" {
+		return i.set(d.build())
+	}
+	c, ok := codecs[uint8(d.Type)]
+	if !ok {
+		return fmt.Errorf("no value can be given to %s: %w", d.Type, &InvalidTypeError{Type: uint8(d.Type)})
+	}
+	v, err := c.fromJSON(d.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode value of %s: %w", d.Type, err)
+	}
+	return i.set(v, nil)
+}
+
+// MarshalYAML returns the IE in the form to be encoded in YAML, which is the same
+// as the one in MarshalJSON.
+func (i *IE) MarshalYAML() (interface{}, error) {
+	return i.document(), nil
+}
+
+// UnmarshalYAML decodes the IE in YAML, in the format generated by MarshalYAML.
+func (i *IE) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var d document
+	if err := unmarshal(&d); err != nil {
+		return err
+	}
+
+	if d.Value == nil {
+		return i.set(d.build())
+	}
+	c, ok := codecs[uint8(d.Type)]
+	if !ok {
+		return fmt.Errorf("no value can be given to %s: %w", d.Type, &InvalidTypeError{Type: uint8(d.Type)})
+	}
+	v, err := c.fromYAML(unmarshal)
+	if err != nil {
+		return fmt.Errorf("failed to decode value of %s: %w", d.Type, err)
+	}
+	return i.set(v, nil)
+}
+
+// set overwrites i with v.
+func (i *IE) set(v *IE, err error) error {
+	if err != nil {
+		return err
+	}
+	*i = *v
+	return nil
+}
+
+// ieType is the type of IE, represented with its name if known.
+type ieType uint8
+
+// name returns the name of IE type, and false if it is unknown or it does not
+// identify the type uniquely.
+func (t ieType) name() (string, bool) {
+	n, ok := ieTypeNameMap[uint8(t)]
+	if !ok {
+		return "", false
+	}
+	if v, ok := ieTypeByName()[n]; !ok || v != uint8(t) {
+		return "", false
+	}
+	return n, true
+}
+
+// String returns the name of IE type, or the number if unknown.
+func (t ieType) String() string {
+	if n, ok := t.name(); ok {
+		return n
+	}
+	return strconv.Itoa(int(t))
+}
+
+// MarshalJSON returns the name of IE type, or the number if unknown.
+func (t ieType) MarshalJSON() ([]byte, error) {
+	if n, ok := t.name(); ok {
+		return json.Marshal(n)
+	}
+	return json.Marshal(uint8(t))
+}
+
+// UnmarshalJSON decodes the name or number of IE type.
+func (t *ieType) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// MarshalYAML returns the name of IE type, or the number if unknown.
+func (t ieType) MarshalYAML() (interface{}, error) {
+	if n, ok := t.name(); ok {
+		return n, nil
+	}
+	return uint8(t), nil
+}
+
+// UnmarshalYAML decodes the name or number of IE type.
+func (t *ieType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// set sets the type given as a name, a number or a number in string.
+func (t *ieType) set(v interface{}) error {
+	switch x := v.(type) {
+	case string:
+		if n, ok := ieTypeByName()[x]; ok {
+			*t = ieType(n)
+			return nil
+		}
+		n, err := strconv.ParseUint(x, 0, 8)
+		if err != nil {
+			return fmt.Errorf("unknown IE type %q", x)
+		}
+		*t = ieType(n)
+	case float64:
+		if x < 0 || x > 255 || x != float64(uint8(x)) {
+			return fmt.Errorf("invalid IE type %v", x)
+		}
+		*t = ieType(x)
+	case int:
+		if x < 0 || x > 255 {
+			return fmt.Errorf("invalid IE type %v", x)
+		}
+		*t = ieType(x)
+	default:
+		return fmt.Errorf("invalid IE type %v", v)
+	}
+	return nil
+}
+
+// ieTypeByName returns the IE types by name, the reverse of ieTypeNameMap.
+// The names used for more than one type are excluded.
+var ieTypeByName = sync.OnceValue(func() map[string]uint8 {
+	m := make(map[string]uint8, len(ieTypeNameMap))
+	dup := map[string]bool{}
+	for t, n := range ieTypeNameMap {
+		if _, ok := m[n]; ok {
+			dup[n] = true
+		}
+		m[n] = t
+	}
+	for n := range dup {
+		delete(m, n)
+	}
+	return m
+})
+
+// hexBytes is the bytes represented in hex.
+type hexBytes []byte
+
+// MarshalText returns b in hex.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes b in hex.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	v, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// codec converts the IE from/to the value in JSON and YAML.
+type codec interface {
+	// value returns the value of IE, and false if the value cannot be encoded
+	// back into the same payload.
+	value(i *IE) (any, bool)
+	fromJSON(b []byte) (*IE, error)
+	fromYAML(unmarshal func(interface{}) error) (*IE, error)
+}
+
+// valueCodec is the codec with the accessor and constructor of the value in T.
+type valueCodec[T any] struct {
+	decode func(i *IE) (T, error)
+	encode func(v T) (*IE, error)
+}
+
+func (c valueCodec[T]) value(i *IE) (any, bool) {
+	v, err := c.decode(i)
+	if err != nil {
+		return nil, false
+	}
+	n, err := c.encode(v)
+	if err != nil || !bytes.Equal(n.Payload, i.Payload) {
+		return nil, false
+	}
+	return v, true
+}
+
+func (c valueCodec[T]) fromJSON(b []byte) (*IE, error) {
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return c.encode(v)
+}
+
+func (c valueCodec[T]) fromYAML(unmarshal func(interface{}) error) (*IE, error) {
+	var d struct {
+		Value T `yaml:"value"`
+	}
+	if err := unmarshal(&d); err != nil {
+		return nil, err
+	}
+	return c.encode(d.Value)
+}
+
+// simpleCodec returns the codec with the constructor that returns nil on failure.
+func simpleCodec[T any](decode func(i *IE) (T, error), encode func(v T) *IE) codec {
+	return valueCodec[T]{
+		decode: decode,
+		encode: func(v T) (*IE, error) {
+			if i := encode(v); i != nil {
+				return i, nil
+			}
+			return nil, ErrMalformed
+		},
+	}
+}
+
+// boolCodec returns the codec for the IE with a single flag, with the accessor
+// that has no error.
+func boolCodec(t uint8, decode func(i *IE) bool, encode func(v bool) *IE) codec {
+	return simpleCodec(
+		func(i *IE) (bool, error) {
+			if i.Type != t {
+				return false, &InvalidTypeError{Type: i.Type}
+			}
+			return decode(i), nil
+		},
+		encode,
+	)
+}
+
+// parseIP parses the IP address in string, returning nil if s is empty.
+func parseIP(s string) (net.IP, error) {
+	if s == "" {
+		return nil, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q: %w", s, ErrMalformed)
+	}
+	return ip, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv0"
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+)
+
+// jsonCases are the IEs that are encoded into JSON/YAML and then decoded
+// back into the same byte sequence.
+var jsonCases = []struct {
+	description string
+	structured  *ie.IE
+}{
+	{
+		"Cause",
+		ie.NewCause(gtpv0.CauseRequestAccepted),
+	}, {
+		"IMSI",
+		ie.NewIMSI("123450123456789"),
+	}, {
+		"RAI",
+		ie.NewRouteingAreaIdentity("123", "45", 0x1111, 0x22),
+	}, {
+		"TLLI",
+		ie.NewTemporaryLogicalLinkIdentity(0xff00ff00),
+	}, {
+		"PacketTMSI",
+		ie.NewPacketTMSI(0xdeadbeef),
+	}, {
+		"QoS Profile",
+		ie.NewQualityOfServiceProfile(1, 1, 1, 1, 1),
+	}, {
+		"ReorderingRequired",
+		ie.NewReorderingRequired(false),
+	}, {
+		"PTMSISignature",
+		ie.NewPTMSISignature(0xbeebee),
+	}, {
+		"Recovery",
+		ie.NewRecovery(0x80),
+	}, {
+		"SelectionMode",
+		ie.NewSelectionMode(0xff),
+	}, {
+		"FlowLabelDataI",
+		ie.NewFlowLabelDataI(0x0001),
+	}, {
+		"FlowLabelSignalling",
+		ie.NewFlowLabelSignalling(0x0001),
+	}, {
+		"FlowLabelDataII",
+		ie.NewFlowLabelDataII(5, 0x0001),
+	}, {
+		"MSNotReachableReason",
+		ie.NewMSNotReachableReason(0xff),
+	}, {
+		"ChargingID",
+		ie.NewChargingID(0xff00ff00),
+	}, {
+		"EndUserAddress/v4",
+		ie.NewEndUserAddressIPv4("1.1.1.1"),
+	}, {
+		"EndUserAddress/v6",
+		ie.NewEndUserAddressIPv6("2001::1"),
+	}, {
+		"EndUserAddress/ppp",
+		ie.NewEndUserAddressPPP(),
+	}, {
+		"AccessPointName",
+		ie.NewAccessPointName("some.apn.example"),
+	}, {
+		"GSNAddress/v4",
+		ie.NewGSNAddress("1.1.1.1"),
+	}, {
+		"GSNAddress/v6",
+		ie.NewGSNAddress("2001::1"),
+	}, {
+		"MSISDN",
+		ie.NewMSISDN("819012345678"),
+	}, {
+		"ChargingGatewayAddress/v4",
+		ie.NewChargingGatewayAddress("1.1.1.1"),
+	}, {
+		"ChargingGatewayAddress/v6",
+		ie.NewChargingGatewayAddress("2001::1"),
+	}, {
+		"PrivateExtension",
+		ie.NewPrivateExtension(0x0080, []byte{0xde, 0xad, 0xbe, 0xef}),
+	},
+}
+
+func TestIEsJSONAndYAML(t *testing.T) {
+	for _, c := range jsonCases {
+		want, err := c.structured.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("json/"+c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+
+		t.Run("yaml/"+c.description, func(t *testing.T) {
+			b, err := yaml.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := yaml.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+	}
+}
+
+func TestIEJSONDocument(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  *ie.IE
+		document    string
+	}{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			`{"type":"IMSI","value":"123451234567890"}`,
+		}, {
+			"ReorderingRequired",
+			ie.NewReorderingRequired(false),
+			`{"type":"ReorderingRequired","value":false}`,
+		}, {
+			"QualityOfServiceProfile",
+			ie.NewQualityOfServiceProfile(1, 1, 1, 1, 1),
+			`{"type":"QualityOfServiceProfile","value":{"delay":1,"reliability":1,"peak":1,"precedence":1,"mean":1}}`,
+		}, {
+			"FlowLabelDataII",
+			ie.NewFlowLabelDataII(5, 0x0102),
+			`{"type":"FlowLabelDataII","value":{"nsapi":5,"flowLabel":258}}`,
+		}, {
+			"EndUserAddress",
+			ie.NewEndUserAddress("10.0.0.1"),
+			`{"type":"EndUserAddress","value":{"pdpTypeOrganization":1,"pdpTypeNumber":33,"ipv4":"10.0.0.1"}}`,
+		}, {
+			"Unknown",
+			ie.New(250, []byte{0xde, 0xad}),
+			`{"type":250,"payload":"dead"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal([]byte(c.document), got); err != nil {
+				t.Fatal(err)
+			}
+			want, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIEJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"type":"NoSuchIE"}`,
+		`{"type":256}`,
+		`{"type":"IMSI","payload":"2143"}`,
+		`{"type":"IMSI","value":1}`,
+		`{"type":250,"value":1}`,
+		`{"type":"FlowLabelDataII","value":{"nsapi":"5"}}`,
+	} {
+		if err := json.Unmarshal([]byte(doc), &ie.IE{}); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// codecs are the codecs of IEs represented with the value in JSON and YAML, by
+// IE type. The IEs not listed here are represented with the payload in hex.
+var codecs = map[uint8]codec{
+	Cause:                        simpleCodec((*IE).Cause, NewCause),
+	IMSI:                         simpleCodec((*IE).IMSI, NewIMSI),
+	TemporaryLogicalLinkIdentity: simpleCodec((*IE).TemporaryLogicalLinkIdentity, NewTemporaryLogicalLinkIdentity),
+	PacketTMSI:                   simpleCodec((*IE).PacketTMSI, NewPacketTMSI),
+	PTMSISignature:               simpleCodec((*IE).PTMSISignature, NewPTMSISignature),
+	Recovery:                     simpleCodec((*IE).Recovery, NewRecovery),
+	SelectionMode:                simpleCodec((*IE).SelectionMode, NewSelectionMode),
+	FlowLabelDataI:               simpleCodec((*IE).FlowLabelDataI, NewFlowLabelDataI),
+	FlowLabelSignalling:          simpleCodec((*IE).FlowLabelSignalling, NewFlowLabelSignalling),
+	MSNotReachableReason:         simpleCodec((*IE).MSNotReachableReason, NewMSNotReachableReason),
+	ChargingID:                   simpleCodec((*IE).ChargingID, NewChargingID),
+	AccessPointName:              simpleCodec((*IE).AccessPointName, NewAccessPointName),
+	GSNAddress:                   simpleCodec((*IE).GSNAddress, NewGSNAddress),
+	MSISDN:                       simpleCodec((*IE).MSISDN, NewMSISDN),
+	ChargingGatewayAddress:       simpleCodec((*IE).ChargingGatewayAddress, NewChargingGatewayAddress),
+	ReorderingRequired:           boolCodec(ReorderingRequired, (*IE).ReorderingRequired, NewReorderingRequired),
+
+	RouteingAreaIdentity:    valueCodec[raiValue]{decodeRAI, encodeRAI},
+	QualityOfServiceProfile: valueCodec[qosValue]{decodeQoS, encodeQoS},
+	FlowLabelDataII:         valueCodec[flowLabelDataIIValue]{decodeFlowLabelDataII, encodeFlowLabelDataII},
+	EndUserAddress:          valueCodec[euaValue]{decodeEUA, encodeEUA},
+	PrivateExtension:        valueCodec[privateExtensionValue]{decodePrivateExtension, encodePrivateExtension},
+}
+
+type raiValue struct {
+	MCC string `json:"mcc" yaml:"mcc"`
+	MNC string `json:"mnc" yaml:"mnc"`
+	LAC uint16 `json:"lac" yaml:"lac"`
+	RAC uint8  `json:"rac" yaml:"rac"`
+}
+
+func decodeRAI(i *IE) (raiValue, error) {
+	if len(i.Payload) < 6 {
+		return raiValue{}, io.ErrUnexpectedEOF
+	}
+	return raiValue{i.MustMCC(), i.MustMNC(), i.MustLAC(), i.MustRAC()}, nil
+}
+
+func encodeRAI(v raiValue) (*IE, error) {
+	if i := NewRouteingAreaIdentity(v.MCC, v.MNC, v.LAC, v.RAC); i != nil {
+		return i, nil
+	}
+	return nil, ErrMalformed
+}
+
+type qosValue struct {
+	Delay       uint8 `json:"delay" yaml:"delay"`
+	Reliability uint8 `json:"reliability" yaml:"reliability"`
+	Peak        uint8 `json:"peak" yaml:"peak"`
+	Precedence  uint8 `json:"precedence" yaml:"precedence"`
+	Mean        uint8 `json:"mean" yaml:"mean"`
+}
+
+func decodeQoS(i *IE) (qosValue, error) {
+	if i.Type != QualityOfServiceProfile {
+		return qosValue{}, &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) < 3 {
+		return qosValue{}, io.ErrUnexpectedEOF
+	}
+	return qosValue{i.MustQoSDelay(), i.MustQoSReliability(), i.MustQoSPeak(), i.MustQoSPrecedence(), i.MustQoSMean()}, nil
+}
+
+func encodeQoS(v qosValue) (*IE, error) {
+	return NewQualityOfServiceProfile(v.Delay, v.Reliability, v.Peak, v.Precedence, v.Mean), nil
+}
+
+type flowLabelDataIIValue struct {
+	NSAPI     uint8  `json:"nsapi" yaml:"nsapi"`
+	FlowLabel uint16 `json:"flowLabel" yaml:"flowLabel"`
+}
+
+func decodeFlowLabelDataII(i *IE) (flowLabelDataIIValue, error) {
+	nsapi, err := i.NSAPI()
+	if err != nil {
+		return flowLabelDataIIValue{}, err
+	}
+	label, err := i.FlowLabelData()
+	if err != nil {
+		return flowLabelDataIIValue{}, err
+	}
+	return flowLabelDataIIValue{nsapi, label}, nil
+}
+
+func encodeFlowLabelDataII(v flowLabelDataIIValue) (*IE, error) {
+	return NewFlowLabelDataII(v.NSAPI, v.FlowLabel), nil
+}
+
+// euaValue is the value of EndUserAddress IE. The PDPTypeOrganization is the
+// lower 4 bits of the first octet. The address is empty if not allocated.
+type euaValue struct {
+	PDPTypeOrganization uint8  `json:"pdpTypeOrganization" yaml:"pdpTypeOrganization"`
+	PDPTypeNumber       uint8  `json:"pdpTypeNumber" yaml:"pdpTypeNumber"`
+	IPv4                string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6                string `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+}
+
+func decodeEUA(i *IE) (euaValue, error) {
+	org, err := i.PDPTypeOrganization()
+	if err != nil {
+		return euaValue{}, err
+	}
+	num, err := i.PDPTypeNumber()
+	if err != nil {
+		return euaValue{}, err
+	}
+
+	v := euaValue{PDPTypeOrganization: org & 0x0f, PDPTypeNumber: num}
+	switch addr := i.Payload[2:]; len(addr) {
+	case 0:
+	case net.IPv4len:
+		v.IPv4 = net.IP(addr).String()
+	case net.IPv6len:
+		v.IPv6 = net.IP(addr).String()
+	case net.IPv4len + net.IPv6len:
+		v.IPv4 = net.IP(addr[:net.IPv4len]).String()
+		v.IPv6 = net.IP(addr[net.IPv4len:]).String()
+	default:
+		return euaValue{}, ErrInvalidLength
+	}
+	return v, nil
+}
+
+func encodeEUA(v euaValue) (*IE, error) {
+	b := []byte{0xf0 | v.PDPTypeOrganization, v.PDPTypeNumber}
+	if v.IPv4 != "" {
+		ip, err := parseIP(v.IPv4)
+		if err != nil {
+			return nil, err
+		}
+		if ip.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q: %w", v.IPv4, ErrMalformed)
+		}
+		b = append(b, ip.To4()...)
+	}
+	if v.IPv6 != "" {
+		ip, err := parseIP(v.IPv6)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ip.To16()...)
+	}
+	return New(EndUserAddress, b), nil
+}
+
+type privateExtensionValue struct {
+	ExtensionIdentifier uint16   `json:"extensionIdentifier" yaml:"extensionIdentifier"`
+	ExtensionValue      hexBytes `json:"extensionValue,omitempty" yaml:"extensionValue,omitempty"`
+}
+
+func decodePrivateExtension(i *IE) (privateExtensionValue, error) {
+	id, err := i.ExtensionIdentifier()
+	if err != nil {
+		return privateExtensionValue{}, err
+	}
+	return privateExtensionValue{id, i.Payload[2:]}, nil
+}
+
+func encodePrivateExtension(v privateExtensionValue) (*IE, error) {
+	return NewPrivateExtension(v.ExtensionIdentifier, v.ExtensionValue), nil
+}
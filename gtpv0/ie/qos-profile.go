@@ -32,6 +32,8 @@ func (i *IE) MustQualityOfServiceProfile() []byte {
 }
 
 // QoSDelay returns QoS Delay value in uint8 if type matches.
+//
+// The value is the Delay class shifted to the least significant bits (0-7).
 func (i *IE) QoSDelay() (uint8, error) {
 	if i.Type != QualityOfServiceProfile {
 		return 0, &InvalidTypeError{Type: i.Type}
@@ -40,7 +42,7 @@ func (i *IE) QoSDelay() (uint8, error) {
 		return 0, io.ErrUnexpectedEOF
 	}
 
-	return i.Payload[0] & 0x38, nil
+	return (i.Payload[0] >> 3) & 0x07, nil
 }
 
 // MustQoSDelay returns QoSDelay in uint8 if type matches.
@@ -70,6 +72,8 @@ func (i *IE) MustQoSReliability() uint8 {
 }
 
 // QoSPeak returns QoS Peak value in uint8 if type matches.
+//
+// The value is the Peak throughput class shifted to the least significant bits (0-15).
 func (i *IE) QoSPeak() (uint8, error) {
 	if i.Type != QualityOfServiceProfile {
 		return 0, &InvalidTypeError{Type: i.Type}
@@ -78,7 +82,7 @@ func (i *IE) QoSPeak() (uint8, error) {
 		return 0, io.ErrUnexpectedEOF
 	}
 
-	return i.Payload[1] & 0xf0, nil
+	return i.Payload[1] >> 4, nil
 }
 
 // MustQoSPeak returns QoSPeak in uint8 if type matches.
@@ -108,6 +112,8 @@ func (i *IE) MustQoSPrecedence() uint8 {
 }
 
 // QoSMean returns QoS Mean value in uint8 if type matches.
+//
+// The value is the Mean throughput class in the least significant bits (0-31).
 func (i *IE) QoSMean() (uint8, error) {
 	if i.Type != QualityOfServiceProfile {
 		return 0, &InvalidTypeError{Type: i.Type}
@@ -116,7 +122,7 @@ func (i *IE) QoSMean() (uint8, error) {
 		return 0, io.ErrUnexpectedEOF
 	}
 
-	return i.Payload[2] & 0x0f, nil
+	return i.Payload[2] & 0x1f, nil
 }
 
 // MustQoSMean returns QoSMean in uint8 if type matches.
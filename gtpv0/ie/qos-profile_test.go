@@ -0,0 +1,27 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+)
+
+func TestQualityOfServiceProfile(t *testing.T) {
+	i := ie.NewQualityOfServiceProfile(4, 3, 9, 2, 31)
+
+	got := []uint8{
+		i.MustQoSDelay(),
+		i.MustQoSReliability(),
+		i.MustQoSPeak(),
+		i.MustQoSPrecedence(),
+		i.MustQoSMean(),
+	}
+	if diff := cmp.Diff(got, []uint8{4, 3, 9, 2, 31}); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -51,7 +51,7 @@ func (i *IE) MCC() (string, error) {
 		if len(i.Payload) < 2 {
 			return "", io.ErrUnexpectedEOF
 		}
-		return utils.SwappedBytesToStr(i.Payload[0:2], false), nil
+		return utils.DecodeMCC(i.Payload[0:2]), nil
 	default:
 		return "", &InvalidTypeError{Type: i.Type}
 	}
@@ -65,13 +65,16 @@ func (i *IE) MustMCC() string {
 }
 
 // MNC returns MNC value if type matches.
+//
+// It is 2 or 3 digits long, depending on whether the filler is set in the third
+// digit of it.
 func (i *IE) MNC() (string, error) {
 	switch i.Type {
 	case RouteingAreaIdentity:
-		if len(i.Payload) < 2 {
+		if len(i.Payload) < 3 {
 			return "", io.ErrUnexpectedEOF
 		}
-		return utils.SwappedBytesToStr(i.Payload[1:2], true), nil
+		return utils.DecodeMNC(i.Payload[1:3]), nil
 	default:
 		return "", &InvalidTypeError{Type: i.Type}
 	}
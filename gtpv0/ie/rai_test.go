@@ -0,0 +1,41 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+)
+
+func TestRouteingAreaIdentity(t *testing.T) {
+	cases := []struct {
+		description string
+		mcc, mnc    string
+	}{
+		{"2-digit-mnc", "123", "45"},
+		{"3-digit-mnc", "310", "260"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			i := ie.NewRouteingAreaIdentity(c.mcc, c.mnc, 0x1111, 0x22)
+
+			if diff := cmp.Diff(i.MustMCC(), c.mcc); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(i.MustMNC(), c.mnc); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(i.MustLAC(), uint16(0x1111)); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(i.MustRAC(), uint8(0x22)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
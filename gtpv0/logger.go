@@ -0,0 +1,40 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"log/slog"
+	"net"
+
+	"github.com/wmnsk/go-gtp/gtpv0/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+// logProto is the value of logkey.Proto attribute.
+const logProto = "gtpv0"
+
+// WithLogger lets Conn write its logs to the *slog.Logger given instead of
+// slog.Default().
+//
+// The records have the attributes with the keys defined in logkey package, e.g.,
+// logkey.Peer and logkey.TID, in the same manner as the connections in the other
+// packages.
+func WithLogger(l *slog.Logger) ConnOption {
+	return func(c *Conn) {
+		c.logger = l
+	}
+}
+
+// Logger returns the *slog.Logger of Conn, which has the common attributes of
+// Conn. It is useful to write the logs from the handlers in the same manner.
+func (c *Conn) Logger() *slog.Logger {
+	return c.logger
+}
+
+// msgAttrs returns the attributes that describe the message from or to peer.
+func msgAttrs(peer net.Addr, msg message.Message) []any {
+	return connlog.MsgAttrs(peer, msg.MessageTypeName(), uint32(msg.Sequence()), slog.String(logkey.TID, msg.TID()))
+}
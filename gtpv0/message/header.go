@@ -93,7 +93,7 @@ func (h *Header) UnmarshalBinary(b []byte) error {
 	h.Length = binary.BigEndian.Uint16(b[2:4])
 	h.SequenceNumber = binary.BigEndian.Uint16(b[4:6])
 	h.FlowLabel = binary.BigEndian.Uint16(b[6:8])
-	h.SndcpNumber = b[9]
+	h.SndcpNumber = b[8]
 	h.TID = binary.BigEndian.Uint64(b[12:20])
 
 	if int(h.Length)+20 != l {
@@ -105,6 +105,9 @@ func (h *Header) UnmarshalBinary(b []byte) error {
 }
 
 // MarshalLen returns the serial length of Header.
+//
+// The Payload is counted, so the messages reset the one left by the previous
+// Marshal before allocating theirs in MarshalTo.
 func (h *Header) MarshalLen() int {
 	return 20 + len(h.Payload)
 }
@@ -145,3 +148,13 @@ func (h *Header) Version() int {
 func (h *Header) MessageType() uint8 {
 	return h.Type
 }
+
+// Sequence returns SequenceNumber in uint16.
+func (h *Header) Sequence() uint16 {
+	return h.SequenceNumber
+}
+
+// SetSequenceNumber sets the SequenceNumber in Header.
+func (h *Header) SetSequenceNumber(seq uint16) {
+	h.SequenceNumber = seq
+}
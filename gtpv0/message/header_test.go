@@ -43,6 +43,40 @@ func TestHeader(t *testing.T) {
 				// dummy Payload
 				0xde, 0xad, 0xbe, 0xef,
 			},
+		}, {
+			Description: "with-sndcp-number",
+			Structured: func() *message.Header {
+				h := message.NewHeader(
+					message.HeaderFlags(
+						0, // version
+						1, // Protocol Type
+						1, // N-PDU?
+					), //Flags
+					0xff, // Message type
+					testutils.TestFlow.Seq, testutils.TestFlow.Label, testutils.TestFlow.TID,
+					[]byte{ // Payload
+						0xde, 0xad, 0xbe, 0xef,
+					},
+				)
+				h.SndcpNumber = 0x12
+				return h
+			}(),
+			Serialized: []byte{
+				// Flags
+				0x1f,
+				// MessageType
+				0xff,
+				// SequenceNumber
+				0x00, 0x04, 0x00, 0x01,
+				// FlowLabel
+				0x00, 0x00,
+				// SndcpNumber
+				0x12, 0xff, 0xff, 0xff,
+				// TID
+				0x21, 0x43, 0x65, 0x87, 0x09, 0x21, 0x43, 0x55,
+				// dummy Payload
+				0xde, 0xad, 0xbe, 0xef,
+			},
 		},
 	}
 
@@ -0,0 +1,293 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+	"github.com/wmnsk/go-gtp/utils"
+)
+
+// document is the JSON/YAML representation of a message.
+//
+// TID is in the same format as TID() of the messages, which is the IMSI and
+// NSAPI in most cases. SndcpNumber is set only when it is not 0xff, and Flags
+// only when it is different from the default one. Payload is used instead of IEs
+// for T-PDU and when the payload cannot be parsed as IEs.
+type document struct {
+	Version     int      `json:"version" yaml:"version"`
+	Type        msgType  `json:"type" yaml:"type"`
+	Flags       *uint8   `json:"flags,omitempty" yaml:"flags,omitempty"`
+	Sequence    uint16   `json:"sequence" yaml:"sequence"`
+	FlowLabel   uint16   `json:"flowLabel" yaml:"flowLabel"`
+	SndcpNumber *uint8   `json:"sndcpNumber,omitempty" yaml:"sndcpNumber,omitempty"`
+	TID         string   `json:"tid" yaml:"tid"`
+	IEs         []*ie.IE `json:"ies,omitempty" yaml:"ies,omitempty"`
+	Payload     hexBytes `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// defaultFlags is the flags used by the messages created with the constructors.
+const defaultFlags uint8 = 0x1e
+
+// newDocument returns the message in the form to be encoded in JSON or YAML.
+func newDocument(m Message) (*document, error) {
+	b, err := Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &document{
+		Version:   0,
+		Type:      msgType(h.Type),
+		Sequence:  h.SequenceNumber,
+		FlowLabel: h.FlowLabel,
+		TID:       h.tid(),
+	}
+	if h.Flags != defaultFlags {
+		d.Flags = &h.Flags
+	}
+	if h.SndcpNumber != 0xff {
+		d.SndcpNumber = &h.SndcpNumber
+	}
+
+	if h.Type != MsgTypeTPDU {
+		d.IEs = parseIEs(h.Payload)
+	}
+	if d.IEs == nil {
+		d.Payload = h.Payload
+	}
+	return d, nil
+}
+
+// parseIEs returns the IEs in b, or nil if b cannot be parsed into the IEs that
+// are serialized back into b.
+func parseIEs(b []byte) []*ie.IE {
+	ies, err := ie.ParseMultiIEs(b)
+	if err != nil {
+		return nil
+	}
+
+	var serialized []byte
+	for _, i := range ies {
+		v, err := i.Marshal()
+		if err != nil {
+			return nil
+		}
+		serialized = append(serialized, v...)
+	}
+	if !bytes.Equal(serialized, b) {
+		return nil
+	}
+	return ies
+}
+
+// message creates the message from the document.
+func (d *document) message() (Message, error) {
+	if d.Version != 0 {
+		return nil, fmt.Errorf("version %d is not GTPv0", d.Version)
+	}
+
+	tid, err := utils.StrToSwappedBytes(d.TID, "f")
+	if err != nil {
+		return nil, fmt.Errorf("invalid TID %q: %w", d.TID, err)
+	}
+	if len(tid) != 8 {
+		return nil, fmt.Errorf("invalid TID %q: %w", d.TID, ErrInvalidLength)
+	}
+
+	payload := d.Payload
+	for _, i := range d.IEs {
+		b, err := i.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, b...)
+	}
+
+	flags := defaultFlags
+	if d.Flags != nil {
+		flags = *d.Flags
+	}
+	h := NewHeader(flags, uint8(d.Type), d.Sequence, d.FlowLabel, binary.BigEndian.Uint64(tid), payload)
+	if d.SndcpNumber != nil {
+		h.SndcpNumber = *d.SndcpNumber
+	}
+
+	b, err := h.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// MarshalJSON returns the message in JSON.
+//
+// The message is represented as the object with the fields in the header and
+// the IEs. See (*ie.IE).MarshalJSON for how the IEs are represented.
+func MarshalJSON(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// ParseJSON decodes the message in JSON, in the format generated by MarshalJSON.
+func ParseJSON(b []byte) (Message, error) {
+	d := &document{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// MarshalYAML returns the message in YAML, in the same format as MarshalJSON.
+func MarshalYAML(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(d)
+}
+
+// ParseYAML decodes the message in YAML, in the format generated by MarshalYAML.
+func ParseYAML(b []byte) (Message, error) {
+	d := &document{}
+	if err := yaml.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// msgType is the type of message, represented with its name if known.
+type msgType uint8
+
+// name returns the name of message type, and false if it is unknown.
+func (t msgType) name() (string, bool) {
+	for n, v := range msgTypeByName() {
+		if v == uint8(t) {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// MarshalJSON returns the name of message type, or the number if unknown.
+func (t msgType) MarshalJSON() ([]byte, error) {
+	if n, ok := t.name(); ok {
+		return json.Marshal(n)
+	}
+	return json.Marshal(uint8(t))
+}
+
+// UnmarshalJSON decodes the name or number of message type.
+func (t *msgType) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// MarshalYAML returns the name of message type, or the number if unknown.
+func (t msgType) MarshalYAML() (interface{}, error) {
+	if n, ok := t.name(); ok {
+		return n, nil
+	}
+	return uint8(t), nil
+}
+
+// UnmarshalYAML decodes the name or number of message type.
+func (t *msgType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// set sets the type given as a name, a number or a number in string.
+func (t *msgType) set(v interface{}) error {
+	switch x := v.(type) {
+	case string:
+		if n, ok := msgTypeByName()[x]; ok {
+			*t = msgType(n)
+			return nil
+		}
+		n, err := strconv.ParseUint(x, 0, 8)
+		if err != nil {
+			return fmt.Errorf("unknown message type %q", x)
+		}
+		*t = msgType(n)
+	case float64:
+		if x < 0 || x > 255 || x != float64(uint8(x)) {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	case int:
+		if x < 0 || x > 255 {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	default:
+		return fmt.Errorf("invalid message type %v", v)
+	}
+	return nil
+}
+
+// msgTypeByName returns the message types by name.
+//
+// The names are available only from the message types, so they are collected by
+// parsing the header with no IEs for each type. The types parsed as Generic have
+// no name.
+var msgTypeByName = sync.OnceValue(func() map[string]uint8 {
+	m := map[string]uint8{}
+	for t := 0; t < 256; t++ {
+		b, err := NewHeader(defaultFlags, uint8(t), 0, 0, 0, nil).Marshal()
+		if err != nil {
+			continue
+		}
+		msg, err := Parse(b)
+		if err != nil {
+			continue
+		}
+		if _, ok := msg.(*Generic); ok {
+			continue
+		}
+		m[msg.MessageTypeName()] = uint8(t)
+	}
+	return m
+})
+
+// hexBytes is the bytes represented in hex.
+type hexBytes []byte
+
+// MarshalText returns b in hex.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes b in hex.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	v, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+	"github.com/wmnsk/go-gtp/gtpv0/message"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  message.Message
+		document    string
+	}{
+		{
+			"EchoRequest",
+			message.NewEchoRequest(1, 0, 0, ie.NewRecovery(2)),
+			`{"version":0,"type":"Echo Request","sequence":1,"flowLabel":0,"tid":"0000000000000000","ies":[{"type":"Recovery","value":2}]}`,
+		}, {
+			"TPDU",
+			message.NewTPDU(1, 2, 0x2143658709214355, []byte{0xde, 0xad}),
+			`{"version":0,"type":"T-PDU","sequence":1,"flowLabel":2,"tid":"1234567890123455","payload":"dead"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := message.MarshalJSON(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	doc := `
+version: 0
+type: Delete PDP Context Request
+sequence: 1
+flowLabel: 0x1111
+tid: "1234567890123455"
+`
+	want := message.NewDeletePDPContextRequest(1, 0x1111, 0x2143658709214355)
+
+	got, err := message.ParseYAML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tid := got.TID(); tid != "1234567890123455" {
+		t.Errorf("got TID %s", tid)
+	}
+
+	gotb, err := message.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantb, err := message.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotb, wantb); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"version":1,"type":"Echo Request","sequence":1,"tid":"0000000000000000"}`,
+		`{"version":0,"type":"Echo Request","sequence":1,"tid":"00"}`,
+		`{"version":0,"type":"Echo Request","sequence":1,"tid":"xx00000000000000"}`,
+		`{"version":0,"type":"No Such Request","sequence":1,"tid":"0000000000000000"}`,
+	} {
+		if _, err := message.ParseJSON([]byte(doc)); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
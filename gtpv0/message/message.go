@@ -85,6 +85,8 @@ type Message interface {
 	MessageType() uint8
 	MessageTypeName() string
 	TID() string
+	Sequence() uint16
+	SetSequenceNumber(seq uint16)
 
 	// deprecated
 	SerializeTo([]byte) error
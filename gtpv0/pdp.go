@@ -0,0 +1,155 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpv0/ie"
+)
+
+// PDPContext is a PDP Context established over Conn, which is identified by the
+// TID (IMSI and NSAPI) and the Flow Labels allocated by both GSNs.
+//
+// The signalling messages and T-PDUs sent to the peer have the TID and the Flow
+// Label allocated by the peer (Remote*Label) in the header, and the ones received
+// from the peer are expected to have the Flow Label allocated by Conn (Local*Label).
+type PDPContext struct {
+	mu sync.Mutex
+
+	IMSI  string
+	NSAPI uint8
+	TID   uint64
+
+	// PeerAddr is the address of the peer GSN to send the signalling messages,
+	// and PeerDataAddr is the one to send the T-PDUs.
+	PeerAddr, PeerDataAddr net.Addr
+
+	// LocalSignallingLabel and LocalDataLabel are the Flow Labels allocated
+	// by Conn, which are told to the peer in the Flow Label IEs.
+	LocalSignallingLabel, LocalDataLabel uint16
+
+	// RemoteSignallingLabel and RemoteDataLabel are the Flow Labels allocated
+	// by the peer GSN.
+	RemoteSignallingLabel, RemoteDataLabel uint16
+
+	// EndUserAddress is the PDP Address of the MS, if any.
+	EndUserAddress string
+
+	// APN is the Access Point Name requested, if any.
+	APN string
+
+	// sequence is the last Sequence Number used in the T-PDU sent.
+	sequence uint16
+}
+
+// nextSequence returns the Sequence Number to be used in the next T-PDU.
+//
+// TS 09.60 9.1; the Sequence Number of T-PDU is incremented for each T-PDU sent
+// on the tunnel so that the receiver can deliver them in sequence.
+func (p *PDPContext) nextSequence() uint16 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sequence++
+	return p.sequence
+}
+
+// applyIEs updates the PDPContext with the Flow Labels, GSN Addresses, End User
+// Address and APN in the IEs received from the peer. gsnAddrs are the GSN
+// Addresses for signalling and user traffic in the order.
+func (p *PDPContext) applyIEs(flowData, flowSignalling, eua, apn *ie.IE, gsnAddrs ...*ie.IE) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var err error
+	if flowData != nil {
+		if p.RemoteDataLabel, err = flowData.FlowLabelDataI(); err != nil {
+			return err
+		}
+	}
+	if flowSignalling != nil {
+		if p.RemoteSignallingLabel, err = flowSignalling.FlowLabelSignalling(); err != nil {
+			return err
+		}
+	}
+	if eua != nil {
+		// PPP or the dynamic address not allocated yet has no address.
+		if addr, err := eua.IPAddress(); err == nil {
+			p.EndUserAddress = addr
+		}
+	}
+	if apn != nil {
+		if p.APN, err = apn.AccessPointName(); err != nil {
+			return err
+		}
+	}
+
+	for n, i := range gsnAddrs {
+		if i == nil {
+			continue
+		}
+		addr, err := i.GSNAddress()
+		if err != nil {
+			return err
+		}
+		udpAddr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(addr, GTPPort[1:]))
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			p.PeerAddr = udpAddr
+		}
+		// the address for signalling is used for user traffic if the latter is not given.
+		if n == 1 || p.PeerDataAddr == nil {
+			p.PeerDataAddr = udpAddr
+		}
+	}
+	return nil
+}
+
+// labels allocates the Flow Labels that are unique in Conn.
+//
+// Flow Label 0 is not allocated, as it is used in Create PDP Context Request,
+// where the peer has not allocated the label yet.
+type labels struct {
+	mu    sync.Mutex
+	last  uint16
+	inUse map[uint16]struct{}
+}
+
+func newLabels() *labels {
+	return &labels{inUse: map[uint16]struct{}{}}
+}
+
+// allocate returns a Flow Label not in use.
+func (l *labels) allocate() (uint16, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for range 0x10000 {
+		l.last++
+		if l.last == 0 {
+			continue
+		}
+		if _, ok := l.inUse[l.last]; !ok {
+			l.inUse[l.last] = struct{}{}
+			return l.last, nil
+		}
+	}
+	return 0, ErrNoLabelAvailable
+}
+
+// release makes the Flow Labels available again.
+func (l *labels) release(labels ...uint16) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, label := range labels {
+		delete(l.inUse, label)
+	}
+}
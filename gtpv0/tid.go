@@ -0,0 +1,41 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/wmnsk/go-gtp/utils"
+)
+
+// NewTID returns the Tunnel IDentifier made of IMSI and NSAPI.
+//
+// The IMSI is encoded in the first 15 digits in the same way as IMSI IE, filled
+// with 'f' if shorter, and NSAPI in the last 4 bits.
+func NewTID(imsi string, nsapi uint8) (uint64, error) {
+	if len(imsi) > 15 {
+		return 0, fmt.Errorf("IMSI %s is too long for TID", imsi)
+	}
+	if nsapi > 0x0f {
+		return 0, fmt.Errorf("NSAPI %d is too large for TID", nsapi)
+	}
+
+	b, err := utils.StrToSwappedBytes(imsi+strings.Repeat("f", 15-len(imsi))+fmt.Sprintf("%x", nsapi), "f")
+	if err != nil {
+		return 0, fmt.Errorf("invalid IMSI %s for TID: %w", imsi, err)
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// ParseTID returns the IMSI and NSAPI in the Tunnel IDentifier.
+func ParseTID(tid uint64) (imsi string, nsapi uint8) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, tid)
+
+	imsi = utils.SwappedBytesToStr(b[:7], false) + fmt.Sprintf("%x", b[7]&0x0f)
+	return strings.TrimRight(imsi, "f"), b[7] >> 4
+}
@@ -0,0 +1,52 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv0_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv0"
+)
+
+func TestTID(t *testing.T) {
+	cases := []struct {
+		description string
+		imsi        string
+		nsapi       uint8
+		tid         uint64
+	}{
+		{"15 digits", "123456789012345", 5, 0x2143658709214355},
+		{"14 digits", "12345678901234", 15, 0x21436587092143ff},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			tid, err := gtpv0.NewTID(c.imsi, c.nsapi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tid, c.tid); diff != "" {
+				t.Error(diff)
+			}
+
+			imsi, nsapi := gtpv0.ParseTID(tid)
+			if diff := cmp.Diff(imsi, c.imsi); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(nsapi, c.nsapi); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := gtpv0.NewTID("1234567890123456", 5); err == nil {
+		t.Error("unexpectedly succeeded with too long IMSI")
+	}
+	if _, err := gtpv0.NewTID("123456789012345", 16); err == nil {
+		t.Error("unexpectedly succeeded with too large NSAPI")
+	}
+}
@@ -93,6 +93,14 @@ const (
 	PDPTypeIETF
 )
 
+// PDP Type Number definitions.
+const (
+	PDPTypeNumberNonIP  uint8 = 0x02 // with PDPTypeETSI
+	PDPTypeNumberIPv4   uint8 = 0x21 // with PDPTypeIETF
+	PDPTypeNumberIPv6   uint8 = 0x57 // with PDPTypeIETF
+	PDPTypeNumberIPv4v6 uint8 = 0x8d // with PDPTypeIETF
+)
+
 // Protocol ID definitions.
 // For more identifiers, see RFC 3232.
 const (
@@ -20,6 +20,9 @@ var (
 	// ErrConnNotOpened indicates that some operation is failed due to the status of
 	// Conn is not valid.
 	ErrConnNotOpened = errors.New("connection is not opened")
+
+	// ErrMessageVetoed indicates that the message is vetoed by the Interceptor.
+	ErrMessageVetoed = errors.New("message vetoed by interceptor")
 )
 
 // ErrorIndicatedError indicates that Error Indication message is received on U-Plane Connection.
@@ -32,6 +35,16 @@ func (e *ErrorIndicatedError) Error() string {
 	return fmt.Sprintf("error received from %s, TEIDDataI: %#x", e.Peer, e.TEID)
 }
 
+// RequiredIEMissingError indicates that the IE required is missing.
+type RequiredIEMissingError struct {
+	Type uint8
+}
+
+// Error returns error with missing IE type.
+func (e *RequiredIEMissingError) Error() string {
+	return fmt.Sprintf("required IE missing: %d", e.Type)
+}
+
 // HandlerNotFoundError indicates that the handler func is not registered in *Conn
 // for the incoming GTPv2 message. In usual cases this error should not be taken
 // as fatal, as the other endpoint can make your program stop working just by
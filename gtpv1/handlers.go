@@ -5,17 +5,23 @@
 package gtpv1
 
 import (
+	"fmt"
 	"net"
 	"sync"
-	"time"
 
 	"github.com/wmnsk/go-gtp/gtpv1/ie"
 	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
 )
 
 // HandlerFunc is a handler for specific GTPv1 message.
 type HandlerFunc func(c Conn, senderAddr net.Addr, msg message.Message) error
 
+// ErrorIndicationHandlerFunc is a handler for Error Indication received on
+// UPlaneConn, which is given TEID Data I and GTP-U Peer Address in the message.
+type ErrorIndicationHandlerFunc func(senderAddr net.Addr, teid uint32, peer net.IP)
+
 type msgHandlerMap struct {
 	syncMap sync.Map
 }
@@ -49,6 +55,8 @@ func newDefaultMsgHandlerMap() *msgHandlerMap {
 			message.MsgTypeEchoRequest:     handleEchoRequest,
 			message.MsgTypeEchoResponse:    handleEchoResponse,
 			message.MsgTypeErrorIndication: handleErrorIndication,
+
+			message.MsgTypeSupportedExtensionHeaderNotification: handleSupportedExtensionHeaderNotification,
 		},
 	)
 }
@@ -71,7 +79,7 @@ func handleTPDU(c Conn, senderAddr net.Addr, msg message.Message) error {
 
 	if u.errIndEnabled {
 		if err := u.ErrorIndication(senderAddr, pdu); err != nil {
-			logf("failed to send Error Indication to %s: %v", senderAddr, err)
+			u.Logger().Warn("failed to send Error Indication", append(msgAttrs(senderAddr, msg), logkey.Error, err)...)
 		}
 		return nil
 	}
@@ -83,16 +91,14 @@ func handleTPDU(c Conn, senderAddr net.Addr, msg message.Message) error {
 		payload: pdu.Payload,
 	}
 
-	// wait for the T-PDU passed to u.tpduCh to be read by ReadFromGTP.
-	// if it got stuck for 3 seconds, it discards the T-PDU received.
-	go func() {
-		select {
-		case u.tpduCh <- tpdu:
-			return
-		case <-time.After(3 * time.Second):
-			return
-		}
-	}()
+	// queue the T-PDU to be read by ReadFromGTP, or drop it if the queue is full
+	// not to let the workers get stuck when ReadFromGTP is not called fast enough.
+	select {
+	case u.tpduCh <- tpdu:
+	default:
+		u.recordTPDUDropped()
+		u.Logger().Debug("T-PDU dropped as the queue is full", msgAttrs(senderAddr, msg)...)
+	}
 	return nil
 }
 
@@ -128,10 +134,65 @@ func handleErrorIndication(c Conn, senderAddr net.Addr, msg message.Message) err
 		return ErrUnexpectedType
 	}
 
+	if ind.TEIDDataI == nil {
+		return &RequiredIEMissingError{Type: ie.TEIDDataI}
+	}
+	if ind.GTPUPeerAddress == nil {
+		return &RequiredIEMissingError{Type: ie.GSNAddress}
+	}
+	teid, err := ind.TEIDDataI.TEID()
+	if err != nil {
+		return err
+	}
+	peerIP, err := ind.GTPUPeerAddress.IP()
+	if err != nil {
+		return err
+	}
+
+	u, ok := c.(*UPlaneConn)
+	if !ok {
+		return ErrInvalidConnection
+	}
+
+	u.mu.Lock()
+	fn := u.errIndHandler
+	teardown := u.errIndTeardownEnabled
+	u.mu.Unlock()
+
+	if teardown {
+		if err := u.removeTunnelsByPeer(teid, peerIP); err != nil {
+			u.Logger().Warn("failed to remove tunnels", logkey.Peer, peerIP.String(), logkey.TEID, connlog.TEID(teid), logkey.Error, err)
+		}
+	}
+
+	if fn == nil {
+		// just log and return
+		u.Logger().Info("ignored Error Indication", logkey.Peer, peerIP.String(), logkey.TEID, connlog.TEID(teid))
+		return nil
+	}
+
+	fn(senderAddr, teid, peerIP)
+	return nil
+}
+
+func handleSupportedExtensionHeaderNotification(c Conn, senderAddr net.Addr, msg message.Message) error {
+	// this should never happen, as the type should have been assured by
+	// msgHandlerMap before this function is called.
+	n, ok := msg.(*message.SupportedExtensionHeaderNotification)
+	if !ok {
+		return ErrUnexpectedType
+	}
+
+	u, ok := c.(*UPlaneConn)
+	if !ok {
+		return ErrInvalidConnection
+	}
+
 	// just log and return
-	logf("Ignored Error Indication: %v", &ErrorIndicatedError{
-		TEID: ind.TEIDDataI.MustTEID(),
-		Peer: ind.GTPUPeerAddress.MustIPAddress(),
-	})
+	var types []uint8
+	if n.ExtensionHeaderTypeList != nil {
+		types = n.ExtensionHeaderTypeList.MustExtensionHeaderTypeList()
+	}
+	u.Logger().Info("ignored Supported Extension Header Notification", append(msgAttrs(senderAddr, msg), "types", fmt.Sprintf("%#x", types))...)
 	return nil
 }
@@ -0,0 +1,41 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// NewAggregateMaximumBitRate creates a new AggregateMaximumBitRate IE, which is
+// used as APN-AMBR. The bit rates are in kbps.
+func NewAggregateMaximumBitRate(up, down uint32) *IE {
+	i := New(AggregateMaximumBitRate, make([]byte, 8))
+	binary.BigEndian.PutUint32(i.Payload[0:4], up)
+	binary.BigEndian.PutUint32(i.Payload[4:8], down)
+	return i
+}
+
+// AMBRForUplink returns the uplink APN-AMBR in kbps if the type of IE matches.
+func (i *IE) AMBRForUplink() (uint32, error) {
+	if i.Type != AggregateMaximumBitRate {
+		return 0, &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) < 4 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint32(i.Payload[0:4]), nil
+}
+
+// AMBRForDownlink returns the downlink APN-AMBR in kbps if the type of IE matches.
+func (i *IE) AMBRForDownlink() (uint32, error) {
+	if i.Type != AggregateMaximumBitRate {
+		return 0, &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) < 8 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint32(i.Payload[4:8]), nil
+}
@@ -14,6 +14,9 @@ const (
 	pdpTypeIETF
 )
 
+// The PDP Type Numbers (0x02: Non-IP, 0x21: IPv4, 0x57: IPv6, 0x8d: IPv4v6) are
+// defined as PDPTypeNumberXxx in gtpv1 package, which cannot be imported here.
+
 // NewEndUserAddress creates a new EndUserAddress IE from the given IP Address in string.
 //
 // The addr can be either IPv4 or IPv6. If the address type is PPP,
@@ -46,7 +49,7 @@ func NewEndUserAddressByIP(ip net.IP) *IE {
 func NewEndUserAddressIPv4(addr string) *IE {
 	v4 := net.ParseIP(addr).To4()
 	if v4 == nil {
-		return New(EndUserAddress, []byte{0xf1, 0x21})
+		return New(EndUserAddress, []byte{pdpTypeIETF, 0x21})
 	}
 
 	return newEUAddrV4(v4)
@@ -56,7 +59,7 @@ func NewEndUserAddressIPv4(addr string) *IE {
 func NewEndUserAddressIPv6(addr string) *IE {
 	v6 := net.ParseIP(addr).To16()
 	if v6 == nil {
-		return New(EndUserAddress, []byte{0xf1, 0x57})
+		return New(EndUserAddress, []byte{pdpTypeIETF, 0x57})
 	}
 
 	return newEUAddrV6(v6)
@@ -86,6 +89,33 @@ func newEUAddrV6(v6 []byte) *IE {
 	return e
 }
 
+// NewEndUserAddressIPv4v6 creates a new EndUserAddress IE with IPv4 and IPv6.
+//
+// The address that cannot be parsed is omitted, which means it is requested to
+// be allocated dynamically. If only one of them is given, the IE contains only
+// that address, as defined in TS 29.060 7.7.27.
+func NewEndUserAddressIPv4v6(v4addr, v6addr string) *IE {
+	v4 := net.ParseIP(v4addr).To4()
+	v6 := net.ParseIP(v6addr)
+	if v6.To4() != nil {
+		v6 = nil
+	}
+
+	e := New(EndUserAddress, make([]byte, 2, 2+len(v4)+len(v6)))
+	e.Payload[0] = pdpTypeIETF
+	e.Payload[1] = 0x8d
+	e.Payload = append(e.Payload, v4...)
+	e.Payload = append(e.Payload, v6...)
+
+	e.SetLength()
+	return e
+}
+
+// NewEndUserAddressNonIP creates a new EndUserAddress IE with Non-IP.
+func NewEndUserAddressNonIP() *IE {
+	return New(EndUserAddress, []byte{pdpTypeETSI, 0x02})
+}
+
 // NewEndUserAddressPPP creates a new EndUserAddress IE with PPP.
 func NewEndUserAddressPPP() *IE {
 	e := New(EndUserAddress, make([]byte, 2))
@@ -0,0 +1,64 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import "io"
+
+// NewEvolvedAllocationRetentionPriorityI creates a new EvolvedAllocationRetentionPriorityI IE.
+//
+// The pci and pvi are the values of the PCI and PVI bits, which are 1 when the
+// pre-emption capability and vulnerability are disabled.
+func NewEvolvedAllocationRetentionPriorityI(pci, pl, pvi uint8) *IE {
+	return newUint8ValIE(EvolvedAllocationRetentionPriorityI, (pci<<6&0x40)|(pl<<2&0x3c)|(pvi&0x01))
+}
+
+// EvolvedAllocationRetentionPriority returns the value of EvolvedAllocationRetentionPriorityI
+// or EvolvedAllocationRetentionPriorityII in uint8 if the type of IE matches.
+func (i *IE) EvolvedAllocationRetentionPriority() (uint8, error) {
+	switch i.Type {
+	case EvolvedAllocationRetentionPriorityI:
+		if len(i.Payload) < 1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return i.Payload[0], nil
+	case EvolvedAllocationRetentionPriorityII:
+		if len(i.Payload) < 2 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return i.Payload[1], nil
+	default:
+		return 0, &InvalidTypeError{Type: i.Type}
+	}
+}
+
+// PriorityLevel returns the Priority Level in EvolvedAllocationRetentionPriorityI
+// or EvolvedAllocationRetentionPriorityII if the type of IE matches.
+func (i *IE) PriorityLevel() (uint8, error) {
+	v, err := i.EvolvedAllocationRetentionPriority()
+	if err != nil {
+		return 0, err
+	}
+	return v >> 2 & 0x0f, nil
+}
+
+// HasPCI reports whether an IE has PCI bit, which indicates the pre-emption
+// capability is disabled.
+func (i *IE) HasPCI() bool {
+	v, err := i.EvolvedAllocationRetentionPriority()
+	if err != nil {
+		return false
+	}
+	return v&0x40 != 0
+}
+
+// HasPVI reports whether an IE has PVI bit, which indicates the pre-emption
+// vulnerability is disabled.
+func (i *IE) HasPVI() bool {
+	v, err := i.EvolvedAllocationRetentionPriority()
+	if err != nil {
+		return false
+	}
+	return v&0x01 != 0
+}
@@ -370,12 +370,11 @@ func newUint8ValIE(t, v uint8) *IE {
 	return New(t, []byte{v})
 }
 
-// left for future use.
-// func newUint16ValIE(t uint8, v uint16) *IE {
-// 	i := New(t, make([]byte, 2))
-// 	binary.BigEndian.PutUint16(i.Payload, v)
-// 	return i
-// }
+func newUint16ValIE(t uint8, v uint16) *IE {
+	i := New(t, make([]byte, 2))
+	binary.BigEndian.PutUint16(i.Payload, v)
+	return i
+}
 
 func newUint32ValIE(t uint8, v uint32) *IE {
 	i := New(t, make([]byte, 4))
@@ -384,6 +383,33 @@ func newUint32ValIE(t uint8, v uint32) *IE {
 }
 
 var ieTypeNameMap = map[uint8]string{
+	1:   "Cause",
+	2:   "IMSI",
+	3:   "RouteingAreaIdentity",
+	4:   "TemporaryLogicalLinkIdentity",
+	5:   "PacketTMSI",
+	8:   "ReorderingRequired",
+	9:   "AuthenticationTriplet",
+	11:  "MAPCause",
+	12:  "PTMSISignature",
+	13:  "MSValidated",
+	14:  "Recovery",
+	15:  "SelectionMode",
+	16:  "TEIDDataI",
+	17:  "TEIDCPlane",
+	18:  "TEIDDataII",
+	19:  "TeardownInd",
+	20:  "NSAPI",
+	21:  "RANAPCause",
+	22:  "RABContext",
+	23:  "RadioPrioritySMS",
+	24:  "RadioPriority",
+	25:  "PacketFlowID",
+	26:  "ChargingCharacteristics",
+	27:  "TraceReference",
+	28:  "TraceType",
+	29:  "MSNotReachableReason",
+	127: "ChargingID",
 	128: "EndUserAddress",
 	129: "MMContext",
 	130: "PDPContext",
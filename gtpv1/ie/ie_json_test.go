@@ -0,0 +1,274 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+// jsonCases are the IEs that are encoded into JSON/YAML and then decoded
+// back into the same byte sequence.
+var jsonCases = []struct {
+	description string
+	structured  *ie.IE
+}{
+	{
+		"IMSI",
+		ie.NewIMSI("123451234567890"),
+	}, {
+		"PacketTMSI",
+		ie.NewPacketTMSI(0xbeebee),
+	}, {
+		"AuthenticationTriplet",
+		ie.NewAuthenticationTriplet(
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			[]byte{0xde, 0xad, 0xbe, 0xef},
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77},
+		),
+	}, {
+		"MAPCause",
+		ie.NewMAPCause(gtpv1.MAPCauseSystemFailure),
+	}, {
+		"PTMSISignature",
+		ie.NewPTMSISignature(0xbeebee),
+	}, {
+		"MSValidated",
+		ie.NewMSValidated(true),
+	}, {
+		"Recovery",
+		ie.NewRecovery(1),
+	}, {
+		"SelectionMode",
+		ie.NewSelectionMode(gtpv1.SelectionModeMSorNetworkProvidedAPNSubscribedVerified),
+	}, {
+		"TEIDDataI",
+		ie.NewTEIDDataI(0xdeadbeef),
+	}, {
+		"TEIDCPlane",
+		ie.NewTEIDCPlane(0xdeadbeef),
+	}, {
+		"TEIDDataII",
+		ie.NewTEIDDataII(0xdeadbeef),
+	}, {
+		"TeardownInd",
+		ie.NewTeardownInd(true),
+	}, {
+		"NSAPI",
+		ie.NewNSAPI(0x05),
+	}, {
+		"RANAPCause",
+		ie.NewRANAPCause(gtpv1.MAPCauseUnknownSubscriber),
+	}, {
+		"EndUserAddress/v4",
+		ie.NewEndUserAddress("1.1.1.1"),
+	}, {
+		"EndUserAddress/v6",
+		ie.NewEndUserAddress("2001::1"),
+	}, {
+		"AccessPointName",
+		ie.NewAccessPointName("some.apn.example"),
+	}, {
+		"GSNAddressV4",
+		ie.NewGSNAddress("1.1.1.1"),
+	}, {
+		"GSNAddressV6",
+		ie.NewGSNAddress("2001::1"),
+	}, {
+		"MSISDN",
+		ie.NewMSISDN("818012345678"),
+	}, {
+		"AuthenticationQuintuplet",
+		ie.NewAuthenticationQuintuplet(
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+			[]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+		),
+	}, {
+		"ExtensionHeaderTypeList",
+		ie.NewExtensionHeaderTypeList(
+			message.ExtHeaderTypePDUSessionContainer,
+			message.ExtHeaderTypeUDPPort,
+		),
+	}, {
+		"CommonFlags",
+		ie.NewCommonFlags(0, 1, 0, 0, 0, 0, 0, 0),
+	}, {
+		"APNRestriction",
+		ie.NewAPNRestriction(gtpv1.APNRestrictionPrivate1),
+	}, {
+		"RATType",
+		ie.NewRATType(gtpv1.RatTypeEUTRAN),
+	}, {
+		"UserLocationInformationWithCGI",
+		ie.NewUserLocationInformationWithCGI("123", "45", 0xff, 0),
+	}, {
+		"UserLocationInformationWithSAI",
+		ie.NewUserLocationInformationWithSAI("123", "45", 0xff, 0),
+	}, {
+		"UserLocationInformationWithRAI",
+		ie.NewUserLocationInformationWithRAI("123", "45", 0xff, 0),
+	}, {
+		"MSTimeZone",
+		ie.NewMSTimeZone(9*time.Hour, 0),
+	}, {
+		"MSTimeZone",
+		ie.NewMSTimeZone(2*time.Hour, 0),
+	}, {
+		"IMEISV",
+		ie.NewIMEISV("123450123456789"),
+	}, {
+		"ULITimestamp",
+		ie.NewULITimestamp(time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}, {
+		"ChargingID",
+		ie.NewChargingID(0xffffffff),
+	}, {
+		"PrivateExtension",
+		ie.NewPrivateExtension(0x0080, []byte{0xde, 0xad, 0xbe, 0xef}),
+	},
+}
+
+func TestIEsJSONAndYAML(t *testing.T) {
+	for _, c := range jsonCases {
+		want, err := c.structured.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("json/"+c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+
+		t.Run("yaml/"+c.description, func(t *testing.T) {
+			b, err := yaml.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := yaml.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+	}
+}
+
+func TestIEJSONDocument(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  *ie.IE
+		document    string
+	}{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			`{"type":"IMSI","value":"123451234567890"}`,
+		}, {
+			"Cause",
+			ie.NewCause(gtpv1.ResCauseRequestAccepted),
+			`{"type":"Cause","value":128}`,
+		}, {
+			"TeardownInd",
+			ie.NewTeardownInd(true),
+			`{"type":"TeardownInd","value":true}`,
+		}, {
+			"EndUserAddress",
+			ie.NewEndUserAddress("10.0.0.1"),
+			`{"type":"EndUserAddress","value":{"pdpTypeOrganization":1,"pdpTypeNumber":33,"ipv4":"10.0.0.1"}}`,
+		}, {
+			"UserLocationInformation",
+			ie.NewUserLocationInformationWithSAI("123", "45", 0x1111, 0x2222),
+			`{"type":"UserLocationInformation","value":{"sai":{"mcc":"123","mnc":"45","lac":4369,"sac":8738}}}`,
+		}, {
+			"ULITimestamp",
+			ie.NewULITimestamp(time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)),
+			`{"type":"ULITimestamp","value":"2019-01-01T00:00:00Z"}`,
+		}, {
+			"QoSProfile",
+			ie.NewQoSProfile([]byte{0x01, 0x02}),
+			`{"type":"QoSProfile","payload":"0102"}`,
+		}, {
+			"Unknown",
+			ie.New(250, []byte{0xde, 0xad}),
+			`{"type":250,"payload":"dead"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal([]byte(c.document), got); err != nil {
+				t.Fatal(err)
+			}
+			want, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIEJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"type":"NoSuchIE"}`,
+		`{"type":256}`,
+		`{"type":"IMSI","payload":"2143"}`,
+		`{"type":"IMSI","value":1}`,
+		`{"type":250,"value":1}`,
+		`{"type":"UserLocationInformation","value":{}}`,
+	} {
+		if err := json.Unmarshal([]byte(doc), &ie.IE{}); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
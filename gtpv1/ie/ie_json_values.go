@@ -0,0 +1,304 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// codecs are the codecs of IEs represented with the value in JSON and YAML, by
+// IE type. The IEs not listed here are represented with the payload in hex.
+var codecs = map[uint8]codec{
+	Cause:                 simpleCodec((*IE).Cause, NewCause),
+	IMSI:                  simpleCodec((*IE).IMSI, NewIMSI),
+	PacketTMSI:            simpleCodec((*IE).PacketTMSI, NewPacketTMSI),
+	MAPCause:              simpleCodec((*IE).MAPCause, NewMAPCause),
+	PTMSISignature:        simpleCodec((*IE).PTMSISignature, NewPTMSISignature),
+	Recovery:              simpleCodec((*IE).Recovery, NewRecovery),
+	SelectionMode:         simpleCodec((*IE).SelectionMode, NewSelectionMode),
+	TEIDDataI:             simpleCodec((*IE).TEID, NewTEIDDataI),
+	TEIDCPlane:            simpleCodec((*IE).TEID, NewTEIDCPlane),
+	TEIDDataII:            simpleCodec((*IE).TEID, NewTEIDDataII),
+	NSAPI:                 simpleCodec((*IE).NSAPI, NewNSAPI),
+	RANAPCause:            simpleCodec((*IE).RANAPCause, NewRANAPCause),
+	ChargingID:            simpleCodec((*IE).ChargingID, NewChargingID),
+	AccessPointName:       simpleCodec((*IE).AccessPointName, NewAccessPointName),
+	GSNAddress:            simpleCodec((*IE).GSNAddress, NewGSNAddress),
+	MSISDN:                simpleCodec((*IE).MSISDN, NewMSISDN),
+	APNRestriction:        simpleCodec((*IE).APNRestriction, NewAPNRestriction),
+	RATType:               simpleCodec((*IE).RATType, NewRATType),
+	IMEISV:                simpleCodec((*IE).IMEISV, NewIMEISV),
+	ReorderingRequired:    boolCodec(ReorderingRequired, (*IE).ReorderingRequired, NewReorderingRequired),
+	MSValidated:           boolCodec(MSValidated, (*IE).MSValidated, NewMSValidated),
+	TeardownInd:           boolCodec(TeardownInd, (*IE).TeardownInd, NewTeardownInd),
+	CommonFlags:           uint8Codec(CommonFlags),
+	ExtendedCommonFlags:   uint8Codec(ExtendedCommonFlags),
+	ExtendedCommonFlagsII: uint8Codec(ExtendedCommonFlagsII),
+
+	RouteingAreaIdentity:         valueCodec[raiValue]{decodeRAI, encodeRAI},
+	EndUserAddress:               valueCodec[euaValue]{decodeEUA, encodeEUA},
+	ProtocolConfigurationOptions: valueCodec[pcoValue]{decodePCO, encodePCO},
+	ExtensionHeaderTypeList:      valueCodec[[]uint16]{decodeEHTypeList, encodeEHTypeList},
+	UserLocationInformation:      valueCodec[uliValue]{decodeULI, encodeULI},
+	MSTimeZone:                   valueCodec[timeZoneValue]{decodeTimeZone, encodeTimeZone},
+	ULITimestamp:                 valueCodec[time.Time]{decodeULITimestamp, encodeULITimestamp},
+	PrivateExtension:             valueCodec[privateExtensionValue]{decodePrivateExtension, encodePrivateExtension},
+}
+
+type raiValue struct {
+	MCC string `json:"mcc" yaml:"mcc"`
+	MNC string `json:"mnc" yaml:"mnc"`
+	LAC uint16 `json:"lac" yaml:"lac"`
+	RAC uint8  `json:"rac" yaml:"rac"`
+}
+
+func decodeRAI(i *IE) (raiValue, error) {
+	if len(i.Payload) < 6 {
+		return raiValue{}, io.ErrUnexpectedEOF
+	}
+	return raiValue{i.MustMCC(), i.MustMNC(), i.MustLAC(), i.MustRAC()}, nil
+}
+
+func encodeRAI(v raiValue) (*IE, error) {
+	if i := NewRouteingAreaIdentity(v.MCC, v.MNC, v.LAC, v.RAC); i != nil {
+		return i, nil
+	}
+	return nil, ErrMalformed
+}
+
+// euaValue is the value of EndUserAddress IE. The PDPTypeOrganization is the
+// lower 4 bits of the first octet. The address is empty if not allocated.
+type euaValue struct {
+	PDPTypeOrganization uint8  `json:"pdpTypeOrganization" yaml:"pdpTypeOrganization"`
+	PDPTypeNumber       uint8  `json:"pdpTypeNumber" yaml:"pdpTypeNumber"`
+	IPv4                string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6                string `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+}
+
+func decodeEUA(i *IE) (euaValue, error) {
+	org, err := i.PDPTypeOrganization()
+	if err != nil {
+		return euaValue{}, err
+	}
+	num, err := i.PDPTypeNumber()
+	if err != nil {
+		return euaValue{}, err
+	}
+
+	v := euaValue{PDPTypeOrganization: org & 0x0f, PDPTypeNumber: num}
+	switch addr := i.Payload[2:]; len(addr) {
+	case 0:
+	case net.IPv4len:
+		v.IPv4 = net.IP(addr).String()
+	case net.IPv6len:
+		v.IPv6 = net.IP(addr).String()
+	case net.IPv4len + net.IPv6len:
+		v.IPv4 = net.IP(addr[:net.IPv4len]).String()
+		v.IPv6 = net.IP(addr[net.IPv4len:]).String()
+	default:
+		return euaValue{}, ErrInvalidLength
+	}
+	return v, nil
+}
+
+func encodeEUA(v euaValue) (*IE, error) {
+	b := []byte{0xf0 | v.PDPTypeOrganization, v.PDPTypeNumber}
+	if v.IPv4 != "" {
+		ip, err := parseIP(v.IPv4)
+		if err != nil {
+			return nil, err
+		}
+		if ip.To4() == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q: %w", v.IPv4, ErrMalformed)
+		}
+		b = append(b, ip.To4()...)
+	}
+	if v.IPv6 != "" {
+		ip, err := parseIP(v.IPv6)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ip.To16()...)
+	}
+	return New(EndUserAddress, b), nil
+}
+
+type pcoOptionValue struct {
+	ProtocolID uint16   `json:"protocolID" yaml:"protocolID"`
+	Contents   hexBytes `json:"contents,omitempty" yaml:"contents,omitempty"`
+}
+
+type pcoValue struct {
+	ConfigurationProtocol uint8            `json:"configurationProtocol" yaml:"configurationProtocol"`
+	Options               []pcoOptionValue `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+func decodePCO(i *IE) (pcoValue, error) {
+	p, err := i.ProtocolConfigurationOptions()
+	if err != nil {
+		return pcoValue{}, err
+	}
+	v := pcoValue{ConfigurationProtocol: p.ConfigurationProtocol}
+	for _, o := range p.ConfigurationProtocolOptions {
+		v.Options = append(v.Options, pcoOptionValue{o.ProtocolID, o.Contents})
+	}
+	return v, nil
+}
+
+func encodePCO(v pcoValue) (*IE, error) {
+	opts := make([]*ConfigurationProtocolOption, len(v.Options))
+	for n, o := range v.Options {
+		opts[n] = NewConfigurationProtocolOption(o.ProtocolID, o.Contents)
+	}
+	if i := NewProtocolConfigurationOptions(v.ConfigurationProtocol, opts...); i != nil {
+		return i, nil
+	}
+	return nil, ErrMalformed
+}
+
+// decodeEHTypeList returns the types in the list as numbers, as []uint8 is
+// represented in base64 in JSON.
+func decodeEHTypeList(i *IE) ([]uint16, error) {
+	types, err := i.ExtensionHeaderTypeList()
+	if err != nil {
+		return nil, err
+	}
+	v := make([]uint16, len(types))
+	for n, t := range types {
+		v[n] = uint16(t)
+	}
+	return v, nil
+}
+
+func encodeEHTypeList(v []uint16) (*IE, error) {
+	types := make([]uint8, len(v))
+	for n, t := range v {
+		if t > 0xff {
+			return nil, fmt.Errorf("invalid extension header type %d: %w", t, ErrMalformed)
+		}
+		types[n] = uint8(t)
+	}
+	return NewExtensionHeaderTypeList(types...), nil
+}
+
+type locationValue struct {
+	MCC string `json:"mcc" yaml:"mcc"`
+	MNC string `json:"mnc" yaml:"mnc"`
+	LAC uint16 `json:"lac" yaml:"lac"`
+	CI  uint16 `json:"ci,omitempty" yaml:"ci,omitempty"`
+	SAC uint16 `json:"sac,omitempty" yaml:"sac,omitempty"`
+	RAC uint8  `json:"rac,omitempty" yaml:"rac,omitempty"`
+}
+
+// uliValue is the value of UserLocationInformation IE, which has one of the
+// locations given by the geographic location type.
+type uliValue struct {
+	CGI *locationValue `json:"cgi,omitempty" yaml:"cgi,omitempty"`
+	SAI *locationValue `json:"sai,omitempty" yaml:"sai,omitempty"`
+	RAI *locationValue `json:"rai,omitempty" yaml:"rai,omitempty"`
+}
+
+func decodeULI(i *IE) (uliValue, error) {
+	if i.Type != UserLocationInformation {
+		return uliValue{}, &InvalidTypeError{Type: i.Type}
+	}
+	if len(i.Payload) < 7 {
+		return uliValue{}, io.ErrUnexpectedEOF
+	}
+
+	l := &locationValue{MCC: i.MustMCC(), MNC: i.MustMNC(), LAC: i.MustLAC()}
+	switch i.Payload[0] {
+	case locTypeCGI:
+		l.CI = i.MustCGI()
+		return uliValue{CGI: l}, nil
+	case locTypeSAI:
+		l.SAC = i.MustSAC()
+		return uliValue{SAI: l}, nil
+	case locTypeRAI:
+		l.RAC = i.MustRAC()
+		return uliValue{RAI: l}, nil
+	default:
+		return uliValue{}, ErrMalformed
+	}
+}
+
+func encodeULI(v uliValue) (*IE, error) {
+	var i *IE
+	switch {
+	case v.CGI != nil && v.SAI == nil && v.RAI == nil:
+		i = NewUserLocationInformationWithCGI(v.CGI.MCC, v.CGI.MNC, v.CGI.LAC, v.CGI.CI)
+	case v.SAI != nil && v.CGI == nil && v.RAI == nil:
+		i = NewUserLocationInformationWithSAI(v.SAI.MCC, v.SAI.MNC, v.SAI.LAC, v.SAI.SAC)
+	case v.RAI != nil && v.CGI == nil && v.SAI == nil:
+		i = NewUserLocationInformationWithRAI(v.RAI.MCC, v.RAI.MNC, v.RAI.LAC, v.RAI.RAC)
+	default:
+		return nil, fmt.Errorf("exactly one of cgi, sai and rai should be given: %w", ErrMalformed)
+	}
+	if i == nil {
+		return nil, ErrMalformed
+	}
+	return i, nil
+}
+
+// timeZoneValue is the value of MSTimeZone IE. TimeZone is in the format of
+// time.Duration, e.g. "9h0m0s" or "-4h30m0s".
+type timeZoneValue struct {
+	TimeZone       string `json:"timeZone" yaml:"timeZone"`
+	DaylightSaving uint8  `json:"daylightSaving" yaml:"daylightSaving"`
+}
+
+func decodeTimeZone(i *IE) (timeZoneValue, error) {
+	tz, err := i.TimeZone()
+	if err != nil {
+		return timeZoneValue{}, err
+	}
+	ds, err := i.DaylightSaving()
+	if err != nil {
+		return timeZoneValue{}, err
+	}
+	return timeZoneValue{tz.String(), ds}, nil
+}
+
+func encodeTimeZone(v timeZoneValue) (*IE, error) {
+	tz, err := time.ParseDuration(v.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return NewMSTimeZone(tz, v.DaylightSaving), nil
+}
+
+// decodeULITimestamp returns the timestamp in UTC, so that the document does
+// not depend on the local time zone.
+func decodeULITimestamp(i *IE) (time.Time, error) {
+	ts, err := i.Timestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.UTC(), nil
+}
+
+func encodeULITimestamp(v time.Time) (*IE, error) {
+	return NewULITimestamp(v), nil
+}
+
+type privateExtensionValue struct {
+	ExtensionIdentifier uint16   `json:"extensionIdentifier" yaml:"extensionIdentifier"`
+	ExtensionValue      hexBytes `json:"extensionValue,omitempty" yaml:"extensionValue,omitempty"`
+}
+
+func decodePrivateExtension(i *IE) (privateExtensionValue, error) {
+	id, err := i.ExtensionIdentifier()
+	if err != nil {
+		return privateExtensionValue{}, err
+	}
+	return privateExtensionValue{id, i.Payload[2:]}, nil
+}
+
+func encodePrivateExtension(v privateExtensionValue) (*IE, error) {
+	return NewPrivateExtension(v.ExtensionIdentifier, v.ExtensionValue), nil
+}
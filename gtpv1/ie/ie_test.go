@@ -85,6 +85,10 @@ func TestIEs(t *testing.T) {
 			"RANAPCause",
 			ie.NewRANAPCause(gtpv1.MAPCauseUnknownSubscriber),
 			[]byte{0x15, 0x01},
+		}, {
+			"ChargingCharacteristics",
+			ie.NewChargingCharacteristics(0x0800),
+			[]byte{0x1a, 0x08, 0x00},
 		}, {
 			"EndUserAddress/v4",
 			ie.NewEndUserAddress("1.1.1.1"),
@@ -96,6 +100,32 @@ func TestIEs(t *testing.T) {
 				0x80, 0x00, 0x12, 0xf1,
 				0x57, 0x20, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
 			},
+		}, {
+			"EndUserAddress/v4v6",
+			ie.NewEndUserAddressIPv4v6("1.1.1.1", "2001::1"),
+			[]byte{
+				0x80, 0x00, 0x16, 0xf1, 0x8d, 0x01, 0x01, 0x01, 0x01,
+				0x20, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			},
+		}, {
+			"EndUserAddress/v4v6-v4-only",
+			ie.NewEndUserAddressIPv4v6("1.1.1.1", ""),
+			[]byte{0x80, 0x00, 0x06, 0xf1, 0x8d, 0x01, 0x01, 0x01, 0x01},
+		}, {
+			"EndUserAddress/v4v6-v6-only",
+			ie.NewEndUserAddressIPv4v6("", "2001::1"),
+			[]byte{
+				0x80, 0x00, 0x12, 0xf1,
+				0x8d, 0x20, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			},
+		}, {
+			"EndUserAddress/v4v6-dynamic",
+			ie.NewEndUserAddressIPv4v6("", ""),
+			[]byte{0x80, 0x00, 0x02, 0xf1, 0x8d},
+		}, {
+			"EndUserAddress/non-ip",
+			ie.NewEndUserAddressNonIP(),
+			[]byte{0x80, 0x00, 0x02, 0xf0, 0x02},
 		}, {
 			"AccessPointName",
 			ie.NewAccessPointName("some.apn.example"),
@@ -114,6 +144,30 @@ func TestIEs(t *testing.T) {
 				0x85, 0x00, 0x10,
 				0x20, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
 			},
+		}, {
+			"QoSProfile",
+			ie.NewQoSProfileFromFields(&ie.QoSProfileFields{
+				AllocationRetentionPriority: 2,
+				DelayClass:                  4,
+				ReliabilityClass:            3,
+				PeakThroughput:              9,
+				PrecedenceClass:             2,
+				MeanThroughput:              0x1f,
+				TrafficClass:                ie.TrafficClassInteractive,
+				DeliveryOrder:               2,
+				DeliveryOfErroneousSDU:      3,
+				MaximumSDUSize:              0x96,
+				MaximumBitRateForUplink:     64,
+				MaximumBitRateForDownlink:   8640,
+				ResidualBER:                 7,
+				SDUErrorRatio:               4,
+				TrafficHandlingPriority:     1,
+				SignallingIndication:        true,
+			}),
+			[]byte{
+				0x87, 0x00, 0x0d,
+				0x02, 0x23, 0x92, 0x1f, 0x73, 0x96, 0x40, 0xfe, 0x74, 0x01, 0xff, 0xff, 0x10,
+			},
 		}, {
 			"MSISDN",
 			ie.NewMSISDN("818012345678"),
@@ -180,6 +234,14 @@ func TestIEs(t *testing.T) {
 			"IMEISV",
 			ie.NewIMEISV("123450123456789"),
 			[]byte{0x9a, 0x00, 0x08, 0x21, 0x43, 0x05, 0x21, 0x43, 0x65, 0x87, 0xf9},
+		}, {
+			"EvolvedAllocationRetentionPriorityI",
+			ie.NewEvolvedAllocationRetentionPriorityI(1, 9, 0),
+			[]byte{0xbf, 0x00, 0x01, 0x64},
+		}, {
+			"AggregateMaximumBitRate",
+			ie.NewAggregateMaximumBitRate(0x11111111, 0x22222222),
+			[]byte{0xc6, 0x00, 0x08, 0x11, 0x11, 0x11, 0x11, 0x22, 0x22, 0x22, 0x22},
 		}, {
 			"ULITimestamp",
 			ie.NewULITimestamp(time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)),
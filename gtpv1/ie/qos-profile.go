@@ -4,16 +4,31 @@
 
 package ie
 
+import (
+	"io"
+)
+
 // NewQoSProfile creates a new QoSProfile IE.
 //
 // XXX - NOT Fully implemented. Users need to put the whole payload in []byte.
+// Use NewQoSProfileFromFields to create it from the structured fields.
 func NewQoSProfile(payload []byte) *IE {
 	return New(QoSProfile, payload)
 }
 
+// NewQoSProfileFromFields creates a new QoSProfile IE from QoSProfileFields.
+func NewQoSProfileFromFields(f *QoSProfileFields) *IE {
+	b, err := f.Marshal()
+	if err != nil {
+		return nil
+	}
+	return New(QoSProfile, b)
+}
+
 // QoSProfile returns QoSProfile if type matches.
 //
 // XXX - NOT Fully implemented. This method just returns the whole payload in []byte.
+// Use QoSProfileFields to get the structured fields.
 func (i *IE) QoSProfile() ([]byte, error) {
 	if i.Type != QoSProfile {
 		return nil, &InvalidTypeError{Type: i.Type}
@@ -27,3 +42,305 @@ func (i *IE) MustQoSProfile() []byte {
 	v, _ := i.QoSProfile()
 	return v
 }
+
+// QoSProfileFields returns QoSProfile in QoSProfileFields if type matches.
+func (i *IE) QoSProfileFields() (*QoSProfileFields, error) {
+	if i.Type != QoSProfile {
+		return nil, &InvalidTypeError{Type: i.Type}
+	}
+	return ParseQoSProfileFields(i.Payload)
+}
+
+// Traffic Class definitions.
+const (
+	TrafficClassSubscribed uint8 = iota
+	TrafficClassConversational
+	TrafficClassStreaming
+	TrafficClassInteractive
+	TrafficClassBackground
+)
+
+// Source Statistics Descriptor definitions.
+const (
+	SourceStatisticsDescriptorUnknown uint8 = iota
+	SourceStatisticsDescriptorSpeech
+)
+
+// The lengths of the QoSProfile payload, which depend on the release of the QoS
+// Profile (TS 24.008 10.5.6.5) and the range of the bit rates.
+const (
+	qosLenR97        = 4
+	qosLenR99        = 12
+	qosLenR5         = 13
+	qosLenExtended   = 17
+	qosLenExtended2  = 21
+	maxBitRate       = 8640     // kbps
+	maxBitRateExt    = 256000   // kbps
+	maxBitRateExt2   = 10000000 // kbps
+	maxTransferDelay = 4000     // ms
+)
+
+// QoSProfileFields is a set of fields in QoSProfile IE, which is the Allocation/Retention
+// Priority and the Quality of Service defined in TS 24.008 10.5.6.5.
+//
+// The bit rates are in kbps, and the transfer delay is in milliseconds. They are
+// rounded down to the nearest values that can be encoded. The other fields are the
+// values as encoded.
+type QoSProfileFields struct {
+	AllocationRetentionPriority uint8
+
+	DelayClass       uint8 // 3-bit
+	ReliabilityClass uint8 // 3-bit
+	PeakThroughput   uint8 // 4-bit
+	PrecedenceClass  uint8 // 3-bit
+	MeanThroughput   uint8 // 5-bit
+
+	TrafficClass                 uint8 // 3-bit
+	DeliveryOrder                uint8 // 2-bit
+	DeliveryOfErroneousSDU       uint8 // 3-bit
+	MaximumSDUSize               uint8
+	MaximumBitRateForUplink      uint32
+	MaximumBitRateForDownlink    uint32
+	ResidualBER                  uint8 // 4-bit
+	SDUErrorRatio                uint8 // 4-bit
+	TransferDelay                uint16
+	TrafficHandlingPriority      uint8 // 2-bit
+	GuaranteedBitRateForUplink   uint32
+	GuaranteedBitRateForDownlink uint32
+
+	SignallingIndication       bool
+	SourceStatisticsDescriptor uint8 // 4-bit
+}
+
+// Marshal serializes QoSProfileFields.
+func (f *QoSProfileFields) Marshal() ([]byte, error) {
+	b := make([]byte, f.MarshalLen())
+	if err := f.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo serializes QoSProfileFields.
+func (f *QoSProfileFields) MarshalTo(b []byte) error {
+	l := f.MarshalLen()
+	if len(b) < l {
+		return io.ErrUnexpectedEOF
+	}
+
+	b[0] = f.AllocationRetentionPriority
+	b[1] = (f.DelayClass & 0x07 << 3) | (f.ReliabilityClass & 0x07)
+	b[2] = (f.PeakThroughput << 4) | (f.PrecedenceClass & 0x07)
+	b[3] = f.MeanThroughput & 0x1f
+	b[4] = (f.TrafficClass << 5) | (f.DeliveryOrder & 0x03 << 3) | (f.DeliveryOfErroneousSDU & 0x07)
+	b[5] = f.MaximumSDUSize
+	b[8] = (f.ResidualBER << 4) | (f.SDUErrorRatio & 0x0f)
+	b[9] = (encodeTransferDelay(f.TransferDelay) << 2) | (f.TrafficHandlingPriority & 0x03)
+
+	// the octets for the bit rates are in the order of the base, extended and
+	// extended-2 ones, and the downlink comes before the uplink in the extended ones.
+	for _, r := range []struct {
+		kbps      uint32
+		base, ext int
+	}{
+		{f.MaximumBitRateForUplink, 6, 2},
+		{f.MaximumBitRateForDownlink, 7, 0},
+		{f.GuaranteedBitRateForUplink, 10, 3},
+		{f.GuaranteedBitRateForDownlink, 11, 1},
+	} {
+		base, ext, ext2 := encodeBitRate(r.kbps)
+		b[r.base] = base
+		if l >= qosLenExtended {
+			b[13+r.ext] = ext
+		}
+		if l >= qosLenExtended2 {
+			b[17+r.ext] = ext2
+		}
+	}
+
+	if l >= qosLenR5 {
+		b[12] = f.SourceStatisticsDescriptor & 0x0f
+		if f.SignallingIndication {
+			b[12] |= 0x10
+		}
+	}
+	return nil
+}
+
+// ParseQoSProfileFields decodes QoSProfileFields.
+func ParseQoSProfileFields(b []byte) (*QoSProfileFields, error) {
+	f := &QoSProfileFields{}
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnmarshalBinary decodes given bytes into QoSProfileFields.
+//
+// The QoS Profile of R97/98 that has only the first three octets of the Quality
+// of Service is also accepted, and the fields added in the later releases are
+// left zero.
+func (f *QoSProfileFields) UnmarshalBinary(b []byte) error {
+	l := len(b)
+	if l < qosLenR97 {
+		return io.ErrUnexpectedEOF
+	}
+
+	f.AllocationRetentionPriority = b[0]
+	f.DelayClass = b[1] >> 3 & 0x07
+	f.ReliabilityClass = b[1] & 0x07
+	f.PeakThroughput = b[2] >> 4
+	f.PrecedenceClass = b[2] & 0x07
+	f.MeanThroughput = b[3] & 0x1f
+	if l < qosLenR99 {
+		return nil
+	}
+
+	f.TrafficClass = b[4] >> 5
+	f.DeliveryOrder = b[4] >> 3 & 0x03
+	f.DeliveryOfErroneousSDU = b[4] & 0x07
+	f.MaximumSDUSize = b[5]
+	f.ResidualBER = b[8] >> 4
+	f.SDUErrorRatio = b[8] & 0x0f
+	f.TransferDelay = decodeTransferDelay(b[9] >> 2)
+	f.TrafficHandlingPriority = b[9] & 0x03
+
+	bitRate := func(base, ext int) uint32 {
+		var e, e2 uint8
+		if l >= qosLenExtended {
+			e = b[13+ext]
+		}
+		if l >= qosLenExtended2 {
+			e2 = b[17+ext]
+		}
+		return decodeBitRate(b[base], e, e2)
+	}
+	f.MaximumBitRateForUplink = bitRate(6, 2)
+	f.MaximumBitRateForDownlink = bitRate(7, 0)
+	f.GuaranteedBitRateForUplink = bitRate(10, 3)
+	f.GuaranteedBitRateForDownlink = bitRate(11, 1)
+
+	if l >= qosLenR5 {
+		f.SignallingIndication = b[12]&0x10 != 0
+		f.SourceStatisticsDescriptor = b[12] & 0x0f
+	}
+	return nil
+}
+
+// MarshalLen returns the serial length of QoSProfileFields in int.
+//
+// The octets for the extended bit rates are included only when any of the bit
+// rates needs them.
+func (f *QoSProfileFields) MarshalLen() int {
+	highest := max(
+		f.MaximumBitRateForUplink, f.MaximumBitRateForDownlink,
+		f.GuaranteedBitRateForUplink, f.GuaranteedBitRateForDownlink,
+	)
+
+	switch {
+	case highest > maxBitRateExt:
+		return qosLenExtended2
+	case highest > maxBitRate:
+		return qosLenExtended
+	default:
+		return qosLenR5
+	}
+}
+
+// encodeBitRate encodes the bit rate in kbps into the octets of the base, extended
+// and extended-2 ones defined in TS 24.008 10.5.6.5.
+func encodeBitRate(kbps uint32) (base, ext, ext2 uint8) {
+	switch {
+	case kbps == 0:
+		return 0xff, 0, 0
+	case kbps < 64:
+		return uint8(kbps), 0, 0
+	case kbps < 576:
+		return uint8(0x40 + (kbps-64)/8), 0, 0
+	case kbps <= maxBitRate:
+		return uint8(0x80 + (kbps-576)/64), 0, 0
+	case kbps <= 16000:
+		return 0xfe, uint8((kbps - 8600) / 100), 0
+	case kbps <= 128000:
+		return 0xfe, uint8(0x4a + (kbps-16000)/1000), 0
+	case kbps <= maxBitRateExt:
+		return 0xfe, uint8(0xba + (kbps-128000)/2000), 0
+	case kbps <= 500000:
+		return 0xfe, 0xfa, uint8((kbps - 256000) / 4000)
+	case kbps <= 1500000:
+		return 0xfe, 0xfa, uint8(0x3d + (kbps-500000)/10000)
+	case kbps <= maxBitRateExt2:
+		return 0xfe, 0xfa, uint8(0xa1 + (kbps-1500000)/100000)
+	default:
+		return 0xfe, 0xfa, 0xf6
+	}
+}
+
+// decodeBitRate decodes the octets of the base, extended and extended-2 bit rates
+// into the bit rate in kbps.
+func decodeBitRate(base, ext, ext2 uint8) uint32 {
+	if base == 0xfe && ext != 0 {
+		if ext == 0xfa && ext2 != 0 {
+			switch {
+			case ext2 <= 0x3d:
+				return 256000 + uint32(ext2)*4000
+			case ext2 <= 0xa1:
+				return 500000 + uint32(ext2-0x3d)*10000
+			default:
+				return 1500000 + uint32(ext2-0xa1)*100000
+			}
+		}
+
+		switch {
+		case ext <= 0x4a:
+			return 8600 + uint32(ext)*100
+		case ext <= 0xba:
+			return 16000 + uint32(ext-0x4a)*1000
+		default:
+			return 128000 + uint32(ext-0xba)*2000
+		}
+	}
+
+	switch {
+	case base == 0xff, base == 0:
+		return 0
+	case base < 0x40:
+		return uint32(base)
+	case base < 0x80:
+		return 64 + uint32(base-0x40)*8
+	default:
+		return 576 + uint32(base-0x80)*64
+	}
+}
+
+// encodeTransferDelay encodes the transfer delay in milliseconds into the 6-bit
+// value defined in TS 24.008 10.5.6.5.
+func encodeTransferDelay(ms uint16) uint8 {
+	switch {
+	case ms == 0:
+		return 0
+	case ms < 200:
+		return uint8(max(ms/10, 1))
+	case ms < 1000:
+		return uint8(0x10 + (ms-200)/50)
+	case ms <= maxTransferDelay:
+		return uint8(0x20 + (ms-1000)/100)
+	default:
+		return 0x3e
+	}
+}
+
+// decodeTransferDelay decodes the 6-bit transfer delay into milliseconds.
+func decodeTransferDelay(v uint8) uint16 {
+	switch {
+	case v == 0, v == 0x3f:
+		return 0
+	case v < 0x10:
+		return uint16(v) * 10
+	case v < 0x20:
+		return 200 + uint16(v-0x10)*50
+	default:
+		return 1000 + uint16(v-0x20)*100
+	}
+}
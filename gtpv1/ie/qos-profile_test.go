@@ -0,0 +1,83 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+func TestQoSProfileFields(t *testing.T) {
+	cases := []struct {
+		description string
+		kbps, want  uint32
+		length      int
+	}{
+		{"zero", 0, 0, 13},
+		{"1kbps step", 63, 63, 13},
+		{"8kbps step", 500, 496, 13},
+		{"64kbps step", 8640, 8640, 13},
+		{"100kbps step", 16000, 16000, 17},
+		{"1Mbps step", 100500, 100000, 17},
+		{"2Mbps step", 256000, 256000, 17},
+		{"4Mbps step", 300000, 300000, 21},
+		{"10Mbps step", 1000000, 1000000, 21},
+		{"100Mbps step", 10000000, 10000000, 21},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			i := ie.NewQoSProfileFromFields(&ie.QoSProfileFields{
+				TrafficClass:                 ie.TrafficClassConversational,
+				MaximumBitRateForUplink:      c.kbps,
+				MaximumBitRateForDownlink:    c.kbps,
+				GuaranteedBitRateForUplink:   c.kbps,
+				GuaranteedBitRateForDownlink: c.kbps,
+				TransferDelay:                150,
+				SourceStatisticsDescriptor:   ie.SourceStatisticsDescriptorSpeech,
+			})
+			if diff := cmp.Diff(len(i.Payload), c.length); diff != "" {
+				t.Error(diff)
+			}
+
+			got, err := i.QoSProfileFields()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &ie.QoSProfileFields{
+				TrafficClass:                 ie.TrafficClassConversational,
+				MaximumBitRateForUplink:      c.want,
+				MaximumBitRateForDownlink:    c.want,
+				GuaranteedBitRateForUplink:   c.want,
+				GuaranteedBitRateForDownlink: c.want,
+				TransferDelay:                150,
+				SourceStatisticsDescriptor:   ie.SourceStatisticsDescriptorSpeech,
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("R97", func(t *testing.T) {
+		got, err := ie.NewQoSProfile([]byte{0x01, 0x23, 0x92, 0x1f}).QoSProfileFields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &ie.QoSProfileFields{
+			AllocationRetentionPriority: 1,
+			DelayClass:                  4,
+			ReliabilityClass:            3,
+			PeakThroughput:              9,
+			PrecedenceClass:             2,
+			MeanThroughput:              0x1f,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+// Packet is a message sent or received by UPlaneConn, given to Interceptor.
+type Packet struct {
+	// Peer is the address of the endpoint that the message is sent to or
+	// received from.
+	Peer net.Addr
+
+	// Message is the message parsed from Raw. It is nil if Raw cannot be parsed.
+	Message message.Message
+
+	// Raw is the message in bytes that is actually sent or received.
+	// It should not be modified; use SetMessage instead. It should also be
+	// copied to be retained after Interceptor returns, as the buffer is reused.
+	Raw []byte
+}
+
+// SetMessage replaces the Message in Packet with msg and updates Raw with the
+// bytes encoded from msg. To mutate the Message in place, modify it and call
+// SetMessage with it to let the change take effect.
+func (p *Packet) SetMessage(msg message.Message) error {
+	b, err := message.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode %T: %w", msg, err)
+	}
+
+	p.Message = msg
+	p.Raw = b
+	return nil
+}
+
+// Interceptor is called with every message sent or received by UPlaneConn,
+// including T-PDUs, before it is written to the network or handled.
+//
+// Interceptor can inspect the message, mutate it with Packet.SetMessage, or veto
+// it by returning an error. The vetoed message is neither sent nor handled.
+type Interceptor func(u *UPlaneConn, pkt *Packet) error
+
+type interceptors struct {
+	inbound, outbound []Interceptor
+}
+
+// AddInboundInterceptor adds the Interceptor called with the messages received
+// by UPlaneConn. The Interceptors are called in the order added.
+//
+// The Interceptor is called before the message is relayed, handled, or passed
+// to ReadFromGTP, and also with the message that cannot be parsed, with nil
+// Message in Packet. The message vetoed is discarded and logged at debug level.
+//
+// Note that the T-PDUs are parsed one by one while any Interceptor is added,
+// which costs in the performance of relaying. The T-PDUs handled by Kernel GTP-U
+// never come to the Interceptor.
+func (u *UPlaneConn) AddInboundInterceptor(fn Interceptor) {
+	u.addInterceptor(func(i *interceptors) {
+		i.inbound = append(i.inbound, fn)
+	})
+}
+
+// AddOutboundInterceptor adds the Interceptor called with the messages sent by
+// UPlaneConn, including the ones written with WriteTo and relayed from another
+// UPlaneConn. The Interceptors are called in the order added.
+//
+// If the Interceptor vetoes the message, the method to send it returns an error
+// that wraps ErrMessageVetoed and the error from Interceptor.
+func (u *UPlaneConn) AddOutboundInterceptor(fn Interceptor) {
+	u.addInterceptor(func(i *interceptors) {
+		i.outbound = append(i.outbound, fn)
+	})
+}
+
+// addInterceptor updates the copy of the current interceptors with fn and
+// stores it, not to block the goroutines calling them.
+func (u *UPlaneConn) addInterceptor(fn func(i *interceptors)) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	i := &interceptors{}
+	if cur := u.interceptors.Load(); cur != nil {
+		i.inbound = append(i.inbound, cur.inbound...)
+		i.outbound = append(i.outbound, cur.outbound...)
+	}
+	fn(i)
+	u.interceptors.Store(i)
+}
+
+func (u *UPlaneConn) hasInbound() bool {
+	i := u.interceptors.Load()
+	return i != nil && len(i.inbound) != 0
+}
+
+func (u *UPlaneConn) hasOutbound() bool {
+	i := u.interceptors.Load()
+	return i != nil && len(i.outbound) != 0
+}
+
+// intercept calls the Interceptors in order, stopping at the first one that
+// vetoes the message.
+func (u *UPlaneConn) intercept(fns []Interceptor, pkt *Packet) error {
+	for _, fn := range fns {
+		if err := fn(u, pkt); err != nil {
+			return fmt.Errorf("%w: %w", ErrMessageVetoed, err)
+		}
+	}
+	return nil
+}
+
+// interceptInbound lets the inbound Interceptors inspect the message received,
+// and returns the bytes to be handled.
+func (u *UPlaneConn) interceptInbound(raddr net.Addr, raw []byte) ([]byte, error) {
+	i := u.interceptors.Load()
+	if i == nil || len(i.inbound) == 0 {
+		return raw, nil
+	}
+
+	// the message not parsed is given to the Interceptors as nil.
+	msg, _ := message.Parse(raw)
+	pkt := &Packet{Peer: raddr, Message: msg, Raw: raw}
+	if err := u.intercept(i.inbound, pkt); err != nil {
+		return nil, err
+	}
+	return pkt.Raw, nil
+}
+
+// interceptOutbound lets the outbound Interceptors inspect the message to be
+// sent, and returns the bytes to be written. msg can be nil if b is not made
+// from a message.Message, in which case b is parsed only when needed.
+func (u *UPlaneConn) interceptOutbound(raddr net.Addr, b []byte, msg message.Message) ([]byte, error) {
+	i := u.interceptors.Load()
+	if i == nil || len(i.outbound) == 0 {
+		return b, nil
+	}
+
+	if msg == nil {
+		msg, _ = message.Parse(b)
+	}
+	pkt := &Packet{Peer: raddr, Message: msg, Raw: b}
+	if err := u.intercept(i.outbound, pkt); err != nil {
+		return nil, err
+	}
+	return pkt.Raw, nil
+}
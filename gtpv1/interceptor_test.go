@@ -0,0 +1,120 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+func TestUPlaneConnInterceptors(t *testing.T) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.91:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	srvConn.DisableErrorIndication()
+	// veto the T-PDU with a specific TEID.
+	srvConn.AddInboundInterceptor(func(u *gtpv1.UPlaneConn, pkt *gtpv1.Packet) error {
+		if pkt.Message.MessageType() == message.MsgTypeTPDU && pkt.Message.TEID() == 0x11111111 {
+			return errors.New("vetoed")
+		}
+		return nil
+	})
+	// mutate the Recovery in Echo Response, and veto the T-PDU with a specific TEID.
+	srvConn.AddOutboundInterceptor(func(u *gtpv1.UPlaneConn, pkt *gtpv1.Packet) error {
+		switch msg := pkt.Message.(type) {
+		case *message.EchoResponse:
+			msg.Recovery = ie.NewRecovery(0x42)
+			return pkt.SetMessage(msg)
+		case *message.TPDU:
+			if msg.TEID() == 0x33333333 {
+				return errors.New("not allowed")
+			}
+		}
+		return nil
+	})
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			t.Errorf("failed to listen on %s: %s", srvConn.LocalAddr(), err)
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	cliConn, err := net.ListenPacket("udp", "127.0.0.92:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+
+	for _, m := range []message.Message{
+		message.NewTPDU(0x11111111, []byte{0xde, 0xad}),
+		message.NewTPDU(0x22222222, []byte{0xbe, 0xef}),
+		message.NewEchoRequest(0, ie.NewRecovery(0)),
+	} {
+		b, err := message.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cliConn.WriteTo(b, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buf := make([]byte, 1500)
+	n, _, teid, err := srvConn.ReadFromGTP(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(teid, uint32(0x22222222)); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(buf[:n], []byte{0xbe, 0xef}); diff != "" {
+		t.Error(diff)
+	}
+
+	if err := cliConn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err = cliConn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, ok := msg.(*message.EchoResponse)
+	if !ok {
+		t.Fatalf("got unexpected type of message: %s", msg.MessageTypeName())
+	}
+	recovery, err := res.Recovery.Recovery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(recovery, uint8(0x42)); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := srvConn.WriteToGTP(0x33333333, []byte{0xde, 0xad}, cliConn.LocalAddr()); !errors.Is(err, gtpv1.ErrMessageVetoed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
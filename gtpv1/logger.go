@@ -5,28 +5,32 @@
 package gtpv1
 
 import (
-	"io"
 	"log"
-	"os"
-	"sync"
-)
+	"log/slog"
+	"net"
 
-var (
-	logger = log.New(os.Stderr, "", log.LstdFlags)
-	logMu  sync.Mutex
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
 )
 
+// stdLogger is the package-level logger used by the UPlaneConns without their own.
+var stdLogger = connlog.NewStdLogger()
+
 // SetLogger replaces the standard logger with arbitrary *log.Logger.
 //
 // This package prints just informational logs from goroutines working background
 // that might help developers test the program but can be ignored safely. More
 // important ones that needs any action by caller would be returned as errors.
+//
+// The logger is used by the UPlaneConns that are not given their own *slog.Logger
+// with WithLogger option.
 func SetLogger(l *log.Logger) {
 	if l == nil {
 		log.Println("Don't pass nil to SetLogger: use DisableLogging instead.")
 	}
 
-	setLogger(l)
+	stdLogger.Set(l)
 }
 
 // EnableLogging enables the logging from the package.
@@ -35,35 +39,44 @@ func SetLogger(l *log.Logger) {
 //
 // See also: SetLogger.
 func EnableLogging(l *log.Logger) {
-	logMu.Lock()
-	defer logMu.Unlock()
-
-	setLogger(l)
+	stdLogger.Set(l)
 }
 
 // DisableLogging disables the logging from the package.
 // Logging is enabled by default.
+//
+// The UPlaneConns with their own *slog.Logger given with WithLogger option are
+// not affected by this.
 func DisableLogging() {
-	logMu.Lock()
-	defer logMu.Unlock()
-
-	logger.SetOutput(io.Discard)
+	stdLogger.Discard()
 }
 
-func setLogger(l *log.Logger) {
-	if l == nil {
-		l = log.New(os.Stderr, "", log.LstdFlags)
-	}
-
-	logMu.Lock()
-	defer logMu.Unlock()
+// logProto is the value of logkey.Proto attribute.
+const logProto = "gtpv1-u"
 
-	logger = l
+// WithLogger lets UPlaneConn write its logs to the *slog.Logger given instead of
+// the package-level logger set by SetLogger.
+//
+// The records have the attributes with the keys defined in logkey package, e.g.,
+// logkey.Peer and logkey.TEID, to help filtering the records of a specific peer
+// or tunnel.
+func WithLogger(l *slog.Logger) UPlaneConnOption {
+	return func(u *UPlaneConn) {
+		u.logger = l
+	}
 }
 
-func logf(format string, v ...interface{}) {
-	logMu.Lock()
-	defer logMu.Unlock()
+// Logger returns the *slog.Logger of UPlaneConn, which has the common attributes
+// of UPlaneConn. It is useful to write the logs from the handlers in the same manner.
+func (u *UPlaneConn) Logger() *slog.Logger {
+	return u.logger
+}
 
-	logger.Printf(format, v...)
+// msgAttrs returns the attributes that describe the message from or to peer.
+func msgAttrs(peer net.Addr, msg message.Message) []any {
+	var attrs []any
+	if msg.TEID() != 0 {
+		attrs = append(attrs, slog.String(logkey.TEID, connlog.TEID(msg.TEID())))
+	}
+	return connlog.MsgAttrs(peer, msg.MessageTypeName(), uint32(msg.Sequence()), attrs...)
 }
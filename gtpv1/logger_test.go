@@ -0,0 +1,55 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/logkey"
+	"github.com/wmnsk/go-gtp/teidalloc"
+)
+
+func TestUPlaneConnWithLogger(t *testing.T) {
+	laddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2152}
+	buf := &bytes.Buffer{}
+	u := gtpv1.NewUPlaneConn(laddr, gtpv1.WithLogger(slog.New(slog.NewJSONHandler(buf, nil))))
+
+	// let NewFTEID fail to log something.
+	allocator, err := teidalloc.NewRangeAllocator(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.SetTEIDAllocator(allocator)
+	if fteid := u.NewFTEID(0, "127.0.0.1", ""); fteid == nil {
+		t.Fatal("NewFTEID failed with available TEID")
+	}
+	if fteid := u.NewFTEID(0, "127.0.0.1", ""); fteid != nil {
+		t.Fatal("NewFTEID should fail with no TEID available")
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	delete(got, slog.TimeKey)
+
+	want := map[string]any{
+		slog.LevelKey:    "WARN",
+		slog.MessageKey:  "failed to allocate TEID",
+		logkey.Proto:     "gtpv1-u",
+		logkey.LocalAddr: laddr.String(),
+		logkey.Error:     teidalloc.ErrExhausted.Error(),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
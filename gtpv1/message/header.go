@@ -242,6 +242,9 @@ func (h *Header) WithExtensionHeaders(extHdrs ...*ExtensionHeader) *Header {
 }
 
 // MarshalLen returns the serial length of Header.
+//
+// The Payload is counted, so the messages reset the one left by the previous
+// Marshal before allocating theirs in MarshalTo.
 func (h *Header) MarshalLen() int {
 	l := len(h.Payload) + 8
 	if h.HasSequence() || h.HasNPDUNumber() || h.HasExtensionHeader() {
@@ -0,0 +1,317 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+)
+
+// document is the JSON/YAML representation of a message.
+//
+// Sequence, NPDUNumber and ExtensionHeaders are set only when the corresponding
+// flags are set. Flags is set only when it is different from the one derived
+// from them. Payload is used instead of IEs for T-PDU and when the payload
+// cannot be parsed as IEs.
+type document struct {
+	Version          int               `json:"version" yaml:"version"`
+	Type             msgType           `json:"type" yaml:"type"`
+	Flags            *uint8            `json:"flags,omitempty" yaml:"flags,omitempty"`
+	TEID             uint32            `json:"teid" yaml:"teid"`
+	Sequence         *uint16           `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	NPDUNumber       *uint8            `json:"npduNumber,omitempty" yaml:"npduNumber,omitempty"`
+	ExtensionHeaders []extensionHeader `json:"extensionHeaders,omitempty" yaml:"extensionHeaders,omitempty"`
+	IEs              []*ie.IE          `json:"ies,omitempty" yaml:"ies,omitempty"`
+	Payload          hexBytes          `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// extensionHeader is the JSON/YAML representation of an ExtensionHeader.
+// The next extension header type is given by the following one.
+type extensionHeader struct {
+	Type    uint8    `json:"type" yaml:"type"`
+	Content hexBytes `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// flags returns the flags derived from the presence of the optional fields.
+func (d *document) flags() uint8 {
+	var e, s, pn int
+	if len(d.ExtensionHeaders) > 0 {
+		e = 1
+	}
+	if d.Sequence != nil {
+		s = 1
+	}
+	if d.NPDUNumber != nil {
+		pn = 1
+	}
+	return NewHeaderFlags(1, 1, e, s, pn)
+}
+
+// newDocument returns the message in the form to be encoded in JSON or YAML.
+func newDocument(m Message) (*document, error) {
+	b, err := Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &document{Version: 1, Type: msgType(h.Type), TEID: h.TEID}
+	if h.HasSequence() {
+		d.Sequence = &h.SequenceNumber
+	}
+	if h.HasNPDUNumber() {
+		d.NPDUNumber = &h.NPDUNumber
+	}
+	if h.HasExtensionHeader() {
+		for _, eh := range h.ExtensionHeaders {
+			d.ExtensionHeaders = append(d.ExtensionHeaders, extensionHeader{eh.Type, eh.Content})
+		}
+	}
+	if f := d.flags(); f != h.Flags {
+		d.Flags = &h.Flags
+	}
+
+	if h.Type != MsgTypeTPDU {
+		d.IEs = parseIEs(h.Payload)
+	}
+	if d.IEs == nil {
+		d.Payload = h.Payload
+	}
+	return d, nil
+}
+
+// parseIEs returns the IEs in b, or nil if b cannot be parsed into the IEs that
+// are serialized back into b.
+func parseIEs(b []byte) []*ie.IE {
+	ies, err := ie.ParseMultiIEs(b)
+	if err != nil {
+		return nil
+	}
+
+	var serialized []byte
+	for _, i := range ies {
+		v, err := i.Marshal()
+		if err != nil {
+			return nil
+		}
+		serialized = append(serialized, v...)
+	}
+	if !bytes.Equal(serialized, b) {
+		return nil
+	}
+	return ies
+}
+
+// message creates the message from the document.
+func (d *document) message() (Message, error) {
+	if d.Version != 1 {
+		return nil, fmt.Errorf("version %d is not GTPv1", d.Version)
+	}
+
+	payload := d.Payload
+	for _, i := range d.IEs {
+		b, err := i.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, b...)
+	}
+
+	h := &Header{Flags: d.flags(), Type: uint8(d.Type), TEID: d.TEID, Payload: payload}
+	if d.Flags != nil {
+		h.Flags = *d.Flags
+	}
+	if d.Sequence != nil {
+		h.SequenceNumber = *d.Sequence
+	}
+	if d.NPDUNumber != nil {
+		h.NPDUNumber = *d.NPDUNumber
+	}
+	for n, eh := range d.ExtensionHeaders {
+		next := ExtHeaderTypeNoMoreExtensionHeaders
+		if n+1 < len(d.ExtensionHeaders) {
+			next = d.ExtensionHeaders[n+1].Type
+		}
+		h.ExtensionHeaders = append(h.ExtensionHeaders, NewExtensionHeader(eh.Type, eh.Content, next))
+	}
+	if len(h.ExtensionHeaders) > 0 {
+		h.NextExtensionHeaderType = h.ExtensionHeaders[0].Type
+	}
+	h.SetLength()
+
+	b, err := h.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// MarshalJSON returns the message in JSON.
+//
+// The message is represented as the object with the fields in the header and
+// the IEs. See (*ie.IE).MarshalJSON for how the IEs are represented.
+func MarshalJSON(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// ParseJSON decodes the message in JSON, in the format generated by MarshalJSON.
+func ParseJSON(b []byte) (Message, error) {
+	d := &document{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// MarshalYAML returns the message in YAML, in the same format as MarshalJSON.
+func MarshalYAML(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(d)
+}
+
+// ParseYAML decodes the message in YAML, in the format generated by MarshalYAML.
+func ParseYAML(b []byte) (Message, error) {
+	d := &document{}
+	if err := yaml.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// msgType is the type of message, represented with its name if known.
+type msgType uint8
+
+// name returns the name of message type, and false if it is unknown.
+func (t msgType) name() (string, bool) {
+	for n, v := range msgTypeByName() {
+		if v == uint8(t) {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// MarshalJSON returns the name of message type, or the number if unknown.
+func (t msgType) MarshalJSON() ([]byte, error) {
+	if n, ok := t.name(); ok {
+		return json.Marshal(n)
+	}
+	return json.Marshal(uint8(t))
+}
+
+// UnmarshalJSON decodes the name or number of message type.
+func (t *msgType) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// MarshalYAML returns the name of message type, or the number if unknown.
+func (t msgType) MarshalYAML() (interface{}, error) {
+	if n, ok := t.name(); ok {
+		return n, nil
+	}
+	return uint8(t), nil
+}
+
+// UnmarshalYAML decodes the name or number of message type.
+func (t *msgType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// set sets the type given as a name, a number or a number in string.
+func (t *msgType) set(v interface{}) error {
+	switch x := v.(type) {
+	case string:
+		if n, ok := msgTypeByName()[x]; ok {
+			*t = msgType(n)
+			return nil
+		}
+		n, err := strconv.ParseUint(x, 0, 8)
+		if err != nil {
+			return fmt.Errorf("unknown message type %q", x)
+		}
+		*t = msgType(n)
+	case float64:
+		if x < 0 || x > 255 || x != float64(uint8(x)) {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	case int:
+		if x < 0 || x > 255 {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	default:
+		return fmt.Errorf("invalid message type %v", v)
+	}
+	return nil
+}
+
+// msgTypeByName returns the message types by name.
+//
+// The names are available only from the message types, so they are collected by
+// parsing the header with no IEs for each type. The types parsed as Generic have
+// no name.
+var msgTypeByName = sync.OnceValue(func() map[string]uint8 {
+	m := map[string]uint8{}
+	for t := 0; t < 256; t++ {
+		b, err := NewHeader(NewHeaderFlags(1, 1, 0, 1, 0), uint8(t), 0, 0, nil).Marshal()
+		if err != nil {
+			continue
+		}
+		msg, err := Parse(b)
+		if err != nil {
+			continue
+		}
+		if _, ok := msg.(*Generic); ok {
+			continue
+		}
+		m[msg.MessageTypeName()] = uint8(t)
+	}
+	return m
+})
+
+// hexBytes is the bytes represented in hex.
+type hexBytes []byte
+
+// MarshalText returns b in hex.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes b in hex.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	v, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  message.Message
+		document    string
+	}{
+		{
+			"EchoRequest",
+			message.NewEchoRequest(1, ie.NewRecovery(2)),
+			`{"version":1,"type":"Echo Request","teid":0,"sequence":1,"ies":[{"type":"Recovery","value":2}]}`,
+		}, {
+			"DeletePDPContextRequest",
+			message.NewDeletePDPContextRequest(0x11111111, 2, ie.NewTeardownInd(true), ie.NewNSAPI(5)),
+			`{"version":1,"type":"Delete PDP Context Request","teid":286331153,"sequence":2,"ies":[{"type":"TeardownInd","value":true},{"type":"NSAPI","value":5}]}`,
+		}, {
+			"TPDU",
+			message.NewTPDU(0x11111111, []byte{0xde, 0xad}),
+			`{"version":1,"type":"T-PDU","teid":286331153,"payload":"dead"}`,
+		}, {
+			"TPDUWithExtensionHeader",
+			message.NewTPDUWithExtentionHeader(
+				0x11111111, []byte{0xde, 0xad},
+				message.NewExtensionHeader(message.ExtHeaderTypeUDPPort, []byte{0x08, 0x68}, message.ExtHeaderTypeNoMoreExtensionHeaders),
+			),
+			`{"version":1,"type":"T-PDU","teid":286331153,"extensionHeaders":[{"type":64,"content":"0868"}],"payload":"dead"}`,
+		}, {
+			"Generic",
+			message.NewGeneric(250, 0, 3),
+			`{"version":1,"type":250,"teid":0,"sequence":3}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := message.MarshalJSON(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	doc := `
+version: 1
+type: Create PDP Context Request
+teid: 0
+sequence: 1
+ies:
+- type: IMSI
+  value: "123451234567890"
+- type: SelectionMode
+  value: 240
+- type: TEIDDataI
+  value: 0x11111111
+- type: TEIDCPlane
+  value: 0x22222222
+- type: NSAPI
+  value: 5
+- type: EndUserAddress
+  value: {pdpTypeOrganization: 1, pdpTypeNumber: 0x21}
+- type: AccessPointName
+  value: some.apn.example
+- type: GSNAddress
+  value: 10.0.0.1
+- type: GSNAddress
+  value: 10.0.0.2
+- type: MSISDN
+  value: "818012345678"
+- type: QoSProfile
+  payload: 0b921f
+`
+	want := message.NewCreatePDPContextRequest(0, 1,
+		ie.NewIMSI("123451234567890"),
+		ie.NewSelectionMode(0xf0),
+		ie.NewTEIDDataI(0x11111111),
+		ie.NewTEIDCPlane(0x22222222),
+		ie.NewNSAPI(5),
+		ie.NewEndUserAddressIPv4(""),
+		ie.NewAccessPointName("some.apn.example"),
+		ie.NewGSNAddress("10.0.0.1"),
+		ie.NewGSNAddress("10.0.0.2"),
+		ie.NewMSISDN("818012345678"),
+		ie.NewQoSProfile([]byte{0x0b, 0x92, 0x1f}),
+	)
+
+	got, err := message.ParseYAML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.(*message.CreatePDPContextRequest); !ok {
+		t.Fatalf("got %T", got)
+	}
+
+	gotb, err := message.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantb, err := message.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotb, wantb); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"version":2,"type":"Echo Request","teid":0,"sequence":1}`,
+		`{"version":1,"type":"No Such Request","teid":0,"sequence":1}`,
+		`{"version":1,"type":1,"teid":0,"sequence":1,"ies":[{"type":"NoSuchIE"}]}`,
+	} {
+		if _, err := message.ParseJSON([]byte(doc)); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/metrics"
+)
+
+// recorderHolder holds metrics.Recorder to be stored in atomic.Pointer.
+type recorderHolder struct {
+	metrics.Recorder
+}
+
+// SetMetrics lets UPlaneConn report the metrics to the metrics.Recorder given.
+// If recorder is nil, UPlaneConn stops reporting.
+//
+// UPlaneConn reports the messages other than T-PDU sent and received per type,
+// the failures in parsing and handling the messages, the number of T-PDUs sent
+// and received including the ones relayed, and the number of T-PDUs dropped
+// without being read by ReadFromGTP. Note that the T-PDUs handled by
+// Kernel GTP-U are not counted, as they never come up to UPlaneConn.
+func (u *UPlaneConn) SetMetrics(recorder metrics.Recorder) {
+	if recorder == nil {
+		u.metrics.Store(nil)
+		return
+	}
+	u.metrics.Store(&recorderHolder{recorder})
+}
+
+// recorder returns the metrics.Recorder of UPlaneConn, or nil if not set.
+func (u *UPlaneConn) recorder() metrics.Recorder {
+	h := u.metrics.Load()
+	if h == nil {
+		return nil
+	}
+	return h.Recorder
+}
+
+// recordSent records the packet in b sent by UPlaneConn.
+func (u *UPlaneConn) recordSent(b []byte) {
+	r := u.recorder()
+	if r == nil || len(b) < 2 {
+		return
+	}
+
+	if b[1] == message.MsgTypeTPDU {
+		r.TPDU(metrics.Sent, 1, len(b))
+		return
+	}
+
+	// the messages other than T-PDU are parsed again only when the metrics
+	// are enabled, as WriteTo is given just a byte sequence.
+	msg, err := message.Parse(b)
+	if err != nil {
+		return
+	}
+	r.Message(metrics.ProtoGTPv1U, metrics.Sent, msg.MessageTypeName(), 0)
+}
+
+// recordReceived records the message other than T-PDU received.
+func (u *UPlaneConn) recordReceived(msg message.Message) {
+	r := u.recorder()
+	if r == nil || msg.MessageType() == message.MsgTypeTPDU {
+		return
+	}
+	r.Message(metrics.ProtoGTPv1U, metrics.Received, msg.MessageTypeName(), 0)
+}
+
+func (u *UPlaneConn) recordParseError() {
+	if r := u.recorder(); r != nil {
+		r.ParseError(metrics.ProtoGTPv1U)
+	}
+}
+
+func (u *UPlaneConn) recordTPDUDropped() {
+	if r := u.recorder(); r != nil {
+		r.TPDUDropped(1)
+	}
+}
+
+func (u *UPlaneConn) recordHandlerError(msg message.Message) {
+	if r := u.recorder(); r != nil {
+		r.HandlerError(metrics.ProtoGTPv1U, msg.MessageTypeName())
+	}
+}
+
+// tpduCounter counts the T-PDUs in a batch to be recorded at a time.
+type tpduCounter struct {
+	packets, bytes int
+}
+
+func (t *tpduCounter) add(b []byte) {
+	if len(b) < 2 || b[1] != message.MsgTypeTPDU {
+		return
+	}
+	t.packets++
+	t.bytes += len(b)
+}
+
+// record records the T-PDUs counted to r, and resets the counter.
+func (t *tpduCounter) record(r metrics.Recorder, dir metrics.Direction) {
+	if t.packets == 0 {
+		return
+	}
+	r.TPDU(dir, t.packets, t.bytes)
+	t.packets, t.bytes = 0, 0
+}
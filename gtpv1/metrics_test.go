@@ -0,0 +1,207 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/metrics"
+)
+
+// testRecorder records the events as strings.
+type testRecorder struct {
+	metrics.NopRecorder
+
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *testRecorder) add(format string, v ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, v...))
+}
+
+func (r *testRecorder) Message(proto string, dir metrics.Direction, msgType string, cause uint8) {
+	r.add("%s %s %s", proto, dir, msgType)
+}
+
+func (r *testRecorder) ParseError(proto string) {
+	r.add("%s parse error", proto)
+}
+
+func (r *testRecorder) TPDU(dir metrics.Direction, packets, bytes int) {
+	r.add("tpdu %s %d %d", dir, packets, bytes)
+}
+
+func TestUPlaneConnSetMetrics(t *testing.T) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.81:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &testRecorder{}
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	srvConn.DisableErrorIndication()
+	srvConn.SetMetrics(rec)
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			t.Errorf("failed to listen on %s: %s", srvConn.LocalAddr(), err)
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	cliConn, err := net.ListenPacket("udp", "127.0.0.82:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+
+	var packets [][]byte
+	for _, m := range []message.Message{
+		message.NewTPDU(0x11111111, []byte{0xde, 0xad, 0xbe, 0xef}),
+		message.NewEchoRequest(0, ie.NewRecovery(0)),
+	} {
+		b, err := message.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packets = append(packets, b)
+	}
+	// broken header that cannot be parsed.
+	packets = append(packets, []byte{0x32, 0x01})
+
+	for _, b := range packets {
+		if _, err := cliConn.WriteTo(b, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// wait for the Echo Response to be sure that the packets are handled.
+	if err := cliConn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1500)
+	if _, _, err := cliConn.ReadFrom(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []string
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		rec.mu.Lock()
+		events = append([]string(nil), rec.events...)
+		rec.mu.Unlock()
+		if len(events) >= 4 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := []string{
+		"tpdu received 1 12",
+		"gtpv1-u received Echo Request",
+		"gtpv1-u sent Echo Response",
+		"gtpv1-u parse error",
+	}
+	if diff := cmp.Diff(want, events, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// dropRecorder counts the T-PDUs dropped.
+type dropRecorder struct {
+	metrics.NopRecorder
+	dropped atomic.Int64
+}
+
+func (r *dropRecorder) TPDUDropped(packets int) {
+	r.dropped.Add(int64(packets))
+}
+
+func TestUPlaneConnTPDUDropped(t *testing.T) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.83:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &dropRecorder{}
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	srvConn.DisableErrorIndication()
+	srvConn.SetMetrics(rec)
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			t.Errorf("failed to listen on %s: %s", srvConn.LocalAddr(), err)
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	cliConn, err := net.ListenPacket("udp", "127.0.0.84:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+
+	b, err := message.Marshal(message.NewTPDU(0x11111111, []byte{0xde, 0xad, 0xbe, 0xef}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// send more T-PDUs than the queue can hold without calling ReadFromGTP.
+	for i := 0; i < 2048; i++ {
+		if _, err := cliConn.WriteTo(b, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+		// not to let the packets be dropped in the socket buffer.
+		if i%64 == 63 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for rec.dropped.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if rec.dropped.Load() == 0 {
+		t.Error("T-PDUs are not dropped with the queue full")
+	}
+
+	// the T-PDUs queued can still be read.
+	buf := make([]byte, 1500)
+	n, _, teid, err := srvConn.ReadFromGTP(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(buf[:n], []byte{0xde, 0xad, 0xbe, 0xef}); diff != "" {
+		t.Error(diff)
+	}
+	if teid != 0x11111111 {
+		t.Errorf("Got wrong TEID: %#x", teid)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+// GTP genetlink attributes that are not defined in netlink package.
+// They are available on Linux 6.11 and later.
+const (
+	gtpaPeerAddr6 = nl.GENL_GTP_ATTR_PAD + 1 + iota
+	gtpaMSAddr6
+	gtpaFamily
+)
+
+// defaults for the gtp device.
+const (
+	defaultKernelGTPMTU         = 1500
+	defaultKernelGTPPDPHashsize = 131072
+)
+
+// gtpLinkAdd adds a gtp device with the parameters that cannot be specified
+// with netlink.LinkAdd.
+func gtpLinkAdd(devname string, fd, role, hashsize, mtu int) (*netlink.GTP, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(nl.NewIfInfomsg(unix.AF_UNSPEC))
+	req.AddData(nl.NewRtAttr(unix.IFLA_IFNAME, nl.ZeroTerminated(devname)))
+	req.AddData(nl.NewRtAttr(unix.IFLA_MTU, nl.Uint32Attr(uint32(mtu))))
+
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("gtp"))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(nl.IFLA_GTP_FD1, nl.Uint32Attr(uint32(fd)))
+	data.AddRtAttr(nl.IFLA_GTP_PDP_HASHSIZE, nl.Uint32Attr(uint32(hashsize)))
+	data.AddRtAttr(nl.IFLA_GTP_ROLE, nl.Uint32Attr(uint32(role)))
+	req.AddData(linkInfo)
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return nil, err
+	}
+
+	link, err := netlink.LinkByName(devname)
+	if err != nil {
+		return nil, err
+	}
+	gtp, ok := link.(*netlink.GTP)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gtp device", devname)
+	}
+	gtp.FD1 = fd
+	return gtp, nil
+}
+
+// gtpPDPAdd adds a PDP context with the peer's IP and the subscriber's IP in IPv4
+// or IPv6, while netlink.GTPPDPAdd works only with IPv4.
+func gtpPDPAdd(link netlink.Link, t *Tunnel) error {
+	return gtpPDPNew(link, t, unix.NLM_F_EXCL|unix.NLM_F_ACK)
+}
+
+// gtpPDPUpdate updates the peer and the outgoing TEID of the PDP context that
+// has both the MS address and the incoming TEID in t, without deleting it.
+func gtpPDPUpdate(link netlink.Link, t *Tunnel) error {
+	// without NLM_F_EXCL, the kernel updates the existing PDP context in place.
+	return gtpPDPNew(link, t, unix.NLM_F_ACK)
+}
+
+func gtpPDPNew(link netlink.Link, t *Tunnel, flags int) error {
+	req, err := newGTPRequest(nl.GENL_GTP_CMD_NEWPDP, flags, link, isIPv6(t.MSIP))
+	if err != nil {
+		return err
+	}
+
+	if v4 := t.PeerIP.To4(); v4 != nil {
+		req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_PEER_ADDRESS, v4))
+	} else {
+		req.AddData(nl.NewRtAttr(gtpaPeerAddr6, t.PeerIP.To16()))
+	}
+	if v4 := t.MSIP.To4(); v4 != nil {
+		req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_MS_ADDRESS, v4))
+	} else {
+		req.AddData(nl.NewRtAttr(gtpaMSAddr6, t.MSIP.To16()))
+	}
+	req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_I_TEI, nl.Uint32Attr(t.ITEI)))
+	req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_O_TEI, nl.Uint32Attr(t.OTEI)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// gtpPDPDel deletes the PDP context specified with the incoming TEID.
+func gtpPDPDel(link netlink.Link, t *Tunnel) error {
+	req, err := newGTPRequest(nl.GENL_GTP_CMD_DELPDP, unix.NLM_F_EXCL|unix.NLM_F_ACK, link, isIPv6(t.MSIP))
+	if err != nil {
+		return err
+	}
+	req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_I_TEI, nl.Uint32Attr(t.ITEI)))
+
+	_, err = req.Execute(unix.NETLINK_GENERIC, 0)
+	return err
+}
+
+// gtpPDPByITEI finds a PDP context with the incoming TEID.
+//
+// The kernel looks up the PDP context only in the address family given, so this
+// tries IPv4 first and then IPv6.
+func gtpPDPByITEI(link netlink.Link, itei uint32) (*Tunnel, error) {
+	var t *Tunnel
+	var err error
+	for _, v6 := range []bool{false, true} {
+		t, err = gtpPDPGet(link, v6, nl.NewRtAttr(nl.GENL_GTP_ATTR_I_TEI, nl.Uint32Attr(itei)))
+		if err == nil {
+			return t, nil
+		}
+	}
+	return nil, err
+}
+
+// gtpPDPByMSAddress finds a PDP context with the subscriber's IP in IPv4 or
+// IPv6, while netlink.GTPPDPByMSAddress works only with IPv4.
+func gtpPDPByMSAddress(link netlink.Link, msIP net.IP) (*Tunnel, error) {
+	if v4 := msIP.To4(); v4 != nil {
+		return gtpPDPGet(link, false, nl.NewRtAttr(nl.GENL_GTP_ATTR_MS_ADDRESS, v4))
+	}
+	return gtpPDPGet(link, true, nl.NewRtAttr(gtpaMSAddr6, msIP.To16()))
+}
+
+func gtpPDPGet(link netlink.Link, v6 bool, attr *nl.RtAttr) (*Tunnel, error) {
+	req, err := newGTPRequest(nl.GENL_GTP_CMD_GETPDP, 0, link, v6)
+	if err != nil {
+		return nil, err
+	}
+	req.AddData(attr)
+
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+	tunnels, err := parseGTPTunnels(msgs)
+	if err != nil {
+		return nil, err
+	}
+	if len(tunnels) != 1 {
+		return nil, errors.New("invalid response for GTP_CMD_GETPDP")
+	}
+	return tunnels[0].Tunnel, nil
+}
+
+// gtpPDPList returns all the PDP contexts on the link.
+func gtpPDPList(link netlink.Link) ([]*Tunnel, error) {
+	f, err := netlink.GenlFamilyGet(nl.GENL_GTP_NAME)
+	if err != nil {
+		return nil, err
+	}
+
+	req := nl.NewNetlinkRequest(int(f.ID), unix.NLM_F_DUMP)
+	req.AddData(&nl.Genlmsg{Command: nl.GENL_GTP_CMD_GETPDP, Version: nl.GENL_GTP_VERSION})
+	msgs, err := req.Execute(unix.NETLINK_GENERIC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := parseGTPTunnels(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the dump contains the PDP contexts on all the gtp devices.
+	var tunnels []*Tunnel
+	for _, t := range all {
+		if t.linkIndex == link.Attrs().Index {
+			tunnels = append(tunnels, t.Tunnel)
+		}
+	}
+	return tunnels, nil
+}
+
+func newGTPRequest(cmd uint8, flags int, link netlink.Link, v6 bool) (*nl.NetlinkRequest, error) {
+	f, err := netlink.GenlFamilyGet(nl.GENL_GTP_NAME)
+	if err != nil {
+		return nil, err
+	}
+
+	req := nl.NewNetlinkRequest(int(f.ID), flags)
+	req.AddData(&nl.Genlmsg{Command: cmd, Version: nl.GENL_GTP_VERSION})
+	req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_VERSION, nl.Uint32Attr(1)))
+	req.AddData(nl.NewRtAttr(nl.GENL_GTP_ATTR_LINK, nl.Uint32Attr(uint32(link.Attrs().Index))))
+
+	// the family is IPv4 if omitted, which also keeps the request valid
+	// for the kernels that do not support IPv6.
+	if v6 {
+		req.AddData(nl.NewRtAttr(gtpaFamily, []byte{unix.AF_INET6}))
+	}
+	return req, nil
+}
+
+func isIPv6(ip net.IP) bool {
+	return ip.To4() == nil
+}
+
+type linkedTunnel struct {
+	*Tunnel
+	linkIndex int
+}
+
+func parseGTPTunnels(msgs [][]byte) ([]*linkedTunnel, error) {
+	tunnels := make([]*linkedTunnel, 0, len(msgs))
+	for _, m := range msgs {
+		if len(m) < nl.SizeofGenlmsg {
+			return nil, errors.New("too short to parse as GTP_CMD_GETPDP response")
+		}
+		attrs, err := nl.ParseRouteAttr(m[nl.SizeofGenlmsg:])
+		if err != nil {
+			return nil, err
+		}
+
+		t := &linkedTunnel{Tunnel: &Tunnel{}}
+		version := uint32(1)
+		for _, a := range attrs {
+			switch a.Attr.Type {
+			case nl.GENL_GTP_ATTR_LINK:
+				t.linkIndex = int(nl.NativeEndian().Uint32(a.Value))
+			case nl.GENL_GTP_ATTR_VERSION:
+				version = nl.NativeEndian().Uint32(a.Value)
+			case nl.GENL_GTP_ATTR_PEER_ADDRESS, gtpaPeerAddr6:
+				t.PeerIP = net.IP(a.Value)
+			case nl.GENL_GTP_ATTR_MS_ADDRESS, gtpaMSAddr6:
+				t.MSIP = net.IP(a.Value)
+			case nl.GENL_GTP_ATTR_I_TEI:
+				t.ITEI = nl.NativeEndian().Uint32(a.Value)
+			case nl.GENL_GTP_ATTR_O_TEI:
+				t.OTEI = nl.NativeEndian().Uint32(a.Value)
+			}
+		}
+
+		// GTPv0 tunnels are not handled by UPlaneConn.
+		if version != 1 {
+			continue
+		}
+		tunnels = append(tunnels, t)
+	}
+	return tunnels, nil
+}
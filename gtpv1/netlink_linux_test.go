@@ -0,0 +1,140 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// pdpMessage returns a GTP_CMD_GETPDP response with the attributes given.
+func pdpMessage(attrs ...*nl.RtAttr) []byte {
+	b := (&nl.Genlmsg{Command: nl.GENL_GTP_CMD_GETPDP, Version: nl.GENL_GTP_VERSION}).Serialize()
+	for _, a := range attrs {
+		b = append(b, a.Serialize()...)
+	}
+	return b
+}
+
+func TestParseGTPTunnels(t *testing.T) {
+	msgs := [][]byte{
+		pdpMessage(
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_LINK, nl.Uint32Attr(3)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_VERSION, nl.Uint32Attr(1)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_PEER_ADDRESS, net.ParseIP("127.0.0.2").To4()),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_MS_ADDRESS, net.ParseIP("10.0.0.1").To4()),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_I_TEI, nl.Uint32Attr(0x11111111)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_O_TEI, nl.Uint32Attr(0x22222222)),
+		),
+		pdpMessage(
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_LINK, nl.Uint32Attr(4)),
+			nl.NewRtAttr(gtpaPeerAddr6, net.ParseIP("2001:db8::2").To16()),
+			nl.NewRtAttr(gtpaMSAddr6, net.ParseIP("2001:db8:1::1").To16()),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_I_TEI, nl.Uint32Attr(0x33333333)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_O_TEI, nl.Uint32Attr(0x44444444)),
+		),
+		// GTPv0 tunnel is ignored.
+		pdpMessage(
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_LINK, nl.Uint32Attr(3)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_VERSION, nl.Uint32Attr(0)),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_PEER_ADDRESS, net.ParseIP("127.0.0.3").To4()),
+			nl.NewRtAttr(nl.GENL_GTP_ATTR_MS_ADDRESS, net.ParseIP("10.0.0.2").To4()),
+		),
+	}
+
+	got, err := parseGTPTunnels(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*linkedTunnel{
+		{
+			Tunnel: &Tunnel{
+				PeerIP: net.ParseIP("127.0.0.2").To4(),
+				MSIP:   net.ParseIP("10.0.0.1").To4(),
+				ITEI:   0x11111111,
+				OTEI:   0x22222222,
+			},
+			linkIndex: 3,
+		}, {
+			Tunnel: &Tunnel{
+				PeerIP: net.ParseIP("2001:db8::2"),
+				MSIP:   net.ParseIP("2001:db8:1::1"),
+				ITEI:   0x33333333,
+				OTEI:   0x44444444,
+			},
+			linkIndex: 4,
+		},
+	}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(linkedTunnel{})); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseGTPTunnelsTooShort(t *testing.T) {
+	if _, err := parseGTPTunnels([][]byte{{nl.GENL_GTP_CMD_GETPDP}}); err == nil {
+		t.Error("unexpectedly succeeded")
+	}
+}
+
+// skipWithoutKernelGTP skips the test if the gtp devices cannot be created.
+func skipWithoutKernelGTP(t *testing.T) {
+	t.Helper()
+
+	if os.Geteuid() != 0 {
+		t.Skip("Kernel GTP-U requires root privilege")
+	}
+	if _, err := netlink.GenlFamilyGet(nl.GENL_GTP_NAME); err != nil {
+		t.Skipf("Kernel GTP-U is not available: %v", err)
+	}
+}
+
+func TestGTPPDPList(t *testing.T) {
+	skipWithoutKernelGTP(t)
+
+	var links []netlink.Link
+	for i, addr := range []string{"127.0.0.71:2152", "127.0.0.72:2152"} {
+		laddr, err := net.ResolveUDPAddr("udp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := NewUPlaneConn(laddr)
+		if err := u.EnableKernelGTP(fmt.Sprintf("gtp-list%d", i), RoleGGSN); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = netlink.LinkDel(u.KernelGTP.Link)
+			_ = u.Close()
+		})
+
+		if err := u.AddTunnel(
+			net.ParseIP("127.0.0.73"), net.IPv4(10, 0, 0, byte(i+1)), uint32(0x100+i), uint32(0x200+i),
+		); err != nil {
+			t.Fatal(err)
+		}
+		links = append(links, u.KernelGTP.Link)
+	}
+
+	// only the tunnel on the first device should be listed.
+	got, err := gtpPDPList(links[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Tunnel{{
+		PeerIP: net.ParseIP("127.0.0.73").To4(),
+		MSIP:   net.ParseIP("10.0.0.1").To4(),
+		OTEI:   0x100,
+		ITEI:   0x200,
+	}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
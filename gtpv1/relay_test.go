@@ -7,9 +7,16 @@ package gtpv1_test
 import (
 	"context"
 	"net"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/teidalloc"
 )
 
 func TestRelay(t *testing.T) {
@@ -50,3 +57,401 @@ func TestRelay(t *testing.T) {
 
 	// TODO: add tests to check if the traffic goes through conns.
 }
+
+func TestRelayTeardownOnErrorIndication(t *testing.T) {
+	leftAddr, err := net.ResolveUDPAddr("udp", "127.0.0.31:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rightAddr, err := net.ResolveUDPAddr("udp", "127.0.0.32:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leftConn := gtpv1.NewUPlaneConn(leftAddr)
+	go func() {
+		if err := leftConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	errIndCh := make(chan uint32, 1)
+	rightConn := gtpv1.NewUPlaneConn(rightAddr)
+	rightConn.EnableTunnelTeardownOnErrorIndication()
+	rightConn.SetErrorIndicationHandler(func(_ net.Addr, teid uint32, _ net.IP) {
+		errIndCh <- teid
+	})
+	go func() {
+		if err := rightConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	peerConn, err := net.ListenPacket("udp", "127.0.0.33:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer peerConn.Close()
+
+	if err := leftConn.RelayTo(rightConn, 0x22222222, 0x11111111, peerConn.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := message.NewErrorIndication(
+		0, 0, ie.NewTEIDDataI(0x11111111), ie.NewGSNAddress("127.0.0.33"),
+	).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peerConn.WriteTo(b, rightAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case teid := <-errIndCh:
+		if diff := cmp.Diff(teid, uint32(0x11111111)); diff != "" {
+			t.Error(diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out while waiting for Error Indication to be handled")
+	}
+
+	// the relay should have been removed, and the T-PDU is rejected by leftConn.
+	b, err = gtpv1.Encapsulate(0x22222222, []byte{0xde, 0xad, 0xbe, 0xef}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peerConn.WriteTo(b, leftAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := peerConn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1500)
+	n, _, err := peerConn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := msg.(*message.ErrorIndication); !ok {
+		t.Errorf("got unexpected type of message: %s", msg.MessageTypeName())
+	}
+}
+
+func TestSwitchRelay(t *testing.T) {
+	leftAddr, err := net.ResolveUDPAddr("udp", "127.0.0.41:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rightAddr, err := net.ResolveUDPAddr("udp", "127.0.0.42:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leftConn := gtpv1.NewUPlaneConn(leftAddr)
+	go func() {
+		if err := leftConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+	rightConn := gtpv1.NewUPlaneConn(rightAddr)
+	go func() {
+		if err := rightConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	oldPeer, err := net.ListenPacket("udp", "127.0.0.43:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer oldPeer.Close()
+	newPeer, err := net.ListenPacket("udp", "127.0.0.44:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newPeer.Close()
+
+	if err := leftConn.RelayTo(rightConn, 0x22222222, 0x11111111, oldPeer.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+	if err := leftConn.SwitchRelay(0x22222222, 0x33333333, newPeer.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 1500)
+	if err := oldPeer.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err := oldPeer.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := msg.(*message.EndMarker); !ok {
+		t.Errorf("got unexpected type of message: %s", msg.MessageTypeName())
+	}
+	if diff := cmp.Diff(msg.TEID(), uint32(0x11111111)); diff != "" {
+		t.Error(diff)
+	}
+
+	// the T-PDU should be relayed to the new peer with the new TEID.
+	b, err := gtpv1.Encapsulate(0x22222222, []byte{0xde, 0xad, 0xbe, 0xef}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newPeer.WriteTo(b, leftAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := newPeer.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err = newPeer.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teid, payload, err := gtpv1.Decapsulate(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(teid, uint32(0x33333333)); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(payload, []byte{0xde, 0xad, 0xbe, 0xef}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRelayEndMarker(t *testing.T) {
+	leftAddr, err := net.ResolveUDPAddr("udp", "127.0.0.45:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rightAddr, err := net.ResolveUDPAddr("udp", "127.0.0.46:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leftConn := gtpv1.NewUPlaneConn(leftAddr)
+	go func() {
+		if err := leftConn.ListenAndServe(ctx); err != nil {
+			t.Errorf("failed to listen on %s: %s", leftConn.LocalAddr(), err)
+			return
+		}
+	}()
+	rightConn := gtpv1.NewUPlaneConn(rightAddr)
+	go func() {
+		if err := rightConn.ListenAndServe(ctx); err != nil {
+			t.Errorf("failed to listen on %s: %s", rightConn.LocalAddr(), err)
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	peer, err := net.ListenPacket("udp", "127.0.0.47:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer peer.Close()
+
+	if err := leftConn.RelayTo(rightConn, 0x22222222, 0x11111111, peer.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+
+	// End Marker has no payload, which is shorter than the T-PDUs.
+	em := message.NewEndMarker()
+	em.Header.TEID = 0x22222222
+	b, err := em.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peer.WriteTo(b, leftAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 1500)
+	if err := peer.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err := peer.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := msg.(*message.EndMarker); !ok {
+		t.Errorf("got unexpected type of message: %s", msg.MessageTypeName())
+	}
+	if diff := cmp.Diff(msg.TEID(), uint32(0x11111111)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func BenchmarkRelay(b *testing.B) {
+	leftAddr, err := net.ResolveUDPAddr("udp", "127.0.0.51:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+	rightAddr, err := net.ResolveUDPAddr("udp", "127.0.0.52:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leftConn := gtpv1.NewUPlaneConn(leftAddr)
+	go func() {
+		if err := leftConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+	rightConn := gtpv1.NewUPlaneConn(rightAddr)
+	go func() {
+		if err := rightConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	srcConn, err := net.ListenPacket("udp", "127.0.0.53:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer srcConn.Close()
+	dstConn, err := net.ListenPacket("udp", "127.0.0.54:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer dstConn.Close()
+
+	if err := leftConn.RelayTo(rightConn, 0x22222222, 0x11111111, dstConn.LocalAddr()); err != nil {
+		b.Fatal(err)
+	}
+
+	pkt, err := gtpv1.Encapsulate(0x22222222, make([]byte, 1000)).Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// keep the number of packets in flight under the window so that the
+	// packets are not dropped due to the lack of socket buffers.
+	inflight := make(chan struct{}, 64)
+	var received atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 1500)
+		for {
+			if _, _, err := dstConn.ReadFrom(buf); err != nil {
+				return
+			}
+			received.Add(1)
+			select {
+			case <-inflight:
+			default:
+			}
+		}
+	}()
+
+	b.SetBytes(int64(len(pkt)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		select {
+		case inflight <- struct{}{}:
+		case <-time.After(100 * time.Millisecond):
+			// some packets are lost; go forward without waiting for them.
+		}
+		if _, err := srcConn.WriteTo(pkt, leftAddr); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for deadline := time.Now().Add(time.Second); received.Load() < int64(b.N); {
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(received.Load())/float64(b.N), "relayed/op")
+	_ = dstConn.SetReadDeadline(time.Now())
+	<-done
+}
+
+func TestRelayTEIDReservation(t *testing.T) {
+	allocator, err := teidalloc.NewRangeAllocator(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := gtpv1.NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2152})
+	u.SetTEIDAllocator(allocator)
+	raddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2152}
+
+	// the TEID given without NewFTEID is not issued while relaying.
+	if err := u.RelayTo(u, 1, 0x11111111, raddr); err != nil {
+		t.Fatal(err)
+	}
+	teid, err := u.NewFTEID(0, "127.0.0.1", "").TEID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if teid != 2 {
+		t.Errorf("Got wrong TEID: %#x", teid)
+	}
+	if err := u.RelayTo(u, teid, 0x22222222, raddr); err != nil {
+		t.Fatal(err)
+	}
+	if got := allocator.Used(); got != 2 {
+		t.Errorf("TEIDs relayed are not marked as used: %d", got)
+	}
+
+	// the TEID issued but not relayed is kept on CloseRelay.
+	if _, err := u.NewFTEID(0, "127.0.0.1", "").TEID(); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.CloseRelay(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := allocator.Used(); got != 3 {
+		t.Errorf("TEID not relayed is released: %d", got)
+	}
+
+	for _, teid := range []uint32{1, 2, 2} {
+		if err := u.CloseRelay(teid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := allocator.Used(); got != 1 {
+		t.Errorf("TEIDs of closed relays are not released: %d", got)
+	}
+}
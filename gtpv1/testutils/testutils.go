@@ -62,6 +62,20 @@ func Run(t *testing.T, cases []TestCase, decode ParseFunc) {
 				}
 			})
 
+			t.Run("MarshalTwice", func(t *testing.T) {
+				if _, err := c.Structured.Marshal(); err != nil {
+					t.Fatal(err)
+				}
+				b, err := c.Structured.Marshal()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got, want := b, c.Serialized; !verify.Values(t, "", got, want) {
+					t.Fail()
+				}
+			})
+
 			t.Run("Len", func(t *testing.T) {
 				if got, want := c.Structured.MarshalLen(), len(c.Serialized); got != want {
 					t.Fatalf("got %v want %v", got, want)
@@ -96,6 +110,44 @@ func Run(t *testing.T, cases []TestCase, decode ParseFunc) {
 					t.Fatalf("got %v want %v", got, want)
 				}
 			})
+
+			t.Run("JSON", func(t *testing.T) {
+				m, ok := c.Structured.(message.Message)
+				if !ok {
+					return
+				}
+				runDocument(t, m, c.Serialized, message.MarshalJSON, message.ParseJSON)
+			})
+
+			t.Run("YAML", func(t *testing.T) {
+				m, ok := c.Structured.(message.Message)
+				if !ok {
+					return
+				}
+				runDocument(t, m, c.Serialized, message.MarshalYAML, message.ParseYAML)
+			})
 		})
 	}
 }
+
+// runDocument checks if the message encoded into a document by enc is decoded
+// by dec into the same bytes.
+func runDocument(t *testing.T, m message.Message, serialized []byte, enc func(message.Message) ([]byte, error), dec func([]byte) (message.Message, error)) {
+	t.Helper()
+
+	doc, err := enc(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := dec(doc)
+	if err != nil {
+		t.Fatalf("%s: %v", doc, err)
+	}
+	b, err := message.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b, serialized; !verify.Values(t, "", got, want) {
+		t.Errorf("%s", doc)
+	}
+}
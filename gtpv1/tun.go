@@ -0,0 +1,369 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+// userTunnel is a GTP-U tunnel handled by the userland GTP-U with TUN device.
+type userTunnel struct {
+	peerAddr   net.Addr
+	peerIP     net.IP
+	msIP       net.IP
+	otei, itei uint32
+}
+
+// userlandGTP consists of the userland GTP-U related objects, which works
+// like the Linux Kernel GTP-U with TUN device instead of gtp device.
+type userlandGTP struct {
+	role Role
+
+	// wmu is the mutex used before writing to dev, as it is not always safe
+	// to write to io.Writer concurrently.
+	wmu sync.Mutex
+	dev io.ReadWriter
+
+	// mu is the mutex used before updating tunnels, to keep byITEI and byMS
+	// consistent. The lookup is done without lock.
+	mu     sync.Mutex
+	byITEI sync.Map // uint32 -> *userTunnel
+	byMS   sync.Map // string -> *userTunnel
+}
+
+func (g *userlandGTP) write(b []byte) error {
+	g.wmu.Lock()
+	defer g.wmu.Unlock()
+
+	_, err := g.dev.Write(b)
+	return err
+}
+
+func (g *userlandGTP) loadByITEI(itei uint32) (*userTunnel, bool) {
+	t, ok := g.byITEI.Load(itei)
+	if !ok {
+		return nil, false
+	}
+	return t.(*userTunnel), true
+}
+
+func (g *userlandGTP) loadByMS(msIP net.IP) (*userTunnel, bool) {
+	t, ok := g.byMS.Load(string(normalizeIP(msIP)))
+	if !ok {
+		return nil, false
+	}
+	return t.(*userTunnel), true
+}
+
+func (g *userlandGTP) list() []*Tunnel {
+	var tunnels []*Tunnel
+	g.byITEI.Range(func(_, v interface{}) bool {
+		t := v.(*userTunnel)
+		tunnels = append(tunnels, &Tunnel{PeerIP: t.peerIP, MSIP: t.msIP, OTEI: t.otei, ITEI: t.itei})
+		return true
+	})
+	return tunnels
+}
+
+func (g *userlandGTP) delete(t *userTunnel) {
+	g.byITEI.CompareAndDelete(t.itei, t)
+	g.byMS.CompareAndDelete(string(normalizeIP(t.msIP)), t)
+}
+
+// msAddress returns the subscriber's IP in the packet from/to TUN device.
+//
+// Just like the Linux Kernel GTP-U, it is the destination address for
+// the packets to be encapsulated on GGSN, and the source address on SGSN.
+// The opposite applies to the packets decapsulated.
+func (g *userlandGTP) msAddress(pkt []byte, encap bool) net.IP {
+	src, dst := ipAddresses(pkt)
+	if (g.role == RoleGGSN) == encap {
+		return dst
+	}
+	return src
+}
+
+// ipAddresses returns the source and destination address of the IPv4/IPv6 packet.
+func ipAddresses(pkt []byte) (src, dst net.IP) {
+	if len(pkt) < 1 {
+		return nil, nil
+	}
+
+	switch pkt[0] >> 4 {
+	case 4:
+		if len(pkt) < 20 {
+			return nil, nil
+		}
+		return net.IP(pkt[12:16]), net.IP(pkt[16:20])
+	case 6:
+		if len(pkt) < 40 {
+			return nil, nil
+		}
+		return net.IP(pkt[8:24]), net.IP(pkt[24:40])
+	default:
+		return nil, nil
+	}
+}
+
+func normalizeIP(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+	return ip.To16()
+}
+
+// EnableTUN enables the userland GTP-U with the TUN device given as dev, which
+// works like the Linux Kernel GTP-U enabled by EnableKernelGTP.
+//
+// After enabled, users should add tunnels by AddTunnel func, just as the same as
+// Kernel GTP-U. The packets read from dev are encapsulated and sent to the peer
+// of the tunnel that has the subscriber's IP, and the T-PDUs received with the
+// incoming TEID of the tunnel are decapsulated and written to dev.
+//
+// dev can be any io.ReadWriter that reads and writes a raw IP packet at a time,
+// such as the one returned by OpenTUN on Linux or the stand-in for tests.
+// If dev implements io.Closer, it is closed when UPlaneConn is closed.
+//
+// Unlike Kernel GTP-U, this does not require the root privilege (except for
+// creating TUN device), while it is not so performant as Kernel GTP-U.
+func (u *UPlaneConn) EnableTUN(dev io.ReadWriter, role Role) error {
+	if u.KernelGTP.enabled {
+		return errors.New("cannot call EnableTUN when using Kernel GTP-U")
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, err := u.listen(); err != nil {
+		return err
+	}
+
+	g := &userlandGTP{role: role, dev: dev}
+	if !u.tun.CompareAndSwap(nil, g) {
+		return errors.New("TUN is already enabled")
+	}
+
+	go u.serveTUN(g)
+	return nil
+}
+
+// serveTUN reads the packets from TUN device and sends them to the peer.
+func (u *UPlaneConn) serveTUN(g *userlandGTP) {
+	go func() {
+		<-u.closed()
+		if c, ok := g.dev.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				u.Logger().Warn("error closing TUN device", logkey.Error, err)
+			}
+		}
+	}()
+
+	buf := make([]byte, 0xffff)
+	for {
+		n, err := g.dev.Read(buf)
+		if err != nil {
+			select {
+			case <-u.closed():
+			default:
+				if !errors.Is(err, io.EOF) {
+					u.Logger().Warn("error reading from TUN device", logkey.Error, err)
+				}
+			}
+			return
+		}
+
+		msIP := g.msAddress(buf[:n], true)
+		if msIP == nil {
+			continue
+		}
+		t, ok := g.loadByMS(msIP)
+		if !ok {
+			// just drop, as Kernel GTP-U does.
+			continue
+		}
+
+		if _, err := u.WriteToGTP(t.otei, buf[:n], t.peerAddr); err != nil {
+			// should not stop serving with this error
+			u.Logger().Warn("error sending T-PDU", logkey.Peer, t.peerAddr.String(), logkey.TEID, connlog.TEID(t.otei), logkey.Error, err)
+		}
+	}
+}
+
+// decapsulateToTUN writes the payload of T-PDU to TUN device if raw is a T-PDU
+// destined to the tunnel handled by userland GTP-U, and reports whether it is
+// consumed or not.
+func (u *UPlaneConn) decapsulateToTUN(raw []byte) bool {
+	g := u.tun.Load()
+	if g == nil || len(raw) < 8 || raw[1] != message.MsgTypeTPDU {
+		return false
+	}
+
+	teid, payload, err := Decapsulate(raw)
+	if err != nil {
+		return false
+	}
+	t, ok := g.loadByITEI(teid)
+	if !ok {
+		return false
+	}
+
+	// drop the packet that does not belong to the subscriber.
+	if msIP := g.msAddress(payload, false); msIP == nil || !msIP.Equal(t.msIP) {
+		return true
+	}
+
+	if err := g.write(payload); err != nil {
+		// should not stop serving with this error
+		u.Logger().Warn("error writing to TUN device", logkey.TEID, connlog.TEID(teid), logkey.Error, err)
+	}
+	return true
+}
+
+func (u *UPlaneConn) newUserTunnel(peerIP, msIP net.IP, otei, itei uint32) (*userTunnel, error) {
+	raddr, err := net.ResolveUDPAddr(u.laddr.Network(), net.JoinHostPort(peerIP.String(), GTPUPort[1:]))
+	if err != nil {
+		return nil, err
+	}
+	return &userTunnel{
+		peerAddr: raddr,
+		peerIP:   peerIP,
+		msIP:     msIP,
+		otei:     otei,
+		itei:     itei,
+	}, nil
+}
+
+func (u *UPlaneConn) addUserTunnel(g *userlandGTP, peerIP, msIP net.IP, otei, itei uint32) error {
+	t, err := u.newUserTunnel(peerIP, msIP, otei, itei)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.loadByITEI(itei); ok {
+		return fmt.Errorf("failed to add tunnel for %s with %s: TEID %#x already exists", msIP, peerIP, itei)
+	}
+	if _, ok := g.loadByMS(msIP); ok {
+		return fmt.Errorf("failed to add tunnel for %s with %s: MS address already exists", msIP, peerIP)
+	}
+
+	u.storeUserTunnelLocked(g, t)
+	return nil
+}
+
+func (u *UPlaneConn) addUserTunnelOverride(g *userlandGTP, peerIP, msIP net.IP, otei, itei uint32) error {
+	t, err := u.newUserTunnel(peerIP, msIP, otei, itei)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	u.storeUserTunnelLocked(g, t)
+	return nil
+}
+
+// storeUserTunnelLocked stores t, replacing the existing tunnels that have the
+// same incoming TEID and/or MS address. The caller must hold g.mu.
+//
+// The new tunnel is stored before the old ones are deleted, so that the packets
+// looked up without lock always find either of them.
+func (u *UPlaneConn) storeUserTunnelLocked(g *userlandGTP, t *userTunnel) {
+	oldByITEI, _ := g.loadByITEI(t.itei)
+	oldByMS, _ := g.loadByMS(t.msIP)
+
+	g.byITEI.Store(t.itei, t)
+	g.byMS.Store(string(normalizeIP(t.msIP)), t)
+
+	// the entries that are not overwritten above are deleted.
+	if oldByITEI != nil {
+		g.delete(oldByITEI)
+	}
+	if oldByMS != nil && oldByMS != oldByITEI {
+		g.delete(oldByMS)
+		if oldByMS.itei != t.itei {
+			u.teidAllocator().Release(oldByMS.itei)
+		}
+	}
+
+	// keep the TEID from being issued by NewFTEID while the tunnel exists.
+	_ = u.teidAllocator().Reserve(t.itei)
+}
+
+func (u *UPlaneConn) switchUserTunnel(g *userlandGTP, peerIP, msIP net.IP, otei, itei uint32) error {
+	t, err := u.newUserTunnel(peerIP, msIP, otei, itei)
+	if err != nil {
+		return err
+	}
+
+	// look up and replace the tunnel at once, not to let the others take its place.
+	g.mu.Lock()
+	old, ok := g.loadByITEI(itei)
+	if !ok {
+		g.mu.Unlock()
+		return fmt.Errorf("failed to find tunnel with %d", itei)
+	}
+	u.storeUserTunnelLocked(g, t)
+	g.mu.Unlock()
+
+	if old.otei == otei && old.peerIP.Equal(peerIP) {
+		return nil
+	}
+
+	if err := u.EndMarker(old.otei, old.peerAddr); err != nil {
+		return fmt.Errorf("failed to send End Marker to %s: %w", old.peerAddr, err)
+	}
+	return nil
+}
+
+func (u *UPlaneConn) delUserTunnelByITEI(g *userlandGTP, itei uint32) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	t, ok := g.loadByITEI(itei)
+	if !ok {
+		return fmt.Errorf("failed to delete tunnel with %d: not found", itei)
+	}
+	g.delete(t)
+
+	u.teidAllocator().Release(itei)
+	return nil
+}
+
+func (u *UPlaneConn) delUserTunnelByMSAddress(g *userlandGTP, msIP net.IP) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	t, ok := g.loadByMS(msIP)
+	if !ok {
+		return fmt.Errorf("failed to delete tunnel with %s: not found", msIP)
+	}
+	g.delete(t)
+
+	u.teidAllocator().Release(t.itei)
+	return nil
+}
+
+func (u *UPlaneConn) delUserTunnelsByPeer(g *userlandGTP, otei uint32, peerIP net.IP) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.byITEI.Range(func(_, v interface{}) bool {
+		t := v.(*userTunnel)
+		if t.otei == otei && t.peerIP.Equal(peerIP) {
+			g.delete(t)
+			u.teidAllocator().Release(t.itei)
+		}
+		return true
+	})
+}
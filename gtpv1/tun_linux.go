@@ -0,0 +1,52 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// OpenTUN opens the TUN device named devname and sets it up, which can be given to
+// EnableTUN. The device is created if it does not exist, which requires CAP_NET_ADMIN.
+//
+// Users should add addresses and routes to the device on their own, just as the
+// same as the gtp device used by Kernel GTP-U.
+func OpenTUN(devname string) (io.ReadWriteCloser, error) {
+	fd, err := unix.Open("/dev/net/tun", unix.O_RDWR|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open /dev/net/tun: %w", err)
+	}
+
+	ifr, err := unix.NewIfreq(devname)
+	if err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("invalid device name %s: %w", devname, err)
+	}
+	ifr.SetUint16(unix.IFF_TUN | unix.IFF_NO_PI)
+	if err := unix.IoctlIfreq(fd, unix.TUNSETIFF, ifr); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("failed to create device %s: %w", devname, err)
+	}
+
+	// the fd is non-blocking, so that os.File can unblock Read on Close.
+	f := os.NewFile(uintptr(fd), "/dev/net/tun")
+
+	link, err := netlink.LinkByName(ifr.Name())
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to find device %s: %w", devname, err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to setup device %s: %w", devname, err)
+	}
+
+	return f, nil
+}
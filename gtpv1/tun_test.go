@@ -0,0 +1,389 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/teidalloc"
+)
+
+// fakeTUN is a stand-in for TUN device, which reads the packets from rx and
+// writes the packets to tx.
+type fakeTUN struct {
+	rx, tx chan []byte
+}
+
+func (f *fakeTUN) Read(b []byte) (int, error) {
+	pkt, ok := <-f.rx
+	if !ok {
+		return 0, io.EOF
+	}
+	return copy(b, pkt), nil
+}
+
+func (f *fakeTUN) Write(b []byte) (int, error) {
+	pkt := make([]byte, len(b))
+	copy(pkt, b)
+	f.tx <- pkt
+	return len(b), nil
+}
+
+// ipv4Packet returns an IPv4 packet with only the header and the payload given.
+func ipv4Packet(src, dst string, payload []byte) []byte {
+	b := make([]byte, 20+len(payload))
+	b[0] = 0x45
+	b[2], b[3] = byte(len(b)>>8), byte(len(b))
+	b[8] = 64
+	b[9] = 17
+	copy(b[12:16], net.ParseIP(src).To4())
+	copy(b[16:20], net.ParseIP(dst).To4())
+	copy(b[20:], payload)
+	return b
+}
+
+func TestTUN(t *testing.T) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.61:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tun := &fakeTUN{rx: make(chan []byte), tx: make(chan []byte, 1)}
+	defer close(tun.rx)
+
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	if err := srvConn.EnableTUN(tun, gtpv1.RoleGGSN); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	peerConn, err := net.ListenPacket("udp", "127.0.0.62:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer peerConn.Close()
+
+	if err := srvConn.AddTunnel(
+		net.ParseIP("127.0.0.62"), net.ParseIP("10.0.0.1"), 0x11111111, 0x22222222,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err := srvConn.AddTunnel(
+		net.ParseIP("127.0.0.62"), net.ParseIP("10.0.0.1"), 0x33333333, 0x44444444,
+	); err == nil {
+		t.Error("AddTunnel should fail with the existing MS address")
+	}
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	t.Run("uplink", func(t *testing.T) {
+		ul := ipv4Packet("10.0.0.1", "192.168.0.1", []byte{0xde, 0xad, 0xbe, 0xef})
+		b, err := gtpv1.Encapsulate(0x22222222, ul).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := peerConn.WriteTo(b, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case pkt := <-tun.tx:
+			if diff := cmp.Diff(pkt, ul); diff != "" {
+				t.Error(diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out while waiting for the packet to be written to TUN")
+		}
+	})
+
+	t.Run("downlink", func(t *testing.T) {
+		dl := ipv4Packet("192.168.0.1", "10.0.0.1", []byte{0xde, 0xad, 0xbe, 0xef})
+		tun.rx <- dl
+
+		if err := peerConn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 1500)
+		n, _, err := peerConn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		teid, payload, err := gtpv1.Decapsulate(buf[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(teid, uint32(0x11111111)); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(payload, dl); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		tunnels, err := srvConn.ListTunnels()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*gtpv1.Tunnel{{
+			PeerIP: net.ParseIP("127.0.0.62"),
+			MSIP:   net.ParseIP("10.0.0.1"),
+			OTEI:   0x11111111,
+			ITEI:   0x22222222,
+		}}
+		if diff := cmp.Diff(tunnels, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := srvConn.DelTunnelByMSAddress(net.ParseIP("10.0.0.1")); err != nil {
+			t.Fatal(err)
+		}
+		if err := srvConn.DelTunnelByITEI(0x22222222); err == nil {
+			t.Error("DelTunnelByITEI should fail with the deleted tunnel")
+		}
+
+		tunnels, err := srvConn.ListTunnels()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tunnels) != 0 {
+			t.Errorf("got %d tunnels after deleting all", len(tunnels))
+		}
+	})
+}
+
+func TestTUNTEIDReservation(t *testing.T) {
+	allocator, err := teidalloc.NewRangeAllocator(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tun := &fakeTUN{rx: make(chan []byte), tx: make(chan []byte, 1)}
+	defer close(tun.rx)
+
+	u := gtpv1.NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 65}, Port: 2152})
+	u.SetTEIDAllocator(allocator)
+	if err := u.EnableTUN(tun, gtpv1.RoleGGSN); err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+
+	peerIP := net.ParseIP("127.0.0.66")
+	if err := u.AddTunnel(peerIP, net.ParseIP("10.0.0.1"), 0x11111111, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.AddTunnel(peerIP, net.ParseIP("10.0.0.2"), 0x22222222, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := allocator.Used(); got != 2 {
+		t.Errorf("TEIDs of tunnels are not marked as used: %d", got)
+	}
+
+	// the tunnel of the same MS address is replaced with the one with new TEID.
+	if err := u.AddTunnelOverride(peerIP, net.ParseIP("10.0.0.2"), 0x22222222, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := allocator.Used(); got != 2 {
+		t.Errorf("TEID of replaced tunnel is not released: %d", got)
+	}
+
+	if err := u.DelTunnelByITEI(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.DelTunnelByMSAddress(net.ParseIP("10.0.0.2")); err != nil {
+		t.Fatal(err)
+	}
+	if got := allocator.Used(); got != 0 {
+		t.Errorf("TEIDs of deleted tunnels are not released: %d", got)
+	}
+}
+
+func TestTUNAddTunnelOverrideConcurrently(t *testing.T) {
+	tun := &fakeTUN{rx: make(chan []byte), tx: make(chan []byte, 1)}
+	defer close(tun.rx)
+
+	u := gtpv1.NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 67}, Port: 2152})
+	if err := u.EnableTUN(tun, gtpv1.RoleGGSN); err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+
+	// the overrides of the same MS address should never conflict with each other.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(itei uint32) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if err := u.AddTunnelOverride(net.ParseIP("127.0.0.68"), net.ParseIP("10.0.0.1"), 0x11111111, itei); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(uint32(i + 1))
+	}
+	wg.Wait()
+
+	tunnels, err := u.ListTunnels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tunnels) != 1 {
+		t.Errorf("Got wrong number of tunnels: %d", len(tunnels))
+	}
+}
+
+func TestTUNSwitchTunnelConcurrently(t *testing.T) {
+	tun := &fakeTUN{rx: make(chan []byte), tx: make(chan []byte, 1)}
+	defer close(tun.rx)
+
+	u := gtpv1.NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 69}, Port: 2152})
+	if err := u.EnableTUN(tun, gtpv1.RoleGGSN); err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+
+	msIP := net.ParseIP("10.0.0.1")
+	if err := u.AddTunnel(net.ParseIP("127.0.0.70"), msIP, 0x11111111, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the tunnel should always exist while switching, so that neither the switch
+	// fails nor the others can take its place.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			if err := u.SwitchTunnel(net.ParseIP("127.0.0.70"), msIP, uint32(0x11111111+n%2), 1); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			if err := u.AddTunnel(net.ParseIP("127.0.0.70"), msIP, 0x22222222, 1); err == nil {
+				t.Error("AddTunnel succeeded while the tunnel should exist")
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	tunnels, err := u.ListTunnels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tunnels) != 1 {
+		t.Errorf("Got wrong number of tunnels: %d", len(tunnels))
+	}
+}
+
+// countingTUN is a stand-in for TUN device that only counts the packets written.
+type countingTUN struct {
+	written atomic.Int64
+	closeCh chan struct{}
+}
+
+func (c *countingTUN) Read(b []byte) (int, error) {
+	<-c.closeCh
+	return 0, io.EOF
+}
+
+func (c *countingTUN) Write(b []byte) (int, error) {
+	c.written.Add(1)
+	return len(b), nil
+}
+
+func BenchmarkTUNDecapsulate(b *testing.B) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.63:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tun := &countingTUN{closeCh: make(chan struct{})}
+	defer close(tun.closeCh)
+
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	if err := srvConn.EnableTUN(tun, gtpv1.RoleGGSN); err != nil {
+		b.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	peerConn, err := net.ListenPacket("udp", "127.0.0.64:2152")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer peerConn.Close()
+
+	if err := srvConn.AddTunnel(
+		net.ParseIP("127.0.0.64"), net.ParseIP("10.0.0.1"), 0x11111111, 0x22222222,
+	); err != nil {
+		b.Fatal(err)
+	}
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	pkt, err := gtpv1.Encapsulate(0x22222222, ipv4Packet("10.0.0.1", "192.168.0.1", make([]byte, 1000))).Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(pkt)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// keep the number of packets in flight small so that the packets are
+		// not dropped due to the lack of socket buffers.
+		for deadline := time.Now().Add(100 * time.Millisecond); int64(i)-tun.written.Load() > 64; {
+			if time.Now().After(deadline) {
+				break
+			}
+			runtime.Gosched()
+		}
+		if _, err := peerConn.WriteTo(pkt, srvAddr); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for deadline := time.Now().Add(time.Second); tun.written.Load() < int64(b.N); {
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(tun.written.Load())/float64(b.N), "written/op")
+}
@@ -6,42 +6,306 @@ package gtpv1
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+	"github.com/wmnsk/go-gtp/metrics"
+	"golang.org/x/net/ipv4"
 )
 
+// Role is a role for Kernel GTP-U and the userland GTP-U with TUN device.
+type Role int
+
+// Role definitions.
+const (
+	RoleGGSN Role = iota
+	RoleSGSN
+)
+
+// Tunnel is a GTP-U tunnel in Linux Kernel GTP-U or the userland GTP-U with TUN device.
+type Tunnel struct {
+	// PeerIP is the IP address of the peer that the packets are encapsulated to.
+	PeerIP net.IP
+	// MSIP is the IP address of the subscriber.
+	MSIP net.IP
+	// OTEI is the outgoing TEID, ITEI is the incoming TEID.
+	OTEI, ITEI uint32
+}
+
 type peer struct {
 	teid    uint32
 	addr    net.Addr
 	srcConn *UPlaneConn
 }
 
+// relayMap is the map of incoming TEID and peer to relay the packets to.
+//
+// It is looked up for every packet received, so the lookup is done without lock.
+type relayMap struct {
+	syncMap sync.Map
+	count   atomic.Int64
+}
+
+func (r *relayMap) load(teidIn uint32) (*peer, bool) {
+	p, ok := r.syncMap.Load(teidIn)
+	if !ok {
+		return nil, false
+	}
+
+	return p.(*peer), true
+}
+
+func (r *relayMap) store(teidIn uint32, p *peer) {
+	if _, loaded := r.syncMap.Swap(teidIn, p); !loaded {
+		r.count.Add(1)
+	}
+}
+
+func (r *relayMap) compareAndSwap(teidIn uint32, old, p *peer) bool {
+	return r.syncMap.CompareAndSwap(teidIn, old, p)
+}
+
+// delete deletes the relay with teidIn, and reports whether it existed or not.
+func (r *relayMap) delete(teidIn uint32) (*peer, bool) {
+	if p, loaded := r.syncMap.LoadAndDelete(teidIn); loaded {
+		r.count.Add(-1)
+		return p.(*peer), true
+	}
+	return nil, false
+}
+
+// clear deletes all the relays, and returns them by the incoming TEIDs.
+func (r *relayMap) clear() map[uint32]*peer {
+	peers := map[uint32]*peer{}
+	r.syncMap.Range(func(k, _ interface{}) bool {
+		if p, ok := r.delete(k.(uint32)); ok {
+			peers[k.(uint32)] = p
+		}
+		return true
+	})
+	return peers
+}
+
+func (r *relayMap) len() int {
+	return int(r.count.Load())
+}
+
+func (r *relayMap) rangeFunc(fn func(teidIn uint32, p *peer) bool) {
+	r.syncMap.Range(func(k, v interface{}) bool {
+		return fn(k.(uint32), v.(*peer))
+	})
+}
+
+// relayBatch is the packets to be relayed, grouped by the UPlaneConn that sends
+// them so that they can be written at a time.
+type relayBatch struct {
+	conns []*UPlaneConn
+	msgs  map[*UPlaneConn][]ipv4.Message
+}
+
+func (r *relayBatch) add(p *peer, b []byte) {
+	if r.msgs == nil {
+		r.msgs = map[*UPlaneConn][]ipv4.Message{}
+	}
+
+	ms, ok := r.msgs[p.srcConn]
+	if !ok || len(ms) == 0 {
+		r.conns = append(r.conns, p.srcConn)
+	}
+
+	// reuse the messages allocated in the previous batches if possible.
+	n := len(ms)
+	if n < cap(ms) {
+		ms = ms[:n+1]
+	} else {
+		ms = append(ms, ipv4.Message{})
+	}
+	if ms[n].Buffers == nil {
+		ms[n].Buffers = make([][]byte, 1)
+	}
+	ms[n].Buffers[0] = b
+	ms[n].Addr = p.addr
+	r.msgs[p.srcConn] = ms
+}
+
+// flush writes all the packets in the batch and makes it empty.
+func (r *relayBatch) flush() {
+	var out tpduCounter
+	for _, c := range r.conns {
+		ms := r.msgs[c]
+		pc := c.packetConn()
+		rec := c.recorder()
+		for len(ms) > 0 {
+			n, err := pc.WriteBatch(ms, 0)
+			if err != nil {
+				// should not stop serving with this error
+				c.Logger().Warn("error relaying T-PDUs", logkey.Error, err)
+				break
+			}
+			if n == 0 {
+				break
+			}
+			if rec != nil {
+				for _, m := range ms[:n] {
+					out.add(m.Buffers[0])
+				}
+			}
+			ms = ms[n:]
+		}
+		if rec != nil {
+			out.record(rec, metrics.Sent)
+		}
+
+		ms = r.msgs[c]
+		for i := range ms {
+			ms[i].Buffers[0] = nil
+			ms[i].Addr = nil
+		}
+		r.msgs[c] = ms[:0]
+	}
+	r.conns = r.conns[:0]
+}
+
 // RelayTo relays T-PDU type of packet to peer node(specified by raddr) from the UPlaneConn given.
 //
 // By using this, owner of UPlaneConn won't be able to Read and Write the packets that has teidIn.
+//
+// teidIn is kept from being issued by NewFTEID until CloseRelay is called, even if
+// it is not the one issued by NewFTEID.
 func (u *UPlaneConn) RelayTo(c *UPlaneConn, teidIn, teidOut uint32, raddr net.Addr) error {
 	if u.KernelGTP.enabled {
 		return errors.New("cannot call RelayTo when using Kernel GTP-U")
 	}
 
-	u.mu.Lock()
-	defer u.mu.Unlock()
-	if u.relayMap == nil {
-		u.relayMap = map[uint32]*peer{}
+	// this fails if teidIn is issued by NewFTEID, which is already marked as used.
+	_ = u.teidAllocator().Reserve(teidIn)
+
+	c.relayersMu.Lock()
+	old, replaced := u.relayMap.load(teidIn)
+	u.relayMap.store(teidIn, &peer{teid: teidOut, addr: raddr, srcConn: c})
+	c.relayers.Store(u, struct{}{})
+	c.relayersMu.Unlock()
+
+	if replaced && old.srcConn != c {
+		u.forgetRelayer(old.srcConn)
+	}
+	return nil
+}
+
+// SwitchRelay updates the peer of the relay specified with teidIn (created by RelayTo)
+// to the one specified with teidOut and raddr, and sends End Marker to the old peer
+// through the UPlaneConn given to RelayTo.
+//
+// This is useful when the downlink F-TEID is changed during handover, in which
+// End Marker should be sent on the old path after switching the path.
+// If the peer is not changed, it does nothing.
+func (u *UPlaneConn) SwitchRelay(teidIn, teidOut uint32, raddr net.Addr) error {
+	if u.KernelGTP.enabled {
+		return errors.New("cannot call SwitchRelay when using Kernel GTP-U")
+	}
+
+	old, ok := u.relayMap.load(teidIn)
+	if !ok {
+		return fmt.Errorf("no relay found with TEID: %#x", teidIn)
+	}
+	if old.teid == teidOut && old.addr.String() == raddr.String() {
+		return nil
+	}
+	if !u.relayMap.compareAndSwap(teidIn, old, &peer{teid: teidOut, addr: raddr, srcConn: old.srcConn}) {
+		return fmt.Errorf("relay with TEID: %#x has been changed while switching", teidIn)
+	}
+
+	if err := old.srcConn.EndMarker(old.teid, old.addr); err != nil {
+		return fmt.Errorf("failed to send End Marker to %s: %w", old.addr, err)
 	}
-	u.relayMap[teidIn] = &peer{teid: teidOut, addr: raddr, srcConn: c}
 	return nil
 }
 
 // CloseRelay stops relaying T-PDU from a conn to conn.
+//
+// teidIn is released to be issued by NewFTEID again.
 func (u *UPlaneConn) CloseRelay(teidIn uint32) error {
 	if u.KernelGTP.enabled {
 		return errors.New("cannot call CloseRelay when using Kernel GTP-U")
 	}
 
-	u.mu.Lock()
-	delete(u.relayMap, teidIn)
-	u.mu.Unlock()
+	if p, ok := u.relayMap.delete(teidIn); ok {
+		u.teidAllocator().Release(teidIn)
+		u.forgetRelayer(p.srcConn)
+	}
+	return nil
+}
+
+// forgetRelayer lets c forget u as the relayer if u no longer relays through c.
+func (u *UPlaneConn) forgetRelayer(c *UPlaneConn) {
+	c.relayersMu.Lock()
+	defer c.relayersMu.Unlock()
+
+	var relaying bool
+	u.relayMap.rangeFunc(func(_ uint32, p *peer) bool {
+		relaying = p.srcConn == c
+		return !relaying
+	})
+	if !relaying {
+		c.relayers.Delete(u)
+	}
+}
+
+// removeTunnelsByPeer removes the relays and GTP-U tunnels that send
+// packets to peerIP with otei.
+func (u *UPlaneConn) removeTunnelsByPeer(otei uint32, peerIP net.IP) error {
+	if u.KernelGTP.enabled {
+		return u.delKernelTunnelsByPeer(otei, peerIP)
+	}
+	if g := u.tun.Load(); g != nil {
+		u.delUserTunnelsByPeer(g, otei, peerIP)
+	}
 
-	u.iteiMap.delete(teidIn)
+	// relays that send packets through u may be on any UPlaneConn including u.
+	u.relayers.Range(func(k, _ interface{}) bool {
+		r := k.(*UPlaneConn)
+		for _, teidIn := range r.relaysTo(u, otei, peerIP) {
+			if err := r.CloseRelay(teidIn); err != nil {
+				r.Logger().Warn("failed to close relay", logkey.TEID, connlog.TEID(teidIn), logkey.Error, err)
+			}
+		}
+		return true
+	})
 	return nil
 }
+
+// relaysTo returns the incoming TEIDs of the relays that send packets through
+// c to peerIP with otei.
+func (u *UPlaneConn) relaysTo(c *UPlaneConn, otei uint32, peerIP net.IP) []uint32 {
+	var teids []uint32
+	u.relayMap.rangeFunc(func(teidIn uint32, p *peer) bool {
+		if p.srcConn != c || p.teid != otei {
+			return true
+		}
+		if ip := addrIP(p.addr); ip != nil && ip.Equal(peerIP) {
+			teids = append(teids, teidIn)
+		}
+		return true
+	})
+	return teids
+}
+
+// addrIP returns the IP address in addr if any.
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return nil
+		}
+		return net.ParseIP(host)
+	}
+}
@@ -12,14 +12,30 @@ import (
 	"github.com/vishvananda/netlink"
 )
 
-// Role is a role for Kernel GTP-U.
-type Role int
+// KernelGTPConfig is the configuration of the gtp device used by Linux Kernel GTP-U.
+// The zero value is the same configuration as EnableKernelGTP uses.
+type KernelGTPConfig struct {
+	// MTU is the MTU of the gtp device. 1500 is used if not specified.
+	MTU int
 
-// Role definitions.
-const (
-	RoleGGSN Role = iota
-	RoleSGSN
-)
+	// PDPHashsize is the size of the hash table of the PDP contexts in the
+	// gtp device. 131072 is used if not specified.
+	PDPHashsize int
+
+	// Reattach lets EnableKernelGTPWithConfig take over the existing gtp device
+	// with the same name, instead of failing with the device already exists.
+	// This is useful when the program is restarted.
+	//
+	// The socket held by the existing device cannot be retrieved by another
+	// process, so the device is deleted to release the local address, and then
+	// created again with the socket of this UPlaneConn with the tunnels in it
+	// restored. The packets arriving in between are dropped.
+	Reattach bool
+
+	// KeepOnClose prevents the gtp device from being deleted when UPlaneConn
+	// is closed, which is expected to be used with Reattach.
+	KeepOnClose bool
+}
 
 // EnableKernelGTP enables Linux Kernel GTP-U.
 // Note that this removes all the existing userland tunnels, and cannot be disabled while
@@ -37,127 +53,288 @@ const (
 // forwarded to the peer(S-GW, in this case).
 //
 // Please see the examples/gw-tester for how each node handles routing from the program.
+//
+// To configure the gtp device, use EnableKernelGTPWithConfig instead.
 func (u *UPlaneConn) EnableKernelGTP(devname string, role Role) error {
-	if u.pktConn == nil {
+	return u.EnableKernelGTPWithConfig(devname, role, KernelGTPConfig{})
+}
+
+// EnableKernelGTPWithConfig enables Linux Kernel GTP-U with the gtp device configured
+// as specified in cfg. See EnableKernelGTP for the details.
+func (u *UPlaneConn) EnableKernelGTPWithConfig(devname string, role Role, cfg KernelGTPConfig) error {
+	if u.tun.Load() != nil {
+		return errors.New("cannot call EnableKernelGTP when using TUN")
+	}
+
+	mtu := cfg.MTU
+	if mtu == 0 {
+		mtu = defaultKernelGTPMTU
+	}
+	hashsize := cfg.PDPHashsize
+	if hashsize == 0 {
+		hashsize = defaultKernelGTPPDPHashsize
+	}
+
+	// the existing device must be detached before binding, as it still holds
+	// the socket bound to the same address.
+	var tunnels []*Tunnel
+	if cfg.Reattach {
 		var err error
-		u.pktConn, err = newPktConn(u.laddr)
+		tunnels, err = detachKernelGTP(devname)
 		if err != nil {
 			return err
 		}
 	}
 
-	f, err := u.pktConn.File()
+	u.mu.Lock()
+	pc, err := u.listen()
+	u.mu.Unlock()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve file from conn: %w", err)
+		return err
 	}
 
-	u.KernelGTP.Link = &netlink.GTP{
-		LinkAttrs: netlink.LinkAttrs{
-			Name: devname,
-		},
-		FD1:  int(f.Fd()),
-		Role: int(role),
+	f, err := pc.File()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve file from conn: %w", err)
 	}
 
-	if err := netlink.LinkAdd(u.KernelGTP.Link); err != nil {
-		_ = f.Close()
-		return fmt.Errorf("failed to add device %s: %w", u.KernelGTP.Link.Name, err)
-	}
-	if err := netlink.LinkSetUp(u.KernelGTP.Link); err != nil {
+	link, err := gtpLinkAdd(devname, int(f.Fd()), int(role), hashsize, mtu)
+	if err != nil {
 		_ = f.Close()
-		return fmt.Errorf("failed to setup device %s: %w", u.KernelGTP.Link.Name, err)
+		return fmt.Errorf("failed to add device %s: %w", devname, err)
 	}
-	if err := netlink.LinkSetMTU(u.KernelGTP.Link, 1500); err != nil {
+	if err := netlink.LinkSetUp(link); err != nil {
 		_ = f.Close()
-		return fmt.Errorf("failed to set MTU for device %s: %w", u.KernelGTP.Link.Name, err)
+		return fmt.Errorf("failed to setup device %s: %w", devname, err)
 	}
+	u.KernelGTP.Link = link
 	u.KernelGTP.connFile = f
+	u.KernelGTP.keepOnClose = cfg.KeepOnClose
 	u.KernelGTP.enabled = true
 
 	// remove relayed userland tunnels if exists
-	if len(u.relayMap) != 0 {
-		u.mu.Lock()
-		u.relayMap = nil
-		u.mu.Unlock()
+	for teidIn, p := range u.relayMap.clear() {
+		u.teidAllocator().Release(teidIn)
+		u.forgetRelayer(p.srcConn)
+	}
+
+	for _, t := range tunnels {
+		if err := gtpPDPAdd(link, t); err != nil {
+			return fmt.Errorf("failed to restore tunnel for %s with %s: %w", t.MSIP, t.PeerIP, err)
+		}
+		// keep the TEIDs from being reused by NewFTEID.
+		_ = u.teidAllocator().Reserve(t.ITEI)
 	}
 
 	return nil
 }
 
+// detachKernelGTP deletes the existing gtp device named devname, and returns
+// the tunnels that were on it.
+func detachKernelGTP(devname string) ([]*Tunnel, error) {
+	link, err := netlink.LinkByName(devname)
+	if err != nil {
+		var notFound netlink.LinkNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find device %s: %w", devname, err)
+	}
+	if link.Type() != "gtp" {
+		return nil, fmt.Errorf("failed to reattach to device %s: not a gtp device", devname)
+	}
+
+	tunnels, err := gtpPDPList(link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tunnels on device %s: %w", devname, err)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return nil, fmt.Errorf("failed to delete device %s: %w", devname, err)
+	}
+	return tunnels, nil
+}
+
+// ListTunnels returns the GTP-U tunnels in Linux Kernel GTP-U.
+// If the userland GTP-U is enabled by EnableTUN, the tunnels in it are returned instead.
+func (u *UPlaneConn) ListTunnels() ([]*Tunnel, error) {
+	if g := u.tun.Load(); g != nil {
+		return g.list(), nil
+	}
+	if !u.KernelGTP.enabled {
+		return nil, errors.New("cannot call ListTunnels when not using Kernel GTP-U")
+	}
+
+	tunnels, err := gtpPDPList(u.KernelGTP.Link)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tunnels: %w", err)
+	}
+	return tunnels, nil
+}
+
 // AddTunnel adds a GTP-U tunnel with Linux Kernel GTP-U via netlink.
+// If the userland GTP-U is enabled by EnableTUN, the tunnel is added to it instead.
+//
+// peerIP and msIP can be IPv6 addresses, which requires Linux 6.11 or later
+// when using Kernel GTP-U.
 func (u *UPlaneConn) AddTunnel(peerIP, msIP net.IP, otei, itei uint32) error {
+	if g := u.tun.Load(); g != nil {
+		return u.addUserTunnel(g, peerIP, msIP, otei, itei)
+	}
 	if !u.KernelGTP.enabled {
 		return errors.New("cannot call AddTunnel when not using Kernel GTP-U")
 	}
 
-	pdp := &netlink.PDP{
-		Version:     1,
-		PeerAddress: peerIP,
-		MSAddress:   msIP,
-		OTEI:        otei,
-		ITEI:        itei,
-	}
-	if err := netlink.GTPPDPAdd(u.KernelGTP.Link, pdp); err != nil {
+	t := &Tunnel{PeerIP: peerIP, MSIP: msIP, OTEI: otei, ITEI: itei}
+	if err := gtpPDPAdd(u.KernelGTP.Link, t); err != nil {
 		return fmt.Errorf("failed to add tunnel for %s with %s: %w", msIP, peerIP, err)
 	}
+
+	// keep the TEID from being issued by NewFTEID while the tunnel exists.
+	_ = u.teidAllocator().Reserve(itei)
 	return nil
 }
 
 // AddTunnelOverride adds a GTP-U tunnel with Linux Kernel GTP-U via netlink.
 // If there is already an existing tunnel that has the same msIP and/or incoming TEID,
 // this deletes it before adding the tunnel.
+// If the userland GTP-U is enabled by EnableTUN, the tunnel is added to it instead.
 func (u *UPlaneConn) AddTunnelOverride(peerIP, msIP net.IP, otei, itei uint32) error {
+	if g := u.tun.Load(); g != nil {
+		return u.addUserTunnelOverride(g, peerIP, msIP, otei, itei)
+	}
 	if !u.KernelGTP.enabled {
 		return errors.New("cannot call AddTunnelOverride when not using Kernel GTP-U")
 	}
 
-	if pdp, _ := netlink.GTPPDPByMSAddress(u.KernelGTP.Link, msIP); pdp != nil {
+	if t, _ := gtpPDPByMSAddress(u.KernelGTP.Link, msIP); t != nil {
 		// do nothing even this fails
-		_ = netlink.GTPPDPDel(u.KernelGTP.Link, pdp)
+		if err := gtpPDPDel(u.KernelGTP.Link, t); err == nil && t.ITEI != itei {
+			u.teidAllocator().Release(t.ITEI)
+		}
 	}
-	if pdp, _ := netlink.GTPPDPByITEI(u.KernelGTP.Link, int(itei)); pdp != nil {
+	if t, _ := gtpPDPByITEI(u.KernelGTP.Link, itei); t != nil {
 		// do nothing even this fails
-		_ = netlink.GTPPDPDel(u.KernelGTP.Link, pdp)
+		_ = gtpPDPDel(u.KernelGTP.Link, t)
 	}
 
 	return u.AddTunnel(peerIP, msIP, otei, itei)
 }
 
+// SwitchTunnel updates the Linux Kernel GTP-U tunnel specified with the incoming TEID
+// to the one with the given peer's IP, subscriber's IP and outgoing TEID, and sends
+// End Marker to the old peer if the peer's IP or outgoing TEID is changed.
+//
+// This works like AddTunnelOverride, but is meant to be used when the downlink
+// F-TEID is changed during handover, in which End Marker should be sent on the old
+// path after switching the path.
+// If the userland GTP-U is enabled by EnableTUN, the tunnel in it is updated instead.
+//
+// When the subscriber's IP is not changed, the tunnel is updated in place without
+// any moment that the packets are dropped. Otherwise, the tunnel is deleted and
+// added again, and the old one is restored if it fails to add the new one.
+func (u *UPlaneConn) SwitchTunnel(peerIP, msIP net.IP, otei, itei uint32) error {
+	if g := u.tun.Load(); g != nil {
+		return u.switchUserTunnel(g, peerIP, msIP, otei, itei)
+	}
+	if !u.KernelGTP.enabled {
+		return errors.New("cannot call SwitchTunnel when not using Kernel GTP-U")
+	}
+
+	old, err := gtpPDPByITEI(u.KernelGTP.Link, itei)
+	if err != nil {
+		return fmt.Errorf("failed to find tunnel with %d: %w", itei, err)
+	}
+
+	if old.MSIP.Equal(msIP) {
+		t := &Tunnel{PeerIP: peerIP, MSIP: msIP, OTEI: otei, ITEI: itei}
+		if err := gtpPDPUpdate(u.KernelGTP.Link, t); err != nil {
+			return fmt.Errorf("failed to switch tunnel for %s to %s: %w", msIP, peerIP, err)
+		}
+	} else if err := u.AddTunnelOverride(peerIP, msIP, otei, itei); err != nil {
+		// restore the old one not to leave the subscriber without tunnel.
+		if t, _ := gtpPDPByITEI(u.KernelGTP.Link, itei); t == nil {
+			if rerr := gtpPDPAdd(u.KernelGTP.Link, old); rerr != nil {
+				return fmt.Errorf("%w (and failed to restore the old tunnel: %w)", err, rerr)
+			}
+		}
+		return err
+	}
+	if old.OTEI == otei && old.PeerIP.Equal(peerIP) {
+		return nil
+	}
+
+	raddr, err := net.ResolveUDPAddr(u.laddr.Network(), net.JoinHostPort(old.PeerIP.String(), GTPUPort[1:]))
+	if err != nil {
+		return err
+	}
+	if err := u.EndMarker(old.OTEI, raddr); err != nil {
+		return fmt.Errorf("failed to send End Marker to %s: %w", raddr, err)
+	}
+	return nil
+}
+
 // DelTunnelByITEI deletes a Linux Kernel GTP-U tunnel specified with the incoming TEID.
+// If the userland GTP-U is enabled by EnableTUN, the tunnel is deleted from it instead.
 func (u *UPlaneConn) DelTunnelByITEI(itei uint32) error {
+	if g := u.tun.Load(); g != nil {
+		return u.delUserTunnelByITEI(g, itei)
+	}
 	if !u.KernelGTP.enabled {
 		return errors.New("cannot call DelTunnel when not using Kernel GTP-U")
 	}
 
-	pdp, err := netlink.GTPPDPByITEI(u.KernelGTP.Link, int(itei))
+	t, err := gtpPDPByITEI(u.KernelGTP.Link, itei)
 	if err != nil {
 		return fmt.Errorf("failed to delete tunnel with %d: %w", itei, err)
 	}
 
-	if err := netlink.GTPPDPDel(u.KernelGTP.Link, pdp); err != nil {
-		return fmt.Errorf("failed to delete tunnel for %s: %w", pdp, err)
+	if err := gtpPDPDel(u.KernelGTP.Link, t); err != nil {
+		return fmt.Errorf("failed to delete tunnel for %s: %w", t.MSIP, err)
 	}
 
-	u.iteiMap.delete(itei)
+	u.teidAllocator().Release(itei)
 	return nil
 }
 
 // DelTunnelByMSAddress deletes a Linux Kernel GTP-U tunnel specified with the subscriber's IP.
+// If the userland GTP-U is enabled by EnableTUN, the tunnel is deleted from it instead.
 func (u *UPlaneConn) DelTunnelByMSAddress(msIP net.IP) error {
+	if g := u.tun.Load(); g != nil {
+		return u.delUserTunnelByMSAddress(g, msIP)
+	}
 	if !u.KernelGTP.enabled {
 		return errors.New("cannot call DelTunnel when not using Kernel GTP-U")
 	}
 
-	pdp, err := netlink.GTPPDPByMSAddress(u.KernelGTP.Link, msIP)
+	t, err := gtpPDPByMSAddress(u.KernelGTP.Link, msIP)
 	if err != nil {
 		return fmt.Errorf("failed to delete tunnel with %s: %w", msIP, err)
 	}
-	itei := pdp.ITEI
 
-	if err := netlink.GTPPDPDel(u.KernelGTP.Link, pdp); err != nil {
-		return fmt.Errorf("failed to delete tunnel for %s: %w", pdp, err)
+	if err := gtpPDPDel(u.KernelGTP.Link, t); err != nil {
+		return fmt.Errorf("failed to delete tunnel for %s: %w", msIP, err)
 	}
 
-	u.iteiMap.delete(itei)
+	u.teidAllocator().Release(t.ITEI)
+	return nil
+}
+
+// delKernelTunnelsByPeer deletes the Linux Kernel GTP-U tunnels specified with
+// the outgoing TEID and the peer's IP.
+func (u *UPlaneConn) delKernelTunnelsByPeer(otei uint32, peerIP net.IP) error {
+	tunnels, err := gtpPDPList(u.KernelGTP.Link)
+	if err != nil {
+		return fmt.Errorf("failed to list tunnels: %w", err)
+	}
+
+	for _, t := range tunnels {
+		if t.OTEI != otei || !t.PeerIP.Equal(peerIP) {
+			continue
+		}
+		if err := gtpPDPDel(u.KernelGTP.Link, t); err != nil {
+			return fmt.Errorf("failed to delete tunnel for %s: %w", t.MSIP, err)
+		}
+		u.teidAllocator().Release(t.ITEI)
+	}
 	return nil
 }
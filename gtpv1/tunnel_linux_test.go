@@ -0,0 +1,64 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vishvananda/netlink"
+)
+
+func TestEnableKernelGTPReattach(t *testing.T) {
+	skipWithoutKernelGTP(t)
+
+	laddr, err := net.ResolveUDPAddr("udp", "127.0.0.74:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u1 := NewUPlaneConn(laddr)
+	if err := u1.EnableKernelGTPWithConfig("gtp-reattach", RoleGGSN, KernelGTPConfig{KeepOnClose: true}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if link, err := netlink.LinkByName("gtp-reattach"); err == nil {
+			_ = netlink.LinkDel(link)
+		}
+	})
+	if err := u1.AddTunnel(net.ParseIP("127.0.0.75"), net.ParseIP("10.0.0.1"), 0x11111111, 0x22222222); err != nil {
+		t.Fatal(err)
+	}
+
+	// the device keeps the tunnel and the socket after the descriptors are
+	// closed, as if the program exited.
+	if err := u1.KernelGTP.connFile.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := u1.packetConn().Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	u2 := NewUPlaneConn(laddr)
+	if err := u2.EnableKernelGTPWithConfig("gtp-reattach", RoleGGSN, KernelGTPConfig{Reattach: true}); err != nil {
+		t.Fatal(err)
+	}
+	defer u2.Close()
+
+	got, err := u2.ListTunnels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Tunnel{{
+		PeerIP: net.ParseIP("127.0.0.75").To4(),
+		MSIP:   net.ParseIP("10.0.0.1").To4(),
+		OTEI:   0x11111111,
+		ITEI:   0x22222222,
+	}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+//go:build !linux
+
+package gtpv1
+
+import (
+	"errors"
+	"net"
+)
+
+// AddTunnel adds a GTP-U tunnel to the userland GTP-U enabled by EnableTUN.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) AddTunnel(peerIP, msIP net.IP, otei, itei uint32) error {
+	g := u.tun.Load()
+	if g == nil {
+		return errors.New("cannot call AddTunnel when not using TUN")
+	}
+	return u.addUserTunnel(g, peerIP, msIP, otei, itei)
+}
+
+// AddTunnelOverride adds a GTP-U tunnel to the userland GTP-U enabled by EnableTUN.
+// If there is already an existing tunnel that has the same msIP and/or incoming TEID,
+// this deletes it before adding the tunnel.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) AddTunnelOverride(peerIP, msIP net.IP, otei, itei uint32) error {
+	g := u.tun.Load()
+	if g == nil {
+		return errors.New("cannot call AddTunnelOverride when not using TUN")
+	}
+	return u.addUserTunnelOverride(g, peerIP, msIP, otei, itei)
+}
+
+// SwitchTunnel updates the tunnel in the userland GTP-U specified with the incoming
+// TEID to the one with the given peer's IP, subscriber's IP and outgoing TEID, and
+// sends End Marker to the old peer if the peer's IP or outgoing TEID is changed.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) SwitchTunnel(peerIP, msIP net.IP, otei, itei uint32) error {
+	g := u.tun.Load()
+	if g == nil {
+		return errors.New("cannot call SwitchTunnel when not using TUN")
+	}
+	return u.switchUserTunnel(g, peerIP, msIP, otei, itei)
+}
+
+// DelTunnelByITEI deletes a tunnel in the userland GTP-U specified with the incoming TEID.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) DelTunnelByITEI(itei uint32) error {
+	g := u.tun.Load()
+	if g == nil {
+		return errors.New("cannot call DelTunnel when not using TUN")
+	}
+	return u.delUserTunnelByITEI(g, itei)
+}
+
+// DelTunnelByMSAddress deletes a tunnel in the userland GTP-U specified with the subscriber's IP.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) DelTunnelByMSAddress(msIP net.IP) error {
+	g := u.tun.Load()
+	if g == nil {
+		return errors.New("cannot call DelTunnel when not using TUN")
+	}
+	return u.delUserTunnelByMSAddress(g, msIP)
+}
+
+// ListTunnels returns the tunnels in the userland GTP-U enabled by EnableTUN.
+//
+// On Linux, this works with Linux Kernel GTP-U as well.
+func (u *UPlaneConn) ListTunnels() ([]*Tunnel, error) {
+	g := u.tun.Load()
+	if g == nil {
+		return nil, errors.New("cannot call ListTunnels when not using TUN")
+	}
+	return g.list(), nil
+}
+
+// delKernelTunnelsByPeer is not available, as Kernel GTP-U works only on Linux.
+func (u *UPlaneConn) delKernelTunnelsByPeer(otei uint32, peerIP net.IP) error {
+	return errors.New("cannot use Kernel GTP-U on this platform")
+}
@@ -0,0 +1,52 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv1
+
+import (
+	"net"
+	"testing"
+)
+
+func TestRelayersForgotten(t *testing.T) {
+	u := NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2152})
+	c := NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2152})
+	raddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 3}, Port: 2152}
+
+	isRelayer := func() bool {
+		_, ok := c.relayers.Load(u)
+		return ok
+	}
+
+	for _, teid := range []uint32{1, 2} {
+		if err := u.RelayTo(c, teid, 0x11111111, raddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// u is kept until the last relay through c is closed.
+	if err := u.CloseRelay(1); err != nil {
+		t.Fatal(err)
+	}
+	if !isRelayer() {
+		t.Error("relayer is forgotten while relaying")
+	}
+	if err := u.CloseRelay(2); err != nil {
+		t.Fatal(err)
+	}
+	if isRelayer() {
+		t.Error("relayer is not forgotten on CloseRelay")
+	}
+
+	// u is forgotten when it is closed.
+	if err := u.RelayTo(c, 3, 0x11111111, raddr); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if isRelayer() {
+		t.Error("relayer is not forgotten on Close")
+	}
+}
@@ -6,25 +6,48 @@ package gtpv1
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net"
 	"os"
-	"strings"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/vishvananda/netlink"
 	"github.com/wmnsk/go-gtp/gtpv1/ie"
 	"github.com/wmnsk/go-gtp/gtpv1/message"
 	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+	"github.com/wmnsk/go-gtp/metrics"
+	"github.com/wmnsk/go-gtp/teidalloc"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
 )
 
+const (
+	// defaultBatchSize is the default number of packets read at a time.
+	defaultBatchSize = 64
+
+	// tpduQueueSize is the number of T-PDUs that can be queued to be read
+	// by ReadFromGTP.
+	tpduQueueSize = 1024
+)
+
+type packet struct {
+	raddr net.Addr
+	raw   []byte
+
+	// buf is the buffer from bufferPool that raw is in.
+	buf *[]byte
+}
+
 type tpduSet struct {
 	raddr   net.Addr
 	teid    uint32
@@ -46,6 +69,19 @@ type pktConn interface {
 	// Attempting to change properties of the original using this duplicate may or may not have the desired effect.
 	File() (f *os.File, err error)
 
+	// ReadBatch reads a batch of messages.
+	// On Linux, a batch read will be optimized with recvmmsg(2).
+	// On other platforms, a batch read reads a single message at a time.
+	ReadBatch(ms []ipv4.Message, flags int) (int, error)
+
+	// WriteBatch writes a batch of messages.
+	// On Linux, a batch write will be optimized with sendmmsg(2).
+	// On other platforms, a batch write writes a single message at a time.
+	WriteBatch(ms []ipv4.Message, flags int) (int, error)
+
+	// writeTo writes a packet with payload p to addr without changing the DSCP/ECN value.
+	writeTo(p []byte, addr net.Addr) (n int, err error)
+
 	net.PacketConn
 }
 
@@ -103,6 +139,21 @@ func (pkt pktConn4) WriteToWithDSCPECN(p []byte, addr net.Addr, dscpecn int) (n
 	return pkt.WriteTo(p, addr)
 }
 
+// WriteBatch writes a batch of messages, waiting for the ones being written with
+// another DSCP/ECN value.
+func (pkt pktConn4) WriteBatch(ms []ipv4.Message, flags int) (int, error) {
+	pkt.mu.Lock()
+	defer pkt.mu.Unlock()
+	return pkt.PacketConn.WriteBatch(ms, flags)
+}
+
+// writeTo implements the pktConn writeTo method.
+func (pkt pktConn4) writeTo(p []byte, addr net.Addr) (n int, err error) {
+	pkt.mu.Lock()
+	defer pkt.mu.Unlock()
+	return pkt.WriteTo(p, addr)
+}
+
 // File returns a copy of the underlying os.File. It is the caller's responsibility to close f when finished.
 // Closing c does not affect f, and closing f does not affect c.
 // The returned os.File's file descriptor is different from the connection's.
@@ -165,6 +216,21 @@ func (pkt pktConn6) WriteToWithDSCPECN(p []byte, addr net.Addr, dscpecn int) (n
 	return pkt.WriteTo(p, addr)
 }
 
+// WriteBatch writes a batch of messages, waiting for the ones being written with
+// another DSCP/ECN value.
+func (pkt pktConn6) WriteBatch(ms []ipv6.Message, flags int) (int, error) {
+	pkt.mu.Lock()
+	defer pkt.mu.Unlock()
+	return pkt.PacketConn.WriteBatch(ms, flags)
+}
+
+// writeTo implements the pktConn writeTo method.
+func (pkt pktConn6) writeTo(p []byte, addr net.Addr) (n int, err error) {
+	pkt.mu.Lock()
+	defer pkt.mu.Unlock()
+	return pkt.WriteTo(p, addr)
+}
+
 // File returns a copy of the underlying os.File. It is the caller's responsibility to close f when finished.
 // Closing c does not affect f, and closing f does not affect c.
 // The returned os.File's file descriptor is different from the connection's.
@@ -201,68 +267,109 @@ func newPktConn(laddr net.Addr) (pktConn, error) {
 	return nil, fmt.Errorf("laddr must refer to an IP address")
 }
 
+// pktConnHolder holds pktConn to be stored in atomic.Pointer.
+type pktConnHolder struct {
+	pktConn
+}
+
 // UPlaneConn represents a U-Plane Connection of GTPv1.
 type UPlaneConn struct {
-	mu      sync.Mutex
-	laddr   net.Addr
-	pktConn pktConn
+	mu    sync.Mutex
+	laddr net.Addr
 	*msgHandlerMap
-	*iteiMap
+
+	// conn is the underlying connection, which is loaded for every packet
+	// sent and received, so it is accessed without lock.
+	conn atomic.Pointer[pktConnHolder]
+
+	// teids is the allocator of the incoming TEIDs issued by NewFTEID.
+	teids teidalloc.Allocator
+
+	logger       *slog.Logger
+	metrics      atomic.Pointer[recorderHolder]
+	interceptors atomic.Pointer[interceptors]
 
 	tpduCh  chan *tpduSet
 	closeCh chan struct{}
 
-	relayMap map[uint32]*peer
+	relayMap relayMap
+
+	// workers is the number of goroutines that handle the incoming
+	// messages other than the relayed ones.
+	workers int
+
+	// batchSize is the number of packets read at a time.
+	batchSize int
 
 	errIndEnabled bool
 
+	// errIndHandler is called when Error Indication is received.
+	errIndHandler ErrorIndicationHandlerFunc
+
+	// errIndTeardownEnabled makes the tunnels that match the Error
+	// Indication received removed automatically.
+	errIndTeardownEnabled bool
+
+	// relayers is the set of UPlaneConn that relay T-PDUs through this
+	// UPlaneConn, used to find the relays to be removed on Error Indication.
+	// relayersMu serializes the updates of it with the relays of the others.
+	relayers   sync.Map
+	relayersMu sync.Mutex
+
+	// supportedExtHdrs is the set of Extension Header types that
+	// this UPlaneConn can comprehend. It is replaced as a whole on
+	// updates so that it can be read without lock for every packet.
+	supportedExtHdrs atomic.Pointer[map[uint8]struct{}]
+
+	// tun is the userland GTP-U with TUN device, enabled by EnableTUN.
+	tun atomic.Pointer[userlandGTP]
+
 	// for Linux kernel GTP with netlink
 	KernelGTP
 }
 
 // KernelGTP consists of the Linux Kernel GTP-U related objects.
 type KernelGTP struct {
-	enabled  bool
-	connFile *os.File
-	Link     *netlink.GTP
+	enabled     bool
+	keepOnClose bool
+	connFile    *os.File
+	Link        *netlink.GTP
 }
 
+// UPlaneConnOption is an option to configure UPlaneConn at NewUPlaneConn or DialUPlane.
+type UPlaneConnOption func(u *UPlaneConn)
+
 // NewUPlaneConn creates a new UPlaneConn used for server. On client side, use DialUPlane instead.
-func NewUPlaneConn(laddr net.Addr) *UPlaneConn {
-	return &UPlaneConn{
+func NewUPlaneConn(laddr net.Addr, opts ...UPlaneConnOption) *UPlaneConn {
+	u := &UPlaneConn{
 		mu:            sync.Mutex{},
 		msgHandlerMap: newDefaultMsgHandlerMap(),
-		iteiMap:       newiteiMap(),
+		teids:         teidalloc.NewDefaultAllocator(),
 		laddr:         laddr,
 
-		tpduCh:  make(chan *tpduSet),
+		tpduCh:  make(chan *tpduSet, tpduQueueSize),
 		closeCh: make(chan struct{}),
 
 		errIndEnabled: true,
 	}
+	u.supportedExtHdrs.Store(newDefaultSupportedExtHdrs())
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.logger == nil {
+		u.logger = stdLogger.Slogger()
+	}
+	u.logger = connlog.New(u.logger, logProto, laddr)
+	return u
 }
 
 // DialUPlane sends Echo Request to raddr to check if the endpoint is alive and returns UPlaneConn.
-func DialUPlane(ctx context.Context, laddr, raddr net.Addr) (*UPlaneConn, error) {
-	u := &UPlaneConn{
-		mu:            sync.Mutex{},
-		msgHandlerMap: newDefaultMsgHandlerMap(),
-		iteiMap:       newiteiMap(),
-		laddr:         laddr,
-
-		tpduCh:  make(chan *tpduSet),
-		closeCh: make(chan struct{}),
-
-		errIndEnabled: true,
-	}
+func DialUPlane(ctx context.Context, laddr, raddr net.Addr, opts ...UPlaneConnOption) (*UPlaneConn, error) {
+	u := NewUPlaneConn(laddr, opts...)
 
 	// setup UDPConn first.
-	var err error
-	if u.pktConn == nil {
-		u.pktConn, err = newPktConn(u.laddr)
-		if err != nil {
-			return nil, err
-		}
+	if _, err := u.listen(); err != nil {
+		return nil, err
 	}
 
 	// if no response coming within 5 seconds, returns error.
@@ -306,7 +413,7 @@ func DialUPlane(ctx context.Context, laddr, raddr net.Addr) (*UPlaneConn, error)
 
 	go func() {
 		if err := u.serve(ctx); err != nil {
-			logf("fatal error on UPlaneConn %s: %s", u.LocalAddr(), err)
+			u.Logger().Error("fatal error on UPlaneConn", logkey.Error, err)
 		}
 	}()
 
@@ -315,16 +422,14 @@ func DialUPlane(ctx context.Context, laddr, raddr net.Addr) (*UPlaneConn, error)
 
 // ListenAndServe creates a new GTPv2-C *Conn and start serving.
 // This blocks, and returns error only if it face the fatal one. Non-fatal errors are logged
-// with logger. See SetLogger/EnableLogger/DisableLogger for handling of those logs.
+// with logger. See WithLogger and SetLogger/EnableLogging/DisableLogging
+// for handling of those logs.
 func (u *UPlaneConn) ListenAndServe(ctx context.Context) error {
-	if u.pktConn == nil {
-		var err error
-		u.mu.Lock()
-		u.pktConn, err = newPktConn(u.laddr)
-		u.mu.Unlock()
-		if err != nil {
-			return err
-		}
+	u.mu.Lock()
+	_, err := u.listen()
+	u.mu.Unlock()
+	if err != nil {
+		return err
 	}
 	return u.listenAndServe(ctx)
 }
@@ -343,22 +448,54 @@ func (u *UPlaneConn) serve(ctx context.Context) error {
 
 		if u.KernelGTP.enabled {
 			if err := u.KernelGTP.connFile.Close(); err != nil {
-				logf("error closing GTPFile: %s", err)
+				u.Logger().Warn("error closing GTPFile", logkey.Error, err)
 			}
-			if err := netlink.LinkDel(u.KernelGTP.Link); err != nil {
-				logf("error deleting GTPLink: %s", err)
+			if !u.KernelGTP.keepOnClose {
+				if err := netlink.LinkDel(u.KernelGTP.Link); err != nil {
+					u.Logger().Warn("error deleting GTPLink", logkey.Error, err)
+				}
 			}
 		}
 
 		// This doesn't finish for some reason when Kernel GTP is enabled.
-		if u.pktConn != nil {
-			if err := u.pktConn.Close(); err != nil {
-				logf("error closing the underlying conn: %s", err)
+		if pc := u.packetConn(); pc != nil {
+			if err := pc.Close(); err != nil {
+				u.Logger().Warn("error closing the underlying conn", logkey.Error, err)
 			}
 		}
 	}()
 
-	buf := make([]byte, 1500)
+	u.mu.Lock()
+	workers, batchSize := u.workers, u.batchSize
+	u.mu.Unlock()
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	// the messages that are not relayed are handled by the fixed number of
+	// workers, which blocks reading when all of them are busy.
+	pktCh := make(chan packet, workers*batchSize)
+	defer close(pktCh)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for pkt := range pktCh {
+				if u.handlePacket(pkt.raddr, pkt.raw) {
+					putBuffer(pkt.buf)
+				}
+			}
+		}()
+	}
+
+	ms := make([]ipv4.Message, batchSize)
+	for i := range ms {
+		ms[i].Buffers = [][]byte{make([]byte, 1500)}
+	}
+	pc := u.packetConn()
+	out := &relayBatch{}
+	in := &tpduCounter{}
 	for {
 		select {
 		case <-ctx.Done():
@@ -369,68 +506,194 @@ func (u *UPlaneConn) serve(ctx context.Context) error {
 			// do nothing and go forward.
 		}
 
-		n, raddr, err := u.ReadFrom(buf)
+		n, err := pc.ReadBatch(ms, 0)
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
-			// TODO: Use net.ErrClosed instead (available from Go 1.16).
-			// https://github.com/golang/go/commit/e9ad52e46dee4b4f9c73ff44f44e1e234815800f
-			if strings.Contains(err.Error(), "use of closed network connection") {
+			if errors.Is(err, net.ErrClosed) {
 				return nil
 			}
 			return fmt.Errorf("error reading from UPlaneConn %s: %w", u.LocalAddr(), err)
 		}
 
-		raw := make([]byte, n)
-		copy(raw, buf)
-		go func() {
-			// just forward T-PDU instead of passing it to reader if relayer is
-			// configured and the message type is T-PDU.
-			if len(u.relayMap) != 0 && raw[1] == message.MsgTypeTPDU {
-				// ignore if the packet size is smaller than minimum header size
-				if n < 11 {
-					return
-				}
-
-				u.mu.Lock()
-				peer, ok := u.relayMap[binary.BigEndian.Uint32(raw[4:8])]
-				u.mu.Unlock()
-				if !ok { // pass message to handler if TEID is unknown
-					msg, err := message.Parse(raw[:n])
-					if err != nil {
-						return
-					}
-
-					if err := u.handleMessage(raddr, msg); err != nil {
-						// should not stop serving with this error
-						logf("error handling message on UPlaneConn %s: %v", u.LocalAddr(), err)
-					}
-					return
-				}
+		r := u.recorder()
+		for _, m := range ms[:n] {
+			b := m.Buffers[0][:m.N]
+			if r != nil {
+				in.add(b)
+			}
 
-				// just use original packet not to get it slow.
-				binary.BigEndian.PutUint32(raw[4:8], peer.teid)
-				if _, err := peer.srcConn.WriteToWithDSCPECN(raw[:n], peer.addr, 0); err != nil {
-					// should not stop serving with this error
-					logf("error sending on UPlaneConn %s: %v", u.LocalAddr(), err)
-				}
-				return
+			// just forward T-PDU in the read buffer instead of passing it to
+			// workers if relayer is configured.
+			if peer, ok := u.relayFast(b); ok {
+				out.add(peer, b)
+				continue
 			}
 
-			msg, err := message.Parse(raw[:n])
+			bp := getBuffer(len(b))
+			raw := (*bp)[:len(b)]
+			copy(raw, b)
+			pktCh <- packet{raddr: m.Addr, raw: raw, buf: bp}
+		}
+
+		if r != nil {
+			in.record(r, metrics.Received)
+		}
+
+		// the read buffers are reused in the next read, so the relayed
+		// packets must be written before that.
+		out.flush()
+	}
+}
+
+// handlePacket handles a packet that is not relayed by relayFast.
+//
+// It reports whether raw can be reused after it returns, which is false if raw
+// is passed to the interceptors or the handlers that may retain it.
+func (u *UPlaneConn) handlePacket(raddr net.Addr, raw []byte) bool {
+	reusable := !u.hasInbound()
+	raw, err := u.interceptInbound(raddr, raw)
+	if err != nil {
+		u.Logger().Debug("message dropped", logkey.Peer, raddr.String(), logkey.Error, err)
+		return reusable
+	}
+
+	if len(raw) < 2 {
+		u.recordParseError()
+		u.Logger().Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, message.ErrTooShortToParse)
+		return reusable
+	}
+
+	// drop the packet if it has any Extension Header that is
+	// comprehension required but not supported by UPlaneConn.
+	if raw[0]&0x04 != 0 {
+		h, err := message.ParseHeader(raw)
+		if err == nil && len(u.unsupportedExtensionHeaders(h)) != 0 {
+			msg, err := message.Parse(raw)
 			if err != nil {
-				logf("error parsing message on UPlaneConn %s: %v", u.LocalAddr(), err)
-				return
+				u.recordParseError()
+				u.Logger().Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, err)
+				return reusable
 			}
+			u.recordReceived(msg)
+			if err := u.SupportedExtensionHeaderNotification(raddr, msg); err != nil {
+				u.Logger().Warn("failed to send Supported Extension Header Notification", append(msgAttrs(raddr, msg), logkey.Error, err)...)
+			}
+			return false
+		}
+	}
 
-			if err := u.handleMessage(raddr, msg); err != nil {
+	// End Marker is also forwarded to the peer so that the receiver of
+	// the relayed traffic can tell the end of the old path.
+	if u.relayMap.len() != 0 && len(raw) >= 8 && isRelayable(raw[1]) {
+		if peer, ok := u.relayMap.load(binary.BigEndian.Uint32(raw[4:8])); ok {
+			binary.BigEndian.PutUint32(raw[4:8], peer.teid)
+			reusable = reusable && !peer.srcConn.hasOutbound()
+			if _, err := peer.srcConn.WriteTo(raw, peer.addr); err != nil {
 				// should not stop serving with this error
-				logf("error handling message on UPlaneConn %s: %v", u.LocalAddr(), err)
-				return
+				u.Logger().Warn("error relaying the message", logkey.Peer, peer.addr.String(), logkey.TEID, connlog.TEID(peer.teid), logkey.Error, err)
 			}
-		}()
+			return reusable
+		}
+	}
+
+	if u.decapsulateToTUN(raw) {
+		return reusable
 	}
+
+	// pass message to handler if TEID is unknown
+	msg, err := message.Parse(raw)
+	if err != nil {
+		u.recordParseError()
+		u.Logger().Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, err)
+		return reusable
+	}
+	u.recordReceived(msg)
+
+	if err := u.handleMessage(raddr, msg); err != nil {
+		// should not stop serving with this error
+		u.recordHandlerError(msg)
+		u.Logger().Warn("error handling the message", append(msgAttrs(raddr, msg), logkey.Error, err)...)
+	}
+	return false
+}
+
+// relayFast rewrites the TEID in b and returns the peer to relay it to, if b is
+// a relayable packet without Extension Headers and the TEID is known.
+//
+// The packets with Extension Headers are left to handlePacket, as they need to
+// be checked if the Extension Headers are supported.
+func (u *UPlaneConn) relayFast(b []byte) (*peer, bool) {
+	if u.relayMap.len() == 0 || len(b) < 8 || b[0]&0x04 != 0 || !isRelayable(b[1]) {
+		return nil, false
+	}
+
+	// the packets to be intercepted are also left to handlePacket.
+	if u.hasInbound() {
+		return nil, false
+	}
+
+	peer, ok := u.relayMap.load(binary.BigEndian.Uint32(b[4:8]))
+	if !ok || peer.srcConn.hasOutbound() {
+		return nil, false
+	}
+
+	binary.BigEndian.PutUint32(b[4:8], peer.teid)
+	return peer, true
+}
+
+func isRelayable(msgType uint8) bool {
+	return msgType == message.MsgTypeTPDU || msgType == message.MsgTypeEndMarker
+}
+
+// SetWorkers sets the number of goroutines that handle the incoming messages
+// except the ones relayed by RelayTo. By default, it is the same as GOMAXPROCS.
+//
+// This should be called before ListenAndServe or DialUPlane, otherwise it has
+// no effect on the UPlaneConn that has already started serving.
+func (u *UPlaneConn) SetWorkers(n int) {
+	u.mu.Lock()
+	u.workers = n
+	u.mu.Unlock()
+}
+
+// SetBatchSize sets the maximum number of packets read from the socket at a
+// time. By default, it is 64.
+//
+// On Linux, the packets are read with recvmmsg(2) and relayed with sendmmsg(2)
+// in the batch. On the other platforms, a batch contains only one packet.
+//
+// This should be called before ListenAndServe or DialUPlane, otherwise it has
+// no effect on the UPlaneConn that has already started serving.
+func (u *UPlaneConn) SetBatchSize(n int) {
+	u.mu.Lock()
+	u.batchSize = n
+	u.mu.Unlock()
+}
+
+// packetConn returns the underlying connection, or nil if not created yet.
+func (u *UPlaneConn) packetConn() pktConn {
+	h := u.conn.Load()
+	if h == nil {
+		return nil
+	}
+	return h.pktConn
+}
+
+// listen creates the underlying connection if not created yet, and returns it.
+// The caller should hold u.mu not to create it twice.
+func (u *UPlaneConn) listen() (pktConn, error) {
+	if pc := u.packetConn(); pc != nil {
+		return pc, nil
+	}
+
+	pc, err := newPktConn(u.laddr)
+	if err != nil {
+		return nil, err
+	}
+	u.conn.Store(&pktConnHolder{pc})
+	return pc, nil
 }
 
 // ReadFrom reads a packet from the connection,
@@ -446,7 +709,7 @@ func (u *UPlaneConn) serve(ctx context.Context) error {
 //
 // Note that valid GTP-U packets handled by Kernel can NOT be retrieved by this.
 func (u *UPlaneConn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
-	return u.pktConn.ReadFrom(p)
+	return u.packetConn().ReadFrom(p)
 }
 
 // ReadFromGTP reads a packet from the connection, copying the payload without
@@ -454,6 +717,8 @@ func (u *UPlaneConn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
 // address that was on the packet, TEID in the GTP header.
 //
 // Note that valid GTP-U packets handled by Kernel can NOT be retrieved by this.
+// Up to 1024 T-PDUs are queued to be read, and the ones received after that are
+// dropped until this is called.
 func (u *UPlaneConn) ReadFromGTP(p []byte) (n int, addr net.Addr, teid uint32, err error) {
 	select {
 	case <-u.closed():
@@ -476,9 +741,22 @@ func (u *UPlaneConn) ReadFromGTP(p []byte) (n int, addr net.Addr, teid uint32, e
 // see SetDeadline and SetWriteDeadline.
 // On packet-oriented connections, write timeouts are rare.
 func (u *UPlaneConn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
-	u.mu.Lock()
-	defer u.mu.Unlock()
-	return u.pktConn.WriteToWithDSCPECN(p, addr, 0)
+	return u.writeTo(p, addr, nil)
+}
+
+// writeTo writes p to addr, where msg is the message p is made from, if any.
+func (u *UPlaneConn) writeTo(p []byte, addr net.Addr, msg message.Message) (n int, err error) {
+	p, err = u.interceptOutbound(addr, p, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err = u.packetConn().writeTo(p, addr)
+
+	if err == nil {
+		u.recordSent(p)
+	}
+	return n, err
 }
 
 // WriteToWithDSCPECN writes a packet with payload p to addr using the given DSCP/ECN value.
@@ -487,20 +765,29 @@ func (u *UPlaneConn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
 // see SetDeadline and SetWriteDeadline.
 // On packet-oriented connections, write timeouts are rare.
 func (u *UPlaneConn) WriteToWithDSCPECN(p []byte, addr net.Addr, dscpecn int) (n int, err error) {
-	return u.pktConn.WriteToWithDSCPECN(p, addr, dscpecn)
+	p, err = u.interceptOutbound(addr, p, nil)
+	if err != nil {
+		return 0, err
+	}
+	return u.packetConn().WriteToWithDSCPECN(p, addr, dscpecn)
 }
 
 // WriteToGTP writes a packet with TEID and payload to addr.
 func (u *UPlaneConn) WriteToGTP(teid uint32, p []byte, addr net.Addr) (n int, err error) {
-	b, err := Encapsulate(teid, p).Marshal()
-	if err != nil {
+	pdu := Encapsulate(teid, p)
+	l := pdu.MarshalLen()
+
+	bp := getBuffer(l)
+	defer putBuffer(bp)
+	b := (*bp)[:l]
+	if err = pdu.MarshalTo(b); err != nil {
 		return
 	}
 
-	if _, err = u.WriteTo(b, addr); err != nil {
+	if _, err = u.writeTo(b, addr, pdu); err != nil {
 		return
 	}
-	return len(b), nil
+	return l, nil
 }
 
 // closed would be used in multiple goroutines.
@@ -517,12 +804,19 @@ func (u *UPlaneConn) Close() error {
 
 	close(u.closeCh)
 
+	// let the UPlaneConns that u relays through forget u.
+	u.relayMap.rangeFunc(func(_ uint32, p *peer) bool {
+		p.srcConn.relayersMu.Lock()
+		p.srcConn.relayers.Delete(u)
+		p.srcConn.relayersMu.Unlock()
+		return true
+	})
 	return nil
 }
 
 // LocalAddr returns the local network address.
 func (u *UPlaneConn) LocalAddr() net.Addr {
-	return u.pktConn.LocalAddr()
+	return u.packetConn().LocalAddr()
 }
 
 // SetDeadline sets the read and write deadlines associated
@@ -541,14 +835,14 @@ func (u *UPlaneConn) LocalAddr() net.Addr {
 //
 // A zero value for t means I/O operations will not time out.
 func (u *UPlaneConn) SetDeadline(t time.Time) error {
-	return u.pktConn.SetDeadline(t)
+	return u.packetConn().SetDeadline(t)
 }
 
 // SetReadDeadline sets the deadline for future Read calls
 // and any currently-blocked Read call.
 // A zero value for t means Read will not time out.
 func (u *UPlaneConn) SetReadDeadline(t time.Time) error {
-	return u.pktConn.SetReadDeadline(t)
+	return u.packetConn().SetReadDeadline(t)
 }
 
 // SetWriteDeadline sets the deadline for future Write calls
@@ -557,7 +851,7 @@ func (u *UPlaneConn) SetReadDeadline(t time.Time) error {
 // some of the data was successfully written.
 // A zero value for t means Write will not time out.
 func (u *UPlaneConn) SetWriteDeadline(t time.Time) error {
-	return u.pktConn.SetWriteDeadline(t)
+	return u.packetConn().SetWriteDeadline(t)
 }
 
 // AddHandler adds a message handler to *UPlaneConn.
@@ -571,9 +865,10 @@ func (u *UPlaneConn) SetWriteDeadline(t time.Time) error {
 // any values, which is in most cases vital to continue working as a node, from the incoming
 // message.
 //
-// HandlerFuncs for EchoResponse and ErrorIndication are registered by default.
-// These HandlerFuncs can be overwritten by specifying message.MsgTypeEchoResponse and/or
-// message.MsgTypeErrorIndication as msgType parameter.
+// HandlerFuncs for EchoResponse, ErrorIndication and SupportedExtensionHeaderNotification
+// are registered by default. These HandlerFuncs can be overwritten by specifying
+// message.MsgTypeEchoResponse, message.MsgTypeErrorIndication and/or
+// message.MsgTypeSupportedExtensionHeaderNotification as msgType parameter.
 func (u *UPlaneConn) AddHandler(msgType uint8, fn HandlerFunc) {
 	u.msgHandlerMap.store(msgType, fn)
 }
@@ -648,6 +943,43 @@ func (u *UPlaneConn) ErrorIndication(raddr net.Addr, received message.Message) e
 	return nil
 }
 
+// EndMarker sends an End Marker message with teid to raddr.
+//
+// This is to indicate the end of the payload stream on the old path when the path
+// is switched. See also SwitchRelay and SwitchTunnel that do this automatically.
+func (u *UPlaneConn) EndMarker(teid uint32, raddr net.Addr) error {
+	em := message.NewEndMarker()
+	em.Header.TEID = teid
+	b, err := em.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.WriteTo(b, raddr); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SupportedExtensionHeaderNotification sends SupportedExtensionHeaderNotification
+// message with the list of Extension Header types supported by UPlaneConn, in
+// response to the received message(specified with "received" param).
+func (u *UPlaneConn) SupportedExtensionHeaderNotification(raddr net.Addr, received message.Message) error {
+	// TEID is always set to zero in Supported Extension Headers Notification.
+	b, err := message.NewSupportedExtensionHeaderNotification(
+		0, received.Sequence(),
+		ie.NewExtensionHeaderTypeList(u.SupportedExtensionHeaders()...),
+	).Marshal()
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.WriteTo(b, raddr); err != nil {
+		return err
+	}
+	return nil
+}
+
 // RespondTo sends a message(specified with "toBeSent" param) in response to
 // a message(specified with "received" param).
 //
@@ -670,68 +1002,184 @@ func (u *UPlaneConn) Restarts() uint8 {
 	return 0
 }
 
-// NewFTEID creates a new GTPv2 F-TEID with random TEID value that is unique within UPlaneConn.
+// NewFTEID creates a new GTPv2 F-TEID with TEID value that is unique within UPlaneConn.
 // To ensure the uniqueness, don't create in the other way if you once use this method.
 // This is meant to be used for creating F-TEID IE for non-local interface type, such as
 // the ones that are used in U-Plane. For local interface, use (*Conn).NewSenderFTEID instead.
 //
-// Note that in the case there's a lot of Session on the Conn, it may take a long
-// time to find a new unique value.
-//
-// TODO: optimize performance...
+// The TEID is issued by the teidalloc.Allocator of UPlaneConn, which is random one by
+// default and can be changed with SetTEIDAllocator. It returns nil if no TEID is
+// available. The TEID is released when the tunnel or relay with it is deleted, or
+// with ReleaseTEID.
 func (u *UPlaneConn) NewFTEID(ifType uint8, v4, v6 string) (fteidIE *v2ie.IE) {
-	var teid uint32
-	for try := uint32(0); try < 0xffff; try++ {
-		const logEvery = 0xff
-		if try&logEvery == logEvery {
-			logf("Generating NewSenderFTEID crossed tries:%d", try)
-		}
+	teid, err := u.teidAllocator().Allocate()
+	if err != nil {
+		u.Logger().Warn("failed to allocate TEID", logkey.Error, err)
+		return nil
+	}
+	return v2ie.NewFullyQualifiedTEID(ifType, teid, v4, v6)
+}
 
-		t := generateRandomUint32()
-		if t == 0 {
+// SetTEIDAllocator replaces the teidalloc.Allocator used by NewFTEID with the one
+// given. By default, TEIDs are allocated randomly from the whole range.
+//
+// For instance, teidalloc.NewPartitionedAllocator can be used to let multiple
+// instances sharing the same address issue TEIDs without collision. The same
+// allocator can be shared with gtpv2.Conn, when the TEIDs for C-Plane and
+// U-Plane should not overlap with each other.
+//
+// This should be called before NewFTEID is called for the first time, as the
+// TEIDs issued before are not known to the new allocator.
+func (u *UPlaneConn) SetTEIDAllocator(allocator teidalloc.Allocator) {
+	u.mu.Lock()
+	u.teids = allocator
+	u.mu.Unlock()
+}
+
+// ReleaseTEID releases the TEID issued by NewFTEID to make it available again.
+//
+// This is needed only when the TEID ends up with not being used for any tunnel
+// or relay, as the TEID is released automatically when they are deleted.
+func (u *UPlaneConn) ReleaseTEID(teid uint32) {
+	u.teidAllocator().Release(teid)
+}
+
+func (u *UPlaneConn) teidAllocator() teidalloc.Allocator {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.teids
+}
+
+// SetSupportedExtensionHeaders replaces the set of Extension Header types that
+// UPlaneConn can comprehend with the given types.
+//
+// UPlaneConn drops the incoming packets with any comprehension required Extension
+// Header that is not in the set, and responds to the sender with Supported Extension
+// Header Notification, as described in §5.2.1, TS 29.281. By default, all the types
+// defined in message package are supported.
+func (u *UPlaneConn) SetSupportedExtensionHeaders(types ...uint8) {
+	m := map[uint8]struct{}{}
+	for _, t := range types {
+		if t == message.ExtHeaderTypeNoMoreExtensionHeaders {
 			continue
 		}
+		m[t] = struct{}{}
+	}
 
-		// Try to mark TEID as taken. Fails if something exists
-		if ok := u.iteiMap.tryStore(t, time.Now()); !ok {
-			logf("TEID-U: %#08x has already been taken, trying to generate another one...", t)
+	u.mu.Lock()
+	u.supportedExtHdrs.Store(&m)
+	u.mu.Unlock()
+}
+
+// AddSupportedExtensionHeaders adds the given Extension Header types to the set of
+// types that UPlaneConn can comprehend.
+//
+// See also: SetSupportedExtensionHeaders.
+func (u *UPlaneConn) AddSupportedExtensionHeaders(types ...uint8) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	m := maps.Clone(*u.supportedExtHdrs.Load())
+	for _, t := range types {
+		if t == message.ExtHeaderTypeNoMoreExtensionHeaders {
 			continue
 		}
-
-		teid = t
-		break
+		m[t] = struct{}{}
 	}
+	u.supportedExtHdrs.Store(&m)
+}
 
-	if teid == 0 {
-		return nil
+// SupportedExtensionHeaders returns the Extension Header types that UPlaneConn
+// can comprehend in ascending order.
+func (u *UPlaneConn) SupportedExtensionHeaders() []uint8 {
+	supported := *u.supportedExtHdrs.Load()
+
+	var types []uint8
+	for t := 0; t <= 0xff; t++ {
+		if _, ok := supported[uint8(t)]; ok {
+			types = append(types, uint8(t))
+		}
 	}
-	return v2ie.NewFullyQualifiedTEID(ifType, teid, v4, v6)
+	return types
 }
 
-func generateRandomUint32() uint32 {
-	b := make([]byte, 4)
-	if _, err := rand.Read(b); err != nil {
-		return 0
+// unsupportedExtensionHeaders returns the comprehension required Extension Headers
+// in h that are not supported by UPlaneConn.
+func (u *UPlaneConn) unsupportedExtensionHeaders(h *message.Header) []*message.ExtensionHeader {
+	if !h.HasExtensionHeader() {
+		return nil
 	}
 
-	return binary.BigEndian.Uint32(b)
+	supported := *u.supportedExtHdrs.Load()
+
+	var unsupported []*message.ExtensionHeader
+	for _, eh := range h.ExtensionHeaders {
+		if !eh.IsComprehensionRequired() {
+			continue
+		}
+		if _, ok := supported[eh.Type]; !ok {
+			unsupported = append(unsupported, eh)
+		}
+	}
+	return unsupported
 }
 
-type iteiMap struct {
-	syncMap sync.Map
+func newDefaultSupportedExtHdrs() *map[uint8]struct{} {
+	m := map[uint8]struct{}{}
+	for _, t := range []uint8{
+		message.ExtHeaderTypeMBMSSupportIndication,
+		message.ExtHeaderTypeMSInfoChangeReportingSupportIndication,
+		message.ExtHeaderTypeLongPDCPPDUNumber,
+		message.ExtHeaderTypeServiceClassIndicator,
+		message.ExtHeaderTypeUDPPort,
+		message.ExtHeaderTypeRANContainer,
+		message.ExtHeaderTypeLongPDCPPDUNumberRequired,
+		message.ExtHeaderTypeXwRANContainer,
+		message.ExtHeaderTypeNRRANContainer,
+		message.ExtHeaderTypePDUSessionContainer,
+		message.ExtHeaderTypePDCPPDUNumber,
+		message.ExtHeaderTypeSuspendRequest,
+		message.ExtHeaderTypeSuspendResponse,
+	} {
+		m[t] = struct{}{}
+	}
+	return &m
 }
 
-func newiteiMap() *iteiMap {
-	return &iteiMap{}
+// SetErrorIndicationHandler sets the func to be called when UPlaneConn receives
+// Error Indication. The TEID Data I and GTP-U Peer Address in the message are
+// given to fn with the address of the sender.
+//
+// This is useful to release the bearer that the peer does not know anymore.
+// To remove the tunnels on UPlaneConn automatically, use
+// EnableTunnelTeardownOnErrorIndication as well. Passing nil lets the Error
+// Indication just logged, which is the default behavior.
+func (u *UPlaneConn) SetErrorIndicationHandler(fn ErrorIndicationHandlerFunc) {
+	u.mu.Lock()
+	u.errIndHandler = fn
+	u.mu.Unlock()
 }
 
-func (t *iteiMap) tryStore(itei uint32, ts time.Time) bool {
-	_, loaded := t.syncMap.LoadOrStore(itei, ts)
-	return !loaded
+// EnableTunnelTeardownOnErrorIndication makes UPlaneConn remove the relays
+// (created by RelayTo) and Kernel GTP-U tunnels (created by AddTunnel) whose
+// outgoing TEID and peer match the Error Indication received, which is disabled
+// by default.
+//
+// See also: DisableTunnelTeardownOnErrorIndication.
+func (u *UPlaneConn) EnableTunnelTeardownOnErrorIndication() {
+	u.mu.Lock()
+	u.errIndTeardownEnabled = true
+	u.mu.Unlock()
 }
 
-func (t *iteiMap) delete(itei uint32) {
-	t.syncMap.Delete(itei)
+// DisableTunnelTeardownOnErrorIndication stops UPlaneConn from removing the
+// tunnels automatically on Error Indication.
+//
+// See also: EnableTunnelTeardownOnErrorIndication.
+func (u *UPlaneConn) DisableTunnelTeardownOnErrorIndication() {
+	u.mu.Lock()
+	u.errIndTeardownEnabled = false
+	u.mu.Unlock()
 }
 
 // EnableErrorIndication re-enables automatic sending of
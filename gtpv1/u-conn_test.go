@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv1/message"
 )
 
 type testVal struct {
@@ -103,3 +104,86 @@ func TestClientWrite(t *testing.T) {
 		t.Fatal("timed out while waiting for response to come")
 	}
 }
+
+func TestUnsupportedExtensionHeader(t *testing.T) {
+	srvAddr, err := net.ResolveUDPAddr("udp", "127.0.0.21:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvConn := gtpv1.NewUPlaneConn(srvAddr)
+	srvConn.SetSupportedExtensionHeaders(message.ExtHeaderTypePDUSessionContainer)
+	go func() {
+		if err := srvConn.ListenAndServe(ctx); err != nil {
+			return
+		}
+	}()
+
+	// XXX - waiting for server to be well-prepared, should consider better way.
+	time.Sleep(1 * time.Second)
+
+	cliConn, err := net.ListenPacket("udp", "127.0.0.22:2152")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+
+	b, err := gtpv1.EncapsulateWithExtensionHeader(
+		0x11111111, []byte{0xde, 0xad, 0xbe, 0xef},
+		message.NewExtensionHeader(
+			message.ExtHeaderTypeNRRANContainer, []byte{0x00, 0x00},
+			message.ExtHeaderTypeNoMoreExtensionHeaders,
+		),
+	).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cliConn.WriteTo(b, srvAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cliConn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1500)
+	n, _, err := cliConn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := message.Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	notif, ok := msg.(*message.SupportedExtensionHeaderNotification)
+	if !ok {
+		t.Fatalf("got unexpected type of message: %s", msg.MessageTypeName())
+	}
+	if diff := cmp.Diff(notif.TEID(), uint32(0)); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(
+		notif.ExtensionHeaderTypeList.MustExtensionHeaderTypeList(),
+		[]uint8{message.ExtHeaderTypePDUSessionContainer},
+	); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSupportedExtensionHeaders(t *testing.T) {
+	u := gtpv1.NewUPlaneConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2152})
+
+	u.SetSupportedExtensionHeaders(message.ExtHeaderTypePDUSessionContainer)
+	before := u.SupportedExtensionHeaders()
+	u.AddSupportedExtensionHeaders(message.ExtHeaderTypeUDPPort, message.ExtHeaderTypeNoMoreExtensionHeaders)
+
+	if diff := cmp.Diff(before, []uint8{message.ExtHeaderTypePDUSessionContainer}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(u.SupportedExtensionHeaders(), []uint8{message.ExtHeaderTypeUDPPort, message.ExtHeaderTypePDUSessionContainer}); diff != "" {
+		t.Error(diff)
+	}
+}
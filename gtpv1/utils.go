@@ -4,7 +4,36 @@
 
 package gtpv1
 
-import "github.com/wmnsk/go-gtp/gtpv1/message"
+import (
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpv1/message"
+)
+
+// bufferPool is the pool of buffers used to serialize the packets to be sent
+// and to keep the packets received, which are large enough for the most of the
+// packets.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 1600)
+		return &b
+	},
+}
+
+// getBuffer returns a buffer from bufferPool that has at least l bytes of capacity.
+func getBuffer(l int) *[]byte {
+	bp := bufferPool.Get().(*[]byte)
+	if cap(*bp) < l {
+		b := make([]byte, l)
+		return &b
+	}
+	return bp
+}
+
+// putBuffer puts the buffer back to bufferPool.
+func putBuffer(bp *[]byte) {
+	bufferPool.Put(bp)
+}
 
 // Encapsulate encapsulates given payload with GTPv1-U Header and returns message.TPDU.
 func Encapsulate(teid uint32, payload []byte) *message.TPDU {
@@ -5,7 +5,11 @@
 package gtpv2
 
 import (
+	"fmt"
 	"net"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
 )
 
 // QoSProfile represents a QoS-related information that belongs to a Bearer.
@@ -27,6 +31,10 @@ type Bearer struct {
 	SubscriberIP, APN string
 	ChargingID        uint32
 	*QoSProfile
+
+	// mu protects filters, the packet filters in TFT compiled by SetTFT.
+	mu      sync.RWMutex
+	filters []*PacketFilter
 }
 
 // NewBearer creates a new Bearer.
@@ -65,3 +73,62 @@ func (b *Bearer) OutgoingTEID() uint32 {
 func (b *Bearer) SetOutgoingTEID(teid uint32) {
 	b.teidOut = teid
 }
+
+// SetTFT applies the TFT operation given to the packet filters of Bearer.
+//
+// The packet filters are compiled on the way, and they can be used to classify the
+// packets into Bearers with Classifier. If any of the filters cannot be compiled,
+// the packet filters of Bearer are not changed.
+func (b *Bearer) SetTFT(tft *ie.TrafficFlowTemplate) error {
+	var filters []*PacketFilter
+	for _, f := range tft.PacketFilters {
+		pf, err := CompilePacketFilter(f)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, pf)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch tft.OperationCode {
+	case ie.TFTOpCreateNewTFT:
+		b.filters = filters
+	case ie.TFTOpDeleteExistingTFT:
+		b.filters = nil
+	case ie.TFTOpAddPacketFiltersToExistingTFT, ie.TFTOpReplacePacketFiltersInExistingTFT:
+		// the filter with the same identifier as the existing one replaces it.
+		for _, f := range filters {
+			b.filters = deletePacketFilter(b.filters, f.Identifier)
+			b.filters = append(b.filters, f)
+		}
+	case ie.TFTOpDeletePacketFiltersFromExistingTFT:
+		for _, id := range tft.PacketFilterIdentifiers {
+			b.filters = deletePacketFilter(b.filters, id&0x0f)
+		}
+	case ie.TFTOpIgnoreThisIE, ie.TFTOpNoTFTOperation:
+	default:
+		return fmt.Errorf("unknown TFT operation code: %d", tft.OperationCode)
+	}
+
+	return nil
+}
+
+// PacketFilters returns the packet filters of Bearer set by SetTFT.
+func (b *Bearer) PacketFilters() []*PacketFilter {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]*PacketFilter(nil), b.filters...)
+}
+
+func deletePacketFilter(filters []*PacketFilter, id uint8) []*PacketFilter {
+	var fs []*PacketFilter
+	for _, f := range filters {
+		if f.Identifier != id {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
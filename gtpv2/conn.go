@@ -6,17 +6,21 @@ package gtpv2
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"sync"
 	"time"
 
 	"github.com/wmnsk/go-gtp/gtpv2/ie"
 	"github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/internal/connlog"
+	"github.com/wmnsk/go-gtp/logkey"
+	"github.com/wmnsk/go-gtp/metrics"
+	"github.com/wmnsk/go-gtp/teidalloc"
 )
 
 // Conn represents a GTPv2-C connection.
@@ -28,14 +32,22 @@ import (
 // connection(=between a node to another).
 // See the docs of CreateSession, AddSession, DeleteSession methods for details.
 type Conn struct {
-	mu      sync.Mutex
-	laddr   net.Addr
-	pktConn net.PacketConn
-	*imsiSessionMap
-	*iteiSessionMap
+	mu          sync.Mutex
+	laddr       net.Addr
+	pktConn     net.PacketConn
+	store       SessionStore
+	teids       teidalloc.Allocator
+	logger      *slog.Logger
 	localIfType uint8
 
+	metrics      metrics.Recorder
+	transactions *transactions
+
+	inbound, outbound []Interceptor
+
 	validationEnabled bool
+	ieRules           IERules
+	errorResponses    bool
 
 	closeCh chan struct{}
 	*msgHandlerMap
@@ -52,13 +64,51 @@ type Conn struct {
 	RestartCounter uint8
 }
 
+// ConnOption is an option to configure Conn at NewConn or Dial.
+type ConnOption func(c *Conn)
+
+// WithSessionStore lets Conn keep the Sessions in the SessionStore given instead
+// of the default in-memory one.
+func WithSessionStore(store SessionStore) ConnOption {
+	return func(c *Conn) {
+		c.store = store
+	}
+}
+
+// WithTEIDAllocator lets Conn allocate the TEIDs for NewSenderFTEID with the
+// teidalloc.Allocator given instead of the default random one.
+//
+// For instance, teidalloc.NewPartitionedAllocator can be used to let multiple
+// instances sharing the same address issue TEIDs without collision.
+func WithTEIDAllocator(allocator teidalloc.Allocator) ConnOption {
+	return func(c *Conn) {
+		c.teids = allocator
+	}
+}
+
+// WithErrorResponses lets Conn respond to the Initial messages that cannot be
+// processed, instead of just discarding them and letting the peer time out.
+//
+// The response is the Triggered message of the corresponding type with the Cause;
+// "Invalid message format" for the ones that cannot be parsed, with the Offending
+// IE if it is the IE that is malformed, "Context not found" for the ones with the
+// unknown TEID, "Service not supported" for the ones without the handler, and the
+// one given by the error for the ones that fail the IE validation (see
+// WithIEValidation).
+// See RejectRequest for how the response is made.
+func WithErrorResponses() ConnOption {
+	return func(c *Conn) {
+		c.errorResponses = true
+	}
+}
+
 // NewConn creates a new Conn used for server. On client side, use Dial instead.
-func NewConn(laddr net.Addr, localIfType, counter uint8) *Conn {
-	return &Conn{
+func NewConn(laddr net.Addr, localIfType, counter uint8, opts ...ConnOption) *Conn {
+	c := &Conn{
 		mu:                sync.Mutex{},
 		laddr:             laddr,
-		imsiSessionMap:    newimsiSessionMap(),
-		iteiSessionMap:    newiteiSessionMap(),
+		store:             NewMemorySessionStore(),
+		teids:             teidalloc.NewDefaultAllocator(),
 		localIfType:       localIfType,
 		validationEnabled: true,
 		closeCh:           make(chan struct{}),
@@ -66,6 +116,15 @@ func NewConn(laddr net.Addr, localIfType, counter uint8) *Conn {
 		sequence:          0,
 		RestartCounter:    counter,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.logger == nil {
+		c.logger = stdLogger.Slogger()
+	}
+	c.logger = connlog.New(c.logger, logProto, laddr)
+	return c
 }
 
 // Dial sends Echo Request to raddr to check if the endpoint is alive and returns Conn.
@@ -74,19 +133,8 @@ func NewConn(laddr net.Addr, localIfType, counter uint8) *Conn {
 // send to/receive from multiple peers with single laddr.
 //
 // If Echo exchange is unnecessary, use NewConn and ListenAndServe instead.
-func Dial(ctx context.Context, laddr, raddr net.Addr, localIfType, counter uint8) (*Conn, error) {
-	c := &Conn{
-		mu:                sync.Mutex{},
-		laddr:             laddr,
-		imsiSessionMap:    newimsiSessionMap(),
-		iteiSessionMap:    newiteiSessionMap(),
-		localIfType:       localIfType,
-		validationEnabled: true,
-		closeCh:           make(chan struct{}),
-		msgHandlerMap:     newDefaultMsgHandlerMap(),
-		sequence:          0,
-		RestartCounter:    counter,
-	}
+func Dial(ctx context.Context, laddr, raddr net.Addr, localIfType, counter uint8, opts ...ConnOption) (*Conn, error) {
+	c := NewConn(laddr, localIfType, counter, opts...)
 
 	// setup underlying connection first.
 	// not using net.Dial, as it binds src/dst IP:Port, which makes it harder to
@@ -119,15 +167,21 @@ func Dial(ctx context.Context, laddr, raddr net.Addr, localIfType, counter uint8
 	// decode incoming message and let it be handled by default handler funcs.
 	msg, err := message.Parse(buf[:n])
 	if err != nil {
+		c.recordParseError()
+		return nil, err
+	}
+	c.recordReceived(buf[:n], raddr, msg)
+	if msg, err = c.interceptInbound(raddr, buf[:n], msg); err != nil {
 		return nil, err
 	}
 	if err := c.handleMessage(raddr, msg); err != nil {
+		c.recordHandlerError(msg)
 		return nil, err
 	}
 
 	go func() {
 		if err := c.Serve(ctx); err != nil {
-			logf("fatal error on Conn %s: %s", c.LocalAddr(), err)
+			c.logger.Error("fatal error on Conn", logkey.Error, err)
 		}
 	}()
 	return c, nil
@@ -172,7 +226,7 @@ func (c *Conn) Serve(ctx context.Context) error {
 		}
 
 		if err := c.pktConn.Close(); err != nil {
-			logf("error closing the underlying conn: %s", err)
+			c.logger.Warn("error closing the underlying conn", logkey.Error, err)
 		}
 	}()
 
@@ -194,12 +248,27 @@ func (c *Conn) Serve(ctx context.Context) error {
 		go func() {
 			msg, err := message.Parse(raw)
 			if err != nil {
-				logf("error parsing the message: %v, %x", err, raw)
+				c.recordParseError()
+				c.logger.Warn("error parsing the message", logkey.Peer, raddr.String(), logkey.Error, err, "raw", fmt.Sprintf("%x", raw))
+				// let the Interceptors see the message that is discarded anyway.
+				_, _ = c.interceptInbound(raddr, raw, nil)
+				if c.errorResponses {
+					if err := c.rejectMalformed(raddr, raw); err != nil {
+						c.logger.Debug("failed to respond to the malformed message", logkey.Peer, raddr.String(), logkey.Error, err)
+					}
+				}
+				return
+			}
+			c.recordReceived(raw, raddr, msg)
+
+			if msg, err = c.interceptInbound(raddr, raw, msg); err != nil {
+				c.logger.Debug("message dropped", logkey.Peer, raddr.String(), logkey.Error, err)
 				return
 			}
 
 			if err := c.handleMessage(raddr, msg); err != nil {
-				logf("error handling message on Conn %s: %v", c.LocalAddr(), err)
+				c.recordHandlerError(msg)
+				c.logger.Warn("error handling the message", append(msgAttrs(raddr, msg), logkey.Error, err)...)
 			}
 		}()
 	}
@@ -225,7 +294,21 @@ func (c *Conn) ReadFrom(p []byte) (n int, addr net.Addr, err error) {
 // see SetDeadline and SetWriteDeadline.
 // On packet-oriented connections, write timeouts are rare.
 func (c *Conn) WriteTo(p []byte, addr net.Addr) (n int, err error) {
-	return c.pktConn.WriteTo(p, addr)
+	return c.writeTo(p, addr, nil)
+}
+
+// writeTo writes p to addr, where msg is the message p is made from, if any.
+func (c *Conn) writeTo(p []byte, addr net.Addr, msg message.Message) (n int, err error) {
+	p, err = c.interceptOutbound(addr, p, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err = c.pktConn.WriteTo(p, addr)
+	if err == nil {
+		c.recordSent(p, addr)
+	}
+	return n, err
 }
 
 // Close closes the connection.
@@ -283,7 +366,8 @@ func (c *Conn) SetWriteDeadline(t time.Time) error {
 //
 // By adding HandlerFunc, Conn(and Session, Bearer created over the Conn) will handle
 // the specified type of message with it's paired HandlerFunc when receiving.
-// Messages without registered handlers are just ignored and logged.
+// Messages without registered handlers are just ignored and logged, or rejected
+// with the Cause "Service not supported" if WithErrorResponses is given.
 //
 // This should be performed just after creating Conn, otherwise the user cannot retrieve
 // any values, which is in most cases vital to continue working as a node, from the incoming
@@ -319,7 +403,13 @@ func (c *Conn) handleMessage(senderAddr net.Addr, msg message.Message) error {
 
 	handle, ok := c.msgHandlerMap.load(msg.MessageType())
 	if !ok {
-		return &HandlerNotFoundError{MsgType: msg.MessageTypeName()}
+		err := &HandlerNotFoundError{MsgType: msg.MessageTypeName()}
+		if c.errorResponses && message.IsInitial(msg.MessageType()) {
+			if rerr := c.RejectRequest(senderAddr, msg, CauseServiceNotSupported, nil); rerr != nil {
+				return fmt.Errorf("failed to reject the message (%w): %w", err, rerr)
+			}
+		}
+		return err
 	}
 
 	if err := handle(c, senderAddr, msg); err != nil {
@@ -337,6 +427,7 @@ func (c *Conn) handleMessage(senderAddr net.Addr, msg message.Message) error {
 //
 // GTP Version is 2
 // TEID is known to Conn
+// IEs fulfill the requirements, if WithIEValidation is given
 //
 // Even the validation is failed, it does not return error to user. Instead, it just logs
 // and discards the packets so that the HandlerFunc won't get the invalid message.
@@ -369,9 +460,31 @@ func (c *Conn) validate(senderAddr net.Addr, msg message.Message) error {
 	// check if TEID is known or not
 	if teid := msg.TEID(); teid != 0 {
 		if _, err := c.GetSessionByTEID(teid, senderAddr); err != nil {
-			return &InvalidTEIDError{TEID: teid}
+			err := &InvalidTEIDError{TEID: teid}
+			if c.errorResponses && message.IsInitial(msg.MessageType()) {
+				if rerr := c.RejectRequest(senderAddr, msg, CauseContextNotFound, nil); rerr != nil {
+					return fmt.Errorf("failed to reject the message (%w): %w", err, rerr)
+				}
+			}
+			return err
 		}
 	}
+
+	if c.ieRules == nil {
+		return nil
+	}
+	if err := c.ieRules.Validate(msg, InterfaceOf(c.localIfType)); err != nil {
+		var ce interface {
+			Cause() uint8
+			OffendingIE() *ie.IE
+		}
+		if c.errorResponses && errors.As(err, &ce) && message.IsInitial(msg.MessageType()) && msg.MessageType() != message.MsgTypeEchoRequest {
+			if rerr := c.RejectRequest(senderAddr, msg, ce.Cause(), ce.OffendingIE()); rerr != nil {
+				return fmt.Errorf("failed to reject the message (%w): %w", err, rerr)
+			}
+		}
+		return err
+	}
 	return nil
 }
 
@@ -387,7 +500,7 @@ func (c *Conn) SendMessageTo(msg message.Message, addr net.Addr) (uint32, error)
 		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
 	}
 
-	if _, err := c.WriteTo(payload, addr); err != nil {
+	if _, err := c.writeTo(payload, addr, msg); err != nil {
 		seq = c.DecSequence()
 		return seq, fmt.Errorf("failed to send %T: %w", msg, err)
 	}
@@ -457,11 +570,108 @@ func (c *Conn) VersionNotSupportedIndication(raddr net.Addr, req message.Message
 	return nil
 }
 
+// RejectRequest sends the Triggered message corresponding to the Initial message
+// req, e.g., Create Session Response to Create Session Request, with only the
+// Cause IE that has cause and offendingIE given (offendingIE can be nil).
+//
+// The TEID in the header is the peer's one of the Session that the TEID in req
+// belongs to if any, the one in the Sender F-TEID for Control Plane in req if
+// available, or 0 otherwise.
+func (c *Conn) RejectRequest(raddr net.Addr, req message.Message, cause uint8, offendingIE *ie.IE) error {
+	resType, ok := message.TriggeredTypeOf(req.MessageType())
+	if !ok || resType == message.MsgTypeEchoResponse {
+		return &UnexpectedTypeError{Msg: req}
+	}
+
+	var teid uint32
+	var found bool
+	if session, err := c.GetSessionByTEID(req.TEID(), raddr); err == nil {
+		teid, found = c.peerTEIDOf(session)
+	}
+	if !found {
+		if ies, err := messageIEs(req); err == nil {
+			for _, i := range ies {
+				if i.Type == ie.FullyQualifiedTEID && i.Instance() == 0 {
+					teid, _ = i.TEID()
+					break
+				}
+			}
+		}
+	}
+
+	b, err := message.NewGeneric(resType, teid, req.Sequence(), ie.NewCause(cause, 0, 0, 0, offendingIE)).Marshal()
+	if err != nil {
+		return err
+	}
+	res, err := message.Parse(b)
+	if err != nil {
+		return err
+	}
+	return c.RespondTo(raddr, req, res)
+}
+
+// peerTEIDOf returns the TEID of the peer in session, which is the one of the
+// interface type on the same interface as the local one of Conn.
+func (c *Conn) peerTEIDOf(session *Session) (uint32, bool) {
+	local := InterfaceOf(c.localIfType)
+
+	var teid uint32
+	var found bool
+	session.teidMap.rangeWithFunc(func(ifType uint8, t uint32) bool {
+		if ifType != c.localIfType && InterfaceOf(ifType)&local != 0 {
+			teid, found = t, true
+			return false
+		}
+		return true
+	})
+	return teid, found
+}
+
+// rejectMalformed responds to the Initial message in raw that cannot be parsed,
+// with the Cause "Invalid message format" and the first IE that is malformed as
+// Offending IE if any.
+//
+// An IE is considered malformed if it is truncated, cannot be parsed, or fails
+// the same checks of the values as the IE validation (see WithIEValidation).
+func (c *Conn) rejectMalformed(raddr net.Addr, raw []byte) error {
+	h, err := message.ParseHeader(raw)
+	if err != nil {
+		return err
+	}
+	req := message.NewGeneric(h.MessageType(), h.TEID, h.Sequence())
+	if h.Version() != 2 || !message.IsInitial(h.MessageType()) {
+		return &UnexpectedTypeError{Msg: req}
+	}
+
+	var offendingIE *ie.IE
+	for b := h.Payload; len(b) >= 4; {
+		l := 4 + int(binary.BigEndian.Uint16(b[1:3]))
+		if l > len(b) {
+			offendingIE = ie.New(b[0], b[3]&0x0f, nil)
+			break
+		}
+		i, err := ie.Parse(b[:l])
+		if err != nil {
+			offendingIE = ie.New(b[0], b[3]&0x0f, nil)
+			break
+		}
+		if check, ok := ieChecks[i.Type]; ok && check(i) != nil {
+			offendingIE = ie.New(i.Type, i.Instance(), nil)
+			break
+		}
+		b = b[l:]
+	}
+
+	return c.RejectRequest(raddr, req, CauseInvalidMessageFormat, offendingIE)
+}
+
 // ParseCreateSession iterates through the ie and returns a session
 func (c *Conn) ParseCreateSession(raddr net.Addr, ies ...*ie.IE) (*Session, error) {
 	// retrieve values from IEs given.
 	sess := NewSession(raddr, &Subscriber{Location: &Location{}})
 	br := sess.GetDefaultBearer()
+	var itei uint32
+	var hasITEI bool
 	var err error
 	for _, i := range ies {
 		if i == nil {
@@ -513,7 +723,7 @@ func (c *Conn) ParseCreateSession(raddr net.Addr, ies ...*ie.IE) (*Session, erro
 			}
 			sess.AddTEID(it, teid)
 			if it == c.localIfType {
-				c.RegisterSession(teid, sess)
+				itei, hasITEI = teid, true
 			}
 		case ie.BearerContext:
 			switch i.Instance() {
@@ -572,6 +782,12 @@ func (c *Conn) ParseCreateSession(raddr net.Addr, ies ...*ie.IE) (*Session, erro
 			}
 		}
 	}
+
+	// register after all the IEs are parsed, as Session is indexed by IMSI
+	// and the IEs can come in any order.
+	if hasITEI {
+		c.RegisterSession(itei, sess)
+	}
 	return sess, nil
 }
 
@@ -654,7 +870,7 @@ func (c *Conn) RespondTo(raddr net.Addr, received, toBeSent message.Message) err
 		return err
 	}
 
-	if _, err := c.WriteTo(b, raddr); err != nil {
+	if _, err := c.writeTo(b, raddr, toBeSent); err != nil {
 		return err
 	}
 	return nil
@@ -662,7 +878,7 @@ func (c *Conn) RespondTo(raddr net.Addr, received, toBeSent message.Message) err
 
 // GetSessionByTEID returns Session looked up by TEID and sender of the message.
 func (c *Conn) GetSessionByTEID(teid uint32, peer net.Addr) (*Session, error) {
-	session, ok := c.iteiSessionMap.load(teid)
+	session, ok := c.store.LoadByTEID(teid)
 	if !ok {
 		return nil, &InvalidTEIDError{TEID: teid}
 	}
@@ -673,11 +889,67 @@ func (c *Conn) GetSessionByTEID(teid uint32, peer net.Addr) (*Session, error) {
 }
 
 // GetSessionByIMSI returns Session looked up by IMSI.
+//
+// If the subscriber has multiple Sessions (= PDN connections), the one registered
+// last is returned. Use GetSessionsByIMSI, GetSessionByIMSIAndAPN or
+// GetSessionByIMSIAndEBI to retrieve the others.
 func (c *Conn) GetSessionByIMSI(imsi string) (*Session, error) {
-	if session, ok := c.imsiSessionMap.load(imsi); ok {
-		return session, nil
+	sessions := c.store.LoadByIMSI(imsi)
+	if len(sessions) == 0 {
+		return nil, &UnknownIMSIError{IMSI: imsi}
+	}
+	return sessions[len(sessions)-1], nil
+}
+
+// GetSessionsByIMSI returns all the Sessions of the subscriber looked up by IMSI,
+// in the order of registration.
+func (c *Conn) GetSessionsByIMSI(imsi string) ([]*Session, error) {
+	sessions := c.store.LoadByIMSI(imsi)
+	if len(sessions) == 0 {
+		return nil, &UnknownIMSIError{IMSI: imsi}
 	}
-	return nil, &UnknownIMSIError{IMSI: imsi}
+	return sessions, nil
+}
+
+// GetSessionsByPeer returns all the Sessions with the peer node given.
+func (c *Conn) GetSessionsByPeer(peer net.Addr) []*Session {
+	return c.store.LoadByPeer(peer)
+}
+
+// GetSessionByIMSIAndAPN returns Session looked up by IMSI and the APN of its
+// default bearer.
+func (c *Conn) GetSessionByIMSIAndAPN(imsi, apn string) (*Session, error) {
+	sessions, err := c.GetSessionsByIMSI(imsi)
+	if err != nil {
+		return nil, err
+	}
+
+	// the last one is returned as GetSessionByIMSI does, in case the APN is
+	// changed after registered.
+	for i := len(sessions) - 1; i >= 0; i-- {
+		if br := sessions[i].GetDefaultBearer(); br != nil && br.APN == apn {
+			return sessions[i], nil
+		}
+	}
+	return nil, &UnknownAPNError{APN: apn}
+}
+
+// GetSessionByIMSIAndEBI returns Session looked up by IMSI and EBI.
+//
+// As the EBI is unique within a subscriber, ebi can be the one of any bearers in
+// Session, not only the default bearer's.
+func (c *Conn) GetSessionByIMSIAndEBI(imsi string, ebi uint8) (*Session, error) {
+	sessions, err := c.GetSessionsByIMSI(imsi)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		if _, err := session.LookupBearerByEBI(ebi); err == nil {
+			return session, nil
+		}
+	}
+	return nil, &BearerNotFoundError{IMSI: imsi}
 }
 
 // GetIMSIByTEID returns IMSI associated with TEID and the peer node.
@@ -696,97 +968,139 @@ func (c *Conn) GetIMSIByTEID(teid uint32, peer net.Addr) (string, error) {
 // Incoming TEID(itei) should be the one with it's local interface type.
 // e.g., if the Conn is used for S-GW on S11 I/F, itei should be the one
 // with interface type=IFTypeS11S4SGWGTPC.
+//
+// A subscriber can have multiple Sessions (= PDN connections) registered with
+// the different incoming TEIDs, one for each APN of the default bearer. If
+// the subscriber already has another Session with the same APN, it is replaced,
+// i.e., removed as with RemoveSession, as the PDN connection is established
+// again. The replacement is done only when both the IMSI and the APN are known;
+// the Sessions without them are just added. Calling this again with the same
+// session only updates the IMSI and the incoming TEID associated with it.
+//
+// If itei is not the one issued by NewSenderFTEID, it is marked as used in the
+// TEID allocator of Conn so that NewSenderFTEID never issues it while session is
+// registered.
 func (c *Conn) RegisterSession(itei uint32, session *Session) {
-	c.iteiSessionMap.store(itei, session)
-	c.imsiSessionMap.store(session.IMSI, session)
+	if br := session.GetDefaultBearer(); br != nil && session.IMSI != "" && br.APN != "" {
+		if old, err := c.GetSessionByIMSIAndAPN(session.IMSI, br.APN); err == nil && old != session {
+			c.logger.Debug("replacing the session of the same APN", logkey.IMSI, session.IMSI, logkey.APN, br.APN)
+			c.RemoveSession(old)
+		}
+	}
+
+	// the same session registered again is not reported twice.
+	var registered bool
+	if old, err := session.GetTEID(c.localIfType); err == nil {
+		s, ok := c.store.LoadByTEID(old)
+		registered = ok && s == session
+	}
+
+	_ = c.teids.Reserve(itei)
+	c.store.Store(itei, session)
 
 	session.AddTEID(c.localIfType, itei)
+
+	if !registered {
+		n := session.BearerCount()
+		session.mu.Lock()
+		session.registeredBearers = n
+		session.mu.Unlock()
+		c.recordSessions(1, n)
+	}
 }
 
 // RemoveSession removes a session registered in a Conn.
+//
+// The incoming TEID of the session is released to be issued again by
+// NewSenderFTEID. The other Sessions of the same subscriber are kept as they are.
 func (c *Conn) RemoveSession(session *Session) {
-	c.imsiSessionMap.delete(session.IMSI)
-
+	// not to release the TEID that is already reused by another Session.
+	var release bool
 	itei, err := session.GetTEID(c.localIfType)
-	if err != nil { // if incoming TEID could not be found for some reason
-		logf("failed to find incoming TEID in session: %+v", err)
+	if err == nil {
+		s, ok := c.store.LoadByTEID(itei)
+		release = ok && s == session
+	}
 
-		c.iteiSessionMap.rangeWithFunc(func(k, v interface{}) bool {
-			s := v.(*Session)
-			if s.IMSI == session.IMSI {
-				c.iteiSessionMap.delete(k.(uint32))
-			}
-			return true
-		})
+	c.store.Delete(session)
+	if release {
+		c.teids.Release(itei)
 
-		return
+		session.mu.Lock()
+		n := session.registeredBearers
+		session.mu.Unlock()
+		c.recordSessions(-1, -n)
 	}
-	c.iteiSessionMap.delete(itei)
 }
 
-// RemoveSessionByIMSI removes a session looked up by IMSI.
+// RemoveSessionByIMSI removes all the sessions of the subscriber looked up by IMSI.
 //
-// Use RemoveSession instead if you already have the Session in your hand.
+// Use RemoveSession instead if you already have the Session in your hand, or if
+// only one of the Sessions of the subscriber should be removed.
 func (c *Conn) RemoveSessionByIMSI(imsi string) {
-	sess, ok := c.imsiSessionMap.load(imsi)
-	if !ok {
-		logf("Session not found by IMSI: %s", imsi)
+	sessions := c.store.LoadByIMSI(imsi)
+	if len(sessions) == 0 {
+		c.logger.Info("Session not found", logkey.IMSI, imsi)
 		return
 	}
-	c.RemoveSession(sess)
+	for _, sess := range sessions {
+		c.RemoveSession(sess)
+	}
 }
 
-// NewSenderFTEID creates a new F-TEID with random TEID value that is unique within Conn.
+// NewSenderFTEID creates a new F-TEID with TEID value that is unique within Conn.
 // To ensure the uniqueness, don't create in the other way if you once use this method.
 // This is meant to be used for creating F-TEID IE only for local interface type that is
 // specified at the creation of Conn.
 //
-// Note that in the case there's a lot of Session on the Conn, it may take a long
-// time to find a new unique value.
+// The TEID is issued by the teidalloc.Allocator of Conn, which is random one by
+// default and can be changed with WithTEIDAllocator option. It returns nil if
+// no TEID is available, including the case that the TEIDs issued are all taken in
+// the SessionStore shared with others.
 //
-// TODO: optimize performance...
+// The TEID is released when the Session registered with it is removed. If it
+// ends up with not being registered (e.g., the Create Session procedure failed),
+// release it with ReleaseTEID.
 func (c *Conn) NewSenderFTEID(v4, v6 string) (fteidIE *ie.IE) {
-	var teid uint32
-	for try := uint32(0); try < 0xffff; try++ {
-		const logEvery = 0xff
-		if try&logEvery == logEvery {
-			logf("Generating NewSenderFTEID crossed tries:%d", try)
-		}
-
-		t := generateRandomUint32()
-		if t == 0 {
-			continue
+	for try := 0; try < 0xffff; try++ {
+		teid, err := c.teids.Allocate()
+		if err != nil {
+			c.logger.Warn("failed to allocate TEID", logkey.Error, err)
+			return nil
 		}
 
-		// Try to mark TEID as taken. Fails if something exists
-		if ok := c.iteiSessionMap.tryStore(t, nil); !ok {
+		// the TEID may be used in the SessionStore shared with others.
+		if ok := c.store.ReserveTEID(teid); !ok {
+			c.teids.Release(teid)
+			c.logger.Debug("TEID has already been taken, trying to allocate another one", logkey.TEID, connlog.TEID(teid))
 			continue
 		}
 
-		teid = t
-		break
+		return ie.NewFullyQualifiedTEID(c.localIfType, teid, v4, v6)
 	}
 
-	if teid == 0 {
-		return nil
-	}
-	return ie.NewFullyQualifiedTEID(c.localIfType, teid, v4, v6)
+	c.logger.Warn("failed to allocate TEID: all the TEIDs tried are taken")
+	return nil
 }
 
-func generateRandomUint32() uint32 {
-	b := make([]byte, 4)
-	if _, err := rand.Read(b); err != nil {
-		return 0
+// ReleaseTEID releases the TEID issued by NewSenderFTEID that is not used by
+// any Session, to make it available again.
+//
+// It does nothing if the TEID is used by a Session; use RemoveSession instead.
+func (c *Conn) ReleaseTEID(teid uint32) {
+	if _, ok := c.store.LoadByTEID(teid); ok {
+		return
 	}
 
-	return binary.BigEndian.Uint32(b)
+	c.store.DeleteTEID(teid)
+	c.teids.Release(teid)
 }
 
 // Sessions returns all the sessions registered in Conn.
 func (c *Conn) Sessions() []*Session {
 	var ss []*Session
-	c.imsiSessionMap.rangeWithFunc(func(k, v interface{}) bool {
-		ss = append(ss, v.(*Session))
+	c.store.Range(func(sess *Session) bool {
+		ss = append(ss, sess)
 		return true
 	})
 
@@ -798,8 +1112,7 @@ func (c *Conn) Sessions() []*Session {
 // This may have some impact on performance in case of large number of Session exists.
 func (c *Conn) SessionCount() int {
 	var count int
-	c.imsiSessionMap.rangeWithFunc(func(k, v interface{}) bool {
-		sess := v.(*Session)
+	c.store.Range(func(sess *Session) bool {
 		if sess.IsActive() {
 			count++
 		}
@@ -814,8 +1127,7 @@ func (c *Conn) SessionCount() int {
 // This may have some impact on performance in case of large number of Session and Bearer exist.
 func (c *Conn) BearerCount() int {
 	var count int
-	c.imsiSessionMap.rangeWithFunc(func(k, v interface{}) bool {
-		sess := v.(*Session)
+	c.store.Range(func(sess *Session) bool {
 		if sess.IsActive() {
 			count += sess.BearerCount()
 		}
@@ -824,64 +1136,3 @@ func (c *Conn) BearerCount() int {
 
 	return count
 }
-
-type imsiSessionMap struct {
-	syncMap sync.Map
-}
-
-func newimsiSessionMap() *imsiSessionMap {
-	return &imsiSessionMap{}
-}
-
-func (i *imsiSessionMap) store(imsi string, session *Session) {
-	i.syncMap.Store(imsi, session)
-}
-
-func (i *imsiSessionMap) load(imsi string) (*Session, bool) {
-	session, ok := i.syncMap.Load(imsi)
-	if ok && session != nil {
-		return session.(*Session), true
-	}
-	return nil, ok
-}
-
-func (i *imsiSessionMap) delete(imsi string) {
-	i.syncMap.Delete(imsi)
-}
-
-func (i *imsiSessionMap) rangeWithFunc(fn func(imsi, session interface{}) bool) {
-	i.syncMap.Range(fn)
-}
-
-type iteiSessionMap struct {
-	syncMap sync.Map
-}
-
-func newiteiSessionMap() *iteiSessionMap {
-	return &iteiSessionMap{}
-}
-
-func (t *iteiSessionMap) store(teid uint32, session *Session) {
-	t.syncMap.Store(teid, session)
-}
-
-func (t *iteiSessionMap) tryStore(teid uint32, session *Session) bool {
-	_, loaded := t.syncMap.LoadOrStore(teid, session)
-	return !loaded
-}
-
-func (t *iteiSessionMap) load(teid uint32) (*Session, bool) {
-	session, ok := t.syncMap.Load(teid)
-	if ok && session != nil {
-		return session.(*Session), true
-	}
-	return nil, ok
-}
-
-func (t *iteiSessionMap) delete(teid uint32) {
-	t.syncMap.Delete(teid)
-}
-
-func (t *iteiSessionMap) rangeWithFunc(fn func(imsi, session interface{}) bool) {
-	t.syncMap.Range(fn)
-}
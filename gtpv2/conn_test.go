@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/wmnsk/go-gtp/gtpv2"
 	"github.com/wmnsk/go-gtp/gtpv2/ie"
 	"github.com/wmnsk/go-gtp/gtpv2/message"
@@ -217,3 +219,137 @@ func TestCreateSession(t *testing.T) {
 		t.Fatal("timed out while waiting for validating Create Session Response")
 	}
 }
+
+func TestErrorResponses(t *testing.T) {
+	cliAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 19}, Port: 2123}
+	srvAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 20}, Port: 2123}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvConn := gtpv2.NewConn(srvAddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithErrorResponses())
+	if err := srvConn.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.Serve(ctx); err != nil {
+			t.Errorf("error on serving: %v", err)
+		}
+	}()
+
+	cliConn, err := gtpv2.Dial(ctx, cliAddr, srvAddr, gtpv2.IFTypeS11MMEGTPC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	causeCh := make(chan *ie.IE, 1)
+	teidCh := make(chan uint32, 1)
+	handler := func(c *gtpv2.Conn, senderAddr net.Addr, msg message.Message) error {
+		teidCh <- msg.TEID()
+		var cause *ie.IE
+		switch res := msg.(type) {
+		case *message.CreateSessionResponse:
+			cause = res.Cause
+		case *message.ModifyBearerResponse:
+			cause = res.Cause
+		case *message.DeleteSessionResponse:
+			cause = res.Cause
+		}
+		causeCh <- cause
+		return nil
+	}
+	cliConn.AddHandlers(map[uint8]gtpv2.HandlerFunc{
+		message.MsgTypeCreateSessionResponse: handler,
+		message.MsgTypeModifyBearerResponse:  handler,
+		message.MsgTypeDeleteSessionResponse: handler,
+	})
+
+	// the length of IMSI exceeds the message.
+	malformed, err := message.Marshal(message.NewCreateSessionRequest(0, 1, ie.NewIMSI("123451234567890")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	malformed[14] = 0xff
+
+	// the IMSI is empty, while the length of MSISDN following it exceeds the message.
+	emptyIMSI, err := message.Marshal(message.NewCreateSessionRequest(0, 1, ie.New(ie.IMSI, 0, nil), ie.NewMSISDN("8130900000000")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyIMSI[18] = 0xff
+
+	// the Session known to both, with the TEID of the client as the peer's one
+	// on the server.
+	srvSession := gtpv2.NewSession(cliAddr, &gtpv2.Subscriber{IMSI: "123451234567890"})
+	srvSession.AddTEID(gtpv2.IFTypeS11MMEGTPC, 0x33333333)
+	srvConn.RegisterSession(0x22222222, srvSession)
+	cliConn.RegisterSession(0x33333333, gtpv2.NewSession(srvAddr, &gtpv2.Subscriber{IMSI: "123451234567890"}))
+
+	cases := []struct {
+		description string
+		send        func() error
+		cause       *ie.IE
+		teid        uint32
+	}{
+		{
+			"Malformed",
+			func() error {
+				_, err := cliConn.WriteTo(malformed, srvAddr)
+				return err
+			},
+			ie.NewCause(gtpv2.CauseInvalidMessageFormat, 0, 0, 0, ie.New(ie.IMSI, 0, nil)),
+			0,
+		}, {
+			"Malformed with invalid value",
+			func() error {
+				_, err := cliConn.WriteTo(emptyIMSI, srvAddr)
+				return err
+			},
+			ie.NewCause(gtpv2.CauseInvalidMessageFormat, 0, 0, 0, ie.New(ie.IMSI, 0, nil)),
+			0,
+		}, {
+			"Unknown TEID",
+			func() error {
+				_, err := cliConn.SendMessageTo(message.NewModifyBearerRequest(0x11111111, 0), srvAddr)
+				return err
+			},
+			ie.NewCause(gtpv2.CauseContextNotFound, 0, 0, 0, nil),
+			0,
+		}, {
+			"No handler",
+			func() error {
+				_, err := cliConn.SendMessageTo(message.NewDeleteSessionRequest(0, 0), srvAddr)
+				return err
+			},
+			ie.NewCause(gtpv2.CauseServiceNotSupported, 0, 0, 0, nil),
+			0,
+		}, {
+			"No handler on known Session",
+			func() error {
+				_, err := cliConn.SendMessageTo(message.NewDeleteSessionRequest(0x22222222, 0), srvAddr)
+				return err
+			},
+			ie.NewCause(gtpv2.CauseServiceNotSupported, 0, 0, 0, nil),
+			0x33333333,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if err := c.send(); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case got := <-causeCh:
+				if diff := cmp.Diff(got, c.cause, cmp.AllowUnexported(ie.IE{})); diff != "" {
+					t.Error(diff)
+				}
+				if diff := cmp.Diff(<-teidCh, c.teid); diff != "" {
+					t.Error(diff)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("timed out waiting for the response")
+			}
+		})
+	}
+}
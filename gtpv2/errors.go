@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
 	"github.com/wmnsk/go-gtp/gtpv2/message"
 )
 
@@ -18,6 +19,9 @@ var (
 	// ErrTimeout indicates that a handler failed to complete its work due to the
 	// absence of message expected to come from another endpoint.
 	ErrTimeout = errors.New("timed out")
+
+	// ErrMessageVetoed indicates that the message is vetoed by the Interceptor.
+	ErrMessageVetoed = errors.New("message vetoed by interceptor")
 )
 
 // CauseNotOKError indicates that the value in Cause IE is not OK.
@@ -156,3 +160,76 @@ type HandlerNotFoundError struct {
 func (e *HandlerNotFoundError) Error() string {
 	return fmt.Sprintf("no handlers found for incoming message: %s, ignoring", e.MsgType)
 }
+
+// MissingIEError indicates that the mandatory or conditional IE is missing in the
+// incoming message. It is returned by IERules.Validate.
+type MissingIEError struct {
+	Type, Instance uint8
+	Presence       Presence
+
+	// Parent is the type of the grouped IE that the IE should be contained in, or
+	// 0 if the IE should be at the top level of the message.
+	Parent uint8
+}
+
+// Error returns the type and instance of the missing IE.
+func (e *MissingIEError) Error() string {
+	kind := "mandatory"
+	if e.Presence == PresenceConditional {
+		kind = "conditional"
+	}
+	if e.Parent != 0 {
+		return fmt.Sprintf("%s IE missing: %d (instance %d) in %d", kind, e.Type, e.Instance, e.Parent)
+	}
+	return fmt.Sprintf("%s IE missing: %d (instance %d)", kind, e.Type, e.Instance)
+}
+
+// Cause returns the Cause value to reject the message with, which is either
+// CauseMandatoryIEMissing or CauseConditionalIEMissing.
+func (e *MissingIEError) Cause() uint8 {
+	if e.Presence == PresenceConditional {
+		return CauseConditionalIEMissing
+	}
+	return CauseMandatoryIEMissing
+}
+
+// OffendingIE returns the IE to be set as Offending IE in the Cause IE.
+func (e *MissingIEError) OffendingIE() *ie.IE {
+	return ie.New(e.Type, e.Instance, nil)
+}
+
+// IncorrectIEError indicates that the mandatory IE in the incoming message has
+// the invalid value. It is returned by IERules.Validate.
+type IncorrectIEError struct {
+	Type, Instance uint8
+
+	// Parent is the type of the grouped IE that the IE is contained in, or 0 if
+	// the IE is at the top level of the message.
+	Parent uint8
+
+	Err error
+}
+
+// Error returns the type and instance of the incorrect IE with the reason.
+func (e *IncorrectIEError) Error() string {
+	if e.Parent != 0 {
+		return fmt.Sprintf("mandatory IE incorrect: %d (instance %d) in %d: %v", e.Type, e.Instance, e.Parent, e.Err)
+	}
+	return fmt.Sprintf("mandatory IE incorrect: %d (instance %d): %v", e.Type, e.Instance, e.Err)
+}
+
+// Unwrap returns the error that makes the IE incorrect.
+func (e *IncorrectIEError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the Cause value to reject the message with, which is always
+// CauseMandatoryIEIncorrect.
+func (e *IncorrectIEError) Cause() uint8 {
+	return CauseMandatoryIEIncorrect
+}
+
+// OffendingIE returns the IE to be set as Offending IE in the Cause IE.
+func (e *IncorrectIEError) OffendingIE() *ie.IE {
+	return ie.New(e.Type, e.Instance, nil)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/teidalloc"
 )
 
 var testConn *gtpv2.Conn
@@ -150,3 +151,168 @@ func TestRemoveSessionByIMSI(t *testing.T) {
 	s.AddTEID(gtpv2.IFTypeS11MMEGTPC, uint32(0))
 	testConn.RegisterSession(0, s)
 }
+
+func TestMultiplePDNSessions(t *testing.T) {
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0)
+
+	newSession := func(apn string, ebi uint8, itei uint32) *gtpv2.Session {
+		sess := gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567891"})
+		_ = sess.Activate()
+		sess.GetDefaultBearer().APN = apn
+		sess.GetDefaultBearer().EBI = ebi
+		c.RegisterSession(itei, sess)
+		return sess
+	}
+	internet := newSession("internet", 5, 1)
+	ims := newSession("ims", 6, 2)
+	ims.AddBearer("voice", gtpv2.NewBearer(7, "ims", &gtpv2.QoSProfile{}))
+
+	if got := c.SessionCount(); got != 2 {
+		t.Errorf("SessionCount is invalid. want: 2, got: %d", got)
+	}
+
+	sessions, err := c.GetSessionsByIMSI("001011234567891")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0] != internet || sessions[1] != ims {
+		t.Errorf("Got wrong sessions: %v", sessions)
+	}
+
+	if sess, err := c.GetSessionByIMSIAndAPN("001011234567891", "internet"); err != nil || sess != internet {
+		t.Errorf("Got wrong session by APN: %v, %v", sess, err)
+	}
+	if sess, err := c.GetSessionByIMSIAndEBI("001011234567891", 7); err != nil || sess != ims {
+		t.Errorf("Got wrong session by EBI: %v, %v", sess, err)
+	}
+	if _, err := c.GetSessionByIMSIAndAPN("001011234567891", "mms"); err == nil {
+		t.Error("GetSessionByIMSIAndAPN should fail with unknown APN")
+	}
+
+	c.RemoveSession(internet)
+
+	if sess, err := c.GetSessionByIMSI("001011234567891"); err != nil || sess != ims {
+		t.Errorf("Sibling session is disturbed: %v, %v", sess, err)
+	}
+	if sess, err := c.GetSessionByTEID(2, dummyAddr); err != nil || sess != ims {
+		t.Errorf("Sibling session is disturbed: %v, %v", sess, err)
+	}
+	if _, err := c.GetSessionByTEID(1, dummyAddr); err == nil {
+		t.Error("Removed session is still found by TEID")
+	}
+
+	c.RemoveSessionByIMSI("001011234567891")
+	if got := c.SessionCount(); got != 0 {
+		t.Errorf("SessionCount is invalid. want: 0, got: %d", got)
+	}
+}
+
+func TestReRegisterSession(t *testing.T) {
+	allocator, err := teidalloc.NewPartitionedAllocator(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithTEIDAllocator(allocator))
+
+	newSession := func(apn string, itei uint32) *gtpv2.Session {
+		sess := gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567891"})
+		_ = sess.Activate()
+		sess.GetDefaultBearer().APN = apn
+		c.RegisterSession(itei, sess)
+		return sess
+	}
+	_ = newSession("internet", 0x30000001)
+	ims := newSession("ims", 0x30000002)
+	internet := newSession("internet", 0x30000003)
+
+	sessions, err := c.GetSessionsByIMSI("001011234567891")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0] != ims || sessions[1] != internet {
+		t.Errorf("Got wrong sessions: %v", sessions)
+	}
+	if sess, err := c.GetSessionByIMSIAndAPN("001011234567891", "internet"); err != nil || sess != internet {
+		t.Errorf("Got wrong session by APN: %v, %v", sess, err)
+	}
+	if sess, err := c.GetSessionByIMSI("001011234567891"); err != nil || sess != internet {
+		t.Errorf("Got wrong session by IMSI: %v, %v", sess, err)
+	}
+	if _, err := c.GetSessionByTEID(0x30000001, dummyAddr); err == nil {
+		t.Error("Replaced session is still found by TEID")
+	}
+	if got := allocator.Used(); got != 2 {
+		t.Errorf("TEID of replaced Session is not released: %d", got)
+	}
+
+	// the Sessions without APN are never replaced.
+	first := newSession("", 0x30000004)
+	second := newSession("", 0x30000005)
+	for _, sess := range []*gtpv2.Session{first, second} {
+		itei, err := sess.GetTEID(gtpv2.IFTypeS11MMEGTPC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := c.GetSessionByTEID(itei, dummyAddr); err != nil || got != sess {
+			t.Errorf("Session without APN is replaced: %v, %v", got, err)
+		}
+	}
+}
+
+func TestNewSenderFTEIDWithTEIDAllocator(t *testing.T) {
+	allocator, err := teidalloc.NewPartitionedAllocator(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithTEIDAllocator(allocator))
+
+	fteid := c.NewSenderFTEID("127.0.0.1", "")
+	teid, err := fteid.TEID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if teid != 0x30000000 {
+		t.Errorf("Got wrong TEID: %#x", teid)
+	}
+
+	sess := gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567891"})
+	c.RegisterSession(teid, sess)
+	c.RegisterSession(0x30000001, gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567892"}))
+	if got := allocator.Used(); got != 2 {
+		t.Errorf("TEIDs registered are not marked as used: %d", got)
+	}
+
+	c.RemoveSession(sess)
+	if got := allocator.Used(); got != 1 {
+		t.Errorf("TEID of removed Session is not released: %d", got)
+	}
+
+	fteid = c.NewSenderFTEID("127.0.0.1", "")
+	if teid, _ := fteid.TEID(); teid != 0x30000002 {
+		t.Errorf("Got wrong TEID: %#x", teid)
+	}
+	c.ReleaseTEID(0x30000002)
+	if got := allocator.Used(); got != 1 {
+		t.Errorf("TEID is not released: %d", got)
+	}
+}
+
+func TestNewSenderFTEIDWithSharedStore(t *testing.T) {
+	allocator, err := teidalloc.NewPartitionedAllocator(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := gtpv2.NewMemorySessionStore()
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithTEIDAllocator(allocator), gtpv2.WithSessionStore(store))
+
+	// the TEID is taken by another Conn sharing the SessionStore.
+	store.ReserveTEID(0x30000000)
+
+	fteid := c.NewSenderFTEID("127.0.0.1", "")
+	if teid, _ := fteid.TEID(); teid != 0x30000001 {
+		t.Errorf("Got wrong TEID: %#x", teid)
+	}
+	if got := allocator.Used(); got != 1 {
+		t.Errorf("TEID taken in SessionStore is not released: %d", got)
+	}
+}
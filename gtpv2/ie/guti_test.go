@@ -0,0 +1,43 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGUTIFields(t *testing.T) {
+	tests := []struct {
+		name string
+		mcc  string
+		mnc  string
+	}{
+		{
+			name: "Test GUTI with 2-digit MNC",
+			mcc:  "123",
+			mnc:  "45",
+		},
+		{
+			name: "Test GUTI with 3-digit MNC",
+			mcc:  "310",
+			mnc:  "260",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := NewGUTIFields(tt.mcc, tt.mnc, 0x1111, 0x22, 0x33333333)
+			got, err := NewGUTI(tt.mcc, tt.mnc, 0x1111, 0x22, 0x33333333).GUTI()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
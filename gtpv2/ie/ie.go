@@ -493,8 +493,9 @@ var ieTypeNameMap = map[uint8]string{
 	117: "GUTI",
 	118: "FContainer",
 	119: "FCause",
-	121: "PLMNID",
-	122: "TargetIdentification",
+	120: "PLMNID",
+	121: "TargetIdentification",
+	122: "Reserved",
 	123: "PacketFlowID",
 	124: "RABContext",
 	125: "SourceRNCPDCPContextInfo",
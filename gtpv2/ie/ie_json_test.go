@@ -0,0 +1,194 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+func TestIEsJSONAndYAML(t *testing.T) {
+	for _, c := range cases {
+		t.Run("json/"+c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, c.serialized); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+
+		t.Run("yaml/"+c.description, func(t *testing.T) {
+			b, err := yaml.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := &ie.IE{}
+			if err := yaml.Unmarshal(b, got); err != nil {
+				t.Fatalf("%s: %v", b, err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, c.serialized); diff != "" {
+				t.Errorf("%s: %s", b, diff)
+			}
+		})
+	}
+}
+
+func TestIEJSONDocument(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  *ie.IE
+		document    string
+	}{
+		{
+			"IMSI",
+			ie.NewIMSI("123451234567890"),
+			`{"type":"IMSI","value":"123451234567890"}`,
+		}, {
+			"Cause",
+			ie.NewCause(gtpv2.CauseMandatoryIEMissing, 0, 0, 0, ie.NewIMSI("")),
+			`{"type":"Cause","value":{"cause":70,"offendingIE":"IMSI"}}`,
+		}, {
+			"FullyQualifiedTEID",
+			ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", "").WithInstance(1),
+			`{"type":"FullyQualifiedTEID","instance":1,"value":{"interfaceType":10,"teid":286331153,"ipv4":"10.0.0.1"}}`,
+		}, {
+			"UserLocationInformation",
+			ie.NewUserLocationInformationStruct(
+				nil, nil, nil,
+				ie.NewTAI("123", "45", 0x0001),
+				ie.NewECGI("123", "45", 0x00000101),
+				nil, nil, nil,
+			),
+			`{"type":"UserLocationInformation","value":{"tai":{"mcc":"123","mnc":"45","tac":1},"ecgi":{"mcc":"123","mnc":"45","eci":257}}}`,
+		}, {
+			"Indication",
+			ie.NewIndicationFromOctets(0x80, 0x08, 0x00, 0x00),
+			`{"type":"Indication","value":{"flags":["DAF","PS"],"octets":4}}`,
+		}, {
+			"GUTI",
+			ie.NewGUTI("123", "45", 0x1111, 0x22, 0x33333333),
+			`{"type":"GUTI","value":{"mcc":"123","mnc":"45","mmeGroupID":4369,"mmeCode":34,"mTMSI":858993459}}`,
+		}, {
+			"PLMNID",
+			ie.NewPLMNID("123", "45"),
+			`{"type":"PLMNID","value":{"mcc":"123","mnc":"45"}}`,
+		}, {
+			"BearerContext",
+			ie.NewBearerContext(ie.NewEPSBearerID(5)),
+			`{"type":"BearerContext","ies":[{"type":"EPSBearerID","value":5}]}`,
+		}, {
+			"Unknown",
+			ie.New(250, 2, []byte{0xde, 0xad}),
+			`{"type":250,"instance":2,"payload":"dead"}`,
+		}, {
+			"NoValue",
+			ie.New(ie.IMSI, 0, nil),
+			`{"type":"IMSI"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := json.Marshal(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+
+			got := &ie.IE{}
+			if err := json.Unmarshal([]byte(c.document), got); err != nil {
+				t.Fatal(err)
+			}
+			want, err := c.structured.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			serialized, err := got.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(serialized, want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIEYAMLDocument(t *testing.T) {
+	doc := `
+type: BearerContext
+ies:
+- type: EPSBearerID
+  value: 5
+- type: 87
+  instance: 2
+  value:
+    interfaceType: 4
+    teid: 0x22222222
+    ipv4: 10.0.0.2
+- type: BearerQoS
+  value:
+    arp: {pci: true, pl: 2, pvi: false}
+    qci: 9
+    mbrUplink: 0
+    mbrDownlink: 0
+    gbrUplink: 0
+    gbrDownlink: 0
+`
+	want := ie.NewBearerContext(
+		ie.NewEPSBearerID(5),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPU, 0x22222222, "10.0.0.2", "").WithInstance(2),
+		ie.NewBearerQoS(1, 2, 0, 9, 0, 0, 0, 0),
+	)
+
+	got := &ie.IE{}
+	if err := yaml.Unmarshal([]byte(doc), got); err != nil {
+		t.Fatal(err)
+	}
+	opt := cmp.AllowUnexported(*got, *want)
+	if diff := cmp.Diff(got, want, opt); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestIEJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"type":"NoSuchIE"}`,
+		`{"type":256}`,
+		`{"type":"IMSI","payload":"zz"}`,
+		`{"type":"IMSI","value":1}`,
+		`{"type":250,"value":1}`,
+		`{"type":"IMSI","ies":[]}`,
+		`{"type":"Indication","value":{"flags":["NOSUCHFLAG"]}}`,
+	} {
+		if err := json.Unmarshal([]byte(doc), &ie.IE{}); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
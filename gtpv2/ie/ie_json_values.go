@@ -0,0 +1,717 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package ie
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/wmnsk/go-gtp/utils"
+)
+
+// codecs are the codecs of IEs represented with the value in JSON and YAML, by
+// IE type. The IEs not listed here are represented with the payload in hex.
+var codecs = map[uint8]codec{
+	IMSI:                    simpleCodec((*IE).IMSI, NewIMSI),
+	MSISDN:                  simpleCodec((*IE).MSISDN, NewMSISDN),
+	MobileEquipmentIdentity: simpleCodec((*IE).MobileEquipmentIdentity, NewMobileEquipmentIdentity),
+	AccessPointName:         simpleCodec((*IE).AccessPointName, NewAccessPointName),
+	Recovery:                simpleCodec((*IE).Recovery, NewRecovery),
+	EPSBearerID:             simpleCodec((*IE).EPSBearerID, NewEPSBearerID),
+	RATType:                 simpleCodec((*IE).RATType, NewRATType),
+	PDNType:                 simpleCodec((*IE).PDNType, NewPDNType),
+	SelectionMode:           simpleCodec((*IE).SelectionMode, NewSelectionMode),
+	APNRestriction:          simpleCodec((*IE).APNRestriction, NewAPNRestriction),
+	NodeType:                simpleCodec((*IE).NodeType, NewNodeType),
+	PortNumber:              simpleCodec((*IE).PortNumber, NewPortNumber),
+	FullyQualifiedDomainName: simpleCodec(
+		(*IE).FullyQualifiedDomainName, NewFullyQualifiedDomainName,
+	),
+	ProcedureTransactionID:  simpleCodec((*IE).ProcedureTransactionID, NewProcedureTransactionID),
+	ChargingID:              simpleCodec((*IE).ChargingID, NewChargingID),
+	ChargingCharacteristics: simpleCodec((*IE).ChargingCharacteristics, NewChargingCharacteristics),
+	TMSI:                    simpleCodec((*IE).TMSI, NewTMSI),
+	PacketTMSI:              simpleCodec((*IE).PacketTMSI, NewPacketTMSI),
+	PTMSISignature:          simpleCodec((*IE).PTMSISignature, NewPTMSISignature),
+	HopCounter:              simpleCodec((*IE).HopCounter, NewHopCounter),
+	RFSPIndex:               simpleCodec((*IE).RFSPIndex, NewRFSPIndex),
+	ServiceIndicator:        simpleCodec((*IE).ServiceIndicator, NewServiceIndicator),
+	DetachType:              simpleCodec((*IE).DetachType, NewDetachType),
+	CSGID:                   simpleCodec((*IE).CSGID, NewCSGID),
+	CSGMembershipIndication: simpleCodec((*IE).CMI, NewCSGMembershipIndication),
+	LocalDistinguishedName:  simpleCodec((*IE).LocalDistinguishedName, NewLocalDistinguishedName),
+	IntegerNumber:           simpleCodec((*IE).IntegerNumber, NewIntegerNumber),
+	IPAddress:               simpleCodec((*IE).IPAddress, NewIPAddress),
+	BearerFlags:             uint8Codec(BearerFlags),
+	NodeFeatures:            uint8Codec(NodeFeatures),
+	MBMSFlags:               uint8Codec(MBMSFlags),
+
+	Cause:                        valueCodec[causeValue]{decodeCause, encodeCause},
+	Indication:                   valueCodec[indicationValue]{decodeIndication, encodeIndication},
+	AggregateMaximumBitRate:      valueCodec[ambrValue]{decodeAMBR, encodeAMBR},
+	AllocationRetensionPriority:  valueCodec[arpValue]{decodeARP, encodeARP},
+	BearerQoS:                    valueCodec[bearerQoSValue]{decodeBearerQoS, encodeBearerQoS},
+	FlowQoS:                      valueCodec[flowQoSValue]{decodeFlowQoS, encodeFlowQoS},
+	UserLocationInformation:      valueCodec[uliValue]{decodeULI, encodeULI},
+	FullyQualifiedTEID:           valueCodec[fteidValue]{decodeFTEID, encodeFTEID},
+	PDNAddressAllocation:         valueCodec[paaValue]{decodePAA, encodePAA},
+	ProtocolConfigurationOptions: valueCodec[pcoValue]{decodePCO, encodePCO},
+	FullyQualifiedCSID:           valueCodec[fqcsidValue]{decodeFQCSID, encodeFQCSID},
+	GUTI:                         valueCodec[gutiValue]{decodeGUTI, encodeGUTI},
+	GlobalCNID:                   valueCodec[globalCNIDValue]{decodeGlobalCNID, encodeGlobalCNID},
+	ServingNetwork:               valueCodec[plmnValue]{decodePLMN, plmnEncoder(NewServingNetwork)},
+	PLMNID:                       valueCodec[plmnValue]{decodePLMN, plmnEncoder(NewPLMNID)},
+	TraceReference:               valueCodec[traceReferenceValue]{decodeTraceReference, encodeTraceReference},
+	UETimeZone:                   valueCodec[timeZoneValue]{decodeTimeZone, encodeTimeZone},
+	UserCSGInformation:           valueCodec[uciValue]{decodeUCI, encodeUCI},
+	RANNASCause:                  valueCodec[ranNASCauseValue]{decodeRANNASCause, encodeRANNASCause},
+	PagingAndServiceInformation:  valueCodec[psiValue]{decodePSI, encodePSI},
+	PrivateExtension:             valueCodec[privateExtensionValue]{decodePrivateExtension, encodePrivateExtension},
+}
+
+// b2u returns 1 if b is true, and 0 otherwise.
+func b2u(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// fieldsIE creates the IE with the payload serialized from the fields.
+func fieldsIE(t uint8, f interface{ Marshal() ([]byte, error) }) (*IE, error) {
+	b, err := f.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return New(t, 0x00, b), nil
+}
+
+type causeValue struct {
+	Cause       uint8   `json:"cause" yaml:"cause"`
+	PCE         bool    `json:"pce,omitempty" yaml:"pce,omitempty"`
+	BCE         bool    `json:"bce,omitempty" yaml:"bce,omitempty"`
+	CS          bool    `json:"cs,omitempty" yaml:"cs,omitempty"`
+	OffendingIE *ieType `json:"offendingIE,omitempty" yaml:"offendingIE,omitempty"`
+}
+
+func decodeCause(i *IE) (causeValue, error) {
+	if len(i.Payload) < 2 {
+		return causeValue{}, io.ErrUnexpectedEOF
+	}
+	v := causeValue{
+		Cause: i.Payload[0],
+		PCE:   has3rdBit(i.Payload[1]),
+		BCE:   has2ndBit(i.Payload[1]),
+		CS:    has1stBit(i.Payload[1]),
+	}
+	if len(i.Payload) > 2 {
+		t := ieType(i.Payload[2])
+		v.OffendingIE = &t
+	}
+	return v, nil
+}
+
+func encodeCause(v causeValue) (*IE, error) {
+	var offending *IE
+	if v.OffendingIE != nil {
+		offending = New(uint8(*v.OffendingIE), 0x00, nil)
+	}
+	return NewCause(v.Cause, b2u(v.PCE), b2u(v.BCE), b2u(v.CS), offending), nil
+}
+
+// indicationFlags are the names of flags in Indication IE, from the most
+// significant bit of the first octet.
+var indicationFlags = [...]string{
+	"DAF", "DTF", "HI", "DFI", "OI", "ISRSI", "ISRAI", "SGWCI",
+	"SQCI", "UIMSI", "CFSI", "CRSI", "PS", "PT", "SI", "MSV",
+	"RetLoc", "PBIC", "SRNI", "S6AF", "S4AF", "MBMDT", "ISRAU", "CCRSI",
+	"CPRAI", "ARRL", "PPOFF", "PPON", "PPSI", "CSFBI", "CLII", "CPSR",
+	"NSI", "UASI", "DTCI", "BDWI", "PSCI", "PCRI", "AOSI", "AOPI",
+	"ROAAI", "EPCOSI", "CPOPCI", "PMTMSI", "S11TF", "PNSI", "UNACCSI", "WPMSI",
+	"5GSNN26", "REPREFI", "5GSIWK", "EEVRSI", "LTEMUI", "LTEMPI", "ENBCRSI", "TSPCMI",
+	"CSRMFI", "MTEDTN", "MTEDTA", "N5GNMI", "5GCNRS", "5GCNRI", "5SRHOI", "ETHPDN",
+	"", "", "", "", "", "", "", "EMCI",
+}
+
+// indicationValue is the set of flags in Indication IE. Octets is the number of
+// octets, which is set only when it is longer than the flags require.
+type indicationValue struct {
+	Flags  []string `json:"flags" yaml:"flags"`
+	Octets int      `json:"octets,omitempty" yaml:"octets,omitempty"`
+}
+
+func decodeIndication(i *IE) (indicationValue, error) {
+	v := indicationValue{Flags: []string{}}
+	last := 0
+	for n, o := range i.Payload {
+		for bit := 0; bit < 8; bit++ {
+			if o&(0x80>>bit) == 0 {
+				continue
+			}
+			if n*8+bit >= len(indicationFlags) || indicationFlags[n*8+bit] == "" {
+				return indicationValue{}, ErrMalformed
+			}
+			v.Flags = append(v.Flags, indicationFlags[n*8+bit])
+			last = n + 1
+		}
+	}
+	if len(i.Payload) > last {
+		v.Octets = len(i.Payload)
+	}
+	return v, nil
+}
+
+func encodeIndication(v indicationValue) (*IE, error) {
+	b := make([]byte, v.Octets, len(indicationFlags)/8)
+	for _, f := range v.Flags {
+		n := indexOf(indicationFlags[:], f)
+		if f == "" || n < 0 {
+			return nil, fmt.Errorf("unknown flag %q: %w", f, ErrMalformed)
+		}
+		for len(b) <= n/8 {
+			b = append(b, 0)
+		}
+		b[n/8] |= 0x80 >> (n % 8)
+	}
+	return New(Indication, 0x00, b), nil
+}
+
+// indexOf returns the index of s in ss, or -1 if not found.
+func indexOf(ss []string, s string) int {
+	for n, v := range ss {
+		if v == s {
+			return n
+		}
+	}
+	return -1
+}
+
+type ambrValue struct {
+	Uplink   uint32 `json:"uplink" yaml:"uplink"`
+	Downlink uint32 `json:"downlink" yaml:"downlink"`
+}
+
+func decodeAMBR(i *IE) (ambrValue, error) {
+	f, err := i.AggregateMaximumBitRate()
+	if err != nil {
+		return ambrValue{}, err
+	}
+	return ambrValue{f.APNAMBRForUplink, f.APNAMBRForDownlink}, nil
+}
+
+func encodeAMBR(v ambrValue) (*IE, error) {
+	return NewAggregateMaximumBitRate(v.Uplink, v.Downlink), nil
+}
+
+type arpValue struct {
+	PCI bool  `json:"pci" yaml:"pci"`
+	PL  uint8 `json:"pl" yaml:"pl"`
+	PVI bool  `json:"pvi" yaml:"pvi"`
+}
+
+// newARPValue returns the value of ARP in the octet.
+func newARPValue(b uint8) arpValue {
+	return arpValue{PCI: has7thBit(b), PL: b >> 2 & 0x0f, PVI: has1stBit(b)}
+}
+
+func decodeARP(i *IE) (arpValue, error) {
+	b, err := i.AllocationRetensionPriority()
+	if err != nil {
+		return arpValue{}, err
+	}
+	return newARPValue(b), nil
+}
+
+func encodeARP(v arpValue) (*IE, error) {
+	return NewAllocationRetensionPriority(b2u(v.PCI), v.PL, b2u(v.PVI)), nil
+}
+
+type bearerQoSValue struct {
+	ARP         arpValue `json:"arp" yaml:"arp"`
+	QCI         uint8    `json:"qci" yaml:"qci"`
+	MBRUplink   uint64   `json:"mbrUplink" yaml:"mbrUplink"`
+	MBRDownlink uint64   `json:"mbrDownlink" yaml:"mbrDownlink"`
+	GBRUplink   uint64   `json:"gbrUplink" yaml:"gbrUplink"`
+	GBRDownlink uint64   `json:"gbrDownlink" yaml:"gbrDownlink"`
+}
+
+func decodeBearerQoS(i *IE) (bearerQoSValue, error) {
+	f, err := i.BearerQoS()
+	if err != nil {
+		return bearerQoSValue{}, err
+	}
+	return bearerQoSValue{
+		ARP:         newARPValue(f.ARP),
+		QCI:         f.QCI,
+		MBRUplink:   f.MaximumBitRateForUplink,
+		MBRDownlink: f.MaximumBitRateForDownlink,
+		GBRUplink:   f.GuaranteedBitRateForUplink,
+		GBRDownlink: f.GuaranteedBitRateForDownlink,
+	}, nil
+}
+
+func encodeBearerQoS(v bearerQoSValue) (*IE, error) {
+	return NewBearerQoS(
+		b2u(v.ARP.PCI), v.ARP.PL, b2u(v.ARP.PVI), v.QCI,
+		v.MBRUplink, v.MBRDownlink, v.GBRUplink, v.GBRDownlink,
+	), nil
+}
+
+type flowQoSValue struct {
+	QCI         uint8  `json:"qci" yaml:"qci"`
+	MBRUplink   uint64 `json:"mbrUplink" yaml:"mbrUplink"`
+	MBRDownlink uint64 `json:"mbrDownlink" yaml:"mbrDownlink"`
+	GBRUplink   uint64 `json:"gbrUplink" yaml:"gbrUplink"`
+	GBRDownlink uint64 `json:"gbrDownlink" yaml:"gbrDownlink"`
+}
+
+func decodeFlowQoS(i *IE) (flowQoSValue, error) {
+	f, err := i.FlowQoS()
+	if err != nil {
+		return flowQoSValue{}, err
+	}
+	return flowQoSValue{
+		QCI:         f.QCI,
+		MBRUplink:   f.MaximumBitRateForUplink,
+		MBRDownlink: f.MaximumBitRateForDownlink,
+		GBRUplink:   f.GuaranteedBitRateForUplink,
+		GBRDownlink: f.GuaranteedBitRateForDownlink,
+	}, nil
+}
+
+func encodeFlowQoS(v flowQoSValue) (*IE, error) {
+	return NewFlowQoS(v.QCI, v.MBRUplink, v.MBRDownlink, v.GBRUplink, v.GBRDownlink), nil
+}
+
+// areaValue is the identifier of area or cell in UserLocationInformation IE.
+// Only the fields of the type of identifier are set.
+type areaValue struct {
+	MCC   string `json:"mcc" yaml:"mcc"`
+	MNC   string `json:"mnc" yaml:"mnc"`
+	LAC   uint16 `json:"lac,omitempty" yaml:"lac,omitempty"`
+	CI    uint16 `json:"ci,omitempty" yaml:"ci,omitempty"`
+	SAC   uint16 `json:"sac,omitempty" yaml:"sac,omitempty"`
+	RAC   uint16 `json:"rac,omitempty" yaml:"rac,omitempty"`
+	TAC   uint16 `json:"tac,omitempty" yaml:"tac,omitempty"`
+	ECI   uint32 `json:"eci,omitempty" yaml:"eci,omitempty"`
+	ENBID uint32 `json:"enbID,omitempty" yaml:"enbID,omitempty"`
+}
+
+type uliValue struct {
+	CGI    *areaValue `json:"cgi,omitempty" yaml:"cgi,omitempty"`
+	SAI    *areaValue `json:"sai,omitempty" yaml:"sai,omitempty"`
+	RAI    *areaValue `json:"rai,omitempty" yaml:"rai,omitempty"`
+	TAI    *areaValue `json:"tai,omitempty" yaml:"tai,omitempty"`
+	ECGI   *areaValue `json:"ecgi,omitempty" yaml:"ecgi,omitempty"`
+	LAI    *areaValue `json:"lai,omitempty" yaml:"lai,omitempty"`
+	MENBI  *areaValue `json:"menbi,omitempty" yaml:"menbi,omitempty"`
+	EMENBI *areaValue `json:"emenbi,omitempty" yaml:"emenbi,omitempty"`
+}
+
+func decodeULI(i *IE) (uliValue, error) {
+	f, err := i.UserLocationInformation()
+	if err != nil {
+		return uliValue{}, err
+	}
+
+	var v uliValue
+	if f.HasCGI() {
+		v.CGI = &areaValue{MCC: f.CGI.MCC, MNC: f.CGI.MNC, LAC: f.CGI.LAC, CI: f.CGI.CI}
+	}
+	if f.HasSAI() {
+		v.SAI = &areaValue{MCC: f.SAI.MCC, MNC: f.SAI.MNC, LAC: f.SAI.LAC, SAC: f.SAI.SAC}
+	}
+	if f.HasRAI() {
+		v.RAI = &areaValue{MCC: f.RAI.MCC, MNC: f.RAI.MNC, LAC: f.RAI.LAC, RAC: f.RAI.RAC}
+	}
+	if f.HasTAI() {
+		v.TAI = &areaValue{MCC: f.TAI.MCC, MNC: f.TAI.MNC, TAC: f.TAI.TAC}
+	}
+	if f.HasECGI() {
+		v.ECGI = &areaValue{MCC: f.ECGI.MCC, MNC: f.ECGI.MNC, ECI: f.ECGI.ECI}
+	}
+	if f.HasLAI() {
+		v.LAI = &areaValue{MCC: f.LAI.MCC, MNC: f.LAI.MNC, LAC: f.LAI.LAC}
+	}
+	if f.HasMENBI() {
+		v.MENBI = &areaValue{MCC: f.MENBI.MCC, MNC: f.MENBI.MNC, ENBID: f.MENBI.MENBI}
+	}
+	if f.HasEMENBI() {
+		v.EMENBI = &areaValue{MCC: f.EMENBI.MCC, MNC: f.EMENBI.MNC, ENBID: f.EMENBI.EMENBI}
+	}
+	return v, nil
+}
+
+func encodeULI(v uliValue) (*IE, error) {
+	var (
+		cgi    *CGI
+		sai    *SAI
+		rai    *RAI
+		tai    *TAI
+		ecgi   *ECGI
+		lai    *LAI
+		menbi  *MENBI
+		emenbi *EMENBI
+	)
+	if a := v.CGI; a != nil {
+		cgi = NewCGI(a.MCC, a.MNC, a.LAC, a.CI)
+	}
+	if a := v.SAI; a != nil {
+		sai = NewSAI(a.MCC, a.MNC, a.LAC, a.SAC)
+	}
+	if a := v.RAI; a != nil {
+		rai = NewRAI(a.MCC, a.MNC, a.LAC, a.RAC)
+	}
+	if a := v.TAI; a != nil {
+		tai = NewTAI(a.MCC, a.MNC, a.TAC)
+	}
+	if a := v.ECGI; a != nil {
+		ecgi = NewECGI(a.MCC, a.MNC, a.ECI)
+	}
+	if a := v.LAI; a != nil {
+		lai = NewLAI(a.MCC, a.MNC, a.LAC)
+	}
+	if a := v.MENBI; a != nil {
+		menbi = NewMENBI(a.MCC, a.MNC, a.ENBID)
+	}
+	if a := v.EMENBI; a != nil {
+		emenbi = NewEMENBI(a.MCC, a.MNC, a.ENBID)
+	}
+	return fieldsIE(UserLocationInformation, NewUserLocationInformationFields(cgi, sai, rai, tai, ecgi, lai, menbi, emenbi))
+}
+
+type fteidValue struct {
+	InterfaceType uint8  `json:"interfaceType" yaml:"interfaceType"`
+	TEID          uint32 `json:"teid" yaml:"teid"`
+	IPv4          string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6          string `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+}
+
+func decodeFTEID(i *IE) (fteidValue, error) {
+	f, err := i.FullyQualifiedTEID()
+	if err != nil {
+		return fteidValue{}, err
+	}
+	return fteidValue{
+		InterfaceType: f.InterfaceType,
+		TEID:          f.TEIDGREKey,
+		IPv4:          ipString(f.IPv4Address),
+		IPv6:          ipString(f.IPv6Address),
+	}, nil
+}
+
+func encodeFTEID(v fteidValue) (*IE, error) {
+	v4, err := parseIP(v.IPv4)
+	if err != nil {
+		return nil, err
+	}
+	v6, err := parseIP(v.IPv6)
+	if err != nil {
+		return nil, err
+	}
+	return fieldsIE(FullyQualifiedTEID, NewFullyQualifiedTEIDFields(v.InterfaceType, v.TEID, v4, v6))
+}
+
+type paaValue struct {
+	PDNType          uint8  `json:"pdnType" yaml:"pdnType"`
+	IPv4             string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6             string `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+	IPv6PrefixLength uint8  `json:"ipv6PrefixLength,omitempty" yaml:"ipv6PrefixLength,omitempty"`
+}
+
+func decodePAA(i *IE) (paaValue, error) {
+	f, err := ParsePDNAddressAllocationFields(i.Payload)
+	if err != nil {
+		return paaValue{}, err
+	}
+	return paaValue{
+		PDNType:          f.PDNType,
+		IPv4:             ipString(f.IPv4Address),
+		IPv6:             ipString(f.IPv6Address),
+		IPv6PrefixLength: f.IPv6PrefixLength,
+	}, nil
+}
+
+func encodePAA(v paaValue) (*IE, error) {
+	v4, err := parseIP(v.IPv4)
+	if err != nil {
+		return nil, err
+	}
+	v6, err := parseIP(v.IPv6)
+	if err != nil {
+		return nil, err
+	}
+	return fieldsIE(PDNAddressAllocation, NewPDNAddressAllocationFields(v.PDNType, v4, v6, v.IPv6PrefixLength))
+}
+
+type pcoContainerValue struct {
+	ID       uint16   `json:"id" yaml:"id"`
+	Contents hexBytes `json:"contents,omitempty" yaml:"contents,omitempty"`
+}
+
+type pcoValue struct {
+	ConfigurationProtocol uint8               `json:"configurationProtocol" yaml:"configurationProtocol"`
+	Containers            []pcoContainerValue `json:"containers,omitempty" yaml:"containers,omitempty"`
+}
+
+func decodePCO(i *IE) (pcoValue, error) {
+	f, err := i.ProtocolConfigurationOptions()
+	if err != nil {
+		return pcoValue{}, err
+	}
+	v := pcoValue{ConfigurationProtocol: f.ConfigurationProtocol}
+	for _, c := range f.ProtocolOrContainers {
+		v.Containers = append(v.Containers, pcoContainerValue{c.ID, c.Contents})
+	}
+	return v, nil
+}
+
+func encodePCO(v pcoValue) (*IE, error) {
+	cs := make([]*PCOContainer, len(v.Containers))
+	for n, c := range v.Containers {
+		cs[n] = NewPCOContainer(c.ID, c.Contents)
+	}
+	return NewProtocolConfigurationOptions(v.ConfigurationProtocol, cs...), nil
+}
+
+// fqcsidValue is the value of FullyQualifiedCSID IE. The NodeID is the IP address,
+// or hex for the other types of node ID.
+type fqcsidValue struct {
+	NodeID string   `json:"nodeID" yaml:"nodeID"`
+	CSIDs  []uint16 `json:"csids" yaml:"csids"`
+}
+
+func decodeFQCSID(i *IE) (fqcsidValue, error) {
+	f, err := i.FullyQualifiedCSID()
+	if err != nil {
+		return fqcsidValue{}, err
+	}
+	v := fqcsidValue{CSIDs: f.CSIDs}
+	switch f.NodeIDType {
+	case nodeIDIPv4, nodeIDIPv6:
+		v.NodeID = net.IP(f.NodeID).String()
+	default:
+		v.NodeID = fmt.Sprintf("%x", f.NodeID)
+	}
+	return v, nil
+}
+
+func encodeFQCSID(v fqcsidValue) (*IE, error) {
+	f := NewFullyQualifiedCSIDFields(v.NodeID, v.CSIDs...)
+	if f == nil {
+		return nil, fmt.Errorf("invalid node ID %q: %w", v.NodeID, ErrMalformed)
+	}
+	return fieldsIE(FullyQualifiedCSID, f)
+}
+
+type gutiValue struct {
+	MCC        string `json:"mcc" yaml:"mcc"`
+	MNC        string `json:"mnc" yaml:"mnc"`
+	MMEGroupID uint16 `json:"mmeGroupID" yaml:"mmeGroupID"`
+	MMECode    uint8  `json:"mmeCode" yaml:"mmeCode"`
+	MTMSI      uint32 `json:"mTMSI" yaml:"mTMSI"`
+}
+
+func decodeGUTI(i *IE) (gutiValue, error) {
+	f, err := i.GUTI()
+	if err != nil {
+		return gutiValue{}, err
+	}
+	return gutiValue{f.MCC, f.MNC, f.MMEGroupID, f.MMECode, f.MTMSI}, nil
+}
+
+func encodeGUTI(v gutiValue) (*IE, error) {
+	return fieldsIE(GUTI, NewGUTIFields(v.MCC, v.MNC, v.MMEGroupID, v.MMECode, v.MTMSI))
+}
+
+type globalCNIDValue struct {
+	MCC  string `json:"mcc" yaml:"mcc"`
+	MNC  string `json:"mnc" yaml:"mnc"`
+	CNID uint16 `json:"cnID" yaml:"cnID"`
+}
+
+func decodeGlobalCNID(i *IE) (globalCNIDValue, error) {
+	mcc, err := i.MCC()
+	if err != nil {
+		return globalCNIDValue{}, err
+	}
+	mnc, err := i.MNC()
+	if err != nil {
+		return globalCNIDValue{}, err
+	}
+	id, err := i.CNID()
+	if err != nil {
+		return globalCNIDValue{}, err
+	}
+	return globalCNIDValue{mcc, mnc, id}, nil
+}
+
+func encodeGlobalCNID(v globalCNIDValue) (*IE, error) {
+	if i := NewGlobalCNID(v.MCC, v.MNC, v.CNID); i != nil {
+		return i, nil
+	}
+	return nil, ErrMalformed
+}
+
+type plmnValue struct {
+	MCC string `json:"mcc" yaml:"mcc"`
+	MNC string `json:"mnc" yaml:"mnc"`
+}
+
+func decodePLMN(i *IE) (plmnValue, error) {
+	mcc, mnc, err := utils.DecodePLMN(i.Payload)
+	if err != nil {
+		return plmnValue{}, err
+	}
+	return plmnValue{mcc, mnc}, nil
+}
+
+// plmnEncoder returns the encoder with the constructor of IE with PLMN ID.
+func plmnEncoder(newIE func(mcc, mnc string) *IE) func(v plmnValue) (*IE, error) {
+	return func(v plmnValue) (*IE, error) {
+		if i := newIE(v.MCC, v.MNC); i != nil {
+			return i, nil
+		}
+		return nil, ErrMalformed
+	}
+}
+
+type traceReferenceValue struct {
+	MCC     string `json:"mcc" yaml:"mcc"`
+	MNC     string `json:"mnc" yaml:"mnc"`
+	TraceID uint32 `json:"traceID" yaml:"traceID"`
+}
+
+func decodeTraceReference(i *IE) (traceReferenceValue, error) {
+	f, err := ParseTraceReferenceFields(i.Payload)
+	if err != nil {
+		return traceReferenceValue{}, err
+	}
+	return traceReferenceValue{f.MCC, f.MNC, f.TraceID}, nil
+}
+
+func encodeTraceReference(v traceReferenceValue) (*IE, error) {
+	return fieldsIE(TraceReference, NewTraceReferenceFields(v.MCC, v.MNC, v.TraceID))
+}
+
+// timeZoneValue is the value of UETimeZone IE. TimeZone is in the format of
+// time.Duration, e.g. "9h0m0s" or "-4h30m0s".
+type timeZoneValue struct {
+	TimeZone       string `json:"timeZone" yaml:"timeZone"`
+	DaylightSaving uint8  `json:"daylightSaving" yaml:"daylightSaving"`
+}
+
+func decodeTimeZone(i *IE) (timeZoneValue, error) {
+	tz, err := i.TimeZone()
+	if err != nil {
+		return timeZoneValue{}, err
+	}
+	ds, err := i.DaylightSaving()
+	if err != nil {
+		return timeZoneValue{}, err
+	}
+	return timeZoneValue{tz.String(), ds}, nil
+}
+
+func encodeTimeZone(v timeZoneValue) (*IE, error) {
+	tz, err := time.ParseDuration(v.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return NewUETimeZone(tz, v.DaylightSaving), nil
+}
+
+type uciValue struct {
+	MCC        string `json:"mcc" yaml:"mcc"`
+	MNC        string `json:"mnc" yaml:"mnc"`
+	CSGID      uint32 `json:"csgID" yaml:"csgID"`
+	AccessMode uint8  `json:"accessMode" yaml:"accessMode"`
+	LCSG       bool   `json:"lcsg,omitempty" yaml:"lcsg,omitempty"`
+	CMI        bool   `json:"cmi,omitempty" yaml:"cmi,omitempty"`
+}
+
+func decodeUCI(i *IE) (uciValue, error) {
+	f, err := i.UserCSGInformation()
+	if err != nil {
+		return uciValue{}, err
+	}
+	return uciValue{
+		MCC:        f.MCC,
+		MNC:        f.MNC,
+		CSGID:      f.CSGID,
+		AccessMode: f.AccessMode,
+		LCSG:       has2ndBit(f.Flags),
+		CMI:        has1stBit(f.Flags),
+	}, nil
+}
+
+func encodeUCI(v uciValue) (*IE, error) {
+	return fieldsIE(UserCSGInformation, NewUserCSGInformationFields(v.MCC, v.MNC, v.CSGID, v.AccessMode, b2u(v.LCSG), b2u(v.CMI)))
+}
+
+type ranNASCauseValue struct {
+	ProtocolType uint8    `json:"protocolType" yaml:"protocolType"`
+	CauseType    uint8    `json:"causeType" yaml:"causeType"`
+	Cause        hexBytes `json:"cause,omitempty" yaml:"cause,omitempty"`
+}
+
+func decodeRANNASCause(i *IE) (ranNASCauseValue, error) {
+	f, err := i.RANNASCause()
+	if err != nil {
+		return ranNASCauseValue{}, err
+	}
+	return ranNASCauseValue{f.ProtocolType, f.CauseType, f.Cause}, nil
+}
+
+func encodeRANNASCause(v ranNASCauseValue) (*IE, error) {
+	return fieldsIE(RANNASCause, NewRANNASCauseFields(v.ProtocolType, v.CauseType, v.Cause))
+}
+
+type psiValue struct {
+	EPSBearerID            uint8  `json:"epsBearerID" yaml:"epsBearerID"`
+	PagingPolicyIndication *uint8 `json:"pagingPolicyIndication,omitempty" yaml:"pagingPolicyIndication,omitempty"`
+}
+
+func decodePSI(i *IE) (psiValue, error) {
+	f, err := i.PagingAndServiceInformation()
+	if err != nil {
+		return psiValue{}, err
+	}
+	v := psiValue{EPSBearerID: f.EPSBearerID}
+	if has1stBit(f.Flags) {
+		v.PagingPolicyIndication = &f.PagingPolicyIndication
+	}
+	return v, nil
+}
+
+func encodePSI(v psiValue) (*IE, error) {
+	if v.PagingPolicyIndication == nil {
+		return fieldsIE(PagingAndServiceInformation, NewPagingAndServiceInformationFields(v.EPSBearerID, 0, 0))
+	}
+	return fieldsIE(PagingAndServiceInformation, NewPagingAndServiceInformationFields(v.EPSBearerID, 1, *v.PagingPolicyIndication))
+}
+
+type privateExtensionValue struct {
+	EnterpriseID uint16   `json:"enterpriseID" yaml:"enterpriseID"`
+	Value        hexBytes `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+func decodePrivateExtension(i *IE) (privateExtensionValue, error) {
+	id, err := i.EnterpriseID()
+	if err != nil {
+		return privateExtensionValue{}, err
+	}
+	v, err := i.PrivateExtension()
+	if err != nil {
+		return privateExtensionValue{}, err
+	}
+	return privateExtensionValue{id, v}, nil
+}
+
+func encodePrivateExtension(v privateExtensionValue) (*IE, error) {
+	return NewPrivateExtension(v.EnterpriseID, v.Value), nil
+}
@@ -752,3 +752,23 @@ func TestIEAddRemove(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestIEName(t *testing.T) {
+	cases := []struct {
+		typ  uint8
+		name string
+	}{
+		{ie.FCause, "FCause"},
+		{ie.PLMNID, "PLMNID"},
+		{ie.TargetIdentification, "TargetIdentification"},
+		{ie.PacketFlowID, "PacketFlowID"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if diff := cmp.Diff(ie.New(c.typ, 0x00, nil).Name(), c.name); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
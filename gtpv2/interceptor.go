@@ -0,0 +1,118 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// Packet is a message sent or received by Conn, given to Interceptor.
+type Packet struct {
+	// Peer is the address of the endpoint that the message is sent to or
+	// received from.
+	Peer net.Addr
+
+	// Message is the message parsed from Raw. It is nil if Raw cannot be parsed.
+	Message message.Message
+
+	// Raw is the message in bytes that is actually sent or received.
+	// It should not be modified; use SetMessage instead. It should also be
+	// copied to be retained after Interceptor returns.
+	Raw []byte
+}
+
+// SetMessage replaces the Message in Packet with msg and updates Raw with the
+// bytes encoded from msg. To mutate the Message in place, modify it and call
+// SetMessage with it to let the change take effect.
+func (p *Packet) SetMessage(msg message.Message) error {
+	b, err := message.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode %T: %w", msg, err)
+	}
+
+	p.Message = msg
+	p.Raw = b
+	return nil
+}
+
+// Interceptor is called with every message sent or received by Conn, before it
+// is written to the network or handled by the HandlerFunc.
+//
+// Interceptor can inspect the message, mutate it with Packet.SetMessage, or veto
+// it by returning an error. The vetoed message is neither sent nor handled.
+type Interceptor func(c *Conn, pkt *Packet) error
+
+// WithInboundInterceptor adds the Interceptor called with the messages received
+// by Conn. It can be given multiple times, and the Interceptors are called in
+// the order given.
+//
+// The Interceptor is called before the validation and the HandlerFunc. It is
+// also called with the message that cannot be parsed, with nil Message in
+// Packet. Such message is discarded anyway whatever the Interceptor returns.
+// The message vetoed is discarded and logged at debug level.
+func WithInboundInterceptor(fn Interceptor) ConnOption {
+	return func(c *Conn) {
+		c.inbound = append(c.inbound, fn)
+	}
+}
+
+// WithOutboundInterceptor adds the Interceptor called with the messages sent by
+// Conn, including the ones written with WriteTo. It can be given multiple times,
+// and the Interceptors are called in the order given.
+//
+// If the Interceptor vetoes the message, the method to send it returns an error
+// that wraps ErrMessageVetoed and the error from Interceptor.
+func WithOutboundInterceptor(fn Interceptor) ConnOption {
+	return func(c *Conn) {
+		c.outbound = append(c.outbound, fn)
+	}
+}
+
+// intercept calls the Interceptors in order, stopping at the first one that
+// vetoes the message.
+func (c *Conn) intercept(fns []Interceptor, pkt *Packet) error {
+	for _, fn := range fns {
+		if err := fn(c, pkt); err != nil {
+			return fmt.Errorf("%w: %w", ErrMessageVetoed, err)
+		}
+	}
+	return nil
+}
+
+// interceptInbound lets the inbound Interceptors inspect the message received,
+// and returns the message to be handled.
+func (c *Conn) interceptInbound(raddr net.Addr, raw []byte, msg message.Message) (message.Message, error) {
+	if len(c.inbound) == 0 {
+		return msg, nil
+	}
+
+	pkt := &Packet{Peer: raddr, Message: msg, Raw: raw}
+	if err := c.intercept(c.inbound, pkt); err != nil {
+		return nil, err
+	}
+	return pkt.Message, nil
+}
+
+// interceptOutbound lets the outbound Interceptors inspect the message to be
+// sent, and returns the bytes to be written. msg can be nil if b is not made
+// from a message.Message, in which case b is parsed only when needed.
+func (c *Conn) interceptOutbound(raddr net.Addr, b []byte, msg message.Message) ([]byte, error) {
+	if len(c.outbound) == 0 {
+		return b, nil
+	}
+
+	if msg == nil {
+		// the message not parsed is given to the Interceptors as nil.
+		msg, _ = message.Parse(b)
+	}
+	pkt := &Packet{Peer: raddr, Message: msg, Raw: b}
+	if err := c.intercept(c.outbound, pkt); err != nil {
+		return nil, err
+	}
+	return pkt.Raw, nil
+}
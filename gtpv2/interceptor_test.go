@@ -0,0 +1,120 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+func TestInterceptors(t *testing.T) {
+	cliAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 13}, Port: 2123}
+	srvAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 14}, Port: 2123}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	srvConn := gtpv2.NewConn(srvAddr, gtpv2.IFTypeS11S4SGWGTPC, 0,
+		// veto the Echo Request with a specific sequence number.
+		gtpv2.WithInboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			if pkt.Message.Sequence() == 0x201 {
+				return errors.New("vetoed")
+			}
+			return nil
+		}),
+		// mutate the Recovery in Echo Response.
+		gtpv2.WithOutboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			res, ok := pkt.Message.(*message.EchoResponse)
+			if !ok {
+				return nil
+			}
+			res.Recovery = ie.NewRecovery(0x42)
+			return pkt.SetMessage(res)
+		}),
+	)
+	if err := srvConn.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.Serve(ctx); err != nil {
+			t.Errorf("error on serving: %v", err)
+		}
+	}()
+
+	cliConn, err := gtpv2.Dial(ctx, cliAddr, srvAddr, gtpv2.IFTypeS11MMEGTPC, 0,
+		gtpv2.WithInboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			res, ok := pkt.Message.(*message.EchoResponse)
+			if !ok {
+				return nil
+			}
+			recovery, err := res.Recovery.Recovery()
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, fmt.Sprintf("%s seq=%#x recovery=%#x", res.MessageTypeName(), res.Sequence(), recovery))
+			return nil
+		}),
+		gtpv2.WithOutboundInterceptor(func(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+			if pkt.Message.MessageType() == message.MsgTypeDeleteSessionRequest {
+				return errors.New("not allowed")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+	defer srvConn.Close()
+
+	for _, seq := range []uint32{0x201, 0x202} {
+		b, err := message.NewEchoRequest(seq, ie.NewRecovery(0)).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cliConn.WriteTo(b, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seq := cliConn.SequenceNumber()
+	if _, err := cliConn.SendMessageTo(message.NewDeleteSessionRequest(0, 0), srvAddr); !errors.Is(err, gtpv2.ErrMessageVetoed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(cliConn.SequenceNumber(), seq); diff != "" {
+		t.Error(diff)
+	}
+
+	// wait for a while not to miss the response to the vetoed request, if any.
+	time.Sleep(500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"Echo Response seq=0x1 recovery=0x42",
+		"Echo Response seq=0x202 recovery=0x42",
+	}
+	if diff := cmp.Diff(received, want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+func TestWithLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0,
+		gtpv2.WithLogger(slog.New(slog.NewJSONHandler(buf, nil))),
+	)
+
+	c.RemoveSessionByIMSI("001011234567899")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	delete(got, slog.TimeKey)
+
+	want := map[string]any{
+		slog.LevelKey:    "INFO",
+		slog.MessageKey:  "Session not found",
+		logkey.Proto:     "gtpv2-c",
+		logkey.LocalAddr: dummyAddr.String(),
+		logkey.IMSI:      "001011234567899",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gtpv2.SetLogger(log.New(buf, "", 0))
+	defer gtpv2.EnableLogging(nil)
+
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0)
+	c.RemoveSessionByIMSI("001011234567899")
+
+	got := buf.String()
+	for _, want := range []string{`msg="Session not found"`, "proto=gtpv2-c", "imsi=001011234567899"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q not found in the log: %s", want, got)
+		}
+	}
+
+	buf.Reset()
+	gtpv2.DisableLogging()
+	c.RemoveSessionByIMSI("001011234567899")
+	if buf.Len() != 0 {
+		t.Errorf("Logged while disabled: %s", buf.String())
+	}
+}
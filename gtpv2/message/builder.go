@@ -0,0 +1,48 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// builder is the common part of the message builders, which keeps the first
+// error that occurred while setting the IEs.
+type builder struct {
+	msgType string
+	err     error
+}
+
+// expect returns true if i is one of the types expected for the field. It
+// records the error and returns false if i is not. nil is silently ignored.
+func (b *builder) expect(field string, i *ie.IE, types ...uint8) bool {
+	if i == nil {
+		return false
+	}
+	for _, t := range types {
+		if i.Type == t {
+			return true
+		}
+	}
+	b.fail(field, IEUnrecognized, i)
+	return false
+}
+
+// fail records the error for i if no error has occurred yet.
+func (b *builder) fail(field string, kind UnexpectedIEKind, i *ie.IE) {
+	if b.err == nil {
+		b.err = newUnexpectedIEError(b.msgType, field, kind, i)
+	}
+}
+
+// withInstance returns i with the instance given. i is copied if the instance
+// is changed, so that the IE given by the caller is not modified.
+func withInstance(i *ie.IE, instance uint8) *ie.IE {
+	if i.Instance() == instance {
+		return i
+	}
+	c := *i
+	return c.WithInstance(instance)
+}
@@ -0,0 +1,111 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+func TestBuilder(t *testing.T) {
+	pgwC := ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.2", "")
+
+	got, err := message.BuildCreateSessionRequest(0, 1).
+		WithIMSI(ie.NewIMSI("123451234567890")).
+		WithSenderFTEIDC(ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", "")).
+		WithPGWS5S8FTEIDC(pgwC).
+		WithULIForSGW(ie.NewUserLocationInformationStruct(nil, nil, nil, ie.NewTAI("123", "45", 0x0001), nil, nil, nil, nil)).
+		WithBearerContextsToBeCreated(
+			ie.NewBearerContext(ie.NewEPSBearerID(5)),
+			ie.NewBearerContext(ie.NewEPSBearerID(6)),
+		).
+		WithIEs(ie.NewRATType(gtpv2.RATTypeEUTRAN), ie.NewAccessPointName("some.apn.example")).
+		WithAdditionalIEs(ie.New(250, 0, []byte{0xde, 0xad})).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := message.NewCreateSessionRequest(0, 1,
+		ie.NewIMSI("123451234567890"),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", ""),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.2", "").WithInstance(1),
+		ie.NewUserLocationInformationStruct(nil, nil, nil, ie.NewTAI("123", "45", 0x0001), nil, nil, nil, nil).WithInstance(1),
+		ie.NewBearerContext(ie.NewEPSBearerID(5)),
+		ie.NewBearerContext(ie.NewEPSBearerID(6)),
+		ie.NewRATType(gtpv2.RATTypeEUTRAN),
+		ie.NewAccessPointName("some.apn.example"),
+		ie.New(250, 0, []byte{0xde, 0xad}),
+	)
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(ie.IE{})); diff != "" {
+		t.Error(diff)
+	}
+
+	if pgwC.Instance() != 0 {
+		t.Errorf("the IE given to the builder is modified: instance %d", pgwC.Instance())
+	}
+}
+
+func TestBuilderUnexpectedIE(t *testing.T) {
+	cases := []struct {
+		description string
+		build       func() (message.Message, error)
+		want        *message.UnexpectedIEError
+	}{
+		{
+			"wrong type for the field",
+			func() (message.Message, error) {
+				return message.BuildCreateSessionRequest(0, 1).
+					WithIMSI(ie.NewMSISDN("123412345678")).
+					Build()
+			},
+			&message.UnexpectedIEError{MsgType: "Create Session Request", Field: "IMSI", Kind: message.IEUnrecognized, Type: ie.MSISDN},
+		}, {
+			"wrong type for the multiple field",
+			func() (message.Message, error) {
+				return message.BuildCreateSessionRequest(0, 1).
+					WithBearerContextsToBeCreated(ie.NewBearerContext(), ie.NewEPSBearerID(5)).
+					Build()
+			},
+			&message.UnexpectedIEError{MsgType: "Create Session Request", Field: "BearerContextsToBeCreated", Kind: message.IEUnrecognized, Type: ie.EPSBearerID},
+		}, {
+			"unknown instance",
+			func() (message.Message, error) {
+				return message.BuildCreateSessionRequest(0, 1).
+					WithIEs(ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.2", "").WithInstance(5)).
+					Build()
+			},
+			&message.UnexpectedIEError{MsgType: "Create Session Request", Kind: message.IEUnexpectedInstance, Type: ie.FullyQualifiedTEID, Instance: 5},
+		}, {
+			"unknown type",
+			func() (message.Message, error) {
+				return message.BuildEchoRequest(1).
+					WithRecovery(ie.NewRecovery(1)).
+					WithIEs(ie.NewIMSI("123451234567890")).
+					Build()
+			},
+			&message.UnexpectedIEError{MsgType: "Echo Request", Kind: message.IEUnrecognized, Type: ie.IMSI},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			_, err := c.build()
+			var got *message.UnexpectedIEError
+			if !errors.As(err, &got) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(got, c.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ChangeNotificationRequest is a ChangeNotificationRequest Header and its IEs above.
 type ChangeNotificationRequest struct {
@@ -37,40 +41,67 @@ func NewChangeNotificationRequest(teid, seq uint32, ies ...*ie.IE) *ChangeNotifi
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.IPAddress:
-			c.PGWS5S8IPAddressForControlPlane = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.PresenceReportingAreaInformation:
-			c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.SecondaryRATUsageDataReport:
-			c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	c.SetLength()
 	return c
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *ChangeNotificationRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		c.IMSI = i
+	case ie.MobileEquipmentIdentity:
+		c.MEI = i
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.RATType:
+		c.RATType = i
+	case ie.UserLocationInformation:
+		c.ULI = i
+	case ie.UserCSGInformation:
+		c.UCI = i
+	case ie.IPAddress:
+		c.PGWS5S8IPAddressForControlPlane = i
+	case ie.EPSBearerID:
+		c.LinkedEBI = i
+	case ie.PresenceReportingAreaInformation:
+		c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
+	case ie.Counter:
+		c.MOExceptionDataCounter = i
+	case ie.SecondaryRATUsageDataReport:
+		c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ChangeNotificationRequest, or 0
+// if it is set to one of the fields.
+func (c *ChangeNotificationRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.MobileEquipmentIdentity, ie.Indication, ie.RATType,
+		ie.UserLocationInformation, ie.UserCSGInformation, ie.IPAddress, ie.EPSBearerID,
+		ie.PresenceReportingAreaInformation, ie.Counter, ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ChangeNotificationRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *ChangeNotificationRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Change Notification Request", c.AdditionalIEs, c.ieKind)
+}
+
 // Marshal serializes ChangeNotificationRequest into bytes.
 func (c *ChangeNotificationRequest) Marshal() ([]byte, error) {
 	b := make([]byte, c.MarshalLen())
@@ -89,77 +120,84 @@ func (c *ChangeNotificationRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MEI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RATType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ULI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UCI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWS5S8IPAddressForControlPlane; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaInformation {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MOExceptionDataCounter; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
+
 	for _, ie := range c.AdditionalIEs {
 		if ie == nil {
 			continue
@@ -198,39 +236,11 @@ func (c *ChangeNotificationRequest) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.IPAddress:
-			c.PGWS5S8IPAddressForControlPlane = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.PresenceReportingAreaInformation:
-			c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.SecondaryRATUsageDataReport:
-			c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -265,12 +275,18 @@ func (c *ChangeNotificationRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaInformation {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.MOExceptionDataCounter; ie != nil {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
@@ -300,3 +316,148 @@ func (c *ChangeNotificationRequest) MessageTypeName() string {
 func (c *ChangeNotificationRequest) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// ChangeNotificationRequestBuilder builds ChangeNotificationRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ChangeNotificationRequestBuilder struct {
+	builder
+	msg *ChangeNotificationRequest
+}
+
+// BuildChangeNotificationRequest returns the builder of ChangeNotificationRequest.
+func BuildChangeNotificationRequest(teid, seq uint32) *ChangeNotificationRequestBuilder {
+	return &ChangeNotificationRequestBuilder{
+		builder: builder{msgType: "Change Notification Request"},
+		msg:     NewChangeNotificationRequest(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ChangeNotificationRequestBuilder) WithIMSI(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithMEI sets the MobileEquipmentIdentity IE to MEI.
+func (b *ChangeNotificationRequestBuilder) WithMEI(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("MEI", i, ie.MobileEquipmentIdentity) {
+		b.msg.MEI = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ChangeNotificationRequestBuilder) WithIndicationFlags(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithRATType sets the RATType IE to RATType.
+func (b *ChangeNotificationRequestBuilder) WithRATType(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("RATType", i, ie.RATType) {
+		b.msg.RATType = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *ChangeNotificationRequestBuilder) WithULI(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithUCI sets the UserCSGInformation IE to UCI.
+func (b *ChangeNotificationRequestBuilder) WithUCI(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("UCI", i, ie.UserCSGInformation) {
+		b.msg.UCI = i
+	}
+	return b
+}
+
+// WithPGWS5S8IPAddressForControlPlane sets the IPAddress IE to PGWS5S8IPAddressForControlPlane.
+func (b *ChangeNotificationRequestBuilder) WithPGWS5S8IPAddressForControlPlane(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("PGWS5S8IPAddressForControlPlane", i, ie.IPAddress) {
+		b.msg.PGWS5S8IPAddressForControlPlane = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *ChangeNotificationRequestBuilder) WithLinkedEBI(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaInformation appends the PresenceReportingAreaInformation IEs to PresenceReportingAreaInformation.
+func (b *ChangeNotificationRequestBuilder) WithPresenceReportingAreaInformation(ies ...*ie.IE) *ChangeNotificationRequestBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaInformation", i, ie.PresenceReportingAreaInformation) {
+			b.msg.PresenceReportingAreaInformation = append(b.msg.PresenceReportingAreaInformation, i)
+		}
+	}
+	return b
+}
+
+// WithMOExceptionDataCounter sets the Counter IE to MOExceptionDataCounter.
+func (b *ChangeNotificationRequestBuilder) WithMOExceptionDataCounter(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("MOExceptionDataCounter", i, ie.Counter) {
+		b.msg.MOExceptionDataCounter = i
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *ChangeNotificationRequestBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *ChangeNotificationRequestBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ChangeNotificationRequestBuilder) WithPrivateExtension(i *ie.IE) *ChangeNotificationRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewChangeNotificationRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ChangeNotificationRequestBuilder) WithIEs(ies ...*ie.IE) *ChangeNotificationRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ChangeNotificationRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *ChangeNotificationRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ChangeNotificationRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ChangeNotificationRequestBuilder) Build() (*ChangeNotificationRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
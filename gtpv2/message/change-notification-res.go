@@ -2,9 +2,13 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ChangeNotificationResponse is a ChangeNotificationResponse Header and its IEs above.
 type ChangeNotificationResponse struct {
@@ -32,30 +36,56 @@ func NewChangeNotificationResponse(teid, seq uint32, ies ...*ie.IE) *ChangeNotif
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.Cause:
-			c.Cause = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	c.SetLength()
 	return c
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *ChangeNotificationResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		c.IMSI = i
+	case ie.MobileEquipmentIdentity:
+		c.MEI = i
+	case ie.Cause:
+		c.Cause = i
+	case ie.ChangeReportingAction:
+		c.ChangeReportingAction = i
+	case ie.CSGInformationReportingAction:
+		c.CSGInformationReportingAction = i
+	case ie.PresenceReportingAreaAction:
+		c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ChangeNotificationResponse, or 0
+// if it is set to one of the fields.
+func (c *ChangeNotificationResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.MobileEquipmentIdentity, ie.Cause, ie.ChangeReportingAction,
+		ie.CSGInformationReportingAction, ie.PresenceReportingAreaAction, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ChangeNotificationResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *ChangeNotificationResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Change Notification Response", c.AdditionalIEs, c.ieKind)
+}
+
 // Marshal serializes ChangeNotificationResponse into bytes.
 func (c *ChangeNotificationResponse) Marshal() ([]byte, error) {
 	b := make([]byte, c.MarshalLen())
@@ -74,43 +104,46 @@ func (c *ChangeNotificationResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MEI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChangeReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CSGInformationReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -155,24 +188,10 @@ func (c *ChangeNotificationResponse) UnmarshalBinary(b []byte) error {
 		return err
 	}
 	for _, i := range decodedIEs {
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.Cause:
-			c.Cause = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+		if i == nil {
+			continue
 		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -198,6 +217,9 @@ func (c *ChangeNotificationResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
@@ -227,3 +249,106 @@ func (c *ChangeNotificationResponse) MessageTypeName() string {
 func (c *ChangeNotificationResponse) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// ChangeNotificationResponseBuilder builds ChangeNotificationResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ChangeNotificationResponseBuilder struct {
+	builder
+	msg *ChangeNotificationResponse
+}
+
+// BuildChangeNotificationResponse returns the builder of ChangeNotificationResponse.
+func BuildChangeNotificationResponse(teid, seq uint32) *ChangeNotificationResponseBuilder {
+	return &ChangeNotificationResponseBuilder{
+		builder: builder{msgType: "Change Notification Response"},
+		msg:     NewChangeNotificationResponse(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ChangeNotificationResponseBuilder) WithIMSI(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithMEI sets the MobileEquipmentIdentity IE to MEI.
+func (b *ChangeNotificationResponseBuilder) WithMEI(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("MEI", i, ie.MobileEquipmentIdentity) {
+		b.msg.MEI = i
+	}
+	return b
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ChangeNotificationResponseBuilder) WithCause(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithChangeReportingAction sets the ChangeReportingAction IE to ChangeReportingAction.
+func (b *ChangeNotificationResponseBuilder) WithChangeReportingAction(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("ChangeReportingAction", i, ie.ChangeReportingAction) {
+		b.msg.ChangeReportingAction = i
+	}
+	return b
+}
+
+// WithCSGInformationReportingAction sets the CSGInformationReportingAction IE to CSGInformationReportingAction.
+func (b *ChangeNotificationResponseBuilder) WithCSGInformationReportingAction(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("CSGInformationReportingAction", i, ie.CSGInformationReportingAction) {
+		b.msg.CSGInformationReportingAction = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaAction appends the PresenceReportingAreaAction IEs to PresenceReportingAreaAction.
+func (b *ChangeNotificationResponseBuilder) WithPresenceReportingAreaAction(ies ...*ie.IE) *ChangeNotificationResponseBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAreaAction = append(b.msg.PresenceReportingAreaAction, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ChangeNotificationResponseBuilder) WithPrivateExtension(i *ie.IE) *ChangeNotificationResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewChangeNotificationResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ChangeNotificationResponseBuilder) WithIEs(ies ...*ie.IE) *ChangeNotificationResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ChangeNotificationResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *ChangeNotificationResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ChangeNotificationResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ChangeNotificationResponseBuilder) Build() (*ChangeNotificationResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -36,42 +38,82 @@ func NewContextAcknowledge(teid, seq uint32, ies ...*ie.IE) *ContextAcknowledge
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			c.ForwardingFTEID = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.NodeNumber:
-			switch i.Instance() {
-			case 0:
-				c.SGSNNumber = i
-			case 1:
-				c.MMENumberForMTSMS = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.NodeIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.SGSNIdentifierForMTSMS = i
-			case 1:
-				c.MMEIdentifierForMTSMS = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		c.setIE(i)
+	}
+
+	c.SetLength()
+	return c
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *ContextAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		c.Cause = i
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.FullyQualifiedTEID:
+		c.ForwardingFTEID = i
+	case ie.BearerContext:
+		c.BearerContexts = append(c.BearerContexts, i)
+	case ie.NodeNumber:
+		switch i.Instance() {
+		case 0:
+			c.SGSNNumber = i
+		case 1:
+			c.MMENumberForMTSMS = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.NodeIdentifier:
+		switch i.Instance() {
+		case 0:
+			c.SGSNIdentifierForMTSMS = i
+		case 1:
+			c.MMEIdentifierForMTSMS = i
 		default:
 			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
 		}
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	c.SetLength()
-	return c
+// ieKind returns the reason why the IE does not belong to ContextAcknowledge, or 0
+// if it is set to one of the fields.
+func (c *ContextAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Indication, ie.FullyQualifiedTEID, ie.BearerContext,
+		ie.PrivateExtension:
+		return 0
+	case ie.NodeNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.NodeIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ContextAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *ContextAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Context Acknowledge", c.AdditionalIEs, c.ieKind)
 }
 
 // Marshal serializes ContextAcknowledge into bytes.
@@ -92,55 +134,58 @@ func (c *ContextAcknowledge) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ForwardingFTEID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNNumber; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMENumberForMTSMS; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNIdentifierForMTSMS; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMEIdentifierForMTSMS; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -188,38 +233,7 @@ func (c *ContextAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			c.ForwardingFTEID = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.NodeNumber:
-			switch i.Instance() {
-			case 0:
-				c.SGSNNumber = i
-			case 1:
-				c.MMENumberForMTSMS = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.NodeIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.SGSNIdentifierForMTSMS = i
-			case 1:
-				c.MMEIdentifierForMTSMS = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -239,6 +253,9 @@ func (c *ContextAcknowledge) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.SGSNNumber; ie != nil {
@@ -280,3 +297,122 @@ func (c *ContextAcknowledge) MessageTypeName() string {
 func (c *ContextAcknowledge) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// ContextAcknowledgeBuilder builds ContextAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ContextAcknowledgeBuilder struct {
+	builder
+	msg *ContextAcknowledge
+}
+
+// BuildContextAcknowledge returns the builder of ContextAcknowledge.
+func BuildContextAcknowledge(teid, seq uint32) *ContextAcknowledgeBuilder {
+	return &ContextAcknowledgeBuilder{
+		builder: builder{msgType: "Context Acknowledge"},
+		msg:     NewContextAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ContextAcknowledgeBuilder) WithCause(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ContextAcknowledgeBuilder) WithIndicationFlags(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithForwardingFTEID sets the FullyQualifiedTEID IE to ForwardingFTEID.
+func (b *ContextAcknowledgeBuilder) WithForwardingFTEID(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("ForwardingFTEID", i, ie.FullyQualifiedTEID) {
+		b.msg.ForwardingFTEID = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *ContextAcknowledgeBuilder) WithBearerContexts(ies ...*ie.IE) *ContextAcknowledgeBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithSGSNNumber sets the NodeNumber IE to SGSNNumber, with the instance set to 0.
+func (b *ContextAcknowledgeBuilder) WithSGSNNumber(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("SGSNNumber", i, ie.NodeNumber) {
+		b.msg.SGSNNumber = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMENumberForMTSMS sets the NodeNumber IE to MMENumberForMTSMS, with the instance set to 1.
+func (b *ContextAcknowledgeBuilder) WithMMENumberForMTSMS(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("MMENumberForMTSMS", i, ie.NodeNumber) {
+		b.msg.MMENumberForMTSMS = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGSNIdentifierForMTSMS sets the NodeIdentifier IE to SGSNIdentifierForMTSMS, with the instance set to 0.
+func (b *ContextAcknowledgeBuilder) WithSGSNIdentifierForMTSMS(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("SGSNIdentifierForMTSMS", i, ie.NodeIdentifier) {
+		b.msg.SGSNIdentifierForMTSMS = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMEIdentifierForMTSMS sets the NodeIdentifier IE to MMEIdentifierForMTSMS, with the instance set to 1.
+func (b *ContextAcknowledgeBuilder) WithMMEIdentifierForMTSMS(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("MMEIdentifierForMTSMS", i, ie.NodeIdentifier) {
+		b.msg.MMEIdentifierForMTSMS = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ContextAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *ContextAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewContextAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ContextAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *ContextAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ContextAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *ContextAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ContextAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ContextAcknowledgeBuilder) Build() (*ContextAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
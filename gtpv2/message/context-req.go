@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -47,64 +49,106 @@ func NewContextRequest(teid, seq uint32, ies ...*ie.IE) *ContextRequest {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.GUTI:
-			c.GUTI = i
-		case ie.UserLocationInformation:
-			c.RAI = i
-		case ie.PacketTMSI:
-			c.PTMSI = i
-		case ie.PTMSISignature:
-			c.PTMSISignature = i
-		case ie.CompleteRequestMessage:
-			c.CompleteTAURequestMessage = i
-		case ie.FullyQualifiedTEID:
-			c.AddressAndTEIDForCPlane = i
-		case ie.PortNumber:
-			c.UDPSourcePortNumber = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.Indication:
-			c.Indication = i
-		case ie.HopCounter:
-			c.HopCounter = i
-		case ie.ServingNetwork:
-			c.TargetPLMNID = i
-		case ie.LocalDistinguishedName:
-			c.MMESGSNLDN = i
-		case ie.FullyQualifiedDomainName:
-			switch i.Instance() {
-			case 0:
-				c.SGSNNodeName = i
-			case 1:
-				c.MMENodeName = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.NodeNumber:
-			c.SGSNNumber = i
-		case ie.NodeIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.SGSNIdentifier = i
-			case 1:
-				c.MMEIdentifier = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.CIoTOptimizationsSupportIndication:
-			c.CIoTOptimizationsSupportIndication = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		c.setIE(i)
+	}
+
+	c.SetLength()
+	return c
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *ContextRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		c.IMSI = i
+	case ie.GUTI:
+		c.GUTI = i
+	case ie.UserLocationInformation:
+		c.RAI = i
+	case ie.PacketTMSI:
+		c.PTMSI = i
+	case ie.PTMSISignature:
+		c.PTMSISignature = i
+	case ie.CompleteRequestMessage:
+		c.CompleteTAURequestMessage = i
+	case ie.FullyQualifiedTEID:
+		c.AddressAndTEIDForCPlane = i
+	case ie.PortNumber:
+		c.UDPSourcePortNumber = i
+	case ie.RATType:
+		c.RATType = i
+	case ie.Indication:
+		c.Indication = i
+	case ie.HopCounter:
+		c.HopCounter = i
+	case ie.ServingNetwork:
+		c.TargetPLMNID = i
+	case ie.LocalDistinguishedName:
+		c.MMESGSNLDN = i
+	case ie.FullyQualifiedDomainName:
+		switch i.Instance() {
+		case 0:
+			c.SGSNNodeName = i
+		case 1:
+			c.MMENodeName = i
 		default:
 			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
 		}
+	case ie.NodeNumber:
+		c.SGSNNumber = i
+	case ie.NodeIdentifier:
+		switch i.Instance() {
+		case 0:
+			c.SGSNIdentifier = i
+		case 1:
+			c.MMEIdentifier = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.CIoTOptimizationsSupportIndication:
+		c.CIoTOptimizationsSupportIndication = i
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ContextRequest, or 0
+// if it is set to one of the fields.
+func (c *ContextRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.GUTI, ie.UserLocationInformation, ie.PacketTMSI,
+		ie.PTMSISignature, ie.CompleteRequestMessage, ie.FullyQualifiedTEID, ie.PortNumber,
+		ie.RATType, ie.Indication, ie.HopCounter, ie.ServingNetwork,
+		ie.LocalDistinguishedName, ie.NodeNumber, ie.CIoTOptimizationsSupportIndication, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedDomainName:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.NodeIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ContextRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *ContextRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Context Request", c.AdditionalIEs, c.ieKind)
 }
 
 // Marshal serializes ContextRequest into bytes.
@@ -125,121 +169,121 @@ func (c *ContextRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.GUTI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RAI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PTMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PTMSISignature; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CompleteTAURequestMessage; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.AddressAndTEIDForCPlane; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UDPSourcePortNumber; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RATType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.Indication; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.HopCounter; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TargetPLMNID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNNodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMENodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNNumber; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMEIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CIoTOptimizationsSupportIndication; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -287,60 +331,7 @@ func (c *ContextRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.GUTI:
-			c.GUTI = i
-		case ie.UserLocationInformation:
-			c.RAI = i
-		case ie.PacketTMSI:
-			c.PTMSI = i
-		case ie.PTMSISignature:
-			c.PTMSISignature = i
-		case ie.CompleteRequestMessage:
-			c.CompleteTAURequestMessage = i
-		case ie.FullyQualifiedTEID:
-			c.AddressAndTEIDForCPlane = i
-		case ie.PortNumber:
-			c.UDPSourcePortNumber = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.Indication:
-			c.Indication = i
-		case ie.HopCounter:
-			c.HopCounter = i
-		case ie.ServingNetwork:
-			c.TargetPLMNID = i
-		case ie.LocalDistinguishedName:
-			c.MMESGSNLDN = i
-		case ie.FullyQualifiedDomainName:
-			switch i.Instance() {
-			case 0:
-				c.SGSNNodeName = i
-			case 1:
-				c.MMENodeName = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.NodeNumber:
-			c.SGSNNumber = i
-		case ie.NodeIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.SGSNIdentifier = i
-			case 1:
-				c.MMEIdentifier = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.CIoTOptimizationsSupportIndication:
-			c.CIoTOptimizationsSupportIndication = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -349,6 +340,7 @@ func (c *ContextRequest) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (c *ContextRequest) MarshalLen() int {
 	l := c.Header.MarshalLen() - len(c.Header.Payload)
+
 	if ie := c.IMSI; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -433,3 +425,208 @@ func (c *ContextRequest) MessageTypeName() string {
 func (c *ContextRequest) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// ContextRequestBuilder builds ContextRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ContextRequestBuilder struct {
+	builder
+	msg *ContextRequest
+}
+
+// BuildContextRequest returns the builder of ContextRequest.
+func BuildContextRequest(teid, seq uint32) *ContextRequestBuilder {
+	return &ContextRequestBuilder{
+		builder: builder{msgType: "Context Request"},
+		msg:     NewContextRequest(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ContextRequestBuilder) WithIMSI(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithGUTI sets the GUTI IE to GUTI.
+func (b *ContextRequestBuilder) WithGUTI(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("GUTI", i, ie.GUTI) {
+		b.msg.GUTI = i
+	}
+	return b
+}
+
+// WithRAI sets the UserLocationInformation IE to RAI.
+func (b *ContextRequestBuilder) WithRAI(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("RAI", i, ie.UserLocationInformation) {
+		b.msg.RAI = i
+	}
+	return b
+}
+
+// WithPTMSI sets the PacketTMSI IE to PTMSI.
+func (b *ContextRequestBuilder) WithPTMSI(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("PTMSI", i, ie.PacketTMSI) {
+		b.msg.PTMSI = i
+	}
+	return b
+}
+
+// WithPTMSISignature sets the PTMSISignature IE to PTMSISignature.
+func (b *ContextRequestBuilder) WithPTMSISignature(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("PTMSISignature", i, ie.PTMSISignature) {
+		b.msg.PTMSISignature = i
+	}
+	return b
+}
+
+// WithCompleteTAURequestMessage sets the CompleteRequestMessage IE to CompleteTAURequestMessage.
+func (b *ContextRequestBuilder) WithCompleteTAURequestMessage(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("CompleteTAURequestMessage", i, ie.CompleteRequestMessage) {
+		b.msg.CompleteTAURequestMessage = i
+	}
+	return b
+}
+
+// WithAddressAndTEIDForCPlane sets the FullyQualifiedTEID IE to AddressAndTEIDForCPlane.
+func (b *ContextRequestBuilder) WithAddressAndTEIDForCPlane(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("AddressAndTEIDForCPlane", i, ie.FullyQualifiedTEID) {
+		b.msg.AddressAndTEIDForCPlane = i
+	}
+	return b
+}
+
+// WithUDPSourcePortNumber sets the PortNumber IE to UDPSourcePortNumber.
+func (b *ContextRequestBuilder) WithUDPSourcePortNumber(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("UDPSourcePortNumber", i, ie.PortNumber) {
+		b.msg.UDPSourcePortNumber = i
+	}
+	return b
+}
+
+// WithRATType sets the RATType IE to RATType.
+func (b *ContextRequestBuilder) WithRATType(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("RATType", i, ie.RATType) {
+		b.msg.RATType = i
+	}
+	return b
+}
+
+// WithIndication sets the Indication IE to Indication.
+func (b *ContextRequestBuilder) WithIndication(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("Indication", i, ie.Indication) {
+		b.msg.Indication = i
+	}
+	return b
+}
+
+// WithHopCounter sets the HopCounter IE to HopCounter.
+func (b *ContextRequestBuilder) WithHopCounter(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("HopCounter", i, ie.HopCounter) {
+		b.msg.HopCounter = i
+	}
+	return b
+}
+
+// WithTargetPLMNID sets the ServingNetwork IE to TargetPLMNID.
+func (b *ContextRequestBuilder) WithTargetPLMNID(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("TargetPLMNID", i, ie.ServingNetwork) {
+		b.msg.TargetPLMNID = i
+	}
+	return b
+}
+
+// WithMMESGSNLDN sets the LocalDistinguishedName IE to MMESGSNLDN.
+func (b *ContextRequestBuilder) WithMMESGSNLDN(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("MMESGSNLDN", i, ie.LocalDistinguishedName) {
+		b.msg.MMESGSNLDN = i
+	}
+	return b
+}
+
+// WithSGSNNodeName sets the FullyQualifiedDomainName IE to SGSNNodeName, with the instance set to 0.
+func (b *ContextRequestBuilder) WithSGSNNodeName(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("SGSNNodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.SGSNNodeName = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMENodeName sets the FullyQualifiedDomainName IE to MMENodeName, with the instance set to 1.
+func (b *ContextRequestBuilder) WithMMENodeName(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("MMENodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.MMENodeName = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGSNNumber sets the NodeNumber IE to SGSNNumber.
+func (b *ContextRequestBuilder) WithSGSNNumber(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("SGSNNumber", i, ie.NodeNumber) {
+		b.msg.SGSNNumber = i
+	}
+	return b
+}
+
+// WithSGSNIdentifier sets the NodeIdentifier IE to SGSNIdentifier, with the instance set to 0.
+func (b *ContextRequestBuilder) WithSGSNIdentifier(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("SGSNIdentifier", i, ie.NodeIdentifier) {
+		b.msg.SGSNIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMEIdentifier sets the NodeIdentifier IE to MMEIdentifier, with the instance set to 1.
+func (b *ContextRequestBuilder) WithMMEIdentifier(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("MMEIdentifier", i, ie.NodeIdentifier) {
+		b.msg.MMEIdentifier = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithCIoTOptimizationsSupportIndication sets the CIoTOptimizationsSupportIndication IE to CIoTOptimizationsSupportIndication.
+func (b *ContextRequestBuilder) WithCIoTOptimizationsSupportIndication(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("CIoTOptimizationsSupportIndication", i, ie.CIoTOptimizationsSupportIndication) {
+		b.msg.CIoTOptimizationsSupportIndication = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ContextRequestBuilder) WithPrivateExtension(i *ie.IE) *ContextRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewContextRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ContextRequestBuilder) WithIEs(ies ...*ie.IE) *ContextRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ContextRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *ContextRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ContextRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ContextRequestBuilder) Build() (*ContextRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
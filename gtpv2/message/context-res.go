@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -58,113 +60,178 @@ func NewContextResponse(teid, seq uint32, ies ...*ie.IE) *ContextResponse {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MMContextEPSSecurityContextQuadrupletsAndQuintuplets,
-			ie.MMContextGSMKeyAndTriplets, ie.MMContextGSMKeyUsedCipherAndQuintuplets,
-			ie.MMContextUMTSKeyAndQuintuplets, ie.MMContextUMTSKeyQuadrupletsAndQuintuplets,
-			ie.MMContextUMTSKeyUsedCipherAndQuintuplets:
-			if c.UEMMContext != nil {
-				c.UEMMContext = i
-			} else {
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PDNConnection:
-			c.UEPDNConnections = append(c.UEPDNConnections, i)
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEID = i
-			case 1:
-				c.SGWS11S4FTEID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FullyQualifiedDomainName:
-			switch i.Instance() {
-			case 0:
-				c.SGWNodeName = i
-			case 1:
-				c.SGSNNodeName = i
-			case 2:
-				c.MMENodeName = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.TraceInformation:
-			c.TraceInformation = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.S101IPAddress = i
-			case 1:
-				c.S102IPAddress = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.RFSPIndex:
-			switch i.Instance() {
-			case 0:
-				c.SubscribedRFSPIndex = i
-			case 1:
-				c.RFSPIndexInUse = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.LocalDistinguishedName:
-			c.MMESGSNLDN = i
-		case ie.MDTConfiguration:
-			c.MDTConfiguration = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.MonitoringEventInformation:
-			c.MonitoringEventInformation = i
-		case ie.MonitoringEventExtensionInformation:
-			c.MonitoringEventExtensionInformation = i
-		case ie.IntegerNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUsageType = i
-			case 1:
-				c.RemainingRunningServiceGapTimer = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.SCEFPDNConnection:
-			c.SCEFPDNConnection = append(c.SCEFPDNConnection, i)
-		case ie.RATType:
-			c.RATType = i
-		case ie.ServingPLMNRateControl:
-			c.ServingPLMNRateControl = i
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.ExtendedTraceInformation:
-			c.ExtendedTraceInformation = i
-		case ie.AdditionalRRMPolicyIndex:
-			switch i.Instance() {
-			case 0:
-				c.SubscribedAdditionalRRMPolicyIndex = i
-			case 1:
-				c.AdditionalRRMPolicyIndexInUse = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		c.setIE(i)
+	}
+
+	c.SetLength()
+	return c
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *ContextResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		c.Cause = i
+	case ie.IMSI:
+		c.IMSI = i
+	case ie.MMContextEPSSecurityContextQuadrupletsAndQuintuplets, ie.MMContextGSMKeyAndTriplets, ie.MMContextGSMKeyUsedCipherAndQuintuplets, ie.MMContextUMTSKeyAndQuintuplets, ie.MMContextUMTSKeyQuadrupletsAndQuintuplets, ie.MMContextUMTSKeyUsedCipherAndQuintuplets:
+		c.UEMMContext = i
+	case ie.PDNConnection:
+		c.UEPDNConnections = append(c.UEPDNConnections, i)
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0:
+			c.SenderFTEID = i
+		case 1:
+			c.SGWS11S4FTEID = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.FullyQualifiedDomainName:
+		switch i.Instance() {
+		case 0:
+			c.SGWNodeName = i
+		case 1:
+			c.SGSNNodeName = i
+		case 2:
+			c.MMENodeName = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.TraceInformation:
+		c.TraceInformation = i
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			c.S101IPAddress = i
+		case 1:
+			c.S102IPAddress = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.RFSPIndex:
+		switch i.Instance() {
+		case 0:
+			c.SubscribedRFSPIndex = i
+		case 1:
+			c.RFSPIndexInUse = i
 		default:
 			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.UETimeZone:
+		c.UETimeZone = i
+	case ie.LocalDistinguishedName:
+		c.MMESGSNLDN = i
+	case ie.MDTConfiguration:
+		c.MDTConfiguration = i
+	case ie.UserCSGInformation:
+		c.UCI = i
+	case ie.MonitoringEventInformation:
+		c.MonitoringEventInformation = i
+	case ie.MonitoringEventExtensionInformation:
+		c.MonitoringEventExtensionInformation = i
+	case ie.IntegerNumber:
+		switch i.Instance() {
+		case 0:
+			c.UEUsageType = i
+		case 1:
+			c.RemainingRunningServiceGapTimer = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.SCEFPDNConnection:
+		c.SCEFPDNConnection = append(c.SCEFPDNConnection, i)
+	case ie.RATType:
+		c.RATType = i
+	case ie.ServingPLMNRateControl:
+		c.ServingPLMNRateControl = i
+	case ie.Counter:
+		c.MOExceptionDataCounter = i
+	case ie.ExtendedTraceInformation:
+		c.ExtendedTraceInformation = i
+	case ie.AdditionalRRMPolicyIndex:
+		switch i.Instance() {
+		case 0:
+			c.SubscribedAdditionalRRMPolicyIndex = i
+		case 1:
+			c.AdditionalRRMPolicyIndexInUse = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
 		}
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	c.SetLength()
-	return c
+// ieKind returns the reason why the IE does not belong to ContextResponse, or 0
+// if it is set to one of the fields.
+func (c *ContextResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.IMSI, ie.MMContextEPSSecurityContextQuadrupletsAndQuintuplets, ie.MMContextGSMKeyAndTriplets,
+		ie.MMContextGSMKeyUsedCipherAndQuintuplets, ie.MMContextUMTSKeyAndQuintuplets, ie.MMContextUMTSKeyQuadrupletsAndQuintuplets, ie.MMContextUMTSKeyUsedCipherAndQuintuplets,
+		ie.PDNConnection, ie.Indication, ie.TraceInformation, ie.UETimeZone,
+		ie.LocalDistinguishedName, ie.MDTConfiguration, ie.UserCSGInformation, ie.MonitoringEventInformation,
+		ie.MonitoringEventExtensionInformation, ie.SCEFPDNConnection, ie.RATType, ie.ServingPLMNRateControl,
+		ie.Counter, ie.ExtendedTraceInformation, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedDomainName:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.RFSPIndex:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IntegerNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.AdditionalRRMPolicyIndex:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ContextResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *ContextResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Context Response", c.AdditionalIEs, c.ieKind)
 }
 
 // Marshal serializes ContextResponse into bytes.
@@ -185,169 +252,193 @@ func (c *ContextResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UEMMContext; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.UEPDNConnections {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SenderFTEID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWS11S4FTEID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWNodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TraceInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.S101IPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.S102IPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SubscribedRFSPIndex; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RFSPIndexInUse; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MDTConfiguration; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGSNNodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMENodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UCI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MonitoringEventInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+	if ie := c.MonitoringEventExtensionInformation; ie != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UEUsageType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.SCEFPDNConnection {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RATType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ServingPLMNRateControl; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MOExceptionDataCounter; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RemainingRunningServiceGapTimer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ExtendedTraceInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+	if ie := c.SubscribedAdditionalRRMPolicyIndex; ie != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+	if ie := c.AdditionalRRMPolicyIndexInUse; ie != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -395,98 +486,7 @@ func (c *ContextResponse) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MMContextEPSSecurityContextQuadrupletsAndQuintuplets,
-			ie.MMContextGSMKeyAndTriplets, ie.MMContextGSMKeyUsedCipherAndQuintuplets,
-			ie.MMContextUMTSKeyAndQuintuplets, ie.MMContextUMTSKeyQuadrupletsAndQuintuplets,
-			ie.MMContextUMTSKeyUsedCipherAndQuintuplets:
-			if c.UEMMContext != nil {
-				c.UEMMContext = i
-			} else {
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PDNConnection:
-			c.UEPDNConnections = append(c.UEPDNConnections, i)
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEID = i
-			case 1:
-				c.SGWS11S4FTEID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FullyQualifiedDomainName:
-			switch i.Instance() {
-			case 0:
-				c.SGWNodeName = i
-			case 1:
-				c.SGSNNodeName = i
-			case 2:
-				c.MMENodeName = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.TraceInformation:
-			c.TraceInformation = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.S101IPAddress = i
-			case 1:
-				c.S102IPAddress = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.RFSPIndex:
-			switch i.Instance() {
-			case 0:
-				c.SubscribedRFSPIndex = i
-			case 1:
-				c.RFSPIndexInUse = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.LocalDistinguishedName:
-			c.MMESGSNLDN = i
-		case ie.MDTConfiguration:
-			c.MDTConfiguration = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.MonitoringEventInformation:
-			c.MonitoringEventInformation = i
-		case ie.IntegerNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUsageType = i
-			case 1:
-				c.RemainingRunningServiceGapTimer = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.SCEFPDNConnection:
-			c.SCEFPDNConnection = append(c.SCEFPDNConnection, i)
-		case ie.RATType:
-			c.RATType = i
-		case ie.ServingPLMNRateControl:
-			c.ServingPLMNRateControl = i
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.ExtendedTraceInformation:
-			c.ExtendedTraceInformation = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -506,6 +506,9 @@ func (c *ContextResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.UEPDNConnections {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.SenderFTEID; ie != nil {
@@ -556,10 +559,16 @@ func (c *ContextResponse) MarshalLen() int {
 	if ie := c.MonitoringEventInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
+	if ie := c.MonitoringEventExtensionInformation; ie != nil {
+		l += ie.MarshalLen()
+	}
 	if ie := c.UEUsageType; ie != nil {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.SCEFPDNConnection {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.RATType; ie != nil {
@@ -577,6 +586,12 @@ func (c *ContextResponse) MarshalLen() int {
 	if ie := c.ExtendedTraceInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
+	if ie := c.SubscribedAdditionalRRMPolicyIndex; ie != nil {
+		l += ie.MarshalLen()
+	}
+	if ie := c.AdditionalRRMPolicyIndexInUse; ie != nil {
+		l += ie.MarshalLen()
+	}
 	if ie := c.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -604,3 +619,300 @@ func (c *ContextResponse) MessageTypeName() string {
 func (c *ContextResponse) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// ContextResponseBuilder builds ContextResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ContextResponseBuilder struct {
+	builder
+	msg *ContextResponse
+}
+
+// BuildContextResponse returns the builder of ContextResponse.
+func BuildContextResponse(teid, seq uint32) *ContextResponseBuilder {
+	return &ContextResponseBuilder{
+		builder: builder{msgType: "Context Response"},
+		msg:     NewContextResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ContextResponseBuilder) WithCause(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ContextResponseBuilder) WithIMSI(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithUEMMContext sets the MMContextEPSSecurityContextQuadrupletsAndQuintuplets or MMContextGSMKeyAndTriplets or MMContextGSMKeyUsedCipherAndQuintuplets or MMContextUMTSKeyAndQuintuplets or MMContextUMTSKeyQuadrupletsAndQuintuplets or MMContextUMTSKeyUsedCipherAndQuintuplets IE to UEMMContext.
+func (b *ContextResponseBuilder) WithUEMMContext(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("UEMMContext", i, ie.MMContextEPSSecurityContextQuadrupletsAndQuintuplets, ie.MMContextGSMKeyAndTriplets, ie.MMContextGSMKeyUsedCipherAndQuintuplets, ie.MMContextUMTSKeyAndQuintuplets, ie.MMContextUMTSKeyQuadrupletsAndQuintuplets, ie.MMContextUMTSKeyUsedCipherAndQuintuplets) {
+		b.msg.UEMMContext = i
+	}
+	return b
+}
+
+// WithUEPDNConnections appends the PDNConnection IEs to UEPDNConnections.
+func (b *ContextResponseBuilder) WithUEPDNConnections(ies ...*ie.IE) *ContextResponseBuilder {
+	for _, i := range ies {
+		if b.expect("UEPDNConnections", i, ie.PDNConnection) {
+			b.msg.UEPDNConnections = append(b.msg.UEPDNConnections, i)
+		}
+	}
+	return b
+}
+
+// WithSenderFTEID sets the FullyQualifiedTEID IE to SenderFTEID, with the instance set to 0.
+func (b *ContextResponseBuilder) WithSenderFTEID(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SenderFTEID", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWS11S4FTEID sets the FullyQualifiedTEID IE to SGWS11S4FTEID, with the instance set to 1.
+func (b *ContextResponseBuilder) WithSGWS11S4FTEID(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SGWS11S4FTEID", i, ie.FullyQualifiedTEID) {
+		b.msg.SGWS11S4FTEID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeName sets the FullyQualifiedDomainName IE to SGWNodeName, with the instance set to 0.
+func (b *ContextResponseBuilder) WithSGWNodeName(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SGWNodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.SGWNodeName = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ContextResponseBuilder) WithIndicationFlags(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithTraceInformation sets the TraceInformation IE to TraceInformation.
+func (b *ContextResponseBuilder) WithTraceInformation(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("TraceInformation", i, ie.TraceInformation) {
+		b.msg.TraceInformation = i
+	}
+	return b
+}
+
+// WithS101IPAddress sets the IPAddress IE to S101IPAddress, with the instance set to 0.
+func (b *ContextResponseBuilder) WithS101IPAddress(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("S101IPAddress", i, ie.IPAddress) {
+		b.msg.S101IPAddress = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithS102IPAddress sets the IPAddress IE to S102IPAddress, with the instance set to 1.
+func (b *ContextResponseBuilder) WithS102IPAddress(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("S102IPAddress", i, ie.IPAddress) {
+		b.msg.S102IPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSubscribedRFSPIndex sets the RFSPIndex IE to SubscribedRFSPIndex, with the instance set to 0.
+func (b *ContextResponseBuilder) WithSubscribedRFSPIndex(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SubscribedRFSPIndex", i, ie.RFSPIndex) {
+		b.msg.SubscribedRFSPIndex = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithRFSPIndexInUse sets the RFSPIndex IE to RFSPIndexInUse, with the instance set to 1.
+func (b *ContextResponseBuilder) WithRFSPIndexInUse(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("RFSPIndexInUse", i, ie.RFSPIndex) {
+		b.msg.RFSPIndexInUse = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *ContextResponseBuilder) WithUETimeZone(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithMMESGSNLDN sets the LocalDistinguishedName IE to MMESGSNLDN.
+func (b *ContextResponseBuilder) WithMMESGSNLDN(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MMESGSNLDN", i, ie.LocalDistinguishedName) {
+		b.msg.MMESGSNLDN = i
+	}
+	return b
+}
+
+// WithMDTConfiguration sets the MDTConfiguration IE to MDTConfiguration.
+func (b *ContextResponseBuilder) WithMDTConfiguration(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MDTConfiguration", i, ie.MDTConfiguration) {
+		b.msg.MDTConfiguration = i
+	}
+	return b
+}
+
+// WithSGSNNodeName sets the FullyQualifiedDomainName IE to SGSNNodeName, with the instance set to 1.
+func (b *ContextResponseBuilder) WithSGSNNodeName(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SGSNNodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.SGSNNodeName = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithMMENodeName sets the FullyQualifiedDomainName IE to MMENodeName, with the instance set to 2.
+func (b *ContextResponseBuilder) WithMMENodeName(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MMENodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.MMENodeName = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithUCI sets the UserCSGInformation IE to UCI.
+func (b *ContextResponseBuilder) WithUCI(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("UCI", i, ie.UserCSGInformation) {
+		b.msg.UCI = i
+	}
+	return b
+}
+
+// WithMonitoringEventInformation sets the MonitoringEventInformation IE to MonitoringEventInformation.
+func (b *ContextResponseBuilder) WithMonitoringEventInformation(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MonitoringEventInformation", i, ie.MonitoringEventInformation) {
+		b.msg.MonitoringEventInformation = i
+	}
+	return b
+}
+
+// WithMonitoringEventExtensionInformation sets the MonitoringEventExtensionInformation IE to MonitoringEventExtensionInformation.
+func (b *ContextResponseBuilder) WithMonitoringEventExtensionInformation(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MonitoringEventExtensionInformation", i, ie.MonitoringEventExtensionInformation) {
+		b.msg.MonitoringEventExtensionInformation = i
+	}
+	return b
+}
+
+// WithUEUsageType sets the IntegerNumber IE to UEUsageType, with the instance set to 0.
+func (b *ContextResponseBuilder) WithUEUsageType(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("UEUsageType", i, ie.IntegerNumber) {
+		b.msg.UEUsageType = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSCEFPDNConnection appends the SCEFPDNConnection IEs to SCEFPDNConnection.
+func (b *ContextResponseBuilder) WithSCEFPDNConnection(ies ...*ie.IE) *ContextResponseBuilder {
+	for _, i := range ies {
+		if b.expect("SCEFPDNConnection", i, ie.SCEFPDNConnection) {
+			b.msg.SCEFPDNConnection = append(b.msg.SCEFPDNConnection, i)
+		}
+	}
+	return b
+}
+
+// WithRATType sets the RATType IE to RATType.
+func (b *ContextResponseBuilder) WithRATType(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("RATType", i, ie.RATType) {
+		b.msg.RATType = i
+	}
+	return b
+}
+
+// WithServingPLMNRateControl sets the ServingPLMNRateControl IE to ServingPLMNRateControl.
+func (b *ContextResponseBuilder) WithServingPLMNRateControl(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("ServingPLMNRateControl", i, ie.ServingPLMNRateControl) {
+		b.msg.ServingPLMNRateControl = i
+	}
+	return b
+}
+
+// WithMOExceptionDataCounter sets the Counter IE to MOExceptionDataCounter.
+func (b *ContextResponseBuilder) WithMOExceptionDataCounter(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("MOExceptionDataCounter", i, ie.Counter) {
+		b.msg.MOExceptionDataCounter = i
+	}
+	return b
+}
+
+// WithRemainingRunningServiceGapTimer sets the IntegerNumber IE to RemainingRunningServiceGapTimer, with the instance set to 1.
+func (b *ContextResponseBuilder) WithRemainingRunningServiceGapTimer(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("RemainingRunningServiceGapTimer", i, ie.IntegerNumber) {
+		b.msg.RemainingRunningServiceGapTimer = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithExtendedTraceInformation sets the ExtendedTraceInformation IE to ExtendedTraceInformation.
+func (b *ContextResponseBuilder) WithExtendedTraceInformation(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("ExtendedTraceInformation", i, ie.ExtendedTraceInformation) {
+		b.msg.ExtendedTraceInformation = i
+	}
+	return b
+}
+
+// WithSubscribedAdditionalRRMPolicyIndex sets the AdditionalRRMPolicyIndex IE to SubscribedAdditionalRRMPolicyIndex, with the instance set to 0.
+func (b *ContextResponseBuilder) WithSubscribedAdditionalRRMPolicyIndex(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("SubscribedAdditionalRRMPolicyIndex", i, ie.AdditionalRRMPolicyIndex) {
+		b.msg.SubscribedAdditionalRRMPolicyIndex = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithAdditionalRRMPolicyIndexInUse sets the AdditionalRRMPolicyIndex IE to AdditionalRRMPolicyIndexInUse, with the instance set to 1.
+func (b *ContextResponseBuilder) WithAdditionalRRMPolicyIndexInUse(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("AdditionalRRMPolicyIndexInUse", i, ie.AdditionalRRMPolicyIndex) {
+		b.msg.AdditionalRRMPolicyIndexInUse = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ContextResponseBuilder) WithPrivateExtension(i *ie.IE) *ContextResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewContextResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ContextResponseBuilder) WithIEs(ies ...*ie.IE) *ContextResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ContextResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *ContextResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ContextResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ContextResponseBuilder) Build() (*ContextResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -45,65 +47,113 @@ func NewCreateBearerRequest(teid, seq uint32, ies ...*ie.IE) *CreateBearerReques
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.ProcedureTransactionID:
-			c.PTI = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		c.setIE(i)
+	}
+
+	c.SetLength()
+	return c
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *CreateBearerRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.ProcedureTransactionID:
+		c.PTI = i
+	case ie.EPSBearerID:
+		c.LinkedEBI = i
+	case ie.ProtocolConfigurationOptions:
+		c.PCO = i
+	case ie.BearerContext:
+		c.BearerContexts = append(c.BearerContexts, i)
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			c.PGWFQCSID = i
+		case 1:
+			c.SGWFQCSID = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.ChangeReportingAction:
+		c.ChangeReportingAction = i
+	case ie.CSGInformationReportingAction:
+		c.CSGInformationReportingAction = i
+	case ie.HeNBInformationReporting:
+		c.HeNBInformationReporting = i
+	case ie.PresenceReportingAreaAction:
+		c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.PGWNodeLoadControlInformation = i
+		case 1:
+			c.PGWAPNLoadControlInformation = i
+		case 2:
+			c.SGWNodeLoadControlInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.PGWOverloadControlInformation = i
+		case 1:
+			c.SGWOverloadControlInformation = i
 		default:
 			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		c.NBIFOMContainer = i
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to CreateBearerRequest, or 0
+// if it is set to one of the fields.
+func (c *CreateBearerRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.ProcedureTransactionID, ie.EPSBearerID, ie.ProtocolConfigurationOptions, ie.BearerContext,
+		ie.ChangeReportingAction, ie.CSGInformationReportingAction, ie.HeNBInformationReporting, ie.PresenceReportingAreaAction,
+		ie.Indication, ie.FContainer, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
 		}
+		return IEUnexpectedInstance
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// CreateBearerRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *CreateBearerRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Create Bearer Request", c.AdditionalIEs, c.ieKind)
 }
 
 // Marshal serializes CreateBearerRequest into bytes.
@@ -124,109 +174,115 @@ func (c *CreateBearerRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.PTI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChangeReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CSGInformationReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.HeNBInformationReporting; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -271,61 +327,10 @@ func (c *CreateBearerRequest) UnmarshalBinary(b []byte) error {
 		return err
 	}
 	for _, i := range decodedIEs {
-		switch i.Type {
-		case ie.ProcedureTransactionID:
-			c.PTI = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+		if i == nil {
+			continue
 		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -345,6 +350,9 @@ func (c *CreateBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.PGWFQCSID; ie != nil {
@@ -363,6 +371,9 @@ func (c *CreateBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
@@ -413,3 +424,196 @@ func (c *CreateBearerRequest) MessageTypeName() string {
 func (c *CreateBearerRequest) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// CreateBearerRequestBuilder builds CreateBearerRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type CreateBearerRequestBuilder struct {
+	builder
+	msg *CreateBearerRequest
+}
+
+// BuildCreateBearerRequest returns the builder of CreateBearerRequest.
+func BuildCreateBearerRequest(teid, seq uint32) *CreateBearerRequestBuilder {
+	return &CreateBearerRequestBuilder{
+		builder: builder{msgType: "Create Bearer Request"},
+		msg:     NewCreateBearerRequest(teid, seq),
+	}
+}
+
+// WithPTI sets the ProcedureTransactionID IE to PTI.
+func (b *CreateBearerRequestBuilder) WithPTI(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PTI", i, ie.ProcedureTransactionID) {
+		b.msg.PTI = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *CreateBearerRequestBuilder) WithLinkedEBI(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *CreateBearerRequestBuilder) WithPCO(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *CreateBearerRequestBuilder) WithBearerContexts(ies ...*ie.IE) *CreateBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 0.
+func (b *CreateBearerRequestBuilder) WithPGWFQCSID(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *CreateBearerRequestBuilder) WithSGWFQCSID(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithChangeReportingAction sets the ChangeReportingAction IE to ChangeReportingAction.
+func (b *CreateBearerRequestBuilder) WithChangeReportingAction(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("ChangeReportingAction", i, ie.ChangeReportingAction) {
+		b.msg.ChangeReportingAction = i
+	}
+	return b
+}
+
+// WithCSGInformationReportingAction sets the CSGInformationReportingAction IE to CSGInformationReportingAction.
+func (b *CreateBearerRequestBuilder) WithCSGInformationReportingAction(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("CSGInformationReportingAction", i, ie.CSGInformationReportingAction) {
+		b.msg.CSGInformationReportingAction = i
+	}
+	return b
+}
+
+// WithHeNBInformationReporting sets the HeNBInformationReporting IE to HeNBInformationReporting.
+func (b *CreateBearerRequestBuilder) WithHeNBInformationReporting(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("HeNBInformationReporting", i, ie.HeNBInformationReporting) {
+		b.msg.HeNBInformationReporting = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaAction appends the PresenceReportingAreaAction IEs to PresenceReportingAreaAction.
+func (b *CreateBearerRequestBuilder) WithPresenceReportingAreaAction(ies ...*ie.IE) *CreateBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAreaAction = append(b.msg.PresenceReportingAreaAction, i)
+		}
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *CreateBearerRequestBuilder) WithIndicationFlags(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 0.
+func (b *CreateBearerRequestBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 1.
+func (b *CreateBearerRequestBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 2.
+func (b *CreateBearerRequestBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *CreateBearerRequestBuilder) WithPGWOverloadControlInformation(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *CreateBearerRequestBuilder) WithSGWOverloadControlInformation(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *CreateBearerRequestBuilder) WithNBIFOMContainer(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *CreateBearerRequestBuilder) WithPrivateExtension(i *ie.IE) *CreateBearerRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewCreateBearerRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *CreateBearerRequestBuilder) WithIEs(ies ...*ie.IE) *CreateBearerRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *CreateBearerRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *CreateBearerRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the CreateBearerRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *CreateBearerRequestBuilder) Build() (*CreateBearerRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
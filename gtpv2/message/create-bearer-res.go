@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -50,88 +52,159 @@ func NewCreateBearerResponse(teid, seq uint32, ies ...*ie.IE) *CreateBearerRespo
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 1:
-				c.WLANLocationTimestamp = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMEOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAction = append(c.PresenceReportingAction, i)
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNIdentifier = i
-			case 1:
-				c.UELocalIPAddress = i
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.UETCPPort = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		c.setIE(i)
+	}
+
+	c.SetLength()
+	return c
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *CreateBearerResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		c.Cause = i
+	case ie.BearerContext:
+		c.BearerContexts = append(c.BearerContexts, i)
+	case ie.Recovery:
+		c.Recovery = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			c.MMEFQCSID = i
+		case 1:
+			c.SGWFQCSID = i
+		case 2:
+			c.EPDGFQCSID = i
+		case 3:
+			c.TWANFQCSID = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.ProtocolConfigurationOptions:
+		c.PCO = i
+	case ie.UETimeZone:
+		c.UETimeZone = i
+	case ie.UserLocationInformation:
+		c.ULI = i
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0:
+			c.TWANIdentifier = i
+		case 1:
+			c.WLANLocationInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.MMEOverloadControlInformation = i
+		case 1:
+			c.SGWOverloadControlInformation = i
+		case 2:
+			c.TWANePDGOverloadControlInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.PresenceReportingAreaAction:
+		c.PresenceReportingAction = append(c.PresenceReportingAction, i)
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			c.MMESGSNIdentifier = i
+		case 1:
+			c.UELocalIPAddress = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 1:
+			c.WLANLocationTimestamp = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			c.UEUDPPort = i
+		case 1:
+			c.UETCPPort = i
 		default:
 			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		c.NBIFOMContainer = i
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to CreateBearerResponse, or 0
+// if it is set to one of the fields.
+func (c *CreateBearerResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.BearerContext, ie.Recovery, ie.ProtocolConfigurationOptions,
+		ie.UETimeZone, ie.UserLocationInformation, ie.PresenceReportingAreaAction, ie.FContainer,
+		ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
 		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// CreateBearerResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *CreateBearerResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Create Bearer Response", c.AdditionalIEs, c.ieKind)
 }
 
 // Marshal serializes CreateBearerResponse into bytes.
@@ -152,139 +225,145 @@ func (c *CreateBearerResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.Recovery; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPDGFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ULI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMEOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANePDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.WLANLocationTimestamp; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UETCPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -328,78 +407,11 @@ func (c *CreateBearerResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			}
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			}
-		case ie.TWANIdentifierTimestamp:
-			if i.Instance() == 1 {
-				c.WLANLocationTimestamp = i
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMEOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAction = append(c.PresenceReportingAction, i)
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNIdentifier = i
-			case 1:
-				c.UELocalIPAddress = i
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.UETCPPort = i
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -413,6 +425,9 @@ func (c *CreateBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.Recovery; ie != nil {
@@ -449,6 +464,9 @@ func (c *CreateBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNIdentifier; ie != nil {
@@ -502,3 +520,236 @@ func (c *CreateBearerResponse) MessageTypeName() string {
 func (c *CreateBearerResponse) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// CreateBearerResponseBuilder builds CreateBearerResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type CreateBearerResponseBuilder struct {
+	builder
+	msg *CreateBearerResponse
+}
+
+// BuildCreateBearerResponse returns the builder of CreateBearerResponse.
+func BuildCreateBearerResponse(teid, seq uint32) *CreateBearerResponseBuilder {
+	return &CreateBearerResponseBuilder{
+		builder: builder{msgType: "Create Bearer Response"},
+		msg:     NewCreateBearerResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *CreateBearerResponseBuilder) WithCause(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *CreateBearerResponseBuilder) WithBearerContexts(ies ...*ie.IE) *CreateBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *CreateBearerResponseBuilder) WithRecovery(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *CreateBearerResponseBuilder) WithMMEFQCSID(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithSGWFQCSID(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGFQCSID sets the FullyQualifiedCSID IE to EPDGFQCSID, with the instance set to 2.
+func (b *CreateBearerResponseBuilder) WithEPDGFQCSID(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("EPDGFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.EPDGFQCSID = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANFQCSID sets the FullyQualifiedCSID IE to TWANFQCSID, with the instance set to 3.
+func (b *CreateBearerResponseBuilder) WithTWANFQCSID(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("TWANFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.TWANFQCSID = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *CreateBearerResponseBuilder) WithPCO(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *CreateBearerResponseBuilder) WithUETimeZone(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *CreateBearerResponseBuilder) WithULI(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithTWANIdentifier sets the TWANIdentifier IE to TWANIdentifier, with the instance set to 0.
+func (b *CreateBearerResponseBuilder) WithTWANIdentifier(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("TWANIdentifier", i, ie.TWANIdentifier) {
+		b.msg.TWANIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMEOverloadControlInformation sets the OverloadControlInformation IE to MMEOverloadControlInformation, with the instance set to 0.
+func (b *CreateBearerResponseBuilder) WithMMEOverloadControlInformation(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("MMEOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMEOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPresenceReportingAction appends the PresenceReportingAreaAction IEs to PresenceReportingAction.
+func (b *CreateBearerResponseBuilder) WithPresenceReportingAction(ies ...*ie.IE) *CreateBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAction = append(b.msg.PresenceReportingAction, i)
+		}
+	}
+	return b
+}
+
+// WithMMESGSNIdentifier sets the IPAddress IE to MMESGSNIdentifier, with the instance set to 0.
+func (b *CreateBearerResponseBuilder) WithMMESGSNIdentifier(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("MMESGSNIdentifier", i, ie.IPAddress) {
+		b.msg.MMESGSNIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformation sets the OverloadControlInformation IE to TWANePDGOverloadControlInformation, with the instance set to 2.
+func (b *CreateBearerResponseBuilder) WithTWANePDGOverloadControlInformation(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("TWANePDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithWLANLocationInformation(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationTimestamp sets the TWANIdentifierTimestamp IE to WLANLocationTimestamp, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithWLANLocationTimestamp(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("WLANLocationTimestamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimestamp = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithUELocalIPAddress(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *CreateBearerResponseBuilder) WithUEUDPPort(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *CreateBearerResponseBuilder) WithNBIFOMContainer(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithUETCPPort sets the PortNumber IE to UETCPPort, with the instance set to 1.
+func (b *CreateBearerResponseBuilder) WithUETCPPort(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("UETCPPort", i, ie.PortNumber) {
+		b.msg.UETCPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *CreateBearerResponseBuilder) WithPrivateExtension(i *ie.IE) *CreateBearerResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewCreateBearerResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *CreateBearerResponseBuilder) WithIEs(ies ...*ie.IE) *CreateBearerResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *CreateBearerResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *CreateBearerResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the CreateBearerResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *CreateBearerResponseBuilder) Build() (*CreateBearerResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -92,189 +94,288 @@ func NewCreateSessionRequest(teid, seq uint32, ies ...*ie.IE) *CreateSessionRequ
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MSISDN:
-			c.MSISDN = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.UserLocationInformation:
-			switch i.Instance() {
-			case 0:
-				c.ULI = i
-			case 1:
-				c.ULIForSGW = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.ServingNetwork:
-			c.ServingNetwork = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEIDC = i
-			case 1:
-				c.PGWS5S8FTEIDC = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.AccessPointName:
-			c.APN = i
-		case ie.SelectionMode:
-			c.SelectionMode = i
-		case ie.PDNType:
-			c.PDNType = i
-		case ie.PDNAddressAllocation:
-			c.PAA = i
-		case ie.APNRestriction:
-			c.APNRestriction = i
-		case ie.AggregateMaximumBitRate:
-			c.AMBR = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.TrustedWLANModeIndication:
-			c.TWMI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				c.BearerContextsToBeCreated = append(c.BearerContextsToBeCreated, i)
-			case 1:
-				c.BearerContextsToBeRemoved = append(c.BearerContextsToBeRemoved, i)
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.TraceInformation:
-			c.TraceInformation = i
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.ChargingCharacteristics:
-			c.ChargingCharacteristics = i
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNLDN = i
-			case 1:
-				c.SGWLDN = i
-			case 2:
-				c.EPDGLDN = i
-			case 3:
-				c.TWANLDN = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.SignallingPriorityIndication:
-			c.SignallingPriorityIndication = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.UELocalIPAddress = i
-			case 1:
-				c.HeNBLocalIPAddress = i
-			case 2:
-				c.MMESGSNIdentifier = i
-			case 3:
-				c.EPDGIPAddress = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.HeNBUDPPort = i
-			case 2:
-				c.UETCPPort = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.AdditionalProtocolConfigurationOptions:
-			c.APCO = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			}
-		case ie.CNOperatorSelectionEntity:
-			c.CNOperatorSelectionEntity = i
-		case ie.PresenceReportingAreaInformation:
-			c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.MillisecondTimeStamp:
-			c.OriginationTimeStamp = i
-		case ie.IntegerNumber:
-			c.MaximumWaitTime = i
-		case ie.TWANIdentifierTimestamp:
-			c.WLANLocationTimeStamp = i
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.RemoteUEContext:
-			c.RemoteUEContextConnected = append(c.RemoteUEContextConnected, i)
-		case ie.NodeIdentifier:
-			c.TGPPAAAServerIdentifier = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			c.EPCO = i
-		case ie.ServingPLMNRateControl:
-			c.ServingPLMNRateControl = i
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.MappedUEUsageType:
-			c.MappedUEUsageType = i
-		case ie.FullyQualifiedDomainName:
-			c.SGWUNodeName = i
-		case ie.SecondaryRATUsageDataReport:
-			c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
-		case ie.UPFunctionSelectionIndicationFlags:
-			c.UPFunctionSelectionIndicationFlags = i
-		case ie.APNRateControlStatus:
-			c.APNRateControlStatus = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	c.SetLength()
 	return c
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *CreateSessionRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		c.IMSI = i
+	case ie.MSISDN:
+		c.MSISDN = i
+	case ie.MobileEquipmentIdentity:
+		c.MEI = i
+	case ie.UserLocationInformation:
+		switch i.Instance() {
+		case 0:
+			c.ULI = i
+		case 1:
+			c.ULIForSGW = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.ServingNetwork:
+		c.ServingNetwork = i
+	case ie.RATType:
+		c.RATType = i
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0:
+			c.SenderFTEIDC = i
+		case 1:
+			c.PGWS5S8FTEIDC = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.AccessPointName:
+		c.APN = i
+	case ie.SelectionMode:
+		c.SelectionMode = i
+	case ie.PDNType:
+		c.PDNType = i
+	case ie.PDNAddressAllocation:
+		c.PAA = i
+	case ie.APNRestriction:
+		c.APNRestriction = i
+	case ie.AggregateMaximumBitRate:
+		c.AMBR = i
+	case ie.EPSBearerID:
+		c.LinkedEBI = i
+	case ie.TrustedWLANModeIndication:
+		c.TWMI = i
+	case ie.ProtocolConfigurationOptions:
+		c.PCO = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			c.BearerContextsToBeCreated = append(c.BearerContextsToBeCreated, i)
+		case 1:
+			c.BearerContextsToBeRemoved = append(c.BearerContextsToBeRemoved, i)
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.TraceInformation:
+		c.TraceInformation = i
+	case ie.Recovery:
+		c.Recovery = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			c.MMEFQCSID = i
+		case 1:
+			c.SGWFQCSID = i
+		case 2:
+			c.EPDGFQCSID = i
+		case 3:
+			c.TWANFQCSID = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.UETimeZone:
+		c.UETimeZone = i
+	case ie.UserCSGInformation:
+		c.UCI = i
+	case ie.ChargingCharacteristics:
+		c.ChargingCharacteristics = i
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0:
+			c.MMESGSNLDN = i
+		case 1:
+			c.SGWLDN = i
+		case 2:
+			c.EPDGLDN = i
+		case 3:
+			c.TWANLDN = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.SignallingPriorityIndication:
+		c.SignallingPriorityIndication = i
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			c.UELocalIPAddress = i
+		case 1:
+			c.HeNBLocalIPAddress = i
+		case 2:
+			c.MMESGSNIdentifier = i
+		case 3:
+			c.EPDGIPAddress = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			c.UEUDPPort = i
+		case 1:
+			c.HeNBUDPPort = i
+		case 2:
+			c.UETCPPort = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.AdditionalProtocolConfigurationOptions:
+		c.APCO = i
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0:
+			c.TWANIdentifier = i
+		case 1:
+			c.WLANLocationInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.CNOperatorSelectionEntity:
+		c.CNOperatorSelectionEntity = i
+	case ie.PresenceReportingAreaInformation:
+		c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.MMESGSNOverloadControlInformation = i
+		case 1:
+			c.SGWOverloadControlInformation = i
+		case 2:
+			c.TWANePDGOverloadControlInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.MillisecondTimeStamp:
+		c.OriginationTimeStamp = i
+	case ie.IntegerNumber:
+		c.MaximumWaitTime = i
+	case ie.TWANIdentifierTimestamp:
+		c.WLANLocationTimeStamp = i
+	case ie.FContainer:
+		c.NBIFOMContainer = i
+	case ie.RemoteUEContext:
+		c.RemoteUEContextConnected = append(c.RemoteUEContextConnected, i)
+	case ie.NodeIdentifier:
+		c.TGPPAAAServerIdentifier = i
+	case ie.ExtendedProtocolConfigurationOptions:
+		c.EPCO = i
+	case ie.ServingPLMNRateControl:
+		c.ServingPLMNRateControl = i
+	case ie.Counter:
+		c.MOExceptionDataCounter = i
+	case ie.MappedUEUsageType:
+		c.MappedUEUsageType = i
+	case ie.FullyQualifiedDomainName:
+		c.SGWUNodeName = i
+	case ie.SecondaryRATUsageDataReport:
+		c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
+	case ie.UPFunctionSelectionIndicationFlags:
+		c.UPFunctionSelectionIndicationFlags = i
+	case ie.APNRateControlStatus:
+		c.APNRateControlStatus = i
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to CreateSessionRequest, or 0
+// if it is set to one of the fields.
+func (c *CreateSessionRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.MSISDN, ie.MobileEquipmentIdentity, ie.ServingNetwork,
+		ie.RATType, ie.Indication, ie.AccessPointName, ie.SelectionMode,
+		ie.PDNType, ie.PDNAddressAllocation, ie.APNRestriction, ie.AggregateMaximumBitRate,
+		ie.EPSBearerID, ie.TrustedWLANModeIndication, ie.ProtocolConfigurationOptions, ie.TraceInformation,
+		ie.Recovery, ie.UETimeZone, ie.UserCSGInformation, ie.ChargingCharacteristics,
+		ie.SignallingPriorityIndication, ie.AdditionalProtocolConfigurationOptions, ie.CNOperatorSelectionEntity, ie.PresenceReportingAreaInformation,
+		ie.MillisecondTimeStamp, ie.IntegerNumber, ie.TWANIdentifierTimestamp, ie.FContainer,
+		ie.RemoteUEContext, ie.NodeIdentifier, ie.ExtendedProtocolConfigurationOptions, ie.ServingPLMNRateControl,
+		ie.Counter, ie.MappedUEUsageType, ie.FullyQualifiedDomainName, ie.SecondaryRATUsageDataReport,
+		ie.UPFunctionSelectionIndicationFlags, ie.APNRateControlStatus, ie.PrivateExtension:
+		return 0
+	case ie.UserLocationInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// CreateSessionRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *CreateSessionRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Create Session Request", c.AdditionalIEs, c.ieKind)
+}
+
 // Marshal serializes CreateSessionRequest into bytes.
 func (c *CreateSessionRequest) Marshal() ([]byte, error) {
 	b := make([]byte, c.MarshalLen())
@@ -293,393 +394,409 @@ func (c *CreateSessionRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MSISDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MEI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ULI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ServingNetwork; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.RATType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWS5S8FTEIDC; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SelectionMode; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PDNType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PAA; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APNRestriction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.AMBR; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWMI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsToBeCreated {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsToBeRemoved {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TraceInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.Recovery; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPDGFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UCI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChargingCharacteristics; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPDGLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SignallingPriorityIndication; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.HeNBLocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.HeNBUDPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPDGIPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CNOperatorSelectionEntity; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaInformation {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TWANePDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.OriginationTimeStamp; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MaximumWaitTime; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.WLANLocationTimeStamp; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.RemoteUEContextConnected {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TGPPAAAServerIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ServingPLMNRateControl; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MOExceptionDataCounter; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UETCPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.MappedUEUsageType; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ULIForSGW; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWUNodeName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.UPFunctionSelectionIndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APNRateControlStatus; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
+		offset += ie.MarshalLen()
 	}
 
 	for _, ie := range c.AdditionalIEs {
@@ -720,190 +837,11 @@ func (c *CreateSessionRequest) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.MSISDN:
-			c.MSISDN = i
-		case ie.MobileEquipmentIdentity:
-			c.MEI = i
-		case ie.UserLocationInformation:
-			switch i.Instance() {
-			case 0:
-				c.ULI = i
-			case 1:
-				c.ULIForSGW = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.ServingNetwork:
-			c.ServingNetwork = i
-		case ie.RATType:
-			c.RATType = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEIDC = i
-			case 1:
-				c.PGWS5S8FTEIDC = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.AccessPointName:
-			c.APN = i
-		case ie.SelectionMode:
-			c.SelectionMode = i
-		case ie.PDNType:
-			c.PDNType = i
-		case ie.PDNAddressAllocation:
-			c.PAA = i
-		case ie.APNRestriction:
-			c.APNRestriction = i
-		case ie.AggregateMaximumBitRate:
-			c.AMBR = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.TrustedWLANModeIndication:
-			c.TWMI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				c.BearerContextsToBeCreated = append(c.BearerContextsToBeCreated, i)
-			case 1:
-				c.BearerContextsToBeRemoved = append(c.BearerContextsToBeRemoved, i)
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.TraceInformation:
-			c.TraceInformation = i
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserCSGInformation:
-			c.UCI = i
-		case ie.ChargingCharacteristics:
-			c.ChargingCharacteristics = i
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNLDN = i
-			case 1:
-				c.SGWLDN = i
-			case 2:
-				c.EPDGLDN = i
-			case 3:
-				c.TWANLDN = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.SignallingPriorityIndication:
-			c.SignallingPriorityIndication = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.UELocalIPAddress = i
-			case 1:
-				c.HeNBLocalIPAddress = i
-			case 2:
-				c.MMESGSNIdentifier = i
-			case 3:
-				c.EPDGIPAddress = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.HeNBUDPPort = i
-			case 2:
-				c.UETCPPort = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.AdditionalProtocolConfigurationOptions:
-			c.APCO = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.CNOperatorSelectionEntity:
-			c.CNOperatorSelectionEntity = i
-		case ie.PresenceReportingAreaInformation:
-			c.PresenceReportingAreaInformation = append(c.PresenceReportingAreaInformation, i)
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.MillisecondTimeStamp:
-			c.OriginationTimeStamp = i
-		case ie.IntegerNumber:
-			c.MaximumWaitTime = i
-		case ie.TWANIdentifierTimestamp:
-			c.WLANLocationTimeStamp = i
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.RemoteUEContext:
-			c.RemoteUEContextConnected = append(c.RemoteUEContextConnected, i)
-		case ie.NodeIdentifier:
-			c.TGPPAAAServerIdentifier = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			c.EPCO = i
-		case ie.ServingPLMNRateControl:
-			c.ServingPLMNRateControl = i
-		case ie.Counter:
-			c.MOExceptionDataCounter = i
-		case ie.MappedUEUsageType:
-			c.MappedUEUsageType = i
-		case ie.FullyQualifiedDomainName:
-			c.SGWUNodeName = i
-		case ie.SecondaryRATUsageDataReport:
-			c.SecondaryRATUsageDataReport = append(c.SecondaryRATUsageDataReport, i)
-		case ie.UPFunctionSelectionIndicationFlags:
-			c.UPFunctionSelectionIndicationFlags = i
-		case ie.APNRateControlStatus:
-			c.APNRateControlStatus = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -968,9 +906,15 @@ func (c *CreateSessionRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsToBeCreated {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsToBeRemoved {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.TraceInformation; ie != nil {
@@ -1043,6 +987,9 @@ func (c *CreateSessionRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaInformation {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.MMESGSNOverloadControlInformation; ie != nil {
@@ -1070,6 +1017,9 @@ func (c *CreateSessionRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.RemoteUEContextConnected {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.TGPPAAAServerIdentifier; ie != nil {
@@ -1097,6 +1047,9 @@ func (c *CreateSessionRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.UPFunctionSelectionIndicationFlags; ie != nil {
@@ -1132,3 +1085,578 @@ func (c *CreateSessionRequest) MessageTypeName() string {
 func (c *CreateSessionRequest) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// CreateSessionRequestBuilder builds CreateSessionRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type CreateSessionRequestBuilder struct {
+	builder
+	msg *CreateSessionRequest
+}
+
+// BuildCreateSessionRequest returns the builder of CreateSessionRequest.
+func BuildCreateSessionRequest(teid, seq uint32) *CreateSessionRequestBuilder {
+	return &CreateSessionRequestBuilder{
+		builder: builder{msgType: "Create Session Request"},
+		msg:     NewCreateSessionRequest(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *CreateSessionRequestBuilder) WithIMSI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithMSISDN sets the MSISDN IE to MSISDN.
+func (b *CreateSessionRequestBuilder) WithMSISDN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MSISDN", i, ie.MSISDN) {
+		b.msg.MSISDN = i
+	}
+	return b
+}
+
+// WithMEI sets the MobileEquipmentIdentity IE to MEI.
+func (b *CreateSessionRequestBuilder) WithMEI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MEI", i, ie.MobileEquipmentIdentity) {
+		b.msg.MEI = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithULI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithServingNetwork sets the ServingNetwork IE to ServingNetwork.
+func (b *CreateSessionRequestBuilder) WithServingNetwork(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("ServingNetwork", i, ie.ServingNetwork) {
+		b.msg.ServingNetwork = i
+	}
+	return b
+}
+
+// WithRATType sets the RATType IE to RATType.
+func (b *CreateSessionRequestBuilder) WithRATType(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("RATType", i, ie.RATType) {
+		b.msg.RATType = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *CreateSessionRequestBuilder) WithIndicationFlags(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithSenderFTEIDC(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWS5S8FTEIDC sets the FullyQualifiedTEID IE to PGWS5S8FTEIDC, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithPGWS5S8FTEIDC(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("PGWS5S8FTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.PGWS5S8FTEIDC = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithAPN sets the AccessPointName IE to APN.
+func (b *CreateSessionRequestBuilder) WithAPN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("APN", i, ie.AccessPointName) {
+		b.msg.APN = i
+	}
+	return b
+}
+
+// WithSelectionMode sets the SelectionMode IE to SelectionMode.
+func (b *CreateSessionRequestBuilder) WithSelectionMode(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SelectionMode", i, ie.SelectionMode) {
+		b.msg.SelectionMode = i
+	}
+	return b
+}
+
+// WithPDNType sets the PDNType IE to PDNType.
+func (b *CreateSessionRequestBuilder) WithPDNType(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("PDNType", i, ie.PDNType) {
+		b.msg.PDNType = i
+	}
+	return b
+}
+
+// WithPAA sets the PDNAddressAllocation IE to PAA.
+func (b *CreateSessionRequestBuilder) WithPAA(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("PAA", i, ie.PDNAddressAllocation) {
+		b.msg.PAA = i
+	}
+	return b
+}
+
+// WithAPNRestriction sets the APNRestriction IE to APNRestriction.
+func (b *CreateSessionRequestBuilder) WithAPNRestriction(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("APNRestriction", i, ie.APNRestriction) {
+		b.msg.APNRestriction = i
+	}
+	return b
+}
+
+// WithAMBR sets the AggregateMaximumBitRate IE to AMBR.
+func (b *CreateSessionRequestBuilder) WithAMBR(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("AMBR", i, ie.AggregateMaximumBitRate) {
+		b.msg.AMBR = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *CreateSessionRequestBuilder) WithLinkedEBI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithTWMI sets the TrustedWLANModeIndication IE to TWMI.
+func (b *CreateSessionRequestBuilder) WithTWMI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TWMI", i, ie.TrustedWLANModeIndication) {
+		b.msg.TWMI = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *CreateSessionRequestBuilder) WithPCO(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithBearerContextsToBeCreated appends the BearerContext IEs to BearerContextsToBeCreated, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithBearerContextsToBeCreated(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeCreated", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeCreated = append(b.msg.BearerContextsToBeCreated, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextsToBeRemoved appends the BearerContext IEs to BearerContextsToBeRemoved, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithBearerContextsToBeRemoved(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeRemoved", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeRemoved = append(b.msg.BearerContextsToBeRemoved, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithTraceInformation sets the TraceInformation IE to TraceInformation.
+func (b *CreateSessionRequestBuilder) WithTraceInformation(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TraceInformation", i, ie.TraceInformation) {
+		b.msg.TraceInformation = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *CreateSessionRequestBuilder) WithRecovery(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithMMEFQCSID(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithSGWFQCSID(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGFQCSID sets the FullyQualifiedCSID IE to EPDGFQCSID, with the instance set to 2.
+func (b *CreateSessionRequestBuilder) WithEPDGFQCSID(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("EPDGFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.EPDGFQCSID = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANFQCSID sets the FullyQualifiedCSID IE to TWANFQCSID, with the instance set to 3.
+func (b *CreateSessionRequestBuilder) WithTWANFQCSID(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TWANFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.TWANFQCSID = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *CreateSessionRequestBuilder) WithUETimeZone(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithUCI sets the UserCSGInformation IE to UCI.
+func (b *CreateSessionRequestBuilder) WithUCI(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UCI", i, ie.UserCSGInformation) {
+		b.msg.UCI = i
+	}
+	return b
+}
+
+// WithChargingCharacteristics sets the ChargingCharacteristics IE to ChargingCharacteristics.
+func (b *CreateSessionRequestBuilder) WithChargingCharacteristics(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("ChargingCharacteristics", i, ie.ChargingCharacteristics) {
+		b.msg.ChargingCharacteristics = i
+	}
+	return b
+}
+
+// WithMMESGSNLDN sets the LocalDistinguishedName IE to MMESGSNLDN, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithMMESGSNLDN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MMESGSNLDN", i, ie.LocalDistinguishedName) {
+		b.msg.MMESGSNLDN = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWLDN sets the LocalDistinguishedName IE to SGWLDN, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithSGWLDN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.SGWLDN = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGLDN sets the LocalDistinguishedName IE to EPDGLDN, with the instance set to 2.
+func (b *CreateSessionRequestBuilder) WithEPDGLDN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("EPDGLDN", i, ie.LocalDistinguishedName) {
+		b.msg.EPDGLDN = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANLDN sets the LocalDistinguishedName IE to TWANLDN, with the instance set to 3.
+func (b *CreateSessionRequestBuilder) WithTWANLDN(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TWANLDN", i, ie.LocalDistinguishedName) {
+		b.msg.TWANLDN = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithSignallingPriorityIndication sets the SignallingPriorityIndication IE to SignallingPriorityIndication.
+func (b *CreateSessionRequestBuilder) WithSignallingPriorityIndication(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SignallingPriorityIndication", i, ie.SignallingPriorityIndication) {
+		b.msg.SignallingPriorityIndication = i
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithUELocalIPAddress(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithUEUDPPort(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithAPCO sets the AdditionalProtocolConfigurationOptions IE to APCO.
+func (b *CreateSessionRequestBuilder) WithAPCO(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("APCO", i, ie.AdditionalProtocolConfigurationOptions) {
+		b.msg.APCO = i
+	}
+	return b
+}
+
+// WithHeNBLocalIPAddress sets the IPAddress IE to HeNBLocalIPAddress, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithHeNBLocalIPAddress(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("HeNBLocalIPAddress", i, ie.IPAddress) {
+		b.msg.HeNBLocalIPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithHeNBUDPPort sets the PortNumber IE to HeNBUDPPort, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithHeNBUDPPort(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("HeNBUDPPort", i, ie.PortNumber) {
+		b.msg.HeNBUDPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithMMESGSNIdentifier sets the IPAddress IE to MMESGSNIdentifier, with the instance set to 2.
+func (b *CreateSessionRequestBuilder) WithMMESGSNIdentifier(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MMESGSNIdentifier", i, ie.IPAddress) {
+		b.msg.MMESGSNIdentifier = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANIdentifier sets the TWANIdentifier IE to TWANIdentifier, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithTWANIdentifier(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TWANIdentifier", i, ie.TWANIdentifier) {
+		b.msg.TWANIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithEPDGIPAddress sets the IPAddress IE to EPDGIPAddress, with the instance set to 3.
+func (b *CreateSessionRequestBuilder) WithEPDGIPAddress(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("EPDGIPAddress", i, ie.IPAddress) {
+		b.msg.EPDGIPAddress = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithCNOperatorSelectionEntity sets the CNOperatorSelectionEntity IE to CNOperatorSelectionEntity.
+func (b *CreateSessionRequestBuilder) WithCNOperatorSelectionEntity(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("CNOperatorSelectionEntity", i, ie.CNOperatorSelectionEntity) {
+		b.msg.CNOperatorSelectionEntity = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaInformation appends the PresenceReportingAreaInformation IEs to PresenceReportingAreaInformation.
+func (b *CreateSessionRequestBuilder) WithPresenceReportingAreaInformation(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaInformation", i, ie.PresenceReportingAreaInformation) {
+			b.msg.PresenceReportingAreaInformation = append(b.msg.PresenceReportingAreaInformation, i)
+		}
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *CreateSessionRequestBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithSGWOverloadControlInformation(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformation sets the OverloadControlInformation IE to TWANePDGOverloadControlInformation, with the instance set to 2.
+func (b *CreateSessionRequestBuilder) WithTWANePDGOverloadControlInformation(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TWANePDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithOriginationTimeStamp sets the MillisecondTimeStamp IE to OriginationTimeStamp.
+func (b *CreateSessionRequestBuilder) WithOriginationTimeStamp(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("OriginationTimeStamp", i, ie.MillisecondTimeStamp) {
+		b.msg.OriginationTimeStamp = i
+	}
+	return b
+}
+
+// WithMaximumWaitTime sets the IntegerNumber IE to MaximumWaitTime.
+func (b *CreateSessionRequestBuilder) WithMaximumWaitTime(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MaximumWaitTime", i, ie.IntegerNumber) {
+		b.msg.MaximumWaitTime = i
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithWLANLocationInformation(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationTimeStamp sets the TWANIdentifierTimestamp IE to WLANLocationTimeStamp.
+func (b *CreateSessionRequestBuilder) WithWLANLocationTimeStamp(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("WLANLocationTimeStamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimeStamp = i
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *CreateSessionRequestBuilder) WithNBIFOMContainer(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithRemoteUEContextConnected appends the RemoteUEContext IEs to RemoteUEContextConnected.
+func (b *CreateSessionRequestBuilder) WithRemoteUEContextConnected(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if b.expect("RemoteUEContextConnected", i, ie.RemoteUEContext) {
+			b.msg.RemoteUEContextConnected = append(b.msg.RemoteUEContextConnected, i)
+		}
+	}
+	return b
+}
+
+// WithTGPPAAAServerIdentifier sets the NodeIdentifier IE to TGPPAAAServerIdentifier.
+func (b *CreateSessionRequestBuilder) WithTGPPAAAServerIdentifier(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("TGPPAAAServerIdentifier", i, ie.NodeIdentifier) {
+		b.msg.TGPPAAAServerIdentifier = i
+	}
+	return b
+}
+
+// WithEPCO sets the ExtendedProtocolConfigurationOptions IE to EPCO.
+func (b *CreateSessionRequestBuilder) WithEPCO(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("EPCO", i, ie.ExtendedProtocolConfigurationOptions) {
+		b.msg.EPCO = i
+	}
+	return b
+}
+
+// WithServingPLMNRateControl sets the ServingPLMNRateControl IE to ServingPLMNRateControl.
+func (b *CreateSessionRequestBuilder) WithServingPLMNRateControl(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("ServingPLMNRateControl", i, ie.ServingPLMNRateControl) {
+		b.msg.ServingPLMNRateControl = i
+	}
+	return b
+}
+
+// WithMOExceptionDataCounter sets the Counter IE to MOExceptionDataCounter.
+func (b *CreateSessionRequestBuilder) WithMOExceptionDataCounter(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MOExceptionDataCounter", i, ie.Counter) {
+		b.msg.MOExceptionDataCounter = i
+	}
+	return b
+}
+
+// WithUETCPPort sets the PortNumber IE to UETCPPort, with the instance set to 2.
+func (b *CreateSessionRequestBuilder) WithUETCPPort(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UETCPPort", i, ie.PortNumber) {
+		b.msg.UETCPPort = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithMappedUEUsageType sets the MappedUEUsageType IE to MappedUEUsageType.
+func (b *CreateSessionRequestBuilder) WithMappedUEUsageType(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("MappedUEUsageType", i, ie.MappedUEUsageType) {
+		b.msg.MappedUEUsageType = i
+	}
+	return b
+}
+
+// WithULIForSGW sets the UserLocationInformation IE to ULIForSGW, with the instance set to 1.
+func (b *CreateSessionRequestBuilder) WithULIForSGW(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("ULIForSGW", i, ie.UserLocationInformation) {
+		b.msg.ULIForSGW = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWUNodeName sets the FullyQualifiedDomainName IE to SGWUNodeName.
+func (b *CreateSessionRequestBuilder) WithSGWUNodeName(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("SGWUNodeName", i, ie.FullyQualifiedDomainName) {
+		b.msg.SGWUNodeName = i
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *CreateSessionRequestBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithUPFunctionSelectionIndicationFlags sets the UPFunctionSelectionIndicationFlags IE to UPFunctionSelectionIndicationFlags.
+func (b *CreateSessionRequestBuilder) WithUPFunctionSelectionIndicationFlags(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("UPFunctionSelectionIndicationFlags", i, ie.UPFunctionSelectionIndicationFlags) {
+		b.msg.UPFunctionSelectionIndicationFlags = i
+	}
+	return b
+}
+
+// WithAPNRateControlStatus sets the APNRateControlStatus IE to APNRateControlStatus.
+func (b *CreateSessionRequestBuilder) WithAPNRateControlStatus(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("APNRateControlStatus", i, ie.APNRateControlStatus) {
+		b.msg.APNRateControlStatus = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *CreateSessionRequestBuilder) WithPrivateExtension(i *ie.IE) *CreateSessionRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewCreateSessionRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *CreateSessionRequestBuilder) WithIEs(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *CreateSessionRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *CreateSessionRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the CreateSessionRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *CreateSessionRequestBuilder) Build() (*CreateSessionRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -25,12 +27,12 @@ type CreateSessionResponse struct {
 	BearerContextsCreated         []*ie.IE
 	BearerContextMarkedForRemoval *ie.IE
 	Recovery                      *ie.IE
-	ChargingGatewayName           *ie.IE // = PGWNodeName
+	ChargingGatewayName           *ie.IE
 	ChargingGatewayAddress        *ie.IE
 	PGWFQCSID                     *ie.IE
 	SGWFQCSID                     *ie.IE
-	SGWLDN                        *ie.IE
 	PGWLDN                        *ie.IE
+	SGWLDN                        *ie.IE
 	PGWBackOffTime                *ie.IE
 	APCO                          *ie.IE
 	TrustedTWANIPv4Parameters     *ie.IE
@@ -56,118 +58,191 @@ func NewCreateSessionResponse(teid, seq uint32, ies ...*ie.IE) *CreateSessionRes
 			MsgTypeCreateSessionResponse, teid, seq, nil,
 		),
 	}
+
 	for _, i := range ies {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEIDC = i
-			case 1:
-				c.PGWS5S8FTEIDC = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PDNAddressAllocation:
-			c.PAA = i
-		case ie.APNRestriction:
-			c.APNRestriction = i
-		case ie.AggregateMaximumBitRate:
-			c.AMBR = i
-		case ie.EPSBearerID:
-			c.EBI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				c.BearerContextsCreated = append(c.BearerContextsCreated, i)
-			case 1:
-				c.BearerContextMarkedForRemoval = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedDomainName:
-			c.ChargingGatewayName = i
-		case ie.IPAddress:
-			c.ChargingGatewayAddress = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				c.PGWLDN = i
-			case 1:
-				c.SGWLDN = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.EPCTimer:
-			c.PGWBackOffTime = i
-		case ie.AdditionalProtocolConfigurationOptions:
-			c.APCO = i
-		case ie.IPv4ConfigurationParameters:
-			c.TrustedTWANIPv4Parameters = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.ChargingID:
-			c.PDNConnectionChargingID = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			c.EPCO = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	c.SetLength()
 	return c
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (c *CreateSessionResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		c.Cause = i
+	case ie.ChangeReportingAction:
+		c.ChangeReportingAction = i
+	case ie.CSGInformationReportingAction:
+		c.CSGInformationReportingAction = i
+	case ie.HeNBInformationReporting:
+		c.HeNBInformationReporting = i
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0:
+			c.SenderFTEIDC = i
+		case 1:
+			c.PGWS5S8FTEIDC = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.PDNAddressAllocation:
+		c.PAA = i
+	case ie.APNRestriction:
+		c.APNRestriction = i
+	case ie.AggregateMaximumBitRate:
+		c.AMBR = i
+	case ie.EPSBearerID:
+		c.EBI = i
+	case ie.ProtocolConfigurationOptions:
+		c.PCO = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			c.BearerContextsCreated = append(c.BearerContextsCreated, i)
+		case 1:
+			c.BearerContextMarkedForRemoval = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.Recovery:
+		c.Recovery = i
+	case ie.FullyQualifiedDomainName:
+		c.ChargingGatewayName = i
+	case ie.IPAddress:
+		c.ChargingGatewayAddress = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			c.PGWFQCSID = i
+		case 1:
+			c.SGWFQCSID = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0:
+			c.PGWLDN = i
+		case 1:
+			c.SGWLDN = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.EPCTimer:
+		c.PGWBackOffTime = i
+	case ie.AdditionalProtocolConfigurationOptions:
+		c.APCO = i
+	case ie.IPv4ConfigurationParameters:
+		c.TrustedTWANIPv4Parameters = i
+	case ie.Indication:
+		c.IndicationFlags = i
+	case ie.PresenceReportingAreaAction:
+		c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.PGWNodeLoadControlInformation = i
+		case 1:
+			c.PGWAPNLoadControlInformation = i
+		case 2:
+			c.SGWNodeLoadControlInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			c.PGWOverloadControlInformation = i
+		case 1:
+			c.SGWOverloadControlInformation = i
+		default:
+			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		c.NBIFOMContainer = i
+	case ie.ChargingID:
+		c.PDNConnectionChargingID = i
+	case ie.ExtendedProtocolConfigurationOptions:
+		c.EPCO = i
+	case ie.PrivateExtension:
+		c.PrivateExtension = i
+	default:
+		c.AdditionalIEs = append(c.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to CreateSessionResponse, or 0
+// if it is set to one of the fields.
+func (c *CreateSessionResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.ChangeReportingAction, ie.CSGInformationReportingAction, ie.HeNBInformationReporting,
+		ie.PDNAddressAllocation, ie.APNRestriction, ie.AggregateMaximumBitRate, ie.EPSBearerID,
+		ie.ProtocolConfigurationOptions, ie.Recovery, ie.FullyQualifiedDomainName, ie.IPAddress,
+		ie.EPCTimer, ie.AdditionalProtocolConfigurationOptions, ie.IPv4ConfigurationParameters, ie.Indication,
+		ie.PresenceReportingAreaAction, ie.FContainer, ie.ChargingID, ie.ExtendedProtocolConfigurationOptions,
+		ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedTEID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// CreateSessionResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (c *CreateSessionResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Create Session Response", c.AdditionalIEs, c.ieKind)
+}
+
 // Marshal serializes CreateSessionResponse into bytes.
 func (c *CreateSessionResponse) Marshal() ([]byte, error) {
 	b := make([]byte, c.MarshalLen())
@@ -186,205 +261,211 @@ func (c *CreateSessionResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChangeReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.CSGInformationReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.HeNBInformationReporting; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWS5S8FTEIDC; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PAA; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APNRestriction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.AMBR; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EBI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsCreated {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.BearerContextMarkedForRemoval; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.Recovery; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChargingGatewayName; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.ChargingGatewayAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWLDN; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWBackOffTime; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.APCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.TrustedTWANIPv4Parameters; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PDNConnectionChargingID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.EPCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -428,113 +509,11 @@ func (c *CreateSessionResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.FullyQualifiedTEID:
-			switch i.Instance() {
-			case 0:
-				c.SenderFTEIDC = i
-			case 1:
-				c.PGWS5S8FTEIDC = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PDNAddressAllocation:
-			c.PAA = i
-		case ie.APNRestriction:
-			c.APNRestriction = i
-		case ie.AggregateMaximumBitRate:
-			c.AMBR = i
-		case ie.EPSBearerID:
-			c.EBI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				c.BearerContextsCreated = append(c.BearerContextsCreated, i)
-			case 1:
-				c.BearerContextMarkedForRemoval = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedDomainName:
-			c.ChargingGatewayName = i
-		case ie.IPAddress:
-			c.ChargingGatewayAddress = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				c.PGWLDN = i
-			case 1:
-				c.SGWLDN = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.EPCTimer:
-			c.PGWBackOffTime = i
-		case ie.AdditionalProtocolConfigurationOptions:
-			c.APCO = i
-		case ie.IPv4ConfigurationParameters:
-			c.TrustedTWANIPv4Parameters = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.ChargingID:
-			c.PDNConnectionChargingID = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			c.EPCO = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		c.setIE(i)
 	}
 
 	return nil
@@ -578,6 +557,9 @@ func (c *CreateSessionResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.BearerContextsCreated {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.BearerContextMarkedForRemoval; ie != nil {
@@ -617,6 +599,9 @@ func (c *CreateSessionResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range c.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := c.PGWNodeLoadControlInformation; ie != nil {
@@ -648,6 +633,9 @@ func (c *CreateSessionResponse) MarshalLen() int {
 	}
 
 	for _, ie := range c.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	return l
@@ -667,3 +655,324 @@ func (c *CreateSessionResponse) MessageTypeName() string {
 func (c *CreateSessionResponse) TEID() uint32 {
 	return c.Header.teid()
 }
+
+// CreateSessionResponseBuilder builds CreateSessionResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type CreateSessionResponseBuilder struct {
+	builder
+	msg *CreateSessionResponse
+}
+
+// BuildCreateSessionResponse returns the builder of CreateSessionResponse.
+func BuildCreateSessionResponse(teid, seq uint32) *CreateSessionResponseBuilder {
+	return &CreateSessionResponseBuilder{
+		builder: builder{msgType: "Create Session Response"},
+		msg:     NewCreateSessionResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *CreateSessionResponseBuilder) WithCause(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithChangeReportingAction sets the ChangeReportingAction IE to ChangeReportingAction.
+func (b *CreateSessionResponseBuilder) WithChangeReportingAction(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("ChangeReportingAction", i, ie.ChangeReportingAction) {
+		b.msg.ChangeReportingAction = i
+	}
+	return b
+}
+
+// WithCSGInformationReportingAction sets the CSGInformationReportingAction IE to CSGInformationReportingAction.
+func (b *CreateSessionResponseBuilder) WithCSGInformationReportingAction(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("CSGInformationReportingAction", i, ie.CSGInformationReportingAction) {
+		b.msg.CSGInformationReportingAction = i
+	}
+	return b
+}
+
+// WithHeNBInformationReporting sets the HeNBInformationReporting IE to HeNBInformationReporting.
+func (b *CreateSessionResponseBuilder) WithHeNBInformationReporting(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("HeNBInformationReporting", i, ie.HeNBInformationReporting) {
+		b.msg.HeNBInformationReporting = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithSenderFTEIDC(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWS5S8FTEIDC sets the FullyQualifiedTEID IE to PGWS5S8FTEIDC, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithPGWS5S8FTEIDC(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWS5S8FTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.PGWS5S8FTEIDC = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPAA sets the PDNAddressAllocation IE to PAA.
+func (b *CreateSessionResponseBuilder) WithPAA(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PAA", i, ie.PDNAddressAllocation) {
+		b.msg.PAA = i
+	}
+	return b
+}
+
+// WithAPNRestriction sets the APNRestriction IE to APNRestriction.
+func (b *CreateSessionResponseBuilder) WithAPNRestriction(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("APNRestriction", i, ie.APNRestriction) {
+		b.msg.APNRestriction = i
+	}
+	return b
+}
+
+// WithAMBR sets the AggregateMaximumBitRate IE to AMBR.
+func (b *CreateSessionResponseBuilder) WithAMBR(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("AMBR", i, ie.AggregateMaximumBitRate) {
+		b.msg.AMBR = i
+	}
+	return b
+}
+
+// WithEBI sets the EPSBearerID IE to EBI.
+func (b *CreateSessionResponseBuilder) WithEBI(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("EBI", i, ie.EPSBearerID) {
+		b.msg.EBI = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *CreateSessionResponseBuilder) WithPCO(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithBearerContextsCreated appends the BearerContext IEs to BearerContextsCreated, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithBearerContextsCreated(ies ...*ie.IE) *CreateSessionResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsCreated", i, ie.BearerContext) {
+			b.msg.BearerContextsCreated = append(b.msg.BearerContextsCreated, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextMarkedForRemoval sets the BearerContext IE to BearerContextMarkedForRemoval, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithBearerContextMarkedForRemoval(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("BearerContextMarkedForRemoval", i, ie.BearerContext) {
+		b.msg.BearerContextMarkedForRemoval = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *CreateSessionResponseBuilder) WithRecovery(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithChargingGatewayName sets the FullyQualifiedDomainName IE to ChargingGatewayName.
+func (b *CreateSessionResponseBuilder) WithChargingGatewayName(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("ChargingGatewayName", i, ie.FullyQualifiedDomainName) {
+		b.msg.ChargingGatewayName = i
+	}
+	return b
+}
+
+// WithChargingGatewayAddress sets the IPAddress IE to ChargingGatewayAddress.
+func (b *CreateSessionResponseBuilder) WithChargingGatewayAddress(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("ChargingGatewayAddress", i, ie.IPAddress) {
+		b.msg.ChargingGatewayAddress = i
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithPGWFQCSID(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithSGWFQCSID(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPGWLDN sets the LocalDistinguishedName IE to PGWLDN, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithPGWLDN(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.PGWLDN = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWLDN sets the LocalDistinguishedName IE to SGWLDN, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithSGWLDN(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("SGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.SGWLDN = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPGWBackOffTime sets the EPCTimer IE to PGWBackOffTime.
+func (b *CreateSessionResponseBuilder) WithPGWBackOffTime(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWBackOffTime", i, ie.EPCTimer) {
+		b.msg.PGWBackOffTime = i
+	}
+	return b
+}
+
+// WithAPCO sets the AdditionalProtocolConfigurationOptions IE to APCO.
+func (b *CreateSessionResponseBuilder) WithAPCO(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("APCO", i, ie.AdditionalProtocolConfigurationOptions) {
+		b.msg.APCO = i
+	}
+	return b
+}
+
+// WithTrustedTWANIPv4Parameters sets the IPv4ConfigurationParameters IE to TrustedTWANIPv4Parameters.
+func (b *CreateSessionResponseBuilder) WithTrustedTWANIPv4Parameters(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("TrustedTWANIPv4Parameters", i, ie.IPv4ConfigurationParameters) {
+		b.msg.TrustedTWANIPv4Parameters = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *CreateSessionResponseBuilder) WithIndicationFlags(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaAction appends the PresenceReportingAreaAction IEs to PresenceReportingAreaAction.
+func (b *CreateSessionResponseBuilder) WithPresenceReportingAreaAction(ies ...*ie.IE) *CreateSessionResponseBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAreaAction = append(b.msg.PresenceReportingAreaAction, i)
+		}
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 2.
+func (b *CreateSessionResponseBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *CreateSessionResponseBuilder) WithPGWOverloadControlInformation(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *CreateSessionResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *CreateSessionResponseBuilder) WithNBIFOMContainer(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithPDNConnectionChargingID sets the ChargingID IE to PDNConnectionChargingID.
+func (b *CreateSessionResponseBuilder) WithPDNConnectionChargingID(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PDNConnectionChargingID", i, ie.ChargingID) {
+		b.msg.PDNConnectionChargingID = i
+	}
+	return b
+}
+
+// WithEPCO sets the ExtendedProtocolConfigurationOptions IE to EPCO.
+func (b *CreateSessionResponseBuilder) WithEPCO(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("EPCO", i, ie.ExtendedProtocolConfigurationOptions) {
+		b.msg.EPCO = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *CreateSessionResponseBuilder) WithPrivateExtension(i *ie.IE) *CreateSessionResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewCreateSessionResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *CreateSessionResponseBuilder) WithIEs(ies ...*ie.IE) *CreateSessionResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *CreateSessionResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *CreateSessionResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the CreateSessionResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *CreateSessionResponseBuilder) Build() (*CreateSessionResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // DeleteBearerCommand is a DeleteBearerCommand Header and its IEs above.
 type DeleteBearerCommand struct {
@@ -34,35 +38,70 @@ func NewDeleteBearerCommand(teid, seq uint32, ies ...*ie.IE) *DeleteBearerComman
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			}
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATDataUsageReport = append(d.SecondaryRATDataUsageReport, i)
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteBearerCommand) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.BearerContext:
+		d.BearerContexts = append(d.BearerContexts, i)
+	case ie.UserLocationInformation:
+		d.ULI = i
+	case ie.ULITimestamp:
+		d.ULITimestamp = i
+	case ie.UETimeZone:
+		d.UETimeZone = i
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.MMESGSNOverloadControlInformation = i
+		case 1:
+			d.SGWOverloadControlInformation = i
 		default:
 			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
 		}
+	case ie.FullyQualifiedTEID:
+		d.SenderFTEIDC = i
+	case ie.SecondaryRATUsageDataReport:
+		d.SecondaryRATDataUsageReport = append(d.SecondaryRATDataUsageReport, i)
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	d.SetLength()
-	return d
+// ieKind returns the reason why the IE does not belong to DeleteBearerCommand, or 0
+// if it is set to one of the fields.
+func (d *DeleteBearerCommand) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.BearerContext, ie.UserLocationInformation, ie.ULITimestamp, ie.UETimeZone,
+		ie.FullyQualifiedTEID, ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteBearerCommand, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteBearerCommand) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Bearer Command", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeleteBearerCommand into bytes.
@@ -83,55 +122,61 @@ func (d *DeleteBearerCommand) MarshalTo(b []byte) error {
 
 	offset := 0
 	for _, ie := range d.BearerContexts {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULITimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range d.SecondaryRATDataUsageReport {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -179,31 +224,7 @@ func (d *DeleteBearerCommand) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			}
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATDataUsageReport = append(d.SecondaryRATDataUsageReport, i)
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -212,7 +233,11 @@ func (d *DeleteBearerCommand) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (d *DeleteBearerCommand) MarshalLen() int {
 	l := d.Header.MarshalLen() - len(d.Header.Payload)
+
 	for _, ie := range d.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.ULI; ie != nil {
@@ -234,6 +259,9 @@ func (d *DeleteBearerCommand) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range d.SecondaryRATDataUsageReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
@@ -263,3 +291,124 @@ func (d *DeleteBearerCommand) MessageTypeName() string {
 func (d *DeleteBearerCommand) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteBearerCommandBuilder builds DeleteBearerCommand.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteBearerCommandBuilder struct {
+	builder
+	msg *DeleteBearerCommand
+}
+
+// BuildDeleteBearerCommand returns the builder of DeleteBearerCommand.
+func BuildDeleteBearerCommand(teid, seq uint32) *DeleteBearerCommandBuilder {
+	return &DeleteBearerCommandBuilder{
+		builder: builder{msgType: "Delete Bearer Command"},
+		msg:     NewDeleteBearerCommand(teid, seq),
+	}
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *DeleteBearerCommandBuilder) WithBearerContexts(ies ...*ie.IE) *DeleteBearerCommandBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *DeleteBearerCommandBuilder) WithULI(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithULITimestamp sets the ULITimestamp IE to ULITimestamp.
+func (b *DeleteBearerCommandBuilder) WithULITimestamp(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("ULITimestamp", i, ie.ULITimestamp) {
+		b.msg.ULITimestamp = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *DeleteBearerCommandBuilder) WithUETimeZone(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *DeleteBearerCommandBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *DeleteBearerCommandBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *DeleteBearerCommandBuilder) WithSenderFTEIDC(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithSecondaryRATDataUsageReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATDataUsageReport.
+func (b *DeleteBearerCommandBuilder) WithSecondaryRATDataUsageReport(ies ...*ie.IE) *DeleteBearerCommandBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATDataUsageReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATDataUsageReport = append(b.msg.SecondaryRATDataUsageReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteBearerCommandBuilder) WithPrivateExtension(i *ie.IE) *DeleteBearerCommandBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteBearerCommand. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteBearerCommandBuilder) WithIEs(ies ...*ie.IE) *DeleteBearerCommandBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteBearerCommandBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteBearerCommandBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteBearerCommand built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteBearerCommandBuilder) Build() (*DeleteBearerCommand, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
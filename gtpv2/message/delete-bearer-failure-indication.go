@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // DeleteBearerFailureIndication is a DeleteBearerFailureIndication Header and its IEs above.
 type DeleteBearerFailureIndication struct {
@@ -32,31 +36,66 @@ func NewDeleteBearerFailureIndication(teid, seq uint32, ies ...*ie.IE) *DeleteBe
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			}
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteBearerFailureIndication) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.BearerContext:
+		d.BearerContexts = append(d.BearerContexts, i)
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.Indication:
+		d.IndicationFlags = i
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.PGWOverloadControlInformation = i
+		case 1:
+			d.SGWOverloadControlInformation = i
 		default:
 			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
 		}
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	d.SetLength()
-	return d
+// ieKind returns the reason why the IE does not belong to DeleteBearerFailureIndication, or 0
+// if it is set to one of the fields.
+func (d *DeleteBearerFailureIndication) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.BearerContext, ie.Recovery, ie.Indication,
+		ie.PrivateExtension:
+		return 0
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteBearerFailureIndication, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteBearerFailureIndication) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Bearer Failure Indication", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeleteBearerFailureIndication into bytes.
@@ -77,43 +116,46 @@ func (d *DeleteBearerFailureIndication) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range d.BearerContexts {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -161,27 +203,7 @@ func (d *DeleteBearerFailureIndication) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			}
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -190,10 +212,14 @@ func (d *DeleteBearerFailureIndication) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (d *DeleteBearerFailureIndication) MarshalLen() int {
 	l := d.Header.MarshalLen() - len(d.Header.Payload)
+
 	if ie := d.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range d.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
@@ -235,3 +261,106 @@ func (d *DeleteBearerFailureIndication) MessageTypeName() string {
 func (d *DeleteBearerFailureIndication) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteBearerFailureIndicationBuilder builds DeleteBearerFailureIndication.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteBearerFailureIndicationBuilder struct {
+	builder
+	msg *DeleteBearerFailureIndication
+}
+
+// BuildDeleteBearerFailureIndication returns the builder of DeleteBearerFailureIndication.
+func BuildDeleteBearerFailureIndication(teid, seq uint32) *DeleteBearerFailureIndicationBuilder {
+	return &DeleteBearerFailureIndicationBuilder{
+		builder: builder{msgType: "Delete Bearer Failure Indication"},
+		msg:     NewDeleteBearerFailureIndication(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeleteBearerFailureIndicationBuilder) WithCause(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *DeleteBearerFailureIndicationBuilder) WithBearerContexts(ies ...*ie.IE) *DeleteBearerFailureIndicationBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DeleteBearerFailureIndicationBuilder) WithRecovery(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *DeleteBearerFailureIndicationBuilder) WithIndicationFlags(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *DeleteBearerFailureIndicationBuilder) WithPGWOverloadControlInformation(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *DeleteBearerFailureIndicationBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteBearerFailureIndicationBuilder) WithPrivateExtension(i *ie.IE) *DeleteBearerFailureIndicationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteBearerFailureIndication. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteBearerFailureIndicationBuilder) WithIEs(ies ...*ie.IE) *DeleteBearerFailureIndicationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteBearerFailureIndicationBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteBearerFailureIndicationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteBearerFailureIndication built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteBearerFailureIndicationBuilder) Build() (*DeleteBearerFailureIndication, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -45,70 +47,125 @@ func NewDeleteBearerRequest(teid, seq uint32, ies ...*ie.IE) *DeleteBearerReques
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.EPSBearerID:
-			switch i.Instance() {
-			case 0:
-				d.LinkedEBI = i
-			case 1:
-				d.EBIs = append(d.EBIs, i)
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.BearerContext:
-			d.FailedBearerContext = i
-		case ie.ProcedureTransactionID:
-			d.PTI = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				d.PGWFQCSID = i
-			case 1:
-				d.SGWFQCSID = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.Cause:
-			d.Cause = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWNodeLoadControlInformation = i
-			case 1:
-				d.PGWAPNLoadControlInformation = i
-			case 2:
-				d.SGWNodeLoadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			d.NBIFOMContainer = i
-		case ie.APNRateControlStatus:
-			d.APNRateControlStatus = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteBearerRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.EPSBearerID:
+		switch i.Instance() {
+		case 0:
+			d.LinkedEBI = i
+		case 1:
+			d.EBIs = append(d.EBIs, i)
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.BearerContext:
+		d.FailedBearerContext = i
+	case ie.ProcedureTransactionID:
+		d.PTI = i
+	case ie.ProtocolConfigurationOptions:
+		d.PCO = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			d.PGWFQCSID = i
+		case 1:
+			d.SGWFQCSID = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.Cause:
+		d.Cause = i
+	case ie.Indication:
+		d.IndicationFlags = i
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.PGWNodeLoadControlInformation = i
+		case 1:
+			d.PGWAPNLoadControlInformation = i
+		case 2:
+			d.SGWNodeLoadControlInformation = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.PGWOverloadControlInformation = i
+		case 1:
+			d.SGWOverloadControlInformation = i
 		default:
 			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
 		}
+	case ie.FContainer:
+		d.NBIFOMContainer = i
+	case ie.APNRateControlStatus:
+		d.APNRateControlStatus = i
+	case ie.ExtendedProtocolConfigurationOptions:
+		d.EPCO = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeleteBearerRequest, or 0
+// if it is set to one of the fields.
+func (d *DeleteBearerRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.BearerContext, ie.ProcedureTransactionID, ie.ProtocolConfigurationOptions, ie.Cause,
+		ie.Indication, ie.FContainer, ie.APNRateControlStatus, ie.ExtendedProtocolConfigurationOptions,
+		ie.PrivateExtension:
+		return 0
+	case ie.EPSBearerID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	d.SetLength()
-	return d
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteBearerRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteBearerRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Bearer Request", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeleteBearerRequest into bytes.
@@ -128,111 +185,113 @@ func (d *DeleteBearerRequest) MarshalTo(b []byte) error {
 	d.Header.Payload = make([]byte, d.MarshalLen()-d.Header.MarshalLen())
 
 	offset := 0
-
 	if ie := d.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range d.EBIs {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.FailedBearerContext; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PTI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.APNRateControlStatus; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.EPCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -280,66 +339,7 @@ func (d *DeleteBearerRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.EPSBearerID:
-			switch i.Instance() {
-			case 0:
-				d.LinkedEBI = i
-			case 1:
-				d.EBIs = append(d.EBIs, i)
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.BearerContext:
-			d.FailedBearerContext = i
-		case ie.ProcedureTransactionID:
-			d.PTI = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				d.PGWFQCSID = i
-			case 1:
-				d.SGWFQCSID = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.Cause:
-			d.Cause = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWNodeLoadControlInformation = i
-			case 1:
-				d.PGWAPNLoadControlInformation = i
-			case 2:
-				d.SGWNodeLoadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.PGWOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			d.NBIFOMContainer = i
-		case ie.APNRateControlStatus:
-			d.APNRateControlStatus = i
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -353,6 +353,9 @@ func (d *DeleteBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range d.EBIs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.FailedBearerContext; ie != nil {
@@ -427,3 +430,194 @@ func (d *DeleteBearerRequest) MessageTypeName() string {
 func (d *DeleteBearerRequest) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteBearerRequestBuilder builds DeleteBearerRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteBearerRequestBuilder struct {
+	builder
+	msg *DeleteBearerRequest
+}
+
+// BuildDeleteBearerRequest returns the builder of DeleteBearerRequest.
+func BuildDeleteBearerRequest(teid, seq uint32) *DeleteBearerRequestBuilder {
+	return &DeleteBearerRequestBuilder{
+		builder: builder{msgType: "Delete Bearer Request"},
+		msg:     NewDeleteBearerRequest(teid, seq),
+	}
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI, with the instance set to 0.
+func (b *DeleteBearerRequestBuilder) WithLinkedEBI(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithEBIs appends the EPSBearerID IEs to EBIs, with the instance set to 1.
+func (b *DeleteBearerRequestBuilder) WithEBIs(ies ...*ie.IE) *DeleteBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("EBIs", i, ie.EPSBearerID) {
+			b.msg.EBIs = append(b.msg.EBIs, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithFailedBearerContext sets the BearerContext IE to FailedBearerContext.
+func (b *DeleteBearerRequestBuilder) WithFailedBearerContext(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("FailedBearerContext", i, ie.BearerContext) {
+		b.msg.FailedBearerContext = i
+	}
+	return b
+}
+
+// WithPTI sets the ProcedureTransactionID IE to PTI.
+func (b *DeleteBearerRequestBuilder) WithPTI(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PTI", i, ie.ProcedureTransactionID) {
+		b.msg.PTI = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *DeleteBearerRequestBuilder) WithPCO(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 0.
+func (b *DeleteBearerRequestBuilder) WithPGWFQCSID(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *DeleteBearerRequestBuilder) WithSGWFQCSID(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeleteBearerRequestBuilder) WithCause(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *DeleteBearerRequestBuilder) WithIndicationFlags(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 0.
+func (b *DeleteBearerRequestBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 1.
+func (b *DeleteBearerRequestBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 2.
+func (b *DeleteBearerRequestBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *DeleteBearerRequestBuilder) WithPGWOverloadControlInformation(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *DeleteBearerRequestBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *DeleteBearerRequestBuilder) WithNBIFOMContainer(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithAPNRateControlStatus sets the APNRateControlStatus IE to APNRateControlStatus.
+func (b *DeleteBearerRequestBuilder) WithAPNRateControlStatus(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("APNRateControlStatus", i, ie.APNRateControlStatus) {
+		b.msg.APNRateControlStatus = i
+	}
+	return b
+}
+
+// WithEPCO sets the ExtendedProtocolConfigurationOptions IE to EPCO.
+func (b *DeleteBearerRequestBuilder) WithEPCO(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("EPCO", i, ie.ExtendedProtocolConfigurationOptions) {
+		b.msg.EPCO = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteBearerRequestBuilder) WithPrivateExtension(i *ie.IE) *DeleteBearerRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteBearerRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteBearerRequestBuilder) WithIEs(ies ...*ie.IE) *DeleteBearerRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteBearerRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteBearerRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteBearerRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteBearerRequestBuilder) Build() (*DeleteBearerRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
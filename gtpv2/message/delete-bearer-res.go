@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -53,98 +55,167 @@ func NewDeleteBearerResponse(teid, seq uint32, ies ...*ie.IE) *DeleteBearerRespo
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.LinkedEBI = i
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				d.MMEFQCSID = i
-			case 1:
-				d.SGWFQCSID = i
-			case 2:
-				d.EPDGFQCSID = i
-			case 3:
-				d.TWANFQCSID = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifier = i
-			case 1:
-				d.WLANLocationInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifierTimestamp = i
-			case 1:
-				d.WLANLocationTimestamp = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMEOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			case 2:
-				d.TWANePDGOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNIdentifier = i
-			case 1:
-				d.UELocalIPAddress = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				d.UEUDPPort = i
-			case 1:
-				d.UETCPPort = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			d.NBIFOMContainer = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATUsageDataReport = append(d.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	d.SetLength()
 	return d
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteBearerResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.EPSBearerID:
+		d.LinkedEBI = i
+	case ie.BearerContext:
+		d.BearerContexts = append(d.BearerContexts, i)
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			d.MMEFQCSID = i
+		case 1:
+			d.SGWFQCSID = i
+		case 2:
+			d.EPDGFQCSID = i
+		case 3:
+			d.TWANFQCSID = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.ProtocolConfigurationOptions:
+		d.PCO = i
+	case ie.UETimeZone:
+		d.UETimeZone = i
+	case ie.UserLocationInformation:
+		d.ULI = i
+	case ie.ULITimestamp:
+		d.ULITimestamp = i
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0:
+			d.TWANIdentifier = i
+		case 1:
+			d.WLANLocationInformation = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 0:
+			d.TWANIdentifierTimestamp = i
+		case 1:
+			d.WLANLocationTimestamp = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.MMEOverloadControlInformation = i
+		case 1:
+			d.SGWOverloadControlInformation = i
+		case 2:
+			d.TWANePDGOverloadControlInformation = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			d.MMESGSNIdentifier = i
+		case 1:
+			d.UELocalIPAddress = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			d.UEUDPPort = i
+		case 1:
+			d.UETCPPort = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		d.NBIFOMContainer = i
+	case ie.SecondaryRATUsageDataReport:
+		d.SecondaryRATUsageDataReport = append(d.SecondaryRATUsageDataReport, i)
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeleteBearerResponse, or 0
+// if it is set to one of the fields.
+func (d *DeleteBearerResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.EPSBearerID, ie.BearerContext, ie.Recovery,
+		ie.ProtocolConfigurationOptions, ie.UETimeZone, ie.UserLocationInformation, ie.ULITimestamp,
+		ie.FContainer, ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteBearerResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteBearerResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Bearer Response", d.AdditionalIEs, d.ieKind)
+}
+
 // Marshal serializes DeleteBearerResponse into bytes.
 func (d *DeleteBearerResponse) Marshal() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -163,157 +234,163 @@ func (d *DeleteBearerResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range d.BearerContexts {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.EPDGFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANFQCSID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULITimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANIdentifier; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANIdentifierTimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.MMEOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.MMESGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANePDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.WLANLocationTimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UETCPPort; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range d.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -357,97 +434,11 @@ func (d *DeleteBearerResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.LinkedEBI = i
-		case ie.BearerContext:
-			d.BearerContexts = append(d.BearerContexts, i)
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				d.MMEFQCSID = i
-			case 1:
-				d.SGWFQCSID = i
-			case 2:
-				d.EPDGFQCSID = i
-			case 3:
-				d.TWANFQCSID = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifier = i
-			case 1:
-				d.WLANLocationInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifierTimestamp = i
-			case 1:
-				d.WLANLocationTimestamp = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMEOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformation = i
-			case 2:
-				d.TWANePDGOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNIdentifier = i
-			case 1:
-				d.UELocalIPAddress = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				d.UEUDPPort = i
-			case 1:
-				d.UETCPPort = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			d.NBIFOMContainer = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATUsageDataReport = append(d.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -464,6 +455,9 @@ func (d *DeleteBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range d.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
@@ -530,6 +524,9 @@ func (d *DeleteBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range d.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
@@ -559,3 +556,260 @@ func (d *DeleteBearerResponse) MessageTypeName() string {
 func (d *DeleteBearerResponse) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteBearerResponseBuilder builds DeleteBearerResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteBearerResponseBuilder struct {
+	builder
+	msg *DeleteBearerResponse
+}
+
+// BuildDeleteBearerResponse returns the builder of DeleteBearerResponse.
+func BuildDeleteBearerResponse(teid, seq uint32) *DeleteBearerResponseBuilder {
+	return &DeleteBearerResponseBuilder{
+		builder: builder{msgType: "Delete Bearer Response"},
+		msg:     NewDeleteBearerResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeleteBearerResponseBuilder) WithCause(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *DeleteBearerResponseBuilder) WithLinkedEBI(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *DeleteBearerResponseBuilder) WithBearerContexts(ies ...*ie.IE) *DeleteBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DeleteBearerResponseBuilder) WithRecovery(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithMMEFQCSID(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithSGWFQCSID(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGFQCSID sets the FullyQualifiedCSID IE to EPDGFQCSID, with the instance set to 2.
+func (b *DeleteBearerResponseBuilder) WithEPDGFQCSID(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("EPDGFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.EPDGFQCSID = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANFQCSID sets the FullyQualifiedCSID IE to TWANFQCSID, with the instance set to 3.
+func (b *DeleteBearerResponseBuilder) WithTWANFQCSID(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("TWANFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.TWANFQCSID = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *DeleteBearerResponseBuilder) WithPCO(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *DeleteBearerResponseBuilder) WithUETimeZone(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *DeleteBearerResponseBuilder) WithULI(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithULITimestamp sets the ULITimestamp IE to ULITimestamp.
+func (b *DeleteBearerResponseBuilder) WithULITimestamp(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("ULITimestamp", i, ie.ULITimestamp) {
+		b.msg.ULITimestamp = i
+	}
+	return b
+}
+
+// WithTWANIdentifier sets the TWANIdentifier IE to TWANIdentifier, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithTWANIdentifier(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("TWANIdentifier", i, ie.TWANIdentifier) {
+		b.msg.TWANIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithTWANIdentifierTimestamp sets the TWANIdentifierTimestamp IE to TWANIdentifierTimestamp, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithTWANIdentifierTimestamp(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("TWANIdentifierTimestamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.TWANIdentifierTimestamp = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMEOverloadControlInformation sets the OverloadControlInformation IE to MMEOverloadControlInformation, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithMMEOverloadControlInformation(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("MMEOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMEOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithMMESGSNIdentifier sets the IPAddress IE to MMESGSNIdentifier, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithMMESGSNIdentifier(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("MMESGSNIdentifier", i, ie.IPAddress) {
+		b.msg.MMESGSNIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformation sets the OverloadControlInformation IE to TWANePDGOverloadControlInformation, with the instance set to 2.
+func (b *DeleteBearerResponseBuilder) WithTWANePDGOverloadControlInformation(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("TWANePDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithWLANLocationInformation(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationTimestamp sets the TWANIdentifierTimestamp IE to WLANLocationTimestamp, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithWLANLocationTimestamp(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("WLANLocationTimestamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimestamp = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithUELocalIPAddress(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *DeleteBearerResponseBuilder) WithUEUDPPort(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *DeleteBearerResponseBuilder) WithNBIFOMContainer(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithUETCPPort sets the PortNumber IE to UETCPPort, with the instance set to 1.
+func (b *DeleteBearerResponseBuilder) WithUETCPPort(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("UETCPPort", i, ie.PortNumber) {
+		b.msg.UETCPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *DeleteBearerResponseBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *DeleteBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteBearerResponseBuilder) WithPrivateExtension(i *ie.IE) *DeleteBearerResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteBearerResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteBearerResponseBuilder) WithIEs(ies ...*ie.IE) *DeleteBearerResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteBearerResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteBearerResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteBearerResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteBearerResponseBuilder) Build() (*DeleteBearerResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -22,7 +24,7 @@ type DeletePDNConnectionSetRequest struct {
 
 // NewDeletePDNConnectionSetRequest creates a new DeletePDNConnectionSetRequest.
 func NewDeletePDNConnectionSetRequest(teid, seq uint32, ies ...*ie.IE) *DeletePDNConnectionSetRequest {
-	m := &DeletePDNConnectionSetRequest{
+	d := &DeletePDNConnectionSetRequest{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeDeletePDNConnectionSetRequest, teid, seq, nil,
@@ -33,122 +35,154 @@ func NewDeletePDNConnectionSetRequest(teid, seq uint32, ies ...*ie.IE) *DeletePD
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			case 2:
-				m.PGWFQCSID = i
-			case 3:
-				m.EPDGFQCSID = i
-			case 4:
-				m.TWANFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeletePDNConnectionSetRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			d.MMEFQCSID = i
+		case 1:
+			d.SGWFQCSID = i
+		case 2:
+			d.PGWFQCSID = i
+		case 3:
+			d.EPDGFQCSID = i
+		case 4:
+			d.TWANFQCSID = i
 		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeletePDNConnectionSetRequest, or 0
+// if it is set to one of the fields.
+func (d *DeletePDNConnectionSetRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1, 2, 3, 4:
+			return 0
 		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeletePDNConnectionSetRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeletePDNConnectionSetRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete PDN Connection Set Request", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeletePDNConnectionSetRequest into bytes.
-func (m *DeletePDNConnectionSetRequest) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
+func (d *DeletePDNConnectionSetRequest) Marshal() ([]byte, error) {
+	b := make([]byte, d.MarshalLen())
+	if err := d.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes DeletePDNConnectionSetRequest into bytes.
-func (m *DeletePDNConnectionSetRequest) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+func (d *DeletePDNConnectionSetRequest) MarshalTo(b []byte) error {
+	if d.Header.Payload != nil {
+		d.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	d.Header.Payload = make([]byte, d.MarshalLen()-d.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.MMEFQCSID; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.SGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.PGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.EPDGFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.EPDGFQCSID; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.TWANFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.TWANFQCSID; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	d.Header.SetLength()
+	return d.Header.MarshalTo(b)
 }
 
 // ParseDeletePDNConnectionSetRequest decodes given bytes as DeletePDNConnectionSetRequest.
 func ParseDeletePDNConnectionSetRequest(b []byte) (*DeletePDNConnectionSetRequest, error) {
-	m := &DeletePDNConnectionSetRequest{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	d := &DeletePDNConnectionSetRequest{}
+	if err := d.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return d, nil
 }
 
 // UnmarshalBinary decodes given bytes as DeletePDNConnectionSetRequest.
-func (m *DeletePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
+func (d *DeletePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	d.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(d.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -156,56 +190,36 @@ func (m *DeletePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			case 2:
-				m.PGWFQCSID = i
-			case 3:
-				m.EPDGFQCSID = i
-			case 4:
-				m.TWANFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (m *DeletePDNConnectionSetRequest) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+func (d *DeletePDNConnectionSetRequest) MarshalLen() int {
+	l := d.Header.MarshalLen() - len(d.Header.Payload)
 
-	if ie := m.MMEFQCSID; ie != nil {
+	if ie := d.MMEFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.SGWFQCSID; ie != nil {
+	if ie := d.SGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PGWFQCSID; ie != nil {
+	if ie := d.PGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.EPDGFQCSID; ie != nil {
+	if ie := d.EPDGFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.TWANFQCSID; ie != nil {
+	if ie := d.TWANFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := d.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -215,16 +229,109 @@ func (m *DeletePDNConnectionSetRequest) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *DeletePDNConnectionSetRequest) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (d *DeletePDNConnectionSetRequest) SetLength() {
+	d.Header.Length = uint16(d.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *DeletePDNConnectionSetRequest) MessageTypeName() string {
+func (d *DeletePDNConnectionSetRequest) MessageTypeName() string {
 	return "Delete PDN Connection Set Request"
 }
 
 // TEID returns the TEID in uint32.
-func (m *DeletePDNConnectionSetRequest) TEID() uint32 {
-	return m.Header.teid()
+func (d *DeletePDNConnectionSetRequest) TEID() uint32 {
+	return d.Header.teid()
+}
+
+// DeletePDNConnectionSetRequestBuilder builds DeletePDNConnectionSetRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeletePDNConnectionSetRequestBuilder struct {
+	builder
+	msg *DeletePDNConnectionSetRequest
+}
+
+// BuildDeletePDNConnectionSetRequest returns the builder of DeletePDNConnectionSetRequest.
+func BuildDeletePDNConnectionSetRequest(teid, seq uint32) *DeletePDNConnectionSetRequestBuilder {
+	return &DeletePDNConnectionSetRequestBuilder{
+		builder: builder{msgType: "Delete PDN Connection Set Request"},
+		msg:     NewDeletePDNConnectionSetRequest(teid, seq),
+	}
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *DeletePDNConnectionSetRequestBuilder) WithMMEFQCSID(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *DeletePDNConnectionSetRequestBuilder) WithSGWFQCSID(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 2.
+func (b *DeletePDNConnectionSetRequestBuilder) WithPGWFQCSID(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithEPDGFQCSID sets the FullyQualifiedCSID IE to EPDGFQCSID, with the instance set to 3.
+func (b *DeletePDNConnectionSetRequestBuilder) WithEPDGFQCSID(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("EPDGFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.EPDGFQCSID = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithTWANFQCSID sets the FullyQualifiedCSID IE to TWANFQCSID, with the instance set to 4.
+func (b *DeletePDNConnectionSetRequestBuilder) WithTWANFQCSID(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("TWANFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.TWANFQCSID = withInstance(i, 4)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeletePDNConnectionSetRequestBuilder) WithPrivateExtension(i *ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeletePDNConnectionSetRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeletePDNConnectionSetRequestBuilder) WithIEs(ies ...*ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeletePDNConnectionSetRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeletePDNConnectionSetRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeletePDNConnectionSetRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeletePDNConnectionSetRequestBuilder) Build() (*DeletePDNConnectionSetRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -19,7 +21,7 @@ type DeletePDNConnectionSetResponse struct {
 
 // NewDeletePDNConnectionSetResponse creates a new DeletePDNConnectionSetResponse.
 func NewDeletePDNConnectionSetResponse(teid, seq uint32, ies ...*ie.IE) *DeletePDNConnectionSetResponse {
-	m := &DeletePDNConnectionSetResponse{
+	d := &DeletePDNConnectionSetResponse{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeDeletePDNConnectionSetResponse, teid, seq, nil,
@@ -30,94 +32,118 @@ func NewDeletePDNConnectionSetResponse(teid, seq uint32, ies ...*ie.IE) *DeleteP
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
-	m.SetLength()
-	return m
+	d.SetLength()
+	return d
 }
 
-// Marshal returns the byte sequence generated from a DeletePDNConnectionSetResponse.
-func (m *DeletePDNConnectionSetResponse) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
-		return nil, err
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeletePDNConnectionSetResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeletePDNConnectionSetResponse, or 0
+// if it is set to one of the fields.
+func (d *DeletePDNConnectionSetResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeletePDNConnectionSetResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeletePDNConnectionSetResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete PDN Connection Set Response", d.AdditionalIEs, d.ieKind)
+}
 
+// Marshal serializes DeletePDNConnectionSetResponse into bytes.
+func (d *DeletePDNConnectionSetResponse) Marshal() ([]byte, error) {
+	b := make([]byte, d.MarshalLen())
+	if err := d.MarshalTo(b); err != nil {
+		return nil, err
+	}
 	return b, nil
 }
 
-// MarshalTo puts the byte sequence in the byte array given as b.
-func (m *DeletePDNConnectionSetResponse) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+// MarshalTo serializes DeletePDNConnectionSetResponse into bytes.
+func (d *DeletePDNConnectionSetResponse) MarshalTo(b []byte) error {
+	if d.Header.Payload != nil {
+		d.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	d.Header.Payload = make([]byte, d.MarshalLen()-d.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.Cause; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.Recovery; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	d.Header.SetLength()
+	return d.Header.MarshalTo(b)
 }
 
-// ParseDeletePDNConnectionSetResponse decodes a given byte sequence as a DeletePDNConnectionSetResponse.
+// ParseDeletePDNConnectionSetResponse decodes given bytes as DeletePDNConnectionSetResponse.
 func ParseDeletePDNConnectionSetResponse(b []byte) (*DeletePDNConnectionSetResponse, error) {
-	m := &DeletePDNConnectionSetResponse{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	d := &DeletePDNConnectionSetResponse{}
+	if err := d.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return d, nil
 }
 
-// UnmarshalBinary decodes given byte sequence as DeletePDNConnectionSetResponse.
-func (m *DeletePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
+// UnmarshalBinary decodes given bytes as DeletePDNConnectionSetResponse.
+func (d *DeletePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	d.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(d.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -125,36 +151,27 @@ func (m *DeletePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
 }
 
-// MarshalLen returns the serial length of Data.
-func (m *DeletePDNConnectionSetResponse) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+// MarshalLen returns the serial length in int.
+func (d *DeletePDNConnectionSetResponse) MarshalLen() int {
+	l := d.Header.MarshalLen() - len(d.Header.Payload)
 
-	if ie := m.Cause; ie != nil {
+	if ie := d.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
+	if ie := d.Recovery; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := d.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -164,16 +181,85 @@ func (m *DeletePDNConnectionSetResponse) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *DeletePDNConnectionSetResponse) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (d *DeletePDNConnectionSetResponse) SetLength() {
+	d.Header.Length = uint16(d.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *DeletePDNConnectionSetResponse) MessageTypeName() string {
+func (d *DeletePDNConnectionSetResponse) MessageTypeName() string {
 	return "Delete PDN Connection Set Response"
 }
 
 // TEID returns the TEID in uint32.
-func (m *DeletePDNConnectionSetResponse) TEID() uint32 {
-	return m.Header.teid()
+func (d *DeletePDNConnectionSetResponse) TEID() uint32 {
+	return d.Header.teid()
+}
+
+// DeletePDNConnectionSetResponseBuilder builds DeletePDNConnectionSetResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeletePDNConnectionSetResponseBuilder struct {
+	builder
+	msg *DeletePDNConnectionSetResponse
+}
+
+// BuildDeletePDNConnectionSetResponse returns the builder of DeletePDNConnectionSetResponse.
+func BuildDeletePDNConnectionSetResponse(teid, seq uint32) *DeletePDNConnectionSetResponseBuilder {
+	return &DeletePDNConnectionSetResponseBuilder{
+		builder: builder{msgType: "Delete PDN Connection Set Response"},
+		msg:     NewDeletePDNConnectionSetResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeletePDNConnectionSetResponseBuilder) WithCause(i *ie.IE) *DeletePDNConnectionSetResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DeletePDNConnectionSetResponseBuilder) WithRecovery(i *ie.IE) *DeletePDNConnectionSetResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeletePDNConnectionSetResponseBuilder) WithPrivateExtension(i *ie.IE) *DeletePDNConnectionSetResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeletePDNConnectionSetResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeletePDNConnectionSetResponseBuilder) WithIEs(ies ...*ie.IE) *DeletePDNConnectionSetResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeletePDNConnectionSetResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeletePDNConnectionSetResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeletePDNConnectionSetResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeletePDNConnectionSetResponseBuilder) Build() (*DeletePDNConnectionSetResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
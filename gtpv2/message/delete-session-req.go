@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -50,80 +52,136 @@ func NewDeleteSessionRequest(teid, seq uint32, ies ...*ie.IE) *DeleteSessionRequ
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.LinkedEBI = i
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.NodeType:
-			d.OriginatingNode = i
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.RANNASCause:
-			d.RANNASReleaseCause = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifier = i
-			case 1:
-				d.WLANLocationInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifierTimestamp = i
-			case 1:
-				d.WLANLocationTimeStamp = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformaion = i
-			case 2:
-				d.TWANePDGOverloadControlInformaion = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.IPAddress:
-			d.UELocalIPAddress = i
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				d.UEUDPPort = i
-			case 1:
-				d.UETCPPort = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATUsageDataReport = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteSessionRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.EPSBearerID:
+		d.LinkedEBI = i
+	case ie.UserLocationInformation:
+		d.ULI = i
+	case ie.Indication:
+		d.IndicationFlags = i
+	case ie.ProtocolConfigurationOptions:
+		d.PCO = i
+	case ie.NodeType:
+		d.OriginatingNode = i
+	case ie.FullyQualifiedTEID:
+		d.SenderFTEIDC = i
+	case ie.UETimeZone:
+		d.UETimeZone = i
+	case ie.ULITimestamp:
+		d.ULITimestamp = i
+	case ie.RANNASCause:
+		d.RANNASReleaseCause = i
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0:
+			d.TWANIdentifier = i
+		case 1:
+			d.WLANLocationInformation = i
 		default:
 			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 0:
+			d.TWANIdentifierTimestamp = i
+		case 1:
+			d.WLANLocationTimeStamp = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			d.MMESGSNOverloadControlInformation = i
+		case 1:
+			d.SGWOverloadControlInformaion = i
+		case 2:
+			d.TWANePDGOverloadControlInformaion = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.IPAddress:
+		d.UELocalIPAddress = i
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			d.UEUDPPort = i
+		case 1:
+			d.UETCPPort = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.ExtendedProtocolConfigurationOptions:
+		d.EPCO = i
+	case ie.SecondaryRATUsageDataReport:
+		d.SecondaryRATUsageDataReport = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeleteSessionRequest, or 0
+// if it is set to one of the fields.
+func (d *DeleteSessionRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.EPSBearerID, ie.UserLocationInformation, ie.Indication,
+		ie.ProtocolConfigurationOptions, ie.NodeType, ie.FullyQualifiedTEID, ie.UETimeZone,
+		ie.ULITimestamp, ie.RANNASCause, ie.IPAddress, ie.ExtendedProtocolConfigurationOptions,
+		ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
 		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	d.SetLength()
-	return d
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteSessionRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteSessionRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Session Request", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeleteSessionRequest into bytes.
@@ -144,139 +202,139 @@ func (d *DeleteSessionRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.OriginatingNode; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.ULITimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.RANNASReleaseCause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANIdentifier; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANIdentifierTimestamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformaion; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.TWANePDGOverloadControlInformaion; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.WLANLocationTimeStamp; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.EPCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.UETCPPort; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SecondaryRATUsageDataReport; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -320,81 +378,11 @@ func (d *DeleteSessionRequest) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.LinkedEBI = i
-		case ie.UserLocationInformation:
-			d.ULI = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.NodeType:
-			d.OriginatingNode = i
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.UETimeZone:
-			d.UETimeZone = i
-		case ie.ULITimestamp:
-			d.ULITimestamp = i
-		case ie.RANNASCause:
-			d.RANNASReleaseCause = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifier = i
-			case 1:
-				d.WLANLocationInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 0:
-				d.TWANIdentifierTimestamp = i
-			case 1:
-				d.WLANLocationTimeStamp = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				d.MMESGSNOverloadControlInformation = i
-			case 1:
-				d.SGWOverloadControlInformaion = i
-			case 2:
-				d.TWANePDGOverloadControlInformaion = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.IPAddress:
-			d.UELocalIPAddress = i
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				d.UEUDPPort = i
-			case 1:
-				d.UETCPPort = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.SecondaryRATUsageDataReport:
-			d.SecondaryRATUsageDataReport = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -497,3 +485,232 @@ func (d *DeleteSessionRequest) MessageTypeName() string {
 func (d *DeleteSessionRequest) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteSessionRequestBuilder builds DeleteSessionRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteSessionRequestBuilder struct {
+	builder
+	msg *DeleteSessionRequest
+}
+
+// BuildDeleteSessionRequest returns the builder of DeleteSessionRequest.
+func BuildDeleteSessionRequest(teid, seq uint32) *DeleteSessionRequestBuilder {
+	return &DeleteSessionRequestBuilder{
+		builder: builder{msgType: "Delete Session Request"},
+		msg:     NewDeleteSessionRequest(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeleteSessionRequestBuilder) WithCause(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *DeleteSessionRequestBuilder) WithLinkedEBI(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *DeleteSessionRequestBuilder) WithULI(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *DeleteSessionRequestBuilder) WithIndicationFlags(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *DeleteSessionRequestBuilder) WithPCO(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithOriginatingNode sets the NodeType IE to OriginatingNode.
+func (b *DeleteSessionRequestBuilder) WithOriginatingNode(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("OriginatingNode", i, ie.NodeType) {
+		b.msg.OriginatingNode = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *DeleteSessionRequestBuilder) WithSenderFTEIDC(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *DeleteSessionRequestBuilder) WithUETimeZone(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithULITimestamp sets the ULITimestamp IE to ULITimestamp.
+func (b *DeleteSessionRequestBuilder) WithULITimestamp(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("ULITimestamp", i, ie.ULITimestamp) {
+		b.msg.ULITimestamp = i
+	}
+	return b
+}
+
+// WithRANNASReleaseCause sets the RANNASCause IE to RANNASReleaseCause.
+func (b *DeleteSessionRequestBuilder) WithRANNASReleaseCause(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("RANNASReleaseCause", i, ie.RANNASCause) {
+		b.msg.RANNASReleaseCause = i
+	}
+	return b
+}
+
+// WithTWANIdentifier sets the TWANIdentifier IE to TWANIdentifier, with the instance set to 0.
+func (b *DeleteSessionRequestBuilder) WithTWANIdentifier(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("TWANIdentifier", i, ie.TWANIdentifier) {
+		b.msg.TWANIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithTWANIdentifierTimestamp sets the TWANIdentifierTimestamp IE to TWANIdentifierTimestamp, with the instance set to 0.
+func (b *DeleteSessionRequestBuilder) WithTWANIdentifierTimestamp(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("TWANIdentifierTimestamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.TWANIdentifierTimestamp = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *DeleteSessionRequestBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformaion sets the OverloadControlInformation IE to SGWOverloadControlInformaion, with the instance set to 1.
+func (b *DeleteSessionRequestBuilder) WithSGWOverloadControlInformaion(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("SGWOverloadControlInformaion", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformaion = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformaion sets the OverloadControlInformation IE to TWANePDGOverloadControlInformaion, with the instance set to 2.
+func (b *DeleteSessionRequestBuilder) WithTWANePDGOverloadControlInformaion(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("TWANePDGOverloadControlInformaion", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformaion = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation, with the instance set to 1.
+func (b *DeleteSessionRequestBuilder) WithWLANLocationInformation(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationTimeStamp sets the TWANIdentifierTimestamp IE to WLANLocationTimeStamp, with the instance set to 1.
+func (b *DeleteSessionRequestBuilder) WithWLANLocationTimeStamp(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("WLANLocationTimeStamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimeStamp = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress.
+func (b *DeleteSessionRequestBuilder) WithUELocalIPAddress(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = i
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *DeleteSessionRequestBuilder) WithUEUDPPort(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithEPCO sets the ExtendedProtocolConfigurationOptions IE to EPCO.
+func (b *DeleteSessionRequestBuilder) WithEPCO(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("EPCO", i, ie.ExtendedProtocolConfigurationOptions) {
+		b.msg.EPCO = i
+	}
+	return b
+}
+
+// WithUETCPPort sets the PortNumber IE to UETCPPort, with the instance set to 1.
+func (b *DeleteSessionRequestBuilder) WithUETCPPort(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("UETCPPort", i, ie.PortNumber) {
+		b.msg.UETCPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport sets the SecondaryRATUsageDataReport IE to SecondaryRATUsageDataReport.
+func (b *DeleteSessionRequestBuilder) WithSecondaryRATUsageDataReport(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+		b.msg.SecondaryRATUsageDataReport = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteSessionRequestBuilder) WithPrivateExtension(i *ie.IE) *DeleteSessionRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteSessionRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteSessionRequestBuilder) WithIEs(ies ...*ie.IE) *DeleteSessionRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteSessionRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteSessionRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteSessionRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteSessionRequestBuilder) Build() (*DeleteSessionRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
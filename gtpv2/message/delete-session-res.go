@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -39,48 +41,88 @@ func NewDeleteSessionResponse(teid, seq uint32, ies ...*ie.IE) *DeleteSessionRes
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 1:
-				d.PGWNodeLoadControlInformation = i
-			case 2:
-				d.PGWAPNLoadControlInformation = i
-			case 3:
-				d.SGWNodeLoadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 1:
-				d.PGWOverloadControlInformation = i
-			case 2:
-				d.SGWOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.APNRateControlStatus:
-			d.APNRateControlStatus = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DeleteSessionResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.ProtocolConfigurationOptions:
+		d.PCO = i
+	case ie.Indication:
+		d.IndicationFlags = i
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 1:
+			d.PGWNodeLoadControlInformation = i
+		case 2:
+			d.PGWAPNLoadControlInformation = i
+		case 3:
+			d.SGWNodeLoadControlInformation = i
 		default:
 			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
 		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 1:
+			d.PGWOverloadControlInformation = i
+		case 2:
+			d.SGWOverloadControlInformation = i
+		default:
+			d.AdditionalIEs = append(d.AdditionalIEs, i)
+			return false
+		}
+	case ie.ExtendedProtocolConfigurationOptions:
+		d.EPCO = i
+	case ie.APNRateControlStatus:
+		d.APNRateControlStatus = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DeleteSessionResponse, or 0
+// if it is set to one of the fields.
+func (d *DeleteSessionResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.ProtocolConfigurationOptions, ie.Indication,
+		ie.ExtendedProtocolConfigurationOptions, ie.APNRateControlStatus, ie.PrivateExtension:
+		return 0
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	d.SetLength()
-	return d
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DeleteSessionResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DeleteSessionResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Delete Session Response", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DeleteSessionResponse into bytes.
@@ -101,73 +143,73 @@ func (d *DeleteSessionResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.EPCO; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.APNRateControlStatus; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -211,49 +253,11 @@ func (d *DeleteSessionResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.ProtocolConfigurationOptions:
-			d.PCO = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 1:
-				d.PGWNodeLoadControlInformation = i
-			case 2:
-				d.PGWAPNLoadControlInformation = i
-			case 3:
-				d.SGWNodeLoadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 1:
-				d.PGWOverloadControlInformation = i
-			case 2:
-				d.SGWOverloadControlInformation = i
-			default:
-				d.AdditionalIEs = append(d.AdditionalIEs, i)
-			}
-		case ie.ExtendedProtocolConfigurationOptions:
-			d.EPCO = i
-		case ie.APNRateControlStatus:
-			d.APNRateControlStatus = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -323,3 +327,144 @@ func (d *DeleteSessionResponse) MessageTypeName() string {
 func (d *DeleteSessionResponse) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DeleteSessionResponseBuilder builds DeleteSessionResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DeleteSessionResponseBuilder struct {
+	builder
+	msg *DeleteSessionResponse
+}
+
+// BuildDeleteSessionResponse returns the builder of DeleteSessionResponse.
+func BuildDeleteSessionResponse(teid, seq uint32) *DeleteSessionResponseBuilder {
+	return &DeleteSessionResponseBuilder{
+		builder: builder{msgType: "Delete Session Response"},
+		msg:     NewDeleteSessionResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DeleteSessionResponseBuilder) WithCause(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DeleteSessionResponseBuilder) WithRecovery(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *DeleteSessionResponseBuilder) WithPCO(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *DeleteSessionResponseBuilder) WithIndicationFlags(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 1.
+func (b *DeleteSessionResponseBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 2.
+func (b *DeleteSessionResponseBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 3.
+func (b *DeleteSessionResponseBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 1.
+func (b *DeleteSessionResponseBuilder) WithPGWOverloadControlInformation(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 2.
+func (b *DeleteSessionResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithEPCO sets the ExtendedProtocolConfigurationOptions IE to EPCO.
+func (b *DeleteSessionResponseBuilder) WithEPCO(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("EPCO", i, ie.ExtendedProtocolConfigurationOptions) {
+		b.msg.EPCO = i
+	}
+	return b
+}
+
+// WithAPNRateControlStatus sets the APNRateControlStatus IE to APNRateControlStatus.
+func (b *DeleteSessionResponseBuilder) WithAPNRateControlStatus(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("APNRateControlStatus", i, ie.APNRateControlStatus) {
+		b.msg.APNRateControlStatus = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DeleteSessionResponseBuilder) WithPrivateExtension(i *ie.IE) *DeleteSessionResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDeleteSessionResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DeleteSessionResponseBuilder) WithIEs(ies ...*ie.IE) *DeleteSessionResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DeleteSessionResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *DeleteSessionResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DeleteSessionResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DeleteSessionResponseBuilder) Build() (*DeleteSessionResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
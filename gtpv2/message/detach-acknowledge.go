@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -19,7 +21,7 @@ type DetachAcknowledge struct {
 
 // NewDetachAcknowledge creates a new DetachAcknowledge.
 func NewDetachAcknowledge(teid, seq uint32, ies ...*ie.IE) *DetachAcknowledge {
-	m := &DetachAcknowledge{
+	d := &DetachAcknowledge{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeDetachAcknowledge, teid, seq, nil,
@@ -30,94 +32,118 @@ func NewDetachAcknowledge(teid, seq uint32, ies ...*ie.IE) *DetachAcknowledge {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
-	m.SetLength()
-	return m
+	d.SetLength()
+	return d
 }
 
-// Marshal returns the byte sequence generated from a DetachAcknowledge.
-func (m *DetachAcknowledge) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
-		return nil, err
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DetachAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DetachAcknowledge, or 0
+// if it is set to one of the fields.
+func (d *DetachAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DetachAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DetachAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Detach Acknowledge", d.AdditionalIEs, d.ieKind)
+}
 
+// Marshal serializes DetachAcknowledge into bytes.
+func (d *DetachAcknowledge) Marshal() ([]byte, error) {
+	b := make([]byte, d.MarshalLen())
+	if err := d.MarshalTo(b); err != nil {
+		return nil, err
+	}
 	return b, nil
 }
 
-// MarshalTo puts the byte sequence in the byte array given as b.
-func (m *DetachAcknowledge) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+// MarshalTo serializes DetachAcknowledge into bytes.
+func (d *DetachAcknowledge) MarshalTo(b []byte) error {
+	if d.Header.Payload != nil {
+		d.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	d.Header.Payload = make([]byte, d.MarshalLen()-d.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.Cause; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.Recovery; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := d.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	d.Header.SetLength()
+	return d.Header.MarshalTo(b)
 }
 
-// ParseDetachAcknowledge decodes a given byte sequence as a DetachAcknowledge.
+// ParseDetachAcknowledge decodes given bytes as DetachAcknowledge.
 func ParseDetachAcknowledge(b []byte) (*DetachAcknowledge, error) {
-	m := &DetachAcknowledge{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	d := &DetachAcknowledge{}
+	if err := d.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return d, nil
 }
 
-// UnmarshalBinary decodes given byte sequence as DetachAcknowledge.
-func (m *DetachAcknowledge) UnmarshalBinary(b []byte) error {
+// UnmarshalBinary decodes given bytes as DetachAcknowledge.
+func (d *DetachAcknowledge) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	d.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(d.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -125,36 +151,27 @@ func (m *DetachAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
 }
 
-// MarshalLen returns the serial length of Data.
-func (m *DetachAcknowledge) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+// MarshalLen returns the serial length in int.
+func (d *DetachAcknowledge) MarshalLen() int {
+	l := d.Header.MarshalLen() - len(d.Header.Payload)
 
-	if ie := m.Cause; ie != nil {
+	if ie := d.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
+	if ie := d.Recovery; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := d.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -164,16 +181,85 @@ func (m *DetachAcknowledge) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *DetachAcknowledge) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (d *DetachAcknowledge) SetLength() {
+	d.Header.Length = uint16(d.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *DetachAcknowledge) MessageTypeName() string {
+func (d *DetachAcknowledge) MessageTypeName() string {
 	return "Detach Acknowledge"
 }
 
 // TEID returns the TEID in uint32.
-func (m *DetachAcknowledge) TEID() uint32 {
-	return m.Header.teid()
+func (d *DetachAcknowledge) TEID() uint32 {
+	return d.Header.teid()
+}
+
+// DetachAcknowledgeBuilder builds DetachAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DetachAcknowledgeBuilder struct {
+	builder
+	msg *DetachAcknowledge
+}
+
+// BuildDetachAcknowledge returns the builder of DetachAcknowledge.
+func BuildDetachAcknowledge(teid, seq uint32) *DetachAcknowledgeBuilder {
+	return &DetachAcknowledgeBuilder{
+		builder: builder{msgType: "Detach Acknowledge"},
+		msg:     NewDetachAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DetachAcknowledgeBuilder) WithCause(i *ie.IE) *DetachAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DetachAcknowledgeBuilder) WithRecovery(i *ie.IE) *DetachAcknowledgeBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DetachAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *DetachAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDetachAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DetachAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *DetachAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DetachAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *DetachAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DetachAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DetachAcknowledgeBuilder) Build() (*DetachAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -19,7 +21,7 @@ type DetachNotification struct {
 
 // NewDetachNotification creates a new DetachNotification.
 func NewDetachNotification(teid, seq uint32, ies ...*ie.IE) *DetachNotification {
-	m := &DetachNotification{
+	d := &DetachNotification{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeDetachNotification, teid, seq, nil,
@@ -30,93 +32,118 @@ func NewDetachNotification(teid, seq uint32, ies ...*ie.IE) *DetachNotification
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.DetachType:
-			m.DetachType = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
+	}
+
+	d.SetLength()
+	return d
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DetachNotification) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.DetachType:
+		d.DetachType = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DetachNotification, or 0
+// if it is set to one of the fields.
+func (d *DetachNotification) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.DetachType, ie.PrivateExtension:
+		return 0
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DetachNotification, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DetachNotification) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Detach Notification", d.AdditionalIEs, d.ieKind)
 }
 
 // Marshal serializes DetachNotification into bytes.
-func (m *DetachNotification) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
+func (d *DetachNotification) Marshal() ([]byte, error) {
+	b := make([]byte, d.MarshalLen())
+	if err := d.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes DetachNotification into bytes.
-func (m *DetachNotification) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+func (d *DetachNotification) MarshalTo(b []byte) error {
+	if d.Header.Payload != nil {
+		d.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	d.Header.Payload = make([]byte, d.MarshalLen()-d.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.Cause; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.DetachType; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.DetachType; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := d.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	d.Header.SetLength()
+	return d.Header.MarshalTo(b)
 }
 
 // ParseDetachNotification decodes given bytes as DetachNotification.
 func ParseDetachNotification(b []byte) (*DetachNotification, error) {
-	m := &DetachNotification{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	d := &DetachNotification{}
+	if err := d.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return d, nil
 }
 
 // UnmarshalBinary decodes given bytes as DetachNotification.
-func (m *DetachNotification) UnmarshalBinary(b []byte) error {
+func (d *DetachNotification) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	d.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(d.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(d.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -124,36 +151,27 @@ func (m *DetachNotification) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.DetachType:
-			m.DetachType = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (m *DetachNotification) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+func (d *DetachNotification) MarshalLen() int {
+	l := d.Header.MarshalLen() - len(d.Header.Payload)
 
-	if ie := m.Cause; ie != nil {
+	if ie := d.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.DetachType; ie != nil {
+	if ie := d.DetachType; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := d.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range d.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -163,16 +181,85 @@ func (m *DetachNotification) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *DetachNotification) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (d *DetachNotification) SetLength() {
+	d.Header.Length = uint16(d.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *DetachNotification) MessageTypeName() string {
+func (d *DetachNotification) MessageTypeName() string {
 	return "Detach Notification"
 }
 
 // TEID returns the TEID in uint32.
-func (m *DetachNotification) TEID() uint32 {
-	return m.Header.teid()
+func (d *DetachNotification) TEID() uint32 {
+	return d.Header.teid()
+}
+
+// DetachNotificationBuilder builds DetachNotification.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DetachNotificationBuilder struct {
+	builder
+	msg *DetachNotification
+}
+
+// BuildDetachNotification returns the builder of DetachNotification.
+func BuildDetachNotification(teid, seq uint32) *DetachNotificationBuilder {
+	return &DetachNotificationBuilder{
+		builder: builder{msgType: "Detach Notification"},
+		msg:     NewDetachNotification(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DetachNotificationBuilder) WithCause(i *ie.IE) *DetachNotificationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithDetachType sets the DetachType IE to DetachType.
+func (b *DetachNotificationBuilder) WithDetachType(i *ie.IE) *DetachNotificationBuilder {
+	if b.expect("DetachType", i, ie.DetachType) {
+		b.msg.DetachType = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DetachNotificationBuilder) WithPrivateExtension(i *ie.IE) *DetachNotificationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDetachNotification. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DetachNotificationBuilder) WithIEs(ies ...*ie.IE) *DetachNotificationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DetachNotificationBuilder) WithAdditionalIEs(ies ...*ie.IE) *DetachNotificationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DetachNotification built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DetachNotificationBuilder) Build() (*DetachNotification, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
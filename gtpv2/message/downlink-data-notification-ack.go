@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -35,32 +37,58 @@ func NewDownlinkDataNotificationAcknowledge(teid, seq uint32, ies ...*ie.IE) *Do
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.DelayValue:
-			d.DataNotificationDelay = i
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.Throttling:
-			d.DLLowPriorityTrafficThrottling = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.EPCTimer:
-			d.DLBufferingDuration = i
-		case ie.IntegerNumber:
-			d.DLBufferingSuggestedPacketCount = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	d.SetLength()
 	return d
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DownlinkDataNotificationAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.DelayValue:
+		d.DataNotificationDelay = i
+	case ie.Recovery:
+		d.Recovery = i
+	case ie.Throttling:
+		d.DLLowPriorityTrafficThrottling = i
+	case ie.IMSI:
+		d.IMSI = i
+	case ie.EPCTimer:
+		d.DLBufferingDuration = i
+	case ie.IntegerNumber:
+		d.DLBufferingSuggestedPacketCount = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DownlinkDataNotificationAcknowledge, or 0
+// if it is set to one of the fields.
+func (d *DownlinkDataNotificationAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.DelayValue, ie.Recovery, ie.Throttling,
+		ie.IMSI, ie.EPCTimer, ie.IntegerNumber, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DownlinkDataNotificationAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DownlinkDataNotificationAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Downlink Data Notification Acknowledge", d.AdditionalIEs, d.ieKind)
+}
+
 // Marshal serializes DownlinkDataNotificationAcknowledge into bytes.
 func (d *DownlinkDataNotificationAcknowledge) Marshal() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -79,49 +107,49 @@ func (d *DownlinkDataNotificationAcknowledge) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.DataNotificationDelay; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.Recovery; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.DLLowPriorityTrafficThrottling; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IMSI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.DLBufferingDuration; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.DLBufferingSuggestedPacketCount; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -169,26 +197,7 @@ func (d *DownlinkDataNotificationAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.DelayValue:
-			d.DataNotificationDelay = i
-		case ie.Recovery:
-			d.Recovery = i
-		case ie.Throttling:
-			d.DLLowPriorityTrafficThrottling = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.EPCTimer:
-			d.DLBufferingDuration = i
-		case ie.IntegerNumber:
-			d.DLBufferingSuggestedPacketCount = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -246,3 +255,112 @@ func (d *DownlinkDataNotificationAcknowledge) MessageTypeName() string {
 func (d *DownlinkDataNotificationAcknowledge) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DownlinkDataNotificationAcknowledgeBuilder builds DownlinkDataNotificationAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DownlinkDataNotificationAcknowledgeBuilder struct {
+	builder
+	msg *DownlinkDataNotificationAcknowledge
+}
+
+// BuildDownlinkDataNotificationAcknowledge returns the builder of DownlinkDataNotificationAcknowledge.
+func BuildDownlinkDataNotificationAcknowledge(teid, seq uint32) *DownlinkDataNotificationAcknowledgeBuilder {
+	return &DownlinkDataNotificationAcknowledgeBuilder{
+		builder: builder{msgType: "Downlink Data Notification Acknowledge"},
+		msg:     NewDownlinkDataNotificationAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithCause(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithDataNotificationDelay sets the DelayValue IE to DataNotificationDelay.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithDataNotificationDelay(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("DataNotificationDelay", i, ie.DelayValue) {
+		b.msg.DataNotificationDelay = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithRecovery(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithDLLowPriorityTrafficThrottling sets the Throttling IE to DLLowPriorityTrafficThrottling.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithDLLowPriorityTrafficThrottling(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("DLLowPriorityTrafficThrottling", i, ie.Throttling) {
+		b.msg.DLLowPriorityTrafficThrottling = i
+	}
+	return b
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithIMSI(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithDLBufferingDuration sets the EPCTimer IE to DLBufferingDuration.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithDLBufferingDuration(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("DLBufferingDuration", i, ie.EPCTimer) {
+		b.msg.DLBufferingDuration = i
+	}
+	return b
+}
+
+// WithDLBufferingSuggestedPacketCount sets the IntegerNumber IE to DLBufferingSuggestedPacketCount.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithDLBufferingSuggestedPacketCount(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("DLBufferingSuggestedPacketCount", i, ie.IntegerNumber) {
+		b.msg.DLBufferingSuggestedPacketCount = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDownlinkDataNotificationAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *DownlinkDataNotificationAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DownlinkDataNotificationAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DownlinkDataNotificationAcknowledgeBuilder) Build() (*DownlinkDataNotificationAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -31,24 +33,49 @@ func NewDownlinkDataNotificationFailureIndication(teid, seq uint32, ies ...*ie.I
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.NodeType:
-			d.OriginatingNode = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	d.SetLength()
 	return d
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DownlinkDataNotificationFailureIndication) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.NodeType:
+		d.OriginatingNode = i
+	case ie.IMSI:
+		d.IMSI = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DownlinkDataNotificationFailureIndication, or 0
+// if it is set to one of the fields.
+func (d *DownlinkDataNotificationFailureIndication) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.NodeType, ie.IMSI, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DownlinkDataNotificationFailureIndication, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DownlinkDataNotificationFailureIndication) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Downlink Data Notification Failure Indication", d.AdditionalIEs, d.ieKind)
+}
+
 // Marshal serializes DownlinkDataNotificationFailureIndication into bytes.
 func (d *DownlinkDataNotificationFailureIndication) Marshal() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -67,25 +94,25 @@ func (d *DownlinkDataNotificationFailureIndication) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.OriginatingNode; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IMSI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -133,18 +160,7 @@ func (d *DownlinkDataNotificationFailureIndication) UnmarshalBinary(b []byte) er
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.NodeType:
-			d.OriginatingNode = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -190,3 +206,80 @@ func (d *DownlinkDataNotificationFailureIndication) MessageTypeName() string {
 func (d *DownlinkDataNotificationFailureIndication) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DownlinkDataNotificationFailureIndicationBuilder builds DownlinkDataNotificationFailureIndication.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DownlinkDataNotificationFailureIndicationBuilder struct {
+	builder
+	msg *DownlinkDataNotificationFailureIndication
+}
+
+// BuildDownlinkDataNotificationFailureIndication returns the builder of DownlinkDataNotificationFailureIndication.
+func BuildDownlinkDataNotificationFailureIndication(teid, seq uint32) *DownlinkDataNotificationFailureIndicationBuilder {
+	return &DownlinkDataNotificationFailureIndicationBuilder{
+		builder: builder{msgType: "Downlink Data Notification Failure Indication"},
+		msg:     NewDownlinkDataNotificationFailureIndication(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithCause(i *ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithOriginatingNode sets the NodeType IE to OriginatingNode.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithOriginatingNode(i *ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	if b.expect("OriginatingNode", i, ie.NodeType) {
+		b.msg.OriginatingNode = i
+	}
+	return b
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithIMSI(i *ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithPrivateExtension(i *ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDownlinkDataNotificationFailureIndication. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithIEs(ies ...*ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) WithAdditionalIEs(ies ...*ie.IE) *DownlinkDataNotificationFailureIndicationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DownlinkDataNotificationFailureIndication built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DownlinkDataNotificationFailureIndicationBuilder) Build() (*DownlinkDataNotificationFailureIndication, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -38,38 +40,65 @@ func NewDownlinkDataNotification(teid, seq uint32, ies ...*ie.IE) *DownlinkDataN
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.EPSBearerID = i
-		case ie.AllocationRetensionPriority:
-			d.AllocationRetensionPriority = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			d.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			d.SGWOverloadControlInformation = i
-		case ie.PagingAndServiceInformation:
-			d.PagingAndServiceInformation = i
-		case ie.IntegerNumber:
-			d.DLDataPacketsSize = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	d.SetLength()
 	return d
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (d *DownlinkDataNotification) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		d.Cause = i
+	case ie.EPSBearerID:
+		d.EPSBearerID = i
+	case ie.AllocationRetensionPriority:
+		d.AllocationRetensionPriority = i
+	case ie.IMSI:
+		d.IMSI = i
+	case ie.FullyQualifiedTEID:
+		d.SenderFTEIDC = i
+	case ie.Indication:
+		d.IndicationFlags = i
+	case ie.LoadControlInformation:
+		d.SGWNodeLoadControlInformation = i
+	case ie.OverloadControlInformation:
+		d.SGWOverloadControlInformation = i
+	case ie.PagingAndServiceInformation:
+		d.PagingAndServiceInformation = i
+	case ie.IntegerNumber:
+		d.DLDataPacketsSize = i
+	case ie.PrivateExtension:
+		d.PrivateExtension = i
+	default:
+		d.AdditionalIEs = append(d.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to DownlinkDataNotification, or 0
+// if it is set to one of the fields.
+func (d *DownlinkDataNotification) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.EPSBearerID, ie.AllocationRetensionPriority, ie.IMSI,
+		ie.FullyQualifiedTEID, ie.Indication, ie.LoadControlInformation, ie.OverloadControlInformation,
+		ie.PagingAndServiceInformation, ie.IntegerNumber, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// DownlinkDataNotification, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (d *DownlinkDataNotification) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Downlink Data Notification", d.AdditionalIEs, d.ieKind)
+}
+
 // Marshal serializes DownlinkDataNotification into bytes.
 func (d *DownlinkDataNotification) Marshal() ([]byte, error) {
 	b := make([]byte, d.MarshalLen())
@@ -88,67 +117,67 @@ func (d *DownlinkDataNotification) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := d.Cause; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.EPSBearerID; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.AllocationRetensionPriority; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IMSI; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PagingAndServiceInformation; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.DLDataPacketsSize; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := d.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(d.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(d.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -196,32 +225,7 @@ func (d *DownlinkDataNotification) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			d.Cause = i
-		case ie.EPSBearerID:
-			d.EPSBearerID = i
-		case ie.AllocationRetensionPriority:
-			d.AllocationRetensionPriority = i
-		case ie.IMSI:
-			d.IMSI = i
-		case ie.FullyQualifiedTEID:
-			d.SenderFTEIDC = i
-		case ie.Indication:
-			d.IndicationFlags = i
-		case ie.LoadControlInformation:
-			d.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			d.SGWOverloadControlInformation = i
-		case ie.PagingAndServiceInformation:
-			d.PagingAndServiceInformation = i
-		case ie.IntegerNumber:
-			d.DLDataPacketsSize = i
-		case ie.PrivateExtension:
-			d.PrivateExtension = i
-		default:
-			d.AdditionalIEs = append(d.AdditionalIEs, i)
-		}
+		d.setIE(i)
 	}
 
 	return nil
@@ -288,3 +292,136 @@ func (d *DownlinkDataNotification) MessageTypeName() string {
 func (d *DownlinkDataNotification) TEID() uint32 {
 	return d.Header.teid()
 }
+
+// DownlinkDataNotificationBuilder builds DownlinkDataNotification.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type DownlinkDataNotificationBuilder struct {
+	builder
+	msg *DownlinkDataNotification
+}
+
+// BuildDownlinkDataNotification returns the builder of DownlinkDataNotification.
+func BuildDownlinkDataNotification(teid, seq uint32) *DownlinkDataNotificationBuilder {
+	return &DownlinkDataNotificationBuilder{
+		builder: builder{msgType: "Downlink Data Notification"},
+		msg:     NewDownlinkDataNotification(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *DownlinkDataNotificationBuilder) WithCause(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithEPSBearerID sets the EPSBearerID IE to EPSBearerID.
+func (b *DownlinkDataNotificationBuilder) WithEPSBearerID(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("EPSBearerID", i, ie.EPSBearerID) {
+		b.msg.EPSBearerID = i
+	}
+	return b
+}
+
+// WithAllocationRetensionPriority sets the AllocationRetensionPriority IE to AllocationRetensionPriority.
+func (b *DownlinkDataNotificationBuilder) WithAllocationRetensionPriority(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("AllocationRetensionPriority", i, ie.AllocationRetensionPriority) {
+		b.msg.AllocationRetensionPriority = i
+	}
+	return b
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *DownlinkDataNotificationBuilder) WithIMSI(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *DownlinkDataNotificationBuilder) WithSenderFTEIDC(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *DownlinkDataNotificationBuilder) WithIndicationFlags(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation.
+func (b *DownlinkDataNotificationBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = i
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation.
+func (b *DownlinkDataNotificationBuilder) WithSGWOverloadControlInformation(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = i
+	}
+	return b
+}
+
+// WithPagingAndServiceInformation sets the PagingAndServiceInformation IE to PagingAndServiceInformation.
+func (b *DownlinkDataNotificationBuilder) WithPagingAndServiceInformation(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("PagingAndServiceInformation", i, ie.PagingAndServiceInformation) {
+		b.msg.PagingAndServiceInformation = i
+	}
+	return b
+}
+
+// WithDLDataPacketsSize sets the IntegerNumber IE to DLDataPacketsSize.
+func (b *DownlinkDataNotificationBuilder) WithDLDataPacketsSize(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("DLDataPacketsSize", i, ie.IntegerNumber) {
+		b.msg.DLDataPacketsSize = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *DownlinkDataNotificationBuilder) WithPrivateExtension(i *ie.IE) *DownlinkDataNotificationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewDownlinkDataNotification. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *DownlinkDataNotificationBuilder) WithIEs(ies ...*ie.IE) *DownlinkDataNotificationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *DownlinkDataNotificationBuilder) WithAdditionalIEs(ies ...*ie.IE) *DownlinkDataNotificationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the DownlinkDataNotification built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *DownlinkDataNotificationBuilder) Build() (*DownlinkDataNotification, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -30,33 +32,57 @@ func NewEchoRequest(seq uint32, ies ...*ie.IE) *EchoRequest {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Recovery:
-			e.Recovery = i
-		case ie.NodeFeatures:
-			e.SendingNodeFeatures = i
-		case ie.PrivateExtension:
-			e.PrivateExtension = i
-		default:
-			e.AdditionalIEs = append(e.AdditionalIEs, i)
-		}
+		e.setIE(i)
 	}
 
 	e.SetLength()
 	return e
 }
 
-// Marshal returns the byte sequence generated from a EchoRequest.
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (e *EchoRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Recovery:
+		e.Recovery = i
+	case ie.NodeFeatures:
+		e.SendingNodeFeatures = i
+	case ie.PrivateExtension:
+		e.PrivateExtension = i
+	default:
+		e.AdditionalIEs = append(e.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to EchoRequest, or 0
+// if it is set to one of the fields.
+func (e *EchoRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Recovery, ie.NodeFeatures, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// EchoRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (e *EchoRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Echo Request", e.AdditionalIEs, e.ieKind)
+}
+
+// Marshal serializes EchoRequest into bytes.
 func (e *EchoRequest) Marshal() ([]byte, error) {
 	b := make([]byte, e.MarshalLen())
 	if err := e.MarshalTo(b); err != nil {
 		return nil, err
 	}
-
 	return b, nil
 }
 
-// MarshalTo puts the byte sequence in the byte array given as b.
+// MarshalTo serializes EchoRequest into bytes.
 func (e *EchoRequest) MarshalTo(b []byte) error {
 	if e.Header.Payload != nil {
 		e.Header.Payload = nil
@@ -84,6 +110,9 @@ func (e *EchoRequest) MarshalTo(b []byte) error {
 	}
 
 	for _, ie := range e.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
 		if err := ie.MarshalTo(e.Header.Payload[offset:]); err != nil {
 			return err
 		}
@@ -94,7 +123,7 @@ func (e *EchoRequest) MarshalTo(b []byte) error {
 	return e.Header.MarshalTo(b)
 }
 
-// ParseEchoRequest decodes a given byte sequence as a EchoRequest.
+// ParseEchoRequest decodes given bytes as EchoRequest.
 func ParseEchoRequest(b []byte) (*EchoRequest, error) {
 	e := &EchoRequest{}
 	if err := e.UnmarshalBinary(b); err != nil {
@@ -103,7 +132,7 @@ func ParseEchoRequest(b []byte) (*EchoRequest, error) {
 	return e, nil
 }
 
-// UnmarshalBinary decodes a given byte sequence as a EchoRequest.
+// UnmarshalBinary decodes given bytes as EchoRequest.
 func (e *EchoRequest) UnmarshalBinary(b []byte) error {
 	var err error
 	e.Header, err = ParseHeader(b)
@@ -118,27 +147,17 @@ func (e *EchoRequest) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Recovery:
-			e.Recovery = i
-		case ie.NodeFeatures:
-			e.SendingNodeFeatures = i
-		case ie.PrivateExtension:
-			e.PrivateExtension = i
-		default:
-			e.AdditionalIEs = append(e.AdditionalIEs, i)
-		}
+		e.setIE(i)
 	}
 
 	return nil
 }
 
-// MarshalLen returns the serial length of Data.
+// MarshalLen returns the serial length in int.
 func (e *EchoRequest) MarshalLen() int {
 	l := e.Header.MarshalLen() - len(e.Header.Payload)
 
@@ -153,9 +172,11 @@ func (e *EchoRequest) MarshalLen() int {
 	}
 
 	for _, ie := range e.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
-
 	return l
 }
 
@@ -173,3 +194,72 @@ func (e *EchoRequest) MessageTypeName() string {
 func (e *EchoRequest) TEID() uint32 {
 	return e.Header.teid()
 }
+
+// EchoRequestBuilder builds EchoRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type EchoRequestBuilder struct {
+	builder
+	msg *EchoRequest
+}
+
+// BuildEchoRequest returns the builder of EchoRequest.
+func BuildEchoRequest(seq uint32) *EchoRequestBuilder {
+	return &EchoRequestBuilder{
+		builder: builder{msgType: "Echo Request"},
+		msg:     NewEchoRequest(seq),
+	}
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *EchoRequestBuilder) WithRecovery(i *ie.IE) *EchoRequestBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithSendingNodeFeatures sets the NodeFeatures IE to SendingNodeFeatures.
+func (b *EchoRequestBuilder) WithSendingNodeFeatures(i *ie.IE) *EchoRequestBuilder {
+	if b.expect("SendingNodeFeatures", i, ie.NodeFeatures) {
+		b.msg.SendingNodeFeatures = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *EchoRequestBuilder) WithPrivateExtension(i *ie.IE) *EchoRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewEchoRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *EchoRequestBuilder) WithIEs(ies ...*ie.IE) *EchoRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *EchoRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *EchoRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the EchoRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *EchoRequestBuilder) Build() (*EchoRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -30,33 +32,57 @@ func NewEchoResponse(seq uint32, ies ...*ie.IE) *EchoResponse {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Recovery:
-			e.Recovery = i
-		case ie.NodeFeatures:
-			e.SendingNodeFeatures = i
-		case ie.PrivateExtension:
-			e.PrivateExtension = i
-		default:
-			e.AdditionalIEs = append(e.AdditionalIEs, i)
-		}
+		e.setIE(i)
 	}
 
 	e.SetLength()
 	return e
 }
 
-// Marshal returns the byte sequence generated from a EchoResponse.
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (e *EchoResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Recovery:
+		e.Recovery = i
+	case ie.NodeFeatures:
+		e.SendingNodeFeatures = i
+	case ie.PrivateExtension:
+		e.PrivateExtension = i
+	default:
+		e.AdditionalIEs = append(e.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to EchoResponse, or 0
+// if it is set to one of the fields.
+func (e *EchoResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Recovery, ie.NodeFeatures, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// EchoResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (e *EchoResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Echo Response", e.AdditionalIEs, e.ieKind)
+}
+
+// Marshal serializes EchoResponse into bytes.
 func (e *EchoResponse) Marshal() ([]byte, error) {
 	b := make([]byte, e.MarshalLen())
 	if err := e.MarshalTo(b); err != nil {
 		return nil, err
 	}
-
 	return b, nil
 }
 
-// MarshalTo puts the byte sequence in the byte array given as b.
+// MarshalTo serializes EchoResponse into bytes.
 func (e *EchoResponse) MarshalTo(b []byte) error {
 	if e.Header.Payload != nil {
 		e.Header.Payload = nil
@@ -97,7 +123,7 @@ func (e *EchoResponse) MarshalTo(b []byte) error {
 	return e.Header.MarshalTo(b)
 }
 
-// ParseEchoResponse decodes a given byte sequence as a EchoResponse.
+// ParseEchoResponse decodes given bytes as EchoResponse.
 func ParseEchoResponse(b []byte) (*EchoResponse, error) {
 	e := &EchoResponse{}
 	if err := e.UnmarshalBinary(b); err != nil {
@@ -106,7 +132,7 @@ func ParseEchoResponse(b []byte) (*EchoResponse, error) {
 	return e, nil
 }
 
-// UnmarshalBinary decodes a given byte sequence as a EchoResponse.
+// UnmarshalBinary decodes given bytes as EchoResponse.
 func (e *EchoResponse) UnmarshalBinary(b []byte) error {
 	var err error
 	e.Header, err = ParseHeader(b)
@@ -121,27 +147,17 @@ func (e *EchoResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Recovery:
-			e.Recovery = i
-		case ie.NodeFeatures:
-			e.SendingNodeFeatures = i
-		case ie.PrivateExtension:
-			e.PrivateExtension = i
-		default:
-			e.AdditionalIEs = append(e.AdditionalIEs, i)
-		}
+		e.setIE(i)
 	}
 
 	return nil
 }
 
-// MarshalLen returns the serial length of Data.
+// MarshalLen returns the serial length in int.
 func (e *EchoResponse) MarshalLen() int {
 	l := e.Header.MarshalLen() - len(e.Header.Payload)
 
@@ -178,3 +194,72 @@ func (e *EchoResponse) MessageTypeName() string {
 func (e *EchoResponse) TEID() uint32 {
 	return e.Header.teid()
 }
+
+// EchoResponseBuilder builds EchoResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type EchoResponseBuilder struct {
+	builder
+	msg *EchoResponse
+}
+
+// BuildEchoResponse returns the builder of EchoResponse.
+func BuildEchoResponse(seq uint32) *EchoResponseBuilder {
+	return &EchoResponseBuilder{
+		builder: builder{msgType: "Echo Response"},
+		msg:     NewEchoResponse(seq),
+	}
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *EchoResponseBuilder) WithRecovery(i *ie.IE) *EchoResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithSendingNodeFeatures sets the NodeFeatures IE to SendingNodeFeatures.
+func (b *EchoResponseBuilder) WithSendingNodeFeatures(i *ie.IE) *EchoResponseBuilder {
+	if b.expect("SendingNodeFeatures", i, ie.NodeFeatures) {
+		b.msg.SendingNodeFeatures = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *EchoResponseBuilder) WithPrivateExtension(i *ie.IE) *EchoResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewEchoResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *EchoResponseBuilder) WithIEs(ies ...*ie.IE) *EchoResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *EchoResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *EchoResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the EchoResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *EchoResponseBuilder) Build() (*EchoResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
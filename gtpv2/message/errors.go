@@ -4,10 +4,48 @@
 
 package message
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error definitions.
 var (
 	ErrInvalidLength   = errors.New("length value is invalid")
 	ErrTooShortToParse = errors.New("too short to decode as GTP")
 )
+
+// UnexpectedIEKind is the reason why the IE does not belong to the message.
+type UnexpectedIEKind uint8
+
+// UnexpectedIEKind definitions.
+const (
+	// IEUnrecognized indicates that the IE type is not defined for the message,
+	// or is not the one expected for the field given by the message builders.
+	IEUnrecognized UnexpectedIEKind = iota + 1
+	// IEUnexpectedInstance indicates that the IE type is defined for the
+	// message but not with the instance.
+	IEUnexpectedInstance
+)
+
+// UnexpectedIEError indicates that the IE does not belong to the message or the
+// field given by the message builders.
+type UnexpectedIEError struct {
+	MsgType  string
+	Field    string
+	Kind     UnexpectedIEKind
+	Type     uint8
+	Instance uint8
+}
+
+// Error returns the type and instance of the IE, and the field if given.
+func (e *UnexpectedIEError) Error() string {
+	switch {
+	case e.Field != "":
+		return fmt.Sprintf("IE type %d is unexpected for %s in %s", e.Type, e.Field, e.MsgType)
+	case e.Kind == IEUnexpectedInstance:
+		return fmt.Sprintf("IE type %d is unexpected with instance %d in %s", e.Type, e.Instance, e.MsgType)
+	default:
+		return fmt.Sprintf("IE type %d is unrecognized in %s", e.Type, e.MsgType)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+// The messages listed in messages.yaml are generated from the table.
+// See the comment at the top of messages.yaml for how to add messages or IEs.
+//
+//go:generate go run ./internal/msggen -table messages.yaml
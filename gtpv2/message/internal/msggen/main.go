@@ -0,0 +1,606 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Command msggen generates the GTPv2 messages from the table in YAML.
+//
+// For each message in the table, the struct with the fields for IEs, the
+// constructor, Marshal, MarshalTo, Parse, UnmarshalBinary, MarshalLen and the
+// other methods that implement message.Message are generated into <file>.go
+// along with the builder, and the deprecated methods into <file>_deprecated.go
+// if required.
+//
+//	msggen -table messages.yaml [-dir .]
+//
+// It is run by go generate in gtpv2/message. See messages.yaml for the format of
+// the table.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	table = flag.String("table", "messages.yaml", "path to the table of messages")
+	dir   = flag.String("dir", "", "directory to write the files in (default: the directory of -table)")
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("msggen: ")
+
+	b, err := os.ReadFile(*table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := generate(b)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := *dir
+	if out == "" {
+		out = filepath.Dir(*table)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(out, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// msgDef is a message in the table.
+type msgDef struct {
+	Name       string   `yaml:"name"`
+	File       string   `yaml:"file"`
+	NoTEID     bool     `yaml:"noTEID"`
+	Deprecated bool     `yaml:"deprecated"`
+	IEs        []*ieDef `yaml:"ies"`
+
+	// derived from the fields above.
+	Recv     string
+	TypeName string
+	Cases    []*caseDef
+}
+
+// ieDef is an IE in a message.
+type ieDef struct {
+	Field    string   `yaml:"field"`
+	Type     string   `yaml:"type"`
+	Types    []string `yaml:"types"`
+	Instance *uint8   `yaml:"instance"`
+	Multiple bool     `yaml:"multiple"`
+}
+
+// TypeList returns the IE types in words, e.g., "IMSI" or "IMSI or MSISDN".
+func (i *ieDef) TypeList() string {
+	return strings.Join(i.types(), " or ")
+}
+
+// TypeArgs returns the IE types as the arguments of builder.expect.
+func (i *ieDef) TypeArgs() string {
+	var args []string
+	for _, t := range i.types() {
+		args = append(args, "ie."+t)
+	}
+	return strings.Join(args, ", ")
+}
+
+// types returns the IE types that can be set to the field.
+func (i *ieDef) types() []string {
+	if i.Type != "" {
+		return append(i.Types[:len(i.Types):len(i.Types)], i.Type)
+	}
+	return i.Types
+}
+
+// AnyTypes returns the IE types set to the fields regardless of the instance.
+func (m *msgDef) AnyTypes() []string {
+	var types []string
+	for _, c := range m.Cases {
+		if c.Any != nil {
+			types = append(types, c.Types...)
+		}
+	}
+	return types
+}
+
+// caseDef is a case for the IE types in the switch that sets the IEs to the
+// fields. Either Any or Instances is set.
+type caseDef struct {
+	Types     []string
+	Any       *ieDef
+	Instances []*ieDef
+}
+
+// InstanceList returns the instances of the fields in the case, e.g., "0, 1".
+func (c *caseDef) InstanceList() string {
+	var instances []string
+	for _, i := range c.Instances {
+		instances = append(instances, fmt.Sprint(*i.Instance))
+	}
+	return strings.Join(instances, ", ")
+}
+
+// generate returns the generated source files by name from the table.
+func generate(b []byte) (map[string][]byte, error) {
+	var msgs []*msgDef
+	if err := yaml.UnmarshalStrict(b, &msgs); err != nil {
+		return nil, fmt.Errorf("failed to decode the table: %w", err)
+	}
+
+	names := map[string]bool{}
+	files := map[string][]byte{}
+	for _, m := range msgs {
+		if err := m.prepare(); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		if names[m.Name] || files[m.File+".go"] != nil {
+			return nil, fmt.Errorf("%s: duplicate message name or file", m.Name)
+		}
+		names[m.Name] = true
+
+		src, err := execute(msgTmpl, m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		files[m.File+".go"] = src
+
+		if !m.Deprecated {
+			continue
+		}
+		src, err = execute(deprecatedTmpl, m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		files[m.File+"_deprecated.go"] = src
+	}
+	return files, nil
+}
+
+// prepare validates the message and fills the derived fields.
+func (m *msgDef) prepare() error {
+	if m.Name == "" || m.File == "" {
+		return fmt.Errorf("name and file are required")
+	}
+	m.Recv = strings.ToLower(m.Name[:1])
+	m.TypeName = typeName(m.Name)
+
+	fields := map[string]bool{"Header": true, "AdditionalIEs": true, "IEs": true}
+	cases := map[string]*caseDef{}
+	for _, i := range m.IEs {
+		if fields[i.Field] {
+			return fmt.Errorf("duplicate field %s", i.Field)
+		}
+		fields[i.Field] = true
+
+		types := i.types()
+		if len(types) == 0 {
+			return fmt.Errorf("%s: no type is given", i.Field)
+		}
+		if len(types) > 1 && i.Instance != nil {
+			return fmt.Errorf("%s: instance cannot be given with types", i.Field)
+		}
+
+		if i.Instance == nil {
+			for _, t := range types {
+				if cases[t] != nil {
+					return fmt.Errorf("%s: %s is already set to the other field", i.Field, t)
+				}
+			}
+			c := &caseDef{Types: types, Any: i}
+			for _, t := range types {
+				cases[t] = c
+			}
+			m.Cases = append(m.Cases, c)
+			continue
+		}
+
+		c := cases[types[0]]
+		if c == nil {
+			c = &caseDef{Types: types}
+			cases[types[0]] = c
+			m.Cases = append(m.Cases, c)
+		}
+		if c.Any != nil {
+			return fmt.Errorf("%s: %s is already set to the other field", i.Field, types[0])
+		}
+		for _, x := range c.Instances {
+			if *x.Instance == *i.Instance {
+				return fmt.Errorf("%s: %s with instance %d is already set to %s", i.Field, types[0], *i.Instance, x.Field)
+			}
+		}
+		c.Instances = append(c.Instances, i)
+	}
+	return nil
+}
+
+var wordBoundary = regexp.MustCompile(`([a-z])([A-Z])|([A-Z])([A-Z][a-z])`)
+
+// typeName returns the name of message type in words, e.g., "PGW Restart
+// Notification" for PGWRestartNotification.
+func typeName(name string) string {
+	return wordBoundary.ReplaceAllString(name, "$1$3 $2$4")
+}
+
+// execute executes the template with m and returns the formatted source.
+func execute(t *template.Template, m *msgDef) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, m); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+const header = `// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
+package message
+`
+
+// setArgs is the argument of "set" template, which sets i to the field of IE.
+type setArgs struct {
+	Recv string
+	IE   *ieDef
+}
+
+var msgTmpl = template.Must(template.New("msg").Funcs(template.FuncMap{
+	"args": func(recv string, i *ieDef) setArgs { return setArgs{recv, i} },
+	"mod":  func(a, b int) int { return a % b },
+}).Parse(header + `
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// {{.Name}} is a {{.Name}} Header and its IEs above.
+type {{.Name}} struct {
+	*Header
+{{- range .IEs}}
+	{{.Field}} {{if .Multiple}}[]{{end}}*ie.IE
+{{- end}}
+	AdditionalIEs []*ie.IE
+}
+
+// New{{.Name}} creates a new {{.Name}}.
+func New{{.Name}}({{if not .NoTEID}}teid, {{end}}seq uint32, ies ...*ie.IE) *{{.Name}} {
+	{{.Recv}} := &{{.Name}}{
+		Header: NewHeader(
+			NewHeaderFlags(2, 0, {{if .NoTEID}}0{{else}}1{{end}}),
+			MsgType{{.Name}}, {{if .NoTEID}}0{{else}}teid{{end}}, seq, nil,
+		),
+	}
+
+	for _, i := range ies {
+		if i == nil {
+			continue
+		}
+		{{.Recv}}.setIE(i)
+	}
+
+	{{.Recv}}.SetLength()
+	return {{.Recv}}
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func ({{.Recv}} *{{.Name}}) setIE(i *ie.IE) bool {
+{{- $r := .Recv}}
+{{- if .Cases}}
+	switch i.Type {
+{{- range .Cases}}
+	case {{range $n, $t := .Types}}{{if $n}}, {{end}}ie.{{$t}}{{end}}:
+{{- if .Any}}
+		{{template "set" (args $r .Any)}}
+{{- else}}
+		switch i.Instance() {
+{{- range .Instances}}
+		case {{.Instance}}:
+			{{template "set" (args $r .)}}
+{{- end}}
+		default:
+			{{$r}}.AdditionalIEs = append({{$r}}.AdditionalIEs, i)
+			return false
+		}
+{{- end}}
+{{- end}}
+	default:
+		{{.Recv}}.AdditionalIEs = append({{.Recv}}.AdditionalIEs, i)
+		return false
+	}
+	return true
+{{- else}}
+	{{.Recv}}.AdditionalIEs = append({{.Recv}}.AdditionalIEs, i)
+	return false
+{{- end}}
+}
+
+// ieKind returns the reason why the IE does not belong to {{.Name}}, or 0
+// if it is set to one of the fields.
+func ({{.Recv}} *{{.Name}}) ieKind(i *ie.IE) UnexpectedIEKind {
+{{- if .Cases}}
+	switch i.Type {
+{{- with .AnyTypes}}
+	case {{range $n, $t := .}}{{if $n}},{{if eq (mod $n 4) 0}}
+		{{else}} {{end}}{{end}}ie.{{$t}}{{end}}:
+		return 0
+{{- end}}
+{{- range .Cases}}
+{{- if not .Any}}
+	case ie.{{index .Types 0}}:
+		switch i.Instance() {
+		case {{.InstanceList}}:
+			return 0
+		}
+		return IEUnexpectedInstance
+{{- end}}
+{{- end}}
+	}
+{{- end}}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// {{.Name}}, telling whether the type is unrecognized or the instance is
+// unexpected.
+func ({{.Recv}} *{{.Name}}) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("{{.TypeName}}", {{.Recv}}.AdditionalIEs, {{.Recv}}.ieKind)
+}
+
+// Marshal serializes {{.Name}} into bytes.
+func ({{.Recv}} *{{.Name}}) Marshal() ([]byte, error) {
+	b := make([]byte, {{.Recv}}.MarshalLen())
+	if err := {{.Recv}}.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// MarshalTo serializes {{.Name}} into bytes.
+func ({{.Recv}} *{{.Name}}) MarshalTo(b []byte) error {
+	if {{.Recv}}.Header.Payload != nil {
+		{{.Recv}}.Header.Payload = nil
+	}
+	{{.Recv}}.Header.Payload = make([]byte, {{.Recv}}.MarshalLen()-{{.Recv}}.Header.MarshalLen())
+
+	offset := 0
+{{- range .IEs}}
+{{- if .Multiple}}
+	for _, ie := range {{$r}}.{{.Field}} {
+		if ie == nil {
+			continue
+		}
+{{- else}}
+	if ie := {{$r}}.{{.Field}}; ie != nil {
+{{- end}}
+		if err := ie.MarshalTo({{$r}}.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+{{- end}}
+
+	for _, ie := range {{.Recv}}.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo({{.Recv}}.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+
+	{{.Recv}}.Header.SetLength()
+	return {{.Recv}}.Header.MarshalTo(b)
+}
+
+// Parse{{.Name}} decodes given bytes as {{.Name}}.
+func Parse{{.Name}}(b []byte) (*{{.Name}}, error) {
+	{{.Recv}} := &{{.Name}}{}
+	if err := {{.Recv}}.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return {{.Recv}}, nil
+}
+
+// UnmarshalBinary decodes given bytes as {{.Name}}.
+func ({{.Recv}} *{{.Name}}) UnmarshalBinary(b []byte) error {
+	var err error
+	{{.Recv}}.Header, err = ParseHeader(b)
+	if err != nil {
+		return err
+	}
+	if len({{.Recv}}.Header.Payload) < 2 {
+		return nil
+	}
+
+	decodedIEs, err := ie.ParseMultiIEs({{.Recv}}.Header.Payload)
+	if err != nil {
+		return err
+	}
+	for _, i := range decodedIEs {
+		if i == nil {
+			continue
+		}
+		{{.Recv}}.setIE(i)
+	}
+
+	return nil
+}
+
+// MarshalLen returns the serial length in int.
+func ({{.Recv}} *{{.Name}}) MarshalLen() int {
+	l := {{.Recv}}.Header.MarshalLen() - len({{.Recv}}.Header.Payload)
+{{range .IEs}}
+{{- if .Multiple}}
+	for _, ie := range {{$r}}.{{.Field}} {
+		if ie == nil {
+			continue
+		}
+{{- else}}
+	if ie := {{$r}}.{{.Field}}; ie != nil {
+{{- end}}
+		l += ie.MarshalLen()
+	}
+{{- end}}
+
+	for _, ie := range {{.Recv}}.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
+		l += ie.MarshalLen()
+	}
+	return l
+}
+
+// SetLength sets the length in Length field.
+func ({{.Recv}} *{{.Name}}) SetLength() {
+	{{.Recv}}.Header.Length = uint16({{.Recv}}.MarshalLen() - 4)
+}
+
+// MessageTypeName returns the name of protocol.
+func ({{.Recv}} *{{.Name}}) MessageTypeName() string {
+	return "{{.TypeName}}"
+}
+
+// TEID returns the TEID in uint32.
+func ({{.Recv}} *{{.Name}}) TEID() uint32 {
+	return {{.Recv}}.Header.teid()
+}
+
+// {{.Name}}Builder builds {{.Name}}.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type {{.Name}}Builder struct {
+	builder
+	msg *{{.Name}}
+}
+
+// Build{{.Name}} returns the builder of {{.Name}}.
+func Build{{.Name}}({{if not .NoTEID}}teid, {{end}}seq uint32) *{{.Name}}Builder {
+	return &{{.Name}}Builder{
+		builder: builder{msgType: "{{.TypeName}}"},
+		msg:     New{{.Name}}({{if not .NoTEID}}teid, {{end}}seq),
+	}
+}
+{{- $name := .Name}}
+{{- range .IEs}}
+{{if .Multiple}}
+// With{{.Field}} appends the {{.TypeList}} IEs to {{.Field}}
+{{- if .Instance}}, with the instance set to {{.Instance}}{{end}}.
+func (b *{{$name}}Builder) With{{.Field}}(ies ...*ie.IE) *{{$name}}Builder {
+	for _, i := range ies {
+		if b.expect("{{.Field}}", i, {{.TypeArgs}}) {
+			b.msg.{{.Field}} = append(b.msg.{{.Field}}, {{if .Instance}}withInstance(i, {{.Instance}}){{else}}i{{end}})
+		}
+	}
+	return b
+}
+{{- else}}
+// With{{.Field}} sets the {{.TypeList}} IE to {{.Field}}
+{{- if .Instance}}, with the instance set to {{.Instance}}{{end}}.
+func (b *{{$name}}Builder) With{{.Field}}(i *ie.IE) *{{$name}}Builder {
+	if b.expect("{{.Field}}", i, {{.TypeArgs}}) {
+		b.msg.{{.Field}} = {{if .Instance}}withInstance(i, {{.Instance}}){{else}}i{{end}}
+	}
+	return b
+}
+{{- end}}
+{{- end}}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as New{{.Name}}. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *{{.Name}}Builder) WithIEs(ies ...*ie.IE) *{{.Name}}Builder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *{{.Name}}Builder) WithAdditionalIEs(ies ...*ie.IE) *{{.Name}}Builder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the {{.Name}} built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *{{.Name}}Builder) Build() (*{{.Name}}, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
+{{- define "set"}}
+{{- if .IE.Multiple}}{{.Recv}}.{{.IE.Field}} = append({{.Recv}}.{{.IE.Field}}, i)
+{{- else}}{{.Recv}}.{{.IE.Field}} = i
+{{- end}}
+{{- end}}
+`))
+
+var deprecatedTmpl = template.Must(template.New("deprecated").Parse(header + `
+import "log"
+
+// Serialize serializes {{.Name}} into bytes.
+//
+// Deprecated: use {{.Name}}.Marshal instead.
+func ({{.Recv}} *{{.Name}}) Serialize() ([]byte, error) {
+	log.Println("{{.Name}}.Serialize is deprecated. use {{.Name}}.Marshal instead")
+	return {{.Recv}}.Marshal()
+}
+
+// SerializeTo serializes {{.Name}} into bytes given as b.
+//
+// Deprecated: use {{.Name}}.MarshalTo instead.
+func ({{.Recv}} *{{.Name}}) SerializeTo(b []byte) error {
+	log.Println("{{.Name}}.SerializeTo is deprecated. use {{.Name}}.MarshalTo instead")
+	return {{.Recv}}.MarshalTo(b)
+}
+
+// Decode{{.Name}} decodes bytes as {{.Name}}.
+//
+// Deprecated: use Parse{{.Name}} instead.
+func Decode{{.Name}}(b []byte) (*{{.Name}}, error) {
+	log.Println("Decode{{.Name}} is deprecated. use Parse{{.Name}} instead")
+	return Parse{{.Name}}(b)
+}
+
+// DecodeFromBytes decodes bytes as {{.Name}}.
+//
+// Deprecated: use {{.Name}}.UnmarshalBinary instead.
+func ({{.Recv}} *{{.Name}}) DecodeFromBytes(b []byte) error {
+	log.Println("{{.Name}}.DecodeFromBytes is deprecated. use {{.Name}}.UnmarshalBinary instead")
+	return {{.Recv}}.UnmarshalBinary(b)
+}
+
+// Len returns the actual length of {{.Name}}.
+//
+// Deprecated: use {{.Name}}.MarshalLen instead.
+func ({{.Recv}} *{{.Name}}) Len() int {
+	log.Println("{{.Name}}.Len is deprecated. use {{.Name}}.MarshalLen instead")
+	return {{.Recv}}.MarshalLen()
+}
+`))
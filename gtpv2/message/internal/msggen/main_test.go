@@ -0,0 +1,73 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..")
+	b, err := os.ReadFile(filepath.Join(dir, "messages.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(got), string(want)); diff != "" {
+			t.Errorf("%s is not up to date; run go generate in gtpv2/message:\n%s", name, diff)
+		}
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	cases := []struct {
+		description string
+		table       string
+	}{
+		{
+			"duplicate field",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery, type: Recovery}, {field: Recovery, type: NodeFeatures}]}`,
+		}, {
+			"duplicate type",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery, type: Recovery}, {field: Recovery2, type: Recovery}]}`,
+		}, {
+			"duplicate instance",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery, type: Recovery, instance: 1}, {field: Recovery2, type: Recovery, instance: 1}]}`,
+		}, {
+			"any instance after specific instance",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery, type: Recovery, instance: 1}, {field: Recovery2, type: Recovery}]}`,
+		}, {
+			"no type",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery}]}`,
+		}, {
+			"unknown key",
+			`- {name: EchoRequest, file: echo-req, ies: [{field: Recovery, type: Recovery, instances: 1}]}`,
+		}, {
+			"duplicate message",
+			"- {name: EchoRequest, file: echo-req}\n- {name: EchoRequest, file: echo-req}",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if _, err := generate([]byte(c.table)); err == nil {
+				t.Error("unexpectedly succeeded")
+			}
+		})
+	}
+}
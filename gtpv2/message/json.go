@@ -0,0 +1,266 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// document is the JSON/YAML representation of a message.
+//
+// TEID is set only when the T flag is set, and Priority only when the MP flag is
+// set. Flags is set only when it is different from the one derived from them.
+// Payload is used instead of IEs when the payload cannot be parsed as IEs.
+type document struct {
+	Version  int      `json:"version" yaml:"version"`
+	Type     msgType  `json:"type" yaml:"type"`
+	Flags    *uint8   `json:"flags,omitempty" yaml:"flags,omitempty"`
+	TEID     *uint32  `json:"teid,omitempty" yaml:"teid,omitempty"`
+	Sequence uint32   `json:"sequence" yaml:"sequence"`
+	Priority *uint8   `json:"priority,omitempty" yaml:"priority,omitempty"`
+	IEs      []*ie.IE `json:"ies,omitempty" yaml:"ies,omitempty"`
+	Payload  hexBytes `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// flags returns the flags derived from the presence of TEID and Priority.
+func (d *document) flags() uint8 {
+	f := NewHeaderFlags(2, 0, 0)
+	if d.TEID != nil {
+		f |= 0x08
+	}
+	if d.Priority != nil {
+		f |= 0x04
+	}
+	return f
+}
+
+// newDocument returns the message in the form to be encoded in JSON or YAML.
+func newDocument(m Message) (*document, error) {
+	b, err := Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &document{Version: 2, Type: msgType(h.Type), Sequence: h.SequenceNumber}
+	if h.HasTEID() {
+		d.TEID = &h.TEID
+	}
+	if h.HasMessagePriority() {
+		p := h.Spare >> 4
+		d.Priority = &p
+	}
+	if f := d.flags(); f != h.Flags {
+		d.Flags = &h.Flags
+	}
+
+	if d.IEs, err = ie.ParseMultiIEs(h.Payload); err != nil {
+		d.IEs, d.Payload = nil, h.Payload
+	}
+	return d, nil
+}
+
+// message creates the message from the document.
+func (d *document) message() (Message, error) {
+	if d.Version != 2 {
+		return nil, fmt.Errorf("version %d is not GTPv2", d.Version)
+	}
+
+	payload := d.Payload
+	for _, i := range d.IEs {
+		b, err := i.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, b...)
+	}
+
+	flags := d.flags()
+	if d.Flags != nil {
+		flags = *d.Flags
+	}
+	var teid uint32
+	if d.TEID != nil {
+		teid = *d.TEID
+	}
+	h := NewHeader(flags, uint8(d.Type), teid, d.Sequence, payload)
+	if d.Priority != nil {
+		h.Spare = *d.Priority << 4
+	}
+
+	b, err := h.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// MarshalJSON returns the message in JSON.
+//
+// The message is represented as the object with the fields in the header and
+// the IEs. See (*ie.IE).MarshalJSON for how the IEs are represented.
+func MarshalJSON(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// ParseJSON decodes the message in JSON, in the format generated by MarshalJSON.
+func ParseJSON(b []byte) (Message, error) {
+	d := &document{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// MarshalYAML returns the message in YAML, in the same format as MarshalJSON.
+func MarshalYAML(m Message) ([]byte, error) {
+	d, err := newDocument(m)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(d)
+}
+
+// ParseYAML decodes the message in YAML, in the format generated by MarshalYAML.
+func ParseYAML(b []byte) (Message, error) {
+	d := &document{}
+	if err := yaml.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+	return d.message()
+}
+
+// msgType is the type of message, represented with its name if known.
+type msgType uint8
+
+// name returns the name of message type, and false if it is unknown.
+func (t msgType) name() (string, bool) {
+	for n, v := range msgTypeByName() {
+		if v == uint8(t) {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// MarshalJSON returns the name of message type, or the number if unknown.
+func (t msgType) MarshalJSON() ([]byte, error) {
+	if n, ok := t.name(); ok {
+		return json.Marshal(n)
+	}
+	return json.Marshal(uint8(t))
+}
+
+// UnmarshalJSON decodes the name or number of message type.
+func (t *msgType) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// MarshalYAML returns the name of message type, or the number if unknown.
+func (t msgType) MarshalYAML() (interface{}, error) {
+	if n, ok := t.name(); ok {
+		return n, nil
+	}
+	return uint8(t), nil
+}
+
+// UnmarshalYAML decodes the name or number of message type.
+func (t *msgType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return t.set(v)
+}
+
+// set sets the type given as a name, a number or a number in string.
+func (t *msgType) set(v interface{}) error {
+	switch x := v.(type) {
+	case string:
+		if n, ok := msgTypeByName()[x]; ok {
+			*t = msgType(n)
+			return nil
+		}
+		n, err := strconv.ParseUint(x, 0, 8)
+		if err != nil {
+			return fmt.Errorf("unknown message type %q", x)
+		}
+		*t = msgType(n)
+	case float64:
+		if x < 0 || x > 255 || x != float64(uint8(x)) {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	case int:
+		if x < 0 || x > 255 {
+			return fmt.Errorf("invalid message type %v", x)
+		}
+		*t = msgType(x)
+	default:
+		return fmt.Errorf("invalid message type %v", v)
+	}
+	return nil
+}
+
+// msgTypeByName returns the message types by name.
+//
+// The names are available only from the message types, so they are collected by
+// parsing the header with no IEs for each type. The types parsed as Generic have
+// no name.
+var msgTypeByName = sync.OnceValue(func() map[string]uint8 {
+	m := map[string]uint8{}
+	for t := 0; t < 256; t++ {
+		b, err := NewHeader(NewHeaderFlags(2, 0, 1), uint8(t), 0, 0, nil).Marshal()
+		if err != nil {
+			continue
+		}
+		msg, err := Parse(b)
+		if err != nil {
+			continue
+		}
+		if _, ok := msg.(*Generic); ok {
+			continue
+		}
+		m[msg.MessageTypeName()] = uint8(t)
+	}
+	return m
+})
+
+// hexBytes is the bytes represented in hex.
+type hexBytes []byte
+
+// MarshalText returns b in hex.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes b in hex.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	v, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		description string
+		structured  message.Message
+		document    string
+	}{
+		{
+			"EchoRequest",
+			message.NewEchoRequest(1, ie.NewRecovery(2)),
+			`{"version":2,"type":"Echo Request","sequence":1,"ies":[{"type":"Recovery","value":2}]}`,
+		}, {
+			"DeleteSessionRequest",
+			message.NewDeleteSessionRequest(0x11111111, 2, ie.NewEPSBearerID(5)),
+			`{"version":2,"type":"Delete Session Request","teid":286331153,"sequence":2,"ies":[{"type":"EPSBearerID","value":5}]}`,
+		}, {
+			"Generic",
+			message.NewGeneric(250, 0, 3),
+			`{"version":2,"type":250,"teid":0,"sequence":3}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := message.MarshalJSON(c.structured)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(b), c.document); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	doc := `
+version: 2
+type: Create Session Request
+teid: 0
+sequence: 1
+ies:
+- type: IMSI
+  value: "123451234567890"
+- type: FullyQualifiedTEID
+  value: {interfaceType: 10, teid: 0x11111111, ipv4: 10.0.0.1}
+- type: FullyQualifiedTEID
+  instance: 1
+  value: {interfaceType: 7, teid: 0, ipv4: 10.0.0.3}
+- type: AccessPointName
+  value: some.apn.example
+- type: BearerContext
+  ies:
+  - type: EPSBearerID
+    value: 5
+`
+	want := message.NewCreateSessionRequest(0, 1,
+		ie.NewIMSI("123451234567890"),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", ""),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.3", "").WithInstance(1),
+		ie.NewAccessPointName("some.apn.example"),
+		ie.NewBearerContext(ie.NewEPSBearerID(5)),
+	)
+
+	got, err := message.ParseYAML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, ok := got.(*message.CreateSessionRequest)
+	if !ok {
+		t.Fatalf("got %T", got)
+	}
+	if imsi := csr.IMSI.MustIMSI(); imsi != "123451234567890" {
+		t.Errorf("got IMSI %s", imsi)
+	}
+
+	gotb, err := message.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantb, err := message.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(gotb, wantb); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	for _, doc := range []string{
+		`{"version":1,"type":"Echo Request","sequence":1}`,
+		`{"version":2,"type":"No Such Request","sequence":1}`,
+		`{"version":2,"type":1,"sequence":1,"ies":[{"type":"NoSuchIE"}]}`,
+	} {
+		if _, err := message.ParseJSON([]byte(doc)); err == nil {
+			t.Errorf("%s: unexpectedly succeeded", doc)
+		}
+	}
+}
@@ -306,7 +306,10 @@ func Marshal(m Message) ([]byte, error) {
 }
 
 // Parse decodes the given bytes as Message.
-func Parse(b []byte) (Message, error) {
+//
+// The IEs that do not belong to the message are kept in AdditionalIEs, which
+// can be reported or rejected with ParseOption.
+func Parse(b []byte, opts ...ParseOption) (Message, error) {
 	var m Message
 
 	if len(b) < 2 {
@@ -409,6 +412,17 @@ func Parse(b []byte) (Message, error) {
 	if err := m.UnmarshalBinary(b); err != nil {
 		return nil, fmt.Errorf("failed to decode GTPv2 Message: %w", err)
 	}
+
+	if len(opts) == 0 {
+		return m, nil
+	}
+	c := &parseConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := c.checkUnexpectedIEs(m); err != nil {
+		return nil, fmt.Errorf("failed to decode GTPv2 Message: %w", err)
+	}
 	return m, nil
 }
 
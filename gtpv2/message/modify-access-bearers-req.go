@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ModifyAccessBearersRequest is a ModifyAccessBearersRequest Header and its IEs above.
 type ModifyAccessBearersRequest struct {
@@ -33,33 +37,68 @@ func NewModifyAccessBearersRequest(teid, seq uint32, ies ...*ie.IE) *ModifyAcces
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.NodeType:
-			m.SenderFTEIDC = i
-		case ie.DelayValue:
-			m.DelayDownlinkPacketNotificationRequest = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
-			case 1:
-				m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.SecondaryRATUsageDataReport:
-			m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyAccessBearersRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Indication:
+		m.IndicationFlags = i
+	case ie.NodeType:
+		m.SenderFTEIDC = i
+	case ie.DelayValue:
+		m.DelayDownlinkPacketNotificationRequest = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
+		case 1:
+			m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
 		}
+	case ie.Recovery:
+		m.Recovery = i
+	case ie.SecondaryRATUsageDataReport:
+		m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	m.SetLength()
-	return m
+// ieKind returns the reason why the IE does not belong to ModifyAccessBearersRequest, or 0
+// if it is set to one of the fields.
+func (m *ModifyAccessBearersRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Indication, ie.NodeType, ie.DelayValue, ie.Recovery,
+		ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyAccessBearersRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyAccessBearersRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Access Bearers Request", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyAccessBearersRequest into bytes.
@@ -80,49 +119,58 @@ func (m *ModifyAccessBearersRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.DelayDownlinkPacketNotificationRequest; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeModified {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeRemoved {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -170,29 +218,7 @@ func (m *ModifyAccessBearersRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.NodeType:
-			m.SenderFTEIDC = i
-		case ie.DelayValue:
-			m.DelayDownlinkPacketNotificationRequest = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
-			case 1:
-				m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.SecondaryRATUsageDataReport:
-			m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -201,6 +227,7 @@ func (m *ModifyAccessBearersRequest) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (m *ModifyAccessBearersRequest) MarshalLen() int {
 	l := m.Header.MarshalLen() - len(m.Header.Payload)
+
 	if ie := m.IndicationFlags; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -211,15 +238,24 @@ func (m *ModifyAccessBearersRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeModified {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeRemoved {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
@@ -249,3 +285,118 @@ func (m *ModifyAccessBearersRequest) MessageTypeName() string {
 func (m *ModifyAccessBearersRequest) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyAccessBearersRequestBuilder builds ModifyAccessBearersRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyAccessBearersRequestBuilder struct {
+	builder
+	msg *ModifyAccessBearersRequest
+}
+
+// BuildModifyAccessBearersRequest returns the builder of ModifyAccessBearersRequest.
+func BuildModifyAccessBearersRequest(teid, seq uint32) *ModifyAccessBearersRequestBuilder {
+	return &ModifyAccessBearersRequestBuilder{
+		builder: builder{msgType: "Modify Access Bearers Request"},
+		msg:     NewModifyAccessBearersRequest(teid, seq),
+	}
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ModifyAccessBearersRequestBuilder) WithIndicationFlags(i *ie.IE) *ModifyAccessBearersRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the NodeType IE to SenderFTEIDC.
+func (b *ModifyAccessBearersRequestBuilder) WithSenderFTEIDC(i *ie.IE) *ModifyAccessBearersRequestBuilder {
+	if b.expect("SenderFTEIDC", i, ie.NodeType) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithDelayDownlinkPacketNotificationRequest sets the DelayValue IE to DelayDownlinkPacketNotificationRequest.
+func (b *ModifyAccessBearersRequestBuilder) WithDelayDownlinkPacketNotificationRequest(i *ie.IE) *ModifyAccessBearersRequestBuilder {
+	if b.expect("DelayDownlinkPacketNotificationRequest", i, ie.DelayValue) {
+		b.msg.DelayDownlinkPacketNotificationRequest = i
+	}
+	return b
+}
+
+// WithBearerContextsToBeModified appends the BearerContext IEs to BearerContextsToBeModified, with the instance set to 0.
+func (b *ModifyAccessBearersRequestBuilder) WithBearerContextsToBeModified(ies ...*ie.IE) *ModifyAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeModified", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeModified = append(b.msg.BearerContextsToBeModified, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextsToBeRemoved appends the BearerContext IEs to BearerContextsToBeRemoved, with the instance set to 1.
+func (b *ModifyAccessBearersRequestBuilder) WithBearerContextsToBeRemoved(ies ...*ie.IE) *ModifyAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeRemoved", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeRemoved = append(b.msg.BearerContextsToBeRemoved, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ModifyAccessBearersRequestBuilder) WithRecovery(i *ie.IE) *ModifyAccessBearersRequestBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *ModifyAccessBearersRequestBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *ModifyAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyAccessBearersRequestBuilder) WithPrivateExtension(i *ie.IE) *ModifyAccessBearersRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyAccessBearersRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyAccessBearersRequestBuilder) WithIEs(ies ...*ie.IE) *ModifyAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyAccessBearersRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyAccessBearersRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyAccessBearersRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyAccessBearersRequestBuilder) Build() (*ModifyAccessBearersRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ModifyAccessBearersResponse is a ModifyAccessBearersResponse Header and its IEs above.
 type ModifyAccessBearersResponse struct {
@@ -33,33 +37,68 @@ func NewModifyAccessBearersResponse(teid, seq uint32, ies ...*ie.IE) *ModifyAcce
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsModified = append(m.BearerContextsModified, i)
-			case 1:
-				m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.LoadControlInformation:
-			m.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			m.SGWOverloadControlInformation = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyAccessBearersResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		m.Cause = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			m.BearerContextsModified = append(m.BearerContextsModified, i)
+		case 1:
+			m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
 		}
+	case ie.Recovery:
+		m.Recovery = i
+	case ie.Indication:
+		m.IndicationFlags = i
+	case ie.LoadControlInformation:
+		m.SGWNodeLoadControlInformation = i
+	case ie.OverloadControlInformation:
+		m.SGWOverloadControlInformation = i
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	m.SetLength()
-	return m
+// ieKind returns the reason why the IE does not belong to ModifyAccessBearersResponse, or 0
+// if it is set to one of the fields.
+func (m *ModifyAccessBearersResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.Indication, ie.LoadControlInformation,
+		ie.OverloadControlInformation, ie.PrivateExtension:
+		return 0
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyAccessBearersResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyAccessBearersResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Access Bearers Response", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyAccessBearersResponse into bytes.
@@ -80,49 +119,55 @@ func (m *ModifyAccessBearersResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsModified {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsMarkedForRemoval {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -170,29 +215,7 @@ func (m *ModifyAccessBearersResponse) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsModified = append(m.BearerContextsModified, i)
-			case 1:
-				m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.LoadControlInformation:
-			m.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			m.SGWOverloadControlInformation = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -201,13 +224,20 @@ func (m *ModifyAccessBearersResponse) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (m *ModifyAccessBearersResponse) MarshalLen() int {
 	l := m.Header.MarshalLen() - len(m.Header.Payload)
+
 	if ie := m.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsModified {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsMarkedForRemoval {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
@@ -249,3 +279,116 @@ func (m *ModifyAccessBearersResponse) MessageTypeName() string {
 func (m *ModifyAccessBearersResponse) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyAccessBearersResponseBuilder builds ModifyAccessBearersResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyAccessBearersResponseBuilder struct {
+	builder
+	msg *ModifyAccessBearersResponse
+}
+
+// BuildModifyAccessBearersResponse returns the builder of ModifyAccessBearersResponse.
+func BuildModifyAccessBearersResponse(teid, seq uint32) *ModifyAccessBearersResponseBuilder {
+	return &ModifyAccessBearersResponseBuilder{
+		builder: builder{msgType: "Modify Access Bearers Response"},
+		msg:     NewModifyAccessBearersResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ModifyAccessBearersResponseBuilder) WithCause(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithBearerContextsModified appends the BearerContext IEs to BearerContextsModified, with the instance set to 0.
+func (b *ModifyAccessBearersResponseBuilder) WithBearerContextsModified(ies ...*ie.IE) *ModifyAccessBearersResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsModified", i, ie.BearerContext) {
+			b.msg.BearerContextsModified = append(b.msg.BearerContextsModified, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextsMarkedForRemoval appends the BearerContext IEs to BearerContextsMarkedForRemoval, with the instance set to 1.
+func (b *ModifyAccessBearersResponseBuilder) WithBearerContextsMarkedForRemoval(ies ...*ie.IE) *ModifyAccessBearersResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsMarkedForRemoval", i, ie.BearerContext) {
+			b.msg.BearerContextsMarkedForRemoval = append(b.msg.BearerContextsMarkedForRemoval, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ModifyAccessBearersResponseBuilder) WithRecovery(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ModifyAccessBearersResponseBuilder) WithIndicationFlags(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation.
+func (b *ModifyAccessBearersResponseBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = i
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation.
+func (b *ModifyAccessBearersResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyAccessBearersResponseBuilder) WithPrivateExtension(i *ie.IE) *ModifyAccessBearersResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyAccessBearersResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyAccessBearersResponseBuilder) WithIEs(ies ...*ie.IE) *ModifyAccessBearersResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyAccessBearersResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyAccessBearersResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyAccessBearersResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyAccessBearersResponseBuilder) Build() (*ModifyAccessBearersResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
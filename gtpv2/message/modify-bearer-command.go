@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ModifyBearerCommand is a ModifyBearerCommand Header and its IEs above.
 type ModifyBearerCommand struct {
@@ -32,31 +36,65 @@ func NewModifyBearerCommand(teid, seq uint32, ies ...*ie.IE) *ModifyBearerComman
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.AggregateMaximumBitRate:
-			m.APNAMBR = i
-		case ie.BearerContext:
-			m.BearerContext = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			case 2:
-				m.TWANePDGOverloadControlInformation = i
-			}
-		case ie.FullyQualifiedTEID:
-			m.SenderFTEIDC = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyBearerCommand) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.AggregateMaximumBitRate:
+		m.APNAMBR = i
+	case ie.BearerContext:
+		m.BearerContext = i
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			m.MMESGSNOverloadControlInformation = i
+		case 1:
+			m.SGWOverloadControlInformation = i
+		case 2:
+			m.TWANePDGOverloadControlInformation = i
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.FullyQualifiedTEID:
+		m.SenderFTEIDC = i
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ModifyBearerCommand, or 0
+// if it is set to one of the fields.
+func (m *ModifyBearerCommand) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.AggregateMaximumBitRate, ie.BearerContext, ie.FullyQualifiedTEID, ie.PrivateExtension:
+		return 0
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
 		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyBearerCommand, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyBearerCommand) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Bearer Command", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyBearerCommand into bytes.
@@ -77,43 +115,43 @@ func (m *ModifyBearerCommand) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.APNAMBR; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.BearerContext; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.TWANePDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -161,27 +199,7 @@ func (m *ModifyBearerCommand) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.AggregateMaximumBitRate:
-			m.APNAMBR = i
-		case ie.BearerContext:
-			m.BearerContext = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			case 2:
-				m.TWANePDGOverloadControlInformation = i
-			}
-		case ie.FullyQualifiedTEID:
-			m.SenderFTEIDC = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -190,6 +208,7 @@ func (m *ModifyBearerCommand) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (m *ModifyBearerCommand) MarshalLen() int {
 	l := m.Header.MarshalLen() - len(m.Header.Payload)
+
 	if ie := m.APNAMBR; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -235,3 +254,104 @@ func (m *ModifyBearerCommand) MessageTypeName() string {
 func (m *ModifyBearerCommand) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyBearerCommandBuilder builds ModifyBearerCommand.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyBearerCommandBuilder struct {
+	builder
+	msg *ModifyBearerCommand
+}
+
+// BuildModifyBearerCommand returns the builder of ModifyBearerCommand.
+func BuildModifyBearerCommand(teid, seq uint32) *ModifyBearerCommandBuilder {
+	return &ModifyBearerCommandBuilder{
+		builder: builder{msgType: "Modify Bearer Command"},
+		msg:     NewModifyBearerCommand(teid, seq),
+	}
+}
+
+// WithAPNAMBR sets the AggregateMaximumBitRate IE to APNAMBR.
+func (b *ModifyBearerCommandBuilder) WithAPNAMBR(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("APNAMBR", i, ie.AggregateMaximumBitRate) {
+		b.msg.APNAMBR = i
+	}
+	return b
+}
+
+// WithBearerContext sets the BearerContext IE to BearerContext.
+func (b *ModifyBearerCommandBuilder) WithBearerContext(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("BearerContext", i, ie.BearerContext) {
+		b.msg.BearerContext = i
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *ModifyBearerCommandBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *ModifyBearerCommandBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformation sets the OverloadControlInformation IE to TWANePDGOverloadControlInformation, with the instance set to 2.
+func (b *ModifyBearerCommandBuilder) WithTWANePDGOverloadControlInformation(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("TWANePDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *ModifyBearerCommandBuilder) WithSenderFTEIDC(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyBearerCommandBuilder) WithPrivateExtension(i *ie.IE) *ModifyBearerCommandBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyBearerCommand. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyBearerCommandBuilder) WithIEs(ies ...*ie.IE) *ModifyBearerCommandBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyBearerCommandBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyBearerCommandBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyBearerCommand built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyBearerCommandBuilder) Build() (*ModifyBearerCommand, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
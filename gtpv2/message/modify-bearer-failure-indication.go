@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ModifyBearerFailureIndication is a ModifyBearerFailureIndication Header and its IEs above.
 type ModifyBearerFailureIndication struct {
@@ -31,29 +35,63 @@ func NewModifyBearerFailureIndication(teid, seq uint32, ies ...*ie.IE) *ModifyBe
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.PGWOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyBearerFailureIndication) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		m.Cause = i
+	case ie.Recovery:
+		m.Recovery = i
+	case ie.Indication:
+		m.IndicationFlags = i
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			m.PGWOverloadControlInformation = i
+		case 1:
+			m.SGWOverloadControlInformation = i
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
 		}
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	m.SetLength()
-	return m
+// ieKind returns the reason why the IE does not belong to ModifyBearerFailureIndication, or 0
+// if it is set to one of the fields.
+func (m *ModifyBearerFailureIndication) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.Indication, ie.PrivateExtension:
+		return 0
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyBearerFailureIndication, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyBearerFailureIndication) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Bearer Failure Indication", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyBearerFailureIndication into bytes.
@@ -74,37 +112,37 @@ func (m *ModifyBearerFailureIndication) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -152,25 +190,7 @@ func (m *ModifyBearerFailureIndication) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.PGWOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -179,6 +199,7 @@ func (m *ModifyBearerFailureIndication) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (m *ModifyBearerFailureIndication) MarshalLen() int {
 	l := m.Header.MarshalLen() - len(m.Header.Payload)
+
 	if ie := m.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -221,3 +242,96 @@ func (m *ModifyBearerFailureIndication) MessageTypeName() string {
 func (m *ModifyBearerFailureIndication) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyBearerFailureIndicationBuilder builds ModifyBearerFailureIndication.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyBearerFailureIndicationBuilder struct {
+	builder
+	msg *ModifyBearerFailureIndication
+}
+
+// BuildModifyBearerFailureIndication returns the builder of ModifyBearerFailureIndication.
+func BuildModifyBearerFailureIndication(teid, seq uint32) *ModifyBearerFailureIndicationBuilder {
+	return &ModifyBearerFailureIndicationBuilder{
+		builder: builder{msgType: "Modify Bearer Failure Indication"},
+		msg:     NewModifyBearerFailureIndication(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ModifyBearerFailureIndicationBuilder) WithCause(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ModifyBearerFailureIndicationBuilder) WithRecovery(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ModifyBearerFailureIndicationBuilder) WithIndicationFlags(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *ModifyBearerFailureIndicationBuilder) WithPGWOverloadControlInformation(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *ModifyBearerFailureIndicationBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyBearerFailureIndicationBuilder) WithPrivateExtension(i *ie.IE) *ModifyBearerFailureIndicationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyBearerFailureIndication. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyBearerFailureIndicationBuilder) WithIEs(ies ...*ie.IE) *ModifyBearerFailureIndicationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyBearerFailureIndicationBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyBearerFailureIndicationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyBearerFailureIndication built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyBearerFailureIndicationBuilder) Build() (*ModifyBearerFailureIndication, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
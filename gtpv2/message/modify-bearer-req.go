@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -62,119 +64,197 @@ func NewModifyBearerRequest(teid, seq uint32, ies ...*ie.IE) *ModifyBearerReques
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.MobileEquipmentIdentity:
-			m.MEI = i
-		case ie.UserLocationInformation:
-			switch i.Instance() {
-			case 0:
-				m.ULI = i
-			case 1:
-				m.ULIForSGW = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ServingNetwork:
-			m.ServingNetwork = i
-		case ie.RATType:
-			m.RATType = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			m.SenderFTEIDC = i
-		case ie.AggregateMaximumBitRate:
-			m.AMBR = i
-		case ie.DelayValue:
-			m.DelayDownlinkPacketNotificationRequest = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
-			case 1:
-				m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.UETimeZone:
-			m.UETimeZone = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.UserCSGInformation:
-			m.UCI = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				m.UELocalIPAddress = i
-			case 1:
-				m.HeNBLocalIPAddress = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				m.UEUDPPort = i
-			case 1:
-				m.HeNBUDPPort = i
-			case 2:
-				m.MMESGSNIdentifier = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNLDN = i
-			case 1:
-				m.SGWLDN = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.CNOperatorSelectionEntity:
-			m.CNOperatorSelectionEntity = i
-		case ie.PresenceReportingAreaInformation:
-			m.PresenceReportingAreaInformation = append(m.PresenceReportingAreaInformation, i)
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			case 2:
-				m.EPDGOverloadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ServingPLMNRateControl:
-			m.ServingPLMNRateControl = i
-		case ie.Counter:
-			m.MOExceptionDataCounter = i
-		case ie.IMSI:
-			m.IMSI = i
-		case ie.TWANIdentifier:
-			m.WLANLocationInformation = i
-		case ie.TWANIdentifierTimestamp:
-			m.WLANLocationTimeStamp = i
-		case ie.SecondaryRATUsageDataReport:
-			m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyBearerRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.MobileEquipmentIdentity:
+		m.MEI = i
+	case ie.UserLocationInformation:
+		switch i.Instance() {
+		case 0:
+			m.ULI = i
+		case 1:
+			m.ULIForSGW = i
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.ServingNetwork:
+		m.ServingNetwork = i
+	case ie.RATType:
+		m.RATType = i
+	case ie.Indication:
+		m.IndicationFlags = i
+	case ie.FullyQualifiedTEID:
+		m.SenderFTEIDC = i
+	case ie.AggregateMaximumBitRate:
+		m.AMBR = i
+	case ie.DelayValue:
+		m.DelayDownlinkPacketNotificationRequest = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
+		case 1:
+			m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.Recovery:
+		m.Recovery = i
+	case ie.UETimeZone:
+		m.UETimeZone = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			m.MMEFQCSID = i
+		case 1:
+			m.SGWFQCSID = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.UserCSGInformation:
+		m.UCI = i
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			m.UELocalIPAddress = i
+		case 1:
+			m.HeNBLocalIPAddress = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			m.UEUDPPort = i
+		case 1:
+			m.HeNBUDPPort = i
+		case 2:
+			m.MMESGSNIdentifier = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0:
+			m.MMESGSNLDN = i
+		case 1:
+			m.SGWLDN = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.CNOperatorSelectionEntity:
+		m.CNOperatorSelectionEntity = i
+	case ie.PresenceReportingAreaInformation:
+		m.PresenceReportingAreaInformation = append(m.PresenceReportingAreaInformation, i)
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			m.MMESGSNOverloadControlInformation = i
+		case 1:
+			m.SGWOverloadControlInformation = i
+		case 2:
+			m.EPDGOverloadControlInformation = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.ServingPLMNRateControl:
+		m.ServingPLMNRateControl = i
+	case ie.Counter:
+		m.MOExceptionDataCounter = i
+	case ie.IMSI:
+		m.IMSI = i
+	case ie.TWANIdentifier:
+		m.WLANLocationInformation = i
+	case ie.TWANIdentifierTimestamp:
+		m.WLANLocationTimeStamp = i
+	case ie.SecondaryRATUsageDataReport:
+		m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ModifyBearerRequest, or 0
+// if it is set to one of the fields.
+func (m *ModifyBearerRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.MobileEquipmentIdentity, ie.ServingNetwork, ie.RATType, ie.Indication,
+		ie.FullyQualifiedTEID, ie.AggregateMaximumBitRate, ie.DelayValue, ie.Recovery,
+		ie.UETimeZone, ie.UserCSGInformation, ie.CNOperatorSelectionEntity, ie.PresenceReportingAreaInformation,
+		ie.ServingPLMNRateControl, ie.Counter, ie.IMSI, ie.TWANIdentifier,
+		ie.TWANIdentifierTimestamp, ie.SecondaryRATUsageDataReport, ie.PrivateExtension:
+		return 0
+	case ie.UserLocationInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
 		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyBearerRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyBearerRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Bearer Request", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyBearerRequest into bytes.
@@ -195,211 +275,223 @@ func (m *ModifyBearerRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.MEI; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ULI; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ServingNetwork; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.RATType; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.AMBR; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.DelayDownlinkPacketNotificationRequest; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeModified {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeRemoved {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.UCI; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MMESGSNLDN; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWLDN; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.HeNBLocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.HeNBUDPPort; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MMESGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.CNOperatorSelectionEntity; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.PresenceReportingAreaInformation {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.EPDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ServingPLMNRateControl; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MOExceptionDataCounter; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.IMSI; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ULIForSGW; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.WLANLocationTimeStamp; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -421,11 +513,11 @@ func (m *ModifyBearerRequest) MarshalTo(b []byte) error {
 
 // ParseModifyBearerRequest decodes given bytes as ModifyBearerRequest.
 func ParseModifyBearerRequest(b []byte) (*ModifyBearerRequest, error) {
-	c := &ModifyBearerRequest{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	m := &ModifyBearerRequest{}
+	if err := m.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return m, nil
 }
 
 // UnmarshalBinary decodes given bytes as ModifyBearerRequest.
@@ -443,120 +535,11 @@ func (m *ModifyBearerRequest) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.MobileEquipmentIdentity:
-			m.MEI = i
-		case ie.UserLocationInformation:
-			switch i.Instance() {
-			case 0:
-				m.ULI = i
-			case 1:
-				m.ULIForSGW = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ServingNetwork:
-			m.ServingNetwork = i
-		case ie.RATType:
-			m.RATType = i
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.FullyQualifiedTEID:
-			m.SenderFTEIDC = i
-		case ie.AggregateMaximumBitRate:
-			m.AMBR = i
-		case ie.DelayValue:
-			m.DelayDownlinkPacketNotificationRequest = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsToBeModified = append(m.BearerContextsToBeModified, i)
-			case 1:
-				m.BearerContextsToBeRemoved = append(m.BearerContextsToBeRemoved, i)
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.UETimeZone:
-			m.UETimeZone = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.UserCSGInformation:
-			m.UCI = i
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				m.UELocalIPAddress = i
-			case 1:
-				m.HeNBLocalIPAddress = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				m.UEUDPPort = i
-			case 1:
-				m.HeNBUDPPort = i
-			case 2:
-				m.MMESGSNIdentifier = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNLDN = i
-			case 1:
-				m.SGWLDN = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.CNOperatorSelectionEntity:
-			m.CNOperatorSelectionEntity = i
-		case ie.PresenceReportingAreaInformation:
-			m.PresenceReportingAreaInformation = append(m.PresenceReportingAreaInformation, i)
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.MMESGSNOverloadControlInformation = i
-			case 1:
-				m.SGWOverloadControlInformation = i
-			case 2:
-				m.EPDGOverloadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ServingPLMNRateControl:
-			m.ServingPLMNRateControl = i
-		case ie.Counter:
-			m.MOExceptionDataCounter = i
-		case ie.IMSI:
-			m.IMSI = i
-		case ie.TWANIdentifier:
-			m.WLANLocationInformation = i
-		case ie.TWANIdentifierTimestamp:
-			m.WLANLocationTimeStamp = i
-		case ie.SecondaryRATUsageDataReport:
-			m.SecondaryRATUsageDataReport = append(m.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -591,9 +574,15 @@ func (m *ModifyBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeModified {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsToBeRemoved {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
@@ -636,6 +625,9 @@ func (m *ModifyBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.PresenceReportingAreaInformation {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.MMESGSNOverloadControlInformation; ie != nil {
@@ -666,6 +658,9 @@ func (m *ModifyBearerRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
@@ -695,3 +690,336 @@ func (m *ModifyBearerRequest) MessageTypeName() string {
 func (m *ModifyBearerRequest) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyBearerRequestBuilder builds ModifyBearerRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyBearerRequestBuilder struct {
+	builder
+	msg *ModifyBearerRequest
+}
+
+// BuildModifyBearerRequest returns the builder of ModifyBearerRequest.
+func BuildModifyBearerRequest(teid, seq uint32) *ModifyBearerRequestBuilder {
+	return &ModifyBearerRequestBuilder{
+		builder: builder{msgType: "Modify Bearer Request"},
+		msg:     NewModifyBearerRequest(teid, seq),
+	}
+}
+
+// WithMEI sets the MobileEquipmentIdentity IE to MEI.
+func (b *ModifyBearerRequestBuilder) WithMEI(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MEI", i, ie.MobileEquipmentIdentity) {
+		b.msg.MEI = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithULI(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithServingNetwork sets the ServingNetwork IE to ServingNetwork.
+func (b *ModifyBearerRequestBuilder) WithServingNetwork(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("ServingNetwork", i, ie.ServingNetwork) {
+		b.msg.ServingNetwork = i
+	}
+	return b
+}
+
+// WithRATType sets the RATType IE to RATType.
+func (b *ModifyBearerRequestBuilder) WithRATType(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("RATType", i, ie.RATType) {
+		b.msg.RATType = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ModifyBearerRequestBuilder) WithIndicationFlags(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *ModifyBearerRequestBuilder) WithSenderFTEIDC(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithAMBR sets the AggregateMaximumBitRate IE to AMBR.
+func (b *ModifyBearerRequestBuilder) WithAMBR(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("AMBR", i, ie.AggregateMaximumBitRate) {
+		b.msg.AMBR = i
+	}
+	return b
+}
+
+// WithDelayDownlinkPacketNotificationRequest sets the DelayValue IE to DelayDownlinkPacketNotificationRequest.
+func (b *ModifyBearerRequestBuilder) WithDelayDownlinkPacketNotificationRequest(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("DelayDownlinkPacketNotificationRequest", i, ie.DelayValue) {
+		b.msg.DelayDownlinkPacketNotificationRequest = i
+	}
+	return b
+}
+
+// WithBearerContextsToBeModified appends the BearerContext IEs to BearerContextsToBeModified, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithBearerContextsToBeModified(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeModified", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeModified = append(b.msg.BearerContextsToBeModified, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextsToBeRemoved appends the BearerContext IEs to BearerContextsToBeRemoved, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithBearerContextsToBeRemoved(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsToBeRemoved", i, ie.BearerContext) {
+			b.msg.BearerContextsToBeRemoved = append(b.msg.BearerContextsToBeRemoved, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ModifyBearerRequestBuilder) WithRecovery(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *ModifyBearerRequestBuilder) WithUETimeZone(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithMMEFQCSID(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithSGWFQCSID(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUCI sets the UserCSGInformation IE to UCI.
+func (b *ModifyBearerRequestBuilder) WithUCI(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("UCI", i, ie.UserCSGInformation) {
+		b.msg.UCI = i
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithUELocalIPAddress(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithUEUDPPort(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMESGSNLDN sets the LocalDistinguishedName IE to MMESGSNLDN, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithMMESGSNLDN(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MMESGSNLDN", i, ie.LocalDistinguishedName) {
+		b.msg.MMESGSNLDN = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWLDN sets the LocalDistinguishedName IE to SGWLDN, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithSGWLDN(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("SGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.SGWLDN = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithHeNBLocalIPAddress sets the IPAddress IE to HeNBLocalIPAddress, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithHeNBLocalIPAddress(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("HeNBLocalIPAddress", i, ie.IPAddress) {
+		b.msg.HeNBLocalIPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithHeNBUDPPort sets the PortNumber IE to HeNBUDPPort, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithHeNBUDPPort(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("HeNBUDPPort", i, ie.PortNumber) {
+		b.msg.HeNBUDPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithMMESGSNIdentifier sets the PortNumber IE to MMESGSNIdentifier, with the instance set to 2.
+func (b *ModifyBearerRequestBuilder) WithMMESGSNIdentifier(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MMESGSNIdentifier", i, ie.PortNumber) {
+		b.msg.MMESGSNIdentifier = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithCNOperatorSelectionEntity sets the CNOperatorSelectionEntity IE to CNOperatorSelectionEntity.
+func (b *ModifyBearerRequestBuilder) WithCNOperatorSelectionEntity(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("CNOperatorSelectionEntity", i, ie.CNOperatorSelectionEntity) {
+		b.msg.CNOperatorSelectionEntity = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaInformation appends the PresenceReportingAreaInformation IEs to PresenceReportingAreaInformation.
+func (b *ModifyBearerRequestBuilder) WithPresenceReportingAreaInformation(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaInformation", i, ie.PresenceReportingAreaInformation) {
+			b.msg.PresenceReportingAreaInformation = append(b.msg.PresenceReportingAreaInformation, i)
+		}
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *ModifyBearerRequestBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGOverloadControlInformation sets the OverloadControlInformation IE to EPDGOverloadControlInformation, with the instance set to 2.
+func (b *ModifyBearerRequestBuilder) WithEPDGOverloadControlInformation(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("EPDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.EPDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithServingPLMNRateControl sets the ServingPLMNRateControl IE to ServingPLMNRateControl.
+func (b *ModifyBearerRequestBuilder) WithServingPLMNRateControl(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("ServingPLMNRateControl", i, ie.ServingPLMNRateControl) {
+		b.msg.ServingPLMNRateControl = i
+	}
+	return b
+}
+
+// WithMOExceptionDataCounter sets the Counter IE to MOExceptionDataCounter.
+func (b *ModifyBearerRequestBuilder) WithMOExceptionDataCounter(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("MOExceptionDataCounter", i, ie.Counter) {
+		b.msg.MOExceptionDataCounter = i
+	}
+	return b
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ModifyBearerRequestBuilder) WithIMSI(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithULIForSGW sets the UserLocationInformation IE to ULIForSGW, with the instance set to 1.
+func (b *ModifyBearerRequestBuilder) WithULIForSGW(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("ULIForSGW", i, ie.UserLocationInformation) {
+		b.msg.ULIForSGW = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation.
+func (b *ModifyBearerRequestBuilder) WithWLANLocationInformation(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = i
+	}
+	return b
+}
+
+// WithWLANLocationTimeStamp sets the TWANIdentifierTimestamp IE to WLANLocationTimeStamp.
+func (b *ModifyBearerRequestBuilder) WithWLANLocationTimeStamp(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("WLANLocationTimeStamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimeStamp = i
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *ModifyBearerRequestBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyBearerRequestBuilder) WithPrivateExtension(i *ie.IE) *ModifyBearerRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyBearerRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyBearerRequestBuilder) WithIEs(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyBearerRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyBearerRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyBearerRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyBearerRequestBuilder) Build() (*ModifyBearerRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
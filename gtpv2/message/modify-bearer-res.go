@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -53,91 +55,154 @@ func NewModifyBearerResponse(teid, seq uint32, ies ...*ie.IE) *ModifyBearerRespo
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.MSISDN:
-			m.MSISDN = i
-		case ie.EPSBearerID:
-			m.LinkedEBI = i
-		case ie.APNRestriction:
-			m.APNRestriction = i
-		case ie.ProtocolConfigurationOptions:
-			m.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsModified = append(m.BearerContextsModified, i)
-			case 1:
-				m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ChangeReportingAction:
-			m.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			m.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			m.HeNBInformationReporting = i
-		case ie.FullyQualifiedDomainName:
-			m.ChargingGatewayName = i
-		case ie.IPAddress:
-			m.ChargingGatewayAddress = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.PGWFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				m.SGWLDN = i
-			case 1:
-				m.PGWLDN = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.PresenceReportingAreaAction:
-			m.PresenceReportingAreaAction = append(m.PresenceReportingAreaAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.PGWNodeLoadControlInformation = i
-			case 1:
-				m.PGWAPNLoadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.SGWNodeLoadControlInformation = i
-			case 1:
-				m.PGWOverloadControlInformation = i
-			case 2:
-				m.SGWOverloadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ChargingID:
-			m.PDNConnectionChargingID = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		m.setIE(i)
+	}
+
+	m.SetLength()
+	return m
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (m *ModifyBearerResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		m.Cause = i
+	case ie.MSISDN:
+		m.MSISDN = i
+	case ie.EPSBearerID:
+		m.LinkedEBI = i
+	case ie.APNRestriction:
+		m.APNRestriction = i
+	case ie.ProtocolConfigurationOptions:
+		m.PCO = i
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0:
+			m.BearerContextsModified = append(m.BearerContextsModified, i)
+		case 1:
+			m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.ChangeReportingAction:
+		m.ChangeReportingAction = i
+	case ie.CSGInformationReportingAction:
+		m.CSGInformationReportingAction = i
+	case ie.HeNBInformationReporting:
+		m.HeNBInformationReporting = i
+	case ie.FullyQualifiedDomainName:
+		m.ChargingGatewayName = i
+	case ie.IPAddress:
+		m.ChargingGatewayAddress = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			m.PGWFQCSID = i
+		case 1:
+			m.SGWFQCSID = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.Recovery:
+		m.Recovery = i
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0:
+			m.SGWLDN = i
+		case 1:
+			m.PGWLDN = i
 		default:
 			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.Indication:
+		m.IndicationFlags = i
+	case ie.PresenceReportingAreaAction:
+		m.PresenceReportingAreaAction = append(m.PresenceReportingAreaAction, i)
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0:
+			m.PGWNodeLoadControlInformation = i
+		case 1:
+			m.PGWAPNLoadControlInformation = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			m.SGWNodeLoadControlInformation = i
+		case 1:
+			m.PGWOverloadControlInformation = i
+		case 2:
+			m.SGWOverloadControlInformation = i
+		default:
+			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			return false
 		}
+	case ie.ChargingID:
+		m.PDNConnectionChargingID = i
+	case ie.PrivateExtension:
+		m.PrivateExtension = i
+	default:
+		m.AdditionalIEs = append(m.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	m.SetLength()
-	return m
+// ieKind returns the reason why the IE does not belong to ModifyBearerResponse, or 0
+// if it is set to one of the fields.
+func (m *ModifyBearerResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.MSISDN, ie.EPSBearerID, ie.APNRestriction,
+		ie.ProtocolConfigurationOptions, ie.ChangeReportingAction, ie.CSGInformationReportingAction, ie.HeNBInformationReporting,
+		ie.FullyQualifiedDomainName, ie.IPAddress, ie.Recovery, ie.Indication,
+		ie.PresenceReportingAreaAction, ie.ChargingID, ie.PrivateExtension:
+		return 0
+	case ie.BearerContext:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LocalDistinguishedName:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ModifyBearerResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (m *ModifyBearerResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Modify Bearer Response", m.AdditionalIEs, m.ieKind)
 }
 
 // Marshal serializes ModifyBearerResponse into bytes.
@@ -158,157 +223,166 @@ func (m *ModifyBearerResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.MSISDN; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.APNRestriction; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PCO; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsModified {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsMarkedForRemoval {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ChangeReportingAction; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.CSGInformationReportingAction; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.HeNBInformationReporting; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ChargingGatewayName; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.ChargingGatewayAddress; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWLDN; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWLDN; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range m.PresenceReportingAreaAction {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PDNConnectionChargingID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -352,92 +426,11 @@ func (m *ModifyBearerResponse) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.MSISDN:
-			m.MSISDN = i
-		case ie.EPSBearerID:
-			m.LinkedEBI = i
-		case ie.APNRestriction:
-			m.APNRestriction = i
-		case ie.ProtocolConfigurationOptions:
-			m.PCO = i
-		case ie.BearerContext:
-			switch i.Instance() {
-			case 0:
-				m.BearerContextsModified = append(m.BearerContextsModified, i)
-			case 1:
-				m.BearerContextsMarkedForRemoval = append(m.BearerContextsMarkedForRemoval, i)
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ChangeReportingAction:
-			m.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			m.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			m.HeNBInformationReporting = i
-		case ie.FullyQualifiedDomainName:
-			m.ChargingGatewayName = i
-		case ie.IPAddress:
-			m.ChargingGatewayAddress = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.PGWFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.LocalDistinguishedName:
-			switch i.Instance() {
-			case 0:
-				m.SGWLDN = i
-			case 1:
-				m.PGWLDN = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			m.IndicationFlags = i
-		case ie.PresenceReportingAreaAction:
-			m.PresenceReportingAreaAction = append(m.PresenceReportingAreaAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.PGWNodeLoadControlInformation = i
-			case 1:
-				m.PGWAPNLoadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				m.SGWNodeLoadControlInformation = i
-			case 1:
-				m.PGWOverloadControlInformation = i
-			case 2:
-				m.SGWOverloadControlInformation = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.ChargingID:
-			m.PDNConnectionChargingID = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		m.setIE(i)
 	}
 
 	return nil
@@ -463,9 +456,15 @@ func (m *ModifyBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsModified {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.BearerContextsMarkedForRemoval {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.ChangeReportingAction; ie != nil {
@@ -502,6 +501,9 @@ func (m *ModifyBearerResponse) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range m.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := m.PGWNodeLoadControlInformation; ie != nil {
@@ -549,3 +551,262 @@ func (m *ModifyBearerResponse) MessageTypeName() string {
 func (m *ModifyBearerResponse) TEID() uint32 {
 	return m.Header.teid()
 }
+
+// ModifyBearerResponseBuilder builds ModifyBearerResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ModifyBearerResponseBuilder struct {
+	builder
+	msg *ModifyBearerResponse
+}
+
+// BuildModifyBearerResponse returns the builder of ModifyBearerResponse.
+func BuildModifyBearerResponse(teid, seq uint32) *ModifyBearerResponseBuilder {
+	return &ModifyBearerResponseBuilder{
+		builder: builder{msgType: "Modify Bearer Response"},
+		msg:     NewModifyBearerResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ModifyBearerResponseBuilder) WithCause(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithMSISDN sets the MSISDN IE to MSISDN.
+func (b *ModifyBearerResponseBuilder) WithMSISDN(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("MSISDN", i, ie.MSISDN) {
+		b.msg.MSISDN = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *ModifyBearerResponseBuilder) WithLinkedEBI(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithAPNRestriction sets the APNRestriction IE to APNRestriction.
+func (b *ModifyBearerResponseBuilder) WithAPNRestriction(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("APNRestriction", i, ie.APNRestriction) {
+		b.msg.APNRestriction = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *ModifyBearerResponseBuilder) WithPCO(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithBearerContextsModified appends the BearerContext IEs to BearerContextsModified, with the instance set to 0.
+func (b *ModifyBearerResponseBuilder) WithBearerContextsModified(ies ...*ie.IE) *ModifyBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsModified", i, ie.BearerContext) {
+			b.msg.BearerContextsModified = append(b.msg.BearerContextsModified, withInstance(i, 0))
+		}
+	}
+	return b
+}
+
+// WithBearerContextsMarkedForRemoval appends the BearerContext IEs to BearerContextsMarkedForRemoval, with the instance set to 1.
+func (b *ModifyBearerResponseBuilder) WithBearerContextsMarkedForRemoval(ies ...*ie.IE) *ModifyBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContextsMarkedForRemoval", i, ie.BearerContext) {
+			b.msg.BearerContextsMarkedForRemoval = append(b.msg.BearerContextsMarkedForRemoval, withInstance(i, 1))
+		}
+	}
+	return b
+}
+
+// WithChangeReportingAction sets the ChangeReportingAction IE to ChangeReportingAction.
+func (b *ModifyBearerResponseBuilder) WithChangeReportingAction(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("ChangeReportingAction", i, ie.ChangeReportingAction) {
+		b.msg.ChangeReportingAction = i
+	}
+	return b
+}
+
+// WithCSGInformationReportingAction sets the CSGInformationReportingAction IE to CSGInformationReportingAction.
+func (b *ModifyBearerResponseBuilder) WithCSGInformationReportingAction(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("CSGInformationReportingAction", i, ie.CSGInformationReportingAction) {
+		b.msg.CSGInformationReportingAction = i
+	}
+	return b
+}
+
+// WithHeNBInformationReporting sets the HeNBInformationReporting IE to HeNBInformationReporting.
+func (b *ModifyBearerResponseBuilder) WithHeNBInformationReporting(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("HeNBInformationReporting", i, ie.HeNBInformationReporting) {
+		b.msg.HeNBInformationReporting = i
+	}
+	return b
+}
+
+// WithChargingGatewayName sets the FullyQualifiedDomainName IE to ChargingGatewayName.
+func (b *ModifyBearerResponseBuilder) WithChargingGatewayName(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("ChargingGatewayName", i, ie.FullyQualifiedDomainName) {
+		b.msg.ChargingGatewayName = i
+	}
+	return b
+}
+
+// WithChargingGatewayAddress sets the IPAddress IE to ChargingGatewayAddress.
+func (b *ModifyBearerResponseBuilder) WithChargingGatewayAddress(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("ChargingGatewayAddress", i, ie.IPAddress) {
+		b.msg.ChargingGatewayAddress = i
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 0.
+func (b *ModifyBearerResponseBuilder) WithPGWFQCSID(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *ModifyBearerResponseBuilder) WithSGWFQCSID(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ModifyBearerResponseBuilder) WithRecovery(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithSGWLDN sets the LocalDistinguishedName IE to SGWLDN, with the instance set to 0.
+func (b *ModifyBearerResponseBuilder) WithSGWLDN(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("SGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.SGWLDN = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWLDN sets the LocalDistinguishedName IE to PGWLDN, with the instance set to 1.
+func (b *ModifyBearerResponseBuilder) WithPGWLDN(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PGWLDN", i, ie.LocalDistinguishedName) {
+		b.msg.PGWLDN = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ModifyBearerResponseBuilder) WithIndicationFlags(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPresenceReportingAreaAction appends the PresenceReportingAreaAction IEs to PresenceReportingAreaAction.
+func (b *ModifyBearerResponseBuilder) WithPresenceReportingAreaAction(ies ...*ie.IE) *ModifyBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAreaAction = append(b.msg.PresenceReportingAreaAction, i)
+		}
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 0.
+func (b *ModifyBearerResponseBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 1.
+func (b *ModifyBearerResponseBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the OverloadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 0.
+func (b *ModifyBearerResponseBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 1.
+func (b *ModifyBearerResponseBuilder) WithPGWOverloadControlInformation(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 2.
+func (b *ModifyBearerResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithPDNConnectionChargingID sets the ChargingID IE to PDNConnectionChargingID.
+func (b *ModifyBearerResponseBuilder) WithPDNConnectionChargingID(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PDNConnectionChargingID", i, ie.ChargingID) {
+		b.msg.PDNConnectionChargingID = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ModifyBearerResponseBuilder) WithPrivateExtension(i *ie.IE) *ModifyBearerResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewModifyBearerResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ModifyBearerResponseBuilder) WithIEs(ies ...*ie.IE) *ModifyBearerResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ModifyBearerResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *ModifyBearerResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ModifyBearerResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ModifyBearerResponseBuilder) Build() (*ModifyBearerResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
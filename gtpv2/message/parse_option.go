@@ -0,0 +1,80 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"errors"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// ParseOption configures how Parse handles the IEs that do not belong to the
+// message, which are kept in AdditionalIEs by default.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	strict       bool
+	unexpectedFn func(Message, *UnexpectedIEError)
+}
+
+// WithUnexpectedIEHandler lets Parse call fn with each IE that does not belong
+// to the message, either the type is unrecognized or the instance is
+// unexpected. The IEs are still kept in AdditionalIEs.
+func WithUnexpectedIEHandler(fn func(msg Message, err *UnexpectedIEError)) ParseOption {
+	return func(c *parseConfig) {
+		c.unexpectedFn = fn
+	}
+}
+
+// WithStrictParsing lets Parse fail if there is any IE that does not belong to
+// the message. The error wraps *UnexpectedIEError for each of the IEs.
+func WithStrictParsing() ParseOption {
+	return func(c *parseConfig) {
+		c.strict = true
+	}
+}
+
+// unexpectedIEReporter is implemented by the messages that can tell which IEs
+// in AdditionalIEs do not belong to the message.
+type unexpectedIEReporter interface {
+	UnexpectedIEs() []*UnexpectedIEError
+}
+
+// checkUnexpectedIEs reports the IEs that do not belong to m as configured.
+func (c *parseConfig) checkUnexpectedIEs(m Message) error {
+	r, ok := m.(unexpectedIEReporter)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, e := range r.UnexpectedIEs() {
+		if c.unexpectedFn != nil {
+			c.unexpectedFn(m, e)
+		}
+		if c.strict {
+			errs = append(errs, e)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unexpectedIEs returns the errors for the IEs in ies whose kind is not zero.
+func unexpectedIEs(msgType string, ies []*ie.IE, kind func(*ie.IE) UnexpectedIEKind) []*UnexpectedIEError {
+	var errs []*UnexpectedIEError
+	for _, i := range ies {
+		if i == nil {
+			continue
+		}
+		if k := kind(i); k != 0 {
+			errs = append(errs, newUnexpectedIEError(msgType, "", k, i))
+		}
+	}
+	return errs
+}
+
+func newUnexpectedIEError(msgType, field string, kind UnexpectedIEKind, i *ie.IE) *UnexpectedIEError {
+	return &UnexpectedIEError{MsgType: msgType, Field: field, Kind: kind, Type: i.Type, Instance: i.Instance()}
+}
@@ -0,0 +1,83 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+func TestParseUnexpectedIEs(t *testing.T) {
+	b, err := message.NewCreateSessionRequest(0, 1,
+		ie.NewIMSI("123451234567890"),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.2", "").WithInstance(1),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.3", "").WithInstance(3),
+		ie.NewMBMSFlags(1, 0),
+	).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*message.UnexpectedIEError{
+		{MsgType: "Create Session Request", Kind: message.IEUnexpectedInstance, Type: ie.FullyQualifiedTEID, Instance: 3},
+		{MsgType: "Create Session Request", Kind: message.IEUnrecognized, Type: ie.MBMSFlags},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		msg, err := message.Parse(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csReq := msg.(*message.CreateSessionRequest)
+		if got := len(csReq.AdditionalIEs); got != 2 {
+			t.Errorf("unexpected number of AdditionalIEs: %d", got)
+		}
+		if diff := cmp.Diff(csReq.UnexpectedIEs(), want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		var got []*message.UnexpectedIEError
+		if _, err := message.Parse(b, message.WithUnexpectedIEHandler(func(msg message.Message, e *message.UnexpectedIEError) {
+			got = append(got, e)
+		})); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		msg, err := message.Parse(b, message.WithStrictParsing())
+		if msg != nil {
+			t.Errorf("unexpectedly parsed: %v", msg)
+		}
+		var got *message.UnexpectedIEError
+		if !errors.As(err, &got) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(got, want[0]); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("strict/valid", func(t *testing.T) {
+		b, err := message.NewEchoRequest(1, ie.NewRecovery(1)).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := message.Parse(b, message.WithStrictParsing()); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -18,7 +20,7 @@ type PGWRestartNotificationAcknowledge struct {
 
 // NewPGWRestartNotificationAcknowledge creates a new PGWRestartNotificationAcknowledge.
 func NewPGWRestartNotificationAcknowledge(teid, seq uint32, ies ...*ie.IE) *PGWRestartNotificationAcknowledge {
-	m := &PGWRestartNotificationAcknowledge{
+	p := &PGWRestartNotificationAcknowledge{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypePGWRestartNotificationAcknowledge, teid, seq, nil,
@@ -29,85 +31,110 @@ func NewPGWRestartNotificationAcknowledge(teid, seq uint32, ies ...*ie.IE) *PGWR
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		p.setIE(i)
+	}
+
+	p.SetLength()
+	return p
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (p *PGWRestartNotificationAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		p.Cause = i
+	case ie.PrivateExtension:
+		p.PrivateExtension = i
+	default:
+		p.AdditionalIEs = append(p.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to PGWRestartNotificationAcknowledge, or 0
+// if it is set to one of the fields.
+func (p *PGWRestartNotificationAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.PrivateExtension:
+		return 0
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// PGWRestartNotificationAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (p *PGWRestartNotificationAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("PGW Restart Notification Acknowledge", p.AdditionalIEs, p.ieKind)
 }
 
 // Marshal serializes PGWRestartNotificationAcknowledge into bytes.
-func (m *PGWRestartNotificationAcknowledge) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
+func (p *PGWRestartNotificationAcknowledge) Marshal() ([]byte, error) {
+	b := make([]byte, p.MarshalLen())
+	if err := p.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes PGWRestartNotificationAcknowledge into bytes.
-func (m *PGWRestartNotificationAcknowledge) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+func (p *PGWRestartNotificationAcknowledge) MarshalTo(b []byte) error {
+	if p.Header.Payload != nil {
+		p.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	p.Header.Payload = make([]byte, p.MarshalLen()-p.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.Cause; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range p.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	p.Header.SetLength()
+	return p.Header.MarshalTo(b)
 }
 
 // ParsePGWRestartNotificationAcknowledge decodes given bytes as PGWRestartNotificationAcknowledge.
 func ParsePGWRestartNotificationAcknowledge(b []byte) (*PGWRestartNotificationAcknowledge, error) {
-	m := &PGWRestartNotificationAcknowledge{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	p := &PGWRestartNotificationAcknowledge{}
+	if err := p.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return p, nil
 }
 
 // UnmarshalBinary decodes given bytes as PGWRestartNotificationAcknowledge.
-func (m *PGWRestartNotificationAcknowledge) UnmarshalBinary(b []byte) error {
+func (p *PGWRestartNotificationAcknowledge) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	p.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(p.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(p.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -115,31 +142,24 @@ func (m *PGWRestartNotificationAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		p.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (m *PGWRestartNotificationAcknowledge) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+func (p *PGWRestartNotificationAcknowledge) MarshalLen() int {
+	l := p.Header.MarshalLen() - len(p.Header.Payload)
 
-	if ie := m.Cause; ie != nil {
+	if ie := p.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := p.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range p.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -149,16 +169,77 @@ func (m *PGWRestartNotificationAcknowledge) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *PGWRestartNotificationAcknowledge) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (p *PGWRestartNotificationAcknowledge) SetLength() {
+	p.Header.Length = uint16(p.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *PGWRestartNotificationAcknowledge) MessageTypeName() string {
+func (p *PGWRestartNotificationAcknowledge) MessageTypeName() string {
 	return "PGW Restart Notification Acknowledge"
 }
 
 // TEID returns the TEID in uint32.
-func (m *PGWRestartNotificationAcknowledge) TEID() uint32 {
-	return m.Header.teid()
+func (p *PGWRestartNotificationAcknowledge) TEID() uint32 {
+	return p.Header.teid()
+}
+
+// PGWRestartNotificationAcknowledgeBuilder builds PGWRestartNotificationAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type PGWRestartNotificationAcknowledgeBuilder struct {
+	builder
+	msg *PGWRestartNotificationAcknowledge
+}
+
+// BuildPGWRestartNotificationAcknowledge returns the builder of PGWRestartNotificationAcknowledge.
+func BuildPGWRestartNotificationAcknowledge(teid, seq uint32) *PGWRestartNotificationAcknowledgeBuilder {
+	return &PGWRestartNotificationAcknowledgeBuilder{
+		builder: builder{msgType: "PGW Restart Notification Acknowledge"},
+		msg:     NewPGWRestartNotificationAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *PGWRestartNotificationAcknowledgeBuilder) WithCause(i *ie.IE) *PGWRestartNotificationAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *PGWRestartNotificationAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *PGWRestartNotificationAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewPGWRestartNotificationAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *PGWRestartNotificationAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *PGWRestartNotificationAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *PGWRestartNotificationAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *PGWRestartNotificationAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the PGWRestartNotificationAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *PGWRestartNotificationAcknowledgeBuilder) Build() (*PGWRestartNotificationAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -20,7 +22,7 @@ type PGWRestartNotification struct {
 
 // NewPGWRestartNotification creates a new PGWRestartNotification.
 func NewPGWRestartNotification(teid, seq uint32, ies ...*ie.IE) *PGWRestartNotification {
-	m := &PGWRestartNotification{
+	p := &PGWRestartNotification{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypePGWRestartNotification, teid, seq, nil,
@@ -31,106 +33,138 @@ func NewPGWRestartNotification(teid, seq uint32, ies ...*ie.IE) *PGWRestartNotif
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				m.PGWS5S8IPAddressForControlPlaneOrPMIP = i
-			case 1:
-				m.SGWS11S4IPAddressForControlPlane = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Cause:
-			m.Cause = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		p.setIE(i)
+	}
+
+	p.SetLength()
+	return p
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (p *PGWRestartNotification) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			p.PGWS5S8IPAddressForControlPlaneOrPMIP = i
+		case 1:
+			p.SGWS11S4IPAddressForControlPlane = i
 		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			p.AdditionalIEs = append(p.AdditionalIEs, i)
+			return false
 		}
+	case ie.Cause:
+		p.Cause = i
+	case ie.PrivateExtension:
+		p.PrivateExtension = i
+	default:
+		p.AdditionalIEs = append(p.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to PGWRestartNotification, or 0
+// if it is set to one of the fields.
+func (p *PGWRestartNotification) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.PrivateExtension:
+		return 0
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// PGWRestartNotification, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (p *PGWRestartNotification) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("PGW Restart Notification", p.AdditionalIEs, p.ieKind)
 }
 
 // Marshal serializes PGWRestartNotification into bytes.
-func (m *PGWRestartNotification) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
+func (p *PGWRestartNotification) Marshal() ([]byte, error) {
+	b := make([]byte, p.MarshalLen())
+	if err := p.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes PGWRestartNotification into bytes.
-func (m *PGWRestartNotification) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+func (p *PGWRestartNotification) MarshalTo(b []byte) error {
+	if p.Header.Payload != nil {
+		p.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	p.Header.Payload = make([]byte, p.MarshalLen()-p.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.PGWS5S8IPAddressForControlPlaneOrPMIP; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.PGWS5S8IPAddressForControlPlaneOrPMIP; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.SGWS11S4IPAddressForControlPlane; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.SGWS11S4IPAddressForControlPlane; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.Cause; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := p.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range p.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(p.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	p.Header.SetLength()
+	return p.Header.MarshalTo(b)
 }
 
 // ParsePGWRestartNotification decodes given bytes as PGWRestartNotification.
 func ParsePGWRestartNotification(b []byte) (*PGWRestartNotification, error) {
-	m := &PGWRestartNotification{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	p := &PGWRestartNotification{}
+	if err := p.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return p, nil
 }
 
 // UnmarshalBinary decodes given bytes as PGWRestartNotification.
-func (m *PGWRestartNotification) UnmarshalBinary(b []byte) error {
+func (p *PGWRestartNotification) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	p.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(p.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(p.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -138,46 +172,30 @@ func (m *PGWRestartNotification) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				m.PGWS5S8IPAddressForControlPlaneOrPMIP = i
-			case 1:
-				m.SGWS11S4IPAddressForControlPlane = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.Cause:
-			m.Cause = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		p.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (m *PGWRestartNotification) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+func (p *PGWRestartNotification) MarshalLen() int {
+	l := p.Header.MarshalLen() - len(p.Header.Payload)
 
-	if ie := m.PGWS5S8IPAddressForControlPlaneOrPMIP; ie != nil {
+	if ie := p.PGWS5S8IPAddressForControlPlaneOrPMIP; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.SGWS11S4IPAddressForControlPlane; ie != nil {
+	if ie := p.SGWS11S4IPAddressForControlPlane; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.Cause; ie != nil {
+	if ie := p.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := p.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range p.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -187,16 +205,93 @@ func (m *PGWRestartNotification) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *PGWRestartNotification) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (p *PGWRestartNotification) SetLength() {
+	p.Header.Length = uint16(p.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *PGWRestartNotification) MessageTypeName() string {
+func (p *PGWRestartNotification) MessageTypeName() string {
 	return "PGW Restart Notification"
 }
 
 // TEID returns the TEID in uint32.
-func (m *PGWRestartNotification) TEID() uint32 {
-	return m.Header.teid()
+func (p *PGWRestartNotification) TEID() uint32 {
+	return p.Header.teid()
+}
+
+// PGWRestartNotificationBuilder builds PGWRestartNotification.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type PGWRestartNotificationBuilder struct {
+	builder
+	msg *PGWRestartNotification
+}
+
+// BuildPGWRestartNotification returns the builder of PGWRestartNotification.
+func BuildPGWRestartNotification(teid, seq uint32) *PGWRestartNotificationBuilder {
+	return &PGWRestartNotificationBuilder{
+		builder: builder{msgType: "PGW Restart Notification"},
+		msg:     NewPGWRestartNotification(teid, seq),
+	}
+}
+
+// WithPGWS5S8IPAddressForControlPlaneOrPMIP sets the IPAddress IE to PGWS5S8IPAddressForControlPlaneOrPMIP, with the instance set to 0.
+func (b *PGWRestartNotificationBuilder) WithPGWS5S8IPAddressForControlPlaneOrPMIP(i *ie.IE) *PGWRestartNotificationBuilder {
+	if b.expect("PGWS5S8IPAddressForControlPlaneOrPMIP", i, ie.IPAddress) {
+		b.msg.PGWS5S8IPAddressForControlPlaneOrPMIP = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWS11S4IPAddressForControlPlane sets the IPAddress IE to SGWS11S4IPAddressForControlPlane, with the instance set to 1.
+func (b *PGWRestartNotificationBuilder) WithSGWS11S4IPAddressForControlPlane(i *ie.IE) *PGWRestartNotificationBuilder {
+	if b.expect("SGWS11S4IPAddressForControlPlane", i, ie.IPAddress) {
+		b.msg.SGWS11S4IPAddressForControlPlane = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *PGWRestartNotificationBuilder) WithCause(i *ie.IE) *PGWRestartNotificationBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *PGWRestartNotificationBuilder) WithPrivateExtension(i *ie.IE) *PGWRestartNotificationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewPGWRestartNotification. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *PGWRestartNotificationBuilder) WithIEs(ies ...*ie.IE) *PGWRestartNotificationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *PGWRestartNotificationBuilder) WithAdditionalIEs(ies ...*ie.IE) *PGWRestartNotificationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the PGWRestartNotification built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *PGWRestartNotificationBuilder) Build() (*PGWRestartNotification, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
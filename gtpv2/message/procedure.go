@@ -0,0 +1,126 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message
+
+import (
+	"encoding/binary"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// initialTypes is the map of the triggered (response) message types and the
+// initial (request) message types that trigger them, defined in TS 29.274 Table 6.1-1.
+var initialTypes = map[uint8]uint8{
+	MsgTypeEchoResponse:                               MsgTypeEchoRequest,
+	MsgTypeCreateSessionResponse:                      MsgTypeCreateSessionRequest,
+	MsgTypeModifyBearerResponse:                       MsgTypeModifyBearerRequest,
+	MsgTypeDeleteSessionResponse:                      MsgTypeDeleteSessionRequest,
+	MsgTypeChangeNotificationResponse:                 MsgTypeChangeNotificationRequest,
+	MsgTypeRemoteUEReportAcknowledge:                  MsgTypeRemoteUEReportNotification,
+	MsgTypeModifyBearerFailureIndication:              MsgTypeModifyBearerCommand,
+	MsgTypeDeleteBearerFailureIndication:              MsgTypeDeleteBearerCommand,
+	MsgTypeBearerResourceFailureIndication:            MsgTypeBearerResourceCommand,
+	MsgTypeCreateBearerResponse:                       MsgTypeCreateBearerRequest,
+	MsgTypeUpdateBearerResponse:                       MsgTypeUpdateBearerRequest,
+	MsgTypeDeleteBearerResponse:                       MsgTypeDeleteBearerRequest,
+	MsgTypeDeletePDNConnectionSetResponse:             MsgTypeDeletePDNConnectionSetRequest,
+	MsgTypePGWDownlinkTriggeringAcknowledge:           MsgTypePGWDownlinkTriggeringNotification,
+	MsgTypeIdentificationResponse:                     MsgTypeIdentificationRequest,
+	MsgTypeContextResponse:                            MsgTypeContextRequest,
+	MsgTypeForwardRelocationResponse:                  MsgTypeForwardRelocationRequest,
+	MsgTypeForwardRelocationCompleteAcknowledge:       MsgTypeForwardRelocationCompleteNotification,
+	MsgTypeForwardAccessContextAcknowledge:            MsgTypeForwardAccessContextNotification,
+	MsgTypeRelocationCancelResponse:                   MsgTypeRelocationCancelRequest,
+	MsgTypeDetachAcknowledge:                          MsgTypeDetachNotification,
+	MsgTypeAlertMMEAcknowledge:                        MsgTypeAlertMMENotification,
+	MsgTypeUEActivityAcknowledge:                      MsgTypeUEActivityNotification,
+	MsgTypeUERegistrationQueryResponse:                MsgTypeUERegistrationQueryRequest,
+	MsgTypeCreateForwardingTunnelResponse:             MsgTypeCreateForwardingTunnelRequest,
+	MsgTypeSuspendAcknowledge:                         MsgTypeSuspendNotification,
+	MsgTypeResumeAcknowledge:                          MsgTypeResumeNotification,
+	MsgTypeCreateIndirectDataForwardingTunnelResponse: MsgTypeCreateIndirectDataForwardingTunnelRequest,
+	MsgTypeDeleteIndirectDataForwardingTunnelResponse: MsgTypeDeleteIndirectDataForwardingTunnelRequest,
+	MsgTypeReleaseAccessBearersResponse:               MsgTypeReleaseAccessBearersRequest,
+	MsgTypeDownlinkDataNotificationAcknowledge:        MsgTypeDownlinkDataNotification,
+	MsgTypePGWRestartNotificationAcknowledge:          MsgTypePGWRestartNotification,
+	MsgTypeUpdatePDNConnectionSetResponse:             MsgTypeUpdatePDNConnectionSetRequest,
+	MsgTypeModifyAccessBearersResponse:                MsgTypeModifyAccessBearersRequest,
+	MsgTypeMBMSSessionStartResponse:                   MsgTypeMBMSSessionStartRequest,
+	MsgTypeMBMSSessionUpdateResponse:                  MsgTypeMBMSSessionUpdateRequest,
+	MsgTypeMBMSSessionStopResponse:                    MsgTypeMBMSSessionStopRequest,
+	MsgTypeSRVCCPsToCsResponse:                        MsgTypeSRVCCPsToCsRequest,
+	MsgTypeSRVCCPsToCsCompleteAcknowledge:             MsgTypeSRVCCPsToCsCompleteNotification,
+	MsgTypeSRVCCPsToCsCancelAcknowledge:               MsgTypeSRVCCPsToCsCancelNotification,
+	MsgTypeSRVCCCsToPsResponse:                        MsgTypeSRVCCCsToPsRequest,
+	MsgTypeSRVCCCsToPsCompleteAcknowledge:             MsgTypeSRVCCCsToPsCompleteNotification,
+	MsgTypeSRVCCCsToPsCancelAcknowledge:               MsgTypeSRVCCCsToPsCancelNotification,
+	MsgTypeDirectTransferResponse:                     MsgTypeDirectTransferRequest,
+	MsgTypeNotificationResponse:                       MsgTypeNotificationRequest,
+}
+
+// IsInitial reports whether msgType is the type of Initial message that expects
+// a Triggered message in response, e.g., Create Session Request.
+func IsInitial(msgType uint8) bool {
+	_, ok := triggeredTypes[msgType]
+	return ok
+}
+
+// IsTriggered reports whether msgType is the type of Triggered message sent in
+// response to an Initial message, e.g., Create Session Response.
+func IsTriggered(msgType uint8) bool {
+	_, ok := initialTypes[msgType]
+	return ok
+}
+
+// InitialTypeOf returns the type of Initial message that the Triggered message
+// of msgType is sent in response to, e.g., MsgTypeCreateSessionRequest for
+// MsgTypeCreateSessionResponse. ok is false if msgType is not a Triggered message.
+func InitialTypeOf(msgType uint8) (initial uint8, ok bool) {
+	initial, ok = initialTypes[msgType]
+	return
+}
+
+// TriggeredTypeOf returns the type of Triggered message that is sent in response
+// to the Initial message of msgType, e.g., MsgTypeCreateSessionResponse for
+// MsgTypeCreateSessionRequest. ok is false if msgType is not an Initial message.
+func TriggeredTypeOf(msgType uint8) (triggered uint8, ok bool) {
+	triggered, ok = triggeredTypes[msgType]
+	return
+}
+
+// triggeredTypes is the reverse of initialTypes.
+var triggeredTypes = func() map[uint8]uint8 {
+	m := make(map[uint8]uint8, len(initialTypes))
+	for res, req := range initialTypes {
+		m[req] = res
+	}
+	return m
+}()
+
+// CauseOf returns the value of the top-level Cause IE in the message in b, or 0
+// if not found. This is to get the Cause without parsing the whole message.
+func CauseOf(b []byte) uint8 {
+	if len(b) < 8 {
+		return 0
+	}
+
+	end := 4 + int(binary.BigEndian.Uint16(b[2:4]))
+	if end > len(b) {
+		end = len(b)
+	}
+	offset := 8
+	if b[0]&0x08 != 0 {
+		offset = 12
+	}
+
+	for offset+4 < end {
+		typ, l, instance := b[offset], int(binary.BigEndian.Uint16(b[offset+1:offset+3])), b[offset+3]&0x0f
+		if typ == ie.Cause && instance == 0 && l > 0 {
+			return b[offset+4]
+		}
+		offset += 4 + l
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+func TestProcedure(t *testing.T) {
+	cases := []struct {
+		description   string
+		msgType       uint8
+		initial       bool
+		triggered     bool
+		initialType   uint8
+		triggeredType uint8
+	}{
+		{"Create Session Request", message.MsgTypeCreateSessionRequest, true, false, 0, message.MsgTypeCreateSessionResponse},
+		{"Create Session Response", message.MsgTypeCreateSessionResponse, false, true, message.MsgTypeCreateSessionRequest, 0},
+		{"Delete Bearer Command", message.MsgTypeDeleteBearerCommand, true, false, 0, message.MsgTypeDeleteBearerFailureIndication},
+		{"Delete Bearer Failure Indication", message.MsgTypeDeleteBearerFailureIndication, false, true, message.MsgTypeDeleteBearerCommand, 0},
+		{"Downlink Data Notification Acknowledge", message.MsgTypeDownlinkDataNotificationAcknowledge, false, true, message.MsgTypeDownlinkDataNotification, 0},
+		{"Version Not Supported Indication", message.MsgTypeVersionNotSupportedIndication, false, false, 0, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if got := message.IsInitial(c.msgType); got != c.initial {
+				t.Errorf("IsInitial: want %v, got %v", c.initial, got)
+			}
+			if got := message.IsTriggered(c.msgType); got != c.triggered {
+				t.Errorf("IsTriggered: want %v, got %v", c.triggered, got)
+			}
+			if got, _ := message.InitialTypeOf(c.msgType); got != c.initialType {
+				t.Errorf("InitialTypeOf: want %d, got %d", c.initialType, got)
+			}
+			if got, _ := message.TriggeredTypeOf(c.msgType); got != c.triggeredType {
+				t.Errorf("TriggeredTypeOf: want %d, got %d", c.triggeredType, got)
+			}
+		})
+	}
+}
+
+func TestCauseOf(t *testing.T) {
+	cases := []struct {
+		description string
+		msg         message.Message
+		cause       uint8
+	}{
+		{
+			"With Cause",
+			message.NewCreateSessionResponse(
+				0x11111111, 0x222222,
+				ie.NewRecovery(1),
+				ie.NewCause(64, 0, 0, 0, nil),
+			),
+			64,
+		}, {
+			"Without Cause",
+			message.NewEchoResponse(0x222222, ie.NewRecovery(1)),
+			0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := message.Marshal(c.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := message.CauseOf(b); got != c.cause {
+				t.Errorf("want %d, got %d", c.cause, got)
+			}
+		})
+	}
+}
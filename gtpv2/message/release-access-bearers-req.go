@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ReleaseAccessBearersRequest is a ReleaseAccessBearersRequest Header and its IEs above.
 type ReleaseAccessBearersRequest struct {
@@ -30,26 +34,52 @@ func NewReleaseAccessBearersRequest(teid, seq uint32, ies ...*ie.IE) *ReleaseAcc
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.EPSBearerID:
-			r.ListOfRABs = append(r.ListOfRABs, i)
-		case ie.NodeType:
-			r.OriginatingNode = i
-		case ie.Indication:
-			r.IndicationFlags = i
-		case ie.SecondaryRATUsageDataReport:
-			r.SecondaryRATUsageDataReport = append(r.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			r.PrivateExtension = i
-		default:
-			r.AdditionalIEs = append(r.AdditionalIEs, i)
-		}
+		r.setIE(i)
 	}
 
 	r.SetLength()
 	return r
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (r *ReleaseAccessBearersRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.EPSBearerID:
+		r.ListOfRABs = append(r.ListOfRABs, i)
+	case ie.NodeType:
+		r.OriginatingNode = i
+	case ie.Indication:
+		r.IndicationFlags = i
+	case ie.SecondaryRATUsageDataReport:
+		r.SecondaryRATUsageDataReport = append(r.SecondaryRATUsageDataReport, i)
+	case ie.PrivateExtension:
+		r.PrivateExtension = i
+	default:
+		r.AdditionalIEs = append(r.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ReleaseAccessBearersRequest, or 0
+// if it is set to one of the fields.
+func (r *ReleaseAccessBearersRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.EPSBearerID, ie.NodeType, ie.Indication, ie.SecondaryRATUsageDataReport,
+		ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ReleaseAccessBearersRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (r *ReleaseAccessBearersRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Release Access Bearers Request", r.AdditionalIEs, r.ieKind)
+}
+
 // Marshal serializes ReleaseAccessBearersRequest into bytes.
 func (r *ReleaseAccessBearersRequest) Marshal() ([]byte, error) {
 	b := make([]byte, r.MarshalLen())
@@ -68,31 +98,37 @@ func (r *ReleaseAccessBearersRequest) MarshalTo(b []byte) error {
 
 	offset := 0
 	for _, ie := range r.ListOfRABs {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.OriginatingNode; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	for _, ie := range r.SecondaryRATUsageDataReport {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -140,20 +176,7 @@ func (r *ReleaseAccessBearersRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.EPSBearerID:
-			r.ListOfRABs = append(r.ListOfRABs, i)
-		case ie.NodeType:
-			r.OriginatingNode = i
-		case ie.Indication:
-			r.IndicationFlags = i
-		case ie.SecondaryRATUsageDataReport:
-			r.SecondaryRATUsageDataReport = append(r.SecondaryRATUsageDataReport, i)
-		case ie.PrivateExtension:
-			r.PrivateExtension = i
-		default:
-			r.AdditionalIEs = append(r.AdditionalIEs, i)
-		}
+		r.setIE(i)
 	}
 
 	return nil
@@ -162,7 +185,11 @@ func (r *ReleaseAccessBearersRequest) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (r *ReleaseAccessBearersRequest) MarshalLen() int {
 	l := r.Header.MarshalLen() - len(r.Header.Payload)
+
 	for _, ie := range r.ListOfRABs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := r.OriginatingNode; ie != nil {
@@ -172,6 +199,9 @@ func (r *ReleaseAccessBearersRequest) MarshalLen() int {
 		l += ie.MarshalLen()
 	}
 	for _, ie := range r.SecondaryRATUsageDataReport {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	if ie := r.PrivateExtension; ie != nil {
@@ -201,3 +231,92 @@ func (r *ReleaseAccessBearersRequest) MessageTypeName() string {
 func (r *ReleaseAccessBearersRequest) TEID() uint32 {
 	return r.Header.teid()
 }
+
+// ReleaseAccessBearersRequestBuilder builds ReleaseAccessBearersRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ReleaseAccessBearersRequestBuilder struct {
+	builder
+	msg *ReleaseAccessBearersRequest
+}
+
+// BuildReleaseAccessBearersRequest returns the builder of ReleaseAccessBearersRequest.
+func BuildReleaseAccessBearersRequest(teid, seq uint32) *ReleaseAccessBearersRequestBuilder {
+	return &ReleaseAccessBearersRequestBuilder{
+		builder: builder{msgType: "Release Access Bearers Request"},
+		msg:     NewReleaseAccessBearersRequest(teid, seq),
+	}
+}
+
+// WithListOfRABs appends the EPSBearerID IEs to ListOfRABs.
+func (b *ReleaseAccessBearersRequestBuilder) WithListOfRABs(ies ...*ie.IE) *ReleaseAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if b.expect("ListOfRABs", i, ie.EPSBearerID) {
+			b.msg.ListOfRABs = append(b.msg.ListOfRABs, i)
+		}
+	}
+	return b
+}
+
+// WithOriginatingNode sets the NodeType IE to OriginatingNode.
+func (b *ReleaseAccessBearersRequestBuilder) WithOriginatingNode(i *ie.IE) *ReleaseAccessBearersRequestBuilder {
+	if b.expect("OriginatingNode", i, ie.NodeType) {
+		b.msg.OriginatingNode = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ReleaseAccessBearersRequestBuilder) WithIndicationFlags(i *ie.IE) *ReleaseAccessBearersRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSecondaryRATUsageDataReport appends the SecondaryRATUsageDataReport IEs to SecondaryRATUsageDataReport.
+func (b *ReleaseAccessBearersRequestBuilder) WithSecondaryRATUsageDataReport(ies ...*ie.IE) *ReleaseAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if b.expect("SecondaryRATUsageDataReport", i, ie.SecondaryRATUsageDataReport) {
+			b.msg.SecondaryRATUsageDataReport = append(b.msg.SecondaryRATUsageDataReport, i)
+		}
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ReleaseAccessBearersRequestBuilder) WithPrivateExtension(i *ie.IE) *ReleaseAccessBearersRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewReleaseAccessBearersRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ReleaseAccessBearersRequestBuilder) WithIEs(ies ...*ie.IE) *ReleaseAccessBearersRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ReleaseAccessBearersRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *ReleaseAccessBearersRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ReleaseAccessBearersRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ReleaseAccessBearersRequestBuilder) Build() (*ReleaseAccessBearersRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // ReleaseAccessBearersResponse is a ReleaseAccessBearersResponse Header and its IEs above.
 type ReleaseAccessBearersResponse struct {
@@ -31,28 +35,54 @@ func NewReleaseAccessBearersResponse(teid, seq uint32, ies ...*ie.IE) *ReleaseAc
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			r.Cause = i
-		case ie.Recovery:
-			r.Recovery = i
-		case ie.Indication:
-			r.IndicationFlags = i
-		case ie.LoadControlInformation:
-			r.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			r.SGWOverloadControlInformation = i
-		case ie.PrivateExtension:
-			r.PrivateExtension = i
-		default:
-			r.AdditionalIEs = append(r.AdditionalIEs, i)
-		}
+		r.setIE(i)
 	}
 
 	r.SetLength()
 	return r
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (r *ReleaseAccessBearersResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		r.Cause = i
+	case ie.Recovery:
+		r.Recovery = i
+	case ie.Indication:
+		r.IndicationFlags = i
+	case ie.LoadControlInformation:
+		r.SGWNodeLoadControlInformation = i
+	case ie.OverloadControlInformation:
+		r.SGWOverloadControlInformation = i
+	case ie.PrivateExtension:
+		r.PrivateExtension = i
+	default:
+		r.AdditionalIEs = append(r.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ReleaseAccessBearersResponse, or 0
+// if it is set to one of the fields.
+func (r *ReleaseAccessBearersResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.Recovery, ie.Indication, ie.LoadControlInformation,
+		ie.OverloadControlInformation, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ReleaseAccessBearersResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (r *ReleaseAccessBearersResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Release Access Bearers Response", r.AdditionalIEs, r.ieKind)
+}
+
 // Marshal serializes ReleaseAccessBearersResponse into bytes.
 func (r *ReleaseAccessBearersResponse) Marshal() ([]byte, error) {
 	b := make([]byte, r.MarshalLen())
@@ -71,37 +101,37 @@ func (r *ReleaseAccessBearersResponse) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := r.Cause; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.Recovery; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := r.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(r.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -149,22 +179,7 @@ func (r *ReleaseAccessBearersResponse) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			r.Cause = i
-		case ie.Recovery:
-			r.Recovery = i
-		case ie.Indication:
-			r.IndicationFlags = i
-		case ie.LoadControlInformation:
-			r.SGWNodeLoadControlInformation = i
-		case ie.OverloadControlInformation:
-			r.SGWOverloadControlInformation = i
-		case ie.PrivateExtension:
-			r.PrivateExtension = i
-		default:
-			r.AdditionalIEs = append(r.AdditionalIEs, i)
-		}
+		r.setIE(i)
 	}
 
 	return nil
@@ -216,3 +231,96 @@ func (r *ReleaseAccessBearersResponse) MessageTypeName() string {
 func (r *ReleaseAccessBearersResponse) TEID() uint32 {
 	return r.Header.teid()
 }
+
+// ReleaseAccessBearersResponseBuilder builds ReleaseAccessBearersResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ReleaseAccessBearersResponseBuilder struct {
+	builder
+	msg *ReleaseAccessBearersResponse
+}
+
+// BuildReleaseAccessBearersResponse returns the builder of ReleaseAccessBearersResponse.
+func BuildReleaseAccessBearersResponse(teid, seq uint32) *ReleaseAccessBearersResponseBuilder {
+	return &ReleaseAccessBearersResponseBuilder{
+		builder: builder{msgType: "Release Access Bearers Response"},
+		msg:     NewReleaseAccessBearersResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ReleaseAccessBearersResponseBuilder) WithCause(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *ReleaseAccessBearersResponseBuilder) WithRecovery(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *ReleaseAccessBearersResponseBuilder) WithIndicationFlags(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation.
+func (b *ReleaseAccessBearersResponseBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = i
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation.
+func (b *ReleaseAccessBearersResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ReleaseAccessBearersResponseBuilder) WithPrivateExtension(i *ie.IE) *ReleaseAccessBearersResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewReleaseAccessBearersResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ReleaseAccessBearersResponseBuilder) WithIEs(ies ...*ie.IE) *ReleaseAccessBearersResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ReleaseAccessBearersResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *ReleaseAccessBearersResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ReleaseAccessBearersResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ReleaseAccessBearersResponseBuilder) Build() (*ReleaseAccessBearersResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
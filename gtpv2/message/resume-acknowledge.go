@@ -2,22 +2,25 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
 	"github.com/wmnsk/go-gtp/gtpv2/ie"
 )
 
-// ResumeAcknowledge is a ResumeAcknowledge Header and its Cause and Private Extension IEs.
+// ResumeAcknowledge is a ResumeAcknowledge Header and its IEs above.
 type ResumeAcknowledge struct {
 	*Header
 	Cause            *ie.IE
 	PrivateExtension *ie.IE
+	AdditionalIEs    []*ie.IE
 }
 
 // NewResumeAcknowledge creates a new ResumeAcknowledge.
 func NewResumeAcknowledge(teid, seq uint32, ies ...*ie.IE) *ResumeAcknowledge {
-	c := &ResumeAcknowledge{
+	r := &ResumeAcknowledge{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeResumeAcknowledge, teid, seq, nil,
@@ -28,72 +31,110 @@ func NewResumeAcknowledge(teid, seq uint32, ies ...*ie.IE) *ResumeAcknowledge {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		}
+		r.setIE(i)
+	}
+
+	r.SetLength()
+	return r
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (r *ResumeAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		r.Cause = i
+	case ie.PrivateExtension:
+		r.PrivateExtension = i
+	default:
+		r.AdditionalIEs = append(r.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ResumeAcknowledge, or 0
+// if it is set to one of the fields.
+func (r *ResumeAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ResumeAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (r *ResumeAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Resume Acknowledge", r.AdditionalIEs, r.ieKind)
 }
 
 // Marshal serializes ResumeAcknowledge into bytes.
-func (c *ResumeAcknowledge) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (r *ResumeAcknowledge) Marshal() ([]byte, error) {
+	b := make([]byte, r.MarshalLen())
+	if err := r.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes ResumeAcknowledge into bytes.
-func (c *ResumeAcknowledge) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (r *ResumeAcknowledge) MarshalTo(b []byte) error {
+	if r.Header.Payload != nil {
+		r.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	r.Header.Payload = make([]byte, r.MarshalLen()-r.Header.MarshalLen())
 
 	offset := 0
-	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.Cause; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
+		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	for _, ie := range r.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+
+	r.Header.SetLength()
+	return r.Header.MarshalTo(b)
 }
 
 // ParseResumeAcknowledge decodes given bytes as ResumeAcknowledge.
 func ParseResumeAcknowledge(b []byte) (*ResumeAcknowledge, error) {
-	c := &ResumeAcknowledge{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	r := &ResumeAcknowledge{}
+	if err := r.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return r, nil
 }
 
 // UnmarshalBinary decodes given bytes as ResumeAcknowledge.
-func (c *ResumeAcknowledge) UnmarshalBinary(b []byte) error {
+func (r *ResumeAcknowledge) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	r.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(r.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(r.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -101,41 +142,104 @@ func (c *ResumeAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		}
+		r.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *ResumeAcknowledge) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
+func (r *ResumeAcknowledge) MarshalLen() int {
+	l := r.Header.MarshalLen() - len(r.Header.Payload)
 
-	if ie := c.Cause; ie != nil {
+	if ie := r.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := r.PrivateExtension; ie != nil {
+		l += ie.MarshalLen()
+	}
+
+	for _, ie := range r.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	return l
 }
 
 // SetLength sets the length in Length field.
-func (c *ResumeAcknowledge) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (r *ResumeAcknowledge) SetLength() {
+	r.Header.Length = uint16(r.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *ResumeAcknowledge) MessageTypeName() string {
+func (r *ResumeAcknowledge) MessageTypeName() string {
 	return "Resume Acknowledge"
 }
 
 // TEID returns the TEID in uint32.
-func (c *ResumeAcknowledge) TEID() uint32 {
-	return c.Header.teid()
+func (r *ResumeAcknowledge) TEID() uint32 {
+	return r.Header.teid()
+}
+
+// ResumeAcknowledgeBuilder builds ResumeAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ResumeAcknowledgeBuilder struct {
+	builder
+	msg *ResumeAcknowledge
+}
+
+// BuildResumeAcknowledge returns the builder of ResumeAcknowledge.
+func BuildResumeAcknowledge(teid, seq uint32) *ResumeAcknowledgeBuilder {
+	return &ResumeAcknowledgeBuilder{
+		builder: builder{msgType: "Resume Acknowledge"},
+		msg:     NewResumeAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *ResumeAcknowledgeBuilder) WithCause(i *ie.IE) *ResumeAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ResumeAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *ResumeAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewResumeAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ResumeAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *ResumeAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ResumeAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *ResumeAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ResumeAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ResumeAcknowledgeBuilder) Build() (*ResumeAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -2,13 +2,15 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
 	"github.com/wmnsk/go-gtp/gtpv2/ie"
 )
 
-// ResumeNotification is a ResumeNotification Header and its IEs.
+// ResumeNotification is a ResumeNotification Header and its IEs above.
 type ResumeNotification struct {
 	*Header
 	IMSI                       *ie.IE
@@ -16,11 +18,12 @@ type ResumeNotification struct {
 	OriginatingNode            *ie.IE
 	SenderFTEIDForControlPlane *ie.IE
 	PrivateExtension           *ie.IE
+	AdditionalIEs              []*ie.IE
 }
 
 // NewResumeNotification creates a new ResumeNotification.
 func NewResumeNotification(teid, seq uint32, ies ...*ie.IE) *ResumeNotification {
-	c := &ResumeNotification{
+	r := &ResumeNotification{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeResumeNotification, teid, seq, nil,
@@ -31,153 +34,273 @@ func NewResumeNotification(teid, seq uint32, ies ...*ie.IE) *ResumeNotification
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.EPSBearerID:
-			c.LinkedEPSBearerID = i
-		case ie.NodeType:
-			c.OriginatingNode = i
-		case ie.FullyQualifiedTEID:
-			c.SenderFTEIDForControlPlane = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		}
+		r.setIE(i)
+	}
+
+	r.SetLength()
+	return r
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (r *ResumeNotification) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		r.IMSI = i
+	case ie.EPSBearerID:
+		r.LinkedEPSBearerID = i
+	case ie.NodeType:
+		r.OriginatingNode = i
+	case ie.FullyQualifiedTEID:
+		r.SenderFTEIDForControlPlane = i
+	case ie.PrivateExtension:
+		r.PrivateExtension = i
+	default:
+		r.AdditionalIEs = append(r.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to ResumeNotification, or 0
+// if it is set to one of the fields.
+func (r *ResumeNotification) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.EPSBearerID, ie.NodeType, ie.FullyQualifiedTEID,
+		ie.PrivateExtension:
+		return 0
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// ResumeNotification, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (r *ResumeNotification) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Resume Notification", r.AdditionalIEs, r.ieKind)
 }
 
 // Marshal serializes ResumeNotification into bytes.
-func (c *ResumeNotification) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (r *ResumeNotification) Marshal() ([]byte, error) {
+	b := make([]byte, r.MarshalLen())
+	if err := r.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes ResumeNotification into bytes.
-func (c *ResumeNotification) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (r *ResumeNotification) MarshalTo(b []byte) error {
+	if r.Header.Payload != nil {
+		r.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	r.Header.Payload = make([]byte, r.MarshalLen()-r.Header.MarshalLen())
 
 	offset := 0
-	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.IMSI; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
+			return err
+		}
+		offset += ie.MarshalLen()
+	}
+	if ie := r.LinkedEPSBearerID; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.LinkedEPSBearerID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.OriginatingNode; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.OriginatingNode; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.SenderFTEIDForControlPlane; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SenderFTEIDForControlPlane; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := r.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+
+	for _, ie := range r.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(r.Header.Payload[offset:]); err != nil {
 			return err
 		}
+		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	r.Header.SetLength()
+	return r.Header.MarshalTo(b)
 }
 
 // ParseResumeNotification decodes given bytes as ResumeNotification.
 func ParseResumeNotification(b []byte) (*ResumeNotification, error) {
-	c := &ResumeNotification{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	r := &ResumeNotification{}
+	if err := r.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return r, nil
 }
 
 // UnmarshalBinary decodes given bytes as ResumeNotification.
-func (c *ResumeNotification) UnmarshalBinary(b []byte) error {
+func (r *ResumeNotification) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	r.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(r.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(r.Header.Payload)
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.EPSBearerID:
-			c.LinkedEPSBearerID = i
-		case ie.NodeType:
-			c.OriginatingNode = i
-		case ie.FullyQualifiedTEID:
-			c.SenderFTEIDForControlPlane = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		}
+		r.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *ResumeNotification) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
-	if ie := c.IMSI; ie != nil {
+func (r *ResumeNotification) MarshalLen() int {
+	l := r.Header.MarshalLen() - len(r.Header.Payload)
+
+	if ie := r.IMSI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.LinkedEPSBearerID; ie != nil {
+	if ie := r.LinkedEPSBearerID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.OriginatingNode; ie != nil {
+	if ie := r.OriginatingNode; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SenderFTEIDForControlPlane; ie != nil {
+	if ie := r.SenderFTEIDForControlPlane; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := r.PrivateExtension; ie != nil {
+		l += ie.MarshalLen()
+	}
+
+	for _, ie := range r.AdditionalIEs {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
 	return l
 }
 
 // SetLength sets the length in Length field.
-func (c *ResumeNotification) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (r *ResumeNotification) SetLength() {
+	r.Header.Length = uint16(r.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *ResumeNotification) MessageTypeName() string {
+func (r *ResumeNotification) MessageTypeName() string {
 	return "Resume Notification"
 }
 
 // TEID returns the TEID in uint32.
-func (c *ResumeNotification) TEID() uint32 {
-	return c.Header.teid()
+func (r *ResumeNotification) TEID() uint32 {
+	return r.Header.teid()
+}
+
+// ResumeNotificationBuilder builds ResumeNotification.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type ResumeNotificationBuilder struct {
+	builder
+	msg *ResumeNotification
+}
+
+// BuildResumeNotification returns the builder of ResumeNotification.
+func BuildResumeNotification(teid, seq uint32) *ResumeNotificationBuilder {
+	return &ResumeNotificationBuilder{
+		builder: builder{msgType: "Resume Notification"},
+		msg:     NewResumeNotification(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *ResumeNotificationBuilder) WithIMSI(i *ie.IE) *ResumeNotificationBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithLinkedEPSBearerID sets the EPSBearerID IE to LinkedEPSBearerID.
+func (b *ResumeNotificationBuilder) WithLinkedEPSBearerID(i *ie.IE) *ResumeNotificationBuilder {
+	if b.expect("LinkedEPSBearerID", i, ie.EPSBearerID) {
+		b.msg.LinkedEPSBearerID = i
+	}
+	return b
+}
+
+// WithOriginatingNode sets the NodeType IE to OriginatingNode.
+func (b *ResumeNotificationBuilder) WithOriginatingNode(i *ie.IE) *ResumeNotificationBuilder {
+	if b.expect("OriginatingNode", i, ie.NodeType) {
+		b.msg.OriginatingNode = i
+	}
+	return b
+}
+
+// WithSenderFTEIDForControlPlane sets the FullyQualifiedTEID IE to SenderFTEIDForControlPlane.
+func (b *ResumeNotificationBuilder) WithSenderFTEIDForControlPlane(i *ie.IE) *ResumeNotificationBuilder {
+	if b.expect("SenderFTEIDForControlPlane", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDForControlPlane = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *ResumeNotificationBuilder) WithPrivateExtension(i *ie.IE) *ResumeNotificationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewResumeNotification. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *ResumeNotificationBuilder) WithIEs(ies ...*ie.IE) *ResumeNotificationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *ResumeNotificationBuilder) WithAdditionalIEs(ies ...*ie.IE) *ResumeNotificationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the ResumeNotification built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *ResumeNotificationBuilder) Build() (*ResumeNotification, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -1,10 +1,14 @@
-// Copyright 2019-2024 go-gtp authors. All rights reservev.
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
-import "github.com/wmnsk/go-gtp/gtpv2/ie"
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
 
 // StopPagingIndication is a StopPagingIndication Header and its IEs above.
 type StopPagingIndication struct {
@@ -27,20 +31,45 @@ func NewStopPagingIndication(teid, seq uint32, ies ...*ie.IE) *StopPagingIndicat
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			s.IMSI = i
-		case ie.PrivateExtension:
-			s.PrivateExtension = i
-		default:
-			s.AdditionalIEs = append(s.AdditionalIEs, i)
-		}
+		s.setIE(i)
 	}
 
 	s.SetLength()
 	return s
 }
 
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (s *StopPagingIndication) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		s.IMSI = i
+	case ie.PrivateExtension:
+		s.PrivateExtension = i
+	default:
+		s.AdditionalIEs = append(s.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to StopPagingIndication, or 0
+// if it is set to one of the fields.
+func (s *StopPagingIndication) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// StopPagingIndication, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (s *StopPagingIndication) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Stop Paging Indication", s.AdditionalIEs, s.ieKind)
+}
+
 // Marshal serializes StopPagingIndication into bytes.
 func (s *StopPagingIndication) Marshal() ([]byte, error) {
 	b := make([]byte, s.MarshalLen())
@@ -59,13 +88,13 @@ func (s *StopPagingIndication) MarshalTo(b []byte) error {
 
 	offset := 0
 	if ie := s.IMSI; ie != nil {
-		if err := ie.MarshalTo(s.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 	if ie := s.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(s.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
@@ -113,14 +142,7 @@ func (s *StopPagingIndication) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			s.IMSI = i
-		case ie.PrivateExtension:
-			s.PrivateExtension = i
-		default:
-			s.AdditionalIEs = append(s.AdditionalIEs, i)
-		}
+		s.setIE(i)
 	}
 
 	return nil
@@ -129,6 +151,7 @@ func (s *StopPagingIndication) UnmarshalBinary(b []byte) error {
 // MarshalLen returns the serial length in int.
 func (s *StopPagingIndication) MarshalLen() int {
 	l := s.Header.MarshalLen() - len(s.Header.Payload)
+
 	if ie := s.IMSI; ie != nil {
 		l += ie.MarshalLen()
 	}
@@ -159,3 +182,64 @@ func (s *StopPagingIndication) MessageTypeName() string {
 func (s *StopPagingIndication) TEID() uint32 {
 	return s.Header.teid()
 }
+
+// StopPagingIndicationBuilder builds StopPagingIndication.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type StopPagingIndicationBuilder struct {
+	builder
+	msg *StopPagingIndication
+}
+
+// BuildStopPagingIndication returns the builder of StopPagingIndication.
+func BuildStopPagingIndication(teid, seq uint32) *StopPagingIndicationBuilder {
+	return &StopPagingIndicationBuilder{
+		builder: builder{msgType: "Stop Paging Indication"},
+		msg:     NewStopPagingIndication(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *StopPagingIndicationBuilder) WithIMSI(i *ie.IE) *StopPagingIndicationBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *StopPagingIndicationBuilder) WithPrivateExtension(i *ie.IE) *StopPagingIndicationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewStopPagingIndication. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *StopPagingIndicationBuilder) WithIEs(ies ...*ie.IE) *StopPagingIndicationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *StopPagingIndicationBuilder) WithAdditionalIEs(ies ...*ie.IE) *StopPagingIndicationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the StopPagingIndication built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *StopPagingIndicationBuilder) Build() (*StopPagingIndication, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -18,7 +20,7 @@ type SuspendAcknowledge struct {
 
 // NewSuspendAcknowledge creates a new SuspendAcknowledge.
 func NewSuspendAcknowledge(teid, seq uint32, ies ...*ie.IE) *SuspendAcknowledge {
-	c := &SuspendAcknowledge{
+	s := &SuspendAcknowledge{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeSuspendAcknowledge, teid, seq, nil,
@@ -29,85 +31,110 @@ func NewSuspendAcknowledge(teid, seq uint32, ies ...*ie.IE) *SuspendAcknowledge
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		s.setIE(i)
+	}
+
+	s.SetLength()
+	return s
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (s *SuspendAcknowledge) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		s.Cause = i
+	case ie.PrivateExtension:
+		s.PrivateExtension = i
+	default:
+		s.AdditionalIEs = append(s.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to SuspendAcknowledge, or 0
+// if it is set to one of the fields.
+func (s *SuspendAcknowledge) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.PrivateExtension:
+		return 0
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// SuspendAcknowledge, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (s *SuspendAcknowledge) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Suspend Acknowledge", s.AdditionalIEs, s.ieKind)
 }
 
 // Marshal serializes SuspendAcknowledge into bytes.
-func (c *SuspendAcknowledge) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (s *SuspendAcknowledge) Marshal() ([]byte, error) {
+	b := make([]byte, s.MarshalLen())
+	if err := s.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes SuspendAcknowledge into bytes.
-func (c *SuspendAcknowledge) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (s *SuspendAcknowledge) MarshalTo(b []byte) error {
+	if s.Header.Payload != nil {
+		s.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	s.Header.Payload = make([]byte, s.MarshalLen()-s.Header.MarshalLen())
 
 	offset := 0
-	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.Cause; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range s.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	s.Header.SetLength()
+	return s.Header.MarshalTo(b)
 }
 
 // ParseSuspendAcknowledge decodes given bytes as SuspendAcknowledge.
 func ParseSuspendAcknowledge(b []byte) (*SuspendAcknowledge, error) {
-	c := &SuspendAcknowledge{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	s := &SuspendAcknowledge{}
+	if err := s.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return s, nil
 }
 
 // UnmarshalBinary decodes given bytes as SuspendAcknowledge.
-func (c *SuspendAcknowledge) UnmarshalBinary(b []byte) error {
+func (s *SuspendAcknowledge) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	s.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(s.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(s.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -115,31 +142,24 @@ func (c *SuspendAcknowledge) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		s.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *SuspendAcknowledge) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
+func (s *SuspendAcknowledge) MarshalLen() int {
+	l := s.Header.MarshalLen() - len(s.Header.Payload)
 
-	if ie := c.Cause; ie != nil {
+	if ie := s.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := s.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range s.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -149,16 +169,77 @@ func (c *SuspendAcknowledge) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (c *SuspendAcknowledge) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (s *SuspendAcknowledge) SetLength() {
+	s.Header.Length = uint16(s.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *SuspendAcknowledge) MessageTypeName() string {
+func (s *SuspendAcknowledge) MessageTypeName() string {
 	return "Suspend Acknowledge"
 }
 
 // TEID returns the TEID in uint32.
-func (c *SuspendAcknowledge) TEID() uint32 {
-	return c.Header.teid()
+func (s *SuspendAcknowledge) TEID() uint32 {
+	return s.Header.teid()
+}
+
+// SuspendAcknowledgeBuilder builds SuspendAcknowledge.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type SuspendAcknowledgeBuilder struct {
+	builder
+	msg *SuspendAcknowledge
+}
+
+// BuildSuspendAcknowledge returns the builder of SuspendAcknowledge.
+func BuildSuspendAcknowledge(teid, seq uint32) *SuspendAcknowledgeBuilder {
+	return &SuspendAcknowledgeBuilder{
+		builder: builder{msgType: "Suspend Acknowledge"},
+		msg:     NewSuspendAcknowledge(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *SuspendAcknowledgeBuilder) WithCause(i *ie.IE) *SuspendAcknowledgeBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *SuspendAcknowledgeBuilder) WithPrivateExtension(i *ie.IE) *SuspendAcknowledgeBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewSuspendAcknowledge. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *SuspendAcknowledgeBuilder) WithIEs(ies ...*ie.IE) *SuspendAcknowledgeBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *SuspendAcknowledgeBuilder) WithAdditionalIEs(ies ...*ie.IE) *SuspendAcknowledgeBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the SuspendAcknowledge built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *SuspendAcknowledgeBuilder) Build() (*SuspendAcknowledge, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
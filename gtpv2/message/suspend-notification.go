@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -26,7 +28,7 @@ type SuspendNotification struct {
 
 // NewSuspendNotification creates a new SuspendNotification.
 func NewSuspendNotification(teid, seq uint32, ies ...*ie.IE) *SuspendNotification {
-	c := &SuspendNotification{
+	s := &SuspendNotification{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeSuspendNotification, teid, seq, nil,
@@ -37,221 +39,225 @@ func NewSuspendNotification(teid, seq uint32, ies ...*ie.IE) *SuspendNotificatio
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.UserLocationInformation:
-			c.RAI = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.PacketTMSI:
-			c.PTMSI = i
-		case ie.NodeType:
-			c.OriginatingNode = i
-		case ie.IPAddress:
-			c.AddressForControlPlane = i
-		case ie.PortNumber:
-			c.UDPSourcePortNumber = i
-		case ie.HopCounter:
-			c.HopCounter = i
-		case ie.FullyQualifiedTEID:
-			c.SenderFTEIDC = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		s.setIE(i)
+	}
+
+	s.SetLength()
+	return s
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (s *SuspendNotification) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.IMSI:
+		s.IMSI = i
+	case ie.UserLocationInformation:
+		s.RAI = i
+	case ie.EPSBearerID:
+		s.LinkedEBI = i
+	case ie.PacketTMSI:
+		s.PTMSI = i
+	case ie.NodeType:
+		s.OriginatingNode = i
+	case ie.IPAddress:
+		s.AddressForControlPlane = i
+	case ie.PortNumber:
+		s.UDPSourcePortNumber = i
+	case ie.HopCounter:
+		s.HopCounter = i
+	case ie.FullyQualifiedTEID:
+		s.SenderFTEIDC = i
+	case ie.PrivateExtension:
+		s.PrivateExtension = i
+	default:
+		s.AdditionalIEs = append(s.AdditionalIEs, i)
+		return false
 	}
+	return true
+}
 
-	c.SetLength()
-	return c
+// ieKind returns the reason why the IE does not belong to SuspendNotification, or 0
+// if it is set to one of the fields.
+func (s *SuspendNotification) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.IMSI, ie.UserLocationInformation, ie.EPSBearerID, ie.PacketTMSI,
+		ie.NodeType, ie.IPAddress, ie.PortNumber, ie.HopCounter,
+		ie.FullyQualifiedTEID, ie.PrivateExtension:
+		return 0
+	}
+	return IEUnrecognized
+}
+
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// SuspendNotification, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (s *SuspendNotification) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Suspend Notification", s.AdditionalIEs, s.ieKind)
 }
 
 // Marshal serializes SuspendNotification into bytes.
-func (c *SuspendNotification) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (s *SuspendNotification) Marshal() ([]byte, error) {
+	b := make([]byte, s.MarshalLen())
+	if err := s.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes SuspendNotification into bytes.
-func (c *SuspendNotification) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (s *SuspendNotification) MarshalTo(b []byte) error {
+	if s.Header.Payload != nil {
+		s.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	s.Header.Payload = make([]byte, s.MarshalLen()-s.Header.MarshalLen())
 
 	offset := 0
-	if ie := c.IMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.IMSI; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.RAI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.RAI; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.LinkedEBI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.LinkedEBI; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PTMSI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.PTMSI; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.OriginatingNode; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.OriginatingNode; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.AddressForControlPlane; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.AddressForControlPlane; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.UDPSourcePortNumber; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.UDPSourcePortNumber; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.HopCounter; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.HopCounter; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SenderFTEIDC; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.SenderFTEIDC; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := s.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range s.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(s.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	s.Header.SetLength()
+	return s.Header.MarshalTo(b)
 }
 
 // ParseSuspendNotification decodes given bytes as SuspendNotification.
 func ParseSuspendNotification(b []byte) (*SuspendNotification, error) {
-	c := &SuspendNotification{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	s := &SuspendNotification{}
+	if err := s.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return s, nil
 }
 
 // UnmarshalBinary decodes given bytes as SuspendNotification.
-func (c *SuspendNotification) UnmarshalBinary(b []byte) error {
+func (s *SuspendNotification) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	s.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(s.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(s.Header.Payload)
 	if err != nil {
 		return err
 	}
-
 	for _, i := range decodedIEs {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.IMSI:
-			c.IMSI = i
-		case ie.UserLocationInformation:
-			c.RAI = i
-		case ie.PacketTMSI:
-			c.PTMSI = i
-		case ie.EPSBearerID:
-			c.LinkedEBI = i
-		case ie.NodeType:
-			c.OriginatingNode = i
-		case ie.IPAddress:
-			c.AddressForControlPlane = i
-		case ie.PortNumber:
-			c.UDPSourcePortNumber = i
-		case ie.HopCounter:
-			c.HopCounter = i
-		case ie.FullyQualifiedTEID:
-			c.SenderFTEIDC = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
-		}
+		s.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *SuspendNotification) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
-	if ie := c.IMSI; ie != nil {
+func (s *SuspendNotification) MarshalLen() int {
+	l := s.Header.MarshalLen() - len(s.Header.Payload)
+
+	if ie := s.IMSI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.RAI; ie != nil {
+	if ie := s.RAI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PTMSI; ie != nil {
+	if ie := s.LinkedEBI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.LinkedEBI; ie != nil {
+	if ie := s.PTMSI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.OriginatingNode; ie != nil {
+	if ie := s.OriginatingNode; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.AddressForControlPlane; ie != nil {
+	if ie := s.AddressForControlPlane; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.UDPSourcePortNumber; ie != nil {
+	if ie := s.UDPSourcePortNumber; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.HopCounter; ie != nil {
+	if ie := s.HopCounter; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SenderFTEIDC; ie != nil {
+	if ie := s.SenderFTEIDC; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := s.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range s.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -261,16 +267,141 @@ func (c *SuspendNotification) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (c *SuspendNotification) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (s *SuspendNotification) SetLength() {
+	s.Header.Length = uint16(s.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *SuspendNotification) MessageTypeName() string {
+func (s *SuspendNotification) MessageTypeName() string {
 	return "Suspend Notification"
 }
 
 // TEID returns the TEID in uint32.
-func (c *SuspendNotification) TEID() uint32 {
-	return c.Header.teid()
+func (s *SuspendNotification) TEID() uint32 {
+	return s.Header.teid()
+}
+
+// SuspendNotificationBuilder builds SuspendNotification.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type SuspendNotificationBuilder struct {
+	builder
+	msg *SuspendNotification
+}
+
+// BuildSuspendNotification returns the builder of SuspendNotification.
+func BuildSuspendNotification(teid, seq uint32) *SuspendNotificationBuilder {
+	return &SuspendNotificationBuilder{
+		builder: builder{msgType: "Suspend Notification"},
+		msg:     NewSuspendNotification(teid, seq),
+	}
+}
+
+// WithIMSI sets the IMSI IE to IMSI.
+func (b *SuspendNotificationBuilder) WithIMSI(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("IMSI", i, ie.IMSI) {
+		b.msg.IMSI = i
+	}
+	return b
+}
+
+// WithRAI sets the UserLocationInformation IE to RAI.
+func (b *SuspendNotificationBuilder) WithRAI(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("RAI", i, ie.UserLocationInformation) {
+		b.msg.RAI = i
+	}
+	return b
+}
+
+// WithLinkedEBI sets the EPSBearerID IE to LinkedEBI.
+func (b *SuspendNotificationBuilder) WithLinkedEBI(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("LinkedEBI", i, ie.EPSBearerID) {
+		b.msg.LinkedEBI = i
+	}
+	return b
+}
+
+// WithPTMSI sets the PacketTMSI IE to PTMSI.
+func (b *SuspendNotificationBuilder) WithPTMSI(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("PTMSI", i, ie.PacketTMSI) {
+		b.msg.PTMSI = i
+	}
+	return b
+}
+
+// WithOriginatingNode sets the NodeType IE to OriginatingNode.
+func (b *SuspendNotificationBuilder) WithOriginatingNode(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("OriginatingNode", i, ie.NodeType) {
+		b.msg.OriginatingNode = i
+	}
+	return b
+}
+
+// WithAddressForControlPlane sets the IPAddress IE to AddressForControlPlane.
+func (b *SuspendNotificationBuilder) WithAddressForControlPlane(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("AddressForControlPlane", i, ie.IPAddress) {
+		b.msg.AddressForControlPlane = i
+	}
+	return b
+}
+
+// WithUDPSourcePortNumber sets the PortNumber IE to UDPSourcePortNumber.
+func (b *SuspendNotificationBuilder) WithUDPSourcePortNumber(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("UDPSourcePortNumber", i, ie.PortNumber) {
+		b.msg.UDPSourcePortNumber = i
+	}
+	return b
+}
+
+// WithHopCounter sets the HopCounter IE to HopCounter.
+func (b *SuspendNotificationBuilder) WithHopCounter(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("HopCounter", i, ie.HopCounter) {
+		b.msg.HopCounter = i
+	}
+	return b
+}
+
+// WithSenderFTEIDC sets the FullyQualifiedTEID IE to SenderFTEIDC.
+func (b *SuspendNotificationBuilder) WithSenderFTEIDC(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("SenderFTEIDC", i, ie.FullyQualifiedTEID) {
+		b.msg.SenderFTEIDC = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *SuspendNotificationBuilder) WithPrivateExtension(i *ie.IE) *SuspendNotificationBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewSuspendNotification. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *SuspendNotificationBuilder) WithIEs(ies ...*ie.IE) *SuspendNotificationBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *SuspendNotificationBuilder) WithAdditionalIEs(ies ...*ie.IE) *SuspendNotificationBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the SuspendNotification built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *SuspendNotificationBuilder) Build() (*SuspendNotification, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
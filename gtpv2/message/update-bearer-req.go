@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -34,7 +36,7 @@ type UpdateBearerRequest struct {
 
 // NewUpdateBearerRequest creates a new UpdateBearerRequest.
 func NewUpdateBearerRequest(teid, seq uint32, ies ...*ie.IE) *UpdateBearerRequest {
-	c := &UpdateBearerRequest{
+	u := &UpdateBearerRequest{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeUpdateBearerRequest, teid, seq, nil,
@@ -45,352 +47,361 @@ func NewUpdateBearerRequest(teid, seq uint32, ies ...*ie.IE) *UpdateBearerReques
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.ProcedureTransactionID:
-			c.PTI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.AggregateMaximumBitRate:
-			c.APNAMBR = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAction = append(c.PresenceReportingAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		u.setIE(i)
+	}
+
+	u.SetLength()
+	return u
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (u *UpdateBearerRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.BearerContext:
+		u.BearerContexts = append(u.BearerContexts, i)
+	case ie.ProcedureTransactionID:
+		u.PTI = i
+	case ie.ProtocolConfigurationOptions:
+		u.PCO = i
+	case ie.AggregateMaximumBitRate:
+		u.APNAMBR = i
+	case ie.ChangeReportingAction:
+		u.ChangeReportingAction = i
+	case ie.CSGInformationReportingAction:
+		u.CSGInformationReportingAction = i
+	case ie.HeNBInformationReporting:
+		u.HeNBInformationReporting = i
+	case ie.Indication:
+		u.IndicationFlags = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			u.PGWFQCSID = i
+		case 1:
+			u.SGWFQCSID = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.PresenceReportingAreaAction:
+		u.PresenceReportingAction = append(u.PresenceReportingAction, i)
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0:
+			u.PGWNodeLoadControlInformation = i
+		case 1:
+			u.PGWAPNLoadControlInformation = i
+		case 2:
+			u.SGWNodeLoadControlInformation = i
 		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			u.PGWOverloadControlInformation = i
+		case 1:
+			u.SGWOverloadControlInformation = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		u.NBIFOMContainer = i
+	case ie.PrivateExtension:
+		u.PrivateExtension = i
+	default:
+		u.AdditionalIEs = append(u.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to UpdateBearerRequest, or 0
+// if it is set to one of the fields.
+func (u *UpdateBearerRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.BearerContext, ie.ProcedureTransactionID, ie.ProtocolConfigurationOptions, ie.AggregateMaximumBitRate,
+		ie.ChangeReportingAction, ie.CSGInformationReportingAction, ie.HeNBInformationReporting, ie.Indication,
+		ie.PresenceReportingAreaAction, ie.FContainer, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.LoadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
 		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// UpdateBearerRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (u *UpdateBearerRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Update Bearer Request", u.AdditionalIEs, u.ieKind)
 }
 
 // Marshal serializes UpdateBearerRequest into bytes.
-func (c *UpdateBearerRequest) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (u *UpdateBearerRequest) Marshal() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes UpdateBearerRequest into bytes.
-func (c *UpdateBearerRequest) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (u *UpdateBearerRequest) MarshalTo(b []byte) error {
+	if u.Header.Payload != nil {
+		u.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	u.Header.Payload = make([]byte, u.MarshalLen()-u.Header.MarshalLen())
 
 	offset := 0
-	for _, ie := range c.BearerContexts {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	for _, ie := range u.BearerContexts {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PTI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PTI; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PCO; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.APNAMBR; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.APNAMBR; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.ChangeReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.ChangeReportingAction; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.CSGInformationReportingAction; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.CSGInformationReportingAction; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.HeNBInformationReporting; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.HeNBInformationReporting; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.IndicationFlags; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.SGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	for _, ie := range c.PresenceReportingAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	for _, ie := range u.PresenceReportingAction {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PGWNodeLoadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PGWAPNLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PGWAPNLoadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SGWNodeLoadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.SGWNodeLoadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PGWOverloadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.SGWOverloadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.NBIFOMContainer; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	u.Header.SetLength()
+	return u.Header.MarshalTo(b)
 }
 
 // ParseUpdateBearerRequest decodes given bytes as UpdateBearerRequest.
 func ParseUpdateBearerRequest(b []byte) (*UpdateBearerRequest, error) {
-	c := &UpdateBearerRequest{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	u := &UpdateBearerRequest{}
+	if err := u.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return u, nil
 }
 
 // UnmarshalBinary decodes given bytes as UpdateBearerRequest.
-func (c *UpdateBearerRequest) UnmarshalBinary(b []byte) error {
+func (u *UpdateBearerRequest) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	u.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(u.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(u.Header.Payload)
 	if err != nil {
 		return err
 	}
 	for _, i := range decodedIEs {
-		switch i.Type {
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.ProcedureTransactionID:
-			c.PTI = i
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.AggregateMaximumBitRate:
-			c.APNAMBR = i
-		case ie.ChangeReportingAction:
-			c.ChangeReportingAction = i
-		case ie.CSGInformationReportingAction:
-			c.CSGInformationReportingAction = i
-		case ie.HeNBInformationReporting:
-			c.HeNBInformationReporting = i
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.PGWFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAction = append(c.PresenceReportingAction, i)
-		case ie.LoadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWNodeLoadControlInformation = i
-			case 1:
-				c.PGWAPNLoadControlInformation = i
-			case 2:
-				c.SGWNodeLoadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.PGWOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+		if i == nil {
+			continue
 		}
+		u.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *UpdateBearerRequest) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
+func (u *UpdateBearerRequest) MarshalLen() int {
+	l := u.Header.MarshalLen() - len(u.Header.Payload)
 
-	for _, ie := range c.BearerContexts {
+	for _, ie := range u.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
-	if ie := c.PTI; ie != nil {
+	if ie := u.PTI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PCO; ie != nil {
+	if ie := u.PCO; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.APNAMBR; ie != nil {
+	if ie := u.APNAMBR; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.ChangeReportingAction; ie != nil {
+	if ie := u.ChangeReportingAction; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.CSGInformationReportingAction; ie != nil {
+	if ie := u.CSGInformationReportingAction; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.HeNBInformationReporting; ie != nil {
+	if ie := u.HeNBInformationReporting; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.IndicationFlags; ie != nil {
+	if ie := u.IndicationFlags; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PGWFQCSID; ie != nil {
+	if ie := u.PGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SGWFQCSID; ie != nil {
+	if ie := u.SGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	for _, ie := range c.PresenceReportingAction {
+	for _, ie := range u.PresenceReportingAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
-	if ie := c.PGWNodeLoadControlInformation; ie != nil {
+	if ie := u.PGWNodeLoadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PGWAPNLoadControlInformation; ie != nil {
+	if ie := u.PGWAPNLoadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SGWNodeLoadControlInformation; ie != nil {
+	if ie := u.SGWNodeLoadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PGWOverloadControlInformation; ie != nil {
+	if ie := u.PGWOverloadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SGWOverloadControlInformation; ie != nil {
+	if ie := u.SGWOverloadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.NBIFOMContainer; ie != nil {
+	if ie := u.NBIFOMContainer; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := u.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -400,16 +411,209 @@ func (c *UpdateBearerRequest) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (c *UpdateBearerRequest) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (u *UpdateBearerRequest) SetLength() {
+	u.Header.Length = uint16(u.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *UpdateBearerRequest) MessageTypeName() string {
+func (u *UpdateBearerRequest) MessageTypeName() string {
 	return "Update Bearer Request"
 }
 
 // TEID returns the TEID in uint32.
-func (c *UpdateBearerRequest) TEID() uint32 {
-	return c.Header.teid()
+func (u *UpdateBearerRequest) TEID() uint32 {
+	return u.Header.teid()
+}
+
+// UpdateBearerRequestBuilder builds UpdateBearerRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type UpdateBearerRequestBuilder struct {
+	builder
+	msg *UpdateBearerRequest
+}
+
+// BuildUpdateBearerRequest returns the builder of UpdateBearerRequest.
+func BuildUpdateBearerRequest(teid, seq uint32) *UpdateBearerRequestBuilder {
+	return &UpdateBearerRequestBuilder{
+		builder: builder{msgType: "Update Bearer Request"},
+		msg:     NewUpdateBearerRequest(teid, seq),
+	}
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *UpdateBearerRequestBuilder) WithBearerContexts(ies ...*ie.IE) *UpdateBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithPTI sets the ProcedureTransactionID IE to PTI.
+func (b *UpdateBearerRequestBuilder) WithPTI(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PTI", i, ie.ProcedureTransactionID) {
+		b.msg.PTI = i
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *UpdateBearerRequestBuilder) WithPCO(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithAPNAMBR sets the AggregateMaximumBitRate IE to APNAMBR.
+func (b *UpdateBearerRequestBuilder) WithAPNAMBR(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("APNAMBR", i, ie.AggregateMaximumBitRate) {
+		b.msg.APNAMBR = i
+	}
+	return b
+}
+
+// WithChangeReportingAction sets the ChangeReportingAction IE to ChangeReportingAction.
+func (b *UpdateBearerRequestBuilder) WithChangeReportingAction(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("ChangeReportingAction", i, ie.ChangeReportingAction) {
+		b.msg.ChangeReportingAction = i
+	}
+	return b
+}
+
+// WithCSGInformationReportingAction sets the CSGInformationReportingAction IE to CSGInformationReportingAction.
+func (b *UpdateBearerRequestBuilder) WithCSGInformationReportingAction(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("CSGInformationReportingAction", i, ie.CSGInformationReportingAction) {
+		b.msg.CSGInformationReportingAction = i
+	}
+	return b
+}
+
+// WithHeNBInformationReporting sets the HeNBInformationReporting IE to HeNBInformationReporting.
+func (b *UpdateBearerRequestBuilder) WithHeNBInformationReporting(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("HeNBInformationReporting", i, ie.HeNBInformationReporting) {
+		b.msg.HeNBInformationReporting = i
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *UpdateBearerRequestBuilder) WithIndicationFlags(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID, with the instance set to 0.
+func (b *UpdateBearerRequestBuilder) WithPGWFQCSID(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *UpdateBearerRequestBuilder) WithSGWFQCSID(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPresenceReportingAction appends the PresenceReportingAreaAction IEs to PresenceReportingAction.
+func (b *UpdateBearerRequestBuilder) WithPresenceReportingAction(ies ...*ie.IE) *UpdateBearerRequestBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAction = append(b.msg.PresenceReportingAction, i)
+		}
+	}
+	return b
+}
+
+// WithPGWNodeLoadControlInformation sets the LoadControlInformation IE to PGWNodeLoadControlInformation, with the instance set to 0.
+func (b *UpdateBearerRequestBuilder) WithPGWNodeLoadControlInformation(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWNodeLoadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithPGWAPNLoadControlInformation sets the LoadControlInformation IE to PGWAPNLoadControlInformation, with the instance set to 1.
+func (b *UpdateBearerRequestBuilder) WithPGWAPNLoadControlInformation(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PGWAPNLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.PGWAPNLoadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithSGWNodeLoadControlInformation sets the LoadControlInformation IE to SGWNodeLoadControlInformation, with the instance set to 2.
+func (b *UpdateBearerRequestBuilder) WithSGWNodeLoadControlInformation(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("SGWNodeLoadControlInformation", i, ie.LoadControlInformation) {
+		b.msg.SGWNodeLoadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithPGWOverloadControlInformation sets the OverloadControlInformation IE to PGWOverloadControlInformation, with the instance set to 0.
+func (b *UpdateBearerRequestBuilder) WithPGWOverloadControlInformation(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.PGWOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *UpdateBearerRequestBuilder) WithSGWOverloadControlInformation(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *UpdateBearerRequestBuilder) WithNBIFOMContainer(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *UpdateBearerRequestBuilder) WithPrivateExtension(i *ie.IE) *UpdateBearerRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewUpdateBearerRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *UpdateBearerRequestBuilder) WithIEs(ies ...*ie.IE) *UpdateBearerRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *UpdateBearerRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *UpdateBearerRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the UpdateBearerRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *UpdateBearerRequestBuilder) Build() (*UpdateBearerRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -38,9 +40,9 @@ type UpdateBearerResponse struct {
 	AdditionalIEs                      []*ie.IE
 }
 
-// NewUpdateBearerRequest creates a new UpdateBearerRequest.
+// NewUpdateBearerResponse creates a new UpdateBearerResponse.
 func NewUpdateBearerResponse(teid, seq uint32, ies ...*ie.IE) *UpdateBearerResponse {
-	c := &UpdateBearerResponse{
+	u := &UpdateBearerResponse{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeUpdateBearerResponse, teid, seq, nil,
@@ -51,456 +53,463 @@ func NewUpdateBearerResponse(teid, seq uint32, ies ...*ie.IE) *UpdateBearerRespo
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNIdentifier = i
-			case 1:
-				c.UELocalIPAddress = i
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 1:
-				c.WLANLocationTimeStamp = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.UETCPPort = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
+		u.setIE(i)
+	}
+
+	u.SetLength()
+	return u
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (u *UpdateBearerResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		u.Cause = i
+	case ie.BearerContext:
+		u.BearerContexts = append(u.BearerContexts, i)
+	case ie.ProtocolConfigurationOptions:
+		u.PCO = i
+	case ie.Recovery:
+		u.Recovery = i
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			u.MMEFQCSID = i
+		case 1:
+			u.SGWFQCSID = i
+		case 2:
+			u.EPDGFQCSID = i
+		case 3:
+			u.TWANFQCSID = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.Indication:
+		u.IndicationFlags = i
+	case ie.UETimeZone:
+		u.UETimeZone = i
+	case ie.UserLocationInformation:
+		u.ULI = i
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0:
+			u.TWANIdentifier = i
+		case 1:
+			u.WLANLocationInformation = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0:
+			u.MMESGSNOverloadControlInformation = i
+		case 1:
+			u.SGWOverloadControlInformation = i
+		case 2:
+			u.TWANePDGOverloadControlInformation = i
 		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
 		}
+	case ie.PresenceReportingAreaAction:
+		u.PresenceReportingAreaAction = append(u.PresenceReportingAreaAction, i)
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0:
+			u.MMESGSNIdentifier = i
+		case 1:
+			u.UELocalIPAddress = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 1:
+			u.WLANLocationTimeStamp = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0:
+			u.UEUDPPort = i
+		case 1:
+			u.UETCPPort = i
+		default:
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.FContainer:
+		u.NBIFOMContainer = i
+	case ie.PrivateExtension:
+		u.PrivateExtension = i
+	default:
+		u.AdditionalIEs = append(u.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to UpdateBearerResponse, or 0
+// if it is set to one of the fields.
+func (u *UpdateBearerResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.BearerContext, ie.ProtocolConfigurationOptions, ie.Recovery,
+		ie.Indication, ie.UETimeZone, ie.UserLocationInformation, ie.PresenceReportingAreaAction,
+		ie.FContainer, ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1, 2, 3:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifier:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.OverloadControlInformation:
+		switch i.Instance() {
+		case 0, 1, 2:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.IPAddress:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.TWANIdentifierTimestamp:
+		switch i.Instance() {
+		case 1:
+			return 0
+		}
+		return IEUnexpectedInstance
+	case ie.PortNumber:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
+		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	c.SetLength()
-	return c
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// UpdateBearerResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (u *UpdateBearerResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Update Bearer Response", u.AdditionalIEs, u.ieKind)
 }
 
 // Marshal serializes UpdateBearerResponse into bytes.
-func (c *UpdateBearerResponse) Marshal() ([]byte, error) {
-	b := make([]byte, c.MarshalLen())
-	if err := c.MarshalTo(b); err != nil {
+func (u *UpdateBearerResponse) Marshal() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes UpdateBearerResponse into bytes.
-func (c *UpdateBearerResponse) MarshalTo(b []byte) error {
-	if c.Header.Payload != nil {
-		c.Header.Payload = nil
+func (u *UpdateBearerResponse) MarshalTo(b []byte) error {
+	if u.Header.Payload != nil {
+		u.Header.Payload = nil
 	}
-	c.Header.Payload = make([]byte, c.MarshalLen()-c.Header.MarshalLen())
+	u.Header.Payload = make([]byte, u.MarshalLen()-u.Header.MarshalLen())
 
 	offset := 0
-	if ie := c.Cause; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.Cause; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	for _, ie := range c.BearerContexts {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	for _, ie := range u.BearerContexts {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PCO; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PCO; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.Recovery; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.Recovery; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.MMEFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.SGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.EPDGFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.EPDGFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.TWANFQCSID; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.TWANFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.IndicationFlags; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.IndicationFlags; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.UETimeZone; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.UETimeZone; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.ULI; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.ULI; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.TWANIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.TWANIdentifier; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.MMESGSNOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.MMESGSNOverloadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.SGWOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.SGWOverloadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	for _, ie := range c.PresenceReportingAreaAction {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	for _, ie := range u.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.MMESGSNIdentifier; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.MMESGSNIdentifier; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.TWANePDGOverloadControlInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.TWANePDGOverloadControlInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.WLANLocationInformation; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.WLANLocationInformation; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.WLANLocationTimeStamp; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.WLANLocationTimeStamp; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.UELocalIPAddress; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.UELocalIPAddress; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.UEUDPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.UEUDPPort; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.NBIFOMContainer; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.NBIFOMContainer; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.UETCPPort; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.UETCPPort; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(c.Payload[offset:]); err != nil {
+	if ie := u.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(c.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	c.Header.SetLength()
-	return c.Header.MarshalTo(b)
+	u.Header.SetLength()
+	return u.Header.MarshalTo(b)
 }
 
 // ParseUpdateBearerResponse decodes given bytes as UpdateBearerResponse.
 func ParseUpdateBearerResponse(b []byte) (*UpdateBearerResponse, error) {
-	c := &UpdateBearerResponse{}
-	if err := c.UnmarshalBinary(b); err != nil {
+	u := &UpdateBearerResponse{}
+	if err := u.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return u, nil
 }
 
 // UnmarshalBinary decodes given bytes as UpdateBearerResponse.
-func (c *UpdateBearerResponse) UnmarshalBinary(b []byte) error {
+func (u *UpdateBearerResponse) UnmarshalBinary(b []byte) error {
 	var err error
-	c.Header, err = ParseHeader(b)
+	u.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(c.Header.Payload) < 2 {
+	if len(u.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(c.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(u.Header.Payload)
 	if err != nil {
 		return err
 	}
 	for _, i := range decodedIEs {
-		switch i.Type {
-		case ie.Cause:
-			c.Cause = i
-		case ie.BearerContext:
-			c.BearerContexts = append(c.BearerContexts, i)
-		case ie.ProtocolConfigurationOptions:
-			c.PCO = i
-		case ie.Recovery:
-			c.Recovery = i
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				c.MMEFQCSID = i
-			case 1:
-				c.SGWFQCSID = i
-			case 2:
-				c.EPDGFQCSID = i
-			case 3:
-				c.TWANFQCSID = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.Indication:
-			c.IndicationFlags = i
-		case ie.UETimeZone:
-			c.UETimeZone = i
-		case ie.UserLocationInformation:
-			c.ULI = i
-		case ie.TWANIdentifier:
-			switch i.Instance() {
-			case 0:
-				c.TWANIdentifier = i
-			case 1:
-				c.WLANLocationInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.OverloadControlInformation:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNOverloadControlInformation = i
-			case 1:
-				c.SGWOverloadControlInformation = i
-			case 2:
-				c.TWANePDGOverloadControlInformation = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PresenceReportingAreaAction:
-			c.PresenceReportingAreaAction = append(c.PresenceReportingAreaAction, i)
-		case ie.IPAddress:
-			switch i.Instance() {
-			case 0:
-				c.MMESGSNIdentifier = i
-			case 1:
-				c.UELocalIPAddress = i
-			}
-		case ie.TWANIdentifierTimestamp:
-			switch i.Instance() {
-			case 1:
-				c.WLANLocationTimeStamp = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.PortNumber:
-			switch i.Instance() {
-			case 0:
-				c.UEUDPPort = i
-			case 1:
-				c.UETCPPort = i
-			default:
-				c.AdditionalIEs = append(c.AdditionalIEs, i)
-			}
-		case ie.FContainer:
-			c.NBIFOMContainer = i
-		case ie.PrivateExtension:
-			c.PrivateExtension = i
-		default:
-			c.AdditionalIEs = append(c.AdditionalIEs, i)
+		if i == nil {
+			continue
 		}
+		u.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (c *UpdateBearerResponse) MarshalLen() int {
-	l := c.Header.MarshalLen() - len(c.Header.Payload)
+func (u *UpdateBearerResponse) MarshalLen() int {
+	l := u.Header.MarshalLen() - len(u.Header.Payload)
 
-	if ie := c.Cause; ie != nil {
+	if ie := u.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	for _, ie := range c.BearerContexts {
+	for _, ie := range u.BearerContexts {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
-	if ie := c.PCO; ie != nil {
+	if ie := u.PCO; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.Recovery; ie != nil {
+	if ie := u.Recovery; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.MMEFQCSID; ie != nil {
+	if ie := u.MMEFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SGWFQCSID; ie != nil {
+	if ie := u.SGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.EPDGFQCSID; ie != nil {
+	if ie := u.EPDGFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.TWANFQCSID; ie != nil {
+	if ie := u.TWANFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.IndicationFlags; ie != nil {
+	if ie := u.IndicationFlags; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.UETimeZone; ie != nil {
+	if ie := u.UETimeZone; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.ULI; ie != nil {
+	if ie := u.ULI; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.TWANIdentifier; ie != nil {
+	if ie := u.TWANIdentifier; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.MMESGSNOverloadControlInformation; ie != nil {
+	if ie := u.MMESGSNOverloadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.SGWOverloadControlInformation; ie != nil {
+	if ie := u.SGWOverloadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	for _, ie := range c.PresenceReportingAreaAction {
+	for _, ie := range u.PresenceReportingAreaAction {
+		if ie == nil {
+			continue
+		}
 		l += ie.MarshalLen()
 	}
-	if ie := c.MMESGSNIdentifier; ie != nil {
+	if ie := u.MMESGSNIdentifier; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.TWANePDGOverloadControlInformation; ie != nil {
+	if ie := u.TWANePDGOverloadControlInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.WLANLocationInformation; ie != nil {
+	if ie := u.WLANLocationInformation; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.WLANLocationTimeStamp; ie != nil {
+	if ie := u.WLANLocationTimeStamp; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.UELocalIPAddress; ie != nil {
+	if ie := u.UELocalIPAddress; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.UEUDPPort; ie != nil {
+	if ie := u.UEUDPPort; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.NBIFOMContainer; ie != nil {
+	if ie := u.NBIFOMContainer; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.UETCPPort; ie != nil {
+	if ie := u.UETCPPort; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := c.PrivateExtension; ie != nil {
+	if ie := u.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range c.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -510,16 +519,257 @@ func (c *UpdateBearerResponse) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (c *UpdateBearerResponse) SetLength() {
-	c.Header.Length = uint16(c.MarshalLen() - 4)
+func (u *UpdateBearerResponse) SetLength() {
+	u.Header.Length = uint16(u.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (c *UpdateBearerResponse) MessageTypeName() string {
+func (u *UpdateBearerResponse) MessageTypeName() string {
 	return "Update Bearer Response"
 }
 
 // TEID returns the TEID in uint32.
-func (c *UpdateBearerResponse) TEID() uint32 {
-	return c.Header.teid()
+func (u *UpdateBearerResponse) TEID() uint32 {
+	return u.Header.teid()
+}
+
+// UpdateBearerResponseBuilder builds UpdateBearerResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type UpdateBearerResponseBuilder struct {
+	builder
+	msg *UpdateBearerResponse
+}
+
+// BuildUpdateBearerResponse returns the builder of UpdateBearerResponse.
+func BuildUpdateBearerResponse(teid, seq uint32) *UpdateBearerResponseBuilder {
+	return &UpdateBearerResponseBuilder{
+		builder: builder{msgType: "Update Bearer Response"},
+		msg:     NewUpdateBearerResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *UpdateBearerResponseBuilder) WithCause(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithBearerContexts appends the BearerContext IEs to BearerContexts.
+func (b *UpdateBearerResponseBuilder) WithBearerContexts(ies ...*ie.IE) *UpdateBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("BearerContexts", i, ie.BearerContext) {
+			b.msg.BearerContexts = append(b.msg.BearerContexts, i)
+		}
+	}
+	return b
+}
+
+// WithPCO sets the ProtocolConfigurationOptions IE to PCO.
+func (b *UpdateBearerResponseBuilder) WithPCO(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("PCO", i, ie.ProtocolConfigurationOptions) {
+		b.msg.PCO = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *UpdateBearerResponseBuilder) WithRecovery(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *UpdateBearerResponseBuilder) WithMMEFQCSID(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithSGWFQCSID(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithEPDGFQCSID sets the FullyQualifiedCSID IE to EPDGFQCSID, with the instance set to 2.
+func (b *UpdateBearerResponseBuilder) WithEPDGFQCSID(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("EPDGFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.EPDGFQCSID = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithTWANFQCSID sets the FullyQualifiedCSID IE to TWANFQCSID, with the instance set to 3.
+func (b *UpdateBearerResponseBuilder) WithTWANFQCSID(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("TWANFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.TWANFQCSID = withInstance(i, 3)
+	}
+	return b
+}
+
+// WithIndicationFlags sets the Indication IE to IndicationFlags.
+func (b *UpdateBearerResponseBuilder) WithIndicationFlags(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("IndicationFlags", i, ie.Indication) {
+		b.msg.IndicationFlags = i
+	}
+	return b
+}
+
+// WithUETimeZone sets the UETimeZone IE to UETimeZone.
+func (b *UpdateBearerResponseBuilder) WithUETimeZone(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("UETimeZone", i, ie.UETimeZone) {
+		b.msg.UETimeZone = i
+	}
+	return b
+}
+
+// WithULI sets the UserLocationInformation IE to ULI.
+func (b *UpdateBearerResponseBuilder) WithULI(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("ULI", i, ie.UserLocationInformation) {
+		b.msg.ULI = i
+	}
+	return b
+}
+
+// WithTWANIdentifier sets the TWANIdentifier IE to TWANIdentifier, with the instance set to 0.
+func (b *UpdateBearerResponseBuilder) WithTWANIdentifier(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("TWANIdentifier", i, ie.TWANIdentifier) {
+		b.msg.TWANIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithMMESGSNOverloadControlInformation sets the OverloadControlInformation IE to MMESGSNOverloadControlInformation, with the instance set to 0.
+func (b *UpdateBearerResponseBuilder) WithMMESGSNOverloadControlInformation(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("MMESGSNOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.MMESGSNOverloadControlInformation = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWOverloadControlInformation sets the OverloadControlInformation IE to SGWOverloadControlInformation, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithSGWOverloadControlInformation(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("SGWOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.SGWOverloadControlInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPresenceReportingAreaAction appends the PresenceReportingAreaAction IEs to PresenceReportingAreaAction.
+func (b *UpdateBearerResponseBuilder) WithPresenceReportingAreaAction(ies ...*ie.IE) *UpdateBearerResponseBuilder {
+	for _, i := range ies {
+		if b.expect("PresenceReportingAreaAction", i, ie.PresenceReportingAreaAction) {
+			b.msg.PresenceReportingAreaAction = append(b.msg.PresenceReportingAreaAction, i)
+		}
+	}
+	return b
+}
+
+// WithMMESGSNIdentifier sets the IPAddress IE to MMESGSNIdentifier, with the instance set to 0.
+func (b *UpdateBearerResponseBuilder) WithMMESGSNIdentifier(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("MMESGSNIdentifier", i, ie.IPAddress) {
+		b.msg.MMESGSNIdentifier = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithTWANePDGOverloadControlInformation sets the OverloadControlInformation IE to TWANePDGOverloadControlInformation, with the instance set to 2.
+func (b *UpdateBearerResponseBuilder) WithTWANePDGOverloadControlInformation(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("TWANePDGOverloadControlInformation", i, ie.OverloadControlInformation) {
+		b.msg.TWANePDGOverloadControlInformation = withInstance(i, 2)
+	}
+	return b
+}
+
+// WithWLANLocationInformation sets the TWANIdentifier IE to WLANLocationInformation, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithWLANLocationInformation(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("WLANLocationInformation", i, ie.TWANIdentifier) {
+		b.msg.WLANLocationInformation = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithWLANLocationTimeStamp sets the TWANIdentifierTimestamp IE to WLANLocationTimeStamp, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithWLANLocationTimeStamp(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("WLANLocationTimeStamp", i, ie.TWANIdentifierTimestamp) {
+		b.msg.WLANLocationTimeStamp = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUELocalIPAddress sets the IPAddress IE to UELocalIPAddress, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithUELocalIPAddress(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("UELocalIPAddress", i, ie.IPAddress) {
+		b.msg.UELocalIPAddress = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithUEUDPPort sets the PortNumber IE to UEUDPPort, with the instance set to 0.
+func (b *UpdateBearerResponseBuilder) WithUEUDPPort(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("UEUDPPort", i, ie.PortNumber) {
+		b.msg.UEUDPPort = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithNBIFOMContainer sets the FContainer IE to NBIFOMContainer.
+func (b *UpdateBearerResponseBuilder) WithNBIFOMContainer(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("NBIFOMContainer", i, ie.FContainer) {
+		b.msg.NBIFOMContainer = i
+	}
+	return b
+}
+
+// WithUETCPPort sets the PortNumber IE to UETCPPort, with the instance set to 1.
+func (b *UpdateBearerResponseBuilder) WithUETCPPort(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("UETCPPort", i, ie.PortNumber) {
+		b.msg.UETCPPort = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *UpdateBearerResponseBuilder) WithPrivateExtension(i *ie.IE) *UpdateBearerResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewUpdateBearerResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *UpdateBearerResponseBuilder) WithIEs(ies ...*ie.IE) *UpdateBearerResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *UpdateBearerResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *UpdateBearerResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the UpdateBearerResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *UpdateBearerResponseBuilder) Build() (*UpdateBearerResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
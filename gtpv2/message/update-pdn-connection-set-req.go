@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -19,7 +21,7 @@ type UpdatePDNConnectionSetRequest struct {
 
 // NewUpdatePDNConnectionSetRequest creates a new UpdatePDNConnectionSetRequest.
 func NewUpdatePDNConnectionSetRequest(teid, seq uint32, ies ...*ie.IE) *UpdatePDNConnectionSetRequest {
-	m := &UpdatePDNConnectionSetRequest{
+	u := &UpdatePDNConnectionSetRequest{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeUpdatePDNConnectionSetRequest, teid, seq, nil,
@@ -30,98 +32,130 @@ func NewUpdatePDNConnectionSetRequest(teid, seq uint32, ies ...*ie.IE) *UpdatePD
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
+		u.setIE(i)
+	}
+
+	u.SetLength()
+	return u
+}
+
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (u *UpdatePDNConnectionSetRequest) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0:
+			u.MMEFQCSID = i
+		case 1:
+			u.SGWFQCSID = i
 		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
+			u.AdditionalIEs = append(u.AdditionalIEs, i)
+			return false
+		}
+	case ie.PrivateExtension:
+		u.PrivateExtension = i
+	default:
+		u.AdditionalIEs = append(u.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to UpdatePDNConnectionSetRequest, or 0
+// if it is set to one of the fields.
+func (u *UpdatePDNConnectionSetRequest) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.PrivateExtension:
+		return 0
+	case ie.FullyQualifiedCSID:
+		switch i.Instance() {
+		case 0, 1:
+			return 0
 		}
+		return IEUnexpectedInstance
 	}
+	return IEUnrecognized
+}
 
-	m.SetLength()
-	return m
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// UpdatePDNConnectionSetRequest, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (u *UpdatePDNConnectionSetRequest) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Update PDN Connection Set Request", u.AdditionalIEs, u.ieKind)
 }
 
 // Marshal serializes UpdatePDNConnectionSetRequest into bytes.
-func (m *UpdatePDNConnectionSetRequest) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
+func (u *UpdatePDNConnectionSetRequest) Marshal() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
 }
 
 // MarshalTo serializes UpdatePDNConnectionSetRequest into bytes.
-func (m *UpdatePDNConnectionSetRequest) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+func (u *UpdatePDNConnectionSetRequest) MarshalTo(b []byte) error {
+	if u.Header.Payload != nil {
+		u.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	u.Header.Payload = make([]byte, u.MarshalLen()-u.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.MMEFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := u.MMEFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.SGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := u.SGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Payload[offset:]); err != nil {
+	if ie := u.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	u.Header.SetLength()
+	return u.Header.MarshalTo(b)
 }
 
 // ParseUpdatePDNConnectionSetRequest decodes given bytes as UpdatePDNConnectionSetRequest.
 func ParseUpdatePDNConnectionSetRequest(b []byte) (*UpdatePDNConnectionSetRequest, error) {
-	m := &UpdatePDNConnectionSetRequest{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	u := &UpdatePDNConnectionSetRequest{}
+	if err := u.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return u, nil
 }
 
 // UnmarshalBinary decodes given bytes as UpdatePDNConnectionSetRequest.
-func (m *UpdatePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
+func (u *UpdatePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	u.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(u.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(u.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -129,41 +163,27 @@ func (m *UpdatePDNConnectionSetRequest) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.FullyQualifiedCSID:
-			switch i.Instance() {
-			case 0:
-				m.MMEFQCSID = i
-			case 1:
-				m.SGWFQCSID = i
-			default:
-				m.AdditionalIEs = append(m.AdditionalIEs, i)
-			}
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		u.setIE(i)
 	}
 
 	return nil
 }
 
 // MarshalLen returns the serial length in int.
-func (m *UpdatePDNConnectionSetRequest) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+func (u *UpdatePDNConnectionSetRequest) MarshalLen() int {
+	l := u.Header.MarshalLen() - len(u.Header.Payload)
 
-	if ie := m.MMEFQCSID; ie != nil {
+	if ie := u.MMEFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.SGWFQCSID; ie != nil {
+	if ie := u.SGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := u.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -173,16 +193,85 @@ func (m *UpdatePDNConnectionSetRequest) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *UpdatePDNConnectionSetRequest) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (u *UpdatePDNConnectionSetRequest) SetLength() {
+	u.Header.Length = uint16(u.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *UpdatePDNConnectionSetRequest) MessageTypeName() string {
+func (u *UpdatePDNConnectionSetRequest) MessageTypeName() string {
 	return "Update PDN Connection Set Request"
 }
 
 // TEID returns the TEID in uint32.
-func (m *UpdatePDNConnectionSetRequest) TEID() uint32 {
-	return m.Header.teid()
+func (u *UpdatePDNConnectionSetRequest) TEID() uint32 {
+	return u.Header.teid()
+}
+
+// UpdatePDNConnectionSetRequestBuilder builds UpdatePDNConnectionSetRequest.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type UpdatePDNConnectionSetRequestBuilder struct {
+	builder
+	msg *UpdatePDNConnectionSetRequest
+}
+
+// BuildUpdatePDNConnectionSetRequest returns the builder of UpdatePDNConnectionSetRequest.
+func BuildUpdatePDNConnectionSetRequest(teid, seq uint32) *UpdatePDNConnectionSetRequestBuilder {
+	return &UpdatePDNConnectionSetRequestBuilder{
+		builder: builder{msgType: "Update PDN Connection Set Request"},
+		msg:     NewUpdatePDNConnectionSetRequest(teid, seq),
+	}
+}
+
+// WithMMEFQCSID sets the FullyQualifiedCSID IE to MMEFQCSID, with the instance set to 0.
+func (b *UpdatePDNConnectionSetRequestBuilder) WithMMEFQCSID(i *ie.IE) *UpdatePDNConnectionSetRequestBuilder {
+	if b.expect("MMEFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.MMEFQCSID = withInstance(i, 0)
+	}
+	return b
+}
+
+// WithSGWFQCSID sets the FullyQualifiedCSID IE to SGWFQCSID, with the instance set to 1.
+func (b *UpdatePDNConnectionSetRequestBuilder) WithSGWFQCSID(i *ie.IE) *UpdatePDNConnectionSetRequestBuilder {
+	if b.expect("SGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.SGWFQCSID = withInstance(i, 1)
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *UpdatePDNConnectionSetRequestBuilder) WithPrivateExtension(i *ie.IE) *UpdatePDNConnectionSetRequestBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewUpdatePDNConnectionSetRequest. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *UpdatePDNConnectionSetRequestBuilder) WithIEs(ies ...*ie.IE) *UpdatePDNConnectionSetRequestBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *UpdatePDNConnectionSetRequestBuilder) WithAdditionalIEs(ies ...*ie.IE) *UpdatePDNConnectionSetRequestBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the UpdatePDNConnectionSetRequest built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *UpdatePDNConnectionSetRequestBuilder) Build() (*UpdatePDNConnectionSetRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
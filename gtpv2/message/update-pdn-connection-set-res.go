@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can be
 // found in the LICENSE file.
 
+// Code generated by msggen from messages.yaml. DO NOT EDIT.
+
 package message
 
 import (
@@ -20,7 +22,7 @@ type UpdatePDNConnectionSetResponse struct {
 
 // NewUpdatePDNConnectionSetResponse creates a new UpdatePDNConnectionSetResponse.
 func NewUpdatePDNConnectionSetResponse(teid, seq uint32, ies ...*ie.IE) *UpdatePDNConnectionSetResponse {
-	m := &UpdatePDNConnectionSetResponse{
+	u := &UpdatePDNConnectionSetResponse{
 		Header: NewHeader(
 			NewHeaderFlags(2, 0, 1),
 			MsgTypeUpdatePDNConnectionSetResponse, teid, seq, nil,
@@ -31,102 +33,126 @@ func NewUpdatePDNConnectionSetResponse(teid, seq uint32, ies ...*ie.IE) *UpdateP
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.FullyQualifiedCSID:
-			m.PGWFQCSID = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		u.setIE(i)
 	}
 
-	m.SetLength()
-	return m
+	u.SetLength()
+	return u
 }
 
-// Marshal returns the byte sequence generated from a UpdatePDNConnectionSetResponse.
-func (m *UpdatePDNConnectionSetResponse) Marshal() ([]byte, error) {
-	b := make([]byte, m.MarshalLen())
-	if err := m.MarshalTo(b); err != nil {
-		return nil, err
+// setIE sets the IE to the field for its type and instance, or appends it to
+// AdditionalIEs and returns false if there is no such field.
+func (u *UpdatePDNConnectionSetResponse) setIE(i *ie.IE) bool {
+	switch i.Type {
+	case ie.Cause:
+		u.Cause = i
+	case ie.FullyQualifiedCSID:
+		u.PGWFQCSID = i
+	case ie.Recovery:
+		u.Recovery = i
+	case ie.PrivateExtension:
+		u.PrivateExtension = i
+	default:
+		u.AdditionalIEs = append(u.AdditionalIEs, i)
+		return false
+	}
+	return true
+}
+
+// ieKind returns the reason why the IE does not belong to UpdatePDNConnectionSetResponse, or 0
+// if it is set to one of the fields.
+func (u *UpdatePDNConnectionSetResponse) ieKind(i *ie.IE) UnexpectedIEKind {
+	switch i.Type {
+	case ie.Cause, ie.FullyQualifiedCSID, ie.Recovery, ie.PrivateExtension:
+		return 0
 	}
+	return IEUnrecognized
+}
 
+// UnexpectedIEs returns the IEs in AdditionalIEs that do not belong to
+// UpdatePDNConnectionSetResponse, telling whether the type is unrecognized or the instance is
+// unexpected.
+func (u *UpdatePDNConnectionSetResponse) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs("Update PDN Connection Set Response", u.AdditionalIEs, u.ieKind)
+}
+
+// Marshal serializes UpdatePDNConnectionSetResponse into bytes.
+func (u *UpdatePDNConnectionSetResponse) Marshal() ([]byte, error) {
+	b := make([]byte, u.MarshalLen())
+	if err := u.MarshalTo(b); err != nil {
+		return nil, err
+	}
 	return b, nil
 }
 
-// MarshalTo puts the byte sequence in the byte array given as b.
-func (m *UpdatePDNConnectionSetResponse) MarshalTo(b []byte) error {
-	if m.Header.Payload != nil {
-		m.Header.Payload = nil
+// MarshalTo serializes UpdatePDNConnectionSetResponse into bytes.
+func (u *UpdatePDNConnectionSetResponse) MarshalTo(b []byte) error {
+	if u.Header.Payload != nil {
+		u.Header.Payload = nil
 	}
-	m.Header.Payload = make([]byte, m.MarshalLen()-m.Header.MarshalLen())
+	u.Header.Payload = make([]byte, u.MarshalLen()-u.Header.MarshalLen())
 
 	offset := 0
-	if ie := m.Cause; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := u.Cause; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PGWFQCSID; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := u.PGWFQCSID; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := u.Recovery; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+	if ie := u.PrivateExtension; ie != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
-		if err := ie.MarshalTo(m.Header.Payload[offset:]); err != nil {
+		if err := ie.MarshalTo(u.Header.Payload[offset:]); err != nil {
 			return err
 		}
 		offset += ie.MarshalLen()
 	}
 
-	m.Header.SetLength()
-	return m.Header.MarshalTo(b)
+	u.Header.SetLength()
+	return u.Header.MarshalTo(b)
 }
 
-// ParseUpdatePDNConnectionSetResponse decodes a given byte sequence as a UpdatePDNConnectionSetResponse.
+// ParseUpdatePDNConnectionSetResponse decodes given bytes as UpdatePDNConnectionSetResponse.
 func ParseUpdatePDNConnectionSetResponse(b []byte) (*UpdatePDNConnectionSetResponse, error) {
-	m := &UpdatePDNConnectionSetResponse{}
-	if err := m.UnmarshalBinary(b); err != nil {
+	u := &UpdatePDNConnectionSetResponse{}
+	if err := u.UnmarshalBinary(b); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return u, nil
 }
 
-// UnmarshalBinary decodes given byte sequence as UpdatePDNConnectionSetResponse.
-func (m *UpdatePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
+// UnmarshalBinary decodes given bytes as UpdatePDNConnectionSetResponse.
+func (u *UpdatePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
 	var err error
-	m.Header, err = ParseHeader(b)
+	u.Header, err = ParseHeader(b)
 	if err != nil {
 		return err
 	}
-	if len(m.Header.Payload) < 2 {
+	if len(u.Header.Payload) < 2 {
 		return nil
 	}
 
-	decodedIEs, err := ie.ParseMultiIEs(m.Header.Payload)
+	decodedIEs, err := ie.ParseMultiIEs(u.Header.Payload)
 	if err != nil {
 		return err
 	}
@@ -134,41 +160,30 @@ func (m *UpdatePDNConnectionSetResponse) UnmarshalBinary(b []byte) error {
 		if i == nil {
 			continue
 		}
-		switch i.Type {
-		case ie.Cause:
-			m.Cause = i
-		case ie.FullyQualifiedCSID:
-			m.PGWFQCSID = i
-		case ie.Recovery:
-			m.Recovery = i
-		case ie.PrivateExtension:
-			m.PrivateExtension = i
-		default:
-			m.AdditionalIEs = append(m.AdditionalIEs, i)
-		}
+		u.setIE(i)
 	}
 
 	return nil
 }
 
-// MarshalLen returns the serial length of Data.
-func (m *UpdatePDNConnectionSetResponse) MarshalLen() int {
-	l := m.Header.MarshalLen() - len(m.Header.Payload)
+// MarshalLen returns the serial length in int.
+func (u *UpdatePDNConnectionSetResponse) MarshalLen() int {
+	l := u.Header.MarshalLen() - len(u.Header.Payload)
 
-	if ie := m.Cause; ie != nil {
+	if ie := u.Cause; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PGWFQCSID; ie != nil {
+	if ie := u.PGWFQCSID; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.Recovery; ie != nil {
+	if ie := u.Recovery; ie != nil {
 		l += ie.MarshalLen()
 	}
-	if ie := m.PrivateExtension; ie != nil {
+	if ie := u.PrivateExtension; ie != nil {
 		l += ie.MarshalLen()
 	}
 
-	for _, ie := range m.AdditionalIEs {
+	for _, ie := range u.AdditionalIEs {
 		if ie == nil {
 			continue
 		}
@@ -178,16 +193,93 @@ func (m *UpdatePDNConnectionSetResponse) MarshalLen() int {
 }
 
 // SetLength sets the length in Length field.
-func (m *UpdatePDNConnectionSetResponse) SetLength() {
-	m.Header.Length = uint16(m.MarshalLen() - 4)
+func (u *UpdatePDNConnectionSetResponse) SetLength() {
+	u.Header.Length = uint16(u.MarshalLen() - 4)
 }
 
 // MessageTypeName returns the name of protocol.
-func (m *UpdatePDNConnectionSetResponse) MessageTypeName() string {
+func (u *UpdatePDNConnectionSetResponse) MessageTypeName() string {
 	return "Update PDN Connection Set Response"
 }
 
 // TEID returns the TEID in uint32.
-func (m *UpdatePDNConnectionSetResponse) TEID() uint32 {
-	return m.Header.teid()
+func (u *UpdatePDNConnectionSetResponse) TEID() uint32 {
+	return u.Header.teid()
+}
+
+// UpdatePDNConnectionSetResponseBuilder builds UpdatePDNConnectionSetResponse.
+//
+// The IEs are set to the fields with the instance given for each field, and the
+// ones that do not belong to the message or the field are reported by Build.
+type UpdatePDNConnectionSetResponseBuilder struct {
+	builder
+	msg *UpdatePDNConnectionSetResponse
+}
+
+// BuildUpdatePDNConnectionSetResponse returns the builder of UpdatePDNConnectionSetResponse.
+func BuildUpdatePDNConnectionSetResponse(teid, seq uint32) *UpdatePDNConnectionSetResponseBuilder {
+	return &UpdatePDNConnectionSetResponseBuilder{
+		builder: builder{msgType: "Update PDN Connection Set Response"},
+		msg:     NewUpdatePDNConnectionSetResponse(teid, seq),
+	}
+}
+
+// WithCause sets the Cause IE to Cause.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithCause(i *ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	if b.expect("Cause", i, ie.Cause) {
+		b.msg.Cause = i
+	}
+	return b
+}
+
+// WithPGWFQCSID sets the FullyQualifiedCSID IE to PGWFQCSID.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithPGWFQCSID(i *ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	if b.expect("PGWFQCSID", i, ie.FullyQualifiedCSID) {
+		b.msg.PGWFQCSID = i
+	}
+	return b
+}
+
+// WithRecovery sets the Recovery IE to Recovery.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithRecovery(i *ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	if b.expect("Recovery", i, ie.Recovery) {
+		b.msg.Recovery = i
+	}
+	return b
+}
+
+// WithPrivateExtension sets the PrivateExtension IE to PrivateExtension.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithPrivateExtension(i *ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	if b.expect("PrivateExtension", i, ie.PrivateExtension) {
+		b.msg.PrivateExtension = i
+	}
+	return b
+}
+
+// WithIEs sets the IEs to the fields for their type and instance in the same
+// way as NewUpdatePDNConnectionSetResponse. The IEs that match none of the fields are kept in
+// AdditionalIEs, and Build returns the error.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithIEs(ies ...*ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	for _, i := range ies {
+		if i != nil && !b.msg.setIE(i) {
+			b.fail("", b.msg.ieKind(i), i)
+		}
+	}
+	return b
+}
+
+// WithAdditionalIEs appends the IEs to AdditionalIEs as they are.
+func (b *UpdatePDNConnectionSetResponseBuilder) WithAdditionalIEs(ies ...*ie.IE) *UpdatePDNConnectionSetResponseBuilder {
+	b.msg.AdditionalIEs = append(b.msg.AdditionalIEs, ies...)
+	return b
+}
+
+// Build returns the UpdatePDNConnectionSetResponse built, or the first error that occurred while
+// setting the IEs, which is *UnexpectedIEError.
+func (b *UpdatePDNConnectionSetResponseBuilder) Build() (*UpdatePDNConnectionSetResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.msg.SetLength()
+	return b.msg, nil
 }
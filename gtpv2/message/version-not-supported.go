@@ -131,3 +131,11 @@ func (v *VersionNotSupportedIndication) MessageTypeName() string {
 func (v *VersionNotSupportedIndication) TEID() uint32 {
 	return v.Header.teid()
 }
+
+// UnexpectedIEs returns all the IEs in AdditionalIEs as unrecognized ones, as
+// VersionNotSupportedIndication has no IE.
+func (v *VersionNotSupportedIndication) UnexpectedIEs() []*UnexpectedIEError {
+	return unexpectedIEs(v.MessageTypeName(), v.AdditionalIEs, func(*ie.IE) UnexpectedIEKind {
+		return IEUnrecognized
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"net"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/metrics"
+)
+
+// WithMetrics lets Conn report the metrics to the metrics.Recorder given.
+//
+// Conn reports the messages sent and received per type and cause, the failures in
+// parsing and handling the messages, the retransmissions and the round-trip time of
+// the requests, and the Sessions registered and removed with RegisterSession and
+// RemoveSession. The exact number of active sessions and bearers can be retrieved
+// at any time with SessionCount and BearerCount.
+//
+// To detect the retransmissions and measure the round-trip time, Conn keeps the
+// requests sent and received for a while, which is not done without this option.
+func WithMetrics(recorder metrics.Recorder) ConnOption {
+	return func(c *Conn) {
+		c.metrics = recorder
+		c.transactions = newTransactions()
+	}
+}
+
+// recordSent records the message in b sent to raddr.
+func (c *Conn) recordSent(b []byte, raddr net.Addr) {
+	if c.metrics == nil {
+		return
+	}
+
+	// the message sent is parsed again only when the metrics are enabled, as
+	// WriteTo is given just a byte sequence.
+	msg, err := message.Parse(b)
+	if err != nil {
+		return
+	}
+	name := msg.MessageTypeName()
+	c.metrics.Message(metrics.ProtoGTPv2C, metrics.Sent, name, message.CauseOf(b))
+
+	if message.IsInitial(msg.MessageType()) {
+		if c.transactions.add(c.transactions.sent, raddr, msg.Sequence(), msg.MessageType(), name) {
+			c.metrics.Retransmission(metrics.ProtoGTPv2C, metrics.Sent, name)
+		}
+	}
+}
+
+// recordReceived records the message received from raddr, which is parsed from raw.
+func (c *Conn) recordReceived(raw []byte, raddr net.Addr, msg message.Message) {
+	if c.metrics == nil {
+		return
+	}
+
+	name := msg.MessageTypeName()
+	c.metrics.Message(metrics.ProtoGTPv2C, metrics.Received, name, message.CauseOf(raw))
+
+	switch {
+	case message.IsInitial(msg.MessageType()):
+		if c.transactions.add(c.transactions.received, raddr, msg.Sequence(), msg.MessageType(), name) {
+			c.metrics.Retransmission(metrics.ProtoGTPv2C, metrics.Received, name)
+		}
+	case message.IsTriggered(msg.MessageType()):
+		if tx, ok := c.transactions.finish(c.transactions.sent, raddr, msg.Sequence(), msg.MessageType()); ok {
+			c.metrics.RoundTrip(metrics.ProtoGTPv2C, tx.name, time.Since(tx.at))
+		}
+	}
+}
+
+func (c *Conn) recordParseError() {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ParseError(metrics.ProtoGTPv2C)
+}
+
+// recordSessions records the changes in the number of Sessions and bearers.
+func (c *Conn) recordSessions(sessions, bearers int) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.Sessions(metrics.ProtoGTPv2C, sessions, bearers)
+}
+
+func (c *Conn) recordHandlerError(msg message.Message) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.HandlerError(metrics.ProtoGTPv2C, msg.MessageTypeName())
+}
@@ -0,0 +1,202 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/metrics"
+)
+
+// testRecorder records the events as strings.
+type testRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *testRecorder) add(format string, v ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, v...))
+}
+
+func (r *testRecorder) Message(proto string, dir metrics.Direction, msgType string, cause uint8) {
+	r.add("%s %s %s cause=%d", proto, dir, msgType, cause)
+}
+
+func (r *testRecorder) ParseError(proto string) {
+	r.add("%s parse error", proto)
+}
+
+func (r *testRecorder) HandlerError(proto, msgType string) {
+	r.add("%s handler error %s", proto, msgType)
+}
+
+func (r *testRecorder) Retransmission(proto string, dir metrics.Direction, msgType string) {
+	r.add("%s retransmission %s %s", proto, dir, msgType)
+}
+
+func (r *testRecorder) RoundTrip(proto, procedure string, rtt time.Duration) {
+	r.add("%s round trip %s", proto, procedure)
+}
+
+func (r *testRecorder) TPDU(dir metrics.Direction, packets, bytes int) {
+	r.add("tpdu %s %d %d", dir, packets, bytes)
+}
+
+func (r *testRecorder) TPDUDropped(packets int) {
+	r.add("tpdu dropped %d", packets)
+}
+
+func (r *testRecorder) Sessions(proto string, sessions, bearers int) {
+	r.add("%s sessions %d bearers %d", proto, sessions, bearers)
+}
+
+// wait waits for n events to be recorded and returns them.
+func (r *testRecorder) wait(t *testing.T, n int) []string {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		r.mu.Lock()
+		events := append([]string(nil), r.events...)
+		r.mu.Unlock()
+
+		if len(events) >= n || time.Now().After(deadline) {
+			return events
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	cliAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 11}, Port: 2123}
+	srvAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 12}, Port: 2123}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvRec, cliRec := &testRecorder{}, &testRecorder{}
+	srvConn := gtpv2.NewConn(srvAddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithMetrics(srvRec))
+	if err := srvConn.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.Serve(ctx); err != nil {
+			t.Errorf("error on serving: %v", err)
+		}
+	}()
+
+	cliConn, err := gtpv2.Dial(ctx, cliAddr, srvAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithMetrics(cliRec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cliConn.Close()
+	defer srvConn.Close()
+
+	// retransmit Echo Request with the same sequence number.
+	echo, err := message.NewEchoRequest(0x100, ie.NewRecovery(0)).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cliConn.WriteTo(echo, srvAddr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// unknown TEID fails the validation.
+	csRsp, err := message.NewCreateSessionResponse(
+		0x1234, 0x101, ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+	).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cliConn.WriteTo(csRsp, srvAddr); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSrv := []string{
+		"gtpv2-c received Echo Request cause=0",
+		"gtpv2-c sent Echo Response cause=0",
+		"gtpv2-c received Echo Request cause=0",
+		"gtpv2-c sent Echo Response cause=0",
+		"gtpv2-c received Echo Request cause=0",
+		"gtpv2-c retransmission received Echo Request",
+		"gtpv2-c sent Echo Response cause=0",
+		"gtpv2-c received Create Session Response cause=16",
+		"gtpv2-c handler error Create Session Response",
+	}
+	gotSrv := srvRec.wait(t, len(wantSrv))
+
+	// the messages are handled concurrently, so the order is not checked.
+	opt := cmp.Transformer("count", func(s []string) map[string]int {
+		m := map[string]int{}
+		for _, v := range s {
+			m[v]++
+		}
+		return m
+	})
+	if diff := cmp.Diff(gotSrv, wantSrv, opt); diff != "" {
+		t.Error(diff)
+	}
+
+	wantCli := []string{
+		"gtpv2-c sent Echo Request cause=0",
+		"gtpv2-c received Echo Response cause=0",
+		"gtpv2-c sent Echo Request cause=0",
+		"gtpv2-c sent Echo Request cause=0",
+		"gtpv2-c retransmission sent Echo Request",
+		"gtpv2-c sent Create Session Response cause=16",
+		"gtpv2-c received Echo Response cause=0",
+		"gtpv2-c received Echo Response cause=0",
+		// the one by Dial and the first one of the retransmitted ones.
+		"gtpv2-c round trip Echo Request",
+		"gtpv2-c round trip Echo Request",
+	}
+	gotCli := cliRec.wait(t, len(wantCli))
+	if diff := cmp.Diff(gotCli, wantCli, opt); diff != "" {
+		t.Error(diff)
+	}
+
+}
+
+func TestWithMetricsSessions(t *testing.T) {
+	recorder := &testRecorder{}
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithMetrics(recorder))
+
+	newSession := func(itei uint32) *gtpv2.Session {
+		sess := gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567891"})
+		sess.GetDefaultBearer().APN = "internet"
+		c.RegisterSession(itei, sess)
+		return sess
+	}
+	first := newSession(1)
+	first.AddBearer("dedicated", gtpv2.NewBearer(6, "internet", &gtpv2.QoSProfile{}))
+	c.RegisterSession(2, first) // registered again
+	second := newSession(3)     // replaces the first one
+	c.RemoveSession(second)
+	c.RemoveSession(second) // removed again
+
+	want := []string{
+		"gtpv2-c sessions 1 bearers 1",
+		"gtpv2-c sessions -1 bearers -1",
+		"gtpv2-c sessions 1 bearers 1",
+		"gtpv2-c sessions -1 bearers -1",
+	}
+	if diff := cmp.Diff(recorder.wait(t, len(want)), want); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -42,6 +42,10 @@ type Session struct {
 	peerAddr       net.Addr
 	peerAddrString string
 
+	// registeredBearers is the number of bearers reported to the metrics.Recorder
+	// when Session is registered, to be subtracted when it is removed.
+	registeredBearers int
+
 	// Subscriber is a Subscriber associated with Session.
 	*Subscriber
 }
@@ -296,6 +300,12 @@ func (t *teidMap) load(ifType uint8) (uint32, bool) {
 	return teid.(uint32), true
 }
 
+func (t *teidMap) rangeWithFunc(fn func(ifType uint8, teid uint32) bool) {
+	t.syncMap.Range(func(k, v interface{}) bool {
+		return fn(k.(uint8), v.(uint32))
+	})
+}
+
 type bearerMap struct {
 	syncMap sync.Map
 }
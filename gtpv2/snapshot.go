@@ -0,0 +1,213 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"fmt"
+	"net"
+)
+
+// SessionSnapshot is a serializable form of Session, which is used to keep the
+// Sessions across the restart of the program or to hand them over to another one.
+//
+// It can be encoded with encoding/json or any other encoders as it is. Note that
+// the packet filters set by (*Bearer).SetTFT are not included; set them again
+// after restoring if needed.
+type SessionSnapshot struct {
+	IMSI     string    `json:"imsi,omitempty"`
+	MSISDN   string    `json:"msisdn,omitempty"`
+	IMEI     string    `json:"imei,omitempty"`
+	Location *Location `json:"location,omitempty"`
+
+	// PeerNetwork and PeerAddr are the network and string form of the address
+	// of the peer node, e.g., "udp" and "127.0.0.1:2123".
+	PeerNetwork string `json:"peer_network"`
+	PeerAddr    string `json:"peer_addr"`
+	Active      bool   `json:"active"`
+
+	// TEIDs are the TEIDs of Session per interface type.
+	TEIDs   map[uint8]uint32           `json:"teids,omitempty"`
+	Bearers map[string]*BearerSnapshot `json:"bearers,omitempty"`
+}
+
+// BearerSnapshot is a serializable form of Bearer.
+type BearerSnapshot struct {
+	EBI          uint8       `json:"ebi"`
+	SubscriberIP string      `json:"subscriber_ip,omitempty"`
+	APN          string      `json:"apn,omitempty"`
+	ChargingID   uint32      `json:"charging_id,omitempty"`
+	QoS          *QoSProfile `json:"qos,omitempty"`
+
+	IncomingTEID uint32 `json:"incoming_teid,omitempty"`
+	OutgoingTEID uint32 `json:"outgoing_teid,omitempty"`
+	// RemoteNetwork and RemoteAddr are the network and string form of the
+	// remote address associated with Bearer, if any.
+	RemoteNetwork string `json:"remote_network,omitempty"`
+	RemoteAddr    string `json:"remote_addr,omitempty"`
+}
+
+// Snapshot returns the SessionSnapshot of Session.
+func (s *Session) Snapshot() *SessionSnapshot {
+	snap := &SessionSnapshot{
+		Active:  s.IsActive(),
+		TEIDs:   map[uint8]uint32{},
+		Bearers: map[string]*BearerSnapshot{},
+	}
+	if s.Subscriber != nil {
+		snap.IMSI, snap.MSISDN, snap.IMEI = s.IMSI, s.MSISDN, s.IMEI
+		if s.Location != nil {
+			loc := *s.Location
+			snap.Location = &loc
+		}
+	}
+	if s.peerAddr != nil {
+		snap.PeerNetwork, snap.PeerAddr = s.peerAddr.Network(), s.peerAddr.String()
+	}
+
+	s.teidMap.rangeWithFunc(func(ifType uint8, teid uint32) bool {
+		snap.TEIDs[ifType] = teid
+		return true
+	})
+	s.bearerMap.rangeWithFunc(func(name, bearer interface{}) bool {
+		snap.Bearers[name.(string)] = bearer.(*Bearer).snapshot()
+		return true
+	})
+
+	return snap
+}
+
+func (b *Bearer) snapshot() *BearerSnapshot {
+	snap := &BearerSnapshot{
+		EBI:          b.EBI,
+		SubscriberIP: b.SubscriberIP,
+		APN:          b.APN,
+		ChargingID:   b.ChargingID,
+		IncomingTEID: b.teidIn,
+		OutgoingTEID: b.teidOut,
+	}
+	if b.QoSProfile != nil {
+		qos := *b.QoSProfile
+		snap.QoS = &qos
+	}
+	if b.raddr != nil {
+		snap.RemoteNetwork, snap.RemoteAddr = b.raddr.Network(), b.raddr.String()
+	}
+
+	return snap
+}
+
+// RestoreSession creates a new Session from the SessionSnapshot.
+//
+// The Session returned is not registered to any Conn. Use (*Conn).ImportSessions
+// to restore the Sessions on Conn.
+func RestoreSession(snap *SessionSnapshot) (*Session, error) {
+	peer, err := resolveAddr(snap.PeerNetwork, snap.PeerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore peer address of Session: %w", err)
+	}
+	if peer == nil {
+		return nil, &RequiredParameterMissingError{"PeerAddr", "Session must have the peer address"}
+	}
+
+	sub := &Subscriber{IMSI: snap.IMSI, MSISDN: snap.MSISDN, IMEI: snap.IMEI, Location: &Location{}}
+	if snap.Location != nil {
+		loc := *snap.Location
+		sub.Location = &loc
+	}
+
+	s := NewSession(peer, sub)
+	s.isActive = snap.Active
+	for ifType, teid := range snap.TEIDs {
+		s.AddTEID(ifType, teid)
+	}
+	for name, bs := range snap.Bearers {
+		b, err := restoreBearer(bs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore Bearer %s: %w", name, err)
+		}
+		s.AddBearer(name, b)
+	}
+
+	return s, nil
+}
+
+func restoreBearer(snap *BearerSnapshot) (*Bearer, error) {
+	qos := &QoSProfile{}
+	if snap.QoS != nil {
+		q := *snap.QoS
+		qos = &q
+	}
+
+	b := NewBearer(snap.EBI, snap.APN, qos)
+	b.SubscriberIP = snap.SubscriberIP
+	b.ChargingID = snap.ChargingID
+	b.teidIn, b.teidOut = snap.IncomingTEID, snap.OutgoingTEID
+
+	raddr, err := resolveAddr(snap.RemoteNetwork, snap.RemoteAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore remote address: %w", err)
+	}
+	b.raddr = raddr
+
+	return b, nil
+}
+
+func resolveAddr(network, addr string) (net.Addr, error) {
+	if addr == "" {
+		return nil, nil
+	}
+
+	switch network {
+	case "", "udp", "udp4", "udp6":
+		if network == "" {
+			network = "udp"
+		}
+		return net.ResolveUDPAddr(network, addr)
+	case "ip", "ip4", "ip6":
+		return net.ResolveIPAddr(network, addr)
+	default:
+		return nil, fmt.Errorf("unsupported network: %s", network)
+	}
+}
+
+// ExportSessions returns the snapshots of all the Sessions registered in Conn.
+func (c *Conn) ExportSessions() []*SessionSnapshot {
+	var snaps []*SessionSnapshot
+	c.store.Range(func(sess *Session) bool {
+		snaps = append(snaps, sess.Snapshot())
+		return true
+	})
+
+	return snaps
+}
+
+// ImportSessions restores the Sessions from the snapshots and registers them to Conn
+// with their incoming TEIDs of the local interface type.
+//
+// The incoming TEIDs are marked as used, so that NewSenderFTEID never issues them
+// again while the Sessions are alive. It fails if any of the TEIDs is already used by
+// the other Session in Conn or reserved by NewSenderFTEID, in which case the Sessions
+// restored before are kept.
+func (c *Conn) ImportSessions(snaps ...*SessionSnapshot) error {
+	for _, snap := range snaps {
+		sess, err := RestoreSession(snap)
+		if err != nil {
+			return fmt.Errorf("failed to restore Session of %s: %w", snap.IMSI, err)
+		}
+
+		itei, err := sess.GetTEID(c.localIfType)
+		if err != nil {
+			return fmt.Errorf("failed to restore Session of %s: no TEID for local interface type %d", snap.IMSI, c.localIfType)
+		}
+		// the TEID may be only reserved by NewSenderFTEID without any Session.
+		if ok := c.store.ReserveTEID(itei); !ok {
+			return fmt.Errorf("failed to restore Session of %s: TEID %#x is already in use", snap.IMSI, itei)
+		}
+
+		c.RegisterSession(itei, sess)
+	}
+
+	return nil
+}
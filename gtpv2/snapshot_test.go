@@ -0,0 +1,83 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+)
+
+func TestExportImportSessions(t *testing.T) {
+	peer := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2123}
+	src := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11S4SGWGTPC, 0)
+
+	sess := gtpv2.NewSession(peer, &gtpv2.Subscriber{
+		IMSI: "001011234567891", MSISDN: "8130900000000", IMEI: "123456786543210",
+		Location: &gtpv2.Location{MCC: "001", MNC: "01", TAI: 1, ECI: 0x11},
+	})
+	_ = sess.Activate()
+	sess.AddTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111)
+	sess.AddTEID(gtpv2.IFTypeS1UeNodeBGTPU, 0x22222222)
+
+	br := sess.GetDefaultBearer()
+	br.EBI, br.APN, br.SubscriberIP = 5, "internet", "10.0.0.1"
+	br.QCI, br.MBRUL, br.MBRDL = 9, 1000, 2000
+	br.SetIncomingTEID(0x33333333)
+	br.SetOutgoingTEID(0x22222222)
+	br.SetRemoteAddress(&net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2152})
+	sess.AddBearer("voice", gtpv2.NewBearer(6, "internet", &gtpv2.QoSProfile{QCI: 1}))
+
+	src.RegisterSession(0x44444444, sess)
+
+	// encode and decode the snapshots as they would be saved in a file.
+	b, err := json.Marshal(src.ExportSessions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snaps []*gtpv2.SessionSnapshot
+	if err := json.Unmarshal(b, &snaps); err != nil {
+		t.Fatal(err)
+	}
+
+	store := gtpv2.NewMemorySessionStore()
+	dst := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithSessionStore(store))
+	if err := dst.ImportSessions(snaps...); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := dst.GetSessionByTEID(0x44444444, peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(restored.Snapshot(), sess.Snapshot()); diff != "" {
+		t.Error(diff)
+	}
+	if !restored.IsActive() {
+		t.Error("restored Session is not active")
+	}
+	if store.ReserveTEID(0x44444444) {
+		t.Error("TEID of restored Session is not reserved")
+	}
+
+	if err := dst.ImportSessions(snaps...); err == nil {
+		t.Error("ImportSessions should fail with the TEID in use")
+	}
+
+	// the TEID issued by NewSenderFTEID is not associated with any Session yet.
+	reserved := gtpv2.NewMemorySessionStore()
+	reserved.ReserveTEID(0x44444444)
+	dst = gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithSessionStore(reserved))
+	if err := dst.ImportSessions(snaps...); err == nil {
+		t.Error("ImportSessions should fail with the TEID reserved")
+	}
+	if len(dst.Sessions()) != 0 {
+		t.Errorf("Session is registered with the TEID reserved: %v", dst.Sessions())
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"net"
+	"sync"
+)
+
+// SessionStore is the storage of Sessions used by Conn.
+//
+// By default, Conn keeps Sessions in memory with MemorySessionStore. Users can
+// give their own implementation with WithSessionStore option, e.g., to share the
+// Sessions across processes or to inspect them externally.
+//
+// The methods are called concurrently, so the implementation must be safe for
+// concurrent use. LoadByTEID is called for every incoming message with TEID,
+// which should be as fast as possible.
+//
+// As Conn has no way to recover from the failure of the storage, the implementation
+// should handle it internally (e.g., retrying and logging).
+type SessionStore interface {
+	// Store stores session with its incoming TEID and IMSI. A Session can be
+	// stored multiple times with the different TEIDs, and a subscriber can have
+	// multiple Sessions (= PDN connections).
+	Store(teid uint32, session *Session)
+
+	// ReserveTEID marks teid as used without any Session associated, and
+	// reports whether it succeeded or not (= the TEID is already used).
+	ReserveTEID(teid uint32) bool
+
+	// LoadByTEID returns the Session associated with teid.
+	// It returns false if not found or the TEID is only reserved.
+	LoadByTEID(teid uint32) (*Session, bool)
+
+	// LoadByIMSI returns all the Sessions of the subscriber in the order of
+	// registration.
+	LoadByIMSI(imsi string) []*Session
+
+	// LoadByPeer returns all the Sessions with the peer node given.
+	LoadByPeer(peer net.Addr) []*Session
+
+	// Delete deletes session and all the TEIDs associated with it.
+	Delete(session *Session)
+
+	// DeleteTEID deletes teid, which is either associated with a Session or
+	// just reserved. The Session associated with it is kept.
+	DeleteTEID(teid uint32)
+
+	// Range calls fn for each Session stored until fn returns false.
+	Range(fn func(session *Session) bool)
+}
+
+// MemorySessionStore is the default SessionStore which keeps Sessions in memory.
+type MemorySessionStore struct {
+	// teids is the map of incoming TEID and Session, which is looked up for
+	// every incoming message, so the lookup is done without lock.
+	teids sync.Map // uint32 -> *Session, or nil if reserved
+
+	// mu protects byIMSI and bySession.
+	mu sync.RWMutex
+	// byIMSI keeps the Sessions of a subscriber in the order of registration.
+	byIMSI map[string][]*Session
+	// bySession keeps the IMSI and TEIDs that each Session is stored with, as
+	// the IMSI in Session can be changed after stored.
+	bySession map[*Session]*sessionEntry
+}
+
+type sessionEntry struct {
+	imsi  string
+	teids []uint32
+}
+
+// NewMemorySessionStore creates a new MemorySessionStore.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		byIMSI:    map[string][]*Session{},
+		bySession: map[*Session]*sessionEntry{},
+	}
+}
+
+// Store stores session with its incoming TEID and IMSI.
+func (m *MemorySessionStore) Store(teid uint32, session *Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.teids.Store(teid, session)
+
+	e, ok := m.bySession[session]
+	if ok && e.imsi != session.IMSI {
+		m.deleteIMSILocked(e.imsi, session)
+		m.byIMSI[session.IMSI] = append(m.byIMSI[session.IMSI], session)
+		e.imsi = session.IMSI
+	}
+	if !ok {
+		e = &sessionEntry{imsi: session.IMSI}
+		m.bySession[session] = e
+		m.byIMSI[session.IMSI] = append(m.byIMSI[session.IMSI], session)
+	}
+
+	for _, t := range e.teids {
+		if t == teid {
+			return
+		}
+	}
+	e.teids = append(e.teids, teid)
+}
+
+// ReserveTEID marks teid as used without any Session associated.
+func (m *MemorySessionStore) ReserveTEID(teid uint32) bool {
+	_, loaded := m.teids.LoadOrStore(teid, (*Session)(nil))
+	return !loaded
+}
+
+// LoadByTEID returns the Session associated with teid.
+func (m *MemorySessionStore) LoadByTEID(teid uint32) (*Session, bool) {
+	v, ok := m.teids.Load(teid)
+	if !ok {
+		return nil, false
+	}
+
+	session, ok := v.(*Session)
+	if !ok || session == nil {
+		return nil, false
+	}
+	return session, true
+}
+
+// LoadByIMSI returns all the Sessions of the subscriber.
+func (m *MemorySessionStore) LoadByIMSI(imsi string) []*Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]*Session(nil), m.byIMSI[imsi]...)
+}
+
+// LoadByPeer returns all the Sessions with the peer node given.
+func (m *MemorySessionStore) LoadByPeer(peer net.Addr) []*Session {
+	addr := peer.String()
+
+	var sessions []*Session
+	m.Range(func(session *Session) bool {
+		if session.peerAddrString == addr {
+			sessions = append(sessions, session)
+		}
+		return true
+	})
+	return sessions
+}
+
+// Delete deletes session and all the TEIDs associated with it.
+func (m *MemorySessionStore) Delete(session *Session) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.bySession[session]
+	if !ok {
+		return
+	}
+	delete(m.bySession, session)
+
+	// not to remove the TEID that is already reused by another Session.
+	for _, teid := range e.teids {
+		m.teids.CompareAndDelete(teid, session)
+	}
+	m.deleteIMSILocked(e.imsi, session)
+}
+
+// DeleteTEID deletes teid.
+func (m *MemorySessionStore) DeleteTEID(teid uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.teids.LoadAndDelete(teid)
+	if !ok {
+		return
+	}
+	session, ok := v.(*Session)
+	if !ok || session == nil {
+		return
+	}
+
+	e, ok := m.bySession[session]
+	if !ok {
+		return
+	}
+	for n, t := range e.teids {
+		if t == teid {
+			e.teids = append(e.teids[:n], e.teids[n+1:]...)
+			return
+		}
+	}
+}
+
+// Range calls fn for each Session stored until fn returns false.
+//
+// fn is called with the snapshot of the Sessions, so it is safe to store and
+// delete Sessions in fn.
+func (m *MemorySessionStore) Range(fn func(session *Session) bool) {
+	m.mu.RLock()
+	sessions := make([]*Session, 0, len(m.bySession))
+	for s := range m.bySession {
+		sessions = append(sessions, s)
+	}
+	m.mu.RUnlock()
+
+	for _, s := range sessions {
+		if !fn(s) {
+			return
+		}
+	}
+}
+
+func (m *MemorySessionStore) deleteIMSILocked(imsi string, session *Session) {
+	sessions := m.byIMSI[imsi]
+	for n, s := range sessions {
+		if s != session {
+			continue
+		}
+
+		// not to modify the slices returned by LoadByIMSI.
+		ss := make([]*Session, 0, len(sessions)-1)
+		ss = append(ss, sessions[:n]...)
+		ss = append(ss, sessions[n+1:]...)
+		if len(ss) == 0 {
+			delete(m.byIMSI, imsi)
+		} else {
+			m.byIMSI[imsi] = ss
+		}
+		return
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	store := gtpv2.NewMemorySessionStore()
+
+	peer1 := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2123}
+	peer2 := &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2123}
+	sess1 := gtpv2.NewSession(peer1, &gtpv2.Subscriber{IMSI: "001011234567891"})
+	sess2 := gtpv2.NewSession(peer2, &gtpv2.Subscriber{IMSI: "001011234567891"})
+
+	store.Store(1, sess1)
+	store.Store(2, sess1)
+	store.Store(3, sess2)
+
+	if !store.ReserveTEID(4) {
+		t.Error("ReserveTEID should succeed with unused TEID")
+	}
+	if store.ReserveTEID(3) {
+		t.Error("ReserveTEID should fail with used TEID")
+	}
+	if _, ok := store.LoadByTEID(4); ok {
+		t.Error("LoadByTEID should not return Session with reserved TEID")
+	}
+
+	for teid, want := range map[uint32]*gtpv2.Session{1: sess1, 2: sess1, 3: sess2} {
+		if got, ok := store.LoadByTEID(teid); !ok || got != want {
+			t.Errorf("Got wrong Session with TEID %d: %v", teid, got)
+		}
+	}
+	if got := store.LoadByIMSI("001011234567891"); len(got) != 2 || got[0] != sess1 || got[1] != sess2 {
+		t.Errorf("Got wrong Sessions by IMSI: %v", got)
+	}
+	if got := store.LoadByPeer(peer2); len(got) != 1 || got[0] != sess2 {
+		t.Errorf("Got wrong Sessions by peer: %v", got)
+	}
+
+	store.DeleteTEID(2)
+	if _, ok := store.LoadByTEID(2); ok {
+		t.Error("TEID is not deleted")
+	}
+	if _, ok := store.LoadByTEID(1); !ok {
+		t.Error("Session is deleted with one of its TEIDs")
+	}
+
+	store.Delete(sess1)
+	if _, ok := store.LoadByTEID(1); ok {
+		t.Error("TEID of deleted Session is still found")
+	}
+	if got := store.LoadByIMSI("001011234567891"); len(got) != 1 || got[0] != sess2 {
+		t.Errorf("Got wrong Sessions by IMSI after deleted: %v", got)
+	}
+
+	var count int
+	store.Range(func(*gtpv2.Session) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("Range iterated over %d Sessions, want 1", count)
+	}
+}
+
+// countingStore is a SessionStore that counts the Sessions stored.
+type countingStore struct {
+	*gtpv2.MemorySessionStore
+	stored int
+}
+
+func (s *countingStore) Store(teid uint32, session *gtpv2.Session) {
+	s.stored++
+	s.MemorySessionStore.Store(teid, session)
+}
+
+func TestWithSessionStore(t *testing.T) {
+	store := &countingStore{MemorySessionStore: gtpv2.NewMemorySessionStore()}
+	c := gtpv2.NewConn(dummyAddr, gtpv2.IFTypeS11MMEGTPC, 0, gtpv2.WithSessionStore(store))
+
+	sess := gtpv2.NewSession(dummyAddr, &gtpv2.Subscriber{IMSI: "001011234567891"})
+	c.RegisterSession(1, sess)
+
+	if store.stored != 1 {
+		t.Errorf("Session is not stored in the store given: %d", store.stored)
+	}
+	if got, err := c.GetSessionByTEID(1, dummyAddr); err != nil || got != sess {
+		t.Errorf("Got wrong Session: %v, %v", got, err)
+	}
+
+	c.RemoveSession(sess)
+	if got := store.LoadByIMSI("001011234567891"); len(got) != 0 {
+		t.Errorf("Session is not removed from the store given: %v", got)
+	}
+}
@@ -0,0 +1,472 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// Direction of the traffic to be classified with TFT.
+const (
+	DirectionDownlink uint8 = iota + 1
+	DirectionUplink
+)
+
+// IP protocol numbers used to find ports and SPI in packets.
+const (
+	protoTCP     uint8 = 6
+	protoUDP     uint8 = 17
+	protoESP     uint8 = 50
+	protoAH      uint8 = 51
+	protoSCTP    uint8 = 132
+	protoUDPLite uint8 = 136
+)
+
+// PacketFilter is a packet filter in TFT compiled to evaluate IP packets.
+//
+// The components of the filter are evaluated as defined in §10.5.6.12, TS 24.008;
+// "remote" means the address and port of the node that the subscriber communicates
+// with, and "local" means those of the subscriber.
+type PacketFilter struct {
+	Identifier, Precedence, Direction uint8
+
+	// version is the IP version that the address components require, or 0 if any.
+	version               int
+	remoteAddr, localAddr *net.IPNet
+	hasProto              bool
+	proto                 uint8
+	remotePort, localPort *portRange
+	hasSPI                bool
+	spi                   uint32
+	hasTOS                bool
+	tos, tosMask          uint8
+	hasFlowLabel          bool
+	flowLabel             uint32
+}
+
+type portRange struct {
+	low, high uint16
+}
+
+func (p *portRange) contains(port uint16) bool {
+	return port >= p.low && port <= p.high
+}
+
+// CompilePacketFilter compiles a packet filter in TFT into PacketFilter.
+//
+// The components for Ethernet PDN (MAC addresses, 802.1Q tags and Ethertype) are
+// not supported, as PacketFilter evaluates only IP packets.
+func CompilePacketFilter(f *ie.TFTPacketFilter) (*PacketFilter, error) {
+	pf := &PacketFilter{
+		Identifier: f.Identifier,
+		Precedence: f.EvaluationPrecedence,
+		Direction:  f.Direction,
+	}
+
+	for _, c := range f.Components {
+		if err := pf.addComponent(c); err != nil {
+			return nil, fmt.Errorf("failed to compile packet filter %d: %w", f.Identifier, err)
+		}
+	}
+
+	return pf, nil
+}
+
+func (p *PacketFilter) addComponent(c *ie.TFTPFComponent) error {
+	switch c.Type {
+	case ie.PFCompIPv4RemoteAddress:
+		ipnet, err := c.IPv4RemoteAddress()
+		if err != nil {
+			return err
+		}
+		return p.setAddr(&p.remoteAddr, ipnet, 4)
+	case ie.PFCompIPv4LocalAddress:
+		ipnet, err := c.IPv4LocalAddress()
+		if err != nil {
+			return err
+		}
+		return p.setAddr(&p.localAddr, ipnet, 4)
+	case ie.PFCompIPv6RemoteAddress:
+		ipnet, err := c.IPv6RemoteAddress()
+		if err != nil {
+			return err
+		}
+		return p.setAddr(&p.remoteAddr, ipnet, 6)
+	case ie.PFCompIPv6RemoteAddressPrefixLength:
+		ipnet, err := c.IPv6RemoteAddressPrefixLength()
+		if err != nil {
+			return err
+		}
+		return p.setAddr(&p.remoteAddr, ipnet, 6)
+	case ie.PFCompIPv6LocalAddressPrefixLength:
+		ipnet, err := c.IPv6LocalAddressPrefixLength()
+		if err != nil {
+			return err
+		}
+		return p.setAddr(&p.localAddr, ipnet, 6)
+	case ie.PFCompProtocolIdentifierNextHeader:
+		proto, err := c.ProtocolIdentifierNextHeader()
+		if err != nil {
+			return err
+		}
+		p.hasProto, p.proto = true, proto
+	case ie.PFCompSingleLocalPort:
+		port, err := c.SingleLocalPort()
+		if err != nil {
+			return err
+		}
+		p.localPort = &portRange{port, port}
+	case ie.PFCompLocalPortRange:
+		low, high, err := c.LocalPortRange()
+		if err != nil {
+			return err
+		}
+		p.localPort = &portRange{low, high}
+	case ie.PFCompSingleRemotePort:
+		port, err := c.SingleRemotePort()
+		if err != nil {
+			return err
+		}
+		p.remotePort = &portRange{port, port}
+	case ie.PFCompRemotePortRange:
+		low, high, err := c.RemotePortRange()
+		if err != nil {
+			return err
+		}
+		p.remotePort = &portRange{low, high}
+	case ie.PFCompSecurityParameterIndex:
+		spi, err := c.SecurityParameterIndex()
+		if err != nil {
+			return err
+		}
+		p.hasSPI, p.spi = true, spi
+	case ie.PFCompTypeOfServiceTrafficClass:
+		tos, mask, err := c.TypeOfServiceTrafficClass()
+		if err != nil {
+			return err
+		}
+		p.hasTOS, p.tos, p.tosMask = true, tos, mask
+	case ie.PFCompFlowLabel:
+		label, err := c.FlowLabel()
+		if err != nil {
+			return err
+		}
+		p.hasFlowLabel, p.flowLabel = true, label&0xfffff
+		// flow label exists only in IPv6.
+		if p.version == 4 {
+			return errors.New("flow label cannot be used with IPv4 address")
+		}
+		p.version = 6
+	default:
+		return fmt.Errorf("unsupported component type: %d", c.Type)
+	}
+
+	return nil
+}
+
+func (p *PacketFilter) setAddr(dst **net.IPNet, ipnet *net.IPNet, version int) error {
+	if p.version != 0 && p.version != version {
+		return fmt.Errorf("IPv%d component cannot be used with IPv%d component", version, p.version)
+	}
+	p.version = version
+
+	*dst = ipnet
+	return nil
+}
+
+// AppliesTo reports whether the PacketFilter is applied to the traffic in the direction
+// given (DirectionDownlink or DirectionUplink).
+//
+// The pre-Rel-7 TFT filters are applied only to the downlink traffic.
+func (p *PacketFilter) AppliesTo(dir uint8) bool {
+	switch p.Direction {
+	case ie.TFTPFBidirectional:
+		return true
+	case ie.TFTPFDownlinkOnly, ie.TFTPFPreRel7TFTFilter:
+		return dir == DirectionDownlink
+	case ie.TFTPFUplinkOnly:
+		return dir == DirectionUplink
+	default:
+		return false
+	}
+}
+
+// Match reports whether the IPv4/IPv6 packet given matches the PacketFilter, regarding
+// the packet is in the direction given (DirectionDownlink or DirectionUplink).
+func (p *PacketFilter) Match(pkt []byte, dir uint8) bool {
+	info, ok := parseIPPacket(pkt)
+	if !ok {
+		return false
+	}
+	return p.AppliesTo(dir) && p.match(info, dir)
+}
+
+func (p *PacketFilter) match(info *ipPacket, dir uint8) bool {
+	if p.version != 0 && p.version != info.version {
+		return false
+	}
+
+	remote, local := info.src, info.dst
+	if dir == DirectionUplink {
+		remote, local = local, remote
+	}
+	if p.remoteAddr != nil && !p.remoteAddr.Contains(remote) {
+		return false
+	}
+	if p.localAddr != nil && !p.localAddr.Contains(local) {
+		return false
+	}
+
+	if p.hasProto && p.proto != info.proto {
+		return false
+	}
+	if p.hasTOS && p.tos&p.tosMask != info.tos&p.tosMask {
+		return false
+	}
+	if p.hasFlowLabel && p.flowLabel != info.flowLabel {
+		return false
+	}
+
+	if p.remotePort != nil || p.localPort != nil {
+		src, dst, ok := info.ports()
+		if !ok {
+			return false
+		}
+		remotePort, localPort := src, dst
+		if dir == DirectionUplink {
+			remotePort, localPort = localPort, remotePort
+		}
+		if p.remotePort != nil && !p.remotePort.contains(remotePort) {
+			return false
+		}
+		if p.localPort != nil && !p.localPort.contains(localPort) {
+			return false
+		}
+	}
+
+	if p.hasSPI {
+		spi, ok := info.spi()
+		if !ok || spi != p.spi {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ipPacket is the fields in IPv4/IPv6 packet that are evaluated by PacketFilter.
+type ipPacket struct {
+	version   int
+	src, dst  net.IP
+	proto     uint8
+	tos       uint8
+	flowLabel uint32
+	// payload is the upper layer header and payload, which is nil when
+	// the packet is not the first fragment.
+	payload []byte
+}
+
+func parseIPPacket(b []byte) (*ipPacket, bool) {
+	if len(b) < 1 {
+		return nil, false
+	}
+
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < 20 {
+			return nil, false
+		}
+		ihl := int(b[0]&0x0f) * 4
+		if ihl < 20 || len(b) < ihl {
+			return nil, false
+		}
+
+		p := &ipPacket{
+			version: 4,
+			src:     net.IP(b[12:16]),
+			dst:     net.IP(b[16:20]),
+			proto:   b[9],
+			tos:     b[1],
+		}
+		if binary.BigEndian.Uint16(b[6:8])&0x1fff == 0 {
+			p.payload = b[ihl:]
+		}
+		return p, true
+	case 6:
+		if len(b) < 40 {
+			return nil, false
+		}
+
+		p := &ipPacket{
+			version:   6,
+			src:       net.IP(b[8:24]),
+			dst:       net.IP(b[24:40]),
+			tos:       b[0]<<4 | b[1]>>4,
+			flowLabel: uint32(b[1]&0x0f)<<16 | uint32(b[2])<<8 | uint32(b[3]),
+		}
+
+		// skip the extension headers to find the upper layer protocol.
+		next, offset, fragmented := b[6], 40, false
+	loop:
+		for {
+			switch next {
+			case 0, 43, 60: // Hop-by-Hop Options, Routing, Destination Options
+				if len(b) < offset+2 {
+					return nil, false
+				}
+				next, offset = b[offset], offset+(int(b[offset+1])+1)*8
+			case 44: // Fragment
+				if len(b) < offset+8 {
+					return nil, false
+				}
+				if binary.BigEndian.Uint16(b[offset+2:offset+4])>>3 != 0 {
+					fragmented = true
+				}
+				next, offset = b[offset], offset+8
+			default:
+				break loop
+			}
+		}
+		if len(b) < offset {
+			return nil, false
+		}
+
+		p.proto = next
+		if !fragmented {
+			p.payload = b[offset:]
+		}
+		return p, true
+	default:
+		return nil, false
+	}
+}
+
+func (p *ipPacket) ports() (src, dst uint16, ok bool) {
+	switch p.proto {
+	case protoTCP, protoUDP, protoSCTP, protoUDPLite:
+		if len(p.payload) < 4 {
+			return 0, 0, false
+		}
+		return binary.BigEndian.Uint16(p.payload[0:2]), binary.BigEndian.Uint16(p.payload[2:4]), true
+	default:
+		return 0, 0, false
+	}
+}
+
+func (p *ipPacket) spi() (uint32, bool) {
+	switch p.proto {
+	case protoESP:
+		if len(p.payload) < 4 {
+			return 0, false
+		}
+		return binary.BigEndian.Uint32(p.payload[0:4]), true
+	case protoAH:
+		if len(p.payload) < 8 {
+			return 0, false
+		}
+		return binary.BigEndian.Uint32(p.payload[4:8]), true
+	default:
+		return 0, false
+	}
+}
+
+// Classifier classifies IP packets into the Bearers by the packet filters in TFT
+// of each Bearer, which is needed for the multi-bearer environment.
+//
+// Classifier is a snapshot of the Bearers and their packet filters at the time it is
+// created. Create a new one when the Bearers or their TFTs are changed.
+type Classifier struct {
+	filters []*classifierFilter
+	// fallback is the Bearer without TFT, which receives the packets that match
+	// no packet filters.
+	fallback *Bearer
+}
+
+type classifierFilter struct {
+	*PacketFilter
+	bearer *Bearer
+}
+
+// NewClassifier creates a new Classifier with the Bearers given.
+//
+// The packet filters of all the Bearers are evaluated in the order of the evaluation
+// precedence (lower value first). If no packet filter matches, the packet is classified
+// into the Bearer without TFT (typically the default bearer) if any.
+func NewClassifier(bearers ...*Bearer) *Classifier {
+	c := &Classifier{}
+	for _, b := range bearers {
+		if b == nil {
+			continue
+		}
+
+		filters := b.PacketFilters()
+		if len(filters) == 0 {
+			if c.fallback == nil {
+				c.fallback = b
+			}
+			continue
+		}
+		for _, f := range filters {
+			c.filters = append(c.filters, &classifierFilter{PacketFilter: f, bearer: b})
+		}
+	}
+
+	sort.SliceStable(c.filters, func(i, j int) bool {
+		return c.filters[i].Precedence < c.filters[j].Precedence
+	})
+	return c
+}
+
+// Classify returns the Bearer that the IPv4/IPv6 packet in the direction given
+// (DirectionDownlink or DirectionUplink) belongs to.
+// It returns false if no Bearer is found or the packet is not a valid IP packet.
+func (c *Classifier) Classify(pkt []byte, dir uint8) (*Bearer, bool) {
+	info, ok := parseIPPacket(pkt)
+	if !ok {
+		return nil, false
+	}
+
+	for _, f := range c.filters {
+		if f.AppliesTo(dir) && f.match(info, dir) {
+			return f.bearer, true
+		}
+	}
+
+	if c.fallback == nil {
+		return nil, false
+	}
+	return c.fallback, true
+}
+
+// Downlink returns the Bearer that the downlink packet belongs to.
+func (c *Classifier) Downlink(pkt []byte) (*Bearer, bool) {
+	return c.Classify(pkt, DirectionDownlink)
+}
+
+// Uplink returns the Bearer that the uplink packet belongs to.
+func (c *Classifier) Uplink(pkt []byte) (*Bearer, bool) {
+	return c.Classify(pkt, DirectionUplink)
+}
+
+// DownlinkTEID returns the outgoing TEID of the Bearer that the downlink packet
+// belongs to, which is the TEID to be used to encapsulate the packet.
+func (c *Classifier) DownlinkTEID(pkt []byte) (uint32, bool) {
+	b, ok := c.Downlink(pkt)
+	if !ok {
+		return 0, false
+	}
+	return b.OutgoingTEID(), true
+}
+
+// NewClassifier creates a new Classifier with all the Bearers in Session.
+func (s *Session) NewClassifier() *Classifier {
+	return NewClassifier(s.Bearers()...)
+}
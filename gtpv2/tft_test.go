@@ -0,0 +1,372 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// ipv4Packet returns an IPv4 packet with the upper layer header that has the
+// ports or SPI given.
+func ipv4Packet(src, dst string, proto, tos uint8, upper []byte) []byte {
+	b := make([]byte, 20+len(upper))
+	b[0] = 0x45
+	b[1] = tos
+	binary.BigEndian.PutUint16(b[2:4], uint16(len(b)))
+	b[8] = 64
+	b[9] = proto
+	copy(b[12:16], net.ParseIP(src).To4())
+	copy(b[16:20], net.ParseIP(dst).To4())
+	copy(b[20:], upper)
+	return b
+}
+
+func ipv6Packet(src, dst string, next, tc uint8, label uint32, upper []byte) []byte {
+	b := make([]byte, 40+len(upper))
+	binary.BigEndian.PutUint32(b[0:4], 6<<28|uint32(tc)<<20|label&0xfffff)
+	binary.BigEndian.PutUint16(b[4:6], uint16(len(upper)))
+	b[6] = next
+	b[7] = 64
+	copy(b[8:24], net.ParseIP(src).To16())
+	copy(b[24:40], net.ParseIP(dst).To16())
+	copy(b[40:], upper)
+	return b
+}
+
+func ports(src, dst uint16) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint16(b[0:2], src)
+	binary.BigEndian.PutUint16(b[2:4], dst)
+	return b
+}
+
+func newBearerWithTFT(t *testing.T, ebi uint8, teid uint32, filters ...*ie.TFTPacketFilter) *gtpv2.Bearer {
+	t.Helper()
+
+	b := gtpv2.NewBearer(ebi, "", &gtpv2.QoSProfile{})
+	b.SetOutgoingTEID(teid)
+	if len(filters) == 0 {
+		return b
+	}
+	if err := b.SetTFT(ie.NewTrafficFlowTemplate(ie.TFTOpCreateNewTFT, filters, nil, nil)); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestPacketFilter(t *testing.T) {
+	cases := []struct {
+		description string
+		filter      *ie.TFTPacketFilter
+		pkt         []byte
+		dir         uint8
+		matched     bool
+	}{
+		{
+			"IPv4RemoteAddress/Downlink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv4RemoteAddress(net.ParseIP("192.168.0.0"), net.IPv4Mask(255, 255, 255, 0)),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"IPv4RemoteAddress/Uplink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv4RemoteAddress(net.ParseIP("192.168.0.0"), net.IPv4Mask(255, 255, 255, 0)),
+			),
+			ipv4Packet("10.0.0.1", "192.168.0.1", 17, 0, ports(10000, 53)),
+			gtpv2.DirectionUplink,
+			true,
+		}, {
+			"IPv4RemoteAddress/NotMatched",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv4RemoteAddress(net.ParseIP("192.168.0.0"), net.IPv4Mask(255, 255, 255, 0)),
+			),
+			ipv4Packet("192.168.1.1", "10.0.0.1", 17, 0, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			false,
+		}, {
+			"IPv4LocalAddress/IPv6Packet",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv4LocalAddress(net.ParseIP("10.0.0.1"), net.IPv4Mask(255, 255, 255, 255)),
+			),
+			ipv6Packet("2001:db8::1", "2001:db8:1::1", 17, 0, 0, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			false,
+		}, {
+			"UplinkOnly/Downlink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFUplinkOnly, 1, 0,
+				ie.NewTFTPFComponentProtocolIdentifierNextHeader(17),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			false,
+		}, {
+			"PreRel7/Downlink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFPreRel7TFTFilter, 1, 0,
+				ie.NewTFTPFComponentProtocolIdentifierNextHeader(17),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"Ports/Downlink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentProtocolIdentifierNextHeader(6),
+				ie.NewTFTPFComponentSingleRemotePort(443),
+				ie.NewTFTPFComponentLocalPortRange(10000, 20000),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 6, 0, ports(443, 12345)),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"Ports/Uplink",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentSingleRemotePort(443),
+				ie.NewTFTPFComponentLocalPortRange(10000, 20000),
+			),
+			ipv4Packet("10.0.0.1", "192.168.0.1", 6, 0, ports(12345, 443)),
+			gtpv2.DirectionUplink,
+			true,
+		}, {
+			"Ports/OutOfRange",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentLocalPortRange(10000, 20000),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 6, 0, ports(443, 20001)),
+			gtpv2.DirectionDownlink,
+			false,
+		}, {
+			"Ports/ICMP",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentSingleRemotePort(443),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 1, 0, ports(443, 443)),
+			gtpv2.DirectionDownlink,
+			false,
+		}, {
+			"TypeOfService",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentTypeOfServiceTrafficClass(0xb8, 0xfc),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0xb9, ports(5060, 5060)),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"SPI/ESP",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentSecurityParameterIndex(0xdeadbeef),
+			),
+			ipv4Packet("192.168.0.1", "10.0.0.1", 50, 0, []byte{0xde, 0xad, 0xbe, 0xef, 0, 0, 0, 1}),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"IPv6RemoteAddressPrefixLength/FlowLabel",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv6RemoteAddressPrefixLength(net.ParseIP("2001:db8::"), 32),
+				ie.NewTFTPFComponentFlowLabel(0x12345),
+			),
+			ipv6Packet("2001:db8::1", "2001:db8:1::1", 17, 0, 0x12345, ports(53, 10000)),
+			gtpv2.DirectionDownlink,
+			true,
+		}, {
+			"IPv6LocalAddressPrefixLength/TrafficClass",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv6LocalAddressPrefixLength(net.ParseIP("2001:db8:1::"), 64),
+				ie.NewTFTPFComponentTypeOfServiceTrafficClass(0xb8, 0xff),
+			),
+			ipv6Packet("2001:db8:1::1", "2001:db8::1", 17, 0xb8, 0, ports(10000, 53)),
+			gtpv2.DirectionUplink,
+			true,
+		}, {
+			"IPv6/ExtensionHeader",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentProtocolIdentifierNextHeader(17),
+				ie.NewTFTPFComponentSingleLocalPort(10000),
+			),
+			ipv6Packet("2001:db8::1", "2001:db8:1::1", 0, 0, 0, append(
+				// Hop-by-Hop Options header with padding only
+				[]byte{17, 0, 1, 4, 0, 0, 0, 0}, ports(53, 10000)...,
+			)),
+			gtpv2.DirectionDownlink,
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			f, err := gtpv2.CompilePacketFilter(c.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := f.Match(c.pkt, c.dir); got != c.matched {
+				t.Errorf("got %v, want %v", got, c.matched)
+			}
+		})
+	}
+}
+
+func TestCompilePacketFilterError(t *testing.T) {
+	cases := []struct {
+		description string
+		filter      *ie.TFTPacketFilter
+	}{
+		{
+			"Ethertype",
+			ie.NewTFTPacketFilter(ie.TFTPFBidirectional, 1, 0, ie.NewTFTPFComponentEthertype(0x0800)),
+		}, {
+			"IPv4AndIPv6",
+			ie.NewTFTPacketFilter(
+				ie.TFTPFBidirectional, 1, 0,
+				ie.NewTFTPFComponentIPv4RemoteAddress(net.ParseIP("192.168.0.0"), net.IPv4Mask(255, 255, 255, 0)),
+				ie.NewTFTPFComponentIPv6LocalAddressPrefixLength(net.ParseIP("2001:db8::"), 32),
+			),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if _, err := gtpv2.CompilePacketFilter(c.filter); err == nil {
+				t.Error("CompilePacketFilter should fail")
+			}
+		})
+	}
+}
+
+func TestClassifier(t *testing.T) {
+	defaultBearer := newBearerWithTFT(t, 5, 0x11111111)
+	voiceBearer := newBearerWithTFT(
+		t, 6, 0x22222222,
+		ie.NewTFTPacketFilter(
+			ie.TFTPFBidirectional, 1, 10,
+			ie.NewTFTPFComponentIPv4RemoteAddress(net.ParseIP("192.168.0.0"), net.IPv4Mask(255, 255, 255, 0)),
+			ie.NewTFTPFComponentProtocolIdentifierNextHeader(17),
+		),
+	)
+	videoBearer := newBearerWithTFT(
+		t, 7, 0x33333333,
+		ie.NewTFTPacketFilter(
+			ie.TFTPFDownlinkOnly, 1, 5,
+			ie.NewTFTPFComponentSingleRemotePort(5004),
+		),
+	)
+
+	c := gtpv2.NewClassifier(defaultBearer, voiceBearer, videoBearer)
+
+	cases := []struct {
+		description string
+		pkt         []byte
+		dir         uint8
+		teid        uint32
+	}{
+		{
+			"MatchedByOne",
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0, ports(5060, 10000)),
+			gtpv2.DirectionDownlink,
+			0x22222222,
+		}, {
+			"MatchedByBoth/Precedence",
+			ipv4Packet("192.168.0.1", "10.0.0.1", 17, 0, ports(5004, 10000)),
+			gtpv2.DirectionDownlink,
+			0x33333333,
+		}, {
+			"MatchedByBoth/Uplink",
+			ipv4Packet("10.0.0.1", "192.168.0.1", 17, 0, ports(10000, 5004)),
+			gtpv2.DirectionUplink,
+			0x22222222,
+		}, {
+			"Fallback",
+			ipv4Packet("172.16.0.1", "10.0.0.1", 6, 0, ports(443, 10000)),
+			gtpv2.DirectionDownlink,
+			0x11111111,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			b, ok := c.Classify(tc.pkt, tc.dir)
+			if !ok {
+				t.Fatal("no Bearer found")
+			}
+			if got := b.OutgoingTEID(); got != tc.teid {
+				t.Errorf("got %#x, want %#x", got, tc.teid)
+			}
+		})
+	}
+
+	t.Run("NoFallback", func(t *testing.T) {
+		c := gtpv2.NewClassifier(voiceBearer)
+		if _, ok := c.DownlinkTEID(ipv4Packet("172.16.0.1", "10.0.0.1", 6, 0, ports(443, 10000))); ok {
+			t.Error("Classifier should not find Bearer")
+		}
+	})
+}
+
+func TestBearerSetTFT(t *testing.T) {
+	b := newBearerWithTFT(
+		t, 5, 0x11111111,
+		ie.NewTFTPacketFilter(ie.TFTPFBidirectional, 1, 10, ie.NewTFTPFComponentProtocolIdentifierNextHeader(17)),
+		ie.NewTFTPacketFilter(ie.TFTPFBidirectional, 2, 20, ie.NewTFTPFComponentProtocolIdentifierNextHeader(6)),
+	)
+
+	ids := func() []uint8 {
+		var ids []uint8
+		for _, f := range b.PacketFilters() {
+			ids = append(ids, f.Identifier)
+		}
+		return ids
+	}
+
+	if err := b.SetTFT(ie.NewTrafficFlowTemplate(
+		ie.TFTOpReplacePacketFiltersInExistingTFT,
+		[]*ie.TFTPacketFilter{
+			ie.NewTFTPacketFilter(ie.TFTPFBidirectional, 1, 30, ie.NewTFTPFComponentProtocolIdentifierNextHeader(1)),
+		},
+		nil, nil,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(); len(got) != 2 || got[0] != 2 || got[1] != 1 {
+		t.Errorf("unexpected filters after replaced: %v", got)
+	}
+
+	if err := b.SetTFT(ie.NewTrafficFlowTemplate(
+		ie.TFTOpDeletePacketFiltersFromExistingTFT, nil, []uint8{2}, nil,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("unexpected filters after deleted: %v", got)
+	}
+
+	if err := b.SetTFT(ie.NewTrafficFlowTemplate(ie.TFTOpDeleteExistingTFT, nil, nil, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(); len(got) != 0 {
+		t.Errorf("unexpected filters after TFT deleted: %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// transactionTimeout is how long the transactions are kept to wait for the
+// response or to detect the retransmission.
+const transactionTimeout = 30 * time.Second
+
+// transactionKey identifies a transaction with the peer and the sequence number,
+// as the Sequence Number is unique for each outstanding initial message from the
+// same endpoint.
+type transactionKey struct {
+	peer string
+	seq  uint32
+}
+
+type transaction struct {
+	msgType uint8
+	name    string
+	at      time.Time
+}
+
+// transactions keeps track of the initial messages sent and received in Conn
+// to measure the round-trip time and to detect the retransmissions.
+type transactions struct {
+	mu       sync.Mutex
+	sent     map[transactionKey]*transaction
+	received map[transactionKey]*transaction
+	pruned   time.Time
+}
+
+func newTransactions() *transactions {
+	return &transactions{
+		sent:     map[transactionKey]*transaction{},
+		received: map[transactionKey]*transaction{},
+		pruned:   time.Now(),
+	}
+}
+
+// add stores the initial message sent to or received from peer, and reports
+// whether it is the retransmission of the one stored before.
+func (t *transactions) add(m map[transactionKey]*transaction, peer net.Addr, seq uint32, msgType uint8, name string) bool {
+	now := time.Now()
+	key := transactionKey{peer.String(), seq}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pruneLocked(now)
+	if tx, ok := m[key]; ok && tx.msgType == msgType {
+		return true
+	}
+	m[key] = &transaction{msgType: msgType, name: name, at: now}
+	return false
+}
+
+// finish removes the initial message that the triggered message is for, and
+// returns it if found.
+func (t *transactions) finish(m map[transactionKey]*transaction, peer net.Addr, seq uint32, msgType uint8) (*transaction, bool) {
+	key := transactionKey{peer.String(), seq}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	initial, _ := message.InitialTypeOf(msgType)
+	tx, ok := m[key]
+	if !ok || tx.msgType != initial {
+		return nil, false
+	}
+	delete(m, key)
+	return tx, true
+}
+
+// pruneLocked removes the expired transactions, at most once in transactionTimeout.
+func (t *transactions) pruneLocked(now time.Time) {
+	if now.Sub(t.pruned) < transactionTimeout {
+		return
+	}
+	t.pruned = now
+
+	for _, m := range []map[transactionKey]*transaction{t.sent, t.received} {
+		for k, tx := range m {
+			if now.Sub(tx.at) >= transactionTimeout {
+				delete(m, k)
+			}
+		}
+	}
+}
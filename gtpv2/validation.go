@@ -0,0 +1,349 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// Interface is a set of the reference points on which the messages are exchanged,
+// which some of the presence requirements of IEs depend on.
+type Interface uint16
+
+// Interface definitions.
+const (
+	InterfaceS11 Interface = 1 << iota
+	InterfaceS4
+	InterfaceS5S8
+	InterfaceS2a
+	InterfaceS2b
+	InterfaceS3
+	InterfaceS10
+	InterfaceS16
+	InterfaceSm
+	InterfaceSn
+)
+
+// InterfaceOf returns the Interface that the control plane interface type used
+// in F-TEID belongs to, e.g., InterfaceS11 for IFTypeS11MMEGTPC.
+//
+// IFTypeS11S4SGWGTPC gives InterfaceS11|InterfaceS4 as it is shared by them, and
+// the types of user plane give 0.
+func InterfaceOf(ifType uint8) Interface {
+	switch ifType {
+	case IFTypeS11MMEGTPC:
+		return InterfaceS11
+	case IFTypeS11S4SGWGTPC:
+		return InterfaceS11 | InterfaceS4
+	case IFTypeS4SGSNGTPC:
+		return InterfaceS4
+	case IFTypeS5S8SGWGTPC, IFTypeS5S8PGWGTPC:
+		return InterfaceS5S8
+	case IFTypeS2aTWANGTPC, IFTypeS2aPGWGTPC:
+		return InterfaceS2a
+	case IFTypeS2bePDGGTPC, IFTypeS2bPGWGTPC:
+		return InterfaceS2b
+	case IFTypeS3MMEGTPC, IFTypeS3SGSNGTPC:
+		return InterfaceS3
+	case IFTypeS10MMEGTPC:
+		return InterfaceS10
+	case IFTypeS16SGSNGTPC:
+		return InterfaceS16
+	case IFTypeSmMBMSGWGTPC, IFTypeSmMMEGTPC:
+		return InterfaceSm
+	case IFTypeSnMBMSGWGTPC, IFTypeSnSGSNGTPC:
+		return InterfaceSn
+	default:
+		return 0
+	}
+}
+
+// Presence is the presence requirement of an IE in a message.
+type Presence uint8
+
+// Presence definitions.
+const (
+	PresenceOptional Presence = iota
+	PresenceMandatory
+	PresenceConditional
+)
+
+// IERule is the presence requirement of an IE identified by the type and instance,
+// defined in the tables of each message in TS 29.274.
+type IERule struct {
+	Type, Instance uint8
+	Presence       Presence
+
+	// Interfaces is the set of Interfaces on which the requirement applies, or 0
+	// if it applies on any. The requirement is ignored when the Interface of Conn
+	// is not known or not entirely contained in Interfaces, not to reject the
+	// message that might be valid on the others.
+	Interfaces Interface
+
+	// Condition reports whether the conditional IE is required in the message
+	// with ies. The IE is always required if nil.
+	Condition func(ies []*ie.IE) bool
+
+	// ChildIEs is the requirements of the IEs in the grouped IE, which are checked
+	// against each of the grouped IEs present in the message.
+	ChildIEs []IERule
+}
+
+// appliesTo reports whether the requirement is applied to the IEs in the message
+// exchanged on iface.
+func (r IERule) appliesTo(iface Interface, ies []*ie.IE) bool {
+	if r.Presence == PresenceOptional {
+		return false
+	}
+	if r.Interfaces != 0 && (iface == 0 || iface&^r.Interfaces != 0) {
+		return false
+	}
+	return r.Condition == nil || r.Condition(ies)
+}
+
+// IERules is the set of the IERules per message type.
+type IERules map[uint8][]IERule
+
+// Validate checks if the IEs in msg exchanged on iface fulfill the requirements
+// for its message type. The messages of the type without rules are always valid.
+//
+// The IEs with the instance that is not in the rules are not taken into account,
+// as TS 29.274 says that they should be ignored.
+//
+// The error returned is either *MissingIEError or *IncorrectIEError, which can
+// be turned into the Cause IE to reject the message with.
+func (r IERules) Validate(msg message.Message, iface Interface) error {
+	rules, ok := r[msg.MessageType()]
+	if !ok {
+		return nil
+	}
+
+	ies, err := messageIEs(msg)
+	if err != nil {
+		return err
+	}
+	return validateIEs(ies, rules, iface, 0)
+}
+
+// messageIEs returns all the IEs at the top level of msg in the order they are
+// serialized, regardless of the fields they are in.
+func messageIEs(msg message.Message) ([]*ie.IE, error) {
+	b, err := message.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	h, err := message.ParseHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	return ie.ParseMultiIEs(h.Payload)
+}
+
+// validateIEs checks ies against rules. parent is the type of the grouped IE that
+// contains ies, or 0 if they are at the top level.
+func validateIEs(ies []*ie.IE, rules []IERule, iface Interface, parent uint8) error {
+	for _, rule := range rules {
+		var found bool
+		for _, i := range ies {
+			if i == nil || i.Type != rule.Type || i.Instance() != rule.Instance {
+				continue
+			}
+			found = true
+
+			if rule.Presence == PresenceMandatory {
+				if check, ok := ieChecks[i.Type]; ok {
+					if err := check(i); err != nil {
+						return &IncorrectIEError{Type: i.Type, Instance: i.Instance(), Parent: parent, Err: err}
+					}
+				}
+			}
+			if len(rule.ChildIEs) > 0 {
+				if err := validateIEs(i.ChildIEs, rule.ChildIEs, iface, i.Type); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !found && rule.appliesTo(iface, ies) {
+			return &MissingIEError{Type: rule.Type, Instance: rule.Instance, Parent: parent, Presence: rule.Presence}
+		}
+	}
+	return nil
+}
+
+// ieChecks is the checks of the values in mandatory IEs, which detect the IEs
+// that are too short or have invalid values.
+var ieChecks = map[uint8]func(i *ie.IE) error{
+	ie.IMSI: func(i *ie.IE) error {
+		_, err := i.IMSI()
+		return err
+	},
+	ie.Cause: func(i *ie.IE) error {
+		_, err := i.Cause()
+		return err
+	},
+	ie.Recovery: func(i *ie.IE) error {
+		_, err := i.Recovery()
+		return err
+	},
+	ie.AccessPointName: func(i *ie.IE) error {
+		_, err := i.AccessPointName()
+		return err
+	},
+	ie.AggregateMaximumBitRate: func(i *ie.IE) error {
+		_, err := i.AggregateMaximumBitRateUp()
+		return err
+	},
+	ie.EPSBearerID: func(i *ie.IE) error {
+		_, err := i.EPSBearerID()
+		return err
+	},
+	ie.IPAddress: func(i *ie.IE) error {
+		_, err := i.IPAddress()
+		return err
+	},
+	ie.RATType: func(i *ie.IE) error {
+		_, err := i.RATType()
+		return err
+	},
+	ie.ServingNetwork: func(i *ie.IE) error {
+		_, err := i.MCC()
+		return err
+	},
+	ie.BearerQoS: func(i *ie.IE) error {
+		_, err := i.QCILabel()
+		return err
+	},
+	ie.FullyQualifiedTEID: func(i *ie.IE) error {
+		_, err := i.TEID()
+		return err
+	},
+}
+
+// causeAccepted reports whether the Cause in ies is an acceptance, which is the
+// condition of many IEs in the responses.
+func causeAccepted(ies []*ie.IE) bool {
+	for _, i := range ies {
+		if i != nil && i.Type == ie.Cause && i.Instance() == 0 {
+			cause, err := i.Cause()
+			// 16-63 are the values for acceptance in the responses.
+			return err == nil && cause >= CauseRequestAccepted && cause < CauseContextNotFound
+		}
+	}
+	return false
+}
+
+// DefaultIERules returns the IERules with the mandatory IEs and the conditional
+// IEs whose conditions can be verified only with the message and the interface,
+// for the messages supported by this package.
+//
+// It returns a new map every time, so that the rules can be modified for Conn
+// with WithIEValidation.
+func DefaultIERules() IERules {
+	cause := IERule{Type: ie.Cause, Presence: PresenceMandatory}
+	recovery := IERule{Type: ie.Recovery, Presence: PresenceMandatory}
+	ebi := IERule{Type: ie.EPSBearerID, Presence: PresenceMandatory}
+	bearerResults := []IERule{ebi, cause}
+
+	return IERules{
+		message.MsgTypeEchoRequest:  {recovery},
+		message.MsgTypeEchoResponse: {recovery},
+		message.MsgTypeCreateSessionRequest: {
+			{Type: ie.RATType, Presence: PresenceMandatory},
+			{Type: ie.FullyQualifiedTEID, Instance: 0, Presence: PresenceMandatory},
+			{Type: ie.FullyQualifiedTEID, Instance: 1, Presence: PresenceConditional, Interfaces: InterfaceS11 | InterfaceS4},
+			{Type: ie.AccessPointName, Presence: PresenceMandatory},
+			{Type: ie.ServingNetwork, Presence: PresenceConditional, Interfaces: InterfaceS11 | InterfaceS4 | InterfaceS5S8},
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: []IERule{ebi}},
+		},
+		message.MsgTypeCreateSessionResponse: {
+			cause,
+			{Type: ie.FullyQualifiedTEID, Instance: 0, Presence: PresenceConditional, Condition: causeAccepted},
+			{Type: ie.FullyQualifiedTEID, Instance: 1, Presence: PresenceConditional, Interfaces: InterfaceS11 | InterfaceS4, Condition: causeAccepted},
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceConditional, Condition: causeAccepted, ChildIEs: bearerResults},
+		},
+		message.MsgTypeModifyBearerResponse: {
+			cause,
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceConditional, Condition: causeAccepted, ChildIEs: bearerResults},
+		},
+		message.MsgTypeDeleteSessionResponse: {cause},
+		message.MsgTypeCreateBearerRequest: {
+			{Type: ie.EPSBearerID, Instance: 0, Presence: PresenceMandatory},
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: []IERule{
+				ebi,
+				{Type: ie.BearerTFT, Presence: PresenceMandatory},
+				{Type: ie.BearerQoS, Presence: PresenceMandatory},
+			}},
+		},
+		message.MsgTypeCreateBearerResponse: {
+			cause,
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: bearerResults},
+		},
+		message.MsgTypeUpdateBearerRequest: {
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: []IERule{ebi}},
+			{Type: ie.AggregateMaximumBitRate, Presence: PresenceMandatory},
+		},
+		message.MsgTypeUpdateBearerResponse: {
+			cause,
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: bearerResults},
+		},
+		message.MsgTypeDeleteBearerResponse: {cause},
+		message.MsgTypeModifyBearerCommand: {
+			{Type: ie.AggregateMaximumBitRate, Presence: PresenceMandatory},
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: []IERule{ebi}},
+		},
+		message.MsgTypeModifyBearerFailureIndication: {cause},
+		message.MsgTypeDeleteBearerCommand: {
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: []IERule{ebi}},
+		},
+		message.MsgTypeDeleteBearerFailureIndication: {
+			cause,
+			{Type: ie.BearerContext, Instance: 0, Presence: PresenceMandatory, ChildIEs: bearerResults},
+		},
+		message.MsgTypeReleaseAccessBearersResponse:              {cause},
+		message.MsgTypeModifyAccessBearersResponse:               {cause},
+		message.MsgTypeDownlinkDataNotificationAcknowledge:       {cause},
+		message.MsgTypeDownlinkDataNotificationFailureIndication: {cause},
+		message.MsgTypeDeletePDNConnectionSetResponse:            {cause},
+		message.MsgTypeUpdatePDNConnectionSetResponse:            {cause},
+		message.MsgTypeChangeNotificationResponse:                {cause},
+		message.MsgTypeContextResponse:                           {cause},
+		message.MsgTypeContextAcknowledge:                        {cause},
+		message.MsgTypeDetachAcknowledge:                         {cause},
+		message.MsgTypeSuspendAcknowledge:                        {cause},
+		message.MsgTypeResumeAcknowledge:                         {cause},
+		message.MsgTypePGWRestartNotificationAcknowledge:         {cause},
+		message.MsgTypePGWRestartNotification: {
+			{Type: ie.IPAddress, Instance: 0, Presence: PresenceMandatory},
+			{Type: ie.IPAddress, Instance: 1, Presence: PresenceMandatory},
+		},
+	}
+}
+
+var defaultIERules = DefaultIERules()
+
+// WithIEValidation lets Conn check the IEs in the incoming messages with rules,
+// or DefaultIERules if rules is nil, on the Interface of the local interface type
+// of Conn.
+//
+// The messages that fail the check are discarded. If WithErrorResponses is also
+// given, the Initial ones are rejected with RejectRequest, with the Cause and
+// Offending IE given by the error. It works only when the validation is enabled;
+// see EnableValidation.
+func WithIEValidation(rules IERules) ConnOption {
+	return func(c *Conn) {
+		if rules == nil {
+			rules = defaultIERules
+		}
+		c.ieRules = rules
+	}
+}
+
+// ValidateIEs checks if the IEs in msg exchanged on iface fulfill the requirements
+// in DefaultIERules. See IERules.Validate for details.
+func ValidateIEs(msg message.Message, iface Interface) error {
+	return defaultIERules.Validate(msg, iface)
+}
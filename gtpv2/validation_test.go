@@ -0,0 +1,215 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtpv2_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// causeOf returns the Cause value and the Offending IE given by the error
+// returned from validation, or zero values if err is nil.
+func causeOf(t *testing.T, err error) (uint8, *ie.IE) {
+	t.Helper()
+
+	if err == nil {
+		return 0, nil
+	}
+	var ce interface {
+		Cause() uint8
+		OffendingIE() *ie.IE
+	}
+	if !errors.As(err, &ce) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return ce.Cause(), ce.OffendingIE()
+}
+
+func TestValidateIEs(t *testing.T) {
+	csReq := func(ies ...*ie.IE) *message.CreateSessionRequest {
+		return message.NewCreateSessionRequest(0, 1, ies...)
+	}
+	var (
+		imsi      = ie.NewIMSI("123451234567890")
+		rat       = ie.NewRATType(gtpv2.RATTypeEUTRAN)
+		senderC   = ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 0x11111111, "10.0.0.1", "")
+		pgwC      = ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "10.0.0.2", "").WithInstance(1)
+		apn       = ie.NewAccessPointName("some.apn.example")
+		sn        = ie.NewServingNetwork("123", "45")
+		bearerCtx = ie.NewBearerContext(ie.NewEPSBearerID(5))
+	)
+
+	cases := []struct {
+		description string
+		msg         message.Message
+		iface       gtpv2.Interface
+		cause       uint8
+		offending   *ie.IE
+	}{
+		{
+			"CSReq/valid",
+			csReq(imsi, rat, senderC, pgwC, apn, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			0, nil,
+		}, {
+			"CSReq/mandatory missing",
+			csReq(imsi, rat, senderC, pgwC, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			gtpv2.CauseMandatoryIEMissing, ie.New(ie.AccessPointName, 0, nil),
+		}, {
+			"CSReq/mandatory in wrong instance",
+			csReq(imsi, rat, ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 1, "10.0.0.1", "").WithInstance(2), pgwC, apn, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			gtpv2.CauseMandatoryIEMissing, ie.New(ie.FullyQualifiedTEID, 0, nil),
+		}, {
+			"CSReq/mandatory incorrect",
+			csReq(imsi, ie.New(ie.RATType, 0, nil), ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 1, "10.0.0.1", ""), pgwC, apn, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			gtpv2.CauseMandatoryIEIncorrect, ie.New(ie.RATType, 0, nil),
+		}, {
+			"CSReq/mandatory missing in grouped",
+			csReq(imsi, rat, ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 1, "10.0.0.1", ""), pgwC, apn, sn, ie.NewBearerContext()),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			gtpv2.CauseMandatoryIEMissing, ie.New(ie.EPSBearerID, 0, nil),
+		}, {
+			"CSReq/conditional missing on S11",
+			csReq(imsi, rat, ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 1, "10.0.0.1", ""), apn, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC),
+			gtpv2.CauseConditionalIEMissing, ie.New(ie.FullyQualifiedTEID, 1, nil),
+		}, {
+			"CSReq/conditional not required on S5/S8",
+			csReq(imsi, rat, ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPC, 1, "10.0.0.1", ""), apn, sn, bearerCtx),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS5S8PGWGTPC),
+			0, nil,
+		}, {
+			"CSReq/conditional not required on unknown interface",
+			csReq(imsi, rat, ie.NewFullyQualifiedTEID(gtpv2.IFTypeS11MMEGTPC, 1, "10.0.0.1", ""), apn, bearerCtx),
+			0,
+			0, nil,
+		}, {
+			"CSRes/rejected",
+			message.NewCreateSessionResponse(1, 1, ie.NewCause(gtpv2.CauseContextNotFound, 0, 0, 0, nil)),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11MMEGTPC),
+			0, nil,
+		}, {
+			"CSRes/conditional missing when accepted",
+			message.NewCreateSessionResponse(1, 1, ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil)),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11MMEGTPC),
+			gtpv2.CauseConditionalIEMissing, ie.New(ie.FullyQualifiedTEID, 0, nil),
+		}, {
+			"MBRes/Cause missing in grouped",
+			message.NewModifyBearerResponse(1, 1,
+				ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+				ie.NewBearerContext(ie.NewEPSBearerID(5)),
+			),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11MMEGTPC),
+			gtpv2.CauseMandatoryIEMissing, ie.New(ie.Cause, 0, nil),
+		}, {
+			"Message without rules",
+			message.NewStopPagingIndication(1, 1),
+			gtpv2.InterfaceOf(gtpv2.IFTypeS11MMEGTPC),
+			0, nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			cause, offending := causeOf(t, gtpv2.ValidateIEs(c.msg, c.iface))
+			if cause != c.cause {
+				t.Errorf("unexpected Cause: want %d, got %d", c.cause, cause)
+			}
+			if diff := cmp.Diff(offending, c.offending, cmp.AllowUnexported(ie.IE{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestValidateIEsWithRules(t *testing.T) {
+	rules := gtpv2.DefaultIERules()
+	rules[message.MsgTypeDeleteSessionRequest] = []gtpv2.IERule{
+		{Type: ie.EPSBearerID, Presence: gtpv2.PresenceConditional, Interfaces: gtpv2.InterfaceS11},
+	}
+
+	msg := message.NewDeleteSessionRequest(1, 1)
+	if err := rules.Validate(msg, gtpv2.InterfaceOf(gtpv2.IFTypeS11S4SGWGTPC)); err != nil {
+		t.Errorf("unexpected error on S11/S4: %v", err)
+	}
+
+	var missing *gtpv2.MissingIEError
+	if err := rules.Validate(msg, gtpv2.InterfaceS11); !errors.As(err, &missing) {
+		t.Fatalf("unexpected error on S11: %v", err)
+	}
+	want := &gtpv2.MissingIEError{Type: ie.EPSBearerID, Presence: gtpv2.PresenceConditional}
+	if diff := cmp.Diff(missing, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestConnRejectsInvalidRequest(t *testing.T) {
+	cliAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 17}, Port: 2123}
+	srvAddr := &net.UDPAddr{IP: net.IP{127, 0, 0, 18}, Port: 2123}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srvConn := gtpv2.NewConn(srvAddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithIEValidation(nil), gtpv2.WithErrorResponses())
+	handled := make(chan struct{}, 1)
+	srvConn.AddHandler(message.MsgTypeCreateSessionRequest, func(c *gtpv2.Conn, senderAddr net.Addr, msg message.Message) error {
+		handled <- struct{}{}
+		return nil
+	})
+	if err := srvConn.Listen(ctx); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srvConn.Serve(ctx); err != nil {
+			t.Errorf("error on serving: %v", err)
+		}
+	}()
+
+	cliConn, err := gtpv2.Dial(ctx, cliAddr, srvAddr, gtpv2.IFTypeS11MMEGTPC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	causeCh := make(chan *ie.IE, 1)
+	cliConn.AddHandler(message.MsgTypeCreateSessionResponse, func(c *gtpv2.Conn, senderAddr net.Addr, msg message.Message) error {
+		causeCh <- msg.(*message.CreateSessionResponse).Cause
+		return nil
+	})
+
+	// Access Point Name is missing.
+	if _, _, err := cliConn.CreateSession(srvAddr,
+		ie.NewIMSI("123451234567890"),
+		ie.NewRATType(gtpv2.RATTypeEUTRAN),
+		cliConn.NewSenderFTEID("127.0.0.17", ""),
+		ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0, "127.0.0.19", "").WithInstance(1),
+		ie.NewServingNetwork("123", "45"),
+		ie.NewBearerContext(ie.NewEPSBearerID(5)),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-causeCh:
+		want := ie.NewCause(gtpv2.CauseMandatoryIEMissing, 0, 0, 0, ie.New(ie.AccessPointName, 0, nil))
+		if diff := cmp.Diff(got, want, cmp.AllowUnexported(ie.IE{})); diff != "" {
+			t.Error(diff)
+		}
+	case <-handled:
+		t.Fatal("invalid request is passed to the handler")
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the response")
+	}
+}
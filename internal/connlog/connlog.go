@@ -0,0 +1,112 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package connlog provides the pieces of the logging shared by the connections in
+// gtpv0, gtpv1, gtpv2 and gtpprime packages, so that their records look the same.
+package connlog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/wmnsk/go-gtp/logkey"
+)
+
+// StdLogger is the package-level *log.Logger that can be replaced at any time,
+// with the *slog.Logger that writes the records to it.
+type StdLogger struct {
+	mu      sync.Mutex
+	logger  *log.Logger
+	slogger *slog.Logger
+}
+
+// NewStdLogger creates a new StdLogger that writes to os.Stderr.
+func NewStdLogger() *StdLogger {
+	s := &StdLogger{logger: log.New(os.Stderr, "", log.LstdFlags)}
+	s.slogger = slog.New(slog.NewTextHandler(s, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// *log.Logger adds the time by itself.
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	return s
+}
+
+// Set replaces the *log.Logger with l, or the default one if l is nil.
+func (s *StdLogger) Set(l *log.Logger) {
+	if l == nil {
+		l = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger = l
+}
+
+// Discard lets the *log.Logger discard everything written.
+func (s *StdLogger) Discard() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger.SetOutput(io.Discard)
+}
+
+// Slogger returns the *slog.Logger that writes the records to the *log.Logger,
+// which is looked up at every write so that Set takes effect on the connections
+// created before.
+func (s *StdLogger) Slogger() *slog.Logger {
+	return s.slogger
+}
+
+// Write writes each record formatted by slog.TextHandler to the *log.Logger.
+func (s *StdLogger) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger.Print(string(bytes.TrimSuffix(p, []byte{'\n'})))
+	return len(p), nil
+}
+
+// New returns l with the common attributes of the connection of proto listening
+// on laddr. If l is nil, slog.Default() is used.
+func New(l *slog.Logger, proto string, laddr net.Addr) *slog.Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+
+	attrs := []any{slog.String(logkey.Proto, proto)}
+	if laddr != nil {
+		attrs = append(attrs, slog.String(logkey.LocalAddr, laddr.String()))
+	}
+	return l.With(attrs...)
+}
+
+// MsgAttrs returns the attributes that describe the message of msgType with seq
+// from or to peer, followed by attrs specific to the protocol.
+func MsgAttrs(peer net.Addr, msgType string, seq uint32, attrs ...any) []any {
+	var a []any
+	if peer != nil {
+		a = append(a, slog.String(logkey.Peer, peer.String()))
+	}
+	a = append(a,
+		slog.String(logkey.MsgType, msgType),
+		slog.Uint64(logkey.Sequence, uint64(seq)),
+	)
+	return append(a, attrs...)
+}
+
+// TEID formats teid in the same way in all the records.
+func TEID(teid uint32) string {
+	return fmt.Sprintf("0x%08x", teid)
+}
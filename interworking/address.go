@@ -0,0 +1,160 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// defaultIPv6PrefixLength is the IPv6 Prefix Length in PAA converted from End
+// User Address, which has no such field. It is always 64 in EPS (TS 23.401 5.3.1.2.2).
+const defaultIPv6PrefixLength = 64
+
+// ToPAA converts the End User Address IE into the PDN Address Allocation IE.
+//
+// The address that is not included in End User Address, which means it is requested
+// to be allocated dynamically, is set to all zeros.
+func ToPAA(eua *v1ie.IE) (*v2ie.IE, error) {
+	org, err := eua.PDPTypeOrganization()
+	if err != nil {
+		return nil, err
+	}
+	num, err := eua.PDPTypeNumber()
+	if err != nil {
+		return nil, err
+	}
+	addr := eua.Payload[2:]
+
+	var f *v2ie.PDNAddressAllocationFields
+	switch {
+	case org&0x0f == gtpv1.PDPTypeETSI&0x0f && num == gtpv1.PDPTypeNumberNonIP:
+		f = v2ie.NewPDNAddressAllocationFields(gtpv2.PDNTypeNonIP, nil, nil, 0)
+	case org&0x0f != gtpv1.PDPTypeIETF&0x0f:
+		return nil, fmt.Errorf("PDP Type Organization %#x: %w", org, ErrUnsupportedPDPType)
+	case num == gtpv1.PDPTypeNumberIPv4:
+		f = v2ie.NewPDNAddressAllocationFields(gtpv2.PDNTypeIPv4, ipOrZero(addr, net.IPv4len), nil, 0)
+	case num == gtpv1.PDPTypeNumberIPv6:
+		f = v2ie.NewPDNAddressAllocationFields(gtpv2.PDNTypeIPv6, nil, ipOrZero(addr, net.IPv6len), defaultIPv6PrefixLength)
+	case num == gtpv1.PDPTypeNumberIPv4v6:
+		// either of the addresses can be omitted (TS 29.060 7.7.27).
+		var v4, v6 []byte
+		switch {
+		case len(addr) >= net.IPv4len+net.IPv6len:
+			v4, v6 = addr[:net.IPv4len], addr[net.IPv4len:net.IPv4len+net.IPv6len]
+		case len(addr) >= net.IPv6len:
+			v6 = addr[:net.IPv6len]
+		case len(addr) >= net.IPv4len:
+			v4 = addr[:net.IPv4len]
+		}
+		f = v2ie.NewPDNAddressAllocationFields(
+			gtpv2.PDNTypeIPv4v6, ipOrZero(v4, net.IPv4len), ipOrZero(v6, net.IPv6len), defaultIPv6PrefixLength,
+		)
+	default:
+		return nil, fmt.Errorf("PDP Type Number %#x: %w", num, ErrUnsupportedPDPType)
+	}
+
+	b, err := f.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.New(v2ie.PDNAddressAllocation, 0, b), nil
+}
+
+// ToPDNType returns the PDN Type IE that corresponds to the PDP Type in the End
+// User Address IE.
+func ToPDNType(eua *v1ie.IE) (*v2ie.IE, error) {
+	paa, err := ToPAA(eua)
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewPDNType(paa.Payload[0] & 0x07), nil
+}
+
+// ToEndUserAddress converts the PDN Address Allocation IE into the End User Address IE.
+//
+// The address that is all zeros in PAA, which means it is requested to be allocated
+// dynamically, is omitted.
+func ToEndUserAddress(paa *v2ie.IE) (*v1ie.IE, error) {
+	if paa.Type != v2ie.PDNAddressAllocation {
+		return nil, &v2ie.InvalidTypeError{Type: paa.Type}
+	}
+	f, err := v2ie.ParsePDNAddressAllocationFields(paa.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f.PDNType {
+	case gtpv2.PDNTypeIPv4:
+		return v1ie.NewEndUserAddressIPv4(addrOrEmpty(f.IPv4Address)), nil
+	case gtpv2.PDNTypeIPv6:
+		return v1ie.NewEndUserAddressIPv6(addrOrEmpty(f.IPv6Address)), nil
+	case gtpv2.PDNTypeIPv4v6:
+		return v1ie.NewEndUserAddressIPv4v6(addrOrEmpty(f.IPv4Address), addrOrEmpty(f.IPv6Address)), nil
+	case gtpv2.PDNTypeNonIP:
+		return v1ie.NewEndUserAddressNonIP(), nil
+	default:
+		return nil, fmt.Errorf("PDN Type %d: %w", f.PDNType, ErrUnsupportedPDPType)
+	}
+}
+
+// ToFTEID converts the TEID and GSN Address IEs into the F-TEID IE with the
+// interface type given.
+func ToFTEID(ifType uint8, teid, addr *v1ie.IE) (*v2ie.IE, error) {
+	id, err := teid.TEID()
+	if err != nil {
+		return nil, err
+	}
+	ip, err := addr.IP()
+	if err != nil {
+		return nil, err
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return v2ie.NewFullyQualifiedTEIDNetIP(ifType, id, v4, nil), nil
+	}
+	return v2ie.NewFullyQualifiedTEIDNetIP(ifType, id, nil, ip), nil
+}
+
+// FromFTEID returns the TEID and the GSN Address in the F-TEID IE. IPv4 address
+// is preferred if F-TEID has both of IPv4 and IPv6 addresses, as GSN Address
+// can have only one of them.
+func FromFTEID(fteid *v2ie.IE) (teid uint32, addr *v1ie.IE, err error) {
+	f, err := fteid.FullyQualifiedTEID()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	switch {
+	case fteid.HasIPv4():
+		addr = v1ie.NewGSNAddressByIP(f.IPv4Address)
+	case fteid.HasIPv6():
+		addr = v1ie.NewGSNAddressByIP(f.IPv6Address)
+	default:
+		return 0, nil, v2ie.ErrIEValueNotFound
+	}
+	return f.TEIDGREKey, addr, nil
+}
+
+// ipOrZero returns the address in b, or the zero address if b is too short.
+func ipOrZero(b []byte, l int) net.IP {
+	if len(b) < l {
+		return make(net.IP, l)
+	}
+	return net.IP(b[:l])
+}
+
+// addrOrEmpty returns the address in string, or empty string if ip is unspecified.
+func addrOrEmpty(ip net.IP) string {
+	if ip == nil || ip.IsUnspecified() {
+		return ""
+	}
+	return ip.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/interworking"
+)
+
+func TestAddress(t *testing.T) {
+	cases := []struct {
+		description string
+		eua         *v1ie.IE
+		paa         *v2ie.IE
+	}{
+		{
+			"IPv4",
+			v1ie.NewEndUserAddressIPv4("10.0.0.1"),
+			v2ie.NewPDNAddressAllocation("10.0.0.1"),
+		}, {
+			"IPv4/dynamic",
+			v1ie.NewEndUserAddressIPv4(""),
+			v2ie.NewPDNAddressAllocation("0.0.0.0"),
+		}, {
+			"IPv6",
+			v1ie.NewEndUserAddressIPv6("2001::1"),
+			v2ie.NewPDNAddressAllocationIPv6("2001::1", 64),
+		}, {
+			"IPv4v6",
+			v1ie.NewEndUserAddressIPv4v6("10.0.0.1", "2001::1"),
+			v2ie.NewPDNAddressAllocationDual("10.0.0.1", "2001::1", 64),
+		}, {
+			"IPv4v6/IPv4 only",
+			v1ie.NewEndUserAddressIPv4v6("10.0.0.1", ""),
+			v2ie.NewPDNAddressAllocationDual("10.0.0.1", "::", 64),
+		}, {
+			"IPv4v6/IPv6 only",
+			v1ie.NewEndUserAddressIPv4v6("", "2001::1"),
+			v2ie.NewPDNAddressAllocationDual("0.0.0.0", "2001::1", 64),
+		}, {
+			"IPv4v6/dynamic",
+			v1ie.NewEndUserAddressIPv4v6("", ""),
+			v2ie.NewPDNAddressAllocationDual("0.0.0.0", "::", 64),
+		}, {
+			"Non-IP",
+			v1ie.NewEndUserAddressNonIP(),
+			v2ie.New(v2ie.PDNAddressAllocation, 0, []byte{gtpv2.PDNTypeNonIP}),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			paa, err := interworking.ToPAA(c.eua)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(paa.Payload, c.paa.Payload); diff != "" {
+				t.Error(diff)
+			}
+
+			eua, err := interworking.ToEndUserAddress(c.paa)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(eua.Payload, c.eua.Payload); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := interworking.ToPAA(v1ie.NewEndUserAddressPPP())
+		if !errors.Is(err, interworking.ErrUnsupportedPDPType) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestFTEID(t *testing.T) {
+	fteid, err := interworking.ToFTEID(gtpv2.IFTypeS5S8SGWGTPU, v1ie.NewTEIDDataI(0x11111111), v1ie.NewGSNAddress("10.0.0.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPU, 0x11111111, "10.0.0.1", "")
+	if diff := cmp.Diff(fteid.Payload, want.Payload); diff != "" {
+		t.Error(diff)
+	}
+
+	teid, addr, err := interworking.FromFTEID(
+		v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPU, 0x22222222, "10.0.0.2", "2001::2"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(teid, uint32(0x22222222)); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(addr.MustIPAddress(), "10.0.0.2"); diff != "" {
+		t.Error(diff)
+	}
+}
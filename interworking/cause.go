@@ -0,0 +1,134 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv2"
+)
+
+// v1ToV2Cause maps the GTPv1-C response causes to the GTPv2-C ones.
+var v1ToV2Cause = map[uint8]uint8{
+	gtpv1.ResCauseRequestAccepted:                                                 gtpv2.CauseRequestAccepted,
+	gtpv1.ResCauseNewPDPTypeDueToNetworkPreference:                                gtpv2.CauseNewPDNTypeDueToNetworkPreference,
+	gtpv1.ResCauseNewPDPTypeDueToSingleAddressBearerOnly:                          gtpv2.CauseNewPDNTypeDueToSingleAddressBearerOnly,
+	gtpv1.ResCauseNonExistent:                                                     gtpv2.CauseContextNotFound,
+	gtpv1.ResCauseInvalidMessageFormat:                                            gtpv2.CauseInvalidMessageFormat,
+	gtpv1.ResCauseIMSIIMEINotKnown:                                                gtpv2.CauseIMSIIMEINotKnown,
+	gtpv1.ResCauseMSIsNotGPRSResponding:                                           gtpv2.CauseUENotResponding,
+	gtpv1.ResCauseMSRefuses:                                                       gtpv2.CauseUERefuses,
+	gtpv1.ResCauseNoResourcesAvailable:                                            gtpv2.CauseNoResourcesAvailable,
+	gtpv1.ResCauseServiceNotSupported:                                             gtpv2.CauseServiceNotSupported,
+	gtpv1.ResCauseMandatoryIEIncorrect:                                            gtpv2.CauseMandatoryIEIncorrect,
+	gtpv1.ResCauseMandatoryIEMissing:                                              gtpv2.CauseMandatoryIEMissing,
+	gtpv1.ResCauseSystemFailure:                                                   gtpv2.CauseSystemFailure,
+	gtpv1.ResCauseRoamingRestriction:                                              gtpv2.CauseServiceDenied,
+	gtpv1.ResCausePTMSISignatureMismatch:                                          gtpv2.CausePTMSISignatureMismatch,
+	gtpv1.ResCauseAuthenticationFailure:                                           gtpv2.CauseUserAuthenticationFailed,
+	gtpv1.ResCauseUserAuthenticationFailed:                                        gtpv2.CauseUserAuthenticationFailed,
+	gtpv1.ResCauseContextNotFound:                                                 gtpv2.CauseContextNotFound,
+	gtpv1.ResCauseAllDynamicPDPAddressesAreOccupied:                               gtpv2.CauseAllDynamicAddressesAreOccupied,
+	gtpv1.ResCauseNoMemoryIsAvailable:                                             gtpv2.CauseNoMemoryAvailable,
+	gtpv1.ResCauseRelocationFailure:                                               gtpv2.CauseRelocationFailure,
+	gtpv1.ResCauseSemanticErrorInTheTFTOperation:                                  gtpv2.CauseSemanticErrorInTheTFTOperation,
+	gtpv1.ResCauseSyntacticErrorInTheTFTOperation:                                 gtpv2.CauseSyntacticErrorInTheTFTOperation,
+	gtpv1.ResCauseSemanticErrorsInPacketFilter:                                    gtpv2.CauseSemanticErrorsInPacketFilters,
+	gtpv1.ResCauseSyntacticErrorsInPacketFilter:                                   gtpv2.CauseSyntacticErrorsInPacketFilters,
+	gtpv1.ResCauseMissingOrUnknownAPN:                                             gtpv2.CauseMissingOrUnknownAPN,
+	gtpv1.ResCauseUnknownPDPAddressOrPDPType:                                      gtpv2.CausePreferredPDNTypeNotSupported,
+	gtpv1.ResCausePDPContextWithoutTFTAlreadyActivated:                            gtpv2.CauseUEContextWithoutTFTAlreadyActivated,
+	gtpv1.ResCauseAPNAccessDeniedNoSubscription:                                   gtpv2.CauseAPNAccessDeniedNoSubscription,
+	gtpv1.ResCauseAPNRestrictionTypeIncompatibilityWithCurrentlyActivePDPContexts: gtpv2.CauseAPNRestrictionTypeIncompatibleWithCurrentlyActivePDNConnection,
+	gtpv1.ResCauseCollisionWithNetworkInitiatedRequest:                            gtpv2.CauseCollisionWithNetworkInitiatedRequest,
+	gtpv1.ResCauseAPNCongestion:                                                   gtpv2.CauseAPNCongestion,
+	gtpv1.ResCauseBearerHandlingNotSupported:                                      gtpv2.CauseBearerHandlingNotSupported,
+	gtpv1.ResCauseTargetAccessRestrictedForTheSubscriber:                          gtpv2.CauseTargetAccessRestrictedForTheSubscriber,
+	gtpv1.ResCauseUEIsTemporarilyNotReachableDueToPowerSaving:                     gtpv2.CauseUEIsTemporarilyNotReachableDueToPowerSaving,
+	gtpv1.ResCauseRelocationFailureDueToNASMessageRedirection:                     gtpv2.CauseRelocationFailureDueToNASMessageRedirection,
+}
+
+// v2ToV1Cause maps the GTPv2-C causes to the GTPv1-C response ones.
+//
+// It is not just the inverse of v1ToV2Cause as some of the GTPv2-C causes have
+// more than one counterpart, and some have the counterpart only in this direction.
+var v2ToV1Cause = map[uint8]uint8{
+	gtpv2.CauseRequestAccepted:                                                gtpv1.ResCauseRequestAccepted,
+	gtpv2.CauseRequestAcceptedPartially:                                       gtpv1.ResCauseRequestAccepted,
+	gtpv2.CauseNewPDNTypeDueToNetworkPreference:                               gtpv1.ResCauseNewPDPTypeDueToNetworkPreference,
+	gtpv2.CauseNewPDNTypeDueToSingleAddressBearerOnly:                         gtpv1.ResCauseNewPDPTypeDueToSingleAddressBearerOnly,
+	gtpv2.CauseContextNotFound:                                                gtpv1.ResCauseNonExistent,
+	gtpv2.CauseInvalidMessageFormat:                                           gtpv1.ResCauseInvalidMessageFormat,
+	gtpv2.CauseInvalidLength:                                                  gtpv1.ResCauseInvalidMessageFormat,
+	gtpv2.CauseIMSIIMEINotKnown:                                               gtpv1.ResCauseIMSIIMEINotKnown,
+	gtpv2.CauseUENotResponding:                                                gtpv1.ResCauseMSIsNotGPRSResponding,
+	gtpv2.CauseUERefuses:                                                      gtpv1.ResCauseMSRefuses,
+	gtpv2.CauseNoResourcesAvailable:                                           gtpv1.ResCauseNoResourcesAvailable,
+	gtpv2.CauseServiceNotSupported:                                            gtpv1.ResCauseServiceNotSupported,
+	gtpv2.CauseMandatoryIEIncorrect:                                           gtpv1.ResCauseMandatoryIEIncorrect,
+	gtpv2.CauseMandatoryIEMissing:                                             gtpv1.ResCauseMandatoryIEMissing,
+	gtpv2.CauseConditionalIEMissing:                                           gtpv1.ResCauseMandatoryIEMissing,
+	gtpv2.CauseSystemFailure:                                                  gtpv1.ResCauseSystemFailure,
+	gtpv2.CauseServiceDenied:                                                  gtpv1.ResCauseRoamingRestriction,
+	gtpv2.CausePTMSISignatureMismatch:                                         gtpv1.ResCausePTMSISignatureMismatch,
+	gtpv2.CauseUserAuthenticationFailed:                                       gtpv1.ResCauseUserAuthenticationFailed,
+	gtpv2.CauseUENotAuthorisedByOCSOrExternalAAAServer:                        gtpv1.ResCauseUserAuthenticationFailed,
+	gtpv2.CauseAllDynamicAddressesAreOccupied:                                 gtpv1.ResCauseAllDynamicPDPAddressesAreOccupied,
+	gtpv2.CauseNoMemoryAvailable:                                              gtpv1.ResCauseNoMemoryIsAvailable,
+	gtpv2.CauseRelocationFailure:                                              gtpv1.ResCauseRelocationFailure,
+	gtpv2.CauseSemanticErrorInTheTFTOperation:                                 gtpv1.ResCauseSemanticErrorInTheTFTOperation,
+	gtpv2.CauseSyntacticErrorInTheTFTOperation:                                gtpv1.ResCauseSyntacticErrorInTheTFTOperation,
+	gtpv2.CauseSemanticErrorsInPacketFilters:                                  gtpv1.ResCauseSemanticErrorsInPacketFilter,
+	gtpv2.CauseSyntacticErrorsInPacketFilters:                                 gtpv1.ResCauseSyntacticErrorsInPacketFilter,
+	gtpv2.CauseMissingOrUnknownAPN:                                            gtpv1.ResCauseMissingOrUnknownAPN,
+	gtpv2.CausePreferredPDNTypeNotSupported:                                   gtpv1.ResCauseUnknownPDPAddressOrPDPType,
+	gtpv2.CauseUEContextWithoutTFTAlreadyActivated:                            gtpv1.ResCausePDPContextWithoutTFTAlreadyActivated,
+	gtpv2.CauseAPNAccessDeniedNoSubscription:                                  gtpv1.ResCauseAPNAccessDeniedNoSubscription,
+	gtpv2.CauseAPNRestrictionTypeIncompatibleWithCurrentlyActivePDNConnection: gtpv1.ResCauseAPNRestrictionTypeIncompatibilityWithCurrentlyActivePDPContexts,
+	gtpv2.CauseCollisionWithNetworkInitiatedRequest:                           gtpv1.ResCauseCollisionWithNetworkInitiatedRequest,
+	gtpv2.CauseAPNCongestion:                                                  gtpv1.ResCauseAPNCongestion,
+	gtpv2.CauseBearerHandlingNotSupported:                                     gtpv1.ResCauseBearerHandlingNotSupported,
+	gtpv2.CauseTargetAccessRestrictedForTheSubscriber:                         gtpv1.ResCauseTargetAccessRestrictedForTheSubscriber,
+	gtpv2.CauseUEIsTemporarilyNotReachableDueToPowerSaving:                    gtpv1.ResCauseUEIsTemporarilyNotReachableDueToPowerSaving,
+	gtpv2.CauseRelocationFailureDueToNASMessageRedirection:                    gtpv1.ResCauseRelocationFailureDueToNASMessageRedirection,
+}
+
+// ToV2Cause converts the GTPv1-C response cause into the GTPv2-C one.
+//
+// The acceptance causes without the counterpart are converted into "Request
+// accepted", and the rejection ones into "Request rejected (reason not specified)".
+func ToV2Cause(cause uint8) uint8 {
+	if c, ok := v1ToV2Cause[cause]; ok {
+		return c
+	}
+	if isV1Accepted(cause) {
+		return gtpv2.CauseRequestAccepted
+	}
+	return gtpv2.CauseRequestRejectedReasonNotSpecified
+}
+
+// ToV1Cause converts the GTPv2-C cause into the GTPv1-C response one.
+//
+// The acceptance causes without the counterpart are converted into "Request
+// accepted", and the rejection ones into "System failure".
+func ToV1Cause(cause uint8) uint8 {
+	if c, ok := v2ToV1Cause[cause]; ok {
+		return c
+	}
+	if isV2Accepted(cause) {
+		return gtpv1.ResCauseRequestAccepted
+	}
+	return gtpv1.ResCauseSystemFailure
+}
+
+// isV1Accepted reports whether the GTPv1-C response cause is the acceptance one.
+func isV1Accepted(cause uint8) bool {
+	return cause >= gtpv1.ResCauseRequestAccepted && cause < gtpv1.ResCauseNonExistent
+}
+
+// isV2Accepted reports whether the GTPv2-C cause is the acceptance one, which is
+// in the range of 16-63.
+func isV2Accepted(cause uint8) bool {
+	return cause >= gtpv2.CauseRequestAccepted && cause <= 63
+}
@@ -0,0 +1,55 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/interworking"
+)
+
+func TestCause(t *testing.T) {
+	t.Run("ToV2Cause", func(t *testing.T) {
+		cases := []struct {
+			description string
+			v1, want    uint8
+		}{
+			{"accepted", gtpv1.ResCauseRequestAccepted, gtpv2.CauseRequestAccepted},
+			{"mapped", gtpv1.ResCauseMissingOrUnknownAPN, gtpv2.CauseMissingOrUnknownAPN},
+			{"accepted without counterpart", gtpv1.ResCauseRequestAccepted + 10, gtpv2.CauseRequestAccepted},
+			{"rejected without counterpart", gtpv1.ResCauseGPRSConnectionSuspended, gtpv2.CauseRequestRejectedReasonNotSpecified},
+		}
+		for _, c := range cases {
+			t.Run(c.description, func(t *testing.T) {
+				if diff := cmp.Diff(interworking.ToV2Cause(c.v1), c.want); diff != "" {
+					t.Error(diff)
+				}
+			})
+		}
+	})
+
+	t.Run("ToV1Cause", func(t *testing.T) {
+		cases := []struct {
+			description string
+			v2, want    uint8
+		}{
+			{"accepted", gtpv2.CauseRequestAccepted, gtpv1.ResCauseRequestAccepted},
+			{"accepted partially", gtpv2.CauseRequestAcceptedPartially, gtpv1.ResCauseRequestAccepted},
+			{"mapped", gtpv2.CauseContextNotFound, gtpv1.ResCauseNonExistent},
+			{"rejected without counterpart", gtpv2.CauseRemotePeerNotResponding, gtpv1.ResCauseSystemFailure},
+		}
+		for _, c := range cases {
+			t.Run(c.description, func(t *testing.T) {
+				if diff := cmp.Diff(interworking.ToV1Cause(c.v2), c.want); diff != "" {
+					t.Error(diff)
+				}
+			})
+		}
+	})
+}
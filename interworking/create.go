@@ -0,0 +1,193 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// ToCreateSessionRequest converts the Create PDP Context Request into the Create
+// Session Request with the TEID and sequence number given.
+//
+// The F-TEIDs are made from the TEIDs and the addresses of the SGSN. The callers
+// that relay the message should replace them with the ones of their own.
+func (m *Mapper) ToCreateSessionRequest(req *v1msg.CreatePDPContextRequest, teid, seq uint32) (*v2msg.CreateSessionRequest, error) {
+	c := &converter{}
+
+	bc := v2ie.NewBearerContext(
+		withInstance(c.toV2("NSAPI", req.NSAPI, v2EBI), 0),
+		withInstance(c.fteid("TEIDDataI", gtpv2.IFTypeS5S8SGWGTPU, req.TEIDDataI, req.SGSNAddressForUserTraffic), 2),
+		withInstance(m.toBearerQoS(c, req.QoSProfile, req.EvolvedARPI), 0),
+	)
+
+	b := v2msg.BuildCreateSessionRequest(teid, seq).
+		WithIMSI(c.toV2("IMSI", req.IMSI, v2IMSI)).
+		WithMSISDN(c.toV2("MSISDN", req.MSISDN, v2MSISDN)).
+		WithMEI(c.toV2("IMEI", req.IMEI, v2MEI)).
+		WithServingNetwork(c.toV2("RAI", req.RAI, v2ServingNetwork)).
+		WithRATType(c.toV2("RATType", req.RATType, v2RATType)).
+		WithSenderFTEIDC(c.fteid("TEIDCPlane", gtpv2.IFTypeS5S8SGWGTPC, req.TEIDCPlane, req.SGSNAddressForSignalling)).
+		WithAPN(c.toV2("APN", req.APN, v2APN)).
+		WithSelectionMode(c.toV2("SelectionMode", req.SelectionMode, v2SelectionMode)).
+		WithPDNType(c.toV2("EndUserAddress", req.EndUserAddress, v2PDNType)).
+		WithPAA(c.toV2("EndUserAddress", req.EndUserAddress, v2PAA)).
+		WithAPNRestriction(c.toV2("APNRestriction", req.APNRestriction, v2APNRestriction)).
+		WithAMBR(c.toV2("APNAMBR", req.APNAMBR, v2AMBR)).
+		WithPCO(c.toV2("PCO", req.PCO, v2PCO)).
+		WithBearerContextsToBeCreated(bc).
+		WithRecovery(c.toV2("Recovery", req.Recovery, v2Recovery)).
+		WithUETimeZone(c.toV2("MSTimeZone", req.MSTimeZone, v2UETimeZone)).
+		WithChargingCharacteristics(c.toV2("ChargingCharacteristics", req.ChargingCharacteristics, v2ChargingCharacteristics))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToCreatePDPContextRequest converts the Create Session Request into the Create
+// PDP Context Request with the TEID and sequence number given.
+//
+// The TEIDs and the SGSN addresses are taken from the F-TEIDs of the SGW. The
+// callers that relay the message should replace them with the ones of their own.
+func (m *Mapper) ToCreatePDPContextRequest(req *v2msg.CreateSessionRequest, teid uint32, seq uint16) (*v1msg.CreatePDPContextRequest, error) {
+	c := &converter{}
+
+	bc := firstBearerContext(req.BearerContextsToBeCreated)
+	teidC, addrC := c.fromFTEID("SenderFTEIDC", req.SenderFTEIDC, v1ie.NewTEIDCPlane)
+	teidU, addrU := c.fromFTEID("S5/S8-U SGW F-TEID", findChild(bc, v2ie.FullyQualifiedTEID, 2), v1ie.NewTEIDDataI)
+	qos, earp := m.toQoSProfile(c, findChild(bc, v2ie.BearerQoS, 0), req.AMBR)
+
+	msg := v1msg.NewCreatePDPContextRequest(
+		teid, seq,
+		c.toV1("IMSI", req.IMSI, v1IMSI),
+		c.toV1("Recovery", req.Recovery, v1Recovery),
+		c.toV1("SelectionMode", req.SelectionMode, v1SelectionMode),
+		teidU, teidC,
+		c.toV1("EBI", findChild(bc, v2ie.EPSBearerID, 0), v1NSAPI),
+		c.toV1("ChargingCharacteristics", req.ChargingCharacteristics, v1ChargingCharacteristics),
+		c.toV1("PAA", req.PAA, v1EndUserAddress),
+		c.toV1("APN", req.APN, v1APN),
+		c.toV1("PCO", req.PCO, v1PCO),
+		c.toV1("MSISDN", req.MSISDN, v1MSISDN),
+		qos,
+		c.toV1("APNRestriction", req.APNRestriction, v1APNRestriction),
+		c.toV1("RATType", req.RATType, v1RATType),
+		c.toV1("UETimeZone", req.UETimeZone, v1MSTimeZone),
+		c.toV1("MEI", req.MEI, v1IMEISV),
+		earp,
+		c.toV1("AMBR", req.AMBR, v1AMBR),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	// the addresses are set directly, as NewCreatePDPContextRequest assigns
+	// GSN Address IEs to the fields in the order given.
+	msg.SGSNAddressForSignalling = addrC
+	msg.SGSNAddressForUserTraffic = addrU
+	msg.SetLength()
+	return msg, nil
+}
+
+// ToCreateSessionResponse converts the Create PDP Context Response into the Create
+// Session Response to req with the TEID given.
+//
+// The EPS Bearer ID in Bearer Context is taken from req, and the F-TEIDs are made
+// from the TEIDs and the addresses of the GGSN.
+func (m *Mapper) ToCreateSessionResponse(req *v2msg.CreateSessionRequest, res *v1msg.CreatePDPContextResponse, teid uint32) (*v2msg.CreateSessionResponse, error) {
+	c := &converter{}
+
+	var ebi *v2ie.IE
+	if e := findChild(firstBearerContext(req.BearerContextsToBeCreated), v2ie.EPSBearerID, 0); e != nil {
+		ebi = v2ie.NewEPSBearerID(e.MustEPSBearerID())
+	}
+	cause := c.toV2("Cause", res.Cause, v2Cause)
+
+	var bc *v2ie.IE
+	if cause != nil {
+		bc = v2ie.NewBearerContext(
+			ebi,
+			withInstance(v2ie.NewCause(cause.MustCause(), 0, 0, 0, nil), 0),
+			withInstance(c.fteid("TEIDDataI", gtpv2.IFTypeS5S8PGWGTPU, res.TEIDDataI, res.GGSNAddressForUserTraffic), 2),
+			withInstance(m.toBearerQoS(c, res.QoSProfile, res.EvolvedARPI), 0),
+			withInstance(c.toV2("ChargingID", res.ChargingID, v2ChargingID), 0),
+		)
+	}
+
+	b := v2msg.BuildCreateSessionResponse(teid, req.Sequence()).
+		WithCause(cause).
+		WithSenderFTEIDC(c.fteid("TEIDCPlane", gtpv2.IFTypeS5S8PGWGTPC, res.TEIDCPlane, res.GGSNAddressForCPlane)).
+		WithPAA(c.toV2("EndUserAddress", res.EndUserAddress, v2PAA)).
+		WithAPNRestriction(c.toV2("APNRestriction", res.APNRestriction, v2APNRestriction)).
+		WithAMBR(c.toV2("APNAMBR", res.APNAMBR, v2AMBR)).
+		WithPCO(c.toV2("PCO", res.PCO, v2PCO)).
+		WithBearerContextsCreated(bc).
+		WithRecovery(c.toV2("Recovery", res.Recovery, v2Recovery))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToCreatePDPContextResponse converts the Create Session Response into the Create
+// PDP Context Response to req with the TEID given.
+//
+// The TEIDs and the GGSN addresses are taken from the F-TEIDs of the PGW. The QoS
+// Profile in req is used if the Bearer QoS is not included in res that is accepted.
+func (m *Mapper) ToCreatePDPContextResponse(req *v1msg.CreatePDPContextRequest, res *v2msg.CreateSessionResponse, teid uint32) (*v1msg.CreatePDPContextResponse, error) {
+	c := &converter{}
+
+	bc := firstBearerContext(res.BearerContextsCreated)
+	teidC, addrC := c.fromFTEID("SenderFTEIDC", res.SenderFTEIDC, v1ie.NewTEIDCPlane)
+	teidU, addrU := c.fromFTEID("S5/S8-U PGW F-TEID", findChild(bc, v2ie.FullyQualifiedTEID, 2), v1ie.NewTEIDDataI)
+	qos, earp := m.toQoSProfile(c, findChild(bc, v2ie.BearerQoS, 0), res.AMBR)
+	if qos == nil && res.Cause != nil && isV2Accepted(res.Cause.MustCause()) {
+		qos = req.QoSProfile
+	}
+
+	msg := v1msg.NewCreatePDPContextResponse(
+		teid, req.Sequence(),
+		c.toV1("Cause", res.Cause, v1Cause),
+		c.toV1("Recovery", res.Recovery, v1Recovery),
+		teidU, teidC,
+		c.toV1("ChargingID", findChild(bc, v2ie.ChargingID, 0), v1ChargingID),
+		c.toV1("PAA", res.PAA, v1EndUserAddress),
+		c.toV1("PCO", res.PCO, v1PCO),
+		qos,
+		c.toV1("APNRestriction", res.APNRestriction, v1APNRestriction),
+		earp,
+		c.toV1("AMBR", res.AMBR, v1AMBR),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	msg.GGSNAddressForCPlane = addrC
+	msg.GGSNAddressForUserTraffic = addrU
+	msg.SetLength()
+	return msg, nil
+}
+
+// toBearerQoS converts the QoS Profile into the Bearer QoS with c.
+func (m *Mapper) toBearerQoS(c *converter, qos, earp *v1ie.IE) *v2ie.IE {
+	return c.toV2("QoSProfile", qos, func(i *v1ie.IE) (*v2ie.IE, error) {
+		return m.ToBearerQoS(i, earp)
+	})
+}
+
+// toQoSProfile converts the Bearer QoS into the QoS Profile and the Evolved ARP I
+// with c.
+func (m *Mapper) toQoSProfile(c *converter, qos, ambr *v2ie.IE) (profile, earp *v1ie.IE) {
+	profile = c.toV1("BearerQoS", qos, func(i *v2ie.IE) (*v1ie.IE, error) {
+		var err error
+		profile, earp, err = m.ToQoSProfile(i, ambr)
+		return profile, err
+	})
+	return profile, earp
+}
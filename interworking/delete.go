@@ -0,0 +1,80 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// ToDeleteSessionRequest converts the Delete PDP Context Request into the Delete
+// Session Request with the TEID and sequence number given.
+//
+// The NSAPI is converted into the Linked EPS Bearer ID, as the PDP Context that
+// corresponds to the default bearer deletes the whole PDN connection.
+func (m *Mapper) ToDeleteSessionRequest(req *v1msg.DeletePDPContextRequest, teid, seq uint32) (*v2msg.DeleteSessionRequest, error) {
+	c := &converter{}
+
+	b := v2msg.BuildDeleteSessionRequest(teid, seq).
+		WithLinkedEBI(c.toV2("NSAPI", req.NSAPI, v2EBI)).
+		WithPCO(c.toV2("PCO", req.PCO, v2PCO)).
+		WithUETimeZone(c.toV2("MSTimeZone", req.MSTimeZone, v2UETimeZone))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToDeletePDPContextRequest converts the Delete Session Request into the Delete
+// PDP Context Request with the TEID and sequence number given.
+//
+// The Teardown Ind is always set, as all the PDP Contexts that share the PDP
+// address are deleted with Delete Session Request.
+func (m *Mapper) ToDeletePDPContextRequest(req *v2msg.DeleteSessionRequest, teid uint32, seq uint16) (*v1msg.DeletePDPContextRequest, error) {
+	c := &converter{}
+
+	msg := v1msg.NewDeletePDPContextRequest(
+		teid, seq,
+		v1ie.NewTeardownInd(true),
+		c.toV1("LinkedEBI", req.LinkedEBI, v1NSAPI),
+		c.toV1("PCO", req.PCO, v1PCO),
+		c.toV1("UETimeZone", req.UETimeZone, v1MSTimeZone),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return msg, nil
+}
+
+// ToDeleteSessionResponse converts the Delete PDP Context Response into the Delete
+// Session Response to req with the TEID given.
+func (m *Mapper) ToDeleteSessionResponse(req *v2msg.DeleteSessionRequest, res *v1msg.DeletePDPContextResponse, teid uint32) (*v2msg.DeleteSessionResponse, error) {
+	c := &converter{}
+
+	b := v2msg.BuildDeleteSessionResponse(teid, req.Sequence()).
+		WithCause(c.toV2("Cause", res.Cause, v2Cause)).
+		WithPCO(c.toV2("PCO", res.PCO, v2PCO))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToDeletePDPContextResponse converts the Delete Session Response into the Delete
+// PDP Context Response to req with the TEID given.
+func (m *Mapper) ToDeletePDPContextResponse(req *v1msg.DeletePDPContextRequest, res *v2msg.DeleteSessionResponse, teid uint32) (*v1msg.DeletePDPContextResponse, error) {
+	c := &converter{}
+
+	msg := v1msg.NewDeletePDPContextResponse(
+		teid, req.Sequence(),
+		c.toV1("Cause", res.Cause, v1Cause),
+		c.toV1("PCO", res.PCO, v1PCO),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return msg, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package interworking provides the helpers to convert the GTPv1-C messages on
+// Gn/Gp into the GTPv2-C messages on S5/S8 and vice versa, for the interworking
+// between the Gn/Gp SGSNs and GGSNs and the EPS described in TS 23.401 Annex D.
+//
+// The PDP Context procedures are mapped to the session procedures as below:
+//
+//	Create PDP Context Request/Response <-> Create Session Request/Response
+//	Update PDP Context Request/Response <-> Modify Bearer Request/Response
+//	Delete PDP Context Request/Response <-> Delete Session Request/Response
+//
+// Only the IEs that have their counterparts in the other version are converted.
+// The others, e.g., the ones that the node keeps as the state of the session,
+// should be set to the fields of the message returned by the callers.
+package interworking
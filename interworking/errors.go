@@ -0,0 +1,31 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnsupportedPDPType indicates the PDP Type or PDN Type cannot be converted.
+	ErrUnsupportedPDPType = errors.New("unsupported PDP/PDN type")
+)
+
+// ConversionError indicates an IE could not be converted.
+type ConversionError struct {
+	IE  string
+	Err error
+}
+
+// Error returns violating IE name and the error.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("failed to convert %s: %v", e.IE, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,386 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// converter keeps the first error that occurred while converting the IEs of
+// a message, so that the conversions can be chained without checking each error.
+type converter struct {
+	err error
+}
+
+// toV2 converts the GTPv1 IE i into GTPv2 with f. nil is returned without calling
+// f if i is nil or any error has occurred.
+func (c *converter) toV2(name string, i *v1ie.IE, f func(*v1ie.IE) (*v2ie.IE, error)) *v2ie.IE {
+	if i == nil || c.err != nil {
+		return nil
+	}
+	v, err := f(i)
+	if err != nil {
+		c.err = &ConversionError{IE: name, Err: err}
+		return nil
+	}
+	return v
+}
+
+// toV1 converts the GTPv2 IE i into GTPv1 with f. nil is returned without calling
+// f if i is nil or any error has occurred.
+func (c *converter) toV1(name string, i *v2ie.IE, f func(*v2ie.IE) (*v1ie.IE, error)) *v1ie.IE {
+	if i == nil || c.err != nil {
+		return nil
+	}
+	v, err := f(i)
+	if err != nil {
+		c.err = &ConversionError{IE: name, Err: err}
+		return nil
+	}
+	return v
+}
+
+// fail records err for the IE name if no error has occurred yet.
+func (c *converter) fail(name string, err error) {
+	if err != nil && c.err == nil {
+		c.err = &ConversionError{IE: name, Err: err}
+	}
+}
+
+// fteid converts the TEID and GSN Address IEs into the F-TEID IE. nil is returned
+// if any of them is nil.
+func (c *converter) fteid(name string, ifType uint8, teid, addr *v1ie.IE) *v2ie.IE {
+	if teid == nil || addr == nil || c.err != nil {
+		return nil
+	}
+	v, err := ToFTEID(ifType, teid, addr)
+	c.fail(name, err)
+	return v
+}
+
+// fromFTEID converts the F-TEID IE into the TEID IE made with newTEID and the GSN
+// Address IE. nil is returned if fteid is nil.
+func (c *converter) fromFTEID(name string, fteid *v2ie.IE, newTEID func(uint32) *v1ie.IE) (teid, addr *v1ie.IE) {
+	if fteid == nil || c.err != nil {
+		return nil, nil
+	}
+	id, addr, err := FromFTEID(fteid)
+	if err != nil {
+		c.fail(name, err)
+		return nil, nil
+	}
+	return newTEID(id), addr
+}
+
+// withInstance sets the instance to i if it is not nil. This is needed for the IEs
+// in the grouped IEs, as the builders set the instance only to the top-level ones.
+func withInstance(i *v2ie.IE, instance uint8) *v2ie.IE {
+	if i == nil {
+		return nil
+	}
+	return i.WithInstance(instance)
+}
+
+// findChild returns the child IE of the grouped IE with the type and instance given.
+func findChild(grouped *v2ie.IE, typ, instance uint8) *v2ie.IE {
+	if grouped == nil {
+		return nil
+	}
+	for _, i := range grouped.ChildIEs {
+		if i.Type == typ && i.Instance() == instance {
+			return i
+		}
+	}
+	return nil
+}
+
+// firstBearerContext returns the first Bearer Context IE, which is the one of the
+// default bearer in the messages converted.
+func firstBearerContext(bcs []*v2ie.IE) *v2ie.IE {
+	if len(bcs) == 0 {
+		return nil
+	}
+	return bcs[0]
+}
+
+func v2Cause(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.Cause()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewCause(ToV2Cause(v), 0, 0, 0, nil), nil
+}
+
+func v1Cause(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.Cause()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewCause(ToV1Cause(v)), nil
+}
+
+func v2IMSI(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.IMSI()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewIMSI(v), nil
+}
+
+func v1IMSI(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.IMSI()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewIMSI(v), nil
+}
+
+func v2MSISDN(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.MSISDN()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewMSISDN(v), nil
+}
+
+func v1MSISDN(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.MSISDN()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewMSISDN(v), nil
+}
+
+func v2MEI(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.IMEISV()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewMobileEquipmentIdentity(v), nil
+}
+
+func v1IMEISV(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.MobileEquipmentIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewIMEISV(v), nil
+}
+
+// the values of RAT Type are the same in GTPv1 and GTPv2.
+func v2RATType(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.RATType()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewRATType(v), nil
+}
+
+func v1RATType(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.RATType()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewRATType(v), nil
+}
+
+// the Selection Mode in GTPv1 has the spare bits set to 1.
+func v2SelectionMode(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.SelectionMode()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewSelectionMode(v & 0x03), nil
+}
+
+func v1SelectionMode(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.SelectionMode()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewSelectionMode(0xf0 | v&0x03), nil
+}
+
+func v2APN(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.AccessPointName()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewAccessPointName(v), nil
+}
+
+func v1APN(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.AccessPointName()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewAccessPointName(v), nil
+}
+
+func v2APNRestriction(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.APNRestriction()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewAPNRestriction(v), nil
+}
+
+func v1APNRestriction(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.APNRestriction()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewAPNRestriction(v), nil
+}
+
+func v2AMBR(i *v1ie.IE) (*v2ie.IE, error) {
+	up, err := i.AMBRForUplink()
+	if err != nil {
+		return nil, err
+	}
+	down, err := i.AMBRForDownlink()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewAggregateMaximumBitRate(up, down), nil
+}
+
+func v1AMBR(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.AggregateMaximumBitRate()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewAggregateMaximumBitRate(v.APNAMBRForUplink, v.APNAMBRForDownlink), nil
+}
+
+// the contents of PCO are the ones defined in TS 24.008 in both GTPv1 and GTPv2.
+func v2PCO(i *v1ie.IE) (*v2ie.IE, error) {
+	if i.Type != v1ie.ProtocolConfigurationOptions {
+		return nil, &v1ie.InvalidTypeError{Type: i.Type}
+	}
+	return v2ie.New(v2ie.ProtocolConfigurationOptions, 0, i.Payload), nil
+}
+
+func v1PCO(i *v2ie.IE) (*v1ie.IE, error) {
+	if i.Type != v2ie.ProtocolConfigurationOptions {
+		return nil, &v2ie.InvalidTypeError{Type: i.Type}
+	}
+	return v1ie.New(v1ie.ProtocolConfigurationOptions, i.Payload), nil
+}
+
+func v2Recovery(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.Recovery()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewRecovery(v), nil
+}
+
+func v1Recovery(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.Recovery()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewRecovery(v), nil
+}
+
+func v2ChargingCharacteristics(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.ChargingCharacteristics()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewChargingCharacteristics(v), nil
+}
+
+func v1ChargingCharacteristics(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.ChargingCharacteristics()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewChargingCharacteristics(v), nil
+}
+
+func v2ChargingID(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.ChargingID()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewChargingID(v), nil
+}
+
+func v1ChargingID(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.ChargingID()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewChargingID(v), nil
+}
+
+func v2UETimeZone(i *v1ie.IE) (*v2ie.IE, error) {
+	tz, err := i.TimeZone()
+	if err != nil {
+		return nil, err
+	}
+	ds, err := i.DaylightSaving()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewUETimeZone(tz, ds), nil
+}
+
+func v1MSTimeZone(i *v2ie.IE) (*v1ie.IE, error) {
+	tz, err := i.TimeZone()
+	if err != nil {
+		return nil, err
+	}
+	ds, err := i.DaylightSaving()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewMSTimeZone(tz, ds), nil
+}
+
+// Serving Network is the PLMN in RAI, while RAI cannot be made from Serving Network.
+func v2ServingNetwork(i *v1ie.IE) (*v2ie.IE, error) {
+	mcc, err := i.MCC()
+	if err != nil {
+		return nil, err
+	}
+	mnc, err := i.MNC()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewServingNetwork(mcc, mnc), nil
+}
+
+// NSAPI and EBI share the same value space (TS 23.401 5.2.1).
+func v2EBI(i *v1ie.IE) (*v2ie.IE, error) {
+	v, err := i.NSAPI()
+	if err != nil {
+		return nil, err
+	}
+	return v2ie.NewEPSBearerID(v), nil
+}
+
+func v1NSAPI(i *v2ie.IE) (*v1ie.IE, error) {
+	v, err := i.EPSBearerID()
+	if err != nil {
+		return nil, err
+	}
+	return v1ie.NewNSAPI(v), nil
+}
+
+func v1EndUserAddress(i *v2ie.IE) (*v1ie.IE, error) {
+	return ToEndUserAddress(i)
+}
+
+func v2PAA(i *v1ie.IE) (*v2ie.IE, error) {
+	return ToPAA(i)
+}
+
+func v2PDNType(i *v1ie.IE) (*v2ie.IE, error) {
+	return ToPDNType(i)
+}
@@ -0,0 +1,353 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/wmnsk/go-gtp/gtpv1"
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/interworking"
+)
+
+var (
+	v1PCO = v1ie.NewProtocolConfigurationOptions(
+		0, v1ie.NewConfigurationProtocolOption(gtpv1.ProtoIDIPCP, []byte{0xde, 0xad, 0xbe, 0xef}),
+	)
+	v2PCO = v2ie.New(v2ie.ProtocolConfigurationOptions, 0, v1PCO.Payload)
+
+	v1QoS = v1ie.NewQoSProfileFromFields(&v1ie.QoSProfileFields{
+		AllocationRetentionPriority: 2,
+		TrafficClass:                v1ie.TrafficClassInteractive,
+		TrafficHandlingPriority:     1,
+		MaximumBitRateForUplink:     1024,
+		MaximumBitRateForDownlink:   2048,
+	})
+	v2QoS = v2ie.NewBearerQoS(0, 10, 0, 6, 1024, 2048, 0, 0)
+)
+
+// assertSame compares the serialized messages, as the IEs in the messages keep
+// the unexported states that differ depending on how they are made.
+func assertSame(t *testing.T, got, want interface{ Marshal() ([]byte, error) }) {
+	t.Helper()
+
+	g, err := got.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := want.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(g, w); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &interworking.Mapper{}
+
+	v1Req := v1msg.NewCreatePDPContextRequest(
+		0, 1,
+		v1ie.NewIMSI("123451234567890"),
+		v1ie.NewRouteingAreaIdentity("123", "45", 0x1111, 0x22),
+		v1ie.NewRecovery(1),
+		v1ie.NewSelectionMode(gtpv1.SelectionModeMSorNetworkProvidedAPNSubscribedVerified),
+		v1ie.NewTEIDDataI(0x11111111),
+		v1ie.NewTEIDCPlane(0x22222222),
+		v1ie.NewNSAPI(5),
+		v1ie.NewChargingCharacteristics(0x0800),
+		v1ie.NewEndUserAddressIPv4(""),
+		v1ie.NewAccessPointName("some.apn.example"),
+		v1PCO,
+		v1ie.NewGSNAddress("10.0.0.1"),
+		v1ie.NewGSNAddress("10.0.0.2"),
+		v1ie.NewMSISDN("818012345678"),
+		v1QoS,
+		v1ie.NewRATType(gtpv1.RatTypeUTRAN),
+		v1ie.NewMSTimeZone(9*time.Hour, 0),
+		v1ie.NewIMEISV("123456789012345"),
+		v1ie.NewAggregateMaximumBitRate(1024, 2048),
+	)
+
+	v2Req, err := m.ToCreateSessionRequest(v1Req, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Req, v2msg.NewCreateSessionRequest(
+		0, 1,
+		v2ie.NewIMSI("123451234567890"),
+		v2ie.NewMSISDN("818012345678"),
+		v2ie.NewMobileEquipmentIdentity("123456789012345"),
+		v2ie.NewServingNetwork("123", "45"),
+		v2ie.NewRATType(gtpv2.RATTypeUTRAN),
+		v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPC, 0x22222222, "10.0.0.1", ""),
+		v2ie.NewAccessPointName("some.apn.example"),
+		v2ie.NewSelectionMode(gtpv2.SelectionModeMSorNetworkProvidedAPNSubscribedVerified),
+		v2ie.NewPDNType(gtpv2.PDNTypeIPv4),
+		v2ie.NewPDNAddressAllocation("0.0.0.0"),
+		v2ie.NewAggregateMaximumBitRate(1024, 2048),
+		v2PCO,
+		v2ie.NewBearerContext(
+			v2ie.NewEPSBearerID(5),
+			v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPU, 0x11111111, "10.0.0.2", "").WithInstance(2),
+			v2QoS,
+		),
+		v2ie.NewRecovery(1),
+		v2ie.NewUETimeZone(9*time.Hour, 0),
+		v2ie.NewChargingCharacteristics(0x0800),
+	))
+
+	t.Run("ToCreatePDPContextRequest", func(t *testing.T) {
+		got, err := m.ToCreatePDPContextRequest(v2Req, 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qos, earp, err := m.ToQoSProfile(v2QoS, v2Req.AMBR)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// RAI is not restored as Serving Network has only the PLMN in it.
+		want := v1msg.NewCreatePDPContextRequest(
+			0, 1,
+			v1ie.NewIMSI("123451234567890"),
+			v1ie.NewRecovery(1),
+			v1ie.NewSelectionMode(gtpv1.SelectionModeMSorNetworkProvidedAPNSubscribedVerified),
+			v1ie.NewTEIDDataI(0x11111111),
+			v1ie.NewTEIDCPlane(0x22222222),
+			v1ie.NewNSAPI(5),
+			v1ie.NewChargingCharacteristics(0x0800),
+			v1ie.NewEndUserAddressIPv4(""),
+			v1ie.NewAccessPointName("some.apn.example"),
+			v1PCO,
+			v1ie.NewGSNAddress("10.0.0.1"),
+			v1ie.NewGSNAddress("10.0.0.2"),
+			v1ie.NewMSISDN("818012345678"),
+			qos,
+			v1ie.NewRATType(gtpv1.RatTypeUTRAN),
+			v1ie.NewMSTimeZone(9*time.Hour, 0),
+			v1ie.NewIMEISV("123456789012345"),
+			earp,
+			v1ie.NewAggregateMaximumBitRate(1024, 2048),
+		)
+		assertSame(t, got, want)
+	})
+
+	v1Res := v1msg.NewCreatePDPContextResponse(
+		0x22222222, 1,
+		v1ie.NewCause(gtpv1.ResCauseRequestAccepted),
+		v1ie.NewRecovery(2),
+		v1ie.NewTEIDDataI(0x33333333),
+		v1ie.NewTEIDCPlane(0x44444444),
+		v1ie.NewChargingID(0xffffffff),
+		v1ie.NewEndUserAddressIPv4("10.10.10.10"),
+		v1PCO,
+		v1ie.NewGSNAddress("10.0.0.3"),
+		v1ie.NewGSNAddress("10.0.0.4"),
+		v1QoS,
+		v1ie.NewAggregateMaximumBitRate(1024, 2048),
+	)
+
+	v2Res, err := m.ToCreateSessionResponse(v2Req, v1Res, 0x22222222)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Res, v2msg.NewCreateSessionResponse(
+		0x22222222, 1,
+		v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+		v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPC, 0x44444444, "10.0.0.3", ""),
+		v2ie.NewPDNAddressAllocation("10.10.10.10"),
+		v2ie.NewAggregateMaximumBitRate(1024, 2048),
+		v2PCO,
+		v2ie.NewBearerContext(
+			v2ie.NewEPSBearerID(5),
+			v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+			v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8PGWGTPU, 0x33333333, "10.0.0.4", "").WithInstance(2),
+			v2QoS,
+			v2ie.NewChargingID(0xffffffff),
+		),
+		v2ie.NewRecovery(2),
+	))
+
+	t.Run("ToCreatePDPContextResponse", func(t *testing.T) {
+		got, err := m.ToCreatePDPContextResponse(v1Req, v2Res, 0x22222222)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qos, earp, err := m.ToQoSProfile(v2QoS, v2Res.AMBR)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := v1msg.NewCreatePDPContextResponse(
+			0x22222222, 1,
+			v1ie.NewCause(gtpv1.ResCauseRequestAccepted),
+			v1ie.NewRecovery(2),
+			v1ie.NewTEIDDataI(0x33333333),
+			v1ie.NewTEIDCPlane(0x44444444),
+			v1ie.NewChargingID(0xffffffff),
+			v1ie.NewEndUserAddressIPv4("10.10.10.10"),
+			v1PCO,
+			v1ie.NewGSNAddress("10.0.0.3"),
+			v1ie.NewGSNAddress("10.0.0.4"),
+			qos,
+			earp,
+			v1ie.NewAggregateMaximumBitRate(1024, 2048),
+		)
+		assertSame(t, got, want)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	m := &interworking.Mapper{}
+
+	v1Req := v1msg.NewUpdatePDPContextRequest(
+		0x22222222, 2,
+		v1ie.NewIMSI("123451234567890"),
+		v1ie.NewTEIDDataI(0x55555555),
+		v1ie.NewTEIDCPlane(0x66666666),
+		v1ie.NewNSAPI(5),
+		v1ie.NewGSNAddress("10.0.0.5"),
+		v1ie.NewGSNAddress("10.0.0.6"),
+		v1QoS,
+		v1ie.NewRATType(gtpv1.RatTypeUTRAN),
+	)
+
+	v2Req, err := m.ToModifyBearerRequest(v1Req, 0x22222222, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Req, v2msg.NewModifyBearerRequest(
+		0x22222222, 2,
+		v2ie.NewIMSI("123451234567890"),
+		v2ie.NewRATType(gtpv2.RATTypeUTRAN),
+		v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPC, 0x66666666, "10.0.0.5", ""),
+		v2ie.NewBearerContext(
+			v2ie.NewEPSBearerID(5),
+			v2ie.NewFullyQualifiedTEID(gtpv2.IFTypeS5S8SGWGTPU, 0x55555555, "10.0.0.6", "").WithInstance(1),
+		),
+	))
+
+	t.Run("ToUpdatePDPContextRequest", func(t *testing.T) {
+		got, err := m.ToUpdatePDPContextRequest(v2Req, 0x22222222, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// QoS Profile is left to the callers.
+		got.QoSProfile = v1QoS
+		got.SetLength()
+		assertSame(t, got, v1Req)
+	})
+
+	v1Res := v1msg.NewUpdatePDPContextResponse(
+		0x66666666, 2,
+		v1ie.NewCause(gtpv1.ResCauseRequestAccepted),
+		v1ie.NewChargingID(0xffffffff),
+	)
+
+	v2Res, err := m.ToModifyBearerResponse(v2Req, v1Res, 0x66666666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Res, v2msg.NewModifyBearerResponse(
+		0x66666666, 2,
+		v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+		v2ie.NewBearerContext(
+			v2ie.NewEPSBearerID(5),
+			v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+			v2ie.NewChargingID(0xffffffff),
+		),
+	))
+
+	t.Run("ToUpdatePDPContextResponse", func(t *testing.T) {
+		got, err := m.ToUpdatePDPContextResponse(v1Req, v2Res, 0x66666666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSame(t, got, v1Res)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	m := &interworking.Mapper{}
+
+	v1Req := v1msg.NewDeletePDPContextRequest(
+		0x22222222, 3,
+		v1ie.NewTeardownInd(true),
+		v1ie.NewNSAPI(5),
+		v1PCO,
+	)
+
+	v2Req, err := m.ToDeleteSessionRequest(v1Req, 0x22222222, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Req, v2msg.NewDeleteSessionRequest(
+		0x22222222, 3,
+		v2ie.NewEPSBearerID(5),
+		v2PCO,
+	))
+
+	t.Run("ToDeletePDPContextRequest", func(t *testing.T) {
+		got, err := m.ToDeletePDPContextRequest(v2Req, 0x22222222, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSame(t, got, v1Req)
+	})
+
+	v1Res := v1msg.NewDeletePDPContextResponse(
+		0x66666666, 3,
+		v1ie.NewCause(gtpv1.ResCauseRequestAccepted),
+	)
+
+	v2Res, err := m.ToDeleteSessionResponse(v2Req, v1Res, 0x66666666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSame(t, v2Res, v2msg.NewDeleteSessionResponse(
+		0x66666666, 3,
+		v2ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil),
+	))
+
+	t.Run("ToDeletePDPContextResponse", func(t *testing.T) {
+		got, err := m.ToDeletePDPContextResponse(v1Req, v2Res, 0x66666666)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSame(t, got, v1Res)
+	})
+}
+
+func TestConversionError(t *testing.T) {
+	m := &interworking.Mapper{}
+
+	req := v1msg.NewCreatePDPContextRequest(
+		0, 1,
+		v1ie.NewIMSI("123451234567890"),
+		v1ie.NewEndUserAddressPPP(),
+	)
+	_, err := m.ToCreateSessionRequest(req, 0, 1)
+
+	var cerr *interworking.ConversionError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(cerr.IE, "EndUserAddress"); diff != "" {
+		t.Error(diff)
+	}
+	if !errors.Is(err, interworking.ErrUnsupportedPDPType) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
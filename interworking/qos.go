@@ -0,0 +1,241 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+)
+
+// The default thresholds of the EPS ARP priority levels used by Mapper.
+const (
+	DefaultARPHigh   uint8 = 5
+	DefaultARPMedium uint8 = 10
+)
+
+// The transfer delays (in ms) used in the mapping between QCI and the Release 99
+// QoS parameters. The conversational traffic with the transfer delay shorter
+// than delayQCI2 is mapped to QCI 3.
+const (
+	delayQCI1 = 100
+	delayQCI2 = 150
+	delayQCI3 = 80
+	delayQCI4 = 300
+)
+
+// QCI definitions for the standardized characteristics used in the mapping.
+const (
+	qci1 uint8 = iota + 1
+	qci2
+	qci3
+	qci4
+	qci5
+	qci6
+	qci7
+	qci8
+	qci9
+)
+
+// Mapper converts the GTPv1-C messages and IEs into the GTPv2-C ones and vice
+// versa. The zero value is ready to use with the default parameters.
+//
+// The Allocation/Retention Priority in the Release 99 QoS Profile has only three
+// levels, which are mapped to and from the EPS ARP priority levels with the two
+// thresholds H and M (TS 23.401 Annex E): the priority levels 1 to H are mapped to
+// 1, H+1 to M to 2, and M+1 to 15 to 3. The opposite mapping uses H, M and 15.
+type Mapper struct {
+	// ARPHigh and ARPMedium are the thresholds H and M of the EPS ARP priority
+	// levels. DefaultARPHigh and DefaultARPMedium are used if they are zero.
+	ARPHigh, ARPMedium uint8
+
+	// PreemptionCapability and PreemptionVulnerability are the values of the PCI
+	// and PVI bits in the EPS ARP converted from the Release 99 QoS Profile without
+	// Evolved ARP. Note that 1 means disabled for both of them.
+	PreemptionCapability, PreemptionVulnerability uint8
+}
+
+func (m *Mapper) thresholds() (high, medium uint8) {
+	high, medium = m.ARPHigh, m.ARPMedium
+	if high == 0 {
+		high = DefaultARPHigh
+	}
+	if medium == 0 {
+		medium = DefaultARPMedium
+	}
+	return high, medium
+}
+
+// ToBearerQoS converts the QoS Profile IE into the Bearer QoS IE.
+//
+// The EPS ARP is taken from the Evolved ARP I IE if given, otherwise it is converted
+// from the Allocation/Retention Priority in QoS Profile.
+func (m *Mapper) ToBearerQoS(qos, earp *v1ie.IE) (*v2ie.IE, error) {
+	f, err := qos.QoSProfileFields()
+	if err != nil {
+		return nil, err
+	}
+
+	pci, pvi := m.PreemptionCapability, m.PreemptionVulnerability
+	var pl uint8
+	if earp != nil {
+		if pl, err = earp.PriorityLevel(); err != nil {
+			return nil, err
+		}
+		pci, pvi = boolToUint8(earp.HasPCI()), boolToUint8(earp.HasPVI())
+	} else {
+		high, medium := m.thresholds()
+		switch f.AllocationRetentionPriority {
+		case 1:
+			pl = high
+		case 2:
+			pl = medium
+		default:
+			pl = 15
+		}
+	}
+
+	qci := toQCI(f)
+	var ugbr, dgbr uint64
+	if isGBR(qci) {
+		ugbr, dgbr = uint64(f.GuaranteedBitRateForUplink), uint64(f.GuaranteedBitRateForDownlink)
+	}
+	return v2ie.NewBearerQoS(
+		pci, pl, pvi, qci,
+		uint64(f.MaximumBitRateForUplink), uint64(f.MaximumBitRateForDownlink), ugbr, dgbr,
+	), nil
+}
+
+// ToQoSProfile converts the Bearer QoS IE into the QoS Profile IE and the Evolved
+// ARP I IE.
+//
+// The Maximum Bit Rates of the non-GBR bearers are taken from the AMBR IE if given
+// (TS 23.401 Annex E), as they are not included in Bearer QoS of them. The Release
+// 99 QoS parameters that have no counterpart in EPS are set to the typical values,
+// and the ones of R97/98 are set to the "best effort" ones.
+func (m *Mapper) ToQoSProfile(qos, ambr *v2ie.IE) (profile, earp *v1ie.IE, err error) {
+	q, err := qos.BearerQoS()
+	if err != nil {
+		return nil, nil, err
+	}
+	pl, pci, pvi := q.ARP>>2&0x0f, q.ARP>>6&0x01, q.ARP&0x01
+
+	high, medium := m.thresholds()
+	f := &v1ie.QoSProfileFields{
+		AllocationRetentionPriority: 3,
+		DelayClass:                  4,
+		ReliabilityClass:            3,
+		PeakThroughput:              1,
+		PrecedenceClass:             2,
+		MeanThroughput:              0x1f,
+		DeliveryOrder:               2,    // without delivery order
+		DeliveryOfErroneousSDU:      3,    // erroneous SDUs are not delivered
+		MaximumSDUSize:              0x96, // 1500 octets
+		ResidualBER:                 7,    // 1*10^-5
+		SDUErrorRatio:               4,    // 1*10^-4
+		MaximumBitRateForUplink:     uint32(q.MaximumBitRateForUplink),
+		MaximumBitRateForDownlink:   uint32(q.MaximumBitRateForDownlink),
+	}
+	switch {
+	case pl <= high:
+		f.AllocationRetentionPriority = 1
+	case pl <= medium:
+		f.AllocationRetentionPriority = 2
+	}
+
+	fromQCI(q.QCI, f)
+	if isGBR(q.QCI) {
+		f.GuaranteedBitRateForUplink = uint32(q.GuaranteedBitRateForUplink)
+		f.GuaranteedBitRateForDownlink = uint32(q.GuaranteedBitRateForDownlink)
+	} else if ambr != nil {
+		a, err := ambr.AggregateMaximumBitRate()
+		if err != nil {
+			return nil, nil, err
+		}
+		f.MaximumBitRateForUplink, f.MaximumBitRateForDownlink = a.APNAMBRForUplink, a.APNAMBRForDownlink
+	}
+
+	return v1ie.NewQoSProfileFromFields(f), v1ie.NewEvolvedAllocationRetentionPriorityI(pci, pl, pvi), nil
+}
+
+// toQCI returns the QCI that corresponds to the Release 99 QoS parameters
+// (TS 23.401 Annex E).
+func toQCI(f *v1ie.QoSProfileFields) uint8 {
+	switch f.TrafficClass {
+	case v1ie.TrafficClassConversational:
+		switch {
+		case f.SourceStatisticsDescriptor == v1ie.SourceStatisticsDescriptorSpeech:
+			return qci1
+		case f.TransferDelay >= delayQCI2:
+			return qci2
+		default:
+			return qci3
+		}
+	case v1ie.TrafficClassStreaming:
+		return qci4
+	case v1ie.TrafficClassInteractive:
+		switch f.TrafficHandlingPriority {
+		case 1:
+			if f.SignallingIndication {
+				return qci5
+			}
+			return qci6
+		case 2:
+			return qci7
+		default:
+			return qci8
+		}
+	default:
+		return qci9
+	}
+}
+
+// fromQCI sets the Release 99 QoS parameters that correspond to the QCI to f
+// (TS 23.401 Annex E). The QCIs other than the standardized ones from 1 to 9 are
+// mapped to the background class.
+func fromQCI(qci uint8, f *v1ie.QoSProfileFields) {
+	switch qci {
+	case qci1:
+		f.TrafficClass = v1ie.TrafficClassConversational
+		f.SourceStatisticsDescriptor = v1ie.SourceStatisticsDescriptorSpeech
+		f.TransferDelay = delayQCI1
+	case qci2:
+		f.TrafficClass = v1ie.TrafficClassConversational
+		f.TransferDelay = delayQCI2
+	case qci3:
+		f.TrafficClass = v1ie.TrafficClassConversational
+		f.TransferDelay = delayQCI3
+	case qci4:
+		f.TrafficClass = v1ie.TrafficClassStreaming
+		f.TransferDelay = delayQCI4
+	case qci5:
+		f.TrafficClass = v1ie.TrafficClassInteractive
+		f.TrafficHandlingPriority = 1
+		f.SignallingIndication = true
+	case qci6:
+		f.TrafficClass = v1ie.TrafficClassInteractive
+		f.TrafficHandlingPriority = 1
+	case qci7:
+		f.TrafficClass = v1ie.TrafficClassInteractive
+		f.TrafficHandlingPriority = 2
+	case qci8:
+		f.TrafficClass = v1ie.TrafficClassInteractive
+		f.TrafficHandlingPriority = 3
+	default:
+		f.TrafficClass = v1ie.TrafficClassBackground
+	}
+}
+
+// isGBR reports whether the QCI is the one of the GBR bearers among the
+// standardized ones mapped.
+func isGBR(qci uint8) bool {
+	return qci >= qci1 && qci <= qci4
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,137 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/interworking"
+)
+
+func TestQoS(t *testing.T) {
+	m := &interworking.Mapper{}
+
+	t.Run("QCI", func(t *testing.T) {
+		for qci := uint8(1); qci <= 9; qci++ {
+			qos, earp, err := m.ToQoSProfile(v2ie.NewBearerQoS(0, 1, 0, qci, 1024, 2048, 512, 1024), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := m.ToBearerQoS(qos, earp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := got.BearerQoS()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(f.QCI, qci); diff != "" {
+				t.Errorf("QCI %d: %s", qci, diff)
+			}
+		}
+	})
+
+	t.Run("ToBearerQoS", func(t *testing.T) {
+		cases := []struct {
+			description string
+			fields      *v1ie.QoSProfileFields
+			earp        *v1ie.IE
+			want        *v2ie.IE
+		}{
+			{
+				"conversational/speech",
+				&v1ie.QoSProfileFields{
+					AllocationRetentionPriority:  1,
+					TrafficClass:                 v1ie.TrafficClassConversational,
+					SourceStatisticsDescriptor:   v1ie.SourceStatisticsDescriptorSpeech,
+					MaximumBitRateForUplink:      64,
+					MaximumBitRateForDownlink:    64,
+					GuaranteedBitRateForUplink:   32,
+					GuaranteedBitRateForDownlink: 32,
+				},
+				nil,
+				v2ie.NewBearerQoS(0, 5, 0, 1, 64, 64, 32, 32),
+			}, {
+				"interactive/signalling",
+				&v1ie.QoSProfileFields{
+					AllocationRetentionPriority:  2,
+					TrafficClass:                 v1ie.TrafficClassInteractive,
+					TrafficHandlingPriority:      1,
+					SignallingIndication:         true,
+					MaximumBitRateForUplink:      1024,
+					MaximumBitRateForDownlink:    2048,
+					GuaranteedBitRateForUplink:   32,
+					GuaranteedBitRateForDownlink: 32,
+				},
+				nil,
+				v2ie.NewBearerQoS(0, 10, 0, 5, 1024, 2048, 0, 0),
+			}, {
+				"background/evolved ARP",
+				&v1ie.QoSProfileFields{
+					AllocationRetentionPriority: 3,
+					TrafficClass:                v1ie.TrafficClassBackground,
+					MaximumBitRateForUplink:     1024,
+					MaximumBitRateForDownlink:   2048,
+				},
+				v1ie.NewEvolvedAllocationRetentionPriorityI(1, 2, 1),
+				v2ie.NewBearerQoS(1, 2, 1, 9, 1024, 2048, 0, 0),
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.description, func(t *testing.T) {
+				got, err := m.ToBearerQoS(v1ie.NewQoSProfileFromFields(c.fields), c.earp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(got.Payload, c.want.Payload); diff != "" {
+					t.Error(diff)
+				}
+			})
+		}
+	})
+
+	t.Run("ToQoSProfile", func(t *testing.T) {
+		qos, earp, err := m.ToQoSProfile(
+			v2ie.NewBearerQoS(1, 8, 0, 6, 0, 0, 0, 0),
+			v2ie.NewAggregateMaximumBitRate(1024, 2048),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := qos.QoSProfileFields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &v1ie.QoSProfileFields{
+			AllocationRetentionPriority: 2,
+			DelayClass:                  4,
+			ReliabilityClass:            3,
+			PeakThroughput:              1,
+			PrecedenceClass:             2,
+			MeanThroughput:              0x1f,
+			TrafficClass:                v1ie.TrafficClassInteractive,
+			DeliveryOrder:               2,
+			DeliveryOfErroneousSDU:      3,
+			MaximumSDUSize:              0x96,
+			MaximumBitRateForUplink:     1024,
+			MaximumBitRateForDownlink:   2048,
+			ResidualBER:                 7,
+			SDUErrorRatio:               4,
+			TrafficHandlingPriority:     1,
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+		if diff := cmp.Diff(earp.Payload, []byte{0x60}); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package interworking
+
+import (
+	v1ie "github.com/wmnsk/go-gtp/gtpv1/ie"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	"github.com/wmnsk/go-gtp/gtpv2"
+	v2ie "github.com/wmnsk/go-gtp/gtpv2/ie"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// ToModifyBearerRequest converts the Update PDP Context Request into the Modify
+// Bearer Request with the TEID and sequence number given.
+//
+// The QoS Profile is not converted, as the QoS cannot be changed with Modify Bearer
+// Request. The F-TEIDs are made from the TEIDs and the addresses of the SGSN.
+func (m *Mapper) ToModifyBearerRequest(req *v1msg.UpdatePDPContextRequest, teid, seq uint32) (*v2msg.ModifyBearerRequest, error) {
+	c := &converter{}
+
+	bc := v2ie.NewBearerContext(
+		withInstance(c.toV2("NSAPI", req.NSAPI, v2EBI), 0),
+		withInstance(c.fteid("TEIDDataI", gtpv2.IFTypeS5S8SGWGTPU, req.TEIDDataI, req.SGSNAddressForUserTraffic), 1),
+	)
+
+	b := v2msg.BuildModifyBearerRequest(teid, seq).
+		WithIMSI(c.toV2("IMSI", req.IMSI, v2IMSI)).
+		WithMEI(c.toV2("IMEI", req.IMEI, v2MEI)).
+		WithServingNetwork(c.toV2("RAI", req.RAI, v2ServingNetwork)).
+		WithRATType(c.toV2("RATType", req.RATType, v2RATType)).
+		WithSenderFTEIDC(c.fteid("TEIDCPlane", gtpv2.IFTypeS5S8SGWGTPC, req.TEIDCPlane, req.SGSNAddressForCPlane)).
+		WithAMBR(c.toV2("APNAMBR", req.APNAMBR, v2AMBR)).
+		WithBearerContextsToBeModified(bc).
+		WithRecovery(c.toV2("Recovery", req.Recovery, v2Recovery)).
+		WithUETimeZone(c.toV2("MSTimeZone", req.MSTimeZone, v2UETimeZone))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToUpdatePDPContextRequest converts the Modify Bearer Request into the Update PDP
+// Context Request with the TEID and sequence number given.
+//
+// The QoS Profile, which is mandatory in Update PDP Context Request, cannot be
+// made from Modify Bearer Request and should be set by the callers.
+func (m *Mapper) ToUpdatePDPContextRequest(req *v2msg.ModifyBearerRequest, teid uint32, seq uint16) (*v1msg.UpdatePDPContextRequest, error) {
+	c := &converter{}
+
+	bc := firstBearerContext(req.BearerContextsToBeModified)
+	teidC, addrC := c.fromFTEID("SenderFTEIDC", req.SenderFTEIDC, v1ie.NewTEIDCPlane)
+	teidU, addrU := c.fromFTEID("S5/S8-U SGW F-TEID", findChild(bc, v2ie.FullyQualifiedTEID, 1), v1ie.NewTEIDDataI)
+
+	msg := v1msg.NewUpdatePDPContextRequest(
+		teid, seq,
+		c.toV1("IMSI", req.IMSI, v1IMSI),
+		c.toV1("Recovery", req.Recovery, v1Recovery),
+		teidU, teidC,
+		c.toV1("EBI", findChild(bc, v2ie.EPSBearerID, 0), v1NSAPI),
+		c.toV1("RATType", req.RATType, v1RATType),
+		c.toV1("UETimeZone", req.UETimeZone, v1MSTimeZone),
+		c.toV1("AMBR", req.AMBR, v1AMBR),
+		c.toV1("MEI", req.MEI, v1IMEISV),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	msg.SGSNAddressForCPlane = addrC
+	msg.SGSNAddressForUserTraffic = addrU
+	msg.SetLength()
+	return msg, nil
+}
+
+// ToModifyBearerResponse converts the Update PDP Context Response into the Modify
+// Bearer Response to req with the TEID given.
+//
+// The EPS Bearer ID in Bearer Context is taken from req.
+func (m *Mapper) ToModifyBearerResponse(req *v2msg.ModifyBearerRequest, res *v1msg.UpdatePDPContextResponse, teid uint32) (*v2msg.ModifyBearerResponse, error) {
+	c := &converter{}
+
+	var ebi *v2ie.IE
+	if e := findChild(firstBearerContext(req.BearerContextsToBeModified), v2ie.EPSBearerID, 0); e != nil {
+		ebi = v2ie.NewEPSBearerID(e.MustEPSBearerID())
+	}
+	cause := c.toV2("Cause", res.Cause, v2Cause)
+
+	var bc *v2ie.IE
+	if cause != nil {
+		bc = v2ie.NewBearerContext(
+			ebi,
+			withInstance(v2ie.NewCause(cause.MustCause(), 0, 0, 0, nil), 0),
+			withInstance(c.toV2("ChargingID", res.ChargingID, v2ChargingID), 0),
+		)
+	}
+
+	b := v2msg.BuildModifyBearerResponse(teid, req.Sequence()).
+		WithCause(cause).
+		WithAPNRestriction(c.toV2("APNRestriction", res.APNRestriction, v2APNRestriction)).
+		WithPCO(c.toV2("PCO", res.PCO, v2PCO)).
+		WithBearerContextsModified(bc).
+		WithRecovery(c.toV2("Recovery", res.Recovery, v2Recovery))
+	if c.err != nil {
+		return nil, c.err
+	}
+	return b.Build()
+}
+
+// ToUpdatePDPContextResponse converts the Modify Bearer Response into the Update
+// PDP Context Response to req with the TEID given.
+//
+// The TEIDs, the GGSN addresses and the QoS Profile cannot be made from Modify
+// Bearer Response and should be set by the callers.
+func (m *Mapper) ToUpdatePDPContextResponse(req *v1msg.UpdatePDPContextRequest, res *v2msg.ModifyBearerResponse, teid uint32) (*v1msg.UpdatePDPContextResponse, error) {
+	c := &converter{}
+
+	bc := firstBearerContext(res.BearerContextsModified)
+	msg := v1msg.NewUpdatePDPContextResponse(
+		teid, req.Sequence(),
+		c.toV1("Cause", res.Cause, v1Cause),
+		c.toV1("Recovery", res.Recovery, v1Recovery),
+		c.toV1("ChargingID", findChild(bc, v2ie.ChargingID, 0), v1ChargingID),
+		c.toV1("PCO", res.PCO, v1PCO),
+		c.toV1("APNRestriction", res.APNRestriction, v1APNRestriction),
+	)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return msg, nil
+}
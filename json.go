@@ -0,0 +1,107 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package gtp
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gopkg.in/yaml.v2"
+
+	v0msg "github.com/wmnsk/go-gtp/gtpv0/message"
+	v1msg "github.com/wmnsk/go-gtp/gtpv1/message"
+	v2msg "github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// versionDocument is used to retrieve the version from the document before
+// decoding it into the message of that version.
+type versionDocument struct {
+	Version *int `json:"version" yaml:"version"`
+}
+
+// MarshalJSON returns the Message in JSON.
+//
+// The format is the one of MarshalJSON in the message package of each version,
+// which has the "version" field to be used in ParseJSON.
+func MarshalJSON(m Message) ([]byte, error) {
+	switch v := m.(type) {
+	case v0msg.Message:
+		if m.Version() == 0 {
+			return v0msg.MarshalJSON(v)
+		}
+	case v1msg.Message:
+		if m.Version() == 1 {
+			return v1msg.MarshalJSON(v)
+		}
+	case v2msg.Message:
+		if m.Version() == 2 {
+			return v2msg.MarshalJSON(v)
+		}
+	}
+	return nil, ErrInvalidVersion
+}
+
+// ParseJSON decodes the Message in JSON, in the format generated by MarshalJSON.
+func ParseJSON(b []byte) (Message, error) {
+	var d versionDocument
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+	if d.Version == nil {
+		return nil, errors.New("no version in the document")
+	}
+
+	switch *d.Version {
+	case 0:
+		return v0msg.ParseJSON(b)
+	case 1:
+		return v1msg.ParseJSON(b)
+	case 2:
+		return v2msg.ParseJSON(b)
+	default:
+		return nil, ErrInvalidVersion
+	}
+}
+
+// MarshalYAML returns the Message in YAML, in the same format as MarshalJSON.
+func MarshalYAML(m Message) ([]byte, error) {
+	switch v := m.(type) {
+	case v0msg.Message:
+		if m.Version() == 0 {
+			return v0msg.MarshalYAML(v)
+		}
+	case v1msg.Message:
+		if m.Version() == 1 {
+			return v1msg.MarshalYAML(v)
+		}
+	case v2msg.Message:
+		if m.Version() == 2 {
+			return v2msg.MarshalYAML(v)
+		}
+	}
+	return nil, ErrInvalidVersion
+}
+
+// ParseYAML decodes the Message in YAML, in the format generated by MarshalYAML.
+func ParseYAML(b []byte) (Message, error) {
+	var d versionDocument
+	if err := yaml.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+	if d.Version == nil {
+		return nil, errors.New("no version in the document")
+	}
+
+	switch *d.Version {
+	case 0:
+		return v0msg.ParseYAML(b)
+	case 1:
+		return v1msg.ParseYAML(b)
+	case 2:
+		return v2msg.ParseYAML(b)
+	default:
+		return nil, ErrInvalidVersion
+	}
+}
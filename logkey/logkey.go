@@ -0,0 +1,25 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package logkey defines the keys of the attributes in the log records written
+// with log/slog by the connections in gtpv0, gtpv1, gtpv2 and gtpprime packages.
+//
+// The records always have Proto and LocalAddr, and the others are added when
+// they are relevant. They help filtering the records of a specific peer or tunnel,
+// and can be used to write the logs from the handlers in the same manner.
+package logkey
+
+// The keys of the attributes.
+const (
+	Proto     = "proto"
+	LocalAddr = "laddr"
+	Peer      = "peer"
+	TEID      = "teid"
+	TID       = "tid" // GTPv0 only
+	MsgType   = "msg_type"
+	Sequence  = "seq"
+	IMSI      = "imsi"
+	APN       = "apn"
+	Error     = "error"
+)
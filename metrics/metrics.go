@@ -0,0 +1,118 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package metrics defines the interface to record the metrics of gtpv1.UPlaneConn
+// and gtpv2.Conn.
+//
+// Give an implementation of Recorder to the connections with gtpv2.WithMetrics or
+// (*gtpv1.UPlaneConn).SetMetrics. The package prommetrics provides the one that
+// exposes the metrics to Prometheus.
+package metrics
+
+import "time"
+
+// The values of proto given to Recorder.
+const (
+	ProtoGTPv1U = "gtpv1-u"
+	ProtoGTPv2C = "gtpv2-c"
+)
+
+// Direction is the direction of the messages or packets.
+type Direction uint8
+
+// Direction definitions.
+const (
+	Sent Direction = iota
+	Received
+)
+
+// String returns the name of Direction.
+func (d Direction) String() string {
+	switch d {
+	case Sent:
+		return "sent"
+	case Received:
+		return "received"
+	default:
+		return "unknown"
+	}
+}
+
+// Recorder is the interface to record the events on the connections.
+//
+// The methods are called synchronously in the goroutines that send or receive the
+// messages, so they should return as quickly as possible. They are called
+// concurrently, so the implementation must be safe for concurrent use.
+type Recorder interface {
+	// Message is called when a message other than T-PDU is sent or received.
+	// msgType is the name of the message type, e.g., "Create Session Request".
+	// cause is the value of Cause IE in the message, or 0 if it has none.
+	Message(proto string, dir Direction, msgType string, cause uint8)
+
+	// ParseError is called when the received message cannot be parsed.
+	ParseError(proto string)
+
+	// HandlerError is called when the received message is failed to be handled,
+	// including the failure of validation and the lack of handler.
+	HandlerError(proto, msgType string)
+
+	// Retransmission is called when a request message is sent or received again
+	// with the same sequence number shortly after the first one.
+	Retransmission(proto string, dir Direction, msgType string)
+
+	// RoundTrip is called when a response message is received for the request
+	// message sent. procedure is the name of the message type of the request.
+	RoundTrip(proto, procedure string, rtt time.Duration)
+
+	// TPDU is called when T-PDUs are sent or received on GTPv1-U, including the
+	// ones relayed. bytes is the total length of the packets including GTP header.
+	TPDU(dir Direction, packets, bytes int)
+
+	// TPDUDropped is called when the T-PDUs received on GTPv1-U are dropped, as
+	// they are not read by ReadFromGTP fast enough.
+	TPDUDropped(packets int)
+
+	// Sessions is called when a Session is registered to or removed from the
+	// GTPv2-C connection, with the changes in the number of the Sessions and their
+	// bearers, e.g., (1, 2) for the one with two bearers registered. The bearers
+	// are counted when the Session is registered, and the same number is subtracted
+	// when it is removed.
+	Sessions(proto string, sessions, bearers int)
+}
+
+// SessionCounter is the interface to get the number of active sessions and
+// bearers, which is implemented by gtpv2.Conn.
+type SessionCounter interface {
+	SessionCount() int
+	BearerCount() int
+}
+
+// NopRecorder is the Recorder that does nothing.
+//
+// It is useful to be embedded in the Recorder that handles only some of the events.
+type NopRecorder struct{}
+
+// Message does nothing.
+func (NopRecorder) Message(string, Direction, string, uint8) {}
+
+// ParseError does nothing.
+func (NopRecorder) ParseError(string) {}
+
+// HandlerError does nothing.
+func (NopRecorder) HandlerError(string, string) {}
+
+// Retransmission does nothing.
+func (NopRecorder) Retransmission(string, Direction, string) {}
+
+// RoundTrip does nothing.
+func (NopRecorder) RoundTrip(string, string, time.Duration) {}
+
+// TPDU does nothing.
+func (NopRecorder) TPDU(Direction, int, int) {}
+
+// TPDUDropped does nothing.
+func (NopRecorder) TPDUDropped(int) {}
+
+// Sessions does nothing.
+func (NopRecorder) Sessions(string, int, int) {}
@@ -0,0 +1,255 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package prommetrics provides the metrics.Recorder that exposes the metrics
+// of gtpv1.UPlaneConn and gtpv2.Conn to Prometheus.
+//
+// Create a Collector, register it to the prometheus.Registerer, and give it to
+// the connections.
+//
+//	collector := prommetrics.NewCollector("gw")
+//	prometheus.MustRegister(collector)
+//
+//	conn := gtpv2.NewConn(laddr, gtpv2.IFTypeS11S4SGWGTPC, 0, gtpv2.WithMetrics(collector))
+//	collector.WatchSessions("s11", conn)
+package prommetrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/wmnsk/go-gtp/metrics"
+)
+
+// DefaultNamespace is the namespace of the metrics used when empty namespace
+// is given to NewCollector.
+const DefaultNamespace = "gtp"
+
+// Collector is the metrics.Recorder and prometheus.Collector that exposes the
+// following metrics, prefixed with the namespace.
+//
+//   - messages_total{proto, direction, type, cause}
+//   - parse_errors_total{proto}
+//   - handler_errors_total{proto, type}
+//   - retransmissions_total{proto, direction, type}
+//   - round_trip_seconds{proto, procedure}
+//   - tpdu_packets_total{direction}
+//   - tpdu_bytes_total{direction}
+//   - tpdu_dropped_total
+//   - registered_sessions{proto}
+//   - registered_bearers{proto}
+//   - active_sessions{conn}
+//   - active_bearers{conn}
+//
+// The cause label is empty for the messages without Cause IE.
+type Collector struct {
+	messages        *prometheus.CounterVec
+	parseErrors     *prometheus.CounterVec
+	handlerErrors   *prometheus.CounterVec
+	retransmissions *prometheus.CounterVec
+	roundTrip       *prometheus.HistogramVec
+	tpduPackets     *prometheus.CounterVec
+	tpduBytes       *prometheus.CounterVec
+	tpduDropped     prometheus.Counter
+	sessions        *prometheus.GaugeVec
+	bearers         *prometheus.GaugeVec
+
+	sessionsDesc *prometheus.Desc
+	bearersDesc  *prometheus.Desc
+
+	mu       sync.RWMutex
+	counters map[string]metrics.SessionCounter
+}
+
+var _ metrics.Recorder = (*Collector)(nil)
+
+// NewCollector creates a new Collector with the namespace of the metrics.
+// If namespace is empty, DefaultNamespace is used.
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	return &Collector{
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_total",
+			Help:      "Number of messages sent and received by message type and cause.",
+		}, []string{"proto", "direction", "type", "cause"}),
+		parseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "parse_errors_total",
+			Help:      "Number of messages received that could not be parsed.",
+		}, []string{"proto"}),
+		handlerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "handler_errors_total",
+			Help:      "Number of messages received that could not be handled by message type.",
+		}, []string{"proto", "type"}),
+		retransmissions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retransmissions_total",
+			Help:      "Number of request messages retransmitted by message type.",
+		}, []string{"proto", "direction", "type"}),
+		roundTrip: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "round_trip_seconds",
+			Help:      "Round-trip time of the requests sent by procedure.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"proto", "procedure"}),
+		tpduPackets: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tpdu_packets_total",
+			Help:      "Number of T-PDU packets sent and received.",
+		}, []string{"direction"}),
+		tpduBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tpdu_bytes_total",
+			Help:      "Number of bytes of T-PDU packets sent and received, including GTP header.",
+		}, []string{"direction"}),
+		tpduDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tpdu_dropped_total",
+			Help:      "Number of T-PDU packets received that were dropped without being read.",
+		}),
+		sessions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "registered_sessions",
+			Help:      "Number of sessions registered.",
+		}, []string{"proto"}),
+		bearers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "registered_bearers",
+			Help:      "Number of bearers of the sessions registered, counted at the registration.",
+		}, []string{"proto"}),
+		sessionsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_sessions"),
+			"Number of active sessions.",
+			[]string{"conn"}, nil,
+		),
+		bearersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_bearers"),
+			"Number of active bearers.",
+			[]string{"conn"}, nil,
+		),
+		counters: map[string]metrics.SessionCounter{},
+	}
+}
+
+// WatchSessions lets Collector expose the number of active sessions and bearers
+// in counter (typically *gtpv2.Conn) with the conn label set to name.
+//
+// The numbers are retrieved every time the metrics are collected. Calling this
+// again with the same name replaces the one watched before.
+func (c *Collector) WatchSessions(name string, counter metrics.SessionCounter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counters[name] = counter
+}
+
+// UnwatchSessions stops exposing the number of sessions and bearers watched
+// with name.
+func (c *Collector) UnwatchSessions(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.counters, name)
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.messages.Describe(ch)
+	c.parseErrors.Describe(ch)
+	c.handlerErrors.Describe(ch)
+	c.retransmissions.Describe(ch)
+	c.roundTrip.Describe(ch)
+	c.tpduPackets.Describe(ch)
+	c.tpduBytes.Describe(ch)
+	c.tpduDropped.Describe(ch)
+	c.sessions.Describe(ch)
+	c.bearers.Describe(ch)
+	ch <- c.sessionsDesc
+	ch <- c.bearersDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.messages.Collect(ch)
+	c.parseErrors.Collect(ch)
+	c.handlerErrors.Collect(ch)
+	c.retransmissions.Collect(ch)
+	c.roundTrip.Collect(ch)
+	c.tpduPackets.Collect(ch)
+	c.tpduBytes.Collect(ch)
+	c.tpduDropped.Collect(ch)
+	c.sessions.Collect(ch)
+	c.bearers.Collect(ch)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, counter := range c.counters {
+		ch <- prometheus.MustNewConstMetric(c.sessionsDesc, prometheus.GaugeValue, float64(counter.SessionCount()), name)
+		ch <- prometheus.MustNewConstMetric(c.bearersDesc, prometheus.GaugeValue, float64(counter.BearerCount()), name)
+	}
+}
+
+// Message implements metrics.Recorder.
+func (c *Collector) Message(proto string, dir metrics.Direction, msgType string, cause uint8) {
+	c.messages.WithLabelValues(proto, dir.String(), msgType, causeLabel(cause)).Inc()
+}
+
+// ParseError implements metrics.Recorder.
+func (c *Collector) ParseError(proto string) {
+	c.parseErrors.WithLabelValues(proto).Inc()
+}
+
+// HandlerError implements metrics.Recorder.
+func (c *Collector) HandlerError(proto, msgType string) {
+	c.handlerErrors.WithLabelValues(proto, msgType).Inc()
+}
+
+// Retransmission implements metrics.Recorder.
+func (c *Collector) Retransmission(proto string, dir metrics.Direction, msgType string) {
+	c.retransmissions.WithLabelValues(proto, dir.String(), msgType).Inc()
+}
+
+// RoundTrip implements metrics.Recorder.
+func (c *Collector) RoundTrip(proto, procedure string, rtt time.Duration) {
+	c.roundTrip.WithLabelValues(proto, procedure).Observe(rtt.Seconds())
+}
+
+// TPDU implements metrics.Recorder.
+func (c *Collector) TPDU(dir metrics.Direction, packets, bytes int) {
+	d := dir.String()
+	c.tpduPackets.WithLabelValues(d).Add(float64(packets))
+	c.tpduBytes.WithLabelValues(d).Add(float64(bytes))
+}
+
+// TPDUDropped implements metrics.Recorder.
+func (c *Collector) TPDUDropped(packets int) {
+	c.tpduDropped.Add(float64(packets))
+}
+
+// Sessions implements metrics.Recorder.
+func (c *Collector) Sessions(proto string, sessions, bearers int) {
+	c.sessions.WithLabelValues(proto).Add(float64(sessions))
+	c.bearers.WithLabelValues(proto).Add(float64(bearers))
+}
+
+// causeLabels is the cache of the cause labels not to allocate them every time.
+var causeLabels = func() [256]string {
+	var labels [256]string
+	for i := 1; i < len(labels); i++ {
+		labels[i] = strconv.Itoa(i)
+	}
+	return labels
+}()
+
+func causeLabel(cause uint8) string {
+	return causeLabels[cause]
+}
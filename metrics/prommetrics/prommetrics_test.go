@@ -0,0 +1,98 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package prommetrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/wmnsk/go-gtp/metrics"
+	"github.com/wmnsk/go-gtp/metrics/prommetrics"
+)
+
+type sessionCounter struct {
+	sessions, bearers int
+}
+
+func (s sessionCounter) SessionCount() int { return s.sessions }
+func (s sessionCounter) BearerCount() int  { return s.bearers }
+
+func TestCollector(t *testing.T) {
+	c := prommetrics.NewCollector("")
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Message(metrics.ProtoGTPv2C, metrics.Sent, "Create Session Request", 0)
+	c.Message(metrics.ProtoGTPv2C, metrics.Received, "Create Session Response", 16)
+	c.Message(metrics.ProtoGTPv2C, metrics.Received, "Create Session Response", 16)
+	c.ParseError(metrics.ProtoGTPv1U)
+	c.HandlerError(metrics.ProtoGTPv2C, "Echo Request")
+	c.Retransmission(metrics.ProtoGTPv2C, metrics.Sent, "Create Session Request")
+	c.RoundTrip(metrics.ProtoGTPv2C, "Create Session Request", 3*time.Millisecond)
+	c.TPDU(metrics.Received, 2, 100)
+	c.TPDUDropped(1)
+	c.Sessions(metrics.ProtoGTPv2C, 1, 2)
+	c.Sessions(metrics.ProtoGTPv2C, 1, 1)
+	c.Sessions(metrics.ProtoGTPv2C, -1, -2)
+	c.WatchSessions("s11", sessionCounter{3, 5})
+	c.WatchSessions("s5", sessionCounter{1, 1})
+	c.UnwatchSessions("s5")
+
+	expected := `
+# HELP gtp_active_bearers Number of active bearers.
+# TYPE gtp_active_bearers gauge
+gtp_active_bearers{conn="s11"} 5
+# HELP gtp_active_sessions Number of active sessions.
+# TYPE gtp_active_sessions gauge
+gtp_active_sessions{conn="s11"} 3
+# HELP gtp_handler_errors_total Number of messages received that could not be handled by message type.
+# TYPE gtp_handler_errors_total counter
+gtp_handler_errors_total{proto="gtpv2-c",type="Echo Request"} 1
+# HELP gtp_messages_total Number of messages sent and received by message type and cause.
+# TYPE gtp_messages_total counter
+gtp_messages_total{cause="",direction="sent",proto="gtpv2-c",type="Create Session Request"} 1
+gtp_messages_total{cause="16",direction="received",proto="gtpv2-c",type="Create Session Response"} 2
+# HELP gtp_parse_errors_total Number of messages received that could not be parsed.
+# TYPE gtp_parse_errors_total counter
+gtp_parse_errors_total{proto="gtpv1-u"} 1
+# HELP gtp_registered_bearers Number of bearers of the sessions registered, counted at the registration.
+# TYPE gtp_registered_bearers gauge
+gtp_registered_bearers{proto="gtpv2-c"} 1
+# HELP gtp_registered_sessions Number of sessions registered.
+# TYPE gtp_registered_sessions gauge
+gtp_registered_sessions{proto="gtpv2-c"} 1
+# HELP gtp_retransmissions_total Number of request messages retransmitted by message type.
+# TYPE gtp_retransmissions_total counter
+gtp_retransmissions_total{direction="sent",proto="gtpv2-c",type="Create Session Request"} 1
+# HELP gtp_tpdu_bytes_total Number of bytes of T-PDU packets sent and received, including GTP header.
+# TYPE gtp_tpdu_bytes_total counter
+gtp_tpdu_bytes_total{direction="received"} 100
+# HELP gtp_tpdu_dropped_total Number of T-PDU packets received that were dropped without being read.
+# TYPE gtp_tpdu_dropped_total counter
+gtp_tpdu_dropped_total 1
+# HELP gtp_tpdu_packets_total Number of T-PDU packets sent and received.
+# TYPE gtp_tpdu_packets_total counter
+gtp_tpdu_packets_total{direction="received"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"gtp_active_bearers", "gtp_active_sessions", "gtp_handler_errors_total",
+		"gtp_messages_total", "gtp_parse_errors_total", "gtp_registered_bearers",
+		"gtp_registered_sessions", "gtp_retransmissions_total",
+		"gtp_tpdu_bytes_total", "gtp_tpdu_dropped_total", "gtp_tpdu_packets_total",
+	); err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(1, testutil.CollectAndCount(c, "gtp_round_trip_seconds")); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package oteltrace provides the interceptors for gtpv2.Conn that produce the
+// OpenTelemetry spans per request/response transaction.
+//
+// Create a Tracer and give its ConnOptions to the connections.
+//
+//	tracer := oteltrace.NewTracer(otel.GetTracerProvider())
+//	conn := gtpv2.NewConn(laddr, gtpv2.IFTypeS11S4SGWGTPC, 0, tracer.ConnOptions()...)
+package oteltrace
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+)
+
+// ScopeName is the instrumentation scope name of the Tracer.
+const ScopeName = "github.com/wmnsk/go-gtp/oteltrace"
+
+// DefaultTimeout is the default duration to wait for the response before ending
+// the span of the transaction as failed.
+const DefaultTimeout = 30 * time.Second
+
+// The keys of the attributes set to the spans.
+const (
+	AttrProtocol    = attribute.Key("network.protocol.name")
+	AttrPeerAddress = attribute.Key("network.peer.address")
+	AttrPeerPort    = attribute.Key("network.peer.port")
+	AttrMessageType = attribute.Key("gtp.message.type")
+	AttrTEID        = attribute.Key("gtp.teid")
+	AttrSequence    = attribute.Key("gtp.sequence")
+	AttrCause       = attribute.Key("gtp.cause")
+
+	AttrResponseType = attribute.Key("gtp.response.type")
+	AttrResponseTEID = attribute.Key("gtp.response.teid")
+)
+
+// The names of the events added to the spans.
+const (
+	EventRetransmission = "retransmission"
+)
+
+// Tracer produces the spans for the messages sent and received by gtpv2.Conn.
+//
+// A span starts when an Initial message (request) is sent or received, and ends
+// when the Triggered message (response) for it is received or sent. The span is
+// of kind Client for the request sent, and of kind Server for the one received.
+// The request retransmitted is recorded as an event in the span of the first one.
+// The span ends with error status if the Cause in the response is not the one of
+// acceptance, or if no response is seen within the timeout.
+//
+// The other messages, e.g., Version Not Supported Indication or the response that
+// does not match any request, produce a span that ends immediately.
+type Tracer struct {
+	tracer  trace.Tracer
+	timeout time.Duration
+
+	mu     sync.Mutex
+	spans  map[spanKey]*pending
+	pruned time.Time
+}
+
+// Option is an option to configure Tracer at NewTracer.
+type Option func(t *Tracer)
+
+// WithTimeout sets the duration to wait for the response before ending the span
+// of the transaction as failed. By default, it is DefaultTimeout.
+func WithTimeout(d time.Duration) Option {
+	return func(t *Tracer) {
+		t.timeout = d
+	}
+}
+
+// spanKey identifies a transaction with the Conn and the peer, and the Sequence
+// Number, which is unique for each outstanding request from the same endpoint.
+type spanKey struct {
+	conn *gtpv2.Conn
+	peer string
+	seq  uint32
+
+	// sent is true if the request is sent by Conn.
+	sent bool
+}
+
+type pending struct {
+	span    trace.Span
+	msgType uint8
+	at      time.Time
+}
+
+// NewTracer creates a new Tracer that produces the spans with the tracer from tp.
+// If tp is nil, the global TracerProvider is used.
+func NewTracer(tp trace.TracerProvider, opts ...Option) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	t := &Tracer{
+		tracer:  tp.Tracer(ScopeName),
+		timeout: DefaultTimeout,
+		spans:   map[spanKey]*pending{},
+		pruned:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// ConnOptions returns the options to let gtpv2.Conn call the interceptors of
+// Tracer, which are Inbound and Outbound.
+func (t *Tracer) ConnOptions() []gtpv2.ConnOption {
+	return []gtpv2.ConnOption{
+		gtpv2.WithInboundInterceptor(t.Inbound),
+		gtpv2.WithOutboundInterceptor(t.Outbound),
+	}
+}
+
+// Inbound is the gtpv2.Interceptor for the messages received. It never vetoes
+// the message.
+func (t *Tracer) Inbound(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+	t.trace(c, pkt, false)
+	return nil
+}
+
+// Outbound is the gtpv2.Interceptor for the messages sent. It never vetoes the
+// message.
+func (t *Tracer) Outbound(c *gtpv2.Conn, pkt *gtpv2.Packet) error {
+	t.trace(c, pkt, true)
+	return nil
+}
+
+// End ends all the spans waiting for the response, e.g., before the program exits.
+func (t *Tracer) End() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for k, p := range t.spans {
+		p.span.SetStatus(codes.Error, "no response")
+		p.span.End()
+		delete(t.spans, k)
+	}
+}
+
+func (t *Tracer) trace(c *gtpv2.Conn, pkt *gtpv2.Packet, sent bool) {
+	msg := pkt.Message
+	if msg == nil {
+		return
+	}
+
+	now := time.Now()
+	msgType := msg.MessageType()
+	switch {
+	case message.IsInitial(msgType):
+		t.start(c, pkt, sent, now)
+	case message.IsTriggered(msgType):
+		// the response sent is for the request received, and vice versa.
+		if !t.finish(c, pkt, !sent, now) {
+			t.instant(pkt, sent, now)
+		}
+	default:
+		t.instant(pkt, sent, now)
+	}
+}
+
+// start starts the span for the request, or adds an event to the existing one
+// if it is retransmitted.
+func (t *Tracer) start(c *gtpv2.Conn, pkt *gtpv2.Packet, sent bool, now time.Time) {
+	msg := pkt.Message
+	key := spanKey{c, pkt.Peer.String(), msg.Sequence(), sent}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pruneLocked(now)
+	if p, ok := t.spans[key]; ok && p.msgType == msg.MessageType() {
+		p.span.AddEvent(EventRetransmission, trace.WithTimestamp(now))
+		return
+	}
+
+	kind := trace.SpanKindServer
+	if sent {
+		kind = trace.SpanKindClient
+	}
+	_, span := t.tracer.Start(context.Background(), msg.MessageTypeName(),
+		trace.WithSpanKind(kind),
+		trace.WithTimestamp(now),
+		trace.WithAttributes(attrs(pkt)...),
+	)
+	t.spans[key] = &pending{span: span, msgType: msg.MessageType(), at: now}
+}
+
+// finish ends the span of the request that the response is for, and reports
+// whether it is found.
+func (t *Tracer) finish(c *gtpv2.Conn, pkt *gtpv2.Packet, sent bool, now time.Time) bool {
+	msg := pkt.Message
+	key := spanKey{c, pkt.Peer.String(), msg.Sequence(), sent}
+	initial, _ := message.InitialTypeOf(msg.MessageType())
+
+	t.mu.Lock()
+	p, ok := t.spans[key]
+	if !ok || p.msgType != initial {
+		t.mu.Unlock()
+		return false
+	}
+	delete(t.spans, key)
+	t.mu.Unlock()
+
+	p.span.SetAttributes(
+		AttrResponseType.String(msg.MessageTypeName()),
+		AttrResponseTEID.Int64(int64(msg.TEID())),
+	)
+	if cause := message.CauseOf(pkt.Raw); cause != 0 {
+		p.span.SetAttributes(AttrCause.Int(int(cause)))
+		if !isAccepted(cause) {
+			p.span.SetStatus(codes.Error, "request rejected")
+		}
+	}
+	p.span.End(trace.WithTimestamp(now))
+	return true
+}
+
+// instant produces the span that ends immediately for the message that is not
+// a part of transaction.
+func (t *Tracer) instant(pkt *gtpv2.Packet, sent bool, now time.Time) {
+	kind := trace.SpanKindConsumer
+	if sent {
+		kind = trace.SpanKindProducer
+	}
+
+	attributes := attrs(pkt)
+	if cause := message.CauseOf(pkt.Raw); cause != 0 {
+		attributes = append(attributes, AttrCause.Int(int(cause)))
+	}
+	_, span := t.tracer.Start(context.Background(), pkt.Message.MessageTypeName(),
+		trace.WithSpanKind(kind),
+		trace.WithTimestamp(now),
+		trace.WithAttributes(attributes...),
+	)
+	span.End(trace.WithTimestamp(now))
+}
+
+// pruneLocked ends the spans that are not responded within the timeout, at most
+// once in the timeout.
+func (t *Tracer) pruneLocked(now time.Time) {
+	if now.Sub(t.pruned) < t.timeout {
+		return
+	}
+	t.pruned = now
+
+	for k, p := range t.spans {
+		if now.Sub(p.at) >= t.timeout {
+			p.span.SetStatus(codes.Error, "no response")
+			p.span.End(trace.WithTimestamp(now))
+			delete(t.spans, k)
+		}
+	}
+}
+
+func attrs(pkt *gtpv2.Packet) []attribute.KeyValue {
+	msg := pkt.Message
+	kvs := []attribute.KeyValue{
+		AttrProtocol.String("gtpv2-c"),
+		AttrMessageType.Int(int(msg.MessageType())),
+		AttrTEID.Int64(int64(msg.TEID())),
+		AttrSequence.Int64(int64(msg.Sequence())),
+	}
+
+	if addr, ok := pkt.Peer.(*net.UDPAddr); ok {
+		kvs = append(kvs, AttrPeerAddress.String(addr.IP.String()), AttrPeerPort.Int(addr.Port))
+	} else {
+		kvs = append(kvs, AttrPeerAddress.String(pkt.Peer.String()))
+	}
+	return kvs
+}
+
+// isAccepted reports whether the cause is the one of acceptance in response,
+// defined in TS 29.274 Table 8.4-1.
+func isAccepted(cause uint8) bool {
+	return cause >= gtpv2.CauseRequestAccepted && cause < 64
+}
@@ -0,0 +1,123 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package oteltrace_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wmnsk/go-gtp/gtpv2"
+	"github.com/wmnsk/go-gtp/gtpv2/ie"
+	"github.com/wmnsk/go-gtp/gtpv2/message"
+	"github.com/wmnsk/go-gtp/oteltrace"
+)
+
+type span struct {
+	Name   string
+	Kind   trace.SpanKind
+	Status codes.Code
+	Cause  int64
+	Events []string
+}
+
+func newPacket(t *testing.T, peer net.Addr, msg message.Message) *gtpv2.Packet {
+	t.Helper()
+
+	b, err := message.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &gtpv2.Packet{Peer: peer, Message: msg, Raw: b}
+}
+
+func TestTracer(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tracer := oteltrace.NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	conn := gtpv2.NewConn(&net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 2123}, gtpv2.IFTypeS11MMEGTPC, 0)
+	peer := &net.UDPAddr{IP: net.IP{127, 0, 0, 2}, Port: 2123}
+
+	for _, step := range []struct {
+		outbound bool
+		msg      message.Message
+	}{
+		// request sent, retransmitted, and accepted.
+		{true, message.NewCreateSessionRequest(0, 1, ie.NewIMSI("123451234567890"))},
+		{true, message.NewCreateSessionRequest(0, 1, ie.NewIMSI("123451234567890"))},
+		{false, message.NewCreateSessionResponse(0x11111111, 1, ie.NewCause(gtpv2.CauseRequestAccepted, 0, 0, 0, nil))},
+		// request received and rejected.
+		{false, message.NewDeleteSessionRequest(0x22222222, 2)},
+		{true, message.NewDeleteSessionResponse(0x33333333, 2, ie.NewCause(gtpv2.CauseContextNotFound, 0, 0, 0, nil))},
+		// message out of transaction.
+		{true, message.NewVersionNotSupportedIndication(0, 3)},
+		// request without response.
+		{true, message.NewEchoRequest(4, ie.NewRecovery(0))},
+	} {
+		pkt := newPacket(t, peer, step.msg)
+		interceptor := tracer.Inbound
+		if step.outbound {
+			interceptor = tracer.Outbound
+		}
+		if err := interceptor(conn, pkt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tracer.End()
+
+	var got []span
+	for _, s := range sr.Ended() {
+		sp := span{Name: s.Name(), Kind: s.SpanKind(), Status: s.Status().Code}
+		for _, kv := range s.Attributes() {
+			if kv.Key == oteltrace.AttrCause {
+				sp.Cause = kv.Value.AsInt64()
+			}
+		}
+		for _, e := range s.Events() {
+			sp.Events = append(sp.Events, e.Name)
+		}
+		got = append(got, sp)
+	}
+
+	want := []span{
+		{
+			Name: "Create Session Request", Kind: trace.SpanKindClient, Status: codes.Unset,
+			Cause: int64(gtpv2.CauseRequestAccepted), Events: []string{oteltrace.EventRetransmission},
+		},
+		{
+			Name: "Delete Session Request", Kind: trace.SpanKindServer, Status: codes.Error,
+			Cause: int64(gtpv2.CauseContextNotFound),
+		},
+		{Name: "Version Not Supported Indication", Kind: trace.SpanKindProducer, Status: codes.Unset},
+		{Name: "Echo Request", Kind: trace.SpanKindClient, Status: codes.Error},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+
+	// check the common attributes with the first one.
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range sr.Ended()[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	for k, v := range map[attribute.Key]attribute.Value{
+		oteltrace.AttrProtocol:     attribute.StringValue("gtpv2-c"),
+		oteltrace.AttrPeerAddress:  attribute.StringValue("127.0.0.2"),
+		oteltrace.AttrPeerPort:     attribute.IntValue(2123),
+		oteltrace.AttrMessageType:  attribute.IntValue(int(message.MsgTypeCreateSessionRequest)),
+		oteltrace.AttrSequence:     attribute.Int64Value(1),
+		oteltrace.AttrResponseTEID: attribute.Int64Value(0x11111111),
+	} {
+		if attrs[k] != v {
+			t.Errorf("%s: want %v, got %v", k, v.Emit(), attrs[k].Emit())
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+// Package teidalloc provides the allocators of TEID (Tunnel Endpoint Identifier)
+// shared by GTPv1-U and GTPv2-C connections.
+//
+// Allocator issues the TEIDs unique within itself. RangeAllocator issues them
+// sequentially from a range, which can be partitioned per instance of the program
+// with NewPartitionedAllocator not to collide with each other. RandomAllocator
+// issues them randomly from a range, which makes them harder to guess.
+//
+// TEID 0 is never allocated as it has the special meaning in GTP.
+package teidalloc
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrExhausted indicates that there's no TEID available in the allocator.
+var ErrExhausted = errors.New("no TEID available")
+
+// Allocator is the interface of TEID allocator.
+//
+// The methods are called concurrently, so the implementation must be safe for
+// concurrent use.
+type Allocator interface {
+	// Allocate returns a new TEID that is not used. It returns ErrExhausted if
+	// all the TEIDs are used.
+	Allocate() (uint32, error)
+
+	// Reserve marks teid as used, which is typically the one restored from
+	// somewhere else, and reports whether it succeeded or not (= the TEID is
+	// already used or not in the range of the allocator).
+	Reserve(teid uint32) bool
+
+	// Release marks teid as unused to make it available again.
+	// Releasing the TEID that is not used has no effect.
+	Release(teid uint32)
+}
+
+// RangeAllocator allocates TEIDs sequentially from a range.
+//
+// The released TEIDs are reused only after all the TEIDs in the range have
+// been allocated once, in the order of release. This keeps the released TEID
+// unused as long as possible, so that the late packets for the old tunnel are
+// not delivered to the new one.
+//
+// Both Allocate and Release take constant time (amortized).
+type RangeAllocator struct {
+	mu       sync.Mutex
+	min, max uint32
+
+	// next is the next TEID that has never been allocated. It is uint64 not
+	// to overflow when max is 0xffffffff.
+	next uint64
+	// free is the FIFO queue of the released TEIDs, and head is its head.
+	free []uint32
+	head int
+	used map[uint32]struct{}
+}
+
+// NewRangeAllocator creates a new RangeAllocator that allocates TEIDs from min
+// to max, inclusive. If min is 0, it starts from 1.
+func NewRangeAllocator(min, max uint32) (*RangeAllocator, error) {
+	if min == 0 {
+		min = 1
+	}
+	if min > max {
+		return nil, fmt.Errorf("invalid TEID range: %#x-%#x", min, max)
+	}
+
+	return &RangeAllocator{
+		min:  min,
+		max:  max,
+		next: uint64(min),
+		used: map[uint32]struct{}{},
+	}, nil
+}
+
+// NewPartitionedAllocator creates a new RangeAllocator with the range of the
+// TEIDs that has nodeID in its top bits.
+//
+// This is useful to run multiple instances of the program that share the same
+// IP address, e.g., with load balancer in front of them. For instance, with 4 bits,
+// up to 16 instances can have their own range that never collides with others.
+func NewPartitionedAllocator(nodeID uint32, bits int) (*RangeAllocator, error) {
+	if bits < 1 || bits > 31 {
+		return nil, fmt.Errorf("invalid number of bits for node ID: %d", bits)
+	}
+	if nodeID >= 1<<bits {
+		return nil, fmt.Errorf("node ID %d does not fit in %d bits", nodeID, bits)
+	}
+
+	shift := 32 - bits
+	min := nodeID << shift
+	return NewRangeAllocator(min, min|(1<<shift-1))
+}
+
+// Range returns the range of TEIDs that RangeAllocator allocates.
+func (r *RangeAllocator) Range() (min, max uint32) {
+	return r.min, r.max
+}
+
+// Allocate returns a new TEID that is not used.
+func (r *RangeAllocator) Allocate() (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for r.next <= uint64(r.max) {
+		teid := uint32(r.next)
+		r.next++
+		// the TEID may be reserved beforehand.
+		if _, ok := r.used[teid]; ok {
+			continue
+		}
+		r.used[teid] = struct{}{}
+		return teid, nil
+	}
+
+	for r.head < len(r.free) {
+		teid := r.free[r.head]
+		r.head++
+		r.compactLocked()
+		// the TEID may be reserved again after released.
+		if _, ok := r.used[teid]; ok {
+			continue
+		}
+		r.used[teid] = struct{}{}
+		return teid, nil
+	}
+
+	return 0, ErrExhausted
+}
+
+// Reserve marks teid as used.
+func (r *RangeAllocator) Reserve(teid uint32) bool {
+	if teid < r.min || teid > r.max {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.used[teid]; ok {
+		return false
+	}
+	r.used[teid] = struct{}{}
+	return true
+}
+
+// Release marks teid as unused.
+func (r *RangeAllocator) Release(teid uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.used[teid]; !ok {
+		return
+	}
+	delete(r.used, teid)
+
+	// the TEIDs that have never been allocated don't need to be queued.
+	if uint64(teid) < r.next {
+		r.free = append(r.free, teid)
+	}
+}
+
+// Used returns the number of TEIDs in use.
+func (r *RangeAllocator) Used() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.used)
+}
+
+// compactLocked drops the consumed part of the queue not to let it grow forever.
+func (r *RangeAllocator) compactLocked() {
+	if r.head < 1024 || r.head*2 < len(r.free) {
+		return
+	}
+	r.free = append(r.free[:0], r.free[r.head:]...)
+	r.head = 0
+}
+
+// defaultMaxTries is the default number of tries of RandomAllocator to find
+// an unused TEID.
+const defaultMaxTries = 0xffff
+
+// RandomAllocator allocates TEIDs randomly from a range.
+//
+// Allocate takes constant time in average as long as the TEIDs in use are
+// sparse in the range, and slows down as it gets filled. Use RangeAllocator
+// if a large part of the range is expected to be used.
+type RandomAllocator struct {
+	mu       sync.Mutex
+	min, max uint32
+	used     map[uint32]struct{}
+
+	// MaxTries is the number of tries to find an unused TEID before Allocate
+	// gives up and returns ErrExhausted.
+	MaxTries int
+}
+
+// NewRandomAllocator creates a new RandomAllocator that allocates TEIDs from
+// min to max, inclusive. If min is 0, it starts from 1.
+func NewRandomAllocator(min, max uint32) (*RandomAllocator, error) {
+	if min == 0 {
+		min = 1
+	}
+	if min > max {
+		return nil, fmt.Errorf("invalid TEID range: %#x-%#x", min, max)
+	}
+
+	return &RandomAllocator{
+		min:      min,
+		max:      max,
+		used:     map[uint32]struct{}{},
+		MaxTries: defaultMaxTries,
+	}, nil
+}
+
+// NewDefaultAllocator creates a new RandomAllocator with the whole range of TEID,
+// which is used by default in the connections in this project.
+func NewDefaultAllocator() *RandomAllocator {
+	r, _ := NewRandomAllocator(1, 0xffffffff)
+	return r
+}
+
+// Range returns the range of TEIDs that RandomAllocator allocates.
+func (r *RandomAllocator) Range() (min, max uint32) {
+	return r.min, r.max
+}
+
+// Allocate returns a new TEID that is not used.
+func (r *RandomAllocator) Allocate() (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := uint64(r.max) - uint64(r.min) + 1
+	if uint64(len(r.used)) >= size {
+		return 0, ErrExhausted
+	}
+
+	for try := 0; try < r.MaxTries; try++ {
+		n, err := randomUint32()
+		if err != nil {
+			return 0, fmt.Errorf("failed to generate random TEID: %w", err)
+		}
+
+		teid := uint32(uint64(r.min) + uint64(n)%size)
+		if _, ok := r.used[teid]; ok {
+			continue
+		}
+		r.used[teid] = struct{}{}
+		return teid, nil
+	}
+
+	return 0, ErrExhausted
+}
+
+// Reserve marks teid as used.
+func (r *RandomAllocator) Reserve(teid uint32) bool {
+	if teid < r.min || teid > r.max {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.used[teid]; ok {
+		return false
+	}
+	r.used[teid] = struct{}{}
+	return true
+}
+
+// Release marks teid as unused.
+func (r *RandomAllocator) Release(teid uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.used, teid)
+}
+
+// Used returns the number of TEIDs in use.
+func (r *RandomAllocator) Used() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.used)
+}
+
+func randomUint32() (uint32, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(b), nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2019-2024 go-gtp authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package teidalloc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/wmnsk/go-gtp/teidalloc"
+)
+
+func TestRangeAllocator(t *testing.T) {
+	r, err := teidalloc.NewRangeAllocator(0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Reserve(2) {
+		t.Error("Reserve should succeed with unused TEID")
+	}
+	if r.Reserve(5) {
+		t.Error("Reserve should fail with TEID out of range")
+	}
+
+	var got []uint32
+	for i := 0; i < 3; i++ {
+		teid, err := r.Allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, teid)
+	}
+	if diff := cmp.Diff(got, []uint32{1, 3, 4}); diff != "" {
+		t.Error(diff)
+	}
+	if _, err := r.Allocate(); !errors.Is(err, teidalloc.ErrExhausted) {
+		t.Errorf("Allocate should fail with all TEIDs used: %v", err)
+	}
+
+	// released TEIDs are reused in the order of release.
+	r.Release(3)
+	r.Release(1)
+	r.Release(1)
+	got = nil
+	for i := 0; i < 2; i++ {
+		teid, err := r.Allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, teid)
+	}
+	if diff := cmp.Diff(got, []uint32{3, 1}); diff != "" {
+		t.Error(diff)
+	}
+	if _, err := r.Allocate(); !errors.Is(err, teidalloc.ErrExhausted) {
+		t.Errorf("Allocate should fail with all TEIDs used: %v", err)
+	}
+	if got := r.Used(); got != 4 {
+		t.Errorf("Used returned %d, want 4", got)
+	}
+}
+
+func TestNewPartitionedAllocator(t *testing.T) {
+	cases := []struct {
+		description string
+		nodeID      uint32
+		bits        int
+		min, max    uint32
+	}{
+		{"node 0", 0, 4, 0x00000001, 0x0fffffff},
+		{"node 1", 1, 4, 0x10000000, 0x1fffffff},
+		{"node 15", 15, 4, 0xf0000000, 0xffffffff},
+		{"8 bits", 0xab, 8, 0xab000000, 0xabffffff},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			r, err := teidalloc.NewPartitionedAllocator(c.nodeID, c.bits)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			min, max := r.Range()
+			if min != c.min || max != c.max {
+				t.Errorf("Got wrong range: %#x-%#x, want: %#x-%#x", min, max, c.min, c.max)
+			}
+			if teid, err := r.Allocate(); err != nil || teid != c.min {
+				t.Errorf("Got wrong TEID: %#x, %v", teid, err)
+			}
+		})
+	}
+
+	if _, err := teidalloc.NewPartitionedAllocator(16, 4); err == nil {
+		t.Error("NewPartitionedAllocator should fail with node ID that does not fit")
+	}
+	if _, err := teidalloc.NewPartitionedAllocator(0, 32); err == nil {
+		t.Error("NewPartitionedAllocator should fail with invalid number of bits")
+	}
+}
+
+func TestRandomAllocator(t *testing.T) {
+	r, err := teidalloc.NewRandomAllocator(0x100, 0x1ff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[uint32]struct{}{}
+	for i := 0; i < 0x100; i++ {
+		teid, err := r.Allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if teid < 0x100 || teid > 0x1ff {
+			t.Fatalf("Got TEID out of range: %#x", teid)
+		}
+		if _, ok := seen[teid]; ok {
+			t.Fatalf("Got duplicate TEID: %#x", teid)
+		}
+		seen[teid] = struct{}{}
+	}
+	if _, err := r.Allocate(); !errors.Is(err, teidalloc.ErrExhausted) {
+		t.Errorf("Allocate should fail with all TEIDs used: %v", err)
+	}
+
+	r.Release(0x123)
+	if teid, err := r.Allocate(); err != nil || teid != 0x123 {
+		t.Errorf("Got wrong TEID: %#x, %v", teid, err)
+	}
+	if r.Reserve(0x123) {
+		t.Error("Reserve should fail with used TEID")
+	}
+}
+
+func BenchmarkRangeAllocator(b *testing.B) {
+	r, _ := teidalloc.NewRangeAllocator(1, 0xffffffff)
+	for i := 0; i < b.N; i++ {
+		teid, err := r.Allocate()
+		if err != nil {
+			b.Fatal(err)
+		}
+		r.Release(teid)
+	}
+}
+
+func BenchmarkRandomAllocator(b *testing.B) {
+	r := teidalloc.NewDefaultAllocator()
+	for i := 0; i < b.N; i++ {
+		teid, err := r.Allocate()
+		if err != nil {
+			b.Fatal(err)
+		}
+		r.Release(teid)
+	}
+}
@@ -8,6 +8,7 @@ package utils
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"strings"
 )
 
@@ -88,7 +89,12 @@ func Uint64To40(n uint64) []byte {
 }
 
 // EncodePLMN encodes MCC and MNC as BCD-encoded bytes.
+//
+// It returns error if mcc is not 3 digits long or mnc is not 2 or 3 digits long.
 func EncodePLMN(mcc, mnc string) ([]byte, error) {
+	if len(mcc) != 3 || (len(mnc) != 2 && len(mnc) != 3) {
+		return nil, fmt.Errorf("invalid MCC/MNC: %s/%s", mcc, mnc)
+	}
 	c, err := StrToSwappedBytes(mcc, "f")
 	if err != nil {
 		return nil, err
@@ -154,3 +154,24 @@ func TestPLMN(t *testing.T) {
 		})
 	}
 }
+
+func TestPLMNInvalid(t *testing.T) {
+	cases := []struct {
+		description string
+		mcc, mnc    string
+	}{
+		{"empty", "", ""},
+		{"short-mcc", "12", "45"},
+		{"long-mcc", "1234", "45"},
+		{"short-mnc", "123", "4"},
+		{"long-mnc", "123", "4567"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if _, err := utils.EncodePLMN(c.mcc, c.mnc); err == nil {
+				t.Errorf("expected error for MCC/MNC: %s/%s", c.mcc, c.mnc)
+			}
+		})
+	}
+}